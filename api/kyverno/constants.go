@@ -9,11 +9,20 @@ const (
 	LabelCleanupTtl       = "cleanup.kyverno.io/ttl"
 	LabelWebhookManagedBy = "webhook.kyverno.io/managed-by"
 	// Well known annotations
-	AnnotationAutogenControllers = "pod-policies.kyverno.io/autogen-controllers"
-	AnnotationImageVerify        = "kyverno.io/verify-images"
-	AnnotationPolicyCategory     = "policies.kyverno.io/category"
-	AnnotationPolicyScored       = "policies.kyverno.io/scored"
-	AnnotationPolicySeverity     = "policies.kyverno.io/severity"
+	AnnotationAutogenControllers       = "pod-policies.kyverno.io/autogen-controllers"
+	AnnotationCleanupTtlFrom           = "cleanup.kyverno.io/ttl-from"
+	AnnotationAutogenCustomControllers = "pod-policies.kyverno.io/autogen-custom-controllers"
+	AnnotationImageVerify              = "kyverno.io/verify-images"
+	AnnotationPolicyCategory           = "policies.kyverno.io/category"
+	AnnotationPolicyExcludeFromReports = "policies.kyverno.io/exclude-from-reports"
+	AnnotationPolicyRemediation        = "policies.kyverno.io/remediation"
+	AnnotationPolicyRuleRemediations   = "policies.kyverno.io/rule-remediations"
+	AnnotationPolicyScanSchedule       = "policies.kyverno.io/scan-schedule"
+	AnnotationPolicyScored             = "policies.kyverno.io/scored"
+	AnnotationPolicySeverity           = "policies.kyverno.io/severity"
+	AnnotationWebhookFailurePolicy     = "webhook.kyverno.io/failure-policy"
+	AnnotationWebhookTimeoutSeconds    = "webhook.kyverno.io/timeout-seconds"
+	AnnotationWebhookReinvocation      = "webhook.kyverno.io/reinvocation-policy"
 	// Well known values
 	ValueKyvernoApp        = "kyverno"
 	ValueTtlDateTimeLayout = "2006-01-02T150405Z"
@@ -15,6 +15,7 @@ import (
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/pod-security-admission/api"
@@ -102,6 +103,9 @@ type AnyAllConditions struct {
 // +kubebuilder:oneOf:={required:{imageRegistry}}
 // +kubebuilder:oneOf:={required:{variable}}
 // +kubebuilder:oneOf:={required:{globalReference}}
+// +kubebuilder:oneOf:={required:{secretStore}}
+// +kubebuilder:oneOf:={required:{grpcCall}}
+// +kubebuilder:oneOf:={required:{externalData}}
 type ContextEntry struct {
 	// Name is the variable name.
 	Name string `json:"name"`
@@ -122,6 +126,27 @@ type ContextEntry struct {
 
 	// GlobalContextEntryReference is a reference to a cached global context entry.
 	GlobalReference *GlobalContextEntryReference `json:"globalReference,omitempty"`
+
+	// SecretStore fetches data from an external secret store.
+	// The fetched values are cached and redacted from logs and reports.
+	SecretStore *SecretStore `json:"secretStore,omitempty"`
+
+	// GRPCCall is a unary call to a gRPC service.
+	// The data returned is stored in the context with the name for the context entry.
+	GRPCCall *GRPCCall `json:"grpcCall,omitempty"`
+
+	// ExternalData queries an external data provider declared by a Provider resource.
+	// The items returned are stored in the context with the name for the context entry.
+	ExternalData *ExternalData `json:"externalData,omitempty"`
+}
+
+// RuleOutput is a named value exported by a rule to the subsequent rules of the policy.
+type RuleOutput struct {
+	// Name is the output name, the value is available to the subsequent rules as `outputs.<name>`.
+	Name string `json:"name"`
+
+	// JMESPath is the JMESPath expression evaluated against the rule context to compute the output.
+	JMESPath string `json:"jmesPath"`
 }
 
 // Variable defines an arbitrary JMESPath context variable that can be defined inline.
@@ -177,6 +202,11 @@ type ImageRegistry struct {
 	// ImageRegistryCredentials provides credentials that will be used for authentication with registry
 	// +kubebuilder:validation:Optional
 	ImageRegistryCredentials *ImageRegistryCredentials `json:"imageRegistryCredentials,omitempty"`
+
+	// Referrers fetches the artifacts referring to the image, such as attestations and SBOMs,
+	// using the OCI referrers API. The artifact digests are available in the referrers field.
+	// +kubebuilder:validation:Optional
+	Referrers bool `json:"referrers,omitempty"`
 }
 
 // ConfigMapReference refers to a ConfigMap
@@ -229,6 +259,12 @@ type ContextAPICall struct {
 	// of deployments across all namespaces.
 	// +kubebuilder:validation:Optional
 	JMESPath string `json:"jmesPath,omitempty"`
+
+	// CacheTTL is an optional duration for which the response is cached in-process
+	// and reused across admission requests. Responses are keyed by the resolved
+	// request, including the URL and body. Responses are not cached by default.
+	// +optional
+	CacheTTL *metav1.Duration `json:"cacheTTL,omitempty"`
 }
 
 type GlobalContextEntryReference struct {
@@ -245,6 +281,93 @@ type GlobalContextEntryReference struct {
 	JMESPath string `json:"jmesPath,omitempty"`
 }
 
+// SecretStore defines a request to fetch data from an external secret store.
+type SecretStore struct {
+	// Vault fetches a secret from a HashiCorp Vault KV version 2 secrets engine.
+	Vault *VaultSecret `json:"vault"`
+
+	// JMESPath is an optional JSON Match Expression that can be used to
+	// transform the secret data.
+	// +kubebuilder:validation:Optional
+	JMESPath string `json:"jmesPath,omitempty"`
+}
+
+// VaultSecret refers to a secret stored in a HashiCorp Vault KV version 2 secrets engine.
+// Kyverno authenticates with its service account token using the Vault Kubernetes auth method,
+// the Vault servers and roles Kyverno can authenticate to are configured in the Kyverno ConfigMap.
+type VaultSecret struct {
+	// Server is the name of a Vault server configured in the Kyverno ConfigMap.
+	Server string `json:"server"`
+
+	// Mount is the mount path of the KV version 2 secrets engine.
+	// +kubebuilder:default=secret
+	// +kubebuilder:validation:Optional
+	Mount string `json:"mount,omitempty"`
+
+	// Path is the secret path, relative to the secrets engine mount.
+	Path string `json:"path"`
+
+	// TTL is the duration the secret data is cached for. Defaults to 5m.
+	// +kubebuilder:validation:Optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+}
+
+// GRPCCall is a unary call to a gRPC service. The request and response message types
+// are resolved using the gRPC server reflection service of the target.
+type GRPCCall struct {
+	// Target is the gRPC server address, for example my-service.my-namespace:9090.
+	Target string `json:"target"`
+
+	// Method is the full method name, for example my.package.MyService/MyMethod.
+	Method string `json:"method"`
+
+	// Request is the JSON representation of the request message.
+	// Variables are substituted before the message is encoded.
+	// +kubebuilder:validation:Optional
+	Request *apiextv1.JSON `json:"request,omitempty"`
+
+	// Insecure disables transport security and uses a plaintext connection.
+	// +kubebuilder:validation:Optional
+	Insecure bool `json:"insecure,omitempty"`
+
+	// TLSSecret references a secret holding the client certificate and key (tls.crt and tls.key)
+	// used for mutual TLS, and optionally the CA bundle (ca.crt) used to validate the server certificate.
+	// The secret must be in the namespace of the policy, cluster policies can use the Kyverno namespace and the namespaces allowed in the configuration.
+	// +kubebuilder:validation:Optional
+	TLSSecret *SecretReference `json:"tlsSecret,omitempty"`
+
+	// Default is an optional arbitrary JSON object that the context
+	// value is set to, if the gRPC call returns error.
+	// +kubebuilder:validation:Optional
+	Default *apiextv1.JSON `json:"default,omitempty"`
+
+	// JMESPath is an optional JSON Match Expression that can be used to
+	// transform the JSON representation of the response message.
+	// +kubebuilder:validation:Optional
+	JMESPath string `json:"jmesPath,omitempty"`
+}
+
+// ExternalData queries an external data provider with a list of keys.
+// The provider returns an item for every key, holding either a value or an error.
+type ExternalData struct {
+	// Provider is the name of the Provider resource to query.
+	Provider string `json:"provider"`
+
+	// Keys are the keys sent to the provider, for example image references.
+	// Variables are substituted before the request is sent.
+	Keys []string `json:"keys"`
+
+	// Default is an optional arbitrary JSON object that the context
+	// value is set to, if the provider query fails and its failure policy is Ignore.
+	// +kubebuilder:validation:Optional
+	Default *apiextv1.JSON `json:"default,omitempty"`
+
+	// JMESPath is an optional JSON Match Expression that can be used to
+	// transform the items returned by the provider.
+	// +kubebuilder:validation:Optional
+	JMESPath string `json:"jmesPath,omitempty"`
+}
+
 type ServiceCall struct {
 	// URL is the JSON web service URL. A typical form is
 	// `https://{service}.{namespace}:{port}/{path}`.
@@ -257,6 +380,18 @@ type ServiceCall struct {
 	// the server certificate.
 	// +kubebuilder:validation:Optional
 	CABundle string `json:"caBundle"`
+
+	// Auth defines the credentials used to authenticate with the service.
+	// When set, the Kyverno service account token is not sent to the service.
+	// +kubebuilder:validation:Optional
+	Auth *ServiceCallAuth `json:"auth,omitempty"`
+}
+
+// ServiceCallAuth defines the credentials used to authenticate with a service.
+type ServiceCallAuth struct {
+	// SecretName is the name of a secret in the Kyverno namespace holding the credentials.
+	// A token key is sent as a bearer token, tls.crt and tls.key keys are used as a client certificate.
+	SecretName string `json:"secretName"`
 }
 
 // Method is a HTTP request type.
@@ -287,7 +422,7 @@ type Condition struct {
 	// Operator is the conditional operation to perform. Valid operators are:
 	// Equals, NotEquals, In, AnyIn, AllIn, NotIn, AnyNotIn, AllNotIn, GreaterThanOrEquals,
 	// GreaterThan, LessThanOrEquals, LessThan, DurationGreaterThanOrEquals, DurationGreaterThan,
-	// DurationLessThanOrEquals, DurationLessThan
+	// DurationLessThanOrEquals, DurationLessThan, SemverSatisfies, InTimeWindow
 	Operator ConditionOperator `json:"operator,omitempty"`
 
 	// Value is the conditional value, or set of values. The values can be fixed set
@@ -316,7 +451,7 @@ func (c *Condition) SetValue(in apiextensions.JSON) {
 }
 
 // ConditionOperator is the operation performed on condition key and value.
-// +kubebuilder:validation:Enum=Equals;NotEquals;In;AnyIn;AllIn;NotIn;AnyNotIn;AllNotIn;GreaterThanOrEquals;GreaterThan;LessThanOrEquals;LessThan;DurationGreaterThanOrEquals;DurationGreaterThan;DurationLessThanOrEquals;DurationLessThan
+// +kubebuilder:validation:Enum=Equals;NotEquals;In;AnyIn;AllIn;NotIn;AnyNotIn;AllNotIn;GreaterThanOrEquals;GreaterThan;LessThanOrEquals;LessThan;DurationGreaterThanOrEquals;DurationGreaterThan;DurationLessThanOrEquals;DurationLessThan;SemverSatisfies;InTimeWindow
 type ConditionOperator string
 
 // ConditionOperators stores all the valid ConditionOperator types as key-value pairs.
@@ -339,6 +474,8 @@ type ConditionOperator string
 // "DurationGreaterThan" evaluates if the key (duration) is greater than the value (duration)
 // "DurationLessThanOrEquals" evaluates if the key (duration) is less than or equal to the value (duration)
 // "DurationLessThan" evaluates if the key (duration) is greater than the value (duration)
+// "SemverSatisfies" evaluates if the key (semantic version) satisfies the value (semantic version range)
+// "InTimeWindow" evaluates if the key (RFC3339 time) falls within the value (cron or weekday window, or list of windows)
 var ConditionOperators = map[string]ConditionOperator{
 	"Equal":                       ConditionOperator("Equal"),
 	"Equals":                      ConditionOperator("Equals"),
@@ -358,6 +495,8 @@ var ConditionOperators = map[string]ConditionOperator{
 	"DurationGreaterThan":         ConditionOperator("DurationGreaterThan"),
 	"DurationLessThanOrEquals":    ConditionOperator("DurationLessThanOrEquals"),
 	"DurationLessThan":            ConditionOperator("DurationLessThan"),
+	"SemverSatisfies":             ConditionOperator("SemverSatisfies"),
+	"InTimeWindow":                ConditionOperator("InTimeWindow"),
 }
 
 // ResourceFilters is a slice of ResourceFilter
@@ -383,6 +522,11 @@ type Mutation struct {
 	// +optional
 	MutateExistingOnPolicyUpdate *bool `json:"mutateExistingOnPolicyUpdate,omitempty"`
 
+	// ServerSideApply controls if the mutateExisting rule applies the mutation to the targets
+	// with server-side apply and a dedicated field manager instead of updating the whole resource.
+	// +optional
+	ServerSideApply *bool `json:"serverSideApply,omitempty"`
+
 	// Targets defines the target resources to be mutated.
 	// +optional
 	Targets []TargetResourceSpec `json:"targets,omitempty"`
@@ -403,6 +547,11 @@ type Mutation struct {
 	ForEachMutation []ForEachMutation `json:"foreach,omitempty"`
 }
 
+// GetServerSideApply returns true if the mutations of existing resources are applied with server-side apply
+func (m *Mutation) GetServerSideApply() bool {
+	return m.ServerSideApply != nil && *m.ServerSideApply
+}
+
 func (m *Mutation) GetPatchStrategicMerge() apiextensions.JSON {
 	return FromJSON(m.RawPatchStrategicMerge)
 }
@@ -417,6 +566,12 @@ type ForEachMutation struct {
 	// to which the validation logic is applied.
 	List string `json:"list,omitempty"`
 
+	// MapEntries iterates over the entries of the object returned by List, binding the
+	// key and value of each entry to `element.key` and `element.value`. Entries are
+	// processed in key order. By default an object is processed as a single element.
+	// +optional
+	MapEntries *bool `json:"mapEntries,omitempty"`
+
 	// Order defines the iteration order on the list.
 	// Can be Ascending to iterate from first to last element or Descending to iterate in from last to first element.
 	// +optional
@@ -468,6 +623,11 @@ func (m *ForEachMutation) SetPatchStrategicMerge(in any) {
 	m.RawPatchStrategicMerge = kyverno.ToAny(in)
 }
 
+// GetMapEntries returns true if the entries of the object returned by List are iterated.
+func (m *ForEachMutation) GetMapEntries() bool {
+	return m.MapEntries != nil && *m.MapEntries
+}
+
 // Validation defines checks to be performed on matching resources.
 type Validation struct {
 	// FailureAction defines if a validation policy rule violation should block
@@ -525,6 +685,10 @@ type Validation struct {
 	// Assert defines a kyverno-json assertion tree.
 	// +optional
 	Assert AssertionTree `json:"assert"`
+
+	// Wasm evaluates a WebAssembly module to validate the resource.
+	// +optional
+	Wasm *Wasm `json:"wasm,omitempty"`
 }
 
 // PodSecurity applies exemptions for Kubernetes Pod Security admission
@@ -591,6 +755,31 @@ func (pss *PodSecurityStandard) Validate(path *field.Path) (errs field.ErrorList
 	return errs
 }
 
+// Wasm references a WebAssembly module implementing a custom validation.
+// The module receives the admission payload as JSON and returns whether the resource is allowed.
+type Wasm struct {
+	// Module is the name of a module stored as a key of the WASM modules ConfigMap
+	// in the Kyverno namespace.
+	// +optional
+	Module string `json:"module,omitempty"`
+
+	// Image is an OCI artifact reference whose single layer is the WASM module.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Function is the name of the function exported by the module, defaults to `validate`.
+	// +optional
+	Function string `json:"function,omitempty"`
+}
+
+// GetFunction returns the function exported by the module, defaulting to `validate`.
+func (w *Wasm) GetFunction() string {
+	if w.Function == "" {
+		return "validate"
+	}
+	return w.Function
+}
+
 // CEL allows validation checks using the Common Expression Language (https://kubernetes.io/docs/reference/using-api/cel/).
 type CEL struct {
 	// Expressions is a list of CELExpression types.
@@ -706,12 +895,25 @@ type ForEachValidation struct {
 	// to which the validation logic is applied.
 	List string `json:"list,omitempty"`
 
+	// MapEntries iterates over the entries of the object returned by List, binding the
+	// key and value of each entry to `element.key` and `element.value`. Entries are
+	// processed in key order. By default an object is processed as a single element.
+	// +optional
+	MapEntries *bool `json:"mapEntries,omitempty"`
+
 	// ElementScope specifies whether to use the current list element as the scope for validation. Defaults to "true" if not specified.
 	// When set to "false", "request.object" is used as the validation scope within the foreach
 	// block to allow referencing other elements in the subtree.
 	// +optional
 	ElementScope *bool `json:"elementScope,omitempty"`
 
+	// Concurrency is the maximum number of list elements validated in parallel.
+	// Results are always processed in list order. Defaults to 1, which validates
+	// elements sequentially.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Concurrency *int `json:"concurrency,omitempty"`
+
 	// Context defines variables and data sources that can be used during rule execution.
 	// +optional
 	Context []ContextEntry `json:"context,omitempty"`
@@ -766,6 +968,19 @@ func (v *ForEachValidation) SetAnyPattern(in apiextensions.JSON) {
 	v.RawAnyPattern = ToJSON(in)
 }
 
+// GetConcurrency returns the maximum number of elements validated in parallel.
+func (v *ForEachValidation) GetConcurrency() int {
+	if v.Concurrency == nil || *v.Concurrency < 1 {
+		return 1
+	}
+	return *v.Concurrency
+}
+
+// GetMapEntries returns true if the entries of the object returned by List are iterated.
+func (v *ForEachValidation) GetMapEntries() bool {
+	return v.MapEntries != nil && *v.MapEntries
+}
+
 // Generation defines how new resources should be created and managed.
 type Generation struct {
 	// GenerateExisting controls whether to trigger the rule in existing resources
@@ -787,6 +1002,12 @@ type Generation struct {
 	// +optional
 	OrphanDownstreamOnPolicyDelete bool `json:"orphanDownstreamOnPolicyDelete,omitempty"`
 
+	// Orphan controls independently whether generated resources are kept when their trigger is deleted,
+	// when the rule is removed from the policy and when the policy is deleted.
+	// It takes precedence over OrphanDownstreamOnPolicyDelete.
+	// +optional
+	Orphan *OrphanOptions `json:"orphan,omitempty"`
+
 	// +optional
 	GeneratePattern `json:",omitempty"`
 
@@ -795,11 +1016,68 @@ type Generation struct {
 	ForEachGeneration []ForEachGeneration `json:"foreach,omitempty"`
 }
 
+// OrphanOptions controls whether generated resources are kept or deleted.
+type OrphanOptions struct {
+	// OnTriggerDelete keeps the generated resources when their trigger is deleted.
+	// Defaults to "false", generated resources are deleted with their trigger when synchronization is enabled.
+	// +optional
+	OnTriggerDelete *bool `json:"onTriggerDelete,omitempty"`
+
+	// OnRuleDelete keeps the generated resources when the rule is removed from the policy.
+	// Defaults to the value of OrphanDownstreamOnPolicyDelete.
+	// +optional
+	OnRuleDelete *bool `json:"onRuleDelete,omitempty"`
+
+	// OnPolicyDelete keeps the generated resources when the policy is deleted.
+	// Defaults to the value of OrphanDownstreamOnPolicyDelete.
+	// +optional
+	OnPolicyDelete *bool `json:"onPolicyDelete,omitempty"`
+
+	// Labels are added to the generated resources when they are kept, so that orphans can be found.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// OrphanOnTriggerDelete returns true if the generated resources are kept when their trigger is deleted.
+func (g *Generation) OrphanOnTriggerDelete() bool {
+	return g.Orphan != nil && g.Orphan.OnTriggerDelete != nil && *g.Orphan.OnTriggerDelete
+}
+
+// OrphanOnRuleDelete returns true if the generated resources are kept when the rule is removed from the policy.
+func (g *Generation) OrphanOnRuleDelete() bool {
+	if g.Orphan != nil && g.Orphan.OnRuleDelete != nil {
+		return *g.Orphan.OnRuleDelete
+	}
+	return g.OrphanDownstreamOnPolicyDelete
+}
+
+// OrphanOnPolicyDelete returns true if the generated resources are kept when the policy is deleted.
+func (g *Generation) OrphanOnPolicyDelete() bool {
+	if g.Orphan != nil && g.Orphan.OnPolicyDelete != nil {
+		return *g.Orphan.OnPolicyDelete
+	}
+	return g.OrphanDownstreamOnPolicyDelete
+}
+
+// GetOrphanLabels returns the labels added to orphaned resources.
+func (g *Generation) GetOrphanLabels() map[string]string {
+	if g.Orphan == nil {
+		return nil
+	}
+	return g.Orphan.Labels
+}
+
 type GeneratePattern struct {
 	// ResourceSpec contains information to select the resource.
 	// +kubebuilder:validation:Optional
 	ResourceSpec `json:",omitempty"`
 
+	// NamespaceSelector selects the namespaces the resource is generated into, by label.
+	// When set, the resource is generated into every matching namespace, including namespaces
+	// created or labelled later, and the namespace of the target resource must be empty.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
 	// Data provides the resource declaration used to populate each generated resource.
 	// At most one of Data or Clone must be specified. If neither are provided, the generated
 	// resource will be created with default data only.
@@ -822,6 +1100,12 @@ type ForEachGeneration struct {
 	// to which the validation logic is applied.
 	List string `json:"list,omitempty"`
 
+	// MapEntries iterates over the entries of the object returned by List, binding the
+	// key and value of each entry to `element.key` and `element.value`. Entries are
+	// processed in key order. By default an object is processed as a single element.
+	// +optional
+	MapEntries *bool `json:"mapEntries,omitempty"`
+
 	// Context defines variables and data sources that can be used during rule execution.
 	// +optional
 	Context []ContextEntry `json:"context,omitempty"`
@@ -836,6 +1120,11 @@ type ForEachGeneration struct {
 	GeneratePattern `json:",omitempty"`
 }
 
+// GetMapEntries returns true if the entries of the object returned by List are iterated.
+func (g *ForEachGeneration) GetMapEntries() bool {
+	return g.MapEntries != nil && *g.MapEntries
+}
+
 type CloneList struct {
 	// Namespace specifies source resource namespace.
 	Namespace string `json:"namespace,omitempty"`
@@ -847,6 +1136,48 @@ type CloneList struct {
 	// wildcard characters are not supported.
 	// +optional
 	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Transform declares changes applied to each cloned resource, to adapt it to the
+	// destination namespace.
+	// +optional
+	Transform *CloneTransform `json:"transform,omitempty"`
+}
+
+// CloneTransform declares changes applied to each resource cloned from a list of sources.
+type CloneTransform struct {
+	// NamePrefix is prepended to the name of each cloned resource.
+	// +optional
+	NamePrefix string `json:"namePrefix,omitempty"`
+
+	// NameSuffix is appended to the name of each cloned resource.
+	// +optional
+	NameSuffix string `json:"nameSuffix,omitempty"`
+
+	// Labels are added to each cloned resource, existing labels with the same keys are overwritten.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// PatchStrategicMerge is a strategic merge patch applied to each cloned resource.
+	// See https://kubernetes.io/docs/tasks/manage-kubernetes-objects/update-api-object-kubectl-patch/.
+	// +optional
+	RawPatchStrategicMerge *apiextv1.JSON `json:"patchStrategicMerge,omitempty"`
+
+	// PatchesJSON6902 is a list of RFC 6902 JSON Patch declarations applied to each cloned resource.
+	// See https://tools.ietf.org/html/rfc6902.
+	// +optional
+	PatchesJSON6902 string `json:"patchesJson6902,omitempty"`
+}
+
+func (t *CloneTransform) GetPatchStrategicMerge() apiextensions.JSON {
+	return FromJSON(t.RawPatchStrategicMerge)
+}
+
+// GetName returns the name of the resource cloned from the source with the given name.
+func (t *CloneTransform) GetName(name string) string {
+	if t == nil {
+		return name
+	}
+	return t.NamePrefix + name + t.NameSuffix
 }
 
 func (g *Generation) Validate(path *field.Path, namespaced bool, policyNamespace string, clusterResources sets.Set[string]) (errs field.ErrorList) {
@@ -868,6 +1199,10 @@ func (g *Generation) Validate(path *field.Path, namespaced bool, policyNamespace
 		return errs
 	}
 
+	if g.Orphan != nil {
+		errs = append(errs, metav1validation.ValidateLabels(g.Orphan.Labels, path.Child("orphan").Child("labels"))...)
+	}
+
 	if g.ForEachGeneration != nil {
 		for i, foreach := range g.ForEachGeneration {
 			err := foreach.GeneratePattern.Validate(path.Child("foreach").Index(i), namespaced, policyNamespace, clusterResources)
@@ -875,7 +1210,7 @@ func (g *Generation) Validate(path *field.Path, namespaced bool, policyNamespace
 		}
 		return errs
 	} else {
-		return g.GeneratePattern.Validate(path, namespaced, policyNamespace, clusterResources)
+		return append(errs, g.GeneratePattern.Validate(path, namespaced, policyNamespace, clusterResources)...)
 	}
 }
 
@@ -886,7 +1221,9 @@ func (g *GeneratePattern) Validate(path *field.Path, namespaced bool, policyName
 		}
 	}
 
-	if g.GetKind() != "" {
+	if g.NamespaceSelector != nil {
+		errs = append(errs, g.validateNamespaceSelector(path, namespaced, clusterResources)...)
+	} else if g.GetKind() != "" {
 		if !clusterResources.Has(g.GetAPIVersion() + "/" + g.GetKind()) {
 			if g.GetNamespace() == "" {
 				errs = append(errs, field.Forbidden(path.Child("namespace"), "target namespace must be set for a namespaced resource"))
@@ -903,8 +1240,13 @@ func (g *GeneratePattern) Validate(path *field.Path, namespaced bool, policyName
 			Kind:       g.ResourceSpec.GetKind(),
 			APIVersion: g.ResourceSpec.GetAPIVersion(),
 		},
-		Clone:     g.Clone,
-		CloneList: g.CloneList,
+		Clone: g.Clone,
+		// transformations can be adapted to the trigger
+		CloneList: CloneList{
+			Namespace: g.CloneList.Namespace,
+			Kinds:     g.CloneList.Kinds,
+			Selector:  g.CloneList.Selector,
+		},
 	}
 
 	if err := regex.ObjectHasVariables(newGeneration); err != nil {
@@ -926,6 +1268,23 @@ func (g *GeneratePattern) Validate(path *field.Path, namespaced bool, policyName
 	return append(errs, g.ValidateCloneList(path, namespaced, policyNamespace, clusterResources)...)
 }
 
+func (g *GeneratePattern) validateNamespaceSelector(path *field.Path, namespaced bool, clusterResources sets.Set[string]) (errs field.ErrorList) {
+	path = path.Child("namespaceSelector")
+	if namespaced {
+		errs = append(errs, field.Forbidden(path, "a namespaced policy cannot generate resources into namespaces selected by label"))
+	}
+	if g.GetNamespace() != "" {
+		errs = append(errs, field.Forbidden(path, "namespace and namespaceSelector can not be specified together"))
+	}
+	if len(g.CloneList.Kinds) == 0 && clusterResources.Has(g.GetAPIVersion()+"/"+g.GetKind()) {
+		errs = append(errs, field.Forbidden(path, "namespaceSelector must not be set for a cluster-wide resource"))
+	}
+	if _, err := metav1.LabelSelectorAsSelector(g.NamespaceSelector); err != nil {
+		errs = append(errs, field.Invalid(path, g.NamespaceSelector, err.Error()))
+	}
+	return errs
+}
+
 func (g *GeneratePattern) ValidateCloneList(path *field.Path, namespaced bool, policyNamespace string, clusterResources sets.Set[string]) (errs field.ErrorList) {
 	if len(g.CloneList.Kinds) == 0 {
 		return nil
@@ -32,7 +32,7 @@ func Test_ImageVerification(t *testing.T) {
 				field.Invalid(
 					path.Child("attestors").Index(0).Child("entries").Index(0).Child("keyless"),
 					i.Attestors[0].Entries[0].Keyless,
-					"Either Rekor URL or roots are required"),
+					"One of Rekor URL, roots or secret is required"),
 			}
 		},
 	}, {
@@ -104,7 +104,7 @@ func Test_ImageVerification(t *testing.T) {
 			errors: func(i *ImageVerification) field.ErrorList {
 				return field.ErrorList{
 					field.Invalid(path.Child("attestors").Index(0).Child("entries").Index(0),
-						&i.Attestors[0].Entries[0], "keys, certificates, keyless, or a nested attestor is required"),
+						&i.Attestors[0].Entries[0], "keys, certificates, keyless, notary, or a nested attestor is required"),
 				}
 			},
 		},
@@ -225,6 +225,150 @@ func Test_ImageVerification(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "valid notary attestor",
+			subject: ImageVerification{
+				Type:            Notary,
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{
+					{Entries: []Attestor{{
+						Notary: &NotaryAttestor{
+							TrustPolicy: "version: \"1.0\"",
+							TrustStore:  SecretReference{Name: "trust-store", Namespace: "kyverno"},
+						},
+					}}},
+				},
+			},
+		},
+		{
+			name: "notary attestor without trust store",
+			subject: ImageVerification{
+				Type:            Notary,
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{
+					{Entries: []Attestor{{
+						Notary: &NotaryAttestor{
+							TrustPolicy: "version: \"1.0\"",
+						},
+					}}},
+				},
+			},
+			errors: func(i *ImageVerification) field.ErrorList {
+				return field.ErrorList{
+					field.Required(path.Child("attestors").Index(0).Child("entries").Index(0).Child("notary").Child("trustStore"),
+						"A trust store secret name and namespace are required"),
+				}
+			},
+		},
+		{
+			name: "notary attestor with cosign",
+			subject: ImageVerification{
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{
+					{Entries: []Attestor{{
+						Notary: &NotaryAttestor{
+							TrustPolicy: "version: \"1.0\"",
+							TrustStore:  SecretReference{Name: "trust-store", Namespace: "kyverno"},
+						},
+					}}},
+				},
+			},
+			errors: func(i *ImageVerification) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(path.Child("attestors"), i, "Notary field is only allowed for type notary"),
+				}
+			},
+		},
+		{
+			name: "bundles with cosign",
+			subject: ImageVerification{
+				ImageReferences: []string{"*"},
+				Bundles:         []BundleSource{{Data: "{}"}},
+				Attestors: []AttestorSet{
+					{Entries: []Attestor{{
+						Keys: &StaticKeyAttestor{PublicKeys: "bla"},
+					}}},
+				},
+			},
+			errors: func(i *ImageVerification) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(path.Child("bundles"), i.Bundles, "Bundles are only allowed for type SigstoreBundle"),
+				}
+			},
+		},
+		{
+			name: "bundle without source",
+			subject: ImageVerification{
+				Type:            SigstoreBundle,
+				ImageReferences: []string{"*"},
+				Bundles:         []BundleSource{{}},
+				Attestors: []AttestorSet{
+					{Entries: []Attestor{{
+						Keyless: &KeylessAttestor{Issuer: "https://token.actions.githubusercontent.com", Subject: "*", Roots: "roots"},
+					}}},
+				},
+			},
+			errors: func(i *ImageVerification) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(path.Child("bundles").Index(0), &i.Bundles[0], "Exactly one of data or secret is required"),
+				}
+			},
+		},
+		{
+			name: "bundle with data and secret",
+			subject: ImageVerification{
+				Type:            SigstoreBundle,
+				ImageReferences: []string{"*"},
+				Bundles:         []BundleSource{{Data: "{}", Secret: &SecretReference{Name: "bundle"}}},
+				Attestors: []AttestorSet{
+					{Entries: []Attestor{{
+						Keyless: &KeylessAttestor{Issuer: "https://token.actions.githubusercontent.com", Subject: "*", Roots: "roots"},
+					}}},
+				},
+			},
+			errors: func(i *ImageVerification) field.ErrorList {
+				return field.ErrorList{
+					field.Invalid(path.Child("bundles").Index(0), &i.Bundles[0], "Exactly one of data or secret is required"),
+					field.Required(path.Child("bundles").Index(0).Child("secret"), "A secret name and namespace are required"),
+				}
+			},
+		},
+		{
+			name: "keyless attestor with secret",
+			subject: ImageVerification{
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{
+					{Entries: []Attestor{{
+						Keyless: &KeylessAttestor{
+							Issuer:  "https://fulcio.example.com",
+							Subject: "*",
+							Secret:  &SecretReference{Name: "sigstore", Namespace: "kyverno"},
+						},
+					}}},
+				},
+			},
+		},
+		{
+			name: "keyless attestor with secret without namespace",
+			subject: ImageVerification{
+				ImageReferences: []string{"*"},
+				Attestors: []AttestorSet{
+					{Entries: []Attestor{{
+						Keyless: &KeylessAttestor{
+							Issuer:  "https://fulcio.example.com",
+							Subject: "*",
+							Secret:  &SecretReference{Name: "sigstore"},
+						},
+					}}},
+				},
+			},
+			errors: func(i *ImageVerification) field.ErrorList {
+				return field.ErrorList{
+					field.Required(path.Child("attestors").Index(0).Child("entries").Index(0).Child("keyless").Child("secret"),
+						"A secret name and namespace are required"),
+				}
+			},
+		},
 		{
 			name: "multiple entries",
 			subject: ImageVerification{
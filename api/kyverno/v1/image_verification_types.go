@@ -106,6 +106,12 @@ type ImageVerification struct {
 	// +optional
 	CosignOCI11 bool `json:"cosignOCI11,omitempty"`
 
+	// Bundles is an optional list of sigstore bundles used to verify the image, in addition to the bundles
+	// stored as OCI referrers of the image. Each entry is either an inline JSON encoded bundle or a reference
+	// to a Secret holding the bundle. Bundles are only used for the SigstoreBundle type.
+	// +kubebuilder:validation:Optional
+	Bundles []BundleSource `json:"bundles,omitempty"`
+
 	// MutateDigest enables replacement of image tags with digests.
 	// Defaults to true.
 	// +kubebuilder:default=true
@@ -171,6 +177,11 @@ type Attestor struct {
 	// +kubebuilder:validation:Optional
 	Keyless *KeylessAttestor `json:"keyless,omitempty"`
 
+	// Notary is a set of attributes used to verify image signatures with a notation trust policy.
+	// It is only allowed for the Notary image verification type.
+	// +kubebuilder:validation:Optional
+	Notary *NotaryAttestor `json:"notary,omitempty"`
+
 	// Attestor is a nested set of Attestor used to specify a more complex set of match authorities.
 	// +kubebuilder:validation:Optional
 	Attestor *apiextv1.JSON `json:"attestor,omitempty"`
@@ -230,6 +241,19 @@ type SecretReference struct {
 	Namespace string `json:"namespace"`
 }
 
+// BundleSource provides a sigstore bundle, either inline or from a Secret.
+type BundleSource struct {
+	// Data is a JSON encoded sigstore bundle.
+	// +kubebuilder:validation:Optional
+	Data string `json:"data,omitempty"`
+
+	// Secret references a Secret holding a JSON encoded sigstore bundle in the `bundle.json` key.
+	// The Secret must be in the namespace of the policy, cluster policies can use the Kyverno namespace
+	// and the namespaces allowed in the configuration.
+	// +kubebuilder:validation:Optional
+	Secret *SecretReference `json:"secret,omitempty"`
+}
+
 type CertificateAttestor struct {
 	// Cert is an optional PEM-encoded public certificate.
 	// +kubebuilder:validation:Optional
@@ -250,6 +274,17 @@ type CertificateAttestor struct {
 	CTLog *CTLog `json:"ctlog,omitempty"`
 }
 
+// NotaryAttestor verifies image signatures with a notation trust policy and trust stores.
+// See https://notaryproject.dev/docs/user-guides/how-to/manage-trust-policy/.
+type NotaryAttestor struct {
+	// TrustPolicy is a notation trust policy document in JSON or YAML format.
+	TrustPolicy string `json:"trustPolicy"`
+
+	// TrustStore is a reference to a Secret containing the PEM encoded certificates of the trust
+	// stores used by the trust policy. Each key of the Secret is the name of a trust store.
+	TrustStore SecretReference `json:"trustStore"`
+}
+
 type KeylessAttestor struct {
 	// Rekor provides configuration for the Rekor transparency log service. If an empty object
 	// is provided the public instance of Rekor (https://rekor.sigstore.dev) is used.
@@ -285,6 +320,14 @@ type KeylessAttestor struct {
 	// AdditionalExtensions are certificate-extensions used for keyless signing.
 	// +kubebuilder:validation:Optional
 	AdditionalExtensions map[string]string `json:"additionalExtensions,omitempty"`
+
+	// Secret references a Secret containing the trust material of a private sigstore deployment.
+	// The Secret can contain the keys `fulcio_v1.crt.pem` (PEM encoded Fulcio root certificates),
+	// `rekor.pub` (PEM encoded Rekor public key) and `ctfe.pub` (PEM encoded CT log public key).
+	// Values found in the Secret take precedence over roots, rekor.pubkey and ctlog.pubkey.
+	// The Secret must be in the namespace of the policy, cluster policies can use the Kyverno namespace and the namespaces allowed in the configuration.
+	// +kubebuilder:validation:Optional
+	Secret *SecretReference `json:"secret,omitempty"`
 }
 
 type Rekor struct {
@@ -341,6 +384,7 @@ type Attestation struct {
 
 	// Conditions are used to verify attributes within a Predicate. If no Conditions are specified
 	// the attestation check is satisfied as long there are predicates that match the predicate type.
+	// For CycloneDX and SPDX attestations the parsed SBOM is also available in the `sbom` variable.
 	// +kubebuilder:validation:Optional
 	Conditions []AnyAllConditions `json:"conditions,omitempty"`
 }
@@ -418,6 +462,24 @@ func (iv *ImageVerification) Validate(isAuditFailureAction bool, path *field.Pat
 		}
 	}
 
+	if len(iv.Bundles) != 0 && iv.Type != SigstoreBundle {
+		errs = append(errs, field.Invalid(path.Child("bundles"), iv.Bundles, "Bundles are only allowed for type SigstoreBundle"))
+	}
+
+	for i := range iv.Bundles {
+		errs = append(errs, iv.Bundles[i].Validate(path.Child("bundles").Index(i))...)
+	}
+
+	if iv.Type != Notary {
+		for _, attestorSet := range iv.Attestors {
+			for _, attestor := range attestorSet.Entries {
+				if attestor.Notary != nil {
+					errs = append(errs, field.Invalid(attestorsPath, iv, "Notary field is only allowed for type notary"))
+				}
+			}
+		}
+	}
+
 	return errs
 }
 
@@ -459,12 +521,13 @@ func validateAttestorSet(as *AttestorSet, path *field.Path) (errs field.ErrorLis
 }
 
 func (a *Attestor) Validate(path *field.Path) (errs field.ErrorList) {
-	if (a.Keys != nil && (a.Certificates != nil || a.Keyless != nil || a.Attestor != nil)) ||
-		(a.Certificates != nil && (a.Keys != nil || a.Keyless != nil || a.Attestor != nil)) ||
-		(a.Keyless != nil && (a.Certificates != nil || a.Keys != nil || a.Attestor != nil)) ||
-		(a.Attestor != nil && (a.Certificates != nil || a.Keys != nil || a.Keyless != nil)) ||
-		(a.Keys == nil && a.Certificates == nil && a.Keyless == nil && a.Attestor == nil) {
-		errs = append(errs, field.Invalid(path, a, "keys, certificates, keyless, or a nested attestor is required"))
+	if (a.Keys != nil && (a.Certificates != nil || a.Keyless != nil || a.Notary != nil || a.Attestor != nil)) ||
+		(a.Certificates != nil && (a.Keys != nil || a.Keyless != nil || a.Notary != nil || a.Attestor != nil)) ||
+		(a.Keyless != nil && (a.Certificates != nil || a.Keys != nil || a.Notary != nil || a.Attestor != nil)) ||
+		(a.Notary != nil && (a.Certificates != nil || a.Keys != nil || a.Keyless != nil || a.Attestor != nil)) ||
+		(a.Attestor != nil && (a.Certificates != nil || a.Keys != nil || a.Keyless != nil || a.Notary != nil)) ||
+		(a.Keys == nil && a.Certificates == nil && a.Keyless == nil && a.Notary == nil && a.Attestor == nil) {
+		errs = append(errs, field.Invalid(path, a, "keys, certificates, keyless, notary, or a nested attestor is required"))
 	}
 
 	if a.Keys != nil {
@@ -485,6 +548,12 @@ func (a *Attestor) Validate(path *field.Path) (errs field.ErrorList) {
 		errs = append(errs, keylessErrors...)
 	}
 
+	if a.Notary != nil {
+		notaryPath := path.Child("notary")
+		notaryErrors := a.Notary.Validate(notaryPath)
+		errs = append(errs, notaryErrors...)
+	}
+
 	if a.Attestor != nil {
 		attestorPath := path.Child("attestor")
 		attestorSet, err := AttestorSetUnmarshal(a.Attestor)
@@ -529,15 +598,39 @@ func (ca *CertificateAttestor) Validate(path *field.Path) (errs field.ErrorList)
 	return errs
 }
 
+func (na *NotaryAttestor) Validate(path *field.Path) (errs field.ErrorList) {
+	if na.TrustPolicy == "" {
+		errs = append(errs, field.Required(path.Child("trustPolicy"), "A trust policy is required"))
+	}
+	if na.TrustStore.Name == "" || na.TrustStore.Namespace == "" {
+		errs = append(errs, field.Required(path.Child("trustStore"), "A trust store secret name and namespace are required"))
+	}
+	return errs
+}
+
+func (bs *BundleSource) Validate(path *field.Path) (errs field.ErrorList) {
+	if (bs.Data == "") == (bs.Secret == nil) {
+		errs = append(errs, field.Invalid(path, bs, "Exactly one of data or secret is required"))
+	}
+	if bs.Secret != nil && (bs.Secret.Name == "" || bs.Secret.Namespace == "") {
+		errs = append(errs, field.Required(path.Child("secret"), "A secret name and namespace are required"))
+	}
+	return errs
+}
+
 func (ka *KeylessAttestor) Validate(path *field.Path) (errs field.ErrorList) {
-	if ka.Rekor == nil && ka.Roots == "" {
-		errs = append(errs, field.Invalid(path, ka, "Either Rekor URL or roots are required"))
+	if ka.Rekor == nil && ka.Roots == "" && ka.Secret == nil {
+		errs = append(errs, field.Invalid(path, ka, "One of Rekor URL, roots or secret is required"))
 	}
 
 	if ka.Rekor != nil && ka.Rekor.URL == "" {
 		errs = append(errs, field.Invalid(path, ka, "An URL is required"))
 	}
 
+	if ka.Secret != nil && (ka.Secret.Name == "" || ka.Secret.Namespace == "") {
+		errs = append(errs, field.Required(path.Child("secret"), "A secret name and namespace are required"))
+	}
+
 	return errs
 }
 
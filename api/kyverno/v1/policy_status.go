@@ -31,6 +31,9 @@ type PolicyStatus struct {
 	// ValidatingAdmissionPolicy contains status information
 	// +optional
 	ValidatingAdmissionPolicy ValidatingAdmissionPolicyStatus `json:"validatingadmissionpolicy"`
+	// GenerateExisting contains the progress of the generate rules rollout to the existing triggers
+	// +optional
+	GenerateExisting *GenerateExistingStatus `json:"generateExisting,omitempty"`
 }
 
 // RuleCountStatus contains four variables which describes counts for
@@ -74,6 +77,14 @@ type AutogenStatus struct {
 	Rules []Rule `json:"rules,omitempty"`
 }
 
+// GenerateExistingStatus contains the progress of the generate rules rollout to the existing triggers.
+type GenerateExistingStatus struct {
+	// Total is the number of existing triggers, per rule, the generate rules apply to
+	Total int `json:"total"`
+	// Processed is the number of existing triggers, per rule, update requests were created for
+	Processed int `json:"processed"`
+}
+
 // ValidatingAdmissionPolicy contains status information
 type ValidatingAdmissionPolicyStatus struct {
 	// Generated indicates whether a validating admission policy is generated from the policy or not
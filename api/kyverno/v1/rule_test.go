@@ -1007,6 +1007,47 @@ func Test_Validate_ClusterPolicy_Generate_Variables(t *testing.T) {
 			}`),
 			shouldFail: true,
 		},
+		{
+			name: "cloneList-transform",
+			rule: []byte(`
+			{
+				"name": "sync-secret",
+				"match": {
+					"any": [
+						{
+							"resources": {
+								"kinds": [
+									"Namespace"
+								]
+							}
+						}
+					]
+				},
+				"generate": {
+					"namespace": "{{request.object.metadata.name}}",
+					"synchronize": true,
+					"cloneList": {
+						"namespace": "default",
+						"kinds": [
+							"v1/Secret",
+							"v1/ConfigMap"
+						],
+						"selector": {
+							"matchLabels": {
+								"allowedToBeCloned": "true"
+							}
+						},
+						"transform": {
+							"namePrefix": "{{request.object.metadata.name}}-",
+							"labels": {
+								"team": "{{request.object.metadata.labels.team}}"
+							}
+						}
+					}
+				}
+			}`),
+			shouldFail: false,
+		},
 		{
 			name: "generate-downstream-namespace",
 			rule: []byte(`
@@ -1137,3 +1178,167 @@ func Test_Validate_ClusterPolicy_Generate_Variables(t *testing.T) {
 		assert.Equal(t, len(errs) != 0, testcase.shouldFail, testcase.name)
 	}
 }
+
+func Test_Validate_Generate_NamespaceSelector(t *testing.T) {
+	path := field.NewPath("dummy")
+	testcases := []struct {
+		name       string
+		generate   []byte
+		namespaced bool
+		shouldFail bool
+	}{
+		{
+			name: "data-namespace-selector",
+			generate: []byte(`
+			{
+				"apiVersion": "v1",
+				"kind": "ConfigMap",
+				"name": "settings",
+				"namespaceSelector": {
+					"matchLabels": {
+						"team": "payments"
+					}
+				},
+				"synchronize": true,
+				"data": {
+					"data": {
+						"foo": "bar"
+					}
+				}
+			}`),
+			shouldFail: false,
+		},
+		{
+			name: "namespace-and-namespace-selector",
+			generate: []byte(`
+			{
+				"apiVersion": "v1",
+				"kind": "ConfigMap",
+				"name": "settings",
+				"namespace": "default",
+				"namespaceSelector": {
+					"matchLabels": {
+						"team": "payments"
+					}
+				},
+				"data": {
+					"data": {
+						"foo": "bar"
+					}
+				}
+			}`),
+			shouldFail: true,
+		},
+		{
+			name: "invalid-namespace-selector",
+			generate: []byte(`
+			{
+				"apiVersion": "v1",
+				"kind": "ConfigMap",
+				"name": "settings",
+				"namespaceSelector": {
+					"matchExpressions": [
+						{
+							"key": "team",
+							"operator": "Unknown"
+						}
+					]
+				},
+				"data": {
+					"data": {
+						"foo": "bar"
+					}
+				}
+			}`),
+			shouldFail: true,
+		},
+		{
+			name: "namespaced-policy-namespace-selector",
+			generate: []byte(`
+			{
+				"apiVersion": "v1",
+				"kind": "ConfigMap",
+				"name": "settings",
+				"namespaceSelector": {
+					"matchLabels": {
+						"team": "payments"
+					}
+				},
+				"data": {
+					"data": {
+						"foo": "bar"
+					}
+				}
+			}`),
+			namespaced: true,
+			shouldFail: true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		var generate *Generation
+		err := json.Unmarshal(testcase.generate, &generate)
+		assert.NilError(t, err, testcase.name)
+		errs := generate.Validate(path, testcase.namespaced, "default", nil)
+		assert.Equal(t, len(errs) != 0, testcase.shouldFail, testcase.name)
+	}
+}
+
+func Test_Generation_Orphan(t *testing.T) {
+	keep, remove := true, false
+	testcases := []struct {
+		name            string
+		generation      Generation
+		onTriggerDelete bool
+		onRuleDelete    bool
+		onPolicyDelete  bool
+	}{
+		{
+			name:       "defaults",
+			generation: Generation{},
+		},
+		{
+			name:           "orphanDownstreamOnPolicyDelete",
+			generation:     Generation{OrphanDownstreamOnPolicyDelete: true},
+			onRuleDelete:   true,
+			onPolicyDelete: true,
+		},
+		{
+			name: "orphan overrides orphanDownstreamOnPolicyDelete",
+			generation: Generation{
+				OrphanDownstreamOnPolicyDelete: true,
+				Orphan: &OrphanOptions{
+					OnTriggerDelete: &keep,
+					OnRuleDelete:    &remove,
+				},
+			},
+			onTriggerDelete: true,
+			onRuleDelete:    false,
+			onPolicyDelete:  true,
+		},
+	}
+	for _, testcase := range testcases {
+		assert.Equal(t, testcase.generation.OrphanOnTriggerDelete(), testcase.onTriggerDelete, testcase.name)
+		assert.Equal(t, testcase.generation.OrphanOnRuleDelete(), testcase.onRuleDelete, testcase.name)
+		assert.Equal(t, testcase.generation.OrphanOnPolicyDelete(), testcase.onPolicyDelete, testcase.name)
+	}
+}
+
+func Test_Validate_Generate_OrphanLabels(t *testing.T) {
+	path := field.NewPath("dummy")
+	generation := Generation{
+		Orphan: &OrphanOptions{
+			Labels: map[string]string{"kyverno.io/orphan": "not a valid value"},
+		},
+		GeneratePattern: GeneratePattern{
+			ResourceSpec: ResourceSpec{APIVersion: "v1", Kind: "ConfigMap", Name: "settings", Namespace: "default"},
+		},
+	}
+	errs := generation.Validate(path, false, "", nil)
+	assert.Equal(t, len(errs), 1)
+	assert.Equal(t, errs[0].Field, "dummy.orphan.labels")
+
+	generation.Orphan.Labels = map[string]string{"kyverno.io/orphan": "true"}
+	errs = generation.Validate(path, false, "", nil)
+	assert.Equal(t, len(errs), 0)
+}
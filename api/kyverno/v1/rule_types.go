@@ -51,6 +51,11 @@ type Rule struct {
 	// +optional
 	Context []ContextEntry `json:"context,omitempty"`
 
+	// Outputs declares values computed from the rule context that are exported to the subsequent
+	// rules of the same policy, they are evaluated once per request after the rule context is loaded.
+	// +optional
+	Outputs []RuleOutput `json:"outputs,omitempty"`
+
 	// ReportProperties are the additional properties from the rule that will be added to the policy report result
 	// +optional
 	ReportProperties map[string]string `json:"reportProperties,omitempty"`
@@ -83,7 +88,7 @@ type Rule struct {
 	RawAnyAllConditions *ConditionsWrapper `json:"preconditions,omitempty"`
 
 	// CELPreconditions are used to determine if a policy rule should be applied by evaluating a
-	// set of CEL conditions. It can only be used with the validate.cel subrule
+	// set of CEL conditions, in addition to preconditions written as JMESPath conditions
 	// +optional
 	CELPreconditions []admissionregistrationv1beta1.MatchCondition `json:"celPreconditions,omitempty"`
 
@@ -177,6 +182,11 @@ func (r *Rule) HasValidateCEL() bool {
 	return r.Validation != nil && r.Validation.CEL != nil && !datautils.DeepEqual(*r.Validation.CEL, CEL{})
 }
 
+// HasValidateWasm checks for validate.wasm rule
+func (r *Rule) HasValidateWasm() bool {
+	return r.Validation != nil && r.Validation.Wasm != nil && !datautils.DeepEqual(*r.Validation.Wasm, Wasm{})
+}
+
 // HasValidateAssert checks for validate.assert rule
 func (r *Rule) HasValidateAssert() bool {
 	return r.Validation != nil && !datautils.DeepEqual(r.Validation.Assert, AssertionTree{})
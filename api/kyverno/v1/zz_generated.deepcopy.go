@@ -137,6 +137,11 @@ func (in *Attestor) DeepCopyInto(out *Attestor) {
 		*out = new(KeylessAttestor)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Notary != nil {
+		in, out := &in.Notary, &out.Notary
+		*out = new(NotaryAttestor)
+		**out = **in
+	}
 	if in.Attestor != nil {
 		in, out := &in.Attestor, &out.Attestor
 		*out = new(apiextensionsv1.JSON)
@@ -213,6 +218,27 @@ func (in *AutogenStatus) DeepCopy() *AutogenStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BundleSource) DeepCopyInto(out *BundleSource) {
+	*out = *in
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(SecretReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleSource.
+func (in *BundleSource) DeepCopy() *BundleSource {
+	if in == nil {
+		return nil
+	}
+	out := new(BundleSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CEL) DeepCopyInto(out *CEL) {
 	*out = *in
@@ -327,6 +353,11 @@ func (in *CloneList) DeepCopyInto(out *CloneList) {
 		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Transform != nil {
+		in, out := &in.Transform, &out.Transform
+		*out = new(CloneTransform)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -340,6 +371,34 @@ func (in *CloneList) DeepCopy() *CloneList {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneTransform) DeepCopyInto(out *CloneTransform) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RawPatchStrategicMerge != nil {
+		in, out := &in.RawPatchStrategicMerge, &out.RawPatchStrategicMerge
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneTransform.
+func (in *CloneTransform) DeepCopy() *CloneTransform {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneTransform)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterPolicy) DeepCopyInto(out *ClusterPolicy) {
 	*out = *in
@@ -452,6 +511,11 @@ func (in *ContextAPICall) DeepCopyInto(out *ContextAPICall) {
 		*out = new(apiextensionsv1.JSON)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CacheTTL != nil {
+		in, out := &in.CacheTTL, &out.CacheTTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -493,6 +557,21 @@ func (in *ContextEntry) DeepCopyInto(out *ContextEntry) {
 		*out = new(GlobalContextEntryReference)
 		**out = **in
 	}
+	if in.SecretStore != nil {
+		in, out := &in.SecretStore, &out.SecretStore
+		*out = new(SecretStore)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GRPCCall != nil {
+		in, out := &in.GRPCCall, &out.GRPCCall
+		*out = new(GRPCCall)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalData != nil {
+		in, out := &in.ExternalData, &out.ExternalData
+		*out = new(ExternalData)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -542,9 +621,40 @@ func (in *DryRunOption) DeepCopy() *DryRunOption {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalData) DeepCopyInto(out *ExternalData) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalData.
+func (in *ExternalData) DeepCopy() *ExternalData {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalData)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ForEachGeneration) DeepCopyInto(out *ForEachGeneration) {
 	*out = *in
+	if in.MapEntries != nil {
+		in, out := &in.MapEntries, &out.MapEntries
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Context != nil {
 		in, out := &in.Context, &out.Context
 		*out = make([]ContextEntry, len(*in))
@@ -574,6 +684,11 @@ func (in *ForEachGeneration) DeepCopy() *ForEachGeneration {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ForEachMutation) DeepCopyInto(out *ForEachMutation) {
 	*out = *in
+	if in.MapEntries != nil {
+		in, out := &in.MapEntries, &out.MapEntries
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Order != nil {
 		in, out := &in.Order, &out.Order
 		*out = new(ForeachOrder)
@@ -615,11 +730,21 @@ func (in *ForEachMutation) DeepCopy() *ForEachMutation {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ForEachValidation) DeepCopyInto(out *ForEachValidation) {
 	*out = *in
+	if in.MapEntries != nil {
+		in, out := &in.MapEntries, &out.MapEntries
+		*out = new(bool)
+		**out = **in
+	}
 	if in.ElementScope != nil {
 		in, out := &in.ElementScope, &out.ElementScope
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(int)
+		**out = **in
+	}
 	if in.Context != nil {
 		in, out := &in.Context, &out.Context
 		*out = make([]ContextEntry, len(*in))
@@ -664,10 +789,62 @@ func (in *ForEachValidation) DeepCopy() *ForEachValidation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCCall) DeepCopyInto(out *GRPCCall) {
+	*out = *in
+	if in.Request != nil {
+		in, out := &in.Request, &out.Request
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSSecret != nil {
+		in, out := &in.TLSSecret, &out.TLSSecret
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCCall.
+func (in *GRPCCall) DeepCopy() *GRPCCall {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCCall)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenerateExistingStatus) DeepCopyInto(out *GenerateExistingStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenerateExistingStatus.
+func (in *GenerateExistingStatus) DeepCopy() *GenerateExistingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GenerateExistingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GeneratePattern) DeepCopyInto(out *GeneratePattern) {
 	*out = *in
 	out.ResourceSpec = in.ResourceSpec
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RawData != nil {
 		in, out := &in.RawData, &out.RawData
 		*out = new(apiextensionsv1.JSON)
@@ -696,6 +873,11 @@ func (in *Generation) DeepCopyInto(out *Generation) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Orphan != nil {
+		in, out := &in.Orphan, &out.Orphan
+		*out = new(OrphanOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	in.GeneratePattern.DeepCopyInto(&out.GeneratePattern)
 	if in.ForEachGeneration != nil {
 		in, out := &in.ForEachGeneration, &out.ForEachGeneration
@@ -910,6 +1092,13 @@ func (in *ImageVerification) DeepCopyInto(out *ImageVerification) {
 			(*out)[key] = val
 		}
 	}
+	if in.Bundles != nil {
+		in, out := &in.Bundles, &out.Bundles
+		*out = make([]BundleSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.Validation.DeepCopyInto(&out.Validation)
 	if in.ImageRegistryCredentials != nil {
 		in, out := &in.ImageRegistryCredentials, &out.ImageRegistryCredentials
@@ -949,6 +1138,11 @@ func (in *KeylessAttestor) DeepCopyInto(out *KeylessAttestor) {
 			(*out)[key] = val
 		}
 	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(SecretReference)
+		**out = **in
+	}
 	return
 }
 
@@ -1033,6 +1227,11 @@ func (in *Mutation) DeepCopyInto(out *Mutation) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ServerSideApply != nil {
+		in, out := &in.ServerSideApply, &out.ServerSideApply
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Targets != nil {
 		in, out := &in.Targets, &out.Targets
 		*out = make([]TargetResourceSpec, len(*in))
@@ -1065,6 +1264,23 @@ func (in *Mutation) DeepCopy() *Mutation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotaryAttestor) DeepCopyInto(out *NotaryAttestor) {
+	*out = *in
+	out.TrustStore = in.TrustStore
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotaryAttestor.
+func (in *NotaryAttestor) DeepCopy() *NotaryAttestor {
+	if in == nil {
+		return nil
+	}
+	out := new(NotaryAttestor)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectFieldBinding) DeepCopyInto(out *ObjectFieldBinding) {
 	*out = *in
@@ -1091,6 +1307,44 @@ func (in *ObjectFieldBinding) DeepCopy() *ObjectFieldBinding {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanOptions) DeepCopyInto(out *OrphanOptions) {
+	*out = *in
+	if in.OnTriggerDelete != nil {
+		in, out := &in.OnTriggerDelete, &out.OnTriggerDelete
+		*out = new(bool)
+		**out = **in
+	}
+	if in.OnRuleDelete != nil {
+		in, out := &in.OnRuleDelete, &out.OnRuleDelete
+		*out = new(bool)
+		**out = **in
+	}
+	if in.OnPolicyDelete != nil {
+		in, out := &in.OnPolicyDelete, &out.OnPolicyDelete
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanOptions.
+func (in *OrphanOptions) DeepCopy() *OrphanOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodSecurity) DeepCopyInto(out *PodSecurity) {
 	*out = *in
@@ -1219,6 +1473,11 @@ func (in *PolicyStatus) DeepCopyInto(out *PolicyStatus) {
 	in.Autogen.DeepCopyInto(&out.Autogen)
 	out.RuleCount = in.RuleCount
 	out.ValidatingAdmissionPolicy = in.ValidatingAdmissionPolicy
+	if in.GenerateExisting != nil {
+		in, out := &in.GenerateExisting, &out.GenerateExisting
+		*out = new(GenerateExistingStatus)
+		**out = **in
+	}
 	return
 }
 
@@ -1388,6 +1647,11 @@ func (in *Rule) DeepCopyInto(out *Rule) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]RuleOutput, len(*in))
+		copy(*out, *in)
+	}
 	if in.ReportProperties != nil {
 		in, out := &in.ReportProperties, &out.ReportProperties
 		*out = make(map[string]string, len(*in))
@@ -1481,6 +1745,22 @@ func (in *RuleCountStatus) DeepCopy() *RuleCountStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleOutput) DeepCopyInto(out *RuleOutput) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleOutput.
+func (in *RuleOutput) DeepCopy() *RuleOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretReference) DeepCopyInto(out *SecretReference) {
 	*out = *in
@@ -1497,6 +1777,27 @@ func (in *SecretReference) DeepCopy() *SecretReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretStore) DeepCopyInto(out *SecretStore) {
+	*out = *in
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultSecret)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretStore.
+func (in *SecretStore) DeepCopy() *SecretStore {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceCall) DeepCopyInto(out *ServiceCall) {
 	*out = *in
@@ -1505,6 +1806,11 @@ func (in *ServiceCall) DeepCopyInto(out *ServiceCall) {
 		*out = make([]HTTPHeader, len(*in))
 		copy(*out, *in)
 	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(ServiceCallAuth)
+		**out = **in
+	}
 	return
 }
 
@@ -1518,6 +1824,22 @@ func (in *ServiceCall) DeepCopy() *ServiceCall {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceCallAuth) DeepCopyInto(out *ServiceCallAuth) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceCallAuth.
+func (in *ServiceCallAuth) DeepCopy() *ServiceCallAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceCallAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Spec) DeepCopyInto(out *Spec) {
 	*out = *in
@@ -1528,6 +1850,13 @@ func (in *Spec) DeepCopyInto(out *Spec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = make([]ContextEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ApplyRules != nil {
 		in, out := &in.ApplyRules, &out.ApplyRules
 		*out = new(ApplyRulesType)
@@ -1800,6 +2129,11 @@ func (in *Validation) DeepCopyInto(out *Validation) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.Assert.DeepCopyInto(&out.Assert)
+	if in.Wasm != nil {
+		in, out := &in.Wasm, &out.Wasm
+		*out = new(Wasm)
+		**out = **in
+	}
 	return
 }
 
@@ -1863,6 +2197,43 @@ func (in *Variable) DeepCopy() *Variable {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultSecret) DeepCopyInto(out *VaultSecret) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecret.
+func (in *VaultSecret) DeepCopy() *VaultSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Wasm) DeepCopyInto(out *Wasm) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Wasm.
+func (in *Wasm) DeepCopy() *Wasm {
+	if in == nil {
+		return nil
+	}
+	out := new(Wasm)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebhookConfiguration) DeepCopyInto(out *WebhookConfiguration) {
 	*out = *in
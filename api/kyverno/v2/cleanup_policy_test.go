@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"gotest.tools/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -44,6 +46,72 @@ func Test_CleanupPolicy_Schedule(t *testing.T) {
 	assert.Equal(t, errs[0].Error(), fmt.Sprintf(`spec.schedule: Invalid value: "%s": schedule spec in the cleanupPolicy is not in proper cron format`, subject.Spec.Schedule))
 }
 
+func Test_CleanupPolicy_TimeZone(t *testing.T) {
+	for _, timeZone := range []string{"", "Mars/Olympus_Mons"} {
+		subject := CleanupPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-policy",
+			},
+			Spec: CleanupPolicySpec{
+				Schedule: "* * * * *",
+				TimeZone: &timeZone,
+			},
+		}
+		errs := subject.Validate(nil)
+		assert.Assert(t, len(errs) == 1)
+		assert.Equal(t, errs[0].Field, "spec.timeZone")
+		assert.Equal(t, errs[0].Type, field.ErrorTypeInvalid)
+	}
+}
+
+func Test_CleanupPolicy_DeletionLimits(t *testing.T) {
+	maxDeletions := 0
+	subject := CleanupPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-policy",
+		},
+		Spec: CleanupPolicySpec{
+			Schedule:      "* * * * *",
+			MaxDeletions:  &maxDeletions,
+			DeletionDelay: &metav1.Duration{Duration: -time.Second},
+		},
+	}
+	errs := subject.Validate(nil)
+	assert.Assert(t, len(errs) == 2)
+	assert.Equal(t, errs[0].Field, "spec.maxDeletions")
+	assert.Equal(t, errs[1].Field, "spec.deletionDelay")
+}
+
+func Test_CleanupPolicy_GetNextExecutionTime(t *testing.T) {
+	timeZone := "America/New_York"
+	subject := CleanupPolicy{
+		Spec: CleanupPolicySpec{
+			Schedule: "0 2 * * *",
+			TimeZone: &timeZone,
+		},
+	}
+	next, err := subject.GetNextExecutionTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.NilError(t, err)
+	assert.Equal(t, next.UTC(), time.Date(2024, 1, 1, 7, 0, 0, 0, time.UTC))
+}
+
+func Test_CleanupPolicy_Context(t *testing.T) {
+	errs := ValidateContext(field.NewPath("spec").Child("context"), []kyvernov1.ContextEntry{{
+		Name:      "inventory",
+		ConfigMap: &kyvernov1.ConfigMapReference{Name: "inventory", Namespace: "default"},
+	}, {
+		Name:    "backups",
+		APICall: &kyvernov1.ContextAPICall{APICall: kyvernov1.APICall{URLPath: "/api/v1/namespaces/{{ target.metadata.namespace }}/configmaps"}},
+	}})
+	assert.Assert(t, len(errs) == 0)
+	errs = ValidateContext(field.NewPath("spec").Child("context"), []kyvernov1.ContextEntry{{
+		Name:          "image",
+		ImageRegistry: &kyvernov1.ImageRegistry{Reference: "nginx"},
+	}})
+	assert.Assert(t, len(errs) == 1)
+	assert.Equal(t, errs[0].Detail, "ImageRegistry is not allowed in CleanUp Policy")
+}
+
 func Test_ClusterCleanupPolicy_Name(t *testing.T) {
 	subject := ClusterCleanupPolicy{
 		ObjectMeta: metav1.ObjectMeta{
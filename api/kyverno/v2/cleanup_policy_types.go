@@ -77,7 +77,11 @@ func (p *CleanupPolicy) GetNextExecutionTime(time time.Time) (*time.Time, error)
 	if err != nil {
 		return nil, err
 	}
-	nextExecutionTime := cronExpr.Next(time)
+	location, err := p.Spec.GetLocation()
+	if err != nil {
+		return nil, err
+	}
+	nextExecutionTime := cronExpr.Next(time.In(location))
 	return &nextExecutionTime, nil
 }
 
@@ -163,7 +167,11 @@ func (p *ClusterCleanupPolicy) GetNextExecutionTime(time time.Time) (*time.Time,
 	if err != nil {
 		return nil, err
 	}
-	nextExecutionTime := cronExpr.Next(time)
+	location, err := p.Spec.GetLocation()
+	if err != nil {
+		return nil, err
+	}
+	nextExecutionTime := cronExpr.Next(time.In(location))
 	return &nextExecutionTime, nil
 }
 
@@ -221,6 +229,34 @@ type CleanupPolicySpec struct {
 	// The schedule in Cron format
 	Schedule string `json:"schedule"`
 
+	// TimeZone is the IANA name of the time zone the schedule is interpreted in (e.g. Europe/Paris).
+	// Defaults to the time zone of the cleanup controller.
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
+
+	// DryRun evaluates the policy on schedule and emits an event for every resource that
+	// would be deleted, without deleting them.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// MaxDeletions is the maximum number of resources deleted in a single run, the remaining
+	// resources are deleted in the next runs. Unlimited when not set.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxDeletions *int `json:"maxDeletions,omitempty"`
+
+	// DeletionDelay is the time waited between two deletions, expressed as a duration string
+	// such as "300ms" or "1s". Resources are deleted without delay when not set.
+	// +kubebuilder:validation:Format=duration
+	// +optional
+	DeletionDelay *metav1.Duration `json:"deletionDelay,omitempty"`
+
+	// DeletionPropagationPolicy is the propagation policy used to delete the resources.
+	// Defaults to the propagation policy of the resource kind.
+	// +kubebuilder:validation:Enum=Foreground;Background;Orphan
+	// +optional
+	DeletionPropagationPolicy *metav1.DeletionPropagation `json:"deletionPropagationPolicy,omitempty"`
+
 	// Conditions defines the conditions used to select the resources which will be cleaned up.
 	// +optional
 	Conditions *AnyAllConditions `json:"conditions,omitempty"`
@@ -237,6 +273,13 @@ func (p *CleanupPolicySpec) Validate(path *field.Path, clusterResources sets.Set
 	// Write context validation code here by following other validations.
 	errs = append(errs, ValidateContext(path.Child("context"), p.Context)...)
 	errs = append(errs, ValidateSchedule(path.Child("schedule"), p.Schedule)...)
+	errs = append(errs, ValidateTimeZone(path.Child("timeZone"), p.TimeZone)...)
+	if p.MaxDeletions != nil && *p.MaxDeletions < 1 {
+		errs = append(errs, field.Invalid(path.Child("maxDeletions"), *p.MaxDeletions, "maxDeletions must be greater than 0"))
+	}
+	if p.DeletionDelay != nil && p.DeletionDelay.Duration < 0 {
+		errs = append(errs, field.Invalid(path.Child("deletionDelay"), p.DeletionDelay.Duration.String(), "deletionDelay must not be negative"))
+	}
 	if userInfoErrs := p.MatchResources.ValidateNoUserInfo(path.Child("match")); len(userInfoErrs) != 0 {
 		errs = append(errs, userInfoErrs...)
 	} else {
@@ -257,8 +300,6 @@ func ValidateContext(path *field.Path, context []kyvernov1.ContextEntry) (errs f
 	for _, entry := range context {
 		if entry.ImageRegistry != nil {
 			errs = append(errs, field.Invalid(path, context, "ImageRegistry is not allowed in CleanUp Policy"))
-		} else if entry.ConfigMap != nil {
-			errs = append(errs, field.Invalid(path, context, "ConfigMap is not allowed in CleanUp Policy"))
 		}
 	}
 	return errs
@@ -272,6 +313,27 @@ func ValidateSchedule(path *field.Path, schedule string) (errs field.ErrorList)
 	return errs
 }
 
+// ValidateTimeZone validates whether the time zone specified is a known IANA time zone or not.
+func ValidateTimeZone(path *field.Path, timeZone *string) (errs field.ErrorList) {
+	if timeZone == nil {
+		return errs
+	}
+	if *timeZone == "" {
+		errs = append(errs, field.Invalid(path, *timeZone, "timeZone must be nil or a non-empty string"))
+	} else if _, err := time.LoadLocation(*timeZone); err != nil {
+		errs = append(errs, field.Invalid(path, *timeZone, "unknown time zone"))
+	}
+	return errs
+}
+
+// GetLocation returns the location the schedule is interpreted in
+func (p *CleanupPolicySpec) GetLocation() (*time.Location, error) {
+	if p.TimeZone == nil {
+		return time.Local, nil
+	}
+	return time.LoadLocation(*p.TimeZone)
+}
+
 // ValidateMatchExcludeConflict checks if the resultant of match and exclude block is not an empty set
 func (spec *CleanupPolicySpec) ValidateMatchExcludeConflict(path *field.Path) (errs field.ErrorList) {
 	if spec.ExcludeResources == nil || len(spec.ExcludeResources.All) > 0 || len(spec.MatchResources.All) > 0 {
@@ -19,6 +19,8 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
 	"github.com/kyverno/kyverno/ext/wildcard"
+	celutils "github.com/kyverno/kyverno/pkg/utils/cel"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -58,6 +60,11 @@ func (p *PolicyException) HasPodSecurity() bool {
 	return len(p.Spec.PodSecurity) > 0
 }
 
+// HasImages checks if the exception is restricted to image references
+func (p *PolicyException) HasImages() bool {
+	return len(p.Spec.Images) > 0
+}
+
 // PolicyExceptionSpec stores policy exception spec
 type PolicyExceptionSpec struct {
 	// Background controls if exceptions are applied to existing policies during a background scan.
@@ -73,6 +80,12 @@ type PolicyExceptionSpec struct {
 	// +optional
 	Conditions *AnyAllConditions `json:"conditions,omitempty"`
 
+	// CELConditions are used to determine if a resource applies to the exception by evaluating a
+	// set of CEL expressions, in addition to the conditions written as JMESPath conditions.
+	// The expressions have access to the object, oldObject, request and authorizer variables.
+	// +optional
+	CELConditions []admissionregistrationv1beta1.MatchCondition `json:"celConditions,omitempty"`
+
 	// Exceptions is a list policy/rules to be excluded
 	Exceptions []Exception `json:"exceptions"`
 
@@ -80,6 +93,12 @@ type PolicyExceptionSpec struct {
 	// Applicable only to policies that have validate.podSecurity subrule.
 	// +optional
 	PodSecurity []kyvernov1.PodSecurityStandard `json:"podSecurity,omitempty"`
+
+	// Images restricts the exception to the image references matching one of the given patterns.
+	// Wildcards ('*' and '?') are allowed. When set, only the matching images are excluded from the
+	// verification performed by verifyImages rules, the rest of the resource is still processed.
+	// +optional
+	Images []string `json:"images,omitempty"`
 }
 
 func (p *PolicyExceptionSpec) BackgroundProcessingEnabled() bool {
@@ -106,6 +125,17 @@ func (p *PolicyExceptionSpec) Validate(path *field.Path) (errs field.ErrorList)
 	for i, p := range p.PodSecurity {
 		errs = append(errs, p.Validate(podSecuityPath.Index(i))...)
 	}
+	imagesPath := path.Child("images")
+	for i, image := range p.Images {
+		if image == "" {
+			errs = append(errs, field.Required(imagesPath.Index(i), "An image reference must not be empty"))
+		}
+	}
+	if len(p.CELConditions) > 0 {
+		if _, err := celutils.CompileMatchConditions(p.CELConditions); err != nil {
+			errs = append(errs, field.Invalid(path.Child("celConditions"), p.CELConditions, err.Error()))
+		}
+	}
 	return errs
 }
 
@@ -169,6 +169,9 @@ const (
 
 	// Skip - the Update Request Controller skips to generate the resource.
 	Skip UpdateRequestState = "Skip"
+
+	// DeadLetter - the Update Request Controller gave up processing the request after exhausting its retries.
+	DeadLetter UpdateRequestState = "DeadLetter"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -1,6 +1,9 @@
 package v2alpha1
 
 import (
+	"fmt"
+	"time"
+
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -8,6 +11,8 @@ import (
 const (
 	// PolicyConditionReady means that the globalcontextentry is ready
 	GlobalContextEntryConditionReady = "Ready"
+	// GlobalContextEntryConditionStale means that the last refresh of the globalcontextentry failed
+	GlobalContextEntryConditionStale = "Stale"
 )
 
 const (
@@ -15,6 +20,10 @@ const (
 	GlobalContextEntryReasonSucceeded = "Succeeded"
 	// GlobalContextEntryReasonFailed is the reason set when the globalcontextentry is not ready
 	GlobalContextEntryReasonFailed = "Failed"
+	// GlobalContextEntryReasonRefreshed is the reason set when the globalcontextentry data was refreshed
+	GlobalContextEntryReasonRefreshed = "Refreshed"
+	// GlobalContextEntryReasonRefreshFailed is the reason set when the globalcontextentry data failed to refresh
+	GlobalContextEntryReasonRefreshFailed = "RefreshFailed"
 )
 
 type GlobalContextEntryStatus struct {
@@ -25,6 +34,18 @@ type GlobalContextEntryStatus struct {
 	// Indicates the time when the globalcontextentry was last refreshed successfully for the API Call
 	// +optional
 	LastRefreshTime metav1.Time `json:"lastRefreshTime,omitempty"`
+	// Indicates the number of items held by the globalcontextentry
+	// +optional
+	ItemCount int `json:"itemCount,omitempty"`
+	// Indicates the approximate size in bytes of the data held by the globalcontextentry
+	// +optional
+	Size int64 `json:"size,omitempty"`
+	// Indicates the duration of the last successful refresh of the globalcontextentry
+	// +optional
+	LastRefreshDuration *metav1.Duration `json:"lastRefreshDuration,omitempty"`
+	// Indicates the number of refreshes which failed since the last successful refresh
+	// +optional
+	RefreshErrorCount int `json:"refreshErrorCount,omitempty"`
 }
 
 func (status *GlobalContextEntryStatus) SetReady(ready bool, message string) {
@@ -47,6 +68,40 @@ func (status *GlobalContextEntryStatus) UpdateRefreshTime() {
 	status.LastRefreshTime = metav1.Now()
 }
 
+// SetRefreshed records a successful refresh of the globalcontextentry data
+func (status *GlobalContextEntryStatus) SetRefreshed(duration time.Duration) {
+	status.UpdateRefreshTime()
+	status.LastRefreshDuration = &metav1.Duration{Duration: duration}
+	status.RefreshErrorCount = 0
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:   GlobalContextEntryConditionStale,
+		Status: metav1.ConditionFalse,
+		Reason: GlobalContextEntryReasonRefreshed,
+	})
+}
+
+// SetRefreshFailed records a failed refresh, the globalcontextentry is stale until it is refreshed again
+func (status *GlobalContextEntryStatus) SetRefreshFailed(message string) {
+	status.RefreshErrorCount++
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:    GlobalContextEntryConditionStale,
+		Status:  metav1.ConditionTrue,
+		Reason:  GlobalContextEntryReasonRefreshFailed,
+		Message: fmt.Sprintf("%s (%d consecutive failures)", message, status.RefreshErrorCount),
+	})
+}
+
+// IsStale indicates if the last refresh of the globalcontextentry failed
+func (status *GlobalContextEntryStatus) IsStale() bool {
+	condition := meta.FindStatusCondition(status.Conditions, GlobalContextEntryConditionStale)
+	return condition != nil && condition.Status == metav1.ConditionTrue
+}
+
+func (status *GlobalContextEntryStatus) SetSize(itemCount int, size int64) {
+	status.ItemCount = itemCount
+	status.Size = size
+}
+
 // IsReady indicates if the globalcontextentry has loaded
 func (status *GlobalContextEntryStatus) IsReady() bool {
 	condition := meta.FindStatusCondition(status.Conditions, GlobalContextEntryConditionReady)
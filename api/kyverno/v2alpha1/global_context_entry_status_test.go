@@ -0,0 +1,28 @@
+package v2alpha1
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGlobalContextEntryStatusRefresh(t *testing.T) {
+	var status GlobalContextEntryStatus
+	if status.IsStale() {
+		t.Errorf("GlobalContextEntryStatus.IsStale() = true for a new entry")
+	}
+	status.SetRefreshFailed("connection refused")
+	status.SetRefreshFailed("connection refused")
+	if !status.IsStale() || status.RefreshErrorCount != 2 {
+		t.Errorf("GlobalContextEntryStatus after two failures: stale = %v, errors = %d", status.IsStale(), status.RefreshErrorCount)
+	}
+	status.SetRefreshed(time.Second)
+	if status.IsStale() || status.RefreshErrorCount != 0 {
+		t.Errorf("GlobalContextEntryStatus after a refresh: stale = %v, errors = %d", status.IsStale(), status.RefreshErrorCount)
+	}
+	if status.LastRefreshDuration == nil || status.LastRefreshDuration.Duration != time.Second {
+		t.Errorf("GlobalContextEntryStatus.LastRefreshDuration = %v, want 1s", status.LastRefreshDuration)
+	}
+	if status.LastRefreshTime.IsZero() {
+		t.Errorf("GlobalContextEntryStatus.LastRefreshTime is not set")
+	}
+}
@@ -16,6 +16,7 @@ limitations under the License.
 package v2alpha1
 
 import (
+	"net/url"
 	"time"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
@@ -33,6 +34,8 @@ import (
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="REFRESH INTERVAL",type="string",JSONPath=".spec.apiCall.refreshInterval"
 // +kubebuilder:printcolumn:name="LAST REFRESH",type="date",JSONPath=".status.lastRefreshTime"
+// +kubebuilder:printcolumn:name="STALE",type=string,JSONPath=`.status.conditions[?(@.type == "Stale")].status`,priority=1
+// +kubebuilder:printcolumn:name="SIZE",type=integer,JSONPath=".status.size",priority=1
 
 // GlobalContextEntry declares resources to be cached.
 type GlobalContextEntry struct {
@@ -56,20 +59,34 @@ func (c *GlobalContextEntry) Validate() (errs field.ErrorList) {
 // GlobalContextEntrySpec stores policy exception spec
 // +kubebuilder:oneOf:={required:{kubernetesResource}}
 // +kubebuilder:oneOf:={required:{apiCall}}
+// +kubebuilder:oneOf:={required:{httpCall}}
 type GlobalContextEntrySpec struct {
 	// Stores a list of Kubernetes resources which will be cached.
-	// Mutually exclusive with APICall.
+	// Mutually exclusive with APICall and HTTPCall.
 	// +kubebuilder:validation:Optional
 	KubernetesResource *KubernetesResource `json:"kubernetesResource,omitempty"`
 
 	// Stores results from an API call which will be cached.
-	// Mutually exclusive with KubernetesResource.
+	// Mutually exclusive with KubernetesResource and HTTPCall.
 	// This can be used to make calls to external (non-Kubernetes API server) services.
 	// It can also be used to make calls to the Kubernetes API server in such cases:
 	// 1. A POST is needed to create a resource.
 	// 2. Finer-grained control is needed. Example: To restrict the number of resources cached.
 	// +kubebuilder:validation:Optional
 	APICall *ExternalAPICall `json:"apiCall,omitempty"`
+
+	// Stores the response of an HTTP endpoint which will be polled and cached.
+	// Mutually exclusive with KubernetesResource and APICall.
+	// This can be used to cache external allowlists or feeds referenced by policies.
+	// +kubebuilder:validation:Optional
+	HTTPCall *HTTPCall `json:"httpCall,omitempty"`
+
+	// Projection is a JMESPath expression applied to the data before it is stored.
+	// For Kubernetes resources it is applied to every resource, for API calls it is applied to the response.
+	// It can be used to only hold the needed fields of large resource lists.
+	// +kubebuilder:validation:Optional
+	// +optional
+	Projection string `json:"projection,omitempty"`
 }
 
 func (c *GlobalContextEntrySpec) IsAPICall() bool {
@@ -80,13 +97,20 @@ func (c *GlobalContextEntrySpec) IsResource() bool {
 	return c.KubernetesResource != nil
 }
 
+func (c *GlobalContextEntrySpec) IsHTTPCall() bool {
+	return c.HTTPCall != nil
+}
+
 // Validate implements programmatic validation
 func (c *GlobalContextEntrySpec) Validate(path *field.Path) (errs field.ErrorList) {
-	if c.IsResource() && c.IsAPICall() {
-		errs = append(errs, field.Forbidden(path.Child("kubernetesResource"), "A global context entry should either have KubernetesResource or APICall"))
+	sources := 0
+	for _, set := range []bool{c.IsResource(), c.IsAPICall(), c.IsHTTPCall()} {
+		if set {
+			sources++
+		}
 	}
-	if !c.IsResource() && !c.IsAPICall() {
-		errs = append(errs, field.Forbidden(path.Child("kubernetesResource"), "A global context entry should either have KubernetesResource or APICall"))
+	if sources != 1 {
+		errs = append(errs, field.Forbidden(path.Child("kubernetesResource"), "A global context entry should either have KubernetesResource, APICall or HTTPCall"))
 	}
 	if c.IsResource() {
 		errs = append(errs, c.KubernetesResource.Validate(path.Child("resource"))...)
@@ -94,6 +118,9 @@ func (c *GlobalContextEntrySpec) Validate(path *field.Path) (errs field.ErrorLis
 	if c.IsAPICall() {
 		errs = append(errs, c.APICall.Validate(path.Child("apiCall"))...)
 	}
+	if c.IsHTTPCall() {
+		errs = append(errs, c.HTTPCall.Validate(path.Child("httpCall"))...)
+	}
 	return errs
 }
 
@@ -124,6 +151,20 @@ type KubernetesResource struct {
 	// +kubebuilder:validation:Optional
 	// +optional
 	Namespace string `json:"namespace,omitempty"`
+	// MaxItems defines the maximum number of resources that can be cached.
+	// When the limit is exceeded the entry is marked as not ready and no data is served.
+	// Leave empty or set to 0 for no limit.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	// +optional
+	MaxItems int `json:"maxItems,omitempty"`
+	// ResyncPeriod defines the interval at which the cached resources are re-processed from the watch cache,
+	// the data is kept up to date by watching the resources and a resync doesn't list them again.
+	// Leave empty or set to 0 to disable resyncs.
+	// +kubebuilder:validation:Format=duration
+	// +kubebuilder:validation:Optional
+	// +optional
+	ResyncPeriod *metav1.Duration `json:"resyncPeriod,omitempty"`
 }
 
 // Validate implements programmatic validation
@@ -138,6 +179,12 @@ func (k *KubernetesResource) Validate(path *field.Path) (errs field.ErrorList) {
 	if k.Resource == "" {
 		errs = append(errs, field.Required(path.Child("resource"), "A Resource entry requires a resource"))
 	}
+	if k.MaxItems < 0 {
+		errs = append(errs, field.Invalid(path.Child("maxItems"), k.MaxItems, "A Resource entry requires a positive max items"))
+	}
+	if k.ResyncPeriod != nil && k.ResyncPeriod.Duration < 0 {
+		errs = append(errs, field.Invalid(path.Child("resyncPeriod"), k.ResyncPeriod.Duration.String(), "A Resource entry requires a positive resync period"))
+	}
 	return errs
 }
 
@@ -170,3 +217,88 @@ func (e *ExternalAPICall) Validate(path *field.Path) (errs field.ErrorList) {
 	}
 	return errs
 }
+
+// HTTPCall stores infos about an HTTP endpoint that should be polled and cached
+type HTTPCall struct {
+	// URL is the URL of the HTTP endpoint.
+	// +kubebuilder:validation:Required
+	URL string `json:"url"`
+	// Method is the HTTP request type (GET or POST). Defaults to GET.
+	// +kubebuilder:default=GET
+	// +kubebuilder:validation:Optional
+	Method kyvernov1.Method `json:"method,omitempty"`
+	// Data specifies the POST data sent to the endpoint.
+	// +kubebuilder:validation:Optional
+	// +optional
+	Data []kyvernov1.RequestData `json:"data,omitempty"`
+	// Headers is a list of optional HTTP headers to be included in the request.
+	// +kubebuilder:validation:Optional
+	// +optional
+	Headers []kyvernov1.HTTPHeader `json:"headers,omitempty"`
+	// CABundle is a PEM encoded CA bundle which will be used to validate
+	// the server certificate.
+	// +kubebuilder:validation:Optional
+	// +optional
+	CABundle string `json:"caBundle,omitempty"`
+	// Auth references a secret in the Kyverno namespace holding the credentials sent to the endpoint.
+	// A token key is sent as a bearer token, tls.crt and tls.key keys are used as a client certificate.
+	// +kubebuilder:validation:Optional
+	// +optional
+	Auth *kyvernov1.ServiceCallAuth `json:"auth,omitempty"`
+	// RefreshInterval defines the interval in duration at which to poll the endpoint.
+	// +kubebuilder:validation:Format=duration
+	// +kubebuilder:default=`10m`
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+	// Jitter defines the maximum random delay added to the refresh interval, it spreads
+	// the requests of the Kyverno replicas polling the same endpoint.
+	// +kubebuilder:validation:Format=duration
+	// +kubebuilder:validation:Optional
+	// +optional
+	Jitter *metav1.Duration `json:"jitter,omitempty"`
+	// RetryLimit defines the number of times the request should be retried in case of failure.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Optional
+	// +optional
+	RetryLimit int `json:"retryLimit,omitempty"`
+}
+
+// APICall returns the service API call polled by the entry
+func (h *HTTPCall) APICall() kyvernov1.APICall {
+	method := h.Method
+	if method == "" {
+		method = "GET"
+	}
+	return kyvernov1.APICall{
+		Method: method,
+		Data:   h.Data,
+		Service: &kyvernov1.ServiceCall{
+			URL:      h.URL,
+			Headers:  h.Headers,
+			CABundle: h.CABundle,
+			Auth:     h.Auth,
+		},
+	}
+}
+
+// Validate implements programmatic validation
+func (h *HTTPCall) Validate(path *field.Path) (errs field.ErrorList) {
+	if h.URL == "" {
+		errs = append(errs, field.Required(path.Child("url"), "An HTTP call requires a url"))
+	} else if u, err := url.Parse(h.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, field.Invalid(path.Child("url"), h.URL, "An HTTP call requires an http or https url"))
+	}
+	if h.RefreshInterval == nil || h.RefreshInterval.Duration <= 0 {
+		errs = append(errs, field.Required(path.Child("refreshInterval"), "An HTTP call requires a refresh interval greater than 0 seconds"))
+	}
+	if h.Jitter != nil && h.Jitter.Duration < 0 {
+		errs = append(errs, field.Invalid(path.Child("jitter"), h.Jitter.Duration.String(), "An HTTP call requires a positive jitter"))
+	}
+	if h.Data != nil && h.Method != "POST" {
+		errs = append(errs, field.Forbidden(path.Child("method"), "An HTTP call with data should have method as POST"))
+	}
+	if h.Auth != nil && h.Auth.SecretName == "" {
+		errs = append(errs, field.Required(path.Child("auth", "secretName"), "An HTTP call auth requires a secret name"))
+	}
+	return errs
+}
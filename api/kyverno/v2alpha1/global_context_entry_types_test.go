@@ -75,6 +75,32 @@ func TestGlobalContextEntrySpecValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid HTTPCall",
+			spec: GlobalContextEntrySpec{
+				HTTPCall: &HTTPCall{
+					URL:             "https://example.com/allowlist.json",
+					RefreshInterval: &metav1.Duration{Duration: 10 * time.Minute},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "both APICall and HTTPCall",
+			spec: GlobalContextEntrySpec{
+				APICall: &ExternalAPICall{
+					APICall: kyvernov1.APICall{
+						URLPath: "/api/v1/namespaces",
+					},
+					RefreshInterval: &metav1.Duration{Duration: 10 * time.Minute},
+				},
+				HTTPCall: &HTTPCall{
+					URL:             "https://example.com/allowlist.json",
+					RefreshInterval: &metav1.Duration{Duration: 10 * time.Minute},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name:    "neither KubernetesResource nor APICall",
 			spec:    GlobalContextEntrySpec{},
@@ -142,6 +168,36 @@ func TestKubernetesResourceValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "negative max items",
+			resource: KubernetesResource{
+				Group:    "apps",
+				Version:  "v1",
+				Resource: "deployments",
+				MaxItems: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "resync period",
+			resource: KubernetesResource{
+				Group:        "apps",
+				Version:      "v1",
+				Resource:     "deployments",
+				ResyncPeriod: &metav1.Duration{Duration: time.Hour},
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative resync period",
+			resource: KubernetesResource{
+				Group:        "apps",
+				Version:      "v1",
+				Resource:     "deployments",
+				ResyncPeriod: &metav1.Duration{Duration: -time.Hour},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -235,6 +291,87 @@ func TestExternalAPICallValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPCallValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		httpCall HTTPCall
+		wantErr  bool
+	}{
+		{
+			name: "valid HTTPCall",
+			httpCall: HTTPCall{
+				URL:             "https://example.com/allowlist.json",
+				Auth:            &kyvernov1.ServiceCallAuth{SecretName: "feed-credentials"},
+				RefreshInterval: &metav1.Duration{Duration: 10 * time.Minute},
+				Jitter:          &metav1.Duration{Duration: time.Minute},
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing URL",
+			httpCall: HTTPCall{
+				RefreshInterval: &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid URL scheme",
+			httpCall: HTTPCall{
+				URL:             "ftp://example.com/allowlist.json",
+				RefreshInterval: &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing RefreshInterval",
+			httpCall: HTTPCall{
+				URL: "https://example.com/allowlist.json",
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative Jitter",
+			httpCall: HTTPCall{
+				URL:             "https://example.com/allowlist.json",
+				RefreshInterval: &metav1.Duration{Duration: 10 * time.Minute},
+				Jitter:          &metav1.Duration{Duration: -time.Minute},
+			},
+			wantErr: true,
+		},
+		{
+			name: "non-POST method with data",
+			httpCall: HTTPCall{
+				URL:    "https://example.com/allowlist.json",
+				Method: "GET",
+				Data: []kyvernov1.RequestData{
+					{Key: "example-key", Value: &apiextv1.JSON{Raw: []byte(`{"field": "value"}`)}},
+				},
+				RefreshInterval: &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			wantErr: true,
+		},
+		{
+			name: "auth without secret name",
+			httpCall: HTTPCall{
+				URL:             "https://example.com/allowlist.json",
+				Auth:            &kyvernov1.ServiceCallAuth{},
+				RefreshInterval: &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.httpCall.Validate(field.NewPath("httpCall"))
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("HTTPCall.Validate() error = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
 func generateRandomVersion() string {
 	rand.NewSource(time.Now().UnixNano())
 	for {
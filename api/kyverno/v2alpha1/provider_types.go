@@ -0,0 +1,161 @@
+package v2alpha1
+
+import (
+	"crypto/x509"
+	"net/url"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:categories=kyverno,scope="Cluster"
+// +kubebuilder:printcolumn:name="PROTOCOL",type=string,JSONPath=".spec.protocol"
+// +kubebuilder:printcolumn:name="URL",type=string,JSONPath=".spec.url"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Provider declares an external data provider queried by externalData context entries.
+type Provider struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec declares the provider endpoint and behaviors.
+	Spec ProviderSpec `json:"spec"`
+}
+
+// Validate implements programmatic validation
+func (p *Provider) Validate() (errs field.ErrorList) {
+	errs = append(errs, p.Spec.Validate(field.NewPath("spec"))...)
+	return errs
+}
+
+// ProviderProtocol defines the protocol used to query a provider.
+// +kubebuilder:validation:Enum=HTTP;GRPC
+type ProviderProtocol string
+
+const (
+	// ProviderProtocolHTTP posts JSON requests to the provider URL
+	ProviderProtocolHTTP ProviderProtocol = "HTTP"
+	// ProviderProtocolGRPC performs unary calls to the provider Query method
+	ProviderProtocolGRPC ProviderProtocol = "GRPC"
+)
+
+// ProviderSpec stores the provider spec
+type ProviderSpec struct {
+	// URL is the provider endpoint.
+	// For the HTTP protocol it is the https URL requests are posted to, for example https://my-provider.my-namespace:8443/query.
+	// For the GRPC protocol it is the server address, for example my-provider.my-namespace:9090.
+	URL string `json:"url"`
+
+	// Protocol is the protocol used to query the provider, either HTTP or GRPC.
+	// +kubebuilder:default=HTTP
+	// +kubebuilder:validation:Optional
+	// +optional
+	Protocol ProviderProtocol `json:"protocol,omitempty"`
+
+	// CABundle is a PEM encoded CA bundle used to validate the provider server certificate.
+	// +kubebuilder:validation:Optional
+	// +optional
+	CABundle string `json:"caBundle,omitempty"`
+
+	// TLSSecret references a secret holding the client certificate and key (tls.crt and tls.key)
+	// used for mutual TLS.
+	// +kubebuilder:validation:Optional
+	// +optional
+	TLSSecret *kyvernov1.SecretReference `json:"tlsSecret,omitempty"`
+
+	// Timeout is the maximum duration of a provider query.
+	// +kubebuilder:validation:Format=duration
+	// +kubebuilder:default=`3s`
+	// +kubebuilder:validation:Optional
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// CacheTTL defines how long the values returned by the provider are cached.
+	// Leave empty or set to 0 to disable caching.
+	// +kubebuilder:validation:Format=duration
+	// +kubebuilder:validation:Optional
+	// +optional
+	CacheTTL *metav1.Duration `json:"cacheTTL,omitempty"`
+
+	// FailurePolicy defines how errors returned by the provider are handled.
+	// With Ignore, the default value of the context entry is used instead.
+	// Allowed values are Ignore or Fail. Defaults to Fail.
+	// +kubebuilder:validation:Enum=Ignore;Fail
+	// +kubebuilder:validation:Optional
+	// +optional
+	FailurePolicy *kyvernov1.FailurePolicyType `json:"failurePolicy,omitempty"`
+}
+
+// GetProtocol returns the protocol used to query the provider
+func (s *ProviderSpec) GetProtocol() ProviderProtocol {
+	if s.Protocol == "" {
+		return ProviderProtocolHTTP
+	}
+	return s.Protocol
+}
+
+// GetTimeout returns the maximum duration of a provider query
+func (s *ProviderSpec) GetTimeout() time.Duration {
+	if s.Timeout == nil || s.Timeout.Duration <= 0 {
+		return 3 * time.Second
+	}
+	return s.Timeout.Duration
+}
+
+// GetCacheTTL returns how long provider values are cached, zero means no caching
+func (s *ProviderSpec) GetCacheTTL() time.Duration {
+	if s.CacheTTL == nil {
+		return 0
+	}
+	return s.CacheTTL.Duration
+}
+
+// GetFailurePolicy returns the failure policy of the provider
+func (s *ProviderSpec) GetFailurePolicy() kyvernov1.FailurePolicyType {
+	if s.FailurePolicy == nil {
+		return kyvernov1.Fail
+	}
+	return *s.FailurePolicy
+}
+
+// Validate implements programmatic validation
+func (s *ProviderSpec) Validate(path *field.Path) (errs field.ErrorList) {
+	if s.URL == "" {
+		errs = append(errs, field.Required(path.Child("url"), "A provider requires a url"))
+	} else if s.GetProtocol() == ProviderProtocolHTTP {
+		if u, err := url.Parse(s.URL); err != nil {
+			errs = append(errs, field.Invalid(path.Child("url"), s.URL, err.Error()))
+		} else if u.Scheme != "https" {
+			errs = append(errs, field.Invalid(path.Child("url"), s.URL, "An HTTP provider requires an https url"))
+		}
+	}
+	if s.CABundle != "" {
+		if !x509.NewCertPool().AppendCertsFromPEM([]byte(s.CABundle)) {
+			errs = append(errs, field.Invalid(path.Child("caBundle"), "<redacted>", "A provider CA bundle must contain PEM encoded certificates"))
+		}
+	}
+	if s.Timeout != nil && s.Timeout.Duration < 0 {
+		errs = append(errs, field.Invalid(path.Child("timeout"), s.Timeout.Duration.String(), "A provider timeout must be positive"))
+	}
+	if s.CacheTTL != nil && s.CacheTTL.Duration < 0 {
+		errs = append(errs, field.Invalid(path.Child("cacheTTL"), s.CacheTTL.Duration.String(), "A provider cache TTL must be positive"))
+	}
+	return errs
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ProviderList is a list of Provider instances.
+type ProviderList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []Provider `json:"items"`
+}
@@ -0,0 +1,70 @@
+package v2alpha1
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestProviderSpecValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    ProviderSpec
+		wantErr bool
+	}{
+		{
+			name: "valid HTTP provider",
+			spec: ProviderSpec{
+				URL:     "https://scanner.kyverno:8443/query",
+				Timeout: &metav1.Duration{Duration: time.Second},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid GRPC provider",
+			spec: ProviderSpec{
+				URL:      "scanner.kyverno:9090",
+				Protocol: ProviderProtocolGRPC,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "missing url",
+			spec:    ProviderSpec{},
+			wantErr: true,
+		},
+		{
+			name: "plain http url",
+			spec: ProviderSpec{
+				URL: "http://scanner.kyverno:8080/query",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid CA bundle",
+			spec: ProviderSpec{
+				URL:      "https://scanner.kyverno:8443/query",
+				CABundle: "not a certificate",
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative cache TTL",
+			spec: ProviderSpec{
+				URL:      "https://scanner.kyverno:8443/query",
+				CacheTTL: &metav1.Duration{Duration: -time.Minute},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.spec.Validate(field.NewPath("spec"))
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("ProviderSpec.Validate() error = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
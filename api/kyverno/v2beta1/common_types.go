@@ -63,10 +63,14 @@ type Validation struct {
 	// Assert defines a kyverno-json assertion tree.
 	// +optional
 	Assert AssertionTree `json:"assert"`
+
+	// Wasm evaluates a WebAssembly module to validate the resource.
+	// +optional
+	Wasm *kyvernov1.Wasm `json:"wasm,omitempty"`
 }
 
 // ConditionOperator is the operation performed on condition key and value.
-// +kubebuilder:validation:Enum=Equals;NotEquals;AnyIn;AllIn;AnyNotIn;AllNotIn;GreaterThanOrEquals;GreaterThan;LessThanOrEquals;LessThan;DurationGreaterThanOrEquals;DurationGreaterThan;DurationLessThanOrEquals;DurationLessThan
+// +kubebuilder:validation:Enum=Equals;NotEquals;AnyIn;AllIn;AnyNotIn;AllNotIn;GreaterThanOrEquals;GreaterThan;LessThanOrEquals;LessThan;DurationGreaterThanOrEquals;DurationGreaterThan;DurationLessThanOrEquals;DurationLessThan;SemverSatisfies;InTimeWindow
 type ConditionOperator string
 
 // ConditionOperators stores all the valid ConditionOperator types as key-value pairs.
@@ -84,6 +88,8 @@ type ConditionOperator string
 // "DurationGreaterThan" evaluates if the key (duration) is greater than the value (duration)
 // "DurationLessThanOrEquals" evaluates if the key (duration) is less than or equal to the value (duration)
 // "DurationLessThan" evaluates if the key (duration) is greater than the value (duration)
+// "SemverSatisfies" evaluates if the key (semantic version) satisfies the value (semantic version range)
+// "InTimeWindow" evaluates if the key (RFC3339 time) falls within the value (cron or weekday window, or list of windows)
 var ConditionOperators = map[string]ConditionOperator{
 	"Equals":                      ConditionOperator("Equals"),
 	"NotEquals":                   ConditionOperator("NotEquals"),
@@ -99,6 +105,8 @@ var ConditionOperators = map[string]ConditionOperator{
 	"DurationGreaterThan":         ConditionOperator("DurationGreaterThan"),
 	"DurationLessThanOrEquals":    ConditionOperator("DurationLessThanOrEquals"),
 	"DurationLessThan":            ConditionOperator("DurationLessThan"),
+	"SemverSatisfies":             ConditionOperator("SemverSatisfies"),
+	"InTimeWindow":                ConditionOperator("InTimeWindow"),
 }
 
 // Deny specifies a list of conditions used to pass or fail a validation rule.
@@ -117,7 +125,7 @@ type Condition struct {
 	// Operator is the conditional operation to perform. Valid operators are:
 	// Equals, NotEquals, In, AnyIn, AllIn, NotIn, AnyNotIn, AllNotIn, GreaterThanOrEquals,
 	// GreaterThan, LessThanOrEquals, LessThan, DurationGreaterThanOrEquals, DurationGreaterThan,
-	// DurationLessThanOrEquals, DurationLessThan
+	// DurationLessThanOrEquals, DurationLessThan, SemverSatisfies, InTimeWindow
 	Operator ConditionOperator `json:"operator,omitempty"`
 
 	// Value is the conditional value, or set of values. The values can be fixed set
@@ -114,5 +114,15 @@ func (iv *ImageVerification) Validate(isAuditFailureAction bool, path *field.Pat
 		}
 	}
 
+	if iv.Type != kyvernov1.Notary {
+		for _, attestorSet := range iv.Attestors {
+			for _, attestor := range attestorSet.Entries {
+				if attestor.Notary != nil {
+					errs = append(errs, field.Invalid(attestorsPath, iv, "Notary field is only allowed for type notary"))
+				}
+			}
+		}
+	}
+
 	return errs
 }
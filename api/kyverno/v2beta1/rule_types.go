@@ -46,7 +46,7 @@ type Rule struct {
 	RawAnyAllConditions *AnyAllConditions `json:"preconditions,omitempty"`
 
 	// CELPreconditions are used to determine if a policy rule should be applied by evaluating a
-	// set of CEL conditions. It can only be used with the validate.cel subrule
+	// set of CEL conditions, in addition to preconditions written as JMESPath conditions
 	// +optional
 	CELPreconditions []admissionregistrationv1.MatchCondition `json:"celPreconditions,omitempty"`
 
@@ -16,6 +16,11 @@ type Spec struct {
 	// each rule can validate, mutate, or generate resources.
 	Rules []Rule `json:"rules,omitempty"`
 
+	// Variables defines named values and data sources shared by all the rules of the policy.
+	// They are loaded once per policy evaluation, before the context of the rules.
+	// +optional
+	Variables []kyvernov1.ContextEntry `json:"variables,omitempty"`
+
 	// ApplyRules controls how rules in a policy are applied. Rule are processed in
 	// the order of declaration. When set to `One` processing stops after a rule has
 	// been applied i.e. the rule matches and results in a pass, fail, or error. When
@@ -76,6 +81,20 @@ type Spec struct {
 	// +optional
 	UseServerSideApply bool `json:"useServerSideApply,omitempty"`
 
+	// DryRun controls whether mutateExisting and generate rules only compute their side effects.
+	// If is set to "true" no update requests are created, the resources that would be mutated or
+	// generated are reported through events instead.
+	// Defaults to "false" if not specified.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// WatchCloneSources controls whether the changes to the ConfigMaps and Secrets cloned by the generate rules
+	// with synchronization enabled are propagated to the downstream resources as soon as they are observed.
+	// Requires the background controller to run with the source watch enabled.
+	// Defaults to "false" if not specified.
+	// +optional
+	WatchCloneSources bool `json:"watchCloneSources,omitempty"`
+
 	// WebhookConfiguration specifies the custom configuration for Kubernetes admission webhookconfiguration.
 	// +optional
 	WebhookConfiguration *kyvernov1.WebhookConfiguration `json:"webhookConfiguration,omitempty"`
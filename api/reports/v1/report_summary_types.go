@@ -0,0 +1,97 @@
+/*
+Copyright 2020 The Kubernetes authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ReportSummarySpec struct {
+	// Reports is the number of summarized policy reports
+	Reports int `json:"reports"`
+
+	// LastScanTime is the most recent timestamp of the summarized results
+	// +optional
+	LastScanTime *metav1.Time `json:"lastScanTime,omitempty"`
+
+	// Summary provides the results count of the namespace
+	// +optional
+	Summary policyreportv1alpha2.PolicyReportSummary `json:"summary,omitempty"`
+
+	// Severities provides the results count by severity
+	// +optional
+	Severities []SeveritySummary `json:"severities,omitempty"`
+
+	// Policies provides the results count by policy
+	// +optional
+	Policies []PolicySummary `json:"policies,omitempty"`
+}
+
+// SeveritySummary provides the results count of a severity
+type SeveritySummary struct {
+	// Severity of the results, empty for results without severity
+	// +optional
+	Severity policyreportv1alpha2.PolicySeverity `json:"severity,omitempty"`
+
+	// Summary provides the results count of the severity
+	Summary policyreportv1alpha2.PolicyReportSummary `json:"summary"`
+}
+
+// PolicySummary provides the results count of a policy
+type PolicySummary struct {
+	// Policy is the name of the policy, namespaced policies are prefixed with their namespace
+	Policy string `json:"policy"`
+
+	// Summary provides the results count of the policy
+	Summary policyreportv1alpha2.PolicyReportSummary `json:"summary"`
+
+	// Severities provides the results count of the policy by severity
+	// +optional
+	Severities []SeveritySummary `json:"severities,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:shortName=rsum,categories=kyverno
+// +kubebuilder:printcolumn:name="Reports",type=integer,JSONPath=".spec.reports"
+// +kubebuilder:printcolumn:name="Pass",type=integer,JSONPath=".spec.summary.pass"
+// +kubebuilder:printcolumn:name="Fail",type=integer,JSONPath=".spec.summary.fail"
+// +kubebuilder:printcolumn:name="Warn",type=integer,JSONPath=".spec.summary.warn"
+// +kubebuilder:printcolumn:name="Error",type=integer,JSONPath=".spec.summary.error"
+// +kubebuilder:printcolumn:name="Skip",type=integer,JSONPath=".spec.summary.skip"
+// +kubebuilder:printcolumn:name="Last Scan",type="date",JSONPath=".spec.lastScanTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ReportSummary is a compact summary of the policy reports of a namespace
+type ReportSummary struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              ReportSummarySpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ReportSummaryList contains a list of ReportSummary
+type ReportSummaryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ReportSummary `json:"items"`
+}
@@ -62,6 +62,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ClusterEphemeralReportList{},
 		&EphemeralReport{},
 		&EphemeralReportList{},
+		&ReportSummary{},
+		&ReportSummaryList{},
 	)
 	// AddToGroupVersion allows the serialization of client types like ListOptions.
 	v1.AddToGroupVersion(scheme, SchemeGroupVersion)
@@ -15,6 +15,15 @@
   {{- with .backPressureThreshold -}}
     {{- $flags = append $flags (print "--maxAdmissionReports=" .) -}}
   {{- end -}}
+  {{- with .batchInterval -}}
+    {{- $flags = append $flags (print "--admissionReportsBatchInterval=" .) -}}
+  {{- end -}}
+  {{- with .batchSize -}}
+    {{- $flags = append $flags (print "--admissionReportsBatchSize=" .) -}}
+  {{- end -}}
+  {{- with .maxQueued -}}
+    {{- $flags = append $flags (print "--admissionReportsMaxQueued=" .) -}}
+  {{- end -}}
 {{- end -}}
 {{- with .aggregateReports -}}
   {{- $flags = append $flags (print "--aggregateReports=" .enabled) -}}
@@ -25,6 +34,9 @@
 {{- with .validatingAdmissionPolicyReports -}}
   {{- $flags = append $flags (print "--validatingAdmissionPolicyReports=" .enabled) -}}
 {{- end -}}
+{{- with .auditSharding -}}
+  {{- $flags = append $flags (print "--auditSharding=" .enabled) -}}
+{{- end -}}
 {{- with .autoUpdateWebhooks -}}
   {{- $flags = append $flags (print "--autoUpdateWebhooks=" .enabled) -}}
 {{- end -}}
@@ -32,6 +44,10 @@
   {{- $flags = append $flags (print "--backgroundScan=" .enabled) -}}
   {{- $flags = append $flags (print "--backgroundScanWorkers=" .backgroundScanWorkers) -}}
   {{- $flags = append $flags (print "--backgroundScanInterval=" .backgroundScanInterval) -}}
+  {{- with .backgroundScanFullResyncInterval -}}
+    {{- $flags = append $flags (print "--backgroundScanFullResyncInterval=" .) -}}
+  {{- end -}}
+  {{- $flags = append $flags (print "--backgroundScanJitter=" .backgroundScanJitter) -}}
   {{- $flags = append $flags (print "--skipResourceFilters=" .skipResourceFilters) -}}
 {{- end -}}
 {{- with .configMapCaching -}}
@@ -40,8 +56,18 @@
 {{- with .deferredLoading -}}
   {{- $flags = append $flags (print "--enableDeferredLoading=" .enabled) -}}
 {{- end -}}
+{{- with .decisionLog -}}
+  {{- if .enabled -}}
+    {{- $flags = append $flags (print "--decisionLogSink=" .sink) -}}
+  {{- end -}}
+{{- end -}}
 {{- with .dumpPayload -}}
   {{- $flags = append $flags (print "--dumpPayload=" .enabled) -}}
+  {{- $flags = append $flags (print "--dumpPayloadSink=" .sink) -}}
+  {{- $flags = append $flags (print "--dumpPayloadSampleRate=" .sampleRate) -}}
+  {{- with .redactFields -}}
+    {{- $flags = append $flags (print "--dumpPayloadRedactFields=" (join "," .)) -}}
+  {{- end -}}
 {{- end -}}
 {{- with .forceFailurePolicyIgnore -}}
   {{- $flags = append $flags (print "--forceFailurePolicyIgnore=" .enabled) -}}
@@ -52,8 +78,17 @@
 {{- with .dumpPatches -}}
   {{- $flags = append $flags (print "--dumpPatches=" .enabled) -}}
 {{- end -}}
+{{- with .generateWebhookMatchConditions -}}
+  {{- $flags = append $flags (print "--generateWebhookMatchConditions=" .enabled) -}}
+{{- end -}}
 {{- with .globalContext -}}
   {{- $flags = append $flags (print "--maxAPICallResponseLength=" (int .maxApiCallResponseLength)) -}}
+  {{- with .snapshot -}}
+    {{- $flags = append $flags (print "--globalContextSnapshot=" .enabled) -}}
+    {{- if .enabled -}}
+      {{- $flags = append $flags (print "--globalContextSnapshotInterval=" .interval) -}}
+    {{- end -}}
+  {{- end -}}
 {{- end -}}
 {{- with .logging -}}
   {{- $flags = append $flags (print "--loggingFormat=" .format) -}}
@@ -93,6 +128,9 @@
   {{- with .rootRaw -}}
     {{- $flags = append $flags (print "--tufRootRaw=" .) -}}
   {{- end -}}
+  {{- with .trustedRoot -}}
+    {{- $flags = append $flags (print "--sigstoreTrustedRoot=" .) -}}
+  {{- end -}}
 {{- end -}}
 {{- with .reporting -}}
   {{- $reportingConfig := list -}}
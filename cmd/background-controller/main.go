@@ -11,11 +11,13 @@ import (
 
 	"github.com/kyverno/kyverno/cmd/internal"
 	"github.com/kyverno/kyverno/pkg/background"
+	"github.com/kyverno/kyverno/pkg/background/mutate"
 	"github.com/kyverno/kyverno/pkg/breaker"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
+	clonesourcecontroller "github.com/kyverno/kyverno/pkg/controllers/clonesource"
 	globalcontextcontroller "github.com/kyverno/kyverno/pkg/controllers/globalcontext"
 	policymetricscontroller "github.com/kyverno/kyverno/pkg/controllers/metrics/policy"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
@@ -23,15 +25,21 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/globalcontext/store"
+	"github.com/kyverno/kyverno/pkg/informers"
 	"github.com/kyverno/kyverno/pkg/leaderelection"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/policy"
+	"github.com/kyverno/kyverno/pkg/sharding"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	"github.com/kyverno/kyverno/pkg/utils/generator"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	corev1 "k8s.io/api/core/v1"
 	apiserver "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeinformers "k8s.io/client-go/informers"
+	metadatainformers "k8s.io/client-go/metadata/metadatainformer"
 	kyamlopenapi "sigs.k8s.io/kustomize/kyaml/openapi"
 )
 
@@ -39,11 +47,48 @@ func sanityChecks(apiserverClient apiserver.Interface) error {
 	return kubeutils.CRDsInstalled(apiserverClient, "updaterequests.kyverno.io")
 }
 
+func createBackgroundController(
+	eng engineapi.Engine,
+	kubeInformer kubeinformers.SharedInformerFactory,
+	kyvernoInformer kyvernoinformer.SharedInformerFactory,
+	nsLabels informers.NamespaceLabels,
+	kyvernoClient versioned.Interface,
+	dynamicClient dclient.Interface,
+	configuration config.Configuration,
+	eventGenerator event.Interface,
+	jp jmespath.Interface,
+	reportsConfig reportutils.ReportingConfiguration,
+	reportsBreaker breaker.Breaker,
+	retryPolicy background.RetryPolicy,
+	mutateOptions mutate.Options,
+	sharder sharding.Sharder,
+) background.Controller {
+	return background.NewController(
+		kyvernoClient,
+		dynamicClient,
+		eng,
+		kyvernoInformer.Kyverno().V1().ClusterPolicies(),
+		kyvernoInformer.Kyverno().V1().Policies(),
+		kyvernoInformer.Kyverno().V2().UpdateRequests(),
+		kubeInformer.Core().V1().Namespaces(),
+		nsLabels,
+		eventGenerator,
+		configuration,
+		jp,
+		reportsConfig,
+		reportsBreaker,
+		retryPolicy,
+		mutateOptions,
+		sharder,
+	)
+}
+
 func createrLeaderControllers(
 	eng engineapi.Engine,
 	genWorkers int,
 	kubeInformer kubeinformers.SharedInformerFactory,
 	kyvernoInformer kyvernoinformer.SharedInformerFactory,
+	metadataInformer metadatainformers.SharedInformerFactory,
 	kyvernoClient versioned.Interface,
 	dynamicClient dclient.Interface,
 	configuration config.Configuration,
@@ -54,7 +99,16 @@ func createrLeaderControllers(
 	urGenerator generator.UpdateRequestGenerator,
 	reportsConfig reportutils.ReportingConfiguration,
 	reportsBreaker breaker.Breaker,
+	retryPolicy background.RetryPolicy,
+	mutateOptions mutate.Options,
+	rollout policy.GenerateExistingRollout,
+	watchCloneSources bool,
+	sharded bool,
 ) ([]internal.Controller, error) {
+	nsLabels, err := informers.NewNamespaceLabels(kubeInformer.Core().V1().Namespaces())
+	if err != nil {
+		return nil, err
+	}
 	policyCtrl, err := policy.NewPolicyController(
 		kyvernoClient,
 		dynamicClient,
@@ -65,33 +119,55 @@ func createrLeaderControllers(
 		configuration,
 		eventGenerator,
 		kubeInformer.Core().V1().Namespaces(),
+		nsLabels,
 		logging.WithName("PolicyController"),
 		backgroundScanInterval,
 		metricsConfig,
 		jp,
 		urGenerator,
+		rollout,
 	)
 	if err != nil {
 		return nil, err
 	}
-	backgroundController := background.NewController(
-		kyvernoClient,
-		dynamicClient,
-		eng,
-		kyvernoInformer.Kyverno().V1().ClusterPolicies(),
-		kyvernoInformer.Kyverno().V1().Policies(),
-		kyvernoInformer.Kyverno().V2().UpdateRequests(),
-		kubeInformer.Core().V1().Namespaces(),
-		eventGenerator,
-		configuration,
-		jp,
-		reportsConfig,
-		reportsBreaker,
-	)
-	return []internal.Controller{
+	leaderControllers := []internal.Controller{
 		internal.NewController("policy-controller", policyCtrl, 2),
-		internal.NewController("background-controller", backgroundController, genWorkers),
-	}, err
+	}
+	// when sharded, every replica runs the background controller for its own namespaces
+	if !sharded {
+		backgroundController := createBackgroundController(
+			eng,
+			kubeInformer,
+			kyvernoInformer,
+			nsLabels,
+			kyvernoClient,
+			dynamicClient,
+			configuration,
+			eventGenerator,
+			jp,
+			reportsConfig,
+			reportsBreaker,
+			retryPolicy,
+			mutateOptions,
+			nil,
+		)
+		leaderControllers = append(leaderControllers, internal.NewController("background-controller", backgroundController, genWorkers))
+	}
+	if watchCloneSources {
+		cloneSourceController := clonesourcecontroller.NewController(
+			dynamicClient,
+			kyvernoClient,
+			kyvernoInformer.Kyverno().V1().ClusterPolicies(),
+			kyvernoInformer.Kyverno().V1().Policies(),
+			map[string]kubeinformers.GenericInformer{
+				"ConfigMap": metadataInformer.ForResource(corev1.SchemeGroupVersion.WithResource("configmaps")),
+				"Secret":    metadataInformer.ForResource(corev1.SchemeGroupVersion.WithResource("secrets")),
+			},
+			urGenerator,
+		)
+		leaderControllers = append(leaderControllers, internal.NewController(clonesourcecontroller.ControllerName, cloneSourceController, clonesourcecontroller.Workers))
+	}
+	return leaderControllers, err
 }
 
 func main() {
@@ -101,6 +177,11 @@ func main() {
 		omitEvents               string
 		maxAPICallResponseLength int64
 		maxBackgroundReports     int
+		retryPolicy              background.RetryPolicy
+		mutateOptions            mutate.Options
+		rollout                  policy.GenerateExistingRollout
+		watchCloneSources        bool
+		namespaceSharding        bool
 	)
 	flagset := flag.NewFlagSet("updaterequest-controller", flag.ExitOnError)
 	flagset.IntVar(&genWorkers, "genWorkers", 10, "Workers for the background controller.")
@@ -108,6 +189,16 @@ func main() {
 	flagset.StringVar(&omitEvents, "omitEvents", "", "Set this flag to a comma sperated list of PolicyViolation, PolicyApplied, PolicyError, PolicySkipped to disable events, e.g. --omitEvents=PolicyApplied,PolicyViolation")
 	flagset.Int64Var(&maxAPICallResponseLength, "maxAPICallResponseLength", 2*1000*1000, "Maximum allowed response size from API Calls. A value of 0 bypasses checks (not recommended).")
 	flagset.IntVar(&maxBackgroundReports, "maxBackgroundReports", 10000, "Maximum number of ephemeralreports created for the background policies.")
+	flagset.IntVar(&retryPolicy.MaxRetries, "updateRequestMaxRetries", 3, "Maximum number of retries of a failed update request before it is dead lettered.")
+	flagset.DurationVar(&retryPolicy.InitialBackoff, "updateRequestInitialBackoff", time.Second, "Delay before the first retry of a failed update request.")
+	flagset.DurationVar(&retryPolicy.MaxBackoff, "updateRequestMaxBackoff", 5*time.Minute, "Maximum delay between two retries of a failed update request.")
+	flagset.Float64Var(&retryPolicy.BackoffFactor, "updateRequestBackoffFactor", 2, "Factor the delay between two retries of a failed update request is multiplied by after every retry.")
+	flagset.BoolVar(&mutateOptions.ServerSideApply, "mutateExistingServerSideApply", false, "Set this flag to 'true' to apply the mutations of existing resources with server-side apply, the serverSideApply field of a rule overrides it.")
+	flagset.DurationVar(&mutateOptions.ApplyWindow, "mutateExistingApplyWindow", 100*time.Millisecond, "Time the server-side apply mutations of an existing resource are collected before being applied at once, a value of 0 applies every mutation on its own.")
+	flagset.IntVar(&rollout.BatchSize, "generateExistingBatchSize", 0, "Maximum number of triggers per update request created for the generate existing rules, a value of 0 creates a single update request.")
+	flagset.DurationVar(&rollout.BatchDelay, "generateExistingBatchDelay", 0, "Delay between the creation of two update requests for the generate existing rules.")
+	flagset.BoolVar(&namespaceSharding, "namespaceSharding", false, "Set this flag to 'true' to process the update requests on every replica, partitioned by namespace, instead of on the leader only.")
+	flagset.BoolVar(&watchCloneSources, "watchCloneSources", false, "Set this flag to 'true' to watch the ConfigMaps and Secrets cloned by the generate rules and synchronize the downstream resources as soon as they change, for the policies with watchCloneSources set.")
 
 	// config
 	appConfig := internal.NewConfiguration(
@@ -119,6 +210,8 @@ func main() {
 		internal.WithConfigMapCaching(),
 		internal.WithDeferredLoading(),
 		internal.WithRegistryClient(),
+		internal.WithWasm(),
+		internal.WithGlobalContext(),
 		internal.WithLeaderElection(),
 		internal.WithKyvernoClient(),
 		internal.WithDynamicClient(),
@@ -153,6 +246,10 @@ func main() {
 			setup.Logger.Error(err, "sanity checks failed")
 			os.Exit(1)
 		}
+		if err := retryPolicy.Validate(); err != nil {
+			setup.Logger.Error(err, "invalid update request retry policy")
+			os.Exit(1)
+		}
 		// informer factories
 		kyvernoInformer := kyvernoinformer.NewSharedInformerFactory(setup.KyvernoClient, setup.ResyncPeriod)
 		polexCache, polexController := internal.NewExceptionSelector(setup.Logger, kyvernoInformer)
@@ -162,6 +259,7 @@ func main() {
 			maxQueuedEvents,
 			strings.Split(omitEvents, ",")...,
 		)
+		internal.ReloadOmitEvents(setup.Configuration, eventGenerator, strings.Split(omitEvents, ",")...)
 		eventController := internal.NewController(
 			event.ControllerName,
 			eventGenerator,
@@ -177,7 +275,8 @@ func main() {
 				setup.KyvernoClient,
 				gcstore,
 				eventGenerator,
-				maxAPICallResponseLength,
+				apicall.NewAPICallConfiguration(maxAPICallResponseLength, apicall.WithAuthSecrets(setup.SecretResolver, setup.Configuration)),
+				setup.Jp,
 				false,
 			),
 			globalcontextcontroller.Workers,
@@ -200,7 +299,7 @@ func main() {
 			setup.KubeClient,
 			setup.KyvernoClient,
 			setup.RegistrySecretLister,
-			apicall.NewAPICallConfiguration(maxAPICallResponseLength),
+			apicall.NewAPICallConfiguration(maxAPICallResponseLength, apicall.WithAuthSecrets(setup.SecretResolver, setup.Configuration)),
 			polexCache,
 			gcstore,
 		)
@@ -209,18 +308,21 @@ func main() {
 			setup.Logger.Error(err, "failed to start background-scan reports watcher")
 			os.Exit(1)
 		}
-		reportsBreaker := breaker.NewBreaker("background scan reports", func(context.Context) bool {
-			count, isRunning := ephrs.Count()
-			if !isRunning {
-				return true
-			}
-			return count > maxBackgroundReports
-		})
+		reportsBreaker := breaker.NewCounterBreaker("background scan reports", ephrs, maxBackgroundReports)
 		// start informers and wait for cache sync
 		if !internal.StartInformersAndWaitForCacheSync(signalCtx, setup.Logger, kyvernoInformer) {
 			setup.Logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
 			os.Exit(1)
 		}
+		// load the global context snapshot before the entries are refreshed
+		gcSnapshot := internal.NewGlobalContextSnapshot(
+			signalCtx,
+			setup.Logger,
+			setup.KubeClient,
+			"kyverno-background-controller-globalcontext",
+			kyvernoInformer.Kyverno().V2alpha1().GlobalContextEntries().Lister(),
+			gcstore,
+		)
 		// setup leader election
 		le, err := leaderelection.New(
 			setup.Logger.WithName("leader-election"),
@@ -234,12 +336,19 @@ func main() {
 				// create leader factories
 				kubeInformer := kubeinformers.NewSharedInformerFactory(setup.KubeClient, setup.ResyncPeriod)
 				kyvernoInformer := kyvernoinformer.NewSharedInformerFactory(setup.KyvernoClient, setup.ResyncPeriod)
+				// the downstream resources are managed by kyverno, they are never clone sources
+				metadataInformer := metadatainformers.NewFilteredSharedInformerFactory(setup.MetadataClient, setup.ResyncPeriod, metav1.NamespaceAll, func(options *metav1.ListOptions) {
+					if selector, err := controllerutils.SelectorNotManagedByKyverno(); err == nil {
+						options.LabelSelector = selector.String()
+					}
+				})
 				// create leader controllers
 				leaderControllers, err := createrLeaderControllers(
 					engine,
 					genWorkers,
 					kubeInformer,
 					kyvernoInformer,
+					metadataInformer,
 					setup.KyvernoClient,
 					setup.KyvernoDynamicClient,
 					setup.Configuration,
@@ -250,6 +359,11 @@ func main() {
 					urGenerator,
 					setup.ReportingConfiguration,
 					reportsBreaker,
+					retryPolicy,
+					mutateOptions,
+					rollout,
+					watchCloneSources,
+					namespaceSharding,
 				)
 				if err != nil {
 					logger.Error(err, "failed to create leader controllers")
@@ -260,6 +374,11 @@ func main() {
 					logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
 					os.Exit(1)
 				}
+				internal.StartInformers(signalCtx, metadataInformer)
+				if !internal.CheckCacheSync(logger, metadataInformer.WaitForCacheSync(signalCtx.Done())) {
+					logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+					os.Exit(1)
+				}
 				// start leader controllers
 				var wg sync.WaitGroup
 				for _, controller := range leaderControllers {
@@ -277,9 +396,58 @@ func main() {
 		// start non leader controllers
 		eventController.Run(signalCtx, setup.Logger, &wg)
 		gceController.Run(signalCtx, setup.Logger, &wg)
+		if gcSnapshot != nil {
+			gcSnapshot.Run(signalCtx, setup.Logger, &wg)
+		}
 		if polexController != nil {
 			polexController.Run(signalCtx, setup.Logger, &wg)
 		}
+		// start the background controller on every replica when sharded
+		if namespaceSharding {
+			sharder := sharding.NewLeaseSharder(
+				setup.Logger.WithName("sharding"),
+				setup.KubeClient.CoordinationV1().Leases(config.KyvernoNamespace()),
+				"kyverno-background-controller",
+				config.KyvernoPodName(),
+				6*internal.LeaderElectionRetryPeriod(),
+			)
+			if err := sharder.Run(signalCtx); err != nil {
+				setup.Logger.Error(err, "failed to join the background controller shards")
+				os.Exit(1)
+			}
+			shardKubeInformer := kubeinformers.NewSharedInformerFactory(setup.KubeClient, setup.ResyncPeriod)
+			shardKyvernoInformer := kyvernoinformer.NewSharedInformerFactory(setup.KyvernoClient, setup.ResyncPeriod)
+			nsLabels, err := informers.NewNamespaceLabels(shardKubeInformer.Core().V1().Namespaces())
+			if err != nil {
+				setup.Logger.Error(err, "failed to create namespace labels informer")
+				os.Exit(1)
+			}
+			backgroundController := internal.NewController(
+				"background-controller",
+				createBackgroundController(
+					engine,
+					shardKubeInformer,
+					shardKyvernoInformer,
+					nsLabels,
+					setup.KyvernoClient,
+					setup.KyvernoDynamicClient,
+					setup.Configuration,
+					eventGenerator,
+					setup.Jp,
+					setup.ReportingConfiguration,
+					reportsBreaker,
+					retryPolicy,
+					mutateOptions,
+					sharder,
+				),
+				genWorkers,
+			)
+			if !internal.StartInformersAndWaitForCacheSync(signalCtx, setup.Logger, shardKyvernoInformer, shardKubeInformer) {
+				setup.Logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+				os.Exit(1)
+			}
+			backgroundController.Run(signalCtx, setup.Logger, &wg)
+		}
 		// start leader election
 		le.Run(signalCtx)
 	}()
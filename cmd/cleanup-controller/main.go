@@ -5,6 +5,7 @@ import (
 	"errors"
 	"flag"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -22,6 +23,8 @@ import (
 	globalcontextcontroller "github.com/kyverno/kyverno/pkg/controllers/globalcontext"
 	ttlcontroller "github.com/kyverno/kyverno/pkg/controllers/ttl"
 	webhookcontroller "github.com/kyverno/kyverno/pkg/controllers/webhook"
+	"github.com/kyverno/kyverno/pkg/engine/apicall"
+	"github.com/kyverno/kyverno/pkg/engine/grpccall"
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/globalcontext/store"
 	"github.com/kyverno/kyverno/pkg/informers"
@@ -32,6 +35,7 @@ import (
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	runtimeutils "github.com/kyverno/kyverno/pkg/utils/runtime"
 	"github.com/kyverno/kyverno/pkg/webhooks"
+	"github.com/kyverno/kyverno/pkg/webhooks/dump"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiserver "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
@@ -74,17 +78,26 @@ func sanityChecks(apiserverClient apiserver.Interface) error {
 func main() {
 	var (
 		dumpPayload              bool
+		dumpPayloadSink          string
+		dumpPayloadSampleRate    float64
+		dumpPayloadRedactFields  string
 		serverIP                 string
 		servicePort              int
 		webhookServerPort        int
 		maxQueuedEvents          int
 		interval                 time.Duration
 		renewBefore              time.Duration
+		certKeyAlgorithm         string
+		caValidityDuration       time.Duration
+		tlsValidityDuration      time.Duration
 		maxAPICallResponseLength int64
 		autoDeleteWebhooks       bool
 	)
 	flagset := flag.NewFlagSet("cleanup-controller", flag.ExitOnError)
 	flagset.BoolVar(&dumpPayload, "dumpPayload", false, "Set this flag to activate/deactivate debug mode.")
+	flagset.StringVar(&dumpPayloadSink, "dumpPayloadSink", "log", "Destination of payload dumps, either log, file:///path/to/dir?maxSize=10Mi&maxAge=1h&maxFiles=10 or s3://bucket/prefix?endpoint=https://host&region=us-east-1&maxSize=10Mi&maxAge=1h.")
+	flagset.Float64Var(&dumpPayloadSampleRate, "dumpPayloadSampleRate", 1, "Ratio of admission requests dumped when debug mode is active, between 0 and 1.")
+	flagset.StringVar(&dumpPayloadRedactFields, "dumpPayloadRedactFields", "", "Comma separated list of dot separated payload fields redacted from dumps, e.g. request.object.data,request.oldObject.data.")
 	flagset.StringVar(&serverIP, "serverIP", "", "IP address where Kyverno controller runs. Only required if out-of-cluster.")
 	flagset.IntVar(&servicePort, "servicePort", 443, "Port used by the Kyverno Service resource and for webhook configurations.")
 	flagset.IntVar(&webhookServerPort, "webhookServerPort", 9443, "Port used by the webhook server.")
@@ -94,6 +107,9 @@ func main() {
 	flagset.StringVar(&caSecretName, "caSecretName", "", "Name of the secret containing CA.")
 	flagset.StringVar(&tlsSecretName, "tlsSecretName", "", "Name of the secret containing TLS pair.")
 	flagset.DurationVar(&renewBefore, "renewBefore", 15*24*time.Hour, "The certificate renewal time before expiration")
+	flagset.StringVar(&certKeyAlgorithm, "certKeyAlgorithm", string(tls.DefaultKeyAlgorithm), "Algorithm of the generated certificate keys, one of RSA2048, RSA4096, ECDSAP256 or ECDSAP384, the CA key is replaced at its next renewal when the algorithm changes.")
+	flagset.DurationVar(&caValidityDuration, "caValidityDuration", tls.CAValidityDuration, "Validity duration of the generated CA certificate.")
+	flagset.DurationVar(&tlsValidityDuration, "tlsValidityDuration", tls.TLSValidityDuration, "Validity duration of the generated TLS certificate.")
 	flagset.Int64Var(&maxAPICallResponseLength, "maxAPICallResponseLength", 2*1000*1000, "Maximum allowed response size from API Calls. A value of 0 bypasses checks (not recommended).")
 	flagset.BoolVar(&autoDeleteWebhooks, "autoDeleteWebhooks", false, "Set this flag to 'true' to enable autodeletion of webhook configurations using finalizers (requires extra permissions).")
 	// config
@@ -102,6 +118,7 @@ func main() {
 		internal.WithMetrics(),
 		internal.WithTracing(),
 		internal.WithKubeconfig(),
+		internal.WithGlobalContext(),
 		internal.WithLeaderElection(),
 		internal.WithKyvernoClient(),
 		internal.WithKyvernoDynamicClient(),
@@ -127,10 +144,38 @@ func main() {
 			setup.Logger.Error(errors.New("exiting... tlsSecretName is a required flag"), "exiting... tlsSecretName is a required flag")
 			os.Exit(1)
 		}
+		keyAlgorithm, err := tls.ParseKeyAlgorithm(certKeyAlgorithm)
+		if err != nil {
+			setup.Logger.Error(err, "invalid certificate key algorithm")
+			os.Exit(1)
+		}
+		if caValidityDuration <= renewBefore || tlsValidityDuration <= renewBefore {
+			setup.Logger.Error(errors.New("exiting... certificate validity durations must be greater than renewBefore"), "exiting... certificate validity durations must be greater than renewBefore")
+			os.Exit(1)
+		}
 		if err := sanityChecks(setup.ApiServerClient); err != nil {
 			setup.Logger.Error(err, "sanity checks failed")
 			os.Exit(1)
 		}
+		// payload dump sink
+		dumpSink, err := dump.NewSink(ctx, dumpPayloadSink)
+		if err != nil {
+			setup.Logger.Error(err, "failed to create payload dump sink")
+			os.Exit(1)
+		}
+		if dumpSink != nil {
+			defer func() {
+				if err := dumpSink.Close(); err != nil {
+					setup.Logger.Error(err, "failed to close payload dump sink")
+				}
+			}()
+		}
+		var redactFields []string
+		for _, field := range strings.Split(dumpPayloadRedactFields, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				redactFields = append(redactFields, field)
+			}
+		}
 		// certificates informers
 		caSecret := informers.NewSecretInformer(setup.KubeClient, config.KyvernoNamespace(), caSecretName, setup.ResyncPeriod)
 		tlsSecret := informers.NewSecretInformer(setup.KubeClient, config.KyvernoNamespace(), tlsSecretName, setup.ResyncPeriod)
@@ -139,6 +184,7 @@ func main() {
 			setup.Logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
 			os.Exit(1)
 		}
+		certificates := tls.NewSecretSource(caSecret, tlsSecret, caSecretName, tlsSecretName, config.KyvernoNamespace())
 		checker := checker.NewSelfChecker(setup.KubeClient.AuthorizationV1().SelfSubjectAccessReviews())
 		// informer factories
 		kubeInformer := kubeinformers.NewSharedInformerFactoryWithOptions(setup.KubeClient, setup.ResyncPeriod)
@@ -177,7 +223,8 @@ func main() {
 				setup.KyvernoClient,
 				gcstore,
 				eventGenerator,
-				maxAPICallResponseLength,
+				apicall.NewAPICallConfiguration(maxAPICallResponseLength, apicall.WithAuthSecrets(setup.SecretResolver, setup.Configuration)),
+				setup.Jp,
 				false,
 			),
 			globalcontextcontroller.Workers,
@@ -186,6 +233,15 @@ func main() {
 		if !internal.StartInformersAndWaitForCacheSync(ctx, setup.Logger, kubeInformer, kyvernoInformer) {
 			os.Exit(1)
 		}
+		// load the global context snapshot before the entries are refreshed
+		gcSnapshot := internal.NewGlobalContextSnapshot(
+			ctx,
+			setup.Logger,
+			setup.KubeClient,
+			"kyverno-cleanup-controller-globalcontext",
+			kyvernoInformer.Kyverno().V2alpha1().GlobalContextEntries().Lister(),
+			gcstore,
+		)
 		runtime := runtimeutils.NewRuntime(
 			setup.Logger.WithName("runtime-checks"),
 			serverIP,
@@ -212,9 +268,10 @@ func main() {
 				renewer := tls.NewCertRenewer(
 					setup.KubeClient.CoreV1().Secrets(config.KyvernoNamespace()),
 					tls.CertRenewalInterval,
-					tls.CAValidityDuration,
-					tls.TLSValidityDuration,
+					caValidityDuration,
+					tlsValidityDuration,
 					renewBefore,
+					keyAlgorithm,
 					serverIP,
 					config.KyvernoServiceName(),
 					config.DnsNames(config.KyvernoServiceName(), config.KyvernoNamespace()),
@@ -228,6 +285,7 @@ func main() {
 						caSecret,
 						tlsSecret,
 						renewer,
+						eventGenerator,
 						caSecretName,
 						tlsSecretName,
 						config.KyvernoNamespace(),
@@ -240,7 +298,7 @@ func main() {
 						policyWebhookControllerName,
 						setup.KubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations(),
 						kubeInformer.Admissionregistration().V1().ValidatingWebhookConfigurations(),
-						caSecret,
+						certificates,
 						kyvernoDeployment,
 						config.CleanupValidatingWebhookConfigurationName,
 						config.CleanupValidatingWebhookServicePath,
@@ -248,6 +306,7 @@ func main() {
 						int32(servicePort),       //nolint:gosec
 						int32(webhookServerPort), //nolint:gosec
 						nil,
+						nil,
 						[]admissionregistrationv1.RuleWithOperations{
 							{
 								Rule: admissionregistrationv1.Rule{
@@ -267,7 +326,6 @@ func main() {
 						genericwebhookcontroller.Fail,
 						genericwebhookcontroller.None,
 						setup.Configuration,
-						caSecretName,
 						runtime,
 						autoDeleteWebhooks,
 						webhookcontroller.WebhookCleanupSetup(setup.KubeClient, policyWebhookControllerFinalizerName),
@@ -281,7 +339,7 @@ func main() {
 						ttlWebhookControllerName,
 						setup.KubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations(),
 						kubeInformer.Admissionregistration().V1().ValidatingWebhookConfigurations(),
-						caSecret,
+						certificates,
 						kyvernoDeployment,
 						config.TtlValidatingWebhookConfigurationName,
 						config.TtlValidatingWebhookServicePath,
@@ -296,6 +354,7 @@ func main() {
 								},
 							},
 						},
+						nil,
 						[]admissionregistrationv1.RuleWithOperations{
 							{
 								Rule: admissionregistrationv1.Rule{
@@ -312,7 +371,6 @@ func main() {
 						genericwebhookcontroller.Ignore,
 						genericwebhookcontroller.None,
 						setup.Configuration,
-						caSecretName,
 						runtime,
 						autoDeleteWebhooks,
 						webhookcontroller.WebhookCleanupSetup(setup.KubeClient, ttlWebhookControllerFinalizerName),
@@ -333,6 +391,7 @@ func main() {
 						setup.Jp,
 						eventGenerator,
 						gcstore,
+						grpccall.NewClient(setup.SecretResolver, setup.Configuration),
 					),
 					cleanup.Workers,
 				)
@@ -340,6 +399,7 @@ func main() {
 					ttlcontroller.ControllerName,
 					ttlcontroller.NewManager(
 						setup.MetadataClient,
+						setup.KyvernoDynamicClient.GetDynamicInterface(),
 						setup.KubeClient.Discovery(),
 						checker,
 						interval,
@@ -383,7 +443,10 @@ func main() {
 			resourceHandlers.Validate,
 			setup.MetricsManager,
 			webhooks.DebugModeOptions{
-				DumpPayload: dumpPayload,
+				DumpPayload:      dumpPayload,
+				DumpSink:         dumpSink,
+				DumpSampleRate:   dumpPayloadSampleRate,
+				DumpRedactFields: redactFields,
 			},
 			probes{},
 			setup.Configuration,
@@ -394,6 +457,9 @@ func main() {
 		// start non leader controllers
 		eventController.Run(ctx, setup.Logger, &wg)
 		gceController.Run(ctx, setup.Logger, &wg)
+		if gcSnapshot != nil {
+			gcSnapshot.Run(ctx, setup.Logger, &wg)
+		}
 		// start leader election
 		le.Run(ctx)
 	}()
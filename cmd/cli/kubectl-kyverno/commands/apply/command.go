@@ -13,6 +13,7 @@ import (
 	"github.com/go-git/go-billy/v5/memfs"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/command"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/deprecations"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/exception"
@@ -20,6 +21,7 @@ import (
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/output/color"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/policy"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/processor"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/resource"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/source"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/userinfo"
@@ -53,6 +55,9 @@ type ApplyCommandConfig struct {
 	Variables             []string
 	ValuesFile            string
 	UserInfoPath          string
+	UserInfoFromConfig    bool
+	ImpersonateUser       string
+	ImpersonateGroups     []string
 	Cluster               bool
 	PolicyReport          bool
 	Stdin                 bool
@@ -68,6 +73,9 @@ type ApplyCommandConfig struct {
 	inlineExceptions      bool
 	GenerateExceptions    bool
 	GeneratedExceptionTTL time.Duration
+	DependencyOrder       bool
+	CheckSchemas          bool
+	CRDSchemaPaths        []string
 }
 
 func Command() *cobra.Command {
@@ -138,6 +146,9 @@ func Command() *cobra.Command {
 	cmd.Flags().StringVarP(&applyCommandConfig.MutateLogPath, "output", "o", "", "Prints the mutated/generated resources in provided file/directory")
 	// currently `set` flag supports variable for single policy applied on single resource
 	cmd.Flags().StringVarP(&applyCommandConfig.UserInfoPath, "userinfo", "u", "", "Admission Info including Roles, Cluster Roles and Subjects")
+	cmd.Flags().BoolVar(&applyCommandConfig.UserInfoFromConfig, "userinfo-from-kubeconfig", false, "Derive admission info from the user of the active kubeconfig context")
+	cmd.Flags().StringVar(&applyCommandConfig.ImpersonateUser, "as", "", "Username to impersonate when evaluating policies, its roles are resolved from the cluster with --cluster")
+	cmd.Flags().StringSliceVar(&applyCommandConfig.ImpersonateGroups, "as-group", nil, "Group to impersonate when evaluating policies, this flag can be repeated to specify multiple groups")
 	cmd.Flags().StringSliceVarP(&applyCommandConfig.Variables, "set", "s", nil, "Variables that are required")
 	cmd.Flags().StringVarP(&applyCommandConfig.ValuesFile, "values-file", "f", "", "File containing values for policy variables")
 	cmd.Flags().BoolVarP(&applyCommandConfig.PolicyReport, "policy-report", "p", false, "Generates policy report when passed (default policyviolation)")
@@ -159,6 +170,9 @@ func Command() *cobra.Command {
 	cmd.Flags().BoolVarP(&applyCommandConfig.inlineExceptions, "exceptions-with-resources", "", false, "Evaluate policy exceptions from the resources path")
 	cmd.Flags().BoolVarP(&applyCommandConfig.GenerateExceptions, "generate-exceptions", "", false, "Generate policy exceptions for each violation")
 	cmd.Flags().DurationVarP(&applyCommandConfig.GeneratedExceptionTTL, "generated-exception-ttl", "", time.Hour*24*30, "Default TTL for generated exceptions")
+	cmd.Flags().BoolVar(&applyCommandConfig.DependencyOrder, "dependency-order", false, "Apply policies on resources in rollout order (CRDs and namespaces first) and make generated and mutated resources visible to later evaluations, including the targets of mutate existing rules")
+	cmd.Flags().BoolVar(&applyCommandConfig.CheckSchemas, "check-schemas", false, "Validate resources against Kubernetes OpenAPI schemas before applying policies")
+	cmd.Flags().StringSliceVar(&applyCommandConfig.CRDSchemaPaths, "crd-schemas", nil, "Path to directories containing CRD files used to validate custom resources, used with --check-schemas flag")
 	return cmd
 }
 
@@ -175,14 +189,9 @@ func (c *ApplyCommandConfig) applyCommandHelper(out io.Writer) (*processor.Resul
 	if err != nil {
 		return rc, resources1, skipInvalidPolicies, responses1, err
 	}
-	var userInfo *kyvernov2.RequestInfo
-	if c.UserInfoPath != "" {
-		info, err := userinfo.Load(nil, c.UserInfoPath, "")
-		if err != nil {
-			return nil, nil, skipInvalidPolicies, nil, fmt.Errorf("failed to load request info (%w)", err)
-		}
-		deprecations.CheckUserInfo(out, c.UserInfoPath, info)
-		userInfo = &info.RequestInfo
+	userInfo, err := c.loadUserInfo(out)
+	if err != nil {
+		return nil, nil, skipInvalidPolicies, nil, err
 	}
 	variables, err := variables.New(out, nil, "", c.ValuesFile, nil, c.Variables...)
 	if err != nil {
@@ -193,6 +202,11 @@ func (c *ApplyCommandConfig) applyCommandHelper(out io.Writer) (*processor.Resul
 	if err != nil {
 		return rc, resources1, skipInvalidPolicies, responses1, err
 	}
+	if userInfo != nil && c.ImpersonateUser != "" && dClient != nil {
+		if err := userinfo.ResolveRoles(context.Background(), dClient.GetKubeClient(), userInfo); err != nil {
+			return nil, nil, skipInvalidPolicies, nil, fmt.Errorf("failed to resolve roles of %s (%w)", c.ImpersonateUser, err)
+		}
+	}
 	rc, resources1, skipInvalidPolicies, responses1, policies, vaps, vapBindings, err := c.loadPolicies(skipInvalidPolicies)
 	if err != nil {
 		return rc, resources1, skipInvalidPolicies, responses1, err
@@ -201,6 +215,14 @@ func (c *ApplyCommandConfig) applyCommandHelper(out io.Writer) (*processor.Resul
 	if err != nil {
 		return rc, resources1, skipInvalidPolicies, responses1, err
 	}
+	if c.CheckSchemas {
+		if err := resource.ValidateSchemas(resources, c.CRDSchemaPaths...); err != nil {
+			return rc, resources1, skipInvalidPolicies, responses1, fmt.Errorf("failed to validate resources schemas (%w)", err)
+		}
+	}
+	if c.DependencyOrder {
+		resources = resource.SortByDependency(resources)
+	}
 	var exceptions []*kyvernov2.PolicyException
 	if c.inlineExceptions {
 		exceptions = exception.SelectFrom(resources)
@@ -223,6 +245,10 @@ func (c *ApplyCommandConfig) applyCommandHelper(out io.Writer) (*processor.Resul
 		}
 	}
 
+	var requestInfo *kyvernov2.RequestInfo
+	if userInfo != nil {
+		requestInfo = &userInfo.RequestInfo
+	}
 	rc, resources1, responses1, err = c.applyPolicytoResource(
 		out,
 		&store,
@@ -232,7 +258,7 @@ func (c *ApplyCommandConfig) applyCommandHelper(out io.Writer) (*processor.Resul
 		exceptions,
 		&skipInvalidPolicies,
 		dClient,
-		userInfo,
+		requestInfo,
 		mutateLogPathIsDir,
 	)
 	if err != nil {
@@ -248,6 +274,28 @@ func (c *ApplyCommandConfig) applyCommandHelper(out io.Writer) (*processor.Resul
 	return rc, resources1, skipInvalidPolicies, responses, nil
 }
 
+func (c *ApplyCommandConfig) loadUserInfo(out io.Writer) (*v1alpha1.UserInfo, error) {
+	var info *v1alpha1.UserInfo
+	if c.UserInfoPath != "" {
+		loaded, err := userinfo.Load(nil, c.UserInfoPath, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to load request info (%w)", err)
+		}
+		deprecations.CheckUserInfo(out, c.UserInfoPath, loaded)
+		info = loaded
+	} else if c.UserInfoFromConfig {
+		loaded, err := userinfo.FromKubeconfig(c.KubeConfig, c.Context)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load request info from kubeconfig (%w)", err)
+		}
+		info = loaded
+	}
+	if c.ImpersonateUser != "" {
+		info = userinfo.Impersonate(info, c.ImpersonateUser, c.ImpersonateGroups)
+	}
+	return info, nil
+}
+
 func (c *ApplyCommandConfig) getMutateLogPathIsDir(skipInvalidPolicies SkippedInvalidPolicies) (*processor.ResultCounts, []*unstructured.Unstructured, SkippedInvalidPolicies, []engineapi.EngineResponse, error, bool) {
 	mutateLogPathIsDir, err := checkMutateLogPath(c.MutateLogPath)
 	if err != nil {
@@ -324,6 +372,8 @@ func (c *ApplyCommandConfig) applyPolicytoResource(
 	}
 
 	var responses []engineapi.EngineResponse
+	var existing []*unstructured.Unstructured
+	namespaceSelectorMap := vars.NamespaceSelectors()
 	for _, resource := range resources {
 		processor := processor.PolicyProcessor{
 			Store:                store,
@@ -335,10 +385,12 @@ func (c *ApplyCommandConfig) applyPolicytoResource(
 			Variables:            vars,
 			UserInfo:             userInfo,
 			PolicyReport:         c.PolicyReport,
-			NamespaceSelectorMap: vars.NamespaceSelectors(),
+			NamespaceSelectorMap: namespaceSelectorMap,
 			Stdin:                c.Stdin,
 			Rc:                   &rc,
 			PrintPatchResource:   true,
+			ExistingResources:    existing,
+			DependencyOrder:      c.DependencyOrder,
 			Client:               dClient,
 			AuditWarn:            c.AuditWarn,
 			Subresources:         vars.Subresources(),
@@ -353,6 +405,9 @@ func (c *ApplyCommandConfig) applyPolicytoResource(
 			return &rc, resources, responses, fmt.Errorf("failed to apply policies on resource %s (%w)", resource.GetName(), err)
 		}
 		responses = append(responses, ers...)
+		if c.DependencyOrder {
+			existing, namespaceSelectorMap = updateClusterState(existing, namespaceSelectorMap, resource, ers)
+		}
 	}
 	for _, policy := range validPolicies {
 		if policy.GetNamespace() == "" && policy.GetKind() == "Policy" {
@@ -362,6 +417,66 @@ func (c *ApplyCommandConfig) applyPolicytoResource(
 	return &rc, resources, responses, nil
 }
 
+// updateClusterState records the resource, as patched by mutate rules, the resources generated for it and
+// the existing resources patched by mutate existing rules so that they are visible when evaluating the next
+// resources. A recorded resource replaces a previous version of the same resource.
+// Namespace labels are recorded too, unless selectors were already provided for the namespace.
+func updateClusterState(
+	existing []*unstructured.Unstructured,
+	namespaceSelectorMap map[string]map[string]string,
+	resource *unstructured.Unstructured,
+	responses []engineapi.EngineResponse,
+) ([]*unstructured.Unstructured, map[string]map[string]string) {
+	patched := resource
+	for i := range responses {
+		for _, rule := range responses[i].PolicyResponse.Rules {
+			switch rule.RuleType() {
+			case engineapi.Generation:
+				for _, generated := range rule.GeneratedResources() {
+					existing = recordResource(existing, generated)
+				}
+			case engineapi.Mutation, engineapi.ImageVerify:
+				if rule.Status() != engineapi.RuleStatusPass {
+					continue
+				}
+				if target, _, _ := rule.PatchedTarget(); target != nil {
+					existing = recordResource(existing, target)
+				} else {
+					patched = &responses[i].PatchedResource
+				}
+			}
+		}
+	}
+	existing = recordResource(existing, patched)
+	if patched.GetKind() == "Namespace" {
+		if _, ok := namespaceSelectorMap[patched.GetName()]; !ok {
+			if namespaceSelectorMap == nil {
+				namespaceSelectorMap = map[string]map[string]string{}
+			}
+			namespaceSelectorMap[patched.GetName()] = patched.GetLabels()
+		}
+	}
+	return existing, namespaceSelectorMap
+}
+
+// recordResource adds a copy of the resource to the existing resources or replaces its previous version
+func recordResource(existing []*unstructured.Unstructured, obj *unstructured.Unstructured) []*unstructured.Unstructured {
+	key := func(obj *unstructured.Unstructured) resource.ResourceKey {
+		return resource.ResourceKey{
+			GroupKind: obj.GroupVersionKind().GroupKind(),
+			Namespace: obj.GetNamespace(),
+			Name:      obj.GetName(),
+		}
+	}
+	for i := range existing {
+		if key(existing[i]) == key(obj) {
+			existing[i] = obj.DeepCopy()
+			return existing
+		}
+	}
+	return append(existing, obj.DeepCopy())
+}
+
 func (c *ApplyCommandConfig) loadResources(out io.Writer, policies []kyvernov1.PolicyInterface, vap []admissionregistrationv1beta1.ValidatingAdmissionPolicy, dClient dclient.Interface) ([]*unstructured.Unstructured, error) {
 	resources, err := common.GetResourceAccordingToResourcePath(out, nil, c.ResourcePaths, c.Cluster, policies, vap, dClient, c.Namespace, c.PolicyReport, "")
 	if err != nil {
@@ -493,6 +608,15 @@ func (c *ApplyCommandConfig) checkArguments() (*processor.ResultCounts, []*unstr
 	if (len(c.PolicyPaths) > 0 && c.PolicyPaths[0] == "-") && len(c.ResourcePaths) > 0 && c.ResourcePaths[0] == "-" {
 		return nil, nil, skipInvalidPolicies, nil, fmt.Errorf("a stdin pipe can be used for either policies or resources, not both")
 	}
+	if c.UserInfoPath != "" && c.UserInfoFromConfig {
+		return nil, nil, skipInvalidPolicies, nil, fmt.Errorf("pass the admission info either using userinfo flag or userinfo-from-kubeconfig flag")
+	}
+	if len(c.ImpersonateGroups) > 0 && c.ImpersonateUser == "" {
+		return nil, nil, skipInvalidPolicies, nil, fmt.Errorf("requesting groups without impersonating a user is not supported, use the as flag")
+	}
+	if len(c.CRDSchemaPaths) > 0 && !c.CheckSchemas {
+		return nil, nil, skipInvalidPolicies, nil, fmt.Errorf("crd-schemas flag requires check-schemas flag")
+	}
 	if len(c.ResourcePaths) == 0 && !c.Cluster {
 		return nil, nil, skipInvalidPolicies, nil, fmt.Errorf("resource file(s) or cluster required")
 	}
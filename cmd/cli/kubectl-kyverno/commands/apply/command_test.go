@@ -12,7 +12,10 @@ import (
 
 	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/report"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 func Test_Apply(t *testing.T) {
@@ -516,3 +519,51 @@ func TestCommandHelp(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, strings.HasPrefix(string(out), cmd.Long))
 }
+
+func Test_updateClusterState(t *testing.T) {
+	newResource := func(kind, namespace, name string, labels map[string]string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind(kind)
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+		obj.SetLabels(labels)
+		return obj
+	}
+	namespace := newResource("Namespace", "", "team-a", nil)
+	mutatedNamespace := newResource("Namespace", "", "team-a", map[string]string{"team": "a"})
+	configMap := newResource("ConfigMap", "team-a", "settings", nil)
+	mutatedConfigMap := newResource("ConfigMap", "team-a", "settings", map[string]string{"mutated": "true"})
+	secret := newResource("Secret", "team-a", "token", nil)
+	pod := newResource("Pod", "team-a", "nginx", nil)
+
+	// the namespace is mutated and a config map is generated for it
+	existing, namespaceSelectorMap := updateClusterState(nil, nil, namespace, []engineapi.EngineResponse{{
+		PatchedResource: *mutatedNamespace,
+		PolicyResponse: engineapi.PolicyResponse{Rules: []engineapi.RuleResponse{
+			*engineapi.RulePass("add-labels", engineapi.Mutation, "", nil),
+		}},
+	}, {
+		PatchedResource: *mutatedNamespace,
+		PolicyResponse: engineapi.PolicyResponse{Rules: []engineapi.RuleResponse{
+			*engineapi.RulePass("generate-settings", engineapi.Generation, "", nil).WithGeneratedResources([]*unstructured.Unstructured{configMap}),
+		}},
+	}})
+	assert.Equal(t, []*unstructured.Unstructured{configMap, mutatedNamespace}, existing)
+	assert.Equal(t, map[string]map[string]string{"team-a": {"team": "a"}}, namespaceSelectorMap)
+
+	// a mutate existing rule patches the config map, a failed rule doesn't change the secret
+	existing, namespaceSelectorMap = updateClusterState(existing, namespaceSelectorMap, pod, []engineapi.EngineResponse{{
+		PatchedResource: *pod,
+		PolicyResponse: engineapi.PolicyResponse{Rules: []engineapi.RuleResponse{
+			*engineapi.RulePass("mutate-settings", engineapi.Mutation, "", nil).WithPatchedTarget(mutatedConfigMap, metav1.GroupVersionResource{}, ""),
+			*engineapi.RuleFail("mutate-token", engineapi.Mutation, "", nil).WithPatchedTarget(secret, metav1.GroupVersionResource{}, ""),
+		}},
+	}})
+	assert.Equal(t, []*unstructured.Unstructured{mutatedConfigMap, mutatedNamespace, pod}, existing)
+	assert.Equal(t, map[string]map[string]string{"team-a": {"team": "a"}}, namespaceSelectorMap)
+
+	// selectors provided for a namespace are kept
+	_, namespaceSelectorMap = updateClusterState(nil, map[string]map[string]string{"team-a": {"team": "b"}}, mutatedNamespace, nil)
+	assert.Equal(t, map[string]map[string]string{"team-a": {"team": "b"}}, namespaceSelectorMap)
+}
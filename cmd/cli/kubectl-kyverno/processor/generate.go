@@ -19,14 +19,16 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
-func handleGeneratePolicy(out io.Writer, store *store.Store, generateResponse *engineapi.EngineResponse, policyContext engine.PolicyContext, ruleToCloneSourceResource map[string]string) ([]engineapi.RuleResponse, error) {
+func handleGeneratePolicy(out io.Writer, store *store.Store, generateResponse *engineapi.EngineResponse, policyContext engine.PolicyContext, ruleToCloneSourceResource map[string]string, existingResources []*unstructured.Unstructured) ([]engineapi.RuleResponse, error) {
 	newResource := policyContext.NewResource()
 	objects := []runtime.Object{&newResource}
+	keys := sets.New(resourceKey(&newResource))
 	for _, rule := range generateResponse.PolicyResponse.Rules {
 		if path, ok := ruleToCloneSourceResource[rule.Name()]; ok {
 			resourceBytes, err := resource.GetFileBytes(path)
@@ -39,10 +41,17 @@ func handleGeneratePolicy(out io.Writer, store *store.Store, generateResponse *e
 				}
 				for _, res := range r {
 					objects = append(objects, res)
+					keys.Insert(resourceKey(res))
 				}
 			}
 		}
 	}
+	for _, res := range existingResources {
+		if res != nil && !keys.Has(resourceKey(res)) {
+			objects = append(objects, res)
+			keys.Insert(resourceKey(res))
+		}
+	}
 
 	listKinds := map[schema.GroupVersionResource]string{}
 
@@ -100,6 +109,14 @@ func handleGeneratePolicy(out io.Writer, store *store.Store, generateResponse *e
 	return newRuleResponse, nil
 }
 
+func resourceKey(obj *unstructured.Unstructured) resource.ResourceKey {
+	return resource.ResourceKey{
+		GroupKind: obj.GroupVersionKind().GroupKind(),
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+}
+
 func initializeMockController(out io.Writer, s *store.Store, gvrToListKind map[schema.GroupVersionResource]string, objects []runtime.Object) (*generate.GenerateController, error) {
 	client, err := dclient.NewFakeClient(runtime.NewScheme(), gvrToListKind, objects...)
 	if err != nil {
@@ -122,6 +139,9 @@ func initializeMockController(out io.Writer, s *store.Store, gvrToListKind map[s
 		imageverifycache.DisabledImageVerifyCache(),
 		store.ContextLoaderFactory(s, nil),
 		nil,
+		nil,
+		nil,
+		nil,
 	))
 	return c, nil
 }
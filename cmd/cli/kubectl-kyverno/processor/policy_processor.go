@@ -16,6 +16,7 @@ import (
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/utils/common"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/variables"
+	"github.com/kyverno/kyverno/pkg/autogen"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine"
@@ -25,6 +26,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
 	"github.com/kyverno/kyverno/pkg/engine/policycontext"
+	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
 	"github.com/kyverno/kyverno/pkg/exceptions"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/registryclient"
@@ -32,7 +34,9 @@ import (
 	"gomodules.xyz/jsonpatch/v2"
 	yamlv2 "gopkg.in/yaml.v2"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 type PolicyProcessor struct {
@@ -50,6 +54,8 @@ type PolicyProcessor struct {
 	Rc                        *ResultCounts
 	PrintPatchResource        bool
 	RuleToCloneSourceResource map[string]string
+	ExistingResources         []*unstructured.Unstructured
+	DependencyOrder           bool
 	Client                    dclient.Interface
 	AuditWarn                 bool
 	Subresources              []v1alpha1.Subresource
@@ -67,6 +73,13 @@ func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse,
 	var client engineapi.Client
 	if p.Client != nil {
 		client = adapters.Client(p.Client)
+	} else if p.DependencyOrder {
+		// mutate existing rules target the resources evaluated before this one
+		clusterState, err := clusterStateClient(p.Policies, p.ExistingResources)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize the cluster state (%w)", err)
+		}
+		client = adapters.Client(clusterState)
 	}
 	rclient := p.Store.GetRegistryClient()
 	if rclient == nil {
@@ -81,6 +94,9 @@ func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse,
 		imageverifycache.DisabledImageVerifyCache(),
 		store.ContextLoaderFactory(p.Store, nil),
 		exceptions.New(policyExceptionLister),
+		nil,
+		nil,
+		nil,
 	)
 	gvk, subresource := resource.GroupVersionKind(), ""
 	resourceKind := resource.GetKind()
@@ -197,7 +213,7 @@ func (p *PolicyProcessor) ApplyPoliciesOnResource() ([]engineapi.EngineResponse,
 			}
 			generateResponse := eng.ApplyBackgroundChecks(context.TODO(), policyContext)
 			if !generateResponse.IsEmpty() {
-				newRuleResponse, err := handleGeneratePolicy(p.Out, p.Store, &generateResponse, *policyContext, p.RuleToCloneSourceResource)
+				newRuleResponse, err := handleGeneratePolicy(p.Out, p.Store, &generateResponse, *policyContext, p.RuleToCloneSourceResource, p.ExistingResources)
 				if err != nil {
 					log.Log.Error(err, "failed to apply generate policy")
 				} else {
@@ -368,6 +384,18 @@ func (p *PolicyProcessor) processMutateEngineResponse(response engineapi.EngineR
 		return fmt.Errorf("failed to print mutated result (%w)", err)
 	}
 	fmt.Fprintf(p.Out, "\n\nMutation:\nMutation has been applied successfully.")
+	// mutateExisting rules only compute the patched targets, they are not applied to the cluster
+	for _, rule := range response.PolicyResponse.Rules {
+		target, _, _ := rule.PatchedTarget()
+		if target == nil || rule.Status() != engineapi.RuleStatusPass {
+			continue
+		}
+		targetPath := fmt.Sprintf("%s/%s/%s", target.GetNamespace(), target.GetKind(), target.GetName())
+		if err := p.printOutput(target.Object, response, targetPath, false); err != nil {
+			return fmt.Errorf("failed to print mutated target (%w)", err)
+		}
+		fmt.Fprintf(p.Out, "\n\nMutate existing:\nTarget %s would be mutated (dry run).", targetPath)
+	}
 	return nil
 }
 
@@ -419,3 +447,39 @@ func (p *PolicyProcessor) printOutput(resource interface{}, response engineapi.E
 	}
 	return nil
 }
+
+// clusterStateClient returns a fake client holding the existing resources, the kinds targeted by mutate
+// existing rules are registered so that a target without existing resources is not found
+func clusterStateClient(policies []kyvernov1.PolicyInterface, existing []*unstructured.Unstructured) (dclient.Interface, error) {
+	objects := make([]runtime.Object, 0, len(existing))
+	gvrs := sets.New[schema.GroupVersionResource]()
+	listKinds := map[schema.GroupVersionResource]string{}
+	register := func(gvk schema.GroupVersionKind) {
+		gvr := gvk.GroupVersion().WithResource(strings.ToLower(gvk.Kind) + "s")
+		gvrs.Insert(gvr)
+		listKinds[gvr] = gvk.Kind + "List"
+	}
+	for _, resource := range existing {
+		objects = append(objects, resource.DeepCopy())
+		register(resource.GroupVersionKind())
+	}
+	for _, policy := range policies {
+		for _, rule := range autogen.ComputeRules(policy, "") {
+			if !rule.HasMutateExisting() {
+				continue
+			}
+			for _, target := range rule.Mutation.Targets {
+				if target.Kind == "" || regex.IsVariable(target.APIVersion+target.Kind) {
+					continue
+				}
+				register(schema.FromAPIVersionAndKind(target.APIVersion, target.Kind))
+			}
+		}
+	}
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), listKinds, objects...)
+	if err != nil {
+		return nil, err
+	}
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient(gvrs.UnsortedList()))
+	return client, nil
+}
@@ -1,11 +1,13 @@
 package processor
 
 import (
+	"io"
 	"os"
 	"testing"
 
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/resource"
 	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/store"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	yamlutils "github.com/kyverno/kyverno/pkg/utils/yaml"
 	"gotest.tools/assert"
 )
@@ -118,3 +120,33 @@ func Test_NamespaceSelector(t *testing.T) {
 		assert.Equal(t, int64(rc.Error), int64(tc.result.Error))
 	}
 }
+
+func Test_MutateExistingClusterState(t *testing.T) {
+	policy := []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"label-settings"},"spec":{"rules":[{"name":"label-settings","match":{"any":[{"resources":{"kinds":["Pod"]}}]},"mutate":{"targets":[{"apiVersion":"v1","kind":"ConfigMap","namespace":"{{ request.object.metadata.namespace }}","name":"settings"}],"patchStrategicMerge":{"metadata":{"labels":{"used-by":"{{ request.object.metadata.name }}"}}}}}]}}`)
+	configMap := []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"settings","namespace":"team-a"},"data":{"key":"value"}}`)
+	pod := []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"nginx","namespace":"team-a"},"spec":{"containers":[{"image":"nginx:latest","name":"nginx"}]}}`)
+	policies, _, _, _ := yamlutils.GetPolicy(policy)
+	existing, err := resource.GetUnstructuredResources(configMap)
+	assert.NilError(t, err)
+	resources, err := resource.GetUnstructuredResources(pod)
+	assert.NilError(t, err)
+	processor := PolicyProcessor{
+		Store:             &store.Store{},
+		Policies:          policies,
+		Resource:          *resources[0],
+		ExistingResources: existing,
+		DependencyOrder:   true,
+		Rc:                &ResultCounts{},
+		Out:               io.Discard,
+	}
+	responses, err := processor.ApplyPoliciesOnResource()
+	assert.NilError(t, err)
+	assert.Equal(t, len(responses), 1)
+	assert.Equal(t, len(responses[0].PolicyResponse.Rules), 1)
+	rule := responses[0].PolicyResponse.Rules[0]
+	assert.Equal(t, rule.Status(), engineapi.RuleStatusPass, rule.Message())
+	target, _, _ := rule.PatchedTarget()
+	assert.Assert(t, target != nil)
+	assert.Equal(t, target.GetName(), "settings")
+	assert.Equal(t, target.GetLabels()["used-by"], "nginx")
+}
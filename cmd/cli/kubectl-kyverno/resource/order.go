@@ -0,0 +1,72 @@
+package resource
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// kindOrder is the order in which resources would typically be created in a cluster.
+// CRDs come first so that custom resources can be admitted, namespaces come before
+// namespaced objects, and workloads come after the resources they depend on.
+// Kinds that are not listed (including custom resources) are ordered last.
+var kindOrder = []string{
+	"CustomResourceDefinition",
+	"Namespace",
+	"NetworkPolicy",
+	"ResourceQuota",
+	"LimitRange",
+	"PodDisruptionBudget",
+	"ServiceAccount",
+	"Secret",
+	"ConfigMap",
+	"StorageClass",
+	"PersistentVolume",
+	"PersistentVolumeClaim",
+	"ClusterRole",
+	"ClusterRoleBinding",
+	"Role",
+	"RoleBinding",
+	"Service",
+	"DaemonSet",
+	"Pod",
+	"ReplicationController",
+	"ReplicaSet",
+	"Deployment",
+	"HorizontalPodAutoscaler",
+	"StatefulSet",
+	"Job",
+	"CronJob",
+	"IngressClass",
+	"Ingress",
+	"APIService",
+}
+
+var kindRank = func() map[string]int {
+	ranks := make(map[string]int, len(kindOrder))
+	for i, kind := range kindOrder {
+		ranks[kind] = i
+	}
+	return ranks
+}()
+
+func rank(resource *unstructured.Unstructured) int {
+	if resource == nil {
+		return len(kindOrder)
+	}
+	if r, ok := kindRank[resource.GetKind()]; ok {
+		return r
+	}
+	return len(kindOrder)
+}
+
+// SortByDependency returns the resources ordered the way they would be rolled out in a cluster.
+// The sort is stable, resources of the same kind keep their relative order.
+func SortByDependency(resources []*unstructured.Unstructured) []*unstructured.Unstructured {
+	sorted := make([]*unstructured.Unstructured, len(resources))
+	copy(sorted, resources)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return rank(sorted[i]) < rank(sorted[j])
+	})
+	return sorted
+}
@@ -0,0 +1,55 @@
+package resource
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestSortByDependency(t *testing.T) {
+	newResource := func(kind, name string) *unstructured.Unstructured {
+		var r unstructured.Unstructured
+		r.SetKind(kind)
+		r.SetName(name)
+		return &r
+	}
+	crd := newResource("CustomResourceDefinition", "foos.example.com")
+	ns := newResource("Namespace", "test")
+	cm := newResource("ConfigMap", "config")
+	pod1 := newResource("Pod", "pod-1")
+	pod2 := newResource("Pod", "pod-2")
+	foo := newResource("Foo", "foo")
+	tests := []struct {
+		name      string
+		resources []*unstructured.Unstructured
+		want      []*unstructured.Unstructured
+	}{{
+		name:      "nil",
+		resources: nil,
+		want:      []*unstructured.Unstructured{},
+	}, {
+		name:      "already sorted",
+		resources: []*unstructured.Unstructured{ns, cm, pod1},
+		want:      []*unstructured.Unstructured{ns, cm, pod1},
+	}, {
+		name:      "namespace first",
+		resources: []*unstructured.Unstructured{pod1, cm, ns},
+		want:      []*unstructured.Unstructured{ns, cm, pod1},
+	}, {
+		name:      "crd before custom resource",
+		resources: []*unstructured.Unstructured{foo, pod1, crd},
+		want:      []*unstructured.Unstructured{crd, pod1, foo},
+	}, {
+		name:      "stable",
+		resources: []*unstructured.Unstructured{pod2, ns, pod1},
+		want:      []*unstructured.Unstructured{ns, pod2, pod1},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SortByDependency(tt.resources); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SortByDependency() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
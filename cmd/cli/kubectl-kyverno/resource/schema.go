@@ -0,0 +1,47 @@
+package resource
+
+import (
+	"fmt"
+	"os"
+
+	resourceloader "github.com/kyverno/kyverno/ext/resource/loader"
+	"go.uber.org/multierr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/openapi"
+	"sigs.k8s.io/kubectl-validate/pkg/openapiclient"
+)
+
+// ValidateSchemas validates resources against the built-in Kubernetes OpenAPI schemas
+// and the schemas of the CRDs found in the given directories.
+func ValidateSchemas(resources []*unstructured.Unstructured, crdPaths ...string) error {
+	clients := []openapi.Client{openapiclient.NewHardcodedBuiltins("1.30")}
+	for _, path := range crdPaths {
+		fi, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("failed to read CRD schemas (%w)", err)
+		}
+		if !fi.IsDir() {
+			return fmt.Errorf("failed to read CRD schemas (%s is not a directory)", path)
+		}
+		clients = append(clients, openapiclient.NewLocalCRDFiles(os.DirFS(path)))
+	}
+	loader, err := resourceloader.New(openapiclient.NewComposite(clients...))
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, resource := range resources {
+		if resource == nil {
+			continue
+		}
+		document, err := resource.MarshalJSON()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if _, _, err := loader.Load(document); err != nil {
+			errs = append(errs, fmt.Errorf("%s/%s/%s: %w", resource.GetNamespace(), resource.GetKind(), resource.GetName(), err))
+		}
+	}
+	return multierr.Combine(errs...)
+}
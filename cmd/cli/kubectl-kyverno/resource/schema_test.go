@@ -0,0 +1,68 @@
+package resource
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestValidateSchemas(t *testing.T) {
+	pod := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]interface{}{
+			"name": "test",
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{
+					"name":  "nginx",
+					"image": "nginx",
+				},
+			},
+		},
+	}}
+	typo := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]interface{}{
+			"name": "typo",
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{
+					"name":   "nginx",
+					"imagee": "nginx",
+				},
+			},
+		},
+	}}
+	tests := []struct {
+		name      string
+		resources []*unstructured.Unstructured
+		crdPaths  []string
+		wantErr   bool
+	}{{
+		name:      "nil",
+		resources: nil,
+	}, {
+		name:      "valid",
+		resources: []*unstructured.Unstructured{pod},
+	}, {
+		name:      "unknown field",
+		resources: []*unstructured.Unstructured{pod, typo},
+		wantErr:   true,
+	}, {
+		name:      "crd path not found",
+		resources: []*unstructured.Unstructured{pod},
+		crdPaths:  []string{"../_testdata/not-found"},
+		wantErr:   true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateSchemas(tt.resources, tt.crdPaths...); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSchemas() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
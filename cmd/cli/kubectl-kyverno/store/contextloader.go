@@ -7,6 +7,7 @@ import (
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/factories"
+	"github.com/kyverno/kyverno/pkg/engine/grpccall"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 )
 
@@ -14,6 +15,7 @@ func ContextLoaderFactory(s *Store, cmResolver engineapi.ConfigmapResolver) engi
 	if !s.IsLocal() {
 		return factories.DefaultContextLoaderFactory(cmResolver)
 	}
+	grpcClient := grpccall.NewClient(nil, nil)
 	return func(policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) engineapi.ContextLoader {
 		init := func(jsonContext enginecontext.Interface) error {
 			rule := s.GetPolicyRule(policy.GetName(), rule.Name)
@@ -34,7 +36,7 @@ func ContextLoaderFactory(s *Store, cmResolver engineapi.ConfigmapResolver) engi
 			}
 			return nil
 		}
-		factory := factories.DefaultContextLoaderFactory(cmResolver, factories.WithInitializer(init))
+		factory := factories.DefaultContextLoaderFactory(cmResolver, factories.WithInitializer(init), factories.WithGRPCClient(grpcClient))
 		return wrapper{
 			store: s,
 			inner: factory(policy, rule),
@@ -0,0 +1,150 @@
+package userinfo
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
+	"github.com/kyverno/kyverno/pkg/userinfo"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// FromKubeconfig derives user infos from the user of the active context in the given kubeconfig.
+// The username and groups are taken from the client certificate subject when available,
+// then from the impersonation settings and finally from the user entry itself.
+func FromKubeconfig(kubeconfig string, context string) (*v1alpha1.UserInfo, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfig
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: context}
+	rawConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).RawConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig (%w)", err)
+	}
+	if context == "" {
+		context = rawConfig.CurrentContext
+	}
+	kubeContext, ok := rawConfig.Contexts[context]
+	if !ok || kubeContext == nil {
+		return nil, fmt.Errorf("context %s not found in kubeconfig", context)
+	}
+	authInfo, ok := rawConfig.AuthInfos[kubeContext.AuthInfo]
+	if !ok || authInfo == nil {
+		return nil, fmt.Errorf("user %s not found in kubeconfig", kubeContext.AuthInfo)
+	}
+	username, groups, err := fromAuthInfo(authInfo)
+	if err != nil {
+		return nil, err
+	}
+	if username == "" {
+		username = kubeContext.AuthInfo
+	}
+	var userInfo v1alpha1.UserInfo
+	userInfo.AdmissionUserInfo.Username = username
+	userInfo.AdmissionUserInfo.Groups = groups
+	return &userInfo, nil
+}
+
+func fromAuthInfo(authInfo *clientcmdapi.AuthInfo) (string, []string, error) {
+	if authInfo.Impersonate != "" {
+		return authInfo.Impersonate, authInfo.ImpersonateGroups, nil
+	}
+	certData := authInfo.ClientCertificateData
+	if len(certData) == 0 && authInfo.ClientCertificate != "" {
+		data, err := os.ReadFile(filepath.Clean(authInfo.ClientCertificate))
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to read client certificate (%w)", err)
+		}
+		certData = data
+	}
+	if len(certData) != 0 {
+		block, _ := pem.Decode(certData)
+		if block == nil {
+			return "", nil, fmt.Errorf("failed to decode client certificate")
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to parse client certificate (%w)", err)
+		}
+		return cert.Subject.CommonName, cert.Subject.Organization, nil
+	}
+	return authInfo.Username, nil, nil
+}
+
+// Impersonate overrides the username and groups of the given user infos, the same way
+// the API server does when a request is sent with impersonation headers. The roles of the
+// original user don't apply to the impersonated user, they are cleared and can be resolved
+// with ResolveRoles.
+func Impersonate(userInfo *v1alpha1.UserInfo, username string, groups []string) *v1alpha1.UserInfo {
+	if userInfo == nil {
+		userInfo = &v1alpha1.UserInfo{}
+	}
+	userInfo.Roles = nil
+	userInfo.ClusterRoles = nil
+	userInfo.AdmissionUserInfo.Username = username
+	userInfo.AdmissionUserInfo.UID = ""
+	userInfo.AdmissionUserInfo.Extra = nil
+	userInfo.AdmissionUserInfo.Groups = append([]string{}, groups...)
+	if username != user.Anonymous {
+		userInfo.AdmissionUserInfo.Groups = append(userInfo.AdmissionUserInfo.Groups, user.AllAuthenticated)
+	}
+	return userInfo
+}
+
+// ResolveRoles sets the roles and cluster roles bound to the user of the given user infos in the cluster.
+func ResolveRoles(ctx context.Context, client kubernetes.Interface, userInfo *v1alpha1.UserInfo) error {
+	roles, clusterRoles, err := userinfo.GetRoleRef(
+		roleBindingLister{ctx: ctx, client: client},
+		clusterRoleBindingLister{ctx: ctx, client: client},
+		userInfo.AdmissionUserInfo,
+	)
+	if err != nil {
+		return err
+	}
+	userInfo.Roles = roles
+	userInfo.ClusterRoles = clusterRoles
+	return nil
+}
+
+type roleBindingLister struct {
+	ctx    context.Context //nolint:containedctx
+	client kubernetes.Interface
+}
+
+func (l roleBindingLister) List(selector labels.Selector) ([]*rbacv1.RoleBinding, error) {
+	list, err := l.client.RbacV1().RoleBindings(metav1.NamespaceAll).List(l.ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	bindings := make([]*rbacv1.RoleBinding, 0, len(list.Items))
+	for i := range list.Items {
+		bindings = append(bindings, &list.Items[i])
+	}
+	return bindings, nil
+}
+
+type clusterRoleBindingLister struct {
+	ctx    context.Context //nolint:containedctx
+	client kubernetes.Interface
+}
+
+func (l clusterRoleBindingLister) List(selector labels.Selector) ([]*rbacv1.ClusterRoleBinding, error) {
+	list, err := l.client.RbacV1().ClusterRoleBindings().List(l.ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	bindings := make([]*rbacv1.ClusterRoleBinding, 0, len(list.Items))
+	for i := range list.Items {
+		bindings = append(bindings, &list.Items[i])
+	}
+	return bindings, nil
+}
@@ -0,0 +1,170 @@
+package userinfo
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/cmd/cli/kubectl-kyverno/apis/v1alpha1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestFromKubeconfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		kubeconfig string
+		context    string
+		want       *v1alpha1.UserInfo
+		wantErr    bool
+	}{{
+		name:       "current context",
+		kubeconfig: "../_testdata/user-infos/kubeconfig.yaml",
+		context:    "",
+		want: &v1alpha1.UserInfo{
+			RequestInfo: kyvernov2.RequestInfo{
+				AdmissionUserInfo: authenticationv1.UserInfo{
+					Username: "molybdenum@somecorp.com",
+				},
+			},
+		},
+	}, {
+		name:       "user name fallback",
+		kubeconfig: "../_testdata/user-infos/kubeconfig.yaml",
+		context:    "admin",
+		want: &v1alpha1.UserInfo{
+			RequestInfo: kyvernov2.RequestInfo{
+				AdmissionUserInfo: authenticationv1.UserInfo{
+					Username: "admin",
+				},
+			},
+		},
+	}, {
+		name:       "impersonated",
+		kubeconfig: "../_testdata/user-infos/kubeconfig.yaml",
+		context:    "impersonated",
+		want: &v1alpha1.UserInfo{
+			RequestInfo: kyvernov2.RequestInfo{
+				AdmissionUserInfo: authenticationv1.UserInfo{
+					Username: "system:serviceaccount:default:builder",
+					Groups:   []string{"builders"},
+				},
+			},
+		},
+	}, {
+		name:       "unknown context",
+		kubeconfig: "../_testdata/user-infos/kubeconfig.yaml",
+		context:    "unknown",
+		wantErr:    true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromKubeconfig(tt.kubeconfig, tt.context)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FromKubeconfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FromKubeconfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImpersonate(t *testing.T) {
+	tests := []struct {
+		name     string
+		userInfo *v1alpha1.UserInfo
+		username string
+		groups   []string
+		want     *v1alpha1.UserInfo
+	}{{
+		name:     "nil",
+		userInfo: nil,
+		username: "jane",
+		groups:   []string{"devs"},
+		want: &v1alpha1.UserInfo{
+			RequestInfo: kyvernov2.RequestInfo{
+				AdmissionUserInfo: authenticationv1.UserInfo{
+					Username: "jane",
+					Groups:   []string{"devs", "system:authenticated"},
+				},
+			},
+		},
+	}, {
+		name: "clears roles",
+		userInfo: &v1alpha1.UserInfo{
+			RequestInfo: kyvernov2.RequestInfo{
+				Roles:        []string{"default:admin"},
+				ClusterRoles: []string{"cluster-admin"},
+				AdmissionUserInfo: authenticationv1.UserInfo{
+					Username: "molybdenum@somecorp.com",
+					UID:      "1234",
+				},
+			},
+		},
+		username: "jane",
+		want: &v1alpha1.UserInfo{
+			RequestInfo: kyvernov2.RequestInfo{
+				AdmissionUserInfo: authenticationv1.UserInfo{
+					Username: "jane",
+					Groups:   []string{"system:authenticated"},
+				},
+			},
+		},
+	}, {
+		name:     "anonymous",
+		userInfo: nil,
+		username: "system:anonymous",
+		want: &v1alpha1.UserInfo{
+			RequestInfo: kyvernov2.RequestInfo{
+				AdmissionUserInfo: authenticationv1.UserInfo{
+					Username: "system:anonymous",
+					Groups:   []string{},
+				},
+			},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Impersonate(tt.userInfo, tt.username, tt.groups); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Impersonate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveRoles(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "admins"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "molybdenum@somecorp.com"}},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "devs"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "devs"}},
+			RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "dev"},
+		},
+	)
+	userInfo := Impersonate(&v1alpha1.UserInfo{
+		RequestInfo: kyvernov2.RequestInfo{
+			ClusterRoles: []string{"cluster-admin"},
+			AdmissionUserInfo: authenticationv1.UserInfo{
+				Username: "molybdenum@somecorp.com",
+			},
+		},
+	}, "jane", []string{"devs"})
+	if err := ResolveRoles(context.TODO(), client, userInfo); err != nil {
+		t.Fatalf("ResolveRoles() error = %v", err)
+	}
+	if !reflect.DeepEqual(userInfo.Roles, []string{"default:dev"}) {
+		t.Errorf("ResolveRoles() roles = %v, want %v", userInfo.Roles, []string{"default:dev"})
+	}
+	if len(userInfo.ClusterRoles) != 0 {
+		t.Errorf("ResolveRoles() cluster roles = %v, want none", userInfo.ClusterRoles)
+	}
+}
@@ -15,6 +15,9 @@ type Configuration interface {
 	UsesCosign() bool
 	UsesRegistryClient() bool
 	UsesImageVerifyCache() bool
+	UsesEngineResultCache() bool
+	UsesWasm() bool
+	UsesGlobalContext() bool
 	UsesLeaderElection() bool
 	UsesKyvernoClient() bool
 	UsesDynamicClient() bool
@@ -96,6 +99,24 @@ func WithImageVerifyCache() ConfigurationOption {
 	}
 }
 
+func WithEngineResultCache() ConfigurationOption {
+	return func(c *configuration) {
+		c.usesEngineResultCache = true
+	}
+}
+
+func WithWasm() ConfigurationOption {
+	return func(c *configuration) {
+		c.usesWasm = true
+	}
+}
+
+func WithGlobalContext() ConfigurationOption {
+	return func(c *configuration) {
+		c.usesGlobalContext = true
+	}
+}
+
 func WithLeaderElection() ConfigurationOption {
 	return func(c *configuration) {
 		c.usesLeaderElection = true
@@ -163,6 +184,9 @@ type configuration struct {
 	usesCosign               bool
 	usesRegistryClient       bool
 	usesImageVerifyCache     bool
+	usesEngineResultCache    bool
+	usesWasm                 bool
+	usesGlobalContext        bool
 	usesLeaderElection       bool
 	usesKyvernoClient        bool
 	usesDynamicClient        bool
@@ -214,6 +238,18 @@ func (c *configuration) UsesImageVerifyCache() bool {
 	return c.usesImageVerifyCache
 }
 
+func (c *configuration) UsesEngineResultCache() bool {
+	return c.usesEngineResultCache
+}
+
+func (c *configuration) UsesWasm() bool {
+	return c.usesWasm
+}
+
+func (c *configuration) UsesGlobalContext() bool {
+	return c.usesGlobalContext
+}
+
 func (c *configuration) UsesLeaderElection() bool {
 	return c.usesLeaderElection
 }
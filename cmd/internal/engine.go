@@ -17,8 +17,11 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/apicall"
 	"github.com/kyverno/kyverno/pkg/engine/context/loaders"
 	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
+	"github.com/kyverno/kyverno/pkg/engine/externaldata"
 	"github.com/kyverno/kyverno/pkg/engine/factories"
+	"github.com/kyverno/kyverno/pkg/engine/grpccall"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/secretstore"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/registryclient"
 	"k8s.io/client-go/kubernetes"
@@ -42,6 +45,13 @@ func NewEngine(
 	gctxStore loaders.Store,
 ) engineapi.Engine {
 	configMapResolver := NewConfigMapResolver(ctx, logger, kubeClient, resyncPeriod)
+	rclientFactory := factories.DefaultRegistryClientFactory(adapters.RegistryClient(rclient), secretLister)
+	wasmEvaluator := setupWasm(ctx, logger, kubeClient, rclientFactory)
+	secretStoreClient, err := secretstore.NewClient(configuration)
+	checkError(logger, err, "failed to create secret store client")
+	secretResolver, err := resolvers.NewSecretResolver(secretLister, kubeClient)
+	checkError(logger, err, "failed to create secret resolver")
+	externalDataClient := NewExternalDataClient(ctx, logger, kyvernoClient, secretResolver, resyncPeriod)
 	logger = logger.WithName("engine")
 	logger.Info("setup engine...")
 	return engine.NewEngine(
@@ -49,10 +59,21 @@ func NewEngine(
 		metricsConfiguration,
 		jp,
 		adapters.Client(client),
-		factories.DefaultRegistryClientFactory(adapters.RegistryClient(rclient), secretLister),
+		rclientFactory,
 		ivCache,
-		factories.DefaultContextLoaderFactory(configMapResolver, factories.WithAPICallConfig(apiCallConfig), factories.WithGlobalContextStore(gctxStore)),
+		factories.DefaultContextLoaderFactory(
+			configMapResolver,
+			factories.WithAPICallConfig(apiCallConfig),
+			factories.WithGlobalContextStore(gctxStore),
+			factories.WithConfiguration(configuration),
+			factories.WithSecretStore(secretStoreClient),
+			factories.WithExternalDataClient(externalDataClient),
+			factories.WithGRPCClient(grpccall.NewClient(secretResolver, configuration)),
+		),
 		exceptionsSelector,
+		setupEngineResultCache(logger),
+		wasmEvaluator,
+		secretResolver,
 	)
 }
 
@@ -108,3 +129,34 @@ func NewConfigMapResolver(
 	}
 	return configMapResolver
 }
+
+// NewExternalDataClient returns a client querying external data providers, the providers informer is only
+// started on the first query so that startup doesn't depend on the Provider CRD being served.
+func NewExternalDataClient(
+	ctx context.Context,
+	logger logr.Logger,
+	kyvernoClient versioned.Interface,
+	secretResolver engineapi.SecretResolver,
+	resyncPeriod time.Duration,
+) externaldata.Client {
+	logger = logger.WithName("external-data-client")
+	logger.Info("setup external data client...")
+	if kyvernoClient == nil {
+		return nil
+	}
+	factory := kyvernoinformer.NewSharedInformerFactory(kyvernoClient, resyncPeriod)
+	lister := factory.Kyverno().V2alpha1().Providers().Lister()
+	waitSync := func(queryCtx context.Context) bool {
+		// informers live as long as the engine, waiting is bounded by the query context
+		factory.Start(ctx.Done())
+		for _, synced := range factory.WaitForCacheSync(queryCtx.Done()) {
+			if !synced {
+				return false
+			}
+		}
+		return true
+	}
+	client, err := externaldata.NewClient(lister, secretResolver, waitSync)
+	checkError(logger, err, "failed to create external data client")
+	return client
+}
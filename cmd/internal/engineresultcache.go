@@ -0,0 +1,18 @@
+package internal
+
+import (
+	"github.com/go-logr/logr"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/resultcache"
+)
+
+func setupEngineResultCache(logger logr.Logger) engineapi.ResultCache {
+	if !engineResultCacheEnabled {
+		return nil
+	}
+	logger = logger.WithName("engine-result-cache").WithValues("maxsize", engineResultCacheMaxSize, "ttl", engineResultCacheTTLDuration)
+	logger.Info("setup engine result cache...")
+	resultCache, err := resultcache.New(engineResultCacheMaxSize, engineResultCacheTTLDuration)
+	checkError(logger, err, "failed to create engine result cache")
+	return resultCache
+}
@@ -28,6 +28,7 @@ var (
 	otel                 string
 	otelCollector        string
 	metricsPort          string
+	metricsAddress       string
 	transportCreds       string
 	disableMetricsExport bool
 	// kubeconfig
@@ -41,10 +42,11 @@ var (
 	exceptionNamespace     string
 	enableConfigMapCaching bool
 	// cosign
-	enableTUF  bool
-	tufMirror  string
-	tufRoot    string
-	tufRootRaw string
+	enableTUF           bool
+	tufMirror           string
+	tufRoot             string
+	tufRootRaw          string
+	sigstoreTrustedRoot string
 	// registry client
 	imagePullSecrets          string
 	allowInsecureRegistry     bool
@@ -57,8 +59,22 @@ var (
 	imageVerifyCacheEnabled     bool
 	imageVerifyCacheTTLDuration time.Duration
 	imageVerifyCacheMaxSize     int64
+	imageVerifyCacheBackend     string
+	imageVerifyCacheRedisURL    string
+	// engine result cache
+	engineResultCacheEnabled     bool
+	engineResultCacheTTLDuration time.Duration
+	engineResultCacheMaxSize     int64
+	// wasm
+	wasmEnabled           bool
+	wasmModulesConfigMap  string
+	wasmAllowedRegistries string
+	wasmMemoryLimitPages  uint
+	wasmTimeout           time.Duration
 	// global context
-	enableGlobalContext bool
+	enableGlobalContext           bool
+	globalContextSnapshot         bool
+	globalContextSnapshotInterval time.Duration
 	// reporting
 	enableReporting string
 	// resync
@@ -90,6 +106,7 @@ func initMetricsFlags() {
 	flag.StringVar(&otelCollector, "otelCollector", "opentelemetrycollector.kyverno.svc.cluster.local", "Set this flag to the OpenTelemetry Collector Service Address. Kyverno will try to connect to this on the metrics port.")
 	flag.StringVar(&transportCreds, "transportCreds", "", "Set this flag to the CA secret containing the certificate which is used by our Opentelemetry Metrics Client. If empty string is set, means an insecure connection will be used")
 	flag.StringVar(&metricsPort, "metricsPort", "8000", "Expose prometheus metrics at the given port, default to 8000.")
+	flag.StringVar(&metricsAddress, "metricsAddress", "", "IP address the prometheus metrics server binds to, all interfaces are used when empty. The IPv6 unspecified address :: is dual-stack and also accepts IPv4 connections, use 0.0.0.0 to restrict the server to IPv4.")
 	flag.BoolVar(&disableMetricsExport, "disableMetrics", false, "Set this flag to 'true' to disable metrics.")
 }
 
@@ -120,6 +137,7 @@ func initCosignFlags() {
 	flag.StringVar(&tufMirror, "tufMirror", tuf.DefaultRemoteRoot, "Alternate TUF mirror for sigstore. If left blank, public sigstore one is used for cosign verification.")
 	flag.StringVar(&tufRoot, "tufRoot", "", "Path to alternate TUF root.json for sigstore (url or env). If left blank, public sigstore one is used for cosign verification.")
 	flag.StringVar(&tufRootRaw, "tufRootRaw", "", "The raw body of alternate TUF root.json for sigstore. If left blank, public sigstore one is used for cosign verification.")
+	flag.StringVar(&sigstoreTrustedRoot, "sigstoreTrustedRoot", "", "Path to a sigstore trusted_root.json (url or file) used to verify sigstore bundles offline. If left blank, the trusted root is fetched from TUF.")
 }
 
 func initRegistryClientFlags() {
@@ -132,6 +150,27 @@ func initImageVerifyCacheFlags() {
 	flag.BoolVar(&imageVerifyCacheEnabled, "imageVerifyCacheEnabled", true, "Enable a TTL cache for verified images.")
 	flag.Int64Var(&imageVerifyCacheMaxSize, "imageVerifyCacheMaxSize", 1000, "Maximum number of keys that can be stored in the TTL cache. Keys are a combination of policy elements along with the image reference. Default is 1000. 0 sets the value to default.")
 	flag.DurationVar(&imageVerifyCacheTTLDuration, "imageVerifyCacheTTLDuration", 60*time.Minute, "Maximum TTL value for a cache expressed as duration. Default is 60m. 0 sets the value to default.")
+	flag.StringVar(&imageVerifyCacheBackend, "imageVerifyCacheBackend", "memory", "Backend storing the image verify cache entries (memory, redis). The redis backend survives restarts and is shared across replicas.")
+	flag.StringVar(&imageVerifyCacheRedisURL, "imageVerifyCacheRedisURL", "", "URL of the redis server used by the redis image verify cache backend, in the form redis://host[:port][/db]. The password is read from the IMAGE_VERIFY_CACHE_REDIS_PASSWORD environment variable and entries are signed with the key read from the IMAGE_VERIFY_CACHE_HMAC_KEY environment variable.")
+}
+
+func initEngineResultCacheFlags() {
+	flag.BoolVar(&engineResultCacheEnabled, "engineResultCacheEnabled", false, "Enable a short lived cache of policy results, identical objects evaluated several times within an admission request and its reinvocations are only evaluated once.")
+	flag.Int64Var(&engineResultCacheMaxSize, "engineResultCacheMaxSize", 1000, "Maximum number of policy results that can be stored in the engine result cache. Default is 1000. 0 sets the value to default.")
+	flag.DurationVar(&engineResultCacheTTLDuration, "engineResultCacheTTLDuration", 10*time.Second, "Maximum TTL value for the engine result cache expressed as duration. Default is 10s. 0 sets the value to default.")
+}
+
+func initWasmFlags() {
+	flag.BoolVar(&wasmEnabled, "enableWasm", false, "Enable validate.wasm rules running WebAssembly modules.")
+	flag.StringVar(&wasmModulesConfigMap, "wasmModulesConfigMap", "kyverno-wasm-modules", "Name of the ConfigMap in the Kyverno namespace storing WASM modules in its binaryData.")
+	flag.StringVar(&wasmAllowedRegistries, "wasmAllowedRegistries", "", "Comma separated list of registries WASM modules referenced with wasm.image can be pulled from, modules must be referenced by digest. Modules can only be loaded from the ConfigMap when empty.")
+	flag.UintVar(&wasmMemoryLimitPages, "wasmMemoryLimitPages", 256, "Maximum number of 64KiB memory pages a WASM module can allocate.")
+	flag.DurationVar(&wasmTimeout, "wasmTimeout", time.Second, "Maximum duration of a WASM module evaluation.")
+}
+
+func initGlobalContextFlags() {
+	flag.BoolVar(&globalContextSnapshot, "globalContextSnapshot", false, "Persist the data of the global context entries in a secret and load it at startup, entries serve the persisted data until they are refreshed.")
+	flag.DurationVar(&globalContextSnapshotInterval, "globalContextSnapshotInterval", time.Minute, "Interval at which the global context snapshot is written.")
 }
 
 func initLeaderElectionFlags() {
@@ -225,6 +264,18 @@ func initFlags(config Configuration, opts ...Option) {
 	if config.UsesImageVerifyCache() {
 		initImageVerifyCacheFlags()
 	}
+	// engine result cache
+	if config.UsesEngineResultCache() {
+		initEngineResultCacheFlags()
+	}
+	// wasm
+	if config.UsesWasm() {
+		initWasmFlags()
+	}
+	// global context
+	if config.UsesGlobalContext() {
+		initGlobalContextFlags()
+	}
 	// leader election
 	if config.UsesLeaderElection() {
 		initLeaderElectionFlags()
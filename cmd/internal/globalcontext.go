@@ -0,0 +1,39 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/globalcontext/snapshot"
+	"github.com/kyverno/kyverno/pkg/globalcontext/store"
+	"k8s.io/client-go/kubernetes"
+)
+
+// NewGlobalContextSnapshot loads the global context snapshot stored in the named secret in the store and returns
+// the controller keeping the snapshot up to date, it returns nil when snapshots are disabled.
+// It must be called once the global context entries informer has synced and before the global context controller runs.
+func NewGlobalContextSnapshot(
+	ctx context.Context,
+	logger logr.Logger,
+	kubeClient kubernetes.Interface,
+	name string,
+	gceLister kyvernov2alpha1listers.GlobalContextEntryLister,
+	storage store.Store,
+) Controller {
+	if !globalContextSnapshot {
+		return nil
+	}
+	logger = logger.WithName("globalcontext-snapshot").WithValues("secret", name, "interval", globalContextSnapshotInterval)
+	client := kubeClient.CoreV1().Secrets(config.KyvernoNamespace())
+	if err := snapshot.Load(ctx, logger, client, name, gceLister, storage); err != nil {
+		// entries are still loaded by the global context controller
+		logger.Error(err, "failed to load global context snapshot")
+	}
+	return NewController(
+		"globalcontext-snapshot",
+		snapshot.NewController(client, name, gceLister, storage, globalContextSnapshotInterval, logger),
+		1,
+	)
+}
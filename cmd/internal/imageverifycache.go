@@ -1,12 +1,20 @@
 package internal
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 )
 
+const (
+	imageVerifyCacheRedisPasswordEnv = "IMAGE_VERIFY_CACHE_REDIS_PASSWORD"
+	imageVerifyCacheHMACKeyEnv       = "IMAGE_VERIFY_CACHE_HMAC_KEY"
+)
+
 func setupImageVerifyCache(logger logr.Logger) imageverifycache.Client {
-	logger = logger.WithName("image-verify-cache").WithValues("enabled", imageVerifyCacheEnabled, "maxsize", imageVerifyCacheMaxSize, "ttl", imageVerifyCacheTTLDuration)
+	logger = logger.WithName("image-verify-cache").WithValues("enabled", imageVerifyCacheEnabled, "maxsize", imageVerifyCacheMaxSize, "ttl", imageVerifyCacheTTLDuration, "backend", imageVerifyCacheBackend)
 	logger.Info("setup image verify cache...")
 	opts := []imageverifycache.Option{
 		imageverifycache.WithLogger(logger),
@@ -14,6 +22,21 @@ func setupImageVerifyCache(logger logr.Logger) imageverifycache.Client {
 		imageverifycache.WithMaxSize(imageVerifyCacheMaxSize),
 		imageverifycache.WithTTLDuration(imageVerifyCacheTTLDuration),
 	}
+	switch imageVerifyCacheBackend {
+	case "", "memory":
+	case "redis":
+		// credentials are never passed on the command line, they come from the environment (usually set from a secret)
+		backend, err := imageverifycache.NewRedisBackend(
+			imageVerifyCacheRedisURL,
+			os.Getenv(imageVerifyCacheRedisPasswordEnv),
+			[]byte(os.Getenv(imageVerifyCacheHMACKeyEnv)),
+			imageVerifyCacheMaxSize,
+		)
+		checkError(logger, err, "failed to create image verify cache redis backend")
+		opts = append(opts, imageverifycache.WithBackend(backend))
+	default:
+		checkError(logger, fmt.Errorf("unsupported backend %s", imageVerifyCacheBackend), "failed to create image verify cache client")
+	}
 	imageVerifyCache, err := imageverifycache.New(opts...)
 	checkError(logger, err, "failed to create image verify cache client")
 	return imageVerifyCache
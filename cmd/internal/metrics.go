@@ -2,6 +2,7 @@ package internal
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"time"
 
@@ -9,14 +10,19 @@ import (
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/metrics"
+	netutils "github.com/kyverno/kyverno/pkg/utils/net"
 	otlp "go.opentelemetry.io/otel"
 	"k8s.io/client-go/kubernetes"
 )
 
 func SetupMetrics(ctx context.Context, logger logr.Logger, metricsConfiguration config.MetricsConfiguration, kubeClient kubernetes.Interface) (metrics.MetricsConfigManager, context.CancelFunc) {
 	logger = logger.WithName("metrics")
-	logger.Info("setup metrics...", "otel", otel, "port", metricsPort, "collector", otelCollector, "creds", transportCreds)
+	logger.Info("setup metrics...", "otel", otel, "address", metricsAddress, "port", metricsPort, "collector", otelCollector, "creds", transportCreds)
 	metricsAddr := ":" + metricsPort
+	// the bind address only applies to the prometheus server, the port is also used to reach the collector
+	bindAddress, err := netutils.ParseBindAddress(metricsAddress)
+	checkError(logger, err, "invalid metrics address")
+	listenAddr := net.JoinHostPort(bindAddress, metricsPort)
 	metricsConfig, metricsServerMux, metricsPusher, err := metrics.InitMetrics(
 		ctx,
 		disableMetricsExport,
@@ -42,7 +48,7 @@ func SetupMetrics(ctx context.Context, logger logr.Logger, metricsConfiguration
 	if otel == "prometheus" {
 		go func() {
 			server := &http.Server{
-				Addr:              metricsAddr,
+				Addr:              listenAddr,
 				Handler:           metricsServerMux,
 				ReadTimeout:       30 * time.Second,
 				WriteTimeout:      30 * time.Second,
@@ -51,7 +57,7 @@ func SetupMetrics(ctx context.Context, logger logr.Logger, metricsConfiguration
 				ErrorLog:          logging.StdLogger(logging.WithName("prometheus-server"), ""),
 			}
 			if err := server.ListenAndServe(); err != nil {
-				logger.Error(err, "failed to enable metrics", "address", metricsAddr)
+				logger.Error(err, "failed to enable metrics", "address", listenAddr)
 			}
 		}()
 	}
@@ -0,0 +1,32 @@
+package internal
+
+import (
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/event"
+)
+
+// OmitEventsSetter replaces the reasons of the events that are not emitted
+type OmitEventsSetter interface {
+	SetOmitEvents(...string)
+}
+
+// ReloadOmitEvents keeps the events omitted by the generator in sync with the configuration, the events omitted
+// by the flag apply when the configuration doesn't set them
+func ReloadOmitEvents(configuration config.Configuration, generator OmitEventsSetter, omitEvents ...string) {
+	apply := func() {
+		if configured, ok := configuration.GetOmitEvents(); ok {
+			generator.SetOmitEvents(configured...)
+		} else {
+			generator.SetOmitEvents(omitEvents...)
+		}
+	}
+	apply()
+	configuration.OnSettingsChanged(func([]config.SettingChange) { apply() })
+}
+
+// EmitSettingsEvents emits an event on the kyverno configmap every time settings overriding flags are changed
+func EmitSettingsEvents(configuration config.Configuration, generator event.Interface, source event.Source) {
+	configuration.OnSettingsChanged(func(changes []config.SettingChange) {
+		generator.Add(event.NewSettingsChangedEvent(source, config.KyvernoNamespace(), config.KyvernoConfigMapName(), changes...))
+	})
+}
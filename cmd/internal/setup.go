@@ -12,6 +12,8 @@ import (
 	kyvernoclient "github.com/kyverno/kyverno/pkg/clients/kyverno"
 	metadataclient "github.com/kyverno/kyverno/pkg/clients/metadata"
 	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/context/resolvers"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/metrics"
@@ -43,6 +45,7 @@ type SetupResult struct {
 	RegistryClient         registryclient.Client
 	ImageVerifyCacheClient imageverifycache.Client
 	RegistrySecretLister   corev1listers.SecretNamespaceLister
+	SecretResolver         engineapi.SecretResolver
 	KyvernoClient          kyvernoclient.UpstreamInterface
 	DynamicClient          dynamicclient.UpstreamInterface
 	ApiServerClient        apiserverclient.UpstreamInterface
@@ -73,6 +76,8 @@ func Setup(config Configuration, name string, skipResourceFilters bool) (context
 	if config.UsesRegistryClient() {
 		registryClient, registrySecretLister = setupRegistryClient(ctx, logger, client)
 	}
+	secretResolver, err := resolvers.NewSecretResolver(registrySecretLister, client)
+	checkError(logger, err, "failed to create secret resolver")
 	var imageVerifyCache imageverifycache.Client
 	if config.UsesImageVerifyCache() {
 		imageVerifyCache = setupImageVerifyCache(logger)
@@ -124,6 +129,7 @@ func Setup(config Configuration, name string, skipResourceFilters bool) (context
 			RegistryClient:         registryClient,
 			ImageVerifyCacheClient: imageVerifyCache,
 			RegistrySecretLister:   registrySecretLister,
+			SecretResolver:         secretResolver,
 			KyvernoClient:          kyvernoClient,
 			DynamicClient:          dynamicClient,
 			ApiServerClient:        apiServerClient,
@@ -6,11 +6,15 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/cosign"
 	"github.com/sigstore/cosign/v2/pkg/blob"
 	"github.com/sigstore/sigstore/pkg/tuf"
 )
 
 func setupSigstoreTUF(ctx context.Context, logger logr.Logger) {
+	if sigstoreTrustedRoot != "" {
+		setupSigstoreTrustedRoot(logger)
+	}
 	if !enableTUF {
 		return
 	}
@@ -37,3 +41,15 @@ func setupSigstoreTUF(ctx context.Context, logger logr.Logger) {
 		checkError(logger, err, fmt.Sprintf("Failed to initialize TUF client from %s : %v", tufRoot, err))
 	}
 }
+
+func setupSigstoreTrustedRoot(logger logr.Logger) {
+	logger = logger.WithName("sigstore-trusted-root").WithValues("sigstoreTrustedRoot", sigstoreTrustedRoot)
+	logger.Info("setup sigstore trusted root...")
+	trustedRootBytes, err := blob.LoadFileOrURL(sigstoreTrustedRoot)
+	if err != nil {
+		checkError(logger, err, fmt.Sprintf("Failed to read sigstore trusted root %s : %v", sigstoreTrustedRoot, err))
+	}
+	if err := cosign.SetTrustedRoot(trustedRootBytes); err != nil {
+		checkError(logger, err, fmt.Sprintf("Failed to parse sigstore trusted root %s : %v", sigstoreTrustedRoot, err))
+	}
+}
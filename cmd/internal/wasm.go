@@ -0,0 +1,44 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/wasm"
+	"github.com/kyverno/kyverno/pkg/informers"
+	"k8s.io/client-go/kubernetes"
+)
+
+func setupWasm(ctx context.Context, logger logr.Logger, kubeClient kubernetes.Interface, rclientFactory engineapi.RegistryClientFactory) engineapi.WasmEvaluator {
+	if !wasmEnabled {
+		return nil
+	}
+	logger = logger.WithName("wasm").WithValues("configmap", wasmModulesConfigMap, "allowedRegistries", wasmAllowedRegistries, "memoryLimitPages", wasmMemoryLimitPages, "timeout", wasmTimeout)
+	logger.Info("setup wasm evaluator...")
+	// modules are only read from the kyverno namespace, policies can't reference arbitrary config maps
+	configMaps := informers.NewConfigMapInformer(kubeClient, config.KyvernoNamespace(), wasmModulesConfigMap, resyncPeriod)
+	if !informers.StartInformersAndWaitForCacheSync(ctx, logger, configMaps) {
+		checkError(logger, errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+	}
+	var allowedRegistries []string
+	if wasmAllowedRegistries != "" {
+		allowedRegistries = strings.Split(wasmAllowedRegistries, ",")
+	}
+	evaluator, err := wasm.NewEvaluator(
+		ctx,
+		configMaps.Lister().ConfigMaps(config.KyvernoNamespace()),
+		wasmModulesConfigMap,
+		rclientFactory,
+		allowedRegistries,
+		wasm.Limits{
+			MemoryPages: uint32(wasmMemoryLimitPages),
+			Timeout:     wasmTimeout,
+		},
+	)
+	checkError(logger, err, "failed to create wasm evaluator")
+	return evaluator
+}
@@ -3,9 +3,14 @@ package main
 // We currently accept the risk of exposing pprof and rely on users to protect the endpoint.
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"flag"
+	"fmt"
+	"net"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -24,6 +29,7 @@ import (
 	globalcontextcontroller "github.com/kyverno/kyverno/pkg/controllers/globalcontext"
 	policymetricscontroller "github.com/kyverno/kyverno/pkg/controllers/metrics/policy"
 	policycachecontroller "github.com/kyverno/kyverno/pkg/controllers/policycache"
+	vapauditcontroller "github.com/kyverno/kyverno/pkg/controllers/report/vapaudit"
 	vapcontroller "github.com/kyverno/kyverno/pkg/controllers/validatingadmissionpolicy-generate"
 	webhookcontroller "github.com/kyverno/kyverno/pkg/controllers/webhook"
 	"github.com/kyverno/kyverno/pkg/engine/apicall"
@@ -31,26 +37,37 @@ import (
 	"github.com/kyverno/kyverno/pkg/globalcontext/store"
 	"github.com/kyverno/kyverno/pkg/informers"
 	"github.com/kyverno/kyverno/pkg/leaderelection"
+	"github.com/kyverno/kyverno/pkg/limiter"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/policycache"
+	"github.com/kyverno/kyverno/pkg/sharding"
 	"github.com/kyverno/kyverno/pkg/tls"
 	"github.com/kyverno/kyverno/pkg/toggle"
 	"github.com/kyverno/kyverno/pkg/utils/generator"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	netutils "github.com/kyverno/kyverno/pkg/utils/net"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	runtimeutils "github.com/kyverno/kyverno/pkg/utils/runtime"
 	"github.com/kyverno/kyverno/pkg/validatingadmissionpolicy"
 	"github.com/kyverno/kyverno/pkg/validation/exception"
 	"github.com/kyverno/kyverno/pkg/webhooks"
+	webhooksconfigmap "github.com/kyverno/kyverno/pkg/webhooks/configmap"
+	"github.com/kyverno/kyverno/pkg/webhooks/decisionlog"
+	"github.com/kyverno/kyverno/pkg/webhooks/dump"
 	webhooksexception "github.com/kyverno/kyverno/pkg/webhooks/exception"
 	webhooksglobalcontext "github.com/kyverno/kyverno/pkg/webhooks/globalcontext"
+	webhookshandlers "github.com/kyverno/kyverno/pkg/webhooks/handlers"
 	webhookspolicy "github.com/kyverno/kyverno/pkg/webhooks/policy"
 	webhooksresource "github.com/kyverno/kyverno/pkg/webhooks/resource"
 	webhookgenerate "github.com/kyverno/kyverno/pkg/webhooks/updaterequest"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiserver "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	kubeinformers "k8s.io/client-go/informers"
 	appsv1informers "k8s.io/client-go/informers/apps/v1"
+	coordinationv1informers "k8s.io/client-go/informers/coordination/v1"
 	corev1informers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
 	kyamlopenapi "sigs.k8s.io/kustomize/kyaml/openapi"
@@ -59,14 +76,26 @@ import (
 const (
 	exceptionWebhookControllerName   = "exception-webhook-controller"
 	gctxWebhookControllerName        = "global-context-webhook-controller"
+	configWebhookControllerName      = "config-webhook-controller"
 	webhookControllerFinalizerName   = "kyverno.io/webhooks"
 	exceptionControllerFinalizerName = "kyverno.io/exceptionwebhooks"
 	gctxControllerFinalizerName      = "kyverno.io/globalcontextwebhooks"
+	configControllerFinalizerName    = "kyverno.io/configwebhooks"
+	auditShardingGroup               = "audit"
+	auditShardingLeaseDuration       = 15 * time.Second
+	auditShardingTimeout             = 5 * time.Second
+	// the aggregation CA is published by the API server in this config map
+	authenticationConfigMapNamespace = "kube-system"
+	authenticationConfigMapName      = "extension-apiserver-authentication"
+	authenticationConfigMapCAKey     = "requestheader-client-ca-file"
+	authenticationConfigMapNamesKey  = "requestheader-allowed-names"
 )
 
 var (
-	caSecretName  string
-	tlsSecretName string
+	caSecretName            string
+	tlsSecretName           string
+	externalCertificatesDir string
+	certManagerIssuer       string
 )
 
 func showWarnings(ctx context.Context, logger logr.Logger) {
@@ -103,7 +132,15 @@ func createNonLeaderControllers(
 		}
 }
 
+// leader controller groups, each group can be led by a different replica with its own lease
+const (
+	leaderGroupCertManager = "certmanager"
+	leaderGroupWebhooks    = "webhooks"
+	leaderGroupVAP         = "vap"
+)
+
 func createrLeaderControllers(
+	groups sets.Set[string],
 	generateVAPs bool,
 	admissionReports bool,
 	serverIP string,
@@ -115,6 +152,7 @@ func createrLeaderControllers(
 	kyvernoInformer kyvernoinformer.SharedInformerFactory,
 	caInformer corev1informers.SecretInformer,
 	tlsInformer corev1informers.SecretInformer,
+	certificates tls.CertificateSource,
 	deploymentInformer appsv1informers.DeploymentInformer,
 	kubeClient kubernetes.Interface,
 	kyvernoClient versioned.Interface,
@@ -127,112 +165,154 @@ func createrLeaderControllers(
 	eventGenerator event.Interface,
 ) ([]internal.Controller, func(context.Context) error, error) {
 	var leaderControllers []internal.Controller
-	certManager := certmanager.NewController(
-		caInformer,
-		tlsInformer,
-		certRenewer,
-		caSecretName,
-		tlsSecretName,
-		config.KyvernoNamespace(),
-	)
-	webhookController := webhookcontroller.NewController(
-		dynamicClient.Discovery(),
-		kubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations(),
-		kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations(),
-		kubeClient.CoordinationV1().Leases(config.KyvernoNamespace()),
-		kyvernoClient,
-		kubeInformer.Admissionregistration().V1().MutatingWebhookConfigurations(),
-		kubeInformer.Admissionregistration().V1().ValidatingWebhookConfigurations(),
-		kyvernoInformer.Kyverno().V1().ClusterPolicies(),
-		kyvernoInformer.Kyverno().V1().Policies(),
-		deploymentInformer,
-		caInformer,
-		kubeKyvernoInformer.Coordination().V1().Leases(),
-		kubeInformer.Rbac().V1().ClusterRoles(),
-		kyvernoInformer.Kyverno().V2alpha1().GlobalContextEntries(),
-		serverIP,
-		int32(webhookTimeout), //nolint:gosec
-		servicePort,
-		webhookServerPort,
-		autoUpdateWebhooks,
-		autoDeleteWebhooks,
-		admissionReports,
-		runtime,
-		configuration,
-		caSecretName,
-		webhookcontroller.WebhookCleanupSetup(kubeClient, webhookControllerFinalizerName),
-		webhookcontroller.WebhookCleanupHandler(kubeClient, webhookControllerFinalizerName),
-	)
-	exceptionWebhookController := genericwebhookcontroller.NewController(
-		exceptionWebhookControllerName,
-		kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations(),
-		kubeInformer.Admissionregistration().V1().ValidatingWebhookConfigurations(),
-		caInformer,
-		deploymentInformer,
-		config.ExceptionValidatingWebhookConfigurationName,
-		config.ExceptionValidatingWebhookServicePath,
-		serverIP,
-		servicePort,
-		webhookServerPort,
-		nil,
-		[]admissionregistrationv1.RuleWithOperations{{
-			Rule: admissionregistrationv1.Rule{
-				APIGroups:   []string{"kyverno.io"},
-				APIVersions: []string{"v2alpha1", "v2beta1"},
-				Resources:   []string{"policyexceptions"},
-			},
-			Operations: []admissionregistrationv1.OperationType{
-				admissionregistrationv1.Create,
-				admissionregistrationv1.Update,
-			},
-		}},
-		genericwebhookcontroller.Fail,
-		genericwebhookcontroller.None,
-		configuration,
-		caSecretName,
-		runtime,
-		autoDeleteWebhooks,
-		webhookcontroller.WebhookCleanupSetup(kubeClient, exceptionControllerFinalizerName),
-		webhookcontroller.WebhookCleanupHandler(kubeClient, exceptionControllerFinalizerName),
-	)
-	gctxWebhookController := genericwebhookcontroller.NewController(
-		gctxWebhookControllerName,
-		kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations(),
-		kubeInformer.Admissionregistration().V1().ValidatingWebhookConfigurations(),
-		caInformer,
-		deploymentInformer,
-		config.GlobalContextValidatingWebhookConfigurationName,
-		config.GlobalContextValidatingWebhookServicePath,
-		serverIP,
-		servicePort,
-		webhookServerPort,
-		nil,
-		[]admissionregistrationv1.RuleWithOperations{{
-			Rule: admissionregistrationv1.Rule{
-				APIGroups:   []string{"kyverno.io"},
-				APIVersions: []string{"v2alpha1"},
-				Resources:   []string{"globalcontextentries"},
-			},
-			Operations: []admissionregistrationv1.OperationType{
-				admissionregistrationv1.Create,
-				admissionregistrationv1.Update,
+	if groups.Has(leaderGroupWebhooks) {
+		webhookController := webhookcontroller.NewController(
+			dynamicClient.Discovery(),
+			kubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations(),
+			kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations(),
+			kubeClient.CoordinationV1().Leases(config.KyvernoNamespace()),
+			kyvernoClient,
+			kubeInformer.Admissionregistration().V1().MutatingWebhookConfigurations(),
+			kubeInformer.Admissionregistration().V1().ValidatingWebhookConfigurations(),
+			kyvernoInformer.Kyverno().V1().ClusterPolicies(),
+			kyvernoInformer.Kyverno().V1().Policies(),
+			deploymentInformer,
+			certificates,
+			kubeKyvernoInformer.Coordination().V1().Leases(),
+			kubeInformer.Rbac().V1().ClusterRoles(),
+			kyvernoInformer.Kyverno().V2alpha1().GlobalContextEntries(),
+			serverIP,
+			int32(webhookTimeout), //nolint:gosec
+			servicePort,
+			webhookServerPort,
+			autoUpdateWebhooks,
+			autoDeleteWebhooks,
+			admissionReports,
+			runtime,
+			configuration,
+			webhookcontroller.WebhookCleanupSetup(kubeClient, webhookControllerFinalizerName),
+			webhookcontroller.WebhookCleanupHandler(kubeClient, webhookControllerFinalizerName),
+		)
+		exceptionWebhookController := genericwebhookcontroller.NewController(
+			exceptionWebhookControllerName,
+			kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations(),
+			kubeInformer.Admissionregistration().V1().ValidatingWebhookConfigurations(),
+			certificates,
+			deploymentInformer,
+			config.ExceptionValidatingWebhookConfigurationName,
+			config.ExceptionValidatingWebhookServicePath,
+			serverIP,
+			servicePort,
+			webhookServerPort,
+			nil,
+			nil,
+			[]admissionregistrationv1.RuleWithOperations{{
+				Rule: admissionregistrationv1.Rule{
+					APIGroups:   []string{"kyverno.io"},
+					APIVersions: []string{"v2alpha1", "v2beta1"},
+					Resources:   []string{"policyexceptions"},
+				},
+				Operations: []admissionregistrationv1.OperationType{
+					admissionregistrationv1.Create,
+					admissionregistrationv1.Update,
+				},
+			}},
+			genericwebhookcontroller.Fail,
+			genericwebhookcontroller.None,
+			configuration,
+			runtime,
+			autoDeleteWebhooks,
+			webhookcontroller.WebhookCleanupSetup(kubeClient, exceptionControllerFinalizerName),
+			webhookcontroller.WebhookCleanupHandler(kubeClient, exceptionControllerFinalizerName),
+		)
+		gctxWebhookController := genericwebhookcontroller.NewController(
+			gctxWebhookControllerName,
+			kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations(),
+			kubeInformer.Admissionregistration().V1().ValidatingWebhookConfigurations(),
+			certificates,
+			deploymentInformer,
+			config.GlobalContextValidatingWebhookConfigurationName,
+			config.GlobalContextValidatingWebhookServicePath,
+			serverIP,
+			servicePort,
+			webhookServerPort,
+			nil,
+			nil,
+			[]admissionregistrationv1.RuleWithOperations{{
+				Rule: admissionregistrationv1.Rule{
+					APIGroups:   []string{"kyverno.io"},
+					APIVersions: []string{"v2alpha1"},
+					Resources:   []string{"globalcontextentries"},
+				},
+				Operations: []admissionregistrationv1.OperationType{
+					admissionregistrationv1.Create,
+					admissionregistrationv1.Update,
+				},
+			}},
+			genericwebhookcontroller.Fail,
+			genericwebhookcontroller.None,
+			configuration,
+			runtime,
+			autoDeleteWebhooks,
+			webhookcontroller.WebhookCleanupSetup(kubeClient, gctxControllerFinalizerName),
+			webhookcontroller.WebhookCleanupHandler(kubeClient, gctxControllerFinalizerName),
+		)
+		configWebhookController := genericwebhookcontroller.NewController(
+			configWebhookControllerName,
+			kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations(),
+			kubeInformer.Admissionregistration().V1().ValidatingWebhookConfigurations(),
+			certificates,
+			deploymentInformer,
+			config.ConfigValidatingWebhookConfigurationName,
+			config.ConfigValidatingWebhookServicePath,
+			serverIP,
+			servicePort,
+			webhookServerPort,
+			nil,
+			&metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					corev1.LabelMetadataName: config.KyvernoNamespace(),
+				},
 			},
-		}},
-		genericwebhookcontroller.Fail,
-		genericwebhookcontroller.None,
-		configuration,
-		caSecretName,
-		runtime,
-		autoDeleteWebhooks,
-		webhookcontroller.WebhookCleanupSetup(kubeClient, gctxControllerFinalizerName),
-		webhookcontroller.WebhookCleanupHandler(kubeClient, gctxControllerFinalizerName),
-	)
-	leaderControllers = append(leaderControllers, internal.NewController(certmanager.ControllerName, certManager, certmanager.Workers))
-	leaderControllers = append(leaderControllers, internal.NewController(webhookcontroller.ControllerName, webhookController, webhookcontroller.Workers))
-	leaderControllers = append(leaderControllers, internal.NewController(exceptionWebhookControllerName, exceptionWebhookController, 1))
-	leaderControllers = append(leaderControllers, internal.NewController(gctxWebhookControllerName, gctxWebhookController, 1))
-
-	if generateVAPs {
+			[]admissionregistrationv1.RuleWithOperations{{
+				Rule: admissionregistrationv1.Rule{
+					APIGroups:   []string{""},
+					APIVersions: []string{"v1"},
+					Resources:   []string{"configmaps"},
+				},
+				Operations: []admissionregistrationv1.OperationType{
+					admissionregistrationv1.Create,
+					admissionregistrationv1.Update,
+				},
+			}},
+			// don't prevent fixing the config map when kyverno is down
+			genericwebhookcontroller.Ignore,
+			genericwebhookcontroller.None,
+			configuration,
+			runtime,
+			autoDeleteWebhooks,
+			webhookcontroller.WebhookCleanupSetup(kubeClient, configControllerFinalizerName),
+			webhookcontroller.WebhookCleanupHandler(kubeClient, configControllerFinalizerName),
+		)
+		leaderControllers = append(leaderControllers, internal.NewController(webhookcontroller.ControllerName, webhookController, webhookcontroller.Workers))
+		leaderControllers = append(leaderControllers, internal.NewController(exceptionWebhookControllerName, exceptionWebhookController, 1))
+		leaderControllers = append(leaderControllers, internal.NewController(gctxWebhookControllerName, gctxWebhookController, 1))
+		leaderControllers = append(leaderControllers, internal.NewController(configWebhookControllerName, configWebhookController, 1))
+	}
+	// certificates are not managed by kyverno when they are mounted from files or issued by cert-manager
+	if certRenewer != nil && groups.Has(leaderGroupCertManager) {
+		certManager := certmanager.NewController(
+			caInformer,
+			tlsInformer,
+			certRenewer,
+			eventGenerator,
+			caSecretName,
+			tlsSecretName,
+			config.KyvernoNamespace(),
+		)
+		leaderControllers = append(leaderControllers, internal.NewController(certmanager.ControllerName, certManager, certmanager.Workers))
+	}
+	if generateVAPs && groups.Has(leaderGroupVAP) {
 		checker := checker.NewSelfChecker(kubeClient.AuthorizationV1().SelfSubjectAccessReviews())
 		vapController := vapcontroller.NewController(
 			kubeClient,
@@ -250,6 +330,88 @@ func createrLeaderControllers(
 	return leaderControllers, nil, nil
 }
 
+func createAuditSharding(
+	kubeClient kubernetes.Interface,
+	leaseInformer coordinationv1informers.LeaseInformer,
+	certificates tls.CertificateSource,
+	webhookServerPort int,
+) (internal.Controller, sharding.Distributor, error) {
+	if config.KyvernoPodIP() == "" {
+		return nil, nil, errors.New("KYVERNO_POD_IP must be set to enable audit sharding")
+	}
+	endpoint := fmt.Sprintf("https://%s", net.JoinHostPort(config.KyvernoPodIP(), strconv.Itoa(webhookServerPort)))
+	membership := sharding.NewLeaseMembership(
+		auditShardingGroup,
+		config.KyvernoPodName(),
+		endpoint,
+		auditShardingLeaseDuration,
+		kubeClient.CoordinationV1().Leases(config.KyvernoNamespace()),
+		leaseInformer.Lister().Leases(config.KyvernoNamespace()),
+	)
+	// replicas share the same tls pair, the key signing forwarded requests is derived from its private key
+	key := func() ([]byte, error) {
+		_, keyPem, err := certificates.KeyPair()
+		if err != nil {
+			return nil, err
+		}
+		return keyPem, nil
+	}
+	// members are reached by ip but serve the certificate of the kyverno service
+	serverName := config.InClusterServiceName(config.KyvernoServiceName(), config.KyvernoNamespace())
+	distributor := sharding.NewDistributor(membership, config.AuditShardServicePath, key, certificates.CABundle, serverName, auditShardingTimeout)
+	return internal.NewController("audit-sharding", membership, 1), distributor, nil
+}
+
+func createWebhookTLSOptions(
+	ctx context.Context,
+	logger logr.Logger,
+	kubeClient kubernetes.Interface,
+	resyncPeriod time.Duration,
+	minVersion string,
+	cipherSuites string,
+	verifyClientCertificates bool,
+) (webhooks.TLSOptions, error) {
+	version, err := tls.ParseVersion(minVersion)
+	if err != nil {
+		return webhooks.TLSOptions{}, err
+	}
+	suites, err := tls.ParseCipherSuites(cipherSuites)
+	if err != nil {
+		return webhooks.TLSOptions{}, err
+	}
+	options := webhooks.TLSOptions{
+		MinVersion:   version,
+		CipherSuites: suites,
+	}
+	if verifyClientCertificates {
+		authentication := informers.NewConfigMapInformer(kubeClient, authenticationConfigMapNamespace, authenticationConfigMapName, resyncPeriod)
+		if !informers.StartInformersAndWaitForCacheSync(ctx, logger, authentication) {
+			return webhooks.TLSOptions{}, errors.New("failed to wait for cache sync")
+		}
+		options.ClientAuthProvider = func() (webhooks.ClientAuthentication, error) {
+			cm, err := authentication.Lister().ConfigMaps(authenticationConfigMapNamespace).Get(authenticationConfigMapName)
+			if err != nil {
+				return webhooks.ClientAuthentication{}, err
+			}
+			ca, ok := cm.Data[authenticationConfigMapCAKey]
+			if !ok || ca == "" {
+				return webhooks.ClientAuthentication{}, fmt.Errorf("key %s not found in config map %s/%s", authenticationConfigMapCAKey, authenticationConfigMapNamespace, authenticationConfigMapName)
+			}
+			var names []string
+			if data := cm.Data[authenticationConfigMapNamesKey]; data != "" {
+				if err := json.Unmarshal([]byte(data), &names); err != nil {
+					return webhooks.ClientAuthentication{}, fmt.Errorf("failed to parse key %s of config map %s/%s: %w", authenticationConfigMapNamesKey, authenticationConfigMapNamespace, authenticationConfigMapName, err)
+				}
+			}
+			return webhooks.ClientAuthentication{
+				CABundle:     []byte(ca),
+				AllowedNames: names,
+			}, nil
+		}
+	}
+	return options, nil
+}
+
 func main() {
 	var (
 		// TODO: this has been added to backward support command line arguments
@@ -263,18 +425,53 @@ func main() {
 		webhookRegistrationTimeout   time.Duration
 		admissionReports             bool
 		dumpPayload                  bool
+		dumpPayloadSink              string
+		dumpPayloadSampleRate        float64
+		dumpPayloadRedactFields      string
 		servicePort                  int
 		webhookServerPort            int
+		webhookServerAddress         string
+		probeAddress                 string
 		backgroundServiceAccountName string
 		reportsServiceAccountName    string
 		maxAPICallResponseLength     int64
 		renewBefore                  time.Duration
+		certKeyAlgorithm             string
+		caValidityDuration           time.Duration
+		tlsValidityDuration          time.Duration
+		certificateReloadJitter      time.Duration
+		certificateSelfTest          bool
+		splitLeaderElection          bool
 		maxAuditWorkers              int
 		maxAuditCapacity             int
 		maxAdmissionReports          int
+		reportsBatchSize             int
+		reportsBatchInterval         time.Duration
+		reportsMaxQueued             int
+		auditSharding                bool
+		decisionLogSink              string
+		maxConcurrentAdmissions      int
+		breakerMaxP99Latency         time.Duration
+		breakerMaxErrorRate          float64
+		breakerWindow                time.Duration
+		breakerMinSamples            int
+		breakerCooldown              time.Duration
+		tlsMinVersion                string
+		tlsCipherSuites              string
+		tlsVerifyClientCertificates  bool
+		auditConcurrencyRatio        float64
+		auditRateLimitQPS            float64
+		auditRateLimitBurst          int
+		shutdownGracePeriod          time.Duration
+		enableEvaluateEndpoint       bool
+		enableAuditEventsEndpoint    bool
+		maxWarningBytes              int
 	)
 	flagset := flag.NewFlagSet("kyverno", flag.ExitOnError)
 	flagset.BoolVar(&dumpPayload, "dumpPayload", false, "Set this flag to activate/deactivate debug mode.")
+	flagset.StringVar(&dumpPayloadSink, "dumpPayloadSink", "log", "Destination of payload dumps, either log, file:///path/to/dir?maxSize=10Mi&maxAge=1h&maxFiles=10 or s3://bucket/prefix?endpoint=https://host&region=us-east-1&maxSize=10Mi&maxAge=1h.")
+	flagset.Float64Var(&dumpPayloadSampleRate, "dumpPayloadSampleRate", 1, "Ratio of admission requests dumped when debug mode is active, between 0 and 1.")
+	flagset.StringVar(&dumpPayloadRedactFields, "dumpPayloadRedactFields", "", "Comma separated list of dot separated payload fields redacted from dumps, e.g. request.object.data,request.oldObject.data.")
 	flagset.IntVar(&webhookTimeout, "webhookTimeout", webhookcontroller.DefaultWebhookTimeout, "Timeout for webhook configurations (number of seconds, integer).")
 	flagset.IntVar(&maxQueuedEvents, "maxQueuedEvents", 1000, "Maximum events to be queued.")
 	flagset.StringVar(&omitEvents, "omitEvents", "", "Set this flag to a comma sperated list of PolicyViolation, PolicyApplied, PolicyError, PolicySkipped to disable events, e.g. --omitEvents=PolicyApplied,PolicyViolation")
@@ -286,18 +483,50 @@ func main() {
 	flagset.Func(toggle.ForceFailurePolicyIgnoreFlagName, toggle.ForceFailurePolicyIgnoreDescription, toggle.ForceFailurePolicyIgnore.Parse)
 	flagset.Func(toggle.GenerateValidatingAdmissionPolicyFlagName, toggle.GenerateValidatingAdmissionPolicyDescription, toggle.GenerateValidatingAdmissionPolicy.Parse)
 	flagset.Func(toggle.DumpMutatePatchesFlagName, toggle.DumpMutatePatchesDescription, toggle.DumpMutatePatches.Parse)
+	flagset.Func(toggle.GenerateWebhookMatchConditionsFlagName, toggle.GenerateWebhookMatchConditionsDescription, toggle.GenerateWebhookMatchConditions.Parse)
 	flagset.BoolVar(&admissionReports, "admissionReports", true, "Enable or disable admission reports.")
 	flagset.IntVar(&servicePort, "servicePort", 443, "Port used by the Kyverno Service resource and for webhook configurations.")
 	flagset.IntVar(&webhookServerPort, "webhookServerPort", 9443, "Port used by the webhook server.")
+	flagset.StringVar(&webhookServerAddress, "webhookServerAddress", "", "IP address the webhook server binds to, all interfaces are used when empty. The IPv6 unspecified address :: is dual-stack and also accepts IPv4 connections, use 0.0.0.0 to restrict the server to IPv4.")
+	flagset.StringVar(&probeAddress, "probeAddress", "", "Address (host:port) of a plain HTTP server exposing the health probes in addition to the webhook server, e.g. :8080, disabled when empty.")
 	flagset.StringVar(&backgroundServiceAccountName, "backgroundServiceAccountName", "", "Background controller service account name.")
 	flagset.StringVar(&reportsServiceAccountName, "reportsServiceAccountName", "", "Reports controller service account name.")
 	flagset.StringVar(&caSecretName, "caSecretName", "", "Name of the secret containing CA.")
 	flagset.StringVar(&tlsSecretName, "tlsSecretName", "", "Name of the secret containing TLS pair.")
+	flagset.StringVar(&externalCertificatesDir, "externalCertificatesDir", "", "Directory containing externally managed tls.crt, tls.key and ca.crt files (e.g. a mounted cert-manager secret), when set kyverno doesn't manage certificates and files are reloaded when they change.")
+	flagset.StringVar(&certManagerIssuer, "certManagerIssuer", "", "Reference to a cert-manager issuer formatted as [<kind>/]<name> (kind defaults to Issuer), when set kyverno creates a cert-manager Certificate for its serving certificate, reads the CA from the issued secret and doesn't renew certificates itself.")
 	flagset.Int64Var(&maxAPICallResponseLength, "maxAPICallResponseLength", 10*1000*1000, "Configure the value of maximum allowed GET response size from API Calls")
 	flagset.DurationVar(&renewBefore, "renewBefore", 15*24*time.Hour, "The certificate renewal time before expiration")
+	flagset.StringVar(&certKeyAlgorithm, "certKeyAlgorithm", string(tls.DefaultKeyAlgorithm), "Algorithm of the generated certificate keys, one of RSA2048, RSA4096, ECDSAP256 or ECDSAP384, the CA key is replaced at its next renewal when the algorithm changes.")
+	flagset.DurationVar(&caValidityDuration, "caValidityDuration", tls.CAValidityDuration, "Validity duration of the generated CA certificate.")
+	flagset.DurationVar(&tlsValidityDuration, "tlsValidityDuration", tls.TLSValidityDuration, "Validity duration of the generated TLS certificate.")
+	flagset.DurationVar(&certificateReloadJitter, "certificateReloadJitter", 10*time.Second, "Maximum random delay before a replica serves a rotated certificate, so that replicas don't switch certificates at the same time.")
+	flagset.BoolVar(&certificateSelfTest, "certificateSelfTest", true, "Call the webhook server with the CA bundle after a certificate is loaded, the replica is ready once the server presents the new certificate.")
+	flagset.BoolVar(&splitLeaderElection, "splitLeaderElection", false, "Use a lease per leader controller group (webhooks, certmanager and vap), so that groups can be led by different replicas.")
 	flagset.IntVar(&maxAuditWorkers, "maxAuditWorkers", 8, "Maximum number of workers for audit policy processing")
 	flagset.IntVar(&maxAuditCapacity, "maxAuditCapacity", 1000, "Maximum capacity of the audit policy task queue")
 	flagset.IntVar(&maxAdmissionReports, "maxAdmissionReports", 10000, "Maximum number of admission reports before we stop creating new ones")
+	flagset.DurationVar(&reportsBatchInterval, "admissionReportsBatchInterval", 0, "Interval at which admission reports are created in batches, reports of the same resource queued within an interval are merged (0 creates every report right away).")
+	flagset.IntVar(&reportsBatchSize, "admissionReportsBatchSize", 100, "Number of queued admission reports triggering a batch before the batch interval.")
+	flagset.IntVar(&reportsMaxQueued, "admissionReportsMaxQueued", 1000, "Maximum number of queued admission reports, new reports are dropped when the queue is full.")
+	flagset.DurationVar(&breakerMaxP99Latency, "breakerMaxP99Latency", 0, "Skip audit policies when the p99 latency of admission requests exceeds this duration (0 disables the latency threshold).")
+	flagset.Float64Var(&breakerMaxErrorRate, "breakerMaxErrorRate", 0, "Skip audit policies when the ratio of admission requests exceeding their deadline is above this value (0 disables the error rate threshold).")
+	flagset.DurationVar(&breakerWindow, "breakerWindow", time.Minute, "Observation window of the admission latency breaker.")
+	flagset.IntVar(&breakerMinSamples, "breakerMinSamples", 50, "Minimum number of admission requests observed in the window before the latency breaker thresholds are evaluated.")
+	flagset.DurationVar(&breakerCooldown, "breakerCooldown", 2*time.Minute, "Time audit policies are skipped after the admission latency breaker opens.")
+	flagset.StringVar(&tlsMinVersion, "tlsMinVersion", "VersionTLS12", "Minimum TLS version accepted by the webhook server (VersionTLS12 or VersionTLS13).")
+	flagset.StringVar(&tlsCipherSuites, "tlsCipherSuites", "", "Comma separated list of TLS 1.2 cipher suites accepted by the webhook server, secure ECDHE AEAD cipher suites are used when empty.")
+	flagset.BoolVar(&tlsVerifyClientCertificates, "tlsVerifyClientCertificates", false, "Require webhook clients to present a certificate signed by the API server aggregation CA with an allowed common name (requires read access to the kube-system/extension-apiserver-authentication config map). The API server only presents a client certificate to webhooks when its admission control configuration references a kubeconfig for them, admission requests are rejected otherwise.")
+	flagset.IntVar(&maxConcurrentAdmissions, "maxConcurrentAdmissions", 0, "Maximum number of admission requests and audit work units processed concurrently, enforce validation and mutation are prioritized over audit work (0 means unlimited).")
+	flagset.Float64Var(&auditConcurrencyRatio, "auditConcurrencyRatio", 0.5, "Ratio of the concurrent admission slots audit work can use, audit work is shed when exceeded.")
+	flagset.Float64Var(&auditRateLimitQPS, "auditRateLimitQPS", 0, "Maximum rate of audit work units processed per second, audit work is shed when exceeded (0 means unlimited).")
+	flagset.IntVar(&auditRateLimitBurst, "auditRateLimitBurst", 10, "Burst of audit work units processed above the audit rate limit.")
+	flagset.StringVar(&decisionLogSink, "decisionLogSink", "", "Destination of the admission decision log, either log, file:///path/to/dir?maxSize=10Mi&maxAge=1h&maxFiles=10 or https://host/path?maxSize=1Mi&maxAge=10s, the decision log is disabled when empty.")
+	flagset.BoolVar(&auditSharding, "auditSharding", false, "Distribute audit policy processing across admission controller replicas.")
+	flagset.DurationVar(&shutdownGracePeriod, "shutdownGracePeriod", 30*time.Second, "Time in-flight admission reviews are given to complete on shutdown, new reviews are refused and webhooks are deregistered afterwards. It must be shorter than the pod termination grace period.")
+	flagset.IntVar(&maxWarningBytes, "maxWarningBytes", 4096, "Maximum total size of the warnings returned in an admission response, warnings are deduplicated and the ones exceeding the budget are replaced by a summary (0 means unlimited).")
+	flagset.BoolVar(&enableEvaluateEndpoint, "enableEvaluateEndpoint", false, "Serve the /evaluate endpoint applying the policies to an AdmissionReview or a raw object without side effects, callers authenticate with a bearer token and must be allowed to post the /evaluate non resource URL (requires permission to create token reviews).")
+	flagset.BoolVar(&enableAuditEventsEndpoint, "enableAuditEventsEndpoint", false, "Serve the /auditevents endpoint ingesting the API server audit events, the failed validations of the ValidatingAdmissionPolicies generated by kyverno are recorded as results of the policies they were generated from. The audit webhook backend authenticates with a bearer token and must be allowed to post the /auditevents non resource URL (requires permission to create token reviews).")
 	// config
 	appConfig := internal.NewConfiguration(
 		internal.WithProfiling(),
@@ -309,7 +538,10 @@ func main() {
 		internal.WithDeferredLoading(),
 		internal.WithCosign(),
 		internal.WithRegistryClient(),
+		internal.WithWasm(),
 		internal.WithImageVerifyCache(),
+		internal.WithEngineResultCache(),
+		internal.WithGlobalContext(),
 		internal.WithLeaderElection(),
 		internal.WithKyvernoClient(),
 		internal.WithDynamicClient(),
@@ -327,14 +559,32 @@ func main() {
 		// setup
 		signalCtx, setup, sdown := internal.Setup(appConfig, "kyverno-admission-controller", false)
 		defer sdown()
-		if caSecretName == "" {
+		if externalCertificatesDir != "" && certManagerIssuer != "" {
+			setup.Logger.Error(errors.New("exiting... externalCertificatesDir and certManagerIssuer are mutually exclusive"), "exiting... externalCertificatesDir and certManagerIssuer are mutually exclusive")
+			os.Exit(1)
+		}
+		if externalCertificatesDir == "" && certManagerIssuer == "" && caSecretName == "" {
 			setup.Logger.Error(errors.New("exiting... caSecretName is a required flag"), "exiting... caSecretName is a required flag")
 			os.Exit(1)
 		}
-		if tlsSecretName == "" {
+		if externalCertificatesDir == "" && tlsSecretName == "" {
 			setup.Logger.Error(errors.New("exiting... tlsSecretName is a required flag"), "exiting... tlsSecretName is a required flag")
 			os.Exit(1)
 		}
+		keyAlgorithm, err := tls.ParseKeyAlgorithm(certKeyAlgorithm)
+		if err != nil {
+			setup.Logger.Error(err, "invalid certificate key algorithm")
+			os.Exit(1)
+		}
+		webhookServerAddress, err = netutils.ParseBindAddress(webhookServerAddress)
+		if err != nil {
+			setup.Logger.Error(err, "invalid webhook server address")
+			os.Exit(1)
+		}
+		if caValidityDuration <= renewBefore || tlsValidityDuration <= renewBefore {
+			setup.Logger.Error(errors.New("exiting... certificate validity durations must be greater than renewBefore"), "exiting... certificate validity durations must be greater than renewBefore")
+			os.Exit(1)
+		}
 		// check if validating admission policies are registered in the API server
 		generateValidatingAdmissionPolicy := toggle.FromContext(context.TODO()).GenerateValidatingAdmissionPolicy()
 		if generateValidatingAdmissionPolicy {
@@ -344,13 +594,103 @@ func main() {
 				os.Exit(1)
 			}
 		}
-		caSecret := informers.NewSecretInformer(setup.KubeClient, config.KyvernoNamespace(), caSecretName, setup.ResyncPeriod)
-		tlsSecret := informers.NewSecretInformer(setup.KubeClient, config.KyvernoNamespace(), tlsSecretName, setup.ResyncPeriod)
 		kyvernoDeployment := informers.NewDeploymentInformer(setup.KubeClient, config.KyvernoNamespace(), config.KyvernoDeploymentName(), setup.ResyncPeriod)
-		if !informers.StartInformersAndWaitForCacheSync(signalCtx, setup.Logger, caSecret, tlsSecret, kyvernoDeployment) {
+		if !informers.StartInformersAndWaitForCacheSync(signalCtx, setup.Logger, kyvernoDeployment) {
 			setup.Logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
 			os.Exit(1)
 		}
+		// certificates are either managed by kyverno in secrets, issued by cert-manager or mounted from files
+		var caSecret, tlsSecret corev1informers.SecretInformer
+		var certificates tls.CertificateSource
+		var certificateFiles *tls.FileSource
+		var certValidator tls.CertValidator
+		if externalCertificatesDir != "" {
+			source, err := tls.NewFileSource(
+				filepath.Join(externalCertificatesDir, corev1.TLSCertKey),
+				filepath.Join(externalCertificatesDir, corev1.TLSPrivateKeyKey),
+				filepath.Join(externalCertificatesDir, corev1.ServiceAccountRootCAKey),
+			)
+			if err != nil {
+				setup.Logger.Error(err, "failed to load external certificates")
+				os.Exit(1)
+			}
+			certificates, certificateFiles, certValidator = source, source, source
+		} else if certManagerIssuer != "" {
+			issuer, err := tls.ParseCertManagerIssuer(certManagerIssuer)
+			if err != nil {
+				setup.Logger.Error(err, "invalid cert-manager issuer")
+				os.Exit(1)
+			}
+			if err := tls.EnsureCertManagerCertificate(
+				signalCtx,
+				setup.KyvernoDynamicClient.GetDynamicInterface(),
+				config.KyvernoNamespace(),
+				tlsSecretName,
+				config.KyvernoServiceName(),
+				config.DnsNames(config.KyvernoServiceName(), config.KyvernoNamespace()),
+				issuer,
+			); err != nil {
+				setup.Logger.Error(err, "failed to create cert-manager certificate")
+				os.Exit(1)
+			}
+			tlsSecret = informers.NewSecretInformer(setup.KubeClient, config.KyvernoNamespace(), tlsSecretName, setup.ResyncPeriod)
+			if !informers.StartInformersAndWaitForCacheSync(signalCtx, setup.Logger, tlsSecret) {
+				setup.Logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+				os.Exit(1)
+			}
+			source := tls.NewCertManagerSource(tlsSecret, tlsSecretName, config.KyvernoNamespace())
+			certificates, certValidator = source, source
+		} else {
+			caSecret = informers.NewSecretInformer(setup.KubeClient, config.KyvernoNamespace(), caSecretName, setup.ResyncPeriod)
+			tlsSecret = informers.NewSecretInformer(setup.KubeClient, config.KyvernoNamespace(), tlsSecretName, setup.ResyncPeriod)
+			if !informers.StartInformersAndWaitForCacheSync(signalCtx, setup.Logger, caSecret, tlsSecret) {
+				setup.Logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+				os.Exit(1)
+			}
+			certificates = tls.NewSecretSource(caSecret, tlsSecret, caSecretName, tlsSecretName, config.KyvernoNamespace())
+		}
+		// webhook server tls options
+		tlsOptions, err := createWebhookTLSOptions(signalCtx, setup.Logger, setup.KubeClient, setup.ResyncPeriod, tlsMinVersion, tlsCipherSuites, tlsVerifyClientCertificates)
+		if err != nil {
+			setup.Logger.Error(err, "failed to configure webhook server tls")
+			os.Exit(1)
+		}
+		// payload dump sink
+		dumpSink, err := dump.NewSink(signalCtx, dumpPayloadSink)
+		if err != nil {
+			setup.Logger.Error(err, "failed to create payload dump sink")
+			os.Exit(1)
+		}
+		if dumpSink != nil {
+			defer func() {
+				if err := dumpSink.Close(); err != nil {
+					setup.Logger.Error(err, "failed to close payload dump sink")
+				}
+			}()
+		}
+		var redactFields []string
+		for _, field := range strings.Split(dumpPayloadRedactFields, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				redactFields = append(redactFields, field)
+			}
+		}
+		// admission decision log
+		var decisionRecorder decisionlog.Recorder
+		if decisionLogSink != "" {
+			decisionSink, err := dump.NewSink(signalCtx, decisionLogSink)
+			if err != nil {
+				setup.Logger.Error(err, "failed to create decision log sink")
+				os.Exit(1)
+			}
+			if decisionSink != nil {
+				defer func() {
+					if err := decisionSink.Close(); err != nil {
+						setup.Logger.Error(err, "failed to close decision log sink")
+					}
+				}()
+			}
+			decisionRecorder = decisionlog.NewRecorder(setup.Logger.WithName("decision-log"), decisionSink)
+		}
 		// show version
 		showWarnings(signalCtx, setup.Logger)
 		// THIS IS AN UGLY FIX
@@ -366,19 +706,25 @@ func main() {
 		kubeKyvernoInformer := kubeinformers.NewSharedInformerFactoryWithOptions(setup.KubeClient, setup.ResyncPeriod, kubeinformers.WithNamespace(config.KyvernoNamespace()))
 		kyvernoInformer := kyvernoinformer.NewSharedInformerFactory(setup.KyvernoClient, setup.ResyncPeriod)
 
-		certRenewer := tls.NewCertRenewer(
-			setup.KubeClient.CoreV1().Secrets(config.KyvernoNamespace()),
-			tls.CertRenewalInterval,
-			tls.CAValidityDuration,
-			tls.TLSValidityDuration,
-			renewBefore,
-			serverIP,
-			config.KyvernoServiceName(),
-			config.DnsNames(config.KyvernoServiceName(), config.KyvernoNamespace()),
-			config.KyvernoNamespace(),
-			caSecretName,
-			tlsSecretName,
-		)
+		// the renewal loop only runs when kyverno manages its own certificates
+		var certRenewer tls.CertRenewer
+		if certValidator == nil {
+			renewer := tls.NewCertRenewer(
+				setup.KubeClient.CoreV1().Secrets(config.KyvernoNamespace()),
+				tls.CertRenewalInterval,
+				caValidityDuration,
+				tlsValidityDuration,
+				renewBefore,
+				keyAlgorithm,
+				serverIP,
+				config.KyvernoServiceName(),
+				config.DnsNames(config.KyvernoServiceName(), config.KyvernoNamespace()),
+				config.KyvernoNamespace(),
+				caSecretName,
+				tlsSecretName,
+			)
+			certRenewer, certValidator = renewer, renewer
+		}
 		policyCache := policycache.NewCache()
 		eventGenerator := event.NewEventGenerator(
 			setup.EventsClient,
@@ -386,6 +732,8 @@ func main() {
 			maxQueuedEvents,
 			strings.Split(omitEvents, ",")...,
 		)
+		internal.ReloadOmitEvents(setup.Configuration, eventGenerator, strings.Split(omitEvents, ",")...)
+		internal.EmitSettingsEvents(setup.Configuration, eventGenerator, event.AdmissionController)
 		gcstore := store.New()
 		gceController := internal.NewController(
 			globalcontextcontroller.ControllerName,
@@ -395,7 +743,8 @@ func main() {
 				setup.KyvernoClient,
 				gcstore,
 				eventGenerator,
-				maxAPICallResponseLength,
+				apicall.NewAPICallConfiguration(maxAPICallResponseLength, apicall.WithAuthSecrets(setup.SecretResolver, setup.Configuration)),
+				setup.Jp,
 				true,
 			),
 			globalcontextcontroller.Workers,
@@ -426,11 +775,22 @@ func main() {
 			kyvernoInformer.Kyverno().V1().ClusterPolicies(),
 			genericloggingcontroller.CheckGeneration,
 		)
+		// the leader rotates the secrets, every replica reloads the key pair with jitter and is ready once the self test passes
+		var selfTest tls.SelfTest
+		if certificateSelfTest && serverIP == "" {
+			selfTestHost := netutils.LoopbackAddress(webhookServerAddress)
+			selfTest = tls.NewServerSelfTest(
+				fmt.Sprintf("https://%s%s", net.JoinHostPort(selfTestHost, strconv.Itoa(webhookServerPort)), config.LivenessServicePath),
+				config.InClusterServiceName(config.KyvernoServiceName(), config.KyvernoNamespace()),
+				5*time.Second,
+			)
+		}
+		keyPairReloader := tls.NewKeyPairReloader(certificates, certValidator, certificateReloadJitter, selfTest)
 		runtime := runtimeutils.NewRuntime(
 			setup.Logger.WithName("runtime-checks"),
 			serverIP,
 			kubeKyvernoInformer.Apps().V1().Deployments(),
-			certRenewer,
+			keyPairReloader,
 		)
 		// engine
 		engine := internal.NewEngine(
@@ -445,7 +805,7 @@ func main() {
 			setup.KubeClient,
 			setup.KyvernoClient,
 			setup.RegistrySecretLister,
-			apicall.NewAPICallConfiguration(maxAPICallResponseLength),
+			apicall.NewAPICallConfiguration(maxAPICallResponseLength, apicall.WithAuthSecrets(setup.SecretResolver, setup.Configuration)),
 			polexCache,
 			gcstore,
 		)
@@ -455,11 +815,24 @@ func main() {
 			setup.KyvernoDynamicClient,
 			policyCache,
 		)
+		if certificateFiles != nil {
+			nonLeaderControllers = append(nonLeaderControllers, internal.NewController("certificate-files", certificateFiles, 1))
+		}
+		nonLeaderControllers = append(nonLeaderControllers, internal.NewController("certificates-reloader", keyPairReloader, 1))
 		// start informers and wait for cache sync
 		if !internal.StartInformersAndWaitForCacheSync(signalCtx, setup.Logger, kyvernoInformer, kubeInformer, kubeKyvernoInformer) {
 			setup.Logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
 			os.Exit(1)
 		}
+		// load the global context snapshot before the entries are refreshed
+		gcSnapshot := internal.NewGlobalContextSnapshot(
+			signalCtx,
+			setup.Logger,
+			setup.KubeClient,
+			"kyverno-admission-controller-globalcontext",
+			kyvernoInformer.Kyverno().V2alpha1().GlobalContextEntries().Lister(),
+			gcstore,
+		)
 		// bootstrap non leader controllers
 		if nonLeaderBootstrap != nil {
 			if err := nonLeaderBootstrap(signalCtx); err != nil {
@@ -467,71 +840,92 @@ func main() {
 				os.Exit(1)
 			}
 		}
-		// setup leader election
-		le, err := leaderelection.New(
-			setup.Logger.WithName("leader-election"),
-			"kyverno",
-			config.KyvernoNamespace(),
-			setup.LeaderElectionClient,
-			config.KyvernoPodName(),
-			internal.LeaderElectionRetryPeriod(),
-			func(ctx context.Context) {
-				logger := setup.Logger.WithName("leader")
-				// create leader factories
-				kubeInformer := kubeinformers.NewSharedInformerFactory(setup.KubeClient, setup.ResyncPeriod)
-				kyvernoInformer := kyvernoinformer.NewSharedInformerFactory(setup.KyvernoClient, setup.ResyncPeriod)
-				// create leader controllers
-				leaderControllers, warmup, err := createrLeaderControllers(
-					generateValidatingAdmissionPolicy,
-					admissionReports,
-					serverIP,
-					webhookTimeout,
-					autoUpdateWebhooks,
-					autoDeleteWebhooks,
-					kubeInformer,
-					kubeKyvernoInformer,
-					kyvernoInformer,
-					caSecret,
-					tlsSecret,
-					kyvernoDeployment,
-					setup.KubeClient,
-					setup.KyvernoClient,
-					setup.KyvernoDynamicClient,
-					certRenewer,
-					runtime,
-					int32(servicePort),       //nolint:gosec
-					int32(webhookServerPort), //nolint:gosec
-					setup.Configuration,
-					eventGenerator,
-				)
-				if err != nil {
-					logger.Error(err, "failed to create leader controllers")
-					os.Exit(1)
-				}
-				// start informers and wait for cache sync
-				if !internal.StartInformersAndWaitForCacheSync(signalCtx, logger, kyvernoInformer, kubeInformer, kubeKyvernoInformer) {
-					logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
-					os.Exit(1)
-				}
-				if warmup != nil {
-					if err := warmup(ctx); err != nil {
-						logger.Error(err, "failed to run warmup")
+		// setup leader election, controller groups share a single lease unless leader election is split
+		leaderGroups := [][]string{{leaderGroupCertManager, leaderGroupWebhooks, leaderGroupVAP}}
+		if splitLeaderElection {
+			leaderGroups = [][]string{{leaderGroupWebhooks}}
+			if certRenewer != nil {
+				leaderGroups = append(leaderGroups, []string{leaderGroupCertManager})
+			}
+			if generateValidatingAdmissionPolicy {
+				leaderGroups = append(leaderGroups, []string{leaderGroupVAP})
+			}
+		}
+		var leaderElections []leaderelection.Interface
+		for _, group := range leaderGroups {
+			groups := sets.New(group...)
+			name := "kyverno"
+			if splitLeaderElection {
+				name = "kyverno-" + group[0]
+			}
+			le, err := leaderelection.New(
+				setup.Logger.WithName("leader-election").WithValues("lease", name),
+				name,
+				config.KyvernoNamespace(),
+				setup.LeaderElectionClient,
+				config.KyvernoPodName(),
+				internal.LeaderElectionRetryPeriod(),
+				func(ctx context.Context) {
+					logger := setup.Logger.WithName("leader").WithValues("lease", name)
+					// create leader factories
+					kubeInformer := kubeinformers.NewSharedInformerFactory(setup.KubeClient, setup.ResyncPeriod)
+					kyvernoInformer := kyvernoinformer.NewSharedInformerFactory(setup.KyvernoClient, setup.ResyncPeriod)
+					// create leader controllers
+					leaderControllers, warmup, err := createrLeaderControllers(
+						groups,
+						generateValidatingAdmissionPolicy,
+						admissionReports,
+						serverIP,
+						webhookTimeout,
+						autoUpdateWebhooks,
+						autoDeleteWebhooks,
+						kubeInformer,
+						kubeKyvernoInformer,
+						kyvernoInformer,
+						caSecret,
+						tlsSecret,
+						certificates,
+						kyvernoDeployment,
+						setup.KubeClient,
+						setup.KyvernoClient,
+						setup.KyvernoDynamicClient,
+						certRenewer,
+						runtime,
+						int32(servicePort),       //nolint:gosec
+						int32(webhookServerPort), //nolint:gosec
+						setup.Configuration,
+						eventGenerator,
+					)
+					if err != nil {
+						logger.Error(err, "failed to create leader controllers")
 						os.Exit(1)
 					}
-				}
-				// start leader controllers
-				var wg sync.WaitGroup
-				for _, controller := range leaderControllers {
-					controller.Run(signalCtx, logger.WithName("controllers"), &wg)
-				}
-				// wait all controllers shut down
-				wg.Wait()
-			},
-			nil,
-		)
-		if err != nil {
-			setup.Logger.Error(err, "failed to initialize leader election")
-			os.Exit(1)
+					// start informers and wait for cache sync
+					if !internal.StartInformersAndWaitForCacheSync(signalCtx, logger, kyvernoInformer, kubeInformer, kubeKyvernoInformer) {
+						logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
+						os.Exit(1)
+					}
+					if warmup != nil {
+						if err := warmup(ctx); err != nil {
+							logger.Error(err, "failed to run warmup")
+							os.Exit(1)
+						}
+					}
+					// start leader controllers
+					var wg sync.WaitGroup
+					for _, controller := range leaderControllers {
+						controller.Run(signalCtx, logger.WithName("controllers"), &wg)
+					}
+					// wait all controllers shut down
+					wg.Wait()
+				},
+				nil,
+			)
+			if err != nil {
+				setup.Logger.Error(err, "failed to initialize leader election")
+				os.Exit(1)
+			}
+			leaderElections = append(leaderElections, le)
 		}
 		urGenerator := generator.NewUpdateRequestGenerator(setup.Configuration, setup.MetadataClient)
 		// create webhooks server
@@ -551,13 +945,83 @@ func main() {
 			setup.Logger.Error(err, "failed to start admission reports watcher")
 			os.Exit(1)
 		}
-		reportsBreaker := breaker.NewBreaker("admission reports", func(context.Context) bool {
-			count, isRunning := ephrs.Count()
-			if !isRunning {
-				return true
+		nsLabels, err := informers.NewNamespaceLabels(kubeInformer.Core().V1().Namespaces())
+		if err != nil {
+			setup.Logger.Error(err, "failed to create namespace labels index")
+			os.Exit(1)
+		}
+		reportsBreaker := breaker.NewDynamicCounterBreaker("admission reports", ephrs, func() int {
+			if limit, ok := setup.Configuration.GetMaxAdmissionReports(); ok {
+				return limit
 			}
-			return count > maxAdmissionReports
+			return maxAdmissionReports
 		})
+		reportsWriter := reportutils.NewReportWriter(setup.KyvernoClient, reportsBreaker)
+		if reportsBatchInterval > 0 {
+			reportsWriter, err = reportutils.NewBatchReportWriter(setup.KyvernoClient, reportsBreaker, reportutils.BatchReportWriterOptions{
+				BatchSize:     reportsBatchSize,
+				BatchInterval: reportsBatchInterval,
+				MaxQueued:     reportsMaxQueued,
+			})
+			if err != nil {
+				setup.Logger.Error(err, "failed to create admission reports writer")
+				os.Exit(1)
+			}
+		}
+		defer reportsWriter.Close()
+		var auditBreaker breaker.Breaker
+		var latencyObserver breaker.LatencyObserver
+		if breakerMaxP99Latency > 0 || breakerMaxErrorRate > 0 {
+			latencyTracker := breaker.NewLatencyTracker(
+				"admission latency",
+				breaker.LatencyOptions{
+					Window:        breakerWindow,
+					MinSamples:    breakerMinSamples,
+					MaxP99Latency: breakerMaxP99Latency,
+					MaxErrorRate:  breakerMaxErrorRate,
+					Cooldown:      breakerCooldown,
+				},
+				func(open bool, reason string) {
+					info := event.Info{
+						Regarding: corev1.ObjectReference{
+							APIVersion: "apps/v1",
+							Kind:       "Deployment",
+							Name:       config.KyvernoDeploymentName(),
+							Namespace:  config.KyvernoNamespace(),
+						},
+						Source: event.AdmissionController,
+						Action: event.None,
+					}
+					if open {
+						setup.Logger.Info("admission controller overloaded, audit policies are skipped", "reason", reason)
+						info.Reason = event.AdmissionDegraded
+						info.Message = fmt.Sprintf("audit policies are skipped for %s: %s", breakerCooldown, reason)
+					} else {
+						setup.Logger.Info("admission controller recovered, audit policies are processed again", "reason", reason)
+						info.Reason = event.AdmissionRecovered
+						info.Message = "audit policies are processed again"
+					}
+					eventGenerator.Add(info)
+				},
+			)
+			auditBreaker = breaker.NewBreaker("audit policies", latencyTracker.IsOpen)
+			latencyObserver = latencyTracker
+		}
+		var auditDistributor sharding.Distributor
+		if auditSharding {
+			auditShardingController, distributor, err := createAuditSharding(
+				setup.KubeClient,
+				kubeKyvernoInformer.Coordination().V1().Leases(),
+				certificates,
+				webhookServerPort,
+			)
+			if err != nil {
+				setup.Logger.Error(err, "failed to setup audit sharding")
+				os.Exit(1)
+			}
+			auditDistributor = distributor
+			nonLeaderControllers = append(nonLeaderControllers, auditShardingController)
+		}
 		resourceHandlers := webhooksresource.NewHandlers(
 			engine,
 			setup.KyvernoDynamicClient,
@@ -565,7 +1029,7 @@ func main() {
 			setup.Configuration,
 			setup.MetricsManager,
 			policyCache,
-			kubeInformer.Core().V1().Namespaces().Lister(),
+			nsLabels,
 			kyvernoInformer.Kyverno().V2().UpdateRequests().Lister().UpdateRequests(config.KyvernoNamespace()),
 			kyvernoInformer.Kyverno().V1().ClusterPolicies(),
 			kyvernoInformer.Kyverno().V1().Policies(),
@@ -578,31 +1042,61 @@ func main() {
 			maxAuditWorkers,
 			maxAuditCapacity,
 			setup.ReportingConfiguration,
-			reportsBreaker,
+			reportsWriter,
+			auditDistributor,
+			decisionRecorder,
+			limiter.NewLimiter(maxConcurrentAdmissions, auditConcurrencyRatio, auditRateLimitQPS, auditRateLimitBurst),
+			auditBreaker,
+			latencyObserver,
 		)
 		exceptionHandlers := webhooksexception.NewHandlers(exception.ValidationOptions{
 			Enabled:   internal.PolicyExceptionEnabled(),
 			Namespace: internal.ExceptionNamespace(),
 		})
 		globalContextHandlers := webhooksglobalcontext.NewHandlers()
+		configHandlers := webhooksconfigmap.NewHandlers()
+		var evaluateAuthenticator webhookshandlers.Authenticator
+		if enableEvaluateEndpoint {
+			evaluateAuthenticator = webhooks.NewTokenAuthenticator(
+				setup.KubeClient.AuthenticationV1().TokenReviews(),
+				setup.KubeClient.AuthorizationV1().SubjectAccessReviews(),
+				config.EvaluateServicePath,
+			)
+		}
+		var auditEventsAuthenticator webhookshandlers.Authenticator
+		var auditEvents webhookshandlers.AuditEventsHandler
+		if enableAuditEventsEndpoint {
+			auditEventsAuthenticator = webhooks.NewTokenAuthenticator(
+				setup.KubeClient.AuthenticationV1().TokenReviews(),
+				setup.KubeClient.AuthorizationV1().SubjectAccessReviews(),
+				config.AuditEventsServicePath,
+			)
+			vapAuditController := vapauditcontroller.NewController(
+				setup.MetadataClient,
+				setup.KyvernoDynamicClient.Discovery(),
+				reportsWriter,
+				kyvernoInformer.Kyverno().V1().ClusterPolicies(),
+			)
+			auditEvents = vapAuditController.Ingest
+			nonLeaderControllers = append(nonLeaderControllers, internal.NewController(vapauditcontroller.ControllerName, vapAuditController, vapauditcontroller.Workers))
+		}
 		server := webhooks.NewServer(
 			signalCtx,
 			policyHandlers,
 			resourceHandlers,
 			exceptionHandlers,
 			globalContextHandlers,
+			configHandlers,
 			setup.Configuration,
 			setup.MetricsManager,
 			webhooks.DebugModeOptions{
-				DumpPayload: dumpPayload,
-			},
-			func() ([]byte, []byte, error) {
-				secret, err := tlsSecret.Lister().Secrets(config.KyvernoNamespace()).Get(tlsSecretName)
-				if err != nil {
-					return nil, nil, err
-				}
-				return secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey], nil
+				DumpPayload:      dumpPayload,
+				DumpSink:         dumpSink,
+				DumpSampleRate:   dumpPayloadSampleRate,
+				DumpRedactFields: redactFields,
 			},
+			keyPairReloader.KeyPair,
+			tlsOptions,
 			setup.KubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations(),
 			setup.KubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations(),
 			setup.KubeClient.CoordinationV1().Leases(config.KyvernoNamespace()),
@@ -610,7 +1104,15 @@ func main() {
 			kubeInformer.Rbac().V1().RoleBindings().Lister(),
 			kubeInformer.Rbac().V1().ClusterRoleBindings().Lister(),
 			setup.KyvernoDynamicClient.Discovery(),
+			webhookServerAddress,
 			int32(webhookServerPort), //nolint:gosec
+			probeAddress,
+			auditDistributor,
+			shutdownGracePeriod,
+			evaluateAuthenticator,
+			maxWarningBytes,
+			auditEventsAuthenticator,
+			auditEvents,
 		)
 		// start informers and wait for cache sync
 		// we need to call start again because we potentially registered new informers
@@ -624,14 +1126,24 @@ func main() {
 		// start non leader controllers
 		eventController.Run(signalCtx, setup.Logger, &wg)
 		gceController.Run(signalCtx, setup.Logger, &wg)
+		if gcSnapshot != nil {
+			gcSnapshot.Run(signalCtx, setup.Logger, &wg)
+		}
 		if polexController != nil {
 			polexController.Run(signalCtx, setup.Logger, &wg)
 		}
 		for _, controller := range nonLeaderControllers {
 			controller.Run(signalCtx, setup.Logger.WithName("controllers"), &wg)
 		}
-		// start leader election
-		le.Run(signalCtx)
+		// start leader elections
+		for _, le := range leaderElections[1:] {
+			wg.Add(1)
+			go func(le leaderelection.Interface) {
+				defer wg.Done()
+				le.Run(signalCtx)
+			}(le)
+		}
+		leaderElections[0].Run(signalCtx)
 	}()
 	// wait for everything to shut down and exit
 	wg.Wait()
@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"flag"
+	"fmt"
 	"os"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/go-logr/logr"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
 	"github.com/kyverno/kyverno/cmd/internal"
 	"github.com/kyverno/kyverno/pkg/breaker"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
@@ -18,7 +21,10 @@ import (
 	globalcontextcontroller "github.com/kyverno/kyverno/pkg/controllers/globalcontext"
 	aggregatereportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/aggregate"
 	backgroundscancontroller "github.com/kyverno/kyverno/pkg/controllers/report/background"
+	compactioncontroller "github.com/kyverno/kyverno/pkg/controllers/report/compaction"
+	exportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/export"
 	resourcereportcontroller "github.com/kyverno/kyverno/pkg/controllers/report/resource"
+	summarycontroller "github.com/kyverno/kyverno/pkg/controllers/report/summary"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/apicall"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
@@ -30,19 +36,65 @@ import (
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	"github.com/kyverno/kyverno/pkg/validatingadmissionpolicy"
 	apiserver "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubeinformers "k8s.io/client-go/informers"
 	admissionregistrationv1beta1informers "k8s.io/client-go/informers/admissionregistration/v1beta1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	metadatainformers "k8s.io/client-go/metadata/metadatainformer"
+	aggregator "k8s.io/kube-aggregator/pkg/client/clientset_generated/clientset"
 	kyamlopenapi "sigs.k8s.io/kustomize/kyaml/openapi"
 )
 
-func sanityChecks(apiserverClient apiserver.Interface) error {
-	return kubeutils.CRDsInstalled(apiserverClient,
+const (
+	reportsStorageTokenEnv = "REPORTS_STORAGE_TOKEN"
+	resultsSinkTokenEnv    = "RESULTS_SINK_TOKEN"
+)
+
+func sanityChecks(apiserverClient apiserver.Interface, reportSummaries bool) error {
+	crds := []string{
 		"clusterpolicyreports.wgpolicyk8s.io",
 		"policyreports.wgpolicyk8s.io",
 		"ephemeralreports.reports.kyverno.io",
 		"clusterephemeralreports.reports.kyverno.io",
-	)
+	}
+	if reportSummaries {
+		crds = append(crds, "reportsummaries.reports.kyverno.io")
+	}
+	return kubeutils.CRDsInstalled(apiserverClient, crds...)
+}
+
+func reportsServerChecks(ctx context.Context, client aggregator.Interface) error {
+	for _, name := range []string{"v1alpha2.wgpolicyk8s.io", "v1.reports.kyverno.io"} {
+		apiService, err := client.ApiregistrationV1().APIServices().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get api service %s: %w", name, err)
+		}
+		if apiService.Spec.Service == nil {
+			return fmt.Errorf("api service %s is not served by the reports-server", name)
+		}
+	}
+	return nil
+}
+
+func createReportStore(ctx context.Context, logger logr.Logger, storage, storageURL string, kyvernoClient versioned.Interface) (reportutils.Store, error) {
+	logger = logger.WithName("report-store").WithValues("storage", storage)
+	logger.Info("setup report store...")
+	switch storage {
+	case "", reportutils.StorageCRD:
+		return reportutils.NewClientStore(kyvernoClient), nil
+	case reportutils.StorageReportsServer:
+		// the reports-server serves the report API groups, reports are written with the kyverno client
+		// once the api services are registered
+		if err := reportsServerChecks(ctx, internal.CreateAggregatorClient(logger)); err != nil {
+			return nil, err
+		}
+		return reportutils.NewClientStore(kyvernoClient), nil
+	case reportutils.StorageHTTP:
+		// the token is never passed on the command line, it comes from the environment (usually set from a secret)
+		return reportutils.NewHTTPStore(storageURL, os.Getenv(reportsStorageTokenEnv))
+	default:
+		return nil, fmt.Errorf("unsupported report storage %s", storage)
+	}
 }
 
 func createReportControllers(
@@ -52,6 +104,7 @@ func createReportControllers(
 	aggregateReports bool,
 	policyReports bool,
 	validatingAdmissionPolicyReports bool,
+	reportSummaries bool,
 	aggregationWorkers int,
 	backgroundScanWorkers int,
 	client dclient.Interface,
@@ -60,11 +113,21 @@ func createReportControllers(
 	kubeInformer kubeinformers.SharedInformerFactory,
 	kyvernoInformer kyvernoinformer.SharedInformerFactory,
 	backgroundScanInterval time.Duration,
+	backgroundScanFullResync time.Duration,
+	backgroundScanJitter time.Duration,
 	configuration config.Configuration,
 	jp jmespath.Interface,
 	eventGenerator event.Interface,
 	reportsConfig reportutils.ReportingConfiguration,
 	reportsBreaker breaker.Breaker,
+	reportStore reportutils.Store,
+	resultSink reportutils.ResultSink,
+	reportsCompaction bool,
+	reportsCompactionInterval time.Duration,
+	reportsResultsTTL time.Duration,
+	reportsExport bool,
+	reportsExportConfig exportcontroller.Config,
+	secretClient corev1client.SecretInterface,
 ) ([]internal.Controller, func(context.Context) error) {
 	var ctrls []internal.Controller
 	var warmups []func(context.Context) error
@@ -98,10 +161,13 @@ func createReportControllers(
 				aggregatereportcontroller.NewController(
 					kyvernoClient,
 					client,
+					reportStore,
+					resultSink,
 					metadataFactory,
 					kyvernoV1.Policies(),
 					kyvernoV1.ClusterPolicies(),
 					vapInformer,
+					configuration,
 				),
 				aggregationWorkers,
 			))
@@ -120,6 +186,8 @@ func createReportControllers(
 				kubeInformer.Core().V1().Namespaces(),
 				resourceReportController,
 				backgroundScanInterval,
+				backgroundScanFullResync,
+				backgroundScanJitter,
 				configuration,
 				jp,
 				eventGenerator,
@@ -134,6 +202,42 @@ func createReportControllers(
 			)
 		}
 	}
+	if reportSummaries {
+		ctrls = append(ctrls, internal.NewController(
+			summarycontroller.ControllerName,
+			summarycontroller.NewController(
+				kyvernoClient,
+				metadataFactory,
+				kyvernoInformer.Reports().V1().ReportSummaries(),
+			),
+			summarycontroller.Workers,
+		))
+	}
+	if reportsCompaction || reportsResultsTTL > 0 {
+		ctrls = append(ctrls, internal.NewController(
+			compactioncontroller.ControllerName,
+			compactioncontroller.NewController(
+				client,
+				reportStore,
+				metadataFactory,
+				reportsCompactionInterval,
+				reportsResultsTTL,
+				reportsCompaction,
+			),
+			compactioncontroller.Workers,
+		))
+	}
+	if reportsExport {
+		ctrls = append(ctrls, internal.NewController(
+			exportcontroller.ControllerName,
+			exportcontroller.NewController(
+				kyvernoClient,
+				secretClient,
+				reportsExportConfig,
+			),
+			exportcontroller.Workers,
+		))
+	}
 	return ctrls, func(ctx context.Context) error {
 		for _, warmup := range warmups {
 			if err := warmup(ctx); err != nil {
@@ -152,6 +256,7 @@ func createrLeaderControllers(
 	aggregateReports bool,
 	policyReports bool,
 	validatingAdmissionPolicyReports bool,
+	reportSummaries bool,
 	aggregationWorkers int,
 	backgroundScanWorkers int,
 	kubeInformer kubeinformers.SharedInformerFactory,
@@ -163,7 +268,17 @@ func createrLeaderControllers(
 	jp jmespath.Interface,
 	eventGenerator event.Interface,
 	backgroundScanInterval time.Duration,
+	backgroundScanFullResync time.Duration,
+	backgroundScanJitter time.Duration,
 	reportsBreaker breaker.Breaker,
+	reportStore reportutils.Store,
+	resultSink reportutils.ResultSink,
+	reportsCompaction bool,
+	reportsCompactionInterval time.Duration,
+	reportsResultsTTL time.Duration,
+	reportsExport bool,
+	reportsExportConfig exportcontroller.Config,
+	secretClient corev1client.SecretInterface,
 ) ([]internal.Controller, func(context.Context) error, error) {
 	reportControllers, warmup := createReportControllers(
 		eng,
@@ -172,6 +287,7 @@ func createrLeaderControllers(
 		aggregateReports,
 		policyReports,
 		validatingAdmissionPolicyReports,
+		reportSummaries,
 		aggregationWorkers,
 		backgroundScanWorkers,
 		dynamicClient,
@@ -180,11 +296,21 @@ func createrLeaderControllers(
 		kubeInformer,
 		kyvernoInformer,
 		backgroundScanInterval,
+		backgroundScanFullResync,
+		backgroundScanJitter,
 		configuration,
 		jp,
 		eventGenerator,
 		reportsConfig,
 		reportsBreaker,
+		reportStore,
+		resultSink,
+		reportsCompaction,
+		reportsCompactionInterval,
+		reportsResultsTTL,
+		reportsExport,
+		reportsExportConfig,
+		secretClient,
 	)
 	return reportControllers, warmup, nil
 }
@@ -196,15 +322,27 @@ func main() {
 		aggregateReports                 bool
 		policyReports                    bool
 		validatingAdmissionPolicyReports bool
+		reportSummaries                  bool
 		reportsCRDsSanityChecks          bool
 		backgroundScanWorkers            int
 		backgroundScanInterval           time.Duration
+		backgroundScanFullResync         time.Duration
+		backgroundScanJitter             time.Duration
 		aggregationWorkers               int
 		maxQueuedEvents                  int
 		omitEvents                       string
 		skipResourceFilters              bool
 		maxAPICallResponseLength         int64
 		maxBackgroundReports             int
+		reportsStorage                   string
+		reportsStorageURL                string
+		reportsCompaction                bool
+		reportsCompactionInterval        time.Duration
+		reportsResultsTTL                time.Duration
+		reportsExport                    bool
+		reportsExportConfig              exportcontroller.Config
+		resultsSinkOptions               reportutils.HTTPResultSinkOptions
+		resultsSinkStatuses              string
 	)
 	flagset := flag.NewFlagSet("reports-controller", flag.ExitOnError)
 	flagset.BoolVar(&backgroundScan, "backgroundScan", true, "Enable or disable background scan.")
@@ -212,15 +350,40 @@ func main() {
 	flagset.BoolVar(&aggregateReports, "aggregateReports", true, "Enable or disable aggregated policy reports.")
 	flagset.BoolVar(&policyReports, "policyReports", true, "Enable or disable policy reports.")
 	flagset.BoolVar(&validatingAdmissionPolicyReports, "validatingAdmissionPolicyReports", false, "Enable or disable validating admission policy reports.")
+	flagset.BoolVar(&reportSummaries, "reportSummaries", true, "Enable or disable the report summaries maintaining the policy reports results count of each namespace.")
 	flagset.IntVar(&aggregationWorkers, "aggregationWorkers", aggregatereportcontroller.Workers, "Configure the number of ephemeral reports aggregation workers.")
 	flagset.IntVar(&backgroundScanWorkers, "backgroundScanWorkers", backgroundscancontroller.Workers, "Configure the number of background scan workers.")
 	flagset.DurationVar(&backgroundScanInterval, "backgroundScanInterval", time.Hour, "Configure background scan interval.")
+	flagset.DurationVar(&backgroundScanFullResync, "backgroundScanFullResyncInterval", 0, "Configure the interval of the full background rescans, in between only the resources whose content, namespace labels or applicable policies changed are rescanned. Every resource is rescanned every background scan interval when not set.")
+	flagset.DurationVar(&backgroundScanJitter, "backgroundScanJitter", 5*time.Minute, "Configure the maximum delay spreading the scans of the policies with a scan schedule annotation.")
 	flagset.IntVar(&maxQueuedEvents, "maxQueuedEvents", 1000, "Maximum events to be queued.")
 	flagset.StringVar(&omitEvents, "omitEvents", "", "Set this flag to a comma separated list of PolicyViolation, PolicyApplied, PolicyError, PolicySkipped to disable events, e.g. --omitEvents=PolicyApplied,PolicyViolation")
 	flagset.BoolVar(&skipResourceFilters, "skipResourceFilters", true, "If true, resource filters wont be considered.")
 	flagset.Int64Var(&maxAPICallResponseLength, "maxAPICallResponseLength", 2*1000*1000, "Maximum allowed response size from API Calls. A value of 0 bypasses checks (not recommended).")
 	flagset.IntVar(&maxBackgroundReports, "maxBackgroundReports", 10000, "Maximum number of ephemeralreports created for the background policies before we stop creating new ones")
 	flagset.BoolVar(&reportsCRDsSanityChecks, "reportsCRDsSanityChecks", true, "Enable or disable sanity checks for policy reports and ephemeral reports CRDs.")
+	flagset.StringVar(&reportsStorage, "reportsStorage", reportutils.StorageCRD, "Storage backend of the policy reports (crd, reports-server, http). The reports-server storage requires the reports-server api services to be registered, the http storage writes policy reports to the external store configured with --reportsStorageURL.")
+	flagset.StringVar(&reportsStorageURL, "reportsStorageURL", "", "URL of the external store used by the http reports storage. The bearer token, if any, is read from the REPORTS_STORAGE_TOKEN environment variable.")
+	flagset.BoolVar(&reportsCompaction, "reportsCompaction", false, "Enable or disable the compaction of policy reports, reports whose resource no longer exists are deleted.")
+	flagset.DurationVar(&reportsCompactionInterval, "reportsCompactionInterval", time.Hour, "Configure the interval between two compactions of the policy reports.")
+	flagset.DurationVar(&reportsResultsTTL, "reportsResultsTTL", 0, "Expire the policy reports results older than this duration (e.g. 720h), expired results are removed by the compaction. A value of 0 never expires results.")
+	flagset.BoolVar(&reportsExport, "reportsExport", false, "Enable or disable the periodic export of the policy reports to an object storage.")
+	flagset.StringVar(&reportsExportConfig.Schedule, "reportsExportSchedule", "0 0 * * *", "Cron schedule of the policy reports exports.")
+	flagset.StringVar(&reportsExportConfig.Format, "reportsExportFormat", exportcontroller.FormatJSON, "Format of the exported policy reports (json, sarif).")
+	flagset.StringVar(&reportsExportConfig.Provider, "reportsExportProvider", exportcontroller.ProviderS3, "Object storage receiving the exported policy reports (s3, gcs, azure).")
+	flagset.StringVar(&reportsExportConfig.Bucket, "reportsExportBucket", "", "Bucket receiving the exported policy reports, the blob container with the azure provider.")
+	flagset.StringVar(&reportsExportConfig.Prefix, "reportsExportPrefix", "", "Prefix of the exported policy reports objects.")
+	flagset.StringVar(&reportsExportConfig.Endpoint, "reportsExportEndpoint", "", "Endpoint of the object storage, required by the azure provider (storage account url), it defaults to the provider endpoint for s3 and gcs.")
+	flagset.StringVar(&reportsExportConfig.Region, "reportsExportRegion", "", "Region of the bucket, only used by the s3 provider.")
+	flagset.StringVar(&reportsExportConfig.Secret, "reportsExportSecret", "", "Name of the secret holding the object storage credentials, in the kyverno namespace.")
+	flagset.StringVar(&resultsSinkOptions.URL, "resultsSinkURL", "", "URL of an http endpoint receiving the report results as they are created or updated, as JSON lines batches. The bearer token, if any, is read from the RESULTS_SINK_TOKEN environment variable.")
+	flagset.StringVar(&resultsSinkStatuses, "resultsSinkStatuses", "fail,warn,error", "Comma separated list of the result statuses sent to the results sink, all results are sent when empty.")
+	flagset.StringVar(&resultsSinkOptions.CAFile, "resultsSinkCAFile", "", "CA bundle used to verify the results sink server certificate.")
+	flagset.StringVar(&resultsSinkOptions.CertFile, "resultsSinkClientCertFile", "", "Client certificate used for mutual TLS with the results sink.")
+	flagset.StringVar(&resultsSinkOptions.KeyFile, "resultsSinkClientKeyFile", "", "Client key used for mutual TLS with the results sink.")
+	flagset.IntVar(&resultsSinkOptions.BatchSize, "resultsSinkBatchSize", 100, "Maximum number of results sent to the results sink in a single request.")
+	flagset.DurationVar(&resultsSinkOptions.BatchInterval, "resultsSinkBatchInterval", 5*time.Second, "Maximum time a result is held before being sent to the results sink.")
+	flagset.IntVar(&resultsSinkOptions.MaxRetries, "resultsSinkMaxRetries", 5, "Number of retries of a batch the results sink failed to receive before it is dropped.")
 	// config
 	appConfig := internal.NewConfiguration(
 		internal.WithProfiling(),
@@ -232,7 +395,9 @@ func main() {
 		internal.WithDeferredLoading(),
 		internal.WithCosign(),
 		internal.WithRegistryClient(),
+		internal.WithWasm(),
 		internal.WithImageVerifyCache(),
+		internal.WithGlobalContext(),
 		internal.WithLeaderElection(),
 		internal.WithKyvernoClient(),
 		internal.WithDynamicClient(),
@@ -257,9 +422,54 @@ func main() {
 		// THIS IS AN UGLY FIX
 		// ELSE KYAML IS NOT THREAD SAFE
 		kyamlopenapi.Schema()
-		if err := sanityChecks(setup.ApiServerClient); err != nil {
-			setup.Logger.Error(err, "sanity checks failed")
-			if reportsCRDsSanityChecks {
+		// summaries are stored in a CRD of the reports.kyverno.io group, the group is served by the reports-server
+		// when it is used and policy reports are not served by the API server with the http storage
+		if reportSummaries && reportsStorage != "" && reportsStorage != reportutils.StorageCRD {
+			setup.Logger.Info("report summaries are only supported with the crd reports storage, disabling them", "storage", reportsStorage)
+			reportSummaries = false
+		}
+		// the reports-server serves reports without CRDs, its api services are checked when creating the report store
+		if reportsStorage != reportutils.StorageReportsServer {
+			if err := sanityChecks(setup.ApiServerClient, reportSummaries); err != nil {
+				setup.Logger.Error(err, "sanity checks failed")
+				if reportsCRDsSanityChecks {
+					os.Exit(1)
+				}
+			}
+		}
+		reportStore, err := createReportStore(ctx, setup.Logger, reportsStorage, reportsStorageURL, setup.KyvernoClient)
+		if err != nil {
+			setup.Logger.Error(err, "failed to create report store")
+			os.Exit(1)
+		}
+		var resultSink reportutils.ResultSink
+		if resultsSinkOptions.URL != "" {
+			// the token is never passed on the command line, it comes from the environment (usually set from a secret)
+			resultsSinkOptions.Token = os.Getenv(resultsSinkTokenEnv)
+			for _, status := range strings.Split(resultsSinkStatuses, ",") {
+				if status := strings.TrimSpace(status); status != "" {
+					resultsSinkOptions.Statuses = append(resultsSinkOptions.Statuses, policyreportv1alpha2.PolicyResult(status))
+				}
+			}
+			resultSink, err = reportutils.NewHTTPResultSink(resultsSinkOptions)
+			if err != nil {
+				setup.Logger.Error(err, "failed to create results sink")
+				os.Exit(1)
+			}
+			defer resultSink.Close()
+		}
+		if (reportsCompaction || reportsResultsTTL > 0) && reportsCompactionInterval <= 0 {
+			setup.Logger.Error(errors.New("reports compaction interval must be positive"), "invalid reports compaction configuration", "interval", reportsCompactionInterval.String())
+			os.Exit(1)
+		}
+		// exported reports are listed from the API server
+		if reportsExport && reportsStorage == reportutils.StorageHTTP {
+			setup.Logger.Info("reports export is not supported with the http reports storage, disabling it")
+			reportsExport = false
+		}
+		if reportsExport {
+			if err := reportsExportConfig.Validate(); err != nil {
+				setup.Logger.Error(err, "invalid reports export configuration")
 				os.Exit(1)
 			}
 		}
@@ -281,6 +491,7 @@ func main() {
 			maxQueuedEvents,
 			strings.Split(omitEvents, ",")...,
 		)
+		internal.ReloadOmitEvents(setup.Configuration, eventGenerator, strings.Split(omitEvents, ",")...)
 		eventController := internal.NewController(
 			event.ControllerName,
 			eventGenerator,
@@ -295,7 +506,8 @@ func main() {
 				setup.KyvernoClient,
 				gcstore,
 				eventGenerator,
-				maxAPICallResponseLength,
+				apicall.NewAPICallConfiguration(maxAPICallResponseLength, apicall.WithAuthSecrets(setup.SecretResolver, setup.Configuration)),
+				setup.Jp,
 				false,
 			),
 			globalcontextcontroller.Workers,
@@ -313,7 +525,7 @@ func main() {
 			setup.KubeClient,
 			setup.KyvernoClient,
 			setup.RegistrySecretLister,
-			apicall.NewAPICallConfiguration(maxAPICallResponseLength),
+			apicall.NewAPICallConfiguration(maxAPICallResponseLength, apicall.WithAuthSecrets(setup.SecretResolver, setup.Configuration)),
 			polexCache,
 			gcstore,
 		)
@@ -322,6 +534,15 @@ func main() {
 			setup.Logger.Error(errors.New("failed to wait for cache sync"), "failed to wait for cache sync")
 			os.Exit(1)
 		}
+		// load the global context snapshot before the entries are refreshed
+		gcSnapshot := internal.NewGlobalContextSnapshot(
+			ctx,
+			setup.Logger,
+			setup.KubeClient,
+			"kyverno-reports-controller-globalcontext",
+			kyvernoInformer.Kyverno().V2alpha1().GlobalContextEntries().Lister(),
+			gcstore,
+		)
 		ephrs, err := breaker.StartBackgroundReportsCounter(ctx, setup.MetadataClient)
 		if err != nil {
 			setup.Logger.Error(err, "failed to start background-scan reports watcher")
@@ -329,13 +550,7 @@ func main() {
 		}
 
 		// create the circuit breaker
-		reportsBreaker := breaker.NewBreaker("background scan reports", func(context.Context) bool {
-			count, isRunning := ephrs.Count()
-			if !isRunning {
-				return true
-			}
-			return count > maxBackgroundReports
-		})
+		reportsBreaker := breaker.NewCounterBreaker("background scan reports", ephrs, maxBackgroundReports)
 		// setup leader election
 		le, err := leaderelection.New(
 			setup.Logger.WithName("leader-election"),
@@ -360,6 +575,7 @@ func main() {
 					aggregateReports,
 					policyReports,
 					validatingAdmissionPolicyReports,
+					reportSummaries,
 					aggregationWorkers,
 					backgroundScanWorkers,
 					kubeInformer,
@@ -371,7 +587,17 @@ func main() {
 					setup.Jp,
 					eventGenerator,
 					backgroundScanInterval,
+					backgroundScanFullResync,
+					backgroundScanJitter,
 					reportsBreaker,
+					reportStore,
+					resultSink,
+					reportsCompaction,
+					reportsCompactionInterval,
+					reportsResultsTTL,
+					reportsExport,
+					reportsExportConfig,
+					setup.KubeClient.CoreV1().Secrets(config.KyvernoNamespace()),
 				)
 				if err != nil {
 					logger.Error(err, "failed to create leader controllers")
@@ -408,6 +634,9 @@ func main() {
 		// start non leader controllers
 		eventController.Run(ctx, setup.Logger, &wg)
 		gceController.Run(ctx, setup.Logger, &wg)
+		if gcSnapshot != nil {
+			gcSnapshot.Run(ctx, setup.Logger, &wg)
+		}
 		if polexController != nil {
 			polexController.Run(ctx, setup.Logger, &wg)
 		}
@@ -149,6 +149,56 @@ func GetRequestedControllers(meta *metav1.ObjectMeta) sets.Set[string] {
 	return sets.New(splitKinds(controllers, ",")...)
 }
 
+// CustomController is a workload kind embedding a pod template, declared through the
+// pod-policies.kyverno.io/autogen-custom-controllers annotation.
+type CustomController struct {
+	// Kind is the kind of the controller, it can be prefixed by its group and version (e.g. argoproj.io/v1alpha1/Rollout)
+	Kind string
+	// TemplatePath is the dot separated path of the pod template in the controller (e.g. spec.template)
+	TemplatePath string
+}
+
+// kindName returns the kind without group and version
+func (c CustomController) kindName() string {
+	_, kind := kubeutils.GetKindFromGVK(c.Kind)
+	return kind
+}
+
+// templateKey returns the path of the pod template relative to the spec
+func (c CustomController) templateKey() string {
+	return strings.TrimPrefix(c.TemplatePath, "spec.")
+}
+
+// GetCustomControllers returns the custom controllers declared in the annotations.
+// The annotation value is a comma separated list of <kind>=<pod template path> entries,
+// entries that are not valid are ignored.
+func GetCustomControllers(annotations map[string]string) []CustomController {
+	value := annotations[kyverno.AnnotationAutogenCustomControllers]
+	if value == "" {
+		return nil
+	}
+	var controllers []CustomController
+	for _, entry := range strings.Split(value, ",") {
+		kind, path, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		kind, path = strings.TrimSpace(kind), strings.TrimSpace(path)
+		if !ok || kind == "" || !strings.HasPrefix(path, "spec.") || strings.Contains(path, "..") || strings.HasSuffix(path, ".") {
+			debug.Info("skip invalid custom autogen controller", "entry", entry)
+			continue
+		}
+		controllers = append(controllers, CustomController{Kind: kind, TemplatePath: path})
+	}
+	return controllers
+}
+
+func findCustomController(controllers []CustomController, kind string) *CustomController {
+	for i := range controllers {
+		if controllers[i].kindName() == kind {
+			return &controllers[i]
+		}
+	}
+	return nil
+}
+
 // GetControllers computes the autogen controllers that should be applied to a policy.
 // It returns the requested, supported and effective controllers (intersection of requested and supported ones).
 func GetControllers(meta *metav1.ObjectMeta, spec *kyvernov1.Spec) ([]string, []string, []string) {
@@ -201,6 +251,23 @@ func generateRules(spec *kyvernov1.Spec, controllers string) []kyvernov1.Rule {
 	return rules
 }
 
+// generateCustomRules generates rules for the custom controllers
+func generateCustomRules(spec *kyvernov1.Spec, controllers []CustomController) []kyvernov1.Rule {
+	var rules []kyvernov1.Rule
+	for i := range spec.Rules {
+		for _, controller := range controllers {
+			if genRule := createRule(generateRuleForCustomController(&spec.Rules[i], controller)); genRule != nil {
+				if convRule, err := convertRule(*genRule, controller.TemplatePath); err == nil {
+					rules = append(rules, *convRule)
+				} else {
+					logger.Error(err, "failed to create custom controller rule", "kind", controller.Kind)
+				}
+			}
+		}
+	}
+	return rules
+}
+
 func convertRule(rule kyvernoRule, kind string) (*kyvernov1.Rule, error) {
 	if bytes, err := json.Marshal(rule); err != nil {
 		return nil, err
@@ -266,19 +333,32 @@ func computeRules(p kyvernov1.PolicyInterface, kind string) []kyvernov1.Rule {
 		}
 	}
 
+	var customControllers []CustomController
+	if applyAutoGen && !actualControllers.Has("none") {
+		customControllers = GetCustomControllers(ann)
+	}
+
 	if kind != "" {
-		if !actualControllers.Has(kind) {
+		if controller := findCustomController(customControllers, kind); controller != nil {
+			kind, customControllers = "none", []CustomController{*controller}
+		} else if !actualControllers.Has(kind) {
 			return spec.Rules
+		} else {
+			customControllers = nil
 		}
 	} else {
 		kind = strings.Join(actualControllers.UnsortedList(), ",")
 	}
 
-	if kind == "none" {
+	if kind == "none" && len(customControllers) == 0 {
 		return spec.Rules
 	}
 
-	genRules := generateRules(spec.DeepCopy(), kind)
+	var genRules []kyvernov1.Rule
+	if kind != "none" {
+		genRules = generateRules(spec.DeepCopy(), kind)
+	}
+	genRules = append(genRules, generateCustomRules(spec.DeepCopy(), customControllers)...)
 	if len(genRules) == 0 {
 		return spec.Rules
 	}
@@ -315,11 +395,7 @@ func createAutogenAssertion(tree kyvernov1.AssertionTree, tplKey string) kyverno
 			continue
 		}
 
-		value[n] = map[string]any{
-			"spec": map[string]any{
-				tplKey: copyMap(object),
-			},
-		}
+		value[n] = wrapTemplate(tplKey, copyMap(object))
 	}
 
 	return kyvernov1.AssertionTree{
@@ -341,6 +341,16 @@ func TestUpdateGenRuleByte(t *testing.T) {
 			kind:  "Pod",
 			want:  []byte("request.object.spec.template.metadata"),
 		},
+		{
+			pbyte: []byte("request.object.spec"),
+			kind:  "spec.jobTargetRef.template",
+			want:  []byte("request.object.spec.jobTargetRef.template.spec"),
+		},
+		{
+			pbyte: []byte("request.oldObject.metadata"),
+			kind:  "spec.jobTargetRef.template",
+			want:  []byte("request.oldObject.spec.jobTargetRef.template.metadata"),
+		},
 	}
 	for _, tt := range tests {
 		got := updateFields(tt.pbyte, tt.kind, false)
@@ -642,3 +652,77 @@ func Test_ValidateWithAssertion(t *testing.T) {
 	rules := computeRules(policies[0], "")
 	assert.Equal(t, 3, len(rules))
 }
+
+func Test_GetCustomControllers(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		expected    []CustomController
+	}{
+		{
+			name:        "no annotation",
+			annotations: nil,
+			expected:    nil,
+		},
+		{
+			name: "valid entries",
+			annotations: map[string]string{
+				kyverno.AnnotationAutogenCustomControllers: "argoproj.io/v1alpha1/Rollout=spec.template, keda.sh/v1alpha1/ScaledJob=spec.jobTargetRef.template",
+			},
+			expected: []CustomController{
+				{Kind: "argoproj.io/v1alpha1/Rollout", TemplatePath: "spec.template"},
+				{Kind: "keda.sh/v1alpha1/ScaledJob", TemplatePath: "spec.jobTargetRef.template"},
+			},
+		},
+		{
+			name: "invalid entries",
+			annotations: map[string]string{
+				kyverno.AnnotationAutogenCustomControllers: "Rollout,=spec.template,ScaledJob=metadata,TaskRun=spec.,Workflow=spec..template,Rollout=spec.template",
+			},
+			expected: []CustomController{
+				{Kind: "Rollout", TemplatePath: "spec.template"},
+			},
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.DeepEqual(t, GetCustomControllers(test.annotations), test.expected)
+		})
+	}
+}
+
+func Test_ComputeRulesWithCustomControllers(t *testing.T) {
+	policy := []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"require-labels","annotations":{"pod-policies.kyverno.io/autogen-custom-controllers":"keda.sh/v1alpha1/ScaledJob=spec.jobTargetRef.template"}},"spec":{"rules":[{"name":"check-team","match":{"any":[{"resources":{"kinds":["Pod"]}}]},"validate":{"message":"label {{request.object.metadata.name}} is required","pattern":{"metadata":{"labels":{"team":"?*"}}}}}]}}`)
+	policies, _, _, err := yamlutils.GetPolicy(policy)
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(policies))
+
+	rules := computeRules(policies[0], "")
+	assert.Equal(t, 4, len(rules))
+	rule := rules[3]
+	assert.Equal(t, rule.Name, "autogen-scaledjob-check-team")
+	assert.DeepEqual(t, rule.MatchResources.Any[0].Kinds, []string{"keda.sh/v1alpha1/ScaledJob"})
+	assert.DeepEqual(t, rule.Validation.GetPattern(), map[string]interface{}{
+		"spec": map[string]interface{}{
+			"jobTargetRef": map[string]interface{}{
+				"template": map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"labels": map[string]interface{}{"team": "?*"},
+					},
+				},
+			},
+		},
+	})
+	assert.Equal(t, rule.Validation.Message, "label {{request.object.spec.jobTargetRef.template.metadata.name}} is required")
+
+	rules = computeRules(policies[0], "ScaledJob")
+	assert.Equal(t, 2, len(rules))
+	assert.Equal(t, rules[1].Name, "autogen-scaledjob-check-team")
+
+	policies[0].SetAnnotations(map[string]string{
+		kyverno.AnnotationAutogenControllers:       "none",
+		kyverno.AnnotationAutogenCustomControllers: "keda.sh/v1alpha1/ScaledJob=spec.jobTargetRef.template",
+	})
+	rules = computeRules(policies[0], "")
+	assert.Equal(t, 1, len(rules))
+}
@@ -106,11 +106,7 @@ func generateRule(name string, rule *kyvernov1.Rule, tplKey, shift string, kinds
 		if target := rule.Mutation.GetPatchStrategicMerge(); target != nil {
 			newMutation := &kyvernov1.Mutation{}
 			newMutation.SetPatchStrategicMerge(
-				map[string]interface{}{
-					"spec": map[string]interface{}{
-						tplKey: target,
-					},
-				},
+				wrapTemplate(tplKey, target),
 			)
 			rule.Mutation = newMutation
 			return rule
@@ -124,11 +120,7 @@ func generateRule(name string, rule *kyvernov1.Rule, tplKey, shift string, kinds
 					AnyAllConditions: foreach.AnyAllConditions,
 				}
 				temp.SetPatchStrategicMerge(
-					map[string]interface{}{
-						"spec": map[string]interface{}{
-							tplKey: foreach.GetPatchStrategicMerge(),
-						},
-					},
+					wrapTemplate(tplKey, foreach.GetPatchStrategicMerge()),
 				)
 				newForEachMutation = append(newForEachMutation, temp)
 			}
@@ -147,11 +139,7 @@ func generateRule(name string, rule *kyvernov1.Rule, tplKey, shift string, kinds
 				AllowExistingViolations: rule.Validation.AllowExistingViolations,
 			}
 			newValidate.SetPattern(
-				map[string]interface{}{
-					"spec": map[string]interface{}{
-						tplKey: target,
-					},
-				},
+				wrapTemplate(tplKey, target),
 			)
 			rule.Validation = newValidate
 			return rule
@@ -191,11 +179,7 @@ func generateRule(name string, rule *kyvernov1.Rule, tplKey, shift string, kinds
 			}
 			var patterns []interface{}
 			for _, pattern := range anyPatterns {
-				newPattern := map[string]interface{}{
-					"spec": map[string]interface{}{
-						tplKey: pattern,
-					},
-				}
+				newPattern := wrapTemplate(tplKey, pattern)
 				patterns = append(patterns, newPattern)
 			}
 			failureAction := rule.Validation.FailureAction
@@ -244,6 +228,15 @@ func generateRule(name string, rule *kyvernov1.Rule, tplKey, shift string, kinds
 	return nil
 }
 
+// wrapTemplate nests the value under the pod template, tplKey is the dot separated path of the template in the spec
+func wrapTemplate(tplKey string, value interface{}) map[string]interface{} {
+	keys := strings.Split(tplKey, ".")
+	for i := len(keys) - 1; i >= 0; i-- {
+		value = map[string]interface{}{keys[i]: value}
+	}
+	return map[string]interface{}{"spec": value}
+}
+
 func getAutogenRuleName(prefix string, name string) string {
 	name = prefix + "-" + name
 	if len(name) > 63 {
@@ -323,6 +316,32 @@ func generateRuleForControllers(rule *kyvernov1.Rule, controllers string) *kyver
 	)
 }
 
+func generateRuleForCustomController(rule *kyvernov1.Rule, controller CustomController) *kyvernov1.Rule {
+	if isAutogenRuleName(rule.Name) {
+		return nil
+	}
+	match := rule.MatchResources
+	matchKinds := match.GetKinds()
+	var excludeKinds []string
+	if exclude := rule.ExcludeResources; exclude != nil {
+		excludeKinds = exclude.GetKinds()
+	}
+	if !kubeutils.ContainsKind(matchKinds, "Pod") || (len(excludeKinds) != 0 && !kubeutils.ContainsKind(excludeKinds, "Pod")) {
+		return nil
+	}
+	debug.Info("generating rule for custom controller", "rulename", rule.Name, "kind", controller.Kind)
+	return generateRule(
+		getAutogenRuleName("autogen-"+strings.ToLower(controller.kindName()), rule.Name),
+		rule,
+		controller.templateKey(),
+		strings.ReplaceAll(controller.TemplatePath, ".", "/"),
+		[]string{controller.Kind},
+		func(r kyvernov1.ResourceFilters, kinds []string) kyvernov1.ResourceFilters {
+			return getAnyAllAutogenRule(r, "Pod", kinds)
+		},
+	)
+}
+
 func splitKinds(controllers, separator string) []string {
 	kinds := strings.Split(controllers, separator)
 	sort.Strings(kinds)
@@ -378,6 +397,24 @@ var (
 	}
 )
 
+// templateReplacementRules computes the replacement rules for a pod template located at the given path
+func templateReplacementRules(templatePath string, cel bool) [][2][]byte {
+	var rules [][2][]byte
+	for _, object := range []string{"object", "oldObject"} {
+		prefix := object
+		if !cel {
+			prefix = "request." + object
+		}
+		for _, field := range []string{"spec", "metadata"} {
+			rules = append(rules, [2][]byte{
+				[]byte(prefix + "." + field),
+				[]byte(prefix + "." + templatePath + "." + field),
+			})
+		}
+	}
+	return rules
+}
+
 func updateFields(data []byte, kind string, cel bool) []byte {
 	switch kind {
 	case "Pod":
@@ -400,6 +437,11 @@ func updateFields(data []byte, kind string, cel bool) []byte {
 				data = bytes.ReplaceAll(data, replacement[0], replacement[1])
 			}
 		}
+	default:
+		// custom controllers, kind holds the path of the pod template
+		for _, replacement := range templateReplacementRules(kind, cel) {
+			data = bytes.ReplaceAll(data, replacement[0], replacement[1])
+		}
 	}
 
 	return data
@@ -2,6 +2,7 @@ package common
 
 import (
 	"context"
+	"fmt"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
@@ -11,6 +12,8 @@ import (
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/logging"
 	errors "github.com/pkg/errors"
+	"go.uber.org/multierr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -29,9 +32,7 @@ func UpdateStatus(client versioned.Interface, urLister kyvernov2listers.UpdateRe
 	}
 
 	if state == kyvernov2.Failed {
-		if latest, err = retryOrDeleteOnFailure(client, latest, 3); err != nil {
-			return nil, err
-		}
+		latest.Status.RetryCount++
 	}
 	new, err := client.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(context.TODO(), latest, metav1.UpdateOptions{})
 	if err != nil {
@@ -59,20 +60,30 @@ func ResourceSpecFromUnstructured(obj unstructured.Unstructured) kyvernov1.Resou
 	}
 }
 
-func retryOrDeleteOnFailure(kyvernoClient versioned.Interface, ur *kyvernov2.UpdateRequest, limit int) (latest *kyvernov2.UpdateRequest, err error) {
-	if ur.Status.RetryCount > limit {
-		err = kyvernoClient.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).Delete(context.TODO(), ur.GetName(), metav1.DeleteOptions{})
-		if err != nil {
-			return nil, errors.Wrapf(err, "exceeds retry limit, failed to delete the UR: %s, retry: %v, resourceVersion: %s", ur.Name, ur.Status.RetryCount, ur.GetResourceVersion())
-		}
-	} else {
-		ur.Status.RetryCount++
-	}
-
-	return ur, nil
-}
-
 func FindDownstream(client dclient.Interface, apiVersion, kind string, labels map[string]string) (*unstructured.UnstructuredList, error) {
 	selector := &metav1.LabelSelector{MatchLabels: labels}
 	return client.ListResource(context.TODO(), apiVersion, kind, "", selector)
 }
+
+// LabelOrphans adds the given labels to the generated resources kept after the deletion of their trigger, rule or policy
+func LabelOrphans(client dclient.Interface, orphans []unstructured.Unstructured, labels map[string]string) error {
+	if len(labels) == 0 {
+		return nil
+	}
+	var errs []error
+	for i := range orphans {
+		orphan := orphans[i].DeepCopy()
+		orphanLabels := orphan.GetLabels()
+		if orphanLabels == nil {
+			orphanLabels = make(map[string]string, len(labels))
+		}
+		for k, v := range labels {
+			orphanLabels[k] = v
+		}
+		orphan.SetLabels(orphanLabels)
+		if _, err := client.UpdateResource(context.TODO(), orphan.GetAPIVersion(), orphan.GetKind(), orphan.GetNamespace(), orphan, false); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to label orphan %s/%s/%s: %w", orphan.GetKind(), orphan.GetNamespace(), orphan.GetName(), err))
+		}
+	}
+	return multierr.Combine(errs...)
+}
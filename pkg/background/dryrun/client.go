@@ -0,0 +1,66 @@
+package dryrun
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// client wraps a dclient.Interface and turns every write into a server side dry run,
+// the objects returned by the api server are recorded instead of being persisted
+type client struct {
+	dclient.Interface
+	lock    sync.Mutex
+	objects []*unstructured.Unstructured
+}
+
+func newClient(inner dclient.Interface) *client {
+	return &client{Interface: inner}
+}
+
+func (c *client) record(obj *unstructured.Unstructured, err error) (*unstructured.Unstructured, error) {
+	if err == nil && obj != nil {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		c.objects = append(c.objects, obj)
+	}
+	return obj, err
+}
+
+// Objects returns the objects recorded so far
+func (c *client) Objects() []*unstructured.Unstructured {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return append([]*unstructured.Unstructured{}, c.objects...)
+}
+
+func (c *client) PatchResource(_ context.Context, apiVersion string, kind string, namespace string, name string, _ []byte) (*unstructured.Unstructured, error) {
+	return nil, fmt.Errorf("patching %s %s/%s is not supported in dry run mode", kind, namespace, name)
+}
+
+func (c *client) DeleteResource(ctx context.Context, apiVersion string, kind string, namespace string, name string, _ bool) error {
+	return c.Interface.DeleteResource(ctx, apiVersion, kind, namespace, name, true)
+}
+
+func (c *client) CreateResource(ctx context.Context, apiVersion string, kind string, namespace string, obj interface{}, _ bool) (*unstructured.Unstructured, error) {
+	return c.record(c.Interface.CreateResource(ctx, apiVersion, kind, namespace, obj, true))
+}
+
+func (c *client) UpdateResource(ctx context.Context, apiVersion string, kind string, namespace string, obj interface{}, _ bool, subresources ...string) (*unstructured.Unstructured, error) {
+	return c.record(c.Interface.UpdateResource(ctx, apiVersion, kind, namespace, obj, true, subresources...))
+}
+
+func (c *client) UpdateStatusResource(ctx context.Context, apiVersion string, kind string, namespace string, obj interface{}, _ bool) (*unstructured.Unstructured, error) {
+	return c.record(c.Interface.UpdateStatusResource(ctx, apiVersion, kind, namespace, obj, true))
+}
+
+func (c *client) ApplyResource(ctx context.Context, apiVersion string, kind string, namespace string, name string, obj interface{}, _ bool, fieldManager string, subresources ...string) (*unstructured.Unstructured, error) {
+	return c.record(c.Interface.ApplyResource(ctx, apiVersion, kind, namespace, name, obj, true, fieldManager, subresources...))
+}
+
+func (c *client) ApplyStatusResource(ctx context.Context, apiVersion string, kind string, namespace string, name string, obj interface{}, _ bool, fieldManager string) (*unstructured.Unstructured, error) {
+	return c.record(c.Interface.ApplyStatusResource(ctx, apiVersion, kind, namespace, name, obj, true, fieldManager))
+}
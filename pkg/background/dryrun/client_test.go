@@ -0,0 +1,57 @@
+package dryrun
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type fakeClient struct {
+	dclient.Interface
+	dryRuns []bool
+}
+
+func (c *fakeClient) CreateResource(_ context.Context, apiVersion string, kind string, namespace string, obj interface{}, dryRun bool) (*unstructured.Unstructured, error) {
+	c.dryRuns = append(c.dryRuns, dryRun)
+	return obj.(*unstructured.Unstructured), nil
+}
+
+func (c *fakeClient) UpdateResource(_ context.Context, apiVersion string, kind string, namespace string, obj interface{}, dryRun bool, _ ...string) (*unstructured.Unstructured, error) {
+	c.dryRuns = append(c.dryRuns, dryRun)
+	return obj.(*unstructured.Unstructured), nil
+}
+
+func (c *fakeClient) DeleteResource(_ context.Context, apiVersion string, kind string, namespace string, name string, dryRun bool) error {
+	c.dryRuns = append(c.dryRuns, dryRun)
+	return nil
+}
+
+func newObject(name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetNamespace("default")
+	obj.SetName(name)
+	return obj
+}
+
+func TestClient(t *testing.T) {
+	inner := &fakeClient{}
+	c := newClient(inner)
+	_, err := c.CreateResource(context.TODO(), "v1", "ConfigMap", "default", newObject("created"), false)
+	assert.NoError(t, err)
+	_, err = c.UpdateResource(context.TODO(), "v1", "ConfigMap", "default", newObject("updated"), false)
+	assert.NoError(t, err)
+	assert.NoError(t, c.DeleteResource(context.TODO(), "v1", "ConfigMap", "default", "deleted", false))
+	_, err = c.PatchResource(context.TODO(), "v1", "ConfigMap", "default", "patched", []byte(`[]`))
+	assert.Error(t, err)
+	assert.Equal(t, []bool{true, true, true}, inner.dryRuns)
+	var names []string
+	for _, obj := range c.Objects() {
+		names = append(names, obj.GetName())
+	}
+	assert.Equal(t, []string{"created", "updated"}, names)
+}
@@ -0,0 +1,99 @@
+package dryrun
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/background/generate"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/engine"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Simulate computes the side effects the mutateExisting and generate rules of the policy would have
+// for the trigger held by the policy context, without creating update requests nor persisting changes.
+// The responses of mutateExisting rules carry the targets as they would be patched, the responses of
+// generate rules carry the resources as they would be created or updated, both are computed by the
+// api server through server side dry runs when a cluster client is available.
+func Simulate(
+	ctx context.Context,
+	logger logr.Logger,
+	eng engineapi.Engine,
+	client dclient.Interface,
+	policyContext *engine.PolicyContext,
+) engineapi.EngineResponse {
+	policy := policyContext.Policy()
+	response := engineapi.NewEngineResponseFromPolicyContext(policyContext)
+	var rules []engineapi.RuleResponse
+	if policy.GetSpec().HasMutateExisting() {
+		rules = append(rules, simulateMutateExisting(ctx, eng, policyContext)...)
+	}
+	if policy.GetSpec().HasGenerate() {
+		rules = append(rules, simulateGenerate(ctx, logger, eng, client, policyContext)...)
+	}
+	response.PolicyResponse.Rules = rules
+	return response
+}
+
+func simulateMutateExisting(ctx context.Context, eng engineapi.Engine, policyContext *engine.PolicyContext) []engineapi.RuleResponse {
+	mutateExisting := sets.New[string]()
+	for _, rule := range autogen.ComputeRules(policyContext.Policy(), "") {
+		if rule.HasMutateExisting() {
+			mutateExisting.Insert(rule.Name)
+		}
+	}
+	// targets are only loaded and patched outside of the admission flow
+	mutateResponse := eng.Mutate(ctx, policyContext.WithAdmissionOperation(false))
+	var rules []engineapi.RuleResponse
+	for _, rule := range mutateResponse.PolicyResponse.Rules {
+		if mutateExisting.Has(rule.Name()) {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+func simulateGenerate(ctx context.Context, logger logr.Logger, eng engineapi.Engine, client dclient.Interface, policyContext *engine.PolicyContext) []engineapi.RuleResponse {
+	backgroundResponse := eng.ApplyBackgroundChecks(ctx, policyContext)
+	var rules []engineapi.RuleResponse
+	var applicable []string
+	for _, rule := range backgroundResponse.PolicyResponse.Rules {
+		if rule.RuleType() != engineapi.Generation {
+			continue
+		}
+		rules = append(rules, rule)
+		if rule.Status() == engineapi.RuleStatusPass {
+			applicable = append(applicable, rule.Name())
+		}
+	}
+	if len(applicable) == 0 || client == nil {
+		return rules
+	}
+	dryRunClient := newClient(client)
+	policyContext.JSONContext().Checkpoint()
+	defer policyContext.JSONContext().Restore()
+	_, err := generate.NewGenerateControllerWithOnlyClient(dryRunClient, eng).ApplyGeneratePolicy(logger, policyContext, applicable)
+	// clone sources are labelled along the way, only keep the generated resources
+	generated := map[string][]*unstructured.Unstructured{}
+	for _, obj := range dryRunClient.Objects() {
+		labels := obj.GetLabels()
+		if labels[common.GeneratePolicyLabel] == policyContext.Policy().GetName() {
+			generated[labels[common.GenerateRuleLabel]] = append(generated[labels[common.GenerateRuleLabel]], obj)
+		}
+	}
+	for i, rule := range rules {
+		if rule.Status() != engineapi.RuleStatusPass {
+			continue
+		}
+		if err != nil {
+			rules[i] = *engineapi.RuleError(rule.Name(), engineapi.Generation, "failed to simulate generate rule", err, rule.Properties())
+			continue
+		}
+		rules[i] = *rule.WithGeneratedResources(generated[rule.Name()])
+	}
+	return rules
+}
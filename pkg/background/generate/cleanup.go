@@ -10,6 +10,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/background/common"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"go.uber.org/multierr"
+	admissionv1 "k8s.io/api/admission/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -69,6 +70,18 @@ func (c *GenerateController) handleNonPolicyChanges(policy kyvernov1.PolicyInter
 			logger.V(4).Info("no downstream resources found by label selectors", "labels", labels)
 			return nil
 		}
+		if ur.Spec.Context.AdmissionRequestInfo.Operation == admissionv1.Delete && rule.HasGenerate() && rule.Generation.OrphanOnTriggerDelete() {
+			logger.Info("keeping the downstream resources on trigger deletion")
+			if err := common.LabelOrphans(c.client, downstreams, rule.Generation.GetOrphanLabels()); err != nil {
+				_, err = c.statusControl.Failed(ur.GetName(), fmt.Sprintf("failed to label orphaned downstream resources: %v", err), nil)
+			} else {
+				_, err = c.statusControl.Success(ur.GetName(), nil)
+			}
+			if err != nil {
+				logger.Error(err, "failed to update ur status")
+			}
+			continue
+		}
 		var errs []error
 		failedDownstreams := []kyvernov1.ResourceSpec{}
 		for _, downstream := range downstreams {
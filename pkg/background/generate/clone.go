@@ -7,10 +7,12 @@ import (
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 func manageClone(log logr.Logger, target, sourceSpec kyvernov1.ResourceSpec, severSideApply bool, pattern kyvernov1.GeneratePattern, client dclient.Interface) generateResponse {
@@ -56,6 +58,12 @@ func manageClone(log logr.Logger, target, sourceSpec kyvernov1.ResourceSpec, sev
 	sourceObjCopy.SetManagedFields(nil)
 	sourceObjCopy.SetResourceVersion("")
 
+	if pattern.CloneList.Transform != nil {
+		if err := transformClone(log, sourceObjCopy, pattern.CloneList.Transform); err != nil {
+			return newSkipGenerateResponse(nil, target, fmt.Errorf("failed to transform clone of %s/%s: %v", source.GetNamespace(), source.GetName(), err))
+		}
+	}
+
 	targetObj, err := client.GetResource(context.TODO(), target.GetAPIVersion(), target.GetKind(), target.GetNamespace(), target.GetName())
 	if err != nil && apierrors.IsNotFound(err) {
 		// the target resource should always exist regardless of synchronize settings
@@ -97,9 +105,9 @@ func manageCloneList(log logr.Logger, targetNamespace string, severSideApply boo
 		}
 
 		for _, source := range sources.Items {
-			target := newResourceSpec(source.GetAPIVersion(), source.GetKind(), targetNamespace, source.GetName())
+			target := newResourceSpec(source.GetAPIVersion(), source.GetKind(), targetNamespace, pattern.CloneList.Transform.GetName(source.GetName()))
 
-			if (pattern.CloneList.Kinds != nil) && (source.GetNamespace() == target.GetNamespace()) {
+			if (pattern.CloneList.Kinds != nil) && (source.GetNamespace() == target.GetNamespace()) && (source.GetName() == target.GetName()) {
 				log.V(4).Info("skip resource self-clone")
 				responses = append(responses, newSkipGenerateResponse(nil, target, nil))
 				continue
@@ -110,3 +118,40 @@ func manageCloneList(log logr.Logger, targetNamespace string, severSideApply boo
 	}
 	return responses
 }
+
+// transformClone applies the patches then the labels declared by a cloneList to a cloned resource
+func transformClone(log logr.Logger, clone *unstructured.Unstructured, transform *kyvernov1.CloneTransform) error {
+	var patchers []patch.Patcher
+	if transform.RawPatchStrategicMerge != nil {
+		patchers = append(patchers, patch.NewPatchStrategicMerge(transform.GetPatchStrategicMerge(), nil))
+	}
+	if transform.PatchesJSON6902 != "" {
+		patchers = append(patchers, patch.NewPatchesJSON6902(transform.PatchesJSON6902))
+	}
+	if len(patchers) != 0 {
+		raw, err := clone.MarshalJSON()
+		if err != nil {
+			return err
+		}
+		for _, patcher := range patchers {
+			raw, err = patcher.Patch(log, raw)
+			if err != nil {
+				return err
+			}
+		}
+		if err := clone.UnmarshalJSON(raw); err != nil {
+			return err
+		}
+	}
+	if len(transform.Labels) != 0 {
+		labels := clone.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string, len(transform.Labels))
+		}
+		for k, v := range transform.Labels {
+			labels[k] = v
+		}
+		clone.SetLabels(labels)
+	}
+	return nil
+}
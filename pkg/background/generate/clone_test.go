@@ -0,0 +1,63 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestTransformClone(t *testing.T) {
+	clone := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":      "settings",
+			"namespace": "default",
+			"labels": map[string]interface{}{
+				"app":  "payments",
+				"tier": "backend",
+			},
+		},
+		"data": map[string]interface{}{
+			"endpoint": "https://payments.default.svc",
+			"debug":    "true",
+		},
+	}}
+	transform := &kyvernov1.CloneTransform{
+		Labels: map[string]string{
+			"tier":   "shared",
+			"cloned": "true",
+		},
+		RawPatchStrategicMerge: &apiextv1.JSON{Raw: []byte(`{"data":{"endpoint":"https://payments.shared.svc"}}`)},
+		PatchesJSON6902:        `[{"op":"remove","path":"/data/debug"}]`,
+	}
+	assert.NilError(t, transformClone(logr.Discard(), clone, transform))
+	assert.DeepEqual(t, clone.GetLabels(), map[string]string{"app": "payments", "tier": "shared", "cloned": "true"})
+	data, _, _ := unstructured.NestedStringMap(clone.Object, "data")
+	assert.DeepEqual(t, data, map[string]string{"endpoint": "https://payments.shared.svc"})
+}
+
+func TestTransformCloneInvalidPatch(t *testing.T) {
+	clone := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name": "settings",
+		},
+	}}
+	transform := &kyvernov1.CloneTransform{
+		PatchesJSON6902: `[{"op":"replace","path":"/data/missing","value":"foo"}]`,
+	}
+	assert.Assert(t, transformClone(logr.Discard(), clone, transform) != nil)
+}
+
+func TestCloneTransformGetName(t *testing.T) {
+	var transform *kyvernov1.CloneTransform
+	assert.Equal(t, transform.GetName("settings"), "settings")
+	transform = &kyvernov1.CloneTransform{NamePrefix: "team-", NameSuffix: "-copy"}
+	assert.Equal(t, transform.GetName("settings"), "team-settings-copy")
+}
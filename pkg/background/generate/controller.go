@@ -25,6 +25,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	regex "github.com/kyverno/kyverno/pkg/engine/variables/regex"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/informers"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
@@ -35,7 +36,6 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 )
 
@@ -48,7 +48,7 @@ type GenerateController struct {
 
 	// listers
 	urLister      kyvernov2listers.UpdateRequestNamespaceLister
-	nsLister      corev1listers.NamespaceLister
+	nsLabels      informers.NamespaceLabels
 	policyLister  kyvernov1listers.ClusterPolicyLister
 	npolicyLister kyvernov1listers.PolicyLister
 
@@ -71,7 +71,7 @@ func NewGenerateController(
 	policyLister kyvernov1listers.ClusterPolicyLister,
 	npolicyLister kyvernov1listers.PolicyLister,
 	urLister kyvernov2listers.UpdateRequestNamespaceLister,
-	nsLister corev1listers.NamespaceLister,
+	nsLabels informers.NamespaceLabels,
 	dynamicConfig config.Configuration,
 	eventGen event.Interface,
 	log logr.Logger,
@@ -87,7 +87,7 @@ func NewGenerateController(
 		policyLister:   policyLister,
 		npolicyLister:  npolicyLister,
 		urLister:       urLister,
-		nsLister:       nsLister,
+		nsLabels:       nsLabels,
 		configuration:  dynamicConfig,
 		eventGen:       eventGen,
 		log:            log,
@@ -214,7 +214,7 @@ func (c *GenerateController) applyGenerate(trigger unstructured.Unstructured, ur
 		return nil, nil
 	}
 
-	namespaceLabels := engineutils.GetNamespaceSelectorsFromNamespaceLister(trigger.GetKind(), trigger.GetNamespace(), c.nsLister, logger)
+	namespaceLabels := engineutils.GetNamespaceLabels(trigger.GetKind(), trigger.GetNamespace(), c.nsLabels, logger)
 	policyContext, err := common.NewBackgroundContext(logger, c.client, ur.Spec.Context, p, &trigger, c.configuration, c.jp, namespaceLabels)
 	if err != nil {
 		return nil, err
@@ -245,19 +245,26 @@ func (c *GenerateController) applyGenerate(trigger unstructured.Unstructured, ur
 	}
 
 	// Apply the generate rule on resource
-	genResourcesMap, err := c.ApplyGeneratePolicy(logger, policyContext, applicableRules)
-	if err != nil {
-		return nil, err
-	}
+	outcomes, err := c.applyGeneratePolicy(logger, policyContext, applicableRules)
 
+	// the outcomes are reported even when a rule failed, the report records what was done to the trigger
 	for i, v := range engineResponse.PolicyResponse.Rules {
-		if resources, ok := genResourcesMap[v.Name()]; ok {
-			unstResources, err := c.GetUnstrResources(resources)
+		outcome, ok := outcomes[v.Name()]
+		if !ok {
+			continue
+		}
+		rule := &v
+		if outcome.err != nil || len(outcome.failed) != 0 {
+			rule = engineapi.RuleError(v.Name(), engineapi.Generation, "failed to generate resources", outcome.error(), v.Properties())
+		}
+		if len(outcome.created) != 0 {
+			unstResources, err := c.GetUnstrResources(outcome.created)
 			if err != nil {
 				c.log.Error(err, "failed to get unst resource names report")
 			}
-			engineResponse.PolicyResponse.Rules[i] = *v.WithGeneratedResources(unstResources)
+			rule = rule.WithGeneratedResources(unstResources)
 		}
+		engineResponse.PolicyResponse.Rules[i] = *rule.WithProperties(outcome.properties(rule.Properties()))
 	}
 
 	if c.needsReports(trigger) {
@@ -266,9 +273,13 @@ func (c *GenerateController) applyGenerate(trigger unstructured.Unstructured, ur
 		}
 	}
 
+	if err != nil {
+		return nil, err
+	}
+
 	genResources := make([]kyvernov1.ResourceSpec, 0)
-	for _, v := range genResourcesMap {
-		genResources = append(genResources, v...)
+	for _, v := range outcomes {
+		genResources = append(genResources, v.created...)
 	}
 
 	for _, res := range genResources {
@@ -303,8 +314,77 @@ func (c *GenerateController) getPolicyObject(ur kyvernov2.UpdateRequest) (kyvern
 	return npolicyObj, nil
 }
 
+// ruleOutcome records what a generate rule did to its targets
+type ruleOutcome struct {
+	created []kyvernov1.ResourceSpec
+	synced  []kyvernov1.ResourceSpec
+	failed  []kyvernov1.ResourceSpec
+	err     error
+}
+
+func (o ruleOutcome) error() error {
+	if o.err != nil {
+		return o.err
+	}
+	if len(o.failed) != 0 {
+		targets := make([]string, 0, len(o.failed))
+		for _, target := range o.failed {
+			targets = append(targets, target.String())
+		}
+		return fmt.Errorf("failed to create or update %s", strings.Join(targets, ", "))
+	}
+	return nil
+}
+
+// properties returns the report properties of the outcome added to the given properties
+func (o ruleOutcome) properties(properties map[string]string) map[string]string {
+	result := make(map[string]string, len(properties)+3)
+	for k, v := range properties {
+		result[k] = v
+	}
+	switch {
+	case o.err != nil || len(o.failed) != 0:
+		result["generation"] = reportutils.GenerationFailed
+	case len(o.created) != 0:
+		result["generation"] = reportutils.GenerationCreated
+	case len(o.synced) != 0:
+		result["generation"] = reportutils.GenerationSynced
+	default:
+		result["generation"] = reportutils.GenerationUnchanged
+	}
+	if len(o.synced) != 0 {
+		result["synced-resources"] = resourceSpecsInfo(o.synced)
+	}
+	if len(o.failed) != 0 {
+		result["failed-resources"] = resourceSpecsInfo(o.failed)
+	}
+	return result
+}
+
+func resourceSpecsInfo(specs []kyvernov1.ResourceSpec) string {
+	info := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		info = append(info, reportutils.ResourceSpecInfo(spec))
+	}
+	return strings.Join(info, "; ")
+}
+
 func (c *GenerateController) ApplyGeneratePolicy(log logr.Logger, policyContext *engine.PolicyContext, applicableRules []string) (map[string][]kyvernov1.ResourceSpec, error) {
-	genResources := make(map[string][]kyvernov1.ResourceSpec)
+	outcomes, err := c.applyGeneratePolicy(log, policyContext, applicableRules)
+	if err != nil {
+		return nil, err
+	}
+	genResources := make(map[string][]kyvernov1.ResourceSpec, len(outcomes))
+	for rule, outcome := range outcomes {
+		genResources[rule] = outcome.created
+	}
+	return genResources, nil
+}
+
+// applyGeneratePolicy applies the generate rules and returns their outcomes, on error the outcomes of
+// the rules processed so far are returned with the failed rule
+func (c *GenerateController) applyGeneratePolicy(log logr.Logger, policyContext *engine.PolicyContext, applicableRules []string) (map[string]ruleOutcome, error) {
+	outcomes := make(map[string]ruleOutcome)
 	policy := policyContext.Policy()
 	resource := policyContext.NewResource()
 	// To manage existing resources, we compare the creation time for the default resource to be generated and policy creation time
@@ -313,6 +393,18 @@ func (c *GenerateController) ApplyGeneratePolicy(log logr.Logger, policyContext
 	applyCount := 0
 	log = log.WithValues("policy", policy.GetName(), "trigger", resource.GetNamespace()+"/"+resource.GetName())
 
+	if variables := policy.GetSpec().Variables; len(variables) > 0 {
+		contextLoader := c.engine.ContextLoader(policy, kyvernov1.Rule{})
+		if err := contextLoader(context.TODO(), variables, policyContext.JSONContext()); err != nil {
+			if _, ok := err.(gojmespath.NotFoundError); ok {
+				log.V(3).Info("failed to load policy variables", "reason", err.Error())
+			} else {
+				log.Error(err, "failed to load policy variables")
+			}
+			return outcomes, fmt.Errorf("failed to load policy variables: %v", err)
+		}
+	}
+
 	for _, rule := range policy.GetSpec().Rules {
 		var err error
 		if !rule.HasGenerate() {
@@ -325,7 +417,7 @@ func (c *GenerateController) ApplyGeneratePolicy(log logr.Logger, policyContext
 		if rule.Generation.Synchronize {
 			ruleRaw, err := json.Marshal(rule.DeepCopy())
 			if err != nil {
-				return nil, fmt.Errorf("failed to serialize the policy: %v", err)
+				return outcomes, fmt.Errorf("failed to serialize the policy: %v", err)
 			}
 			vars := regex.RegexVariables.FindAllStringSubmatch(string(ruleRaw), -1)
 
@@ -351,27 +443,30 @@ func (c *GenerateController) ApplyGeneratePolicy(log logr.Logger, policyContext
 			} else {
 				logger.Error(err, "failed to load rule level context")
 			}
-			return nil, fmt.Errorf("failed to load rule level context: %v", err)
+			err = fmt.Errorf("failed to load rule level context: %v", err)
+			outcomes[rule.Name] = ruleOutcome{err: err}
+			return outcomes, err
 		}
 
+		var g *generator
 		if rule.Generation.ForEachGeneration != nil {
-			g := newForeachGenerator(c.client, logger, policyContext, policy, rule, rule.Context, rule.GetAnyAllConditions(), policyContext.NewResource(), rule.Generation.ForEachGeneration, contextLoader)
+			g = newForeachGenerator(c.client, logger, policyContext, policy, rule, rule.Context, rule.GetAnyAllConditions(), policyContext.NewResource(), rule.Generation.ForEachGeneration, contextLoader)
 			genResource, err = g.generateForeach()
 		} else {
-			g := newGenerator(c.client, logger, policyContext, policy, rule, rule.Context, rule.GetAnyAllConditions(), policyContext.NewResource(), rule.Generation.GeneratePattern, contextLoader)
+			g = newGenerator(c.client, logger, policyContext, policy, rule, rule.Context, rule.GetAnyAllConditions(), policyContext.NewResource(), rule.Generation.GeneratePattern, contextLoader)
 			genResource, err = g.generate()
 		}
+		outcomes[rule.Name] = ruleOutcome{created: genResource, synced: g.synced, failed: g.failed, err: err}
 
 		if err != nil {
 			log.Error(err, "failed to apply generate rule")
-			return nil, err
+			return outcomes, err
 		}
 		ruleNameToProcessingTime[rule.Name] = time.Since(startTime)
-		genResources[rule.Name] = genResource
 		applyCount++
 	}
 
-	return genResources, nil
+	return outcomes, nil
 }
 
 // NewGenerateControllerWithOnlyClient returns an instance of Controller with only the client.
@@ -0,0 +1,65 @@
+package generate
+
+import (
+	"errors"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+)
+
+func TestRuleOutcomeProperties(t *testing.T) {
+	created := kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "ConfigMap", Namespace: "ns-1", Name: "settings"}
+	synced := kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "Secret", Namespace: "ns-1", Name: "settings"}
+	tests := []struct {
+		name    string
+		outcome ruleOutcome
+		want    map[string]string
+	}{{
+		name:    "unchanged",
+		outcome: ruleOutcome{},
+		want:    map[string]string{"team": "a", "generation": "unchanged"},
+	}, {
+		name:    "created and synced",
+		outcome: ruleOutcome{created: []kyvernov1.ResourceSpec{created}, synced: []kyvernov1.ResourceSpec{synced}},
+		want: map[string]string{
+			"team":             "a",
+			"generation":       "created",
+			"synced-resources": "/v1, Kind=Secret Name=settings Namespace=ns-1",
+		},
+	}, {
+		name:    "synced",
+		outcome: ruleOutcome{synced: []kyvernov1.ResourceSpec{synced}},
+		want: map[string]string{
+			"team":             "a",
+			"generation":       "synced",
+			"synced-resources": "/v1, Kind=Secret Name=settings Namespace=ns-1",
+		},
+	}, {
+		name:    "failed",
+		outcome: ruleOutcome{failed: []kyvernov1.ResourceSpec{created}},
+		want: map[string]string{
+			"team":             "a",
+			"generation":       "failed",
+			"failed-resources": "/v1, Kind=ConfigMap Name=settings Namespace=ns-1",
+		},
+	}, {
+		name:    "error",
+		outcome: ruleOutcome{err: errors.New("boom")},
+		want:    map[string]string{"team": "a", "generation": "failed"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			properties := map[string]string{"team": "a"}
+			assert.DeepEqual(t, tt.outcome.properties(properties), tt.want)
+			assert.DeepEqual(t, properties, map[string]string{"team": "a"})
+		})
+	}
+}
+
+func TestRuleOutcomeError(t *testing.T) {
+	assert.NilError(t, ruleOutcome{}.error())
+	assert.Error(t, ruleOutcome{err: errors.New("boom")}.error(), "boom")
+	failed := ruleOutcome{failed: []kyvernov1.ResourceSpec{{APIVersion: "v1", Kind: "ConfigMap", Namespace: "ns-1", Name: "settings"}}}
+	assert.ErrorContains(t, failed.error(), "failed to create or update")
+}
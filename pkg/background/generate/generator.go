@@ -13,9 +13,13 @@ import (
 	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/engine/validate"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
+	regex "github.com/kyverno/kyverno/pkg/engine/variables/regex"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"go.uber.org/multierr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 type generator struct {
@@ -30,6 +34,12 @@ type generator struct {
 	forEach          []kyvernov1.ForEachGeneration
 	pattern          kyvernov1.GeneratePattern
 	contextLoader    engineapi.EngineContextLoader
+	// targets holds every resource the generator resolved, whether or not it had to be created or updated
+	targets []kyvernov1.ResourceSpec
+	// synced holds the existing targets that were updated to match the rule
+	synced []kyvernov1.ResourceSpec
+	// failed holds the targets that couldn't be created or updated
+	failed []kyvernov1.ResourceSpec
 }
 
 func newGenerator(client dclient.Interface,
@@ -115,22 +125,36 @@ func (g *generator) generate() ([]kyvernov1.ResourceSpec, error) {
 	target := pattern.ResourceSpec
 	logger := g.logger.WithValues("target", target.String())
 
-	if pattern.Clone.Name != "" {
-		resp := manageClone(logger.WithValues("type", "clone"), target, kyvernov1.ResourceSpec{}, g.policy.GetSpec().UseServerSideApply, *pattern, g.client)
-		responses = append(responses, resp)
-	} else if len(pattern.CloneList.Kinds) != 0 {
-		responses = manageCloneList(logger.WithValues("type", "cloneList"), target.GetNamespace(), g.policy.GetSpec().UseServerSideApply, *pattern, g.client)
-	} else {
-		resp := manageData(logger.WithValues("type", "data"), target, pattern.RawData, g.rule.Generation.Synchronize, g.client)
-		responses = append(responses, resp)
+	namespaces := []string{target.GetNamespace()}
+	if pattern.NamespaceSelector != nil {
+		namespaces, err = g.selectNamespaces(pattern.NamespaceSelector)
+		if err != nil {
+			return newGenResources, fmt.Errorf("failed to select target namespaces: %v", err)
+		}
+		logger.V(4).Info("selected target namespaces", "namespaces", namespaces)
+	}
+
+	for _, namespace := range namespaces {
+		target.Namespace = namespace
+		if pattern.Clone.Name != "" {
+			resp := manageClone(logger.WithValues("type", "clone"), target, kyvernov1.ResourceSpec{}, g.policy.GetSpec().UseServerSideApply, *pattern, g.client)
+			responses = append(responses, resp)
+		} else if len(pattern.CloneList.Kinds) != 0 {
+			responses = append(responses, manageCloneList(logger.WithValues("type", "cloneList"), target.GetNamespace(), g.policy.GetSpec().UseServerSideApply, *pattern, g.client)...)
+		} else {
+			resp := manageData(logger.WithValues("type", "data"), target, pattern.RawData, g.rule.Generation.Synchronize, g.client)
+			responses = append(responses, resp)
+		}
 	}
 
 	for _, response := range responses {
 		targetMeta := response.GetTarget()
 		if response.GetError() != nil {
 			logger.Error(response.GetError(), "failed to generate resource", "mode", response.GetAction())
+			g.failed = append(g.failed, targetMeta)
 			return newGenResources, err
 		}
+		g.targets = append(g.targets, targetMeta)
 
 		if response.GetAction() == Skip {
 			continue
@@ -139,7 +163,7 @@ func (g *generator) generate() ([]kyvernov1.ResourceSpec, error) {
 		logger.V(3).Info("applying generate rule", "mode", response.GetAction())
 		if response.GetData() == nil && response.GetAction() == Update {
 			logger.V(4).Info("no changes required for generate target resource")
-			return newGenResources, nil
+			continue
 		}
 
 		newResource := &unstructured.Unstructured{}
@@ -161,6 +185,7 @@ func (g *generator) generate() ([]kyvernov1.ResourceSpec, error) {
 			}
 			if err != nil {
 				if !apierrors.IsAlreadyExists(err) {
+					g.failed = append(g.failed, targetMeta)
 					return newGenResources, err
 				}
 			}
@@ -176,6 +201,7 @@ func (g *generator) generate() ([]kyvernov1.ResourceSpec, error) {
 					_, err = g.client.CreateResource(context.TODO(), targetMeta.GetAPIVersion(), targetMeta.GetKind(), targetMeta.GetNamespace(), newResource, false)
 				}
 				if err != nil {
+					g.failed = append(g.failed, targetMeta)
 					return newGenResources, err
 				}
 				newGenResources = append(newGenResources, targetMeta)
@@ -207,8 +233,10 @@ func (g *generator) generate() ([]kyvernov1.ResourceSpec, error) {
 				}
 				if err != nil {
 					logger.Error(err, "failed to update resource")
+					g.failed = append(g.failed, targetMeta)
 					return newGenResources, err
 				}
+				g.synced = append(g.synced, targetMeta)
 			}
 			logger.V(3).Info("updated generate target resource")
 		}
@@ -221,7 +249,7 @@ func (g *generator) generateForeach() ([]kyvernov1.ResourceSpec, error) {
 	var genResources []kyvernov1.ResourceSpec
 
 	for i, foreach := range g.forEach {
-		elements, err := engineutils.EvaluateList(foreach.List, g.policyContext.JSONContext())
+		elements, err := engineutils.EvaluateForEachList(foreach.List, foreach.GetMapEntries(), g.policyContext.JSONContext())
 		if err != nil {
 			errors = append(errors, fmt.Errorf("failed to evaluate %v foreach list: %v", i, err))
 			continue
@@ -234,9 +262,86 @@ func (g *generator) generateForeach() ([]kyvernov1.ResourceSpec, error) {
 			genResources = append(genResources, gen...)
 		}
 	}
+	if len(errors) == 0 && g.rule.Generation.Synchronize {
+		if err := g.pruneElements(); err != nil {
+			errors = append(errors, fmt.Errorf("failed to delete resources of removed elements: %v", err))
+		}
+	}
 	return genResources, multierr.Combine(errors...)
 }
 
+// pruneElements deletes the resources previously generated by the foreach
+// declarations of the rule for the trigger that no longer map to an element
+func (g *generator) pruneElements() error {
+	selector := map[string]string{
+		common.GeneratePolicyLabel:          g.policy.GetName(),
+		common.GeneratePolicyNamespaceLabel: g.policy.GetNamespace(),
+		common.GenerateRuleLabel:            g.rule.Name,
+		common.GenerateTriggerUIDLabel:      string(g.trigger.GetUID()),
+	}
+	var errors []error
+	for _, kind := range foreachKinds(g.forEach, g.targets) {
+		downstreams, err := common.FindDownstream(g.client, kind.APIVersion, kind.Kind, selector)
+		if err != nil {
+			errors = append(errors, err)
+			continue
+		}
+		for _, stale := range staleDownstreams(downstreams.Items, g.targets) {
+			g.logger.V(2).Info("deleting resource of removed foreach element", "kind", stale.GetKind(), "namespace", stale.GetNamespace(), "name", stale.GetName())
+			if err := g.client.DeleteResource(context.TODO(), stale.GetAPIVersion(), stale.GetKind(), stale.GetNamespace(), stale.GetName(), false); err != nil && !apierrors.IsNotFound(err) {
+				errors = append(errors, err)
+			}
+		}
+	}
+	return multierr.Combine(errors...)
+}
+
+// foreachKinds returns the distinct kinds the foreach declarations may generate,
+// kinds set through variables are only known from the resolved targets
+func foreachKinds(forEach []kyvernov1.ForEachGeneration, targets []kyvernov1.ResourceSpec) []kyvernov1.ResourceSpec {
+	var kinds []kyvernov1.ResourceSpec
+	seen := sets.New[string]()
+	add := func(apiVersion, kind string) {
+		if kind == "" || regex.RegexVariables.MatchString(apiVersion+kind) {
+			return
+		}
+		if seen.Has(apiVersion + "/" + kind) {
+			return
+		}
+		seen.Insert(apiVersion + "/" + kind)
+		kinds = append(kinds, kyvernov1.ResourceSpec{APIVersion: apiVersion, Kind: kind})
+	}
+	for _, foreach := range forEach {
+		for _, kind := range foreach.CloneList.Kinds {
+			apiVersion, k := kubeutils.GetKindFromGVK(kind)
+			add(apiVersion, k)
+		}
+		add(foreach.GetAPIVersion(), foreach.GetKind())
+	}
+	for _, target := range targets {
+		add(target.GetAPIVersion(), target.GetKind())
+	}
+	return kinds
+}
+
+// staleDownstreams returns the downstream resources that are not part of the targets
+func staleDownstreams(downstreams []unstructured.Unstructured, targets []kyvernov1.ResourceSpec) []unstructured.Unstructured {
+	var stale []unstructured.Unstructured
+	for _, downstream := range downstreams {
+		found := false
+		for _, target := range targets {
+			if target.GetKind() == downstream.GetKind() && target.GetNamespace() == downstream.GetNamespace() && target.GetName() == downstream.GetName() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			stale = append(stale, downstream)
+		}
+	}
+	return stale
+}
+
 func (g *generator) generateElements(foreach kyvernov1.ForEachGeneration, elements []interface{}, elementScope *bool) ([]kyvernov1.ResourceSpec, error) {
 	var errors []error
 	var genResources []kyvernov1.ResourceSpec
@@ -256,7 +361,7 @@ func (g *generator) generateElements(foreach kyvernov1.ForEachGeneration, elemen
 			continue
 		}
 
-		gen, err := newGenerator(g.client,
+		elementGenerator := newGenerator(g.client,
 			g.logger,
 			policyContext,
 			g.policy,
@@ -265,8 +370,11 @@ func (g *generator) generateElements(foreach kyvernov1.ForEachGeneration, elemen
 			foreach.AnyAllConditions,
 			g.trigger,
 			foreach.GeneratePattern,
-			g.contextLoader).
-			generate()
+			g.contextLoader)
+		gen, err := elementGenerator.generate()
+		g.targets = append(g.targets, elementGenerator.targets...)
+		g.synced = append(g.synced, elementGenerator.synced...)
+		g.failed = append(g.failed, elementGenerator.failed...)
 		if err != nil {
 			errors = append(errors, fmt.Errorf("failed to process %v element: %v", index, err))
 		}
@@ -277,6 +385,22 @@ func (g *generator) generateElements(foreach kyvernov1.ForEachGeneration, elemen
 	return genResources, multierr.Combine(errors...)
 }
 
+// selectNamespaces returns the names of the active namespaces matching the selector
+func (g *generator) selectNamespaces(selector *metav1.LabelSelector) ([]string, error) {
+	list, err := g.client.ListResource(context.TODO(), "v1", "Namespace", "", selector)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := make([]string, 0, len(list.Items))
+	for _, ns := range list.Items {
+		if ns.GetDeletionTimestamp() != nil {
+			continue
+		}
+		namespaces = append(namespaces, ns.GetName())
+	}
+	return namespaces, nil
+}
+
 func (g *generator) loadContext(ctx context.Context) error {
 	if err := g.contextLoader(ctx, g.contextEntries, g.policyContext.JSONContext()); err != nil {
 		if _, ok := err.(gojmespath.NotFoundError); ok {
@@ -0,0 +1,67 @@
+package generate
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newDownstream(kind, namespace, name string) unstructured.Unstructured {
+	obj := unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+func TestStaleDownstreams(t *testing.T) {
+	downstreams := []unstructured.Unstructured{
+		newDownstream("ConfigMap", "ns-1", "settings"),
+		newDownstream("ConfigMap", "ns-2", "settings"),
+		newDownstream("Secret", "ns-1", "settings"),
+	}
+	targets := []kyvernov1.ResourceSpec{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "ns-1", Name: "settings"},
+		{APIVersion: "v1", Kind: "Secret", Namespace: "ns-1", Name: "settings"},
+	}
+	stale := staleDownstreams(downstreams, targets)
+	assert.Equal(t, len(stale), 1)
+	assert.Equal(t, stale[0].GetNamespace(), "ns-2")
+
+	assert.Equal(t, len(staleDownstreams(downstreams, nil)), 3)
+	assert.Equal(t, len(staleDownstreams(nil, targets)), 0)
+}
+
+func TestForeachKinds(t *testing.T) {
+	forEach := []kyvernov1.ForEachGeneration{
+		{
+			GeneratePattern: kyvernov1.GeneratePattern{
+				ResourceSpec: kyvernov1.ResourceSpec{APIVersion: "networking.k8s.io/v1", Kind: "NetworkPolicy", Name: "deny-{{element}}"},
+			},
+		},
+		{
+			GeneratePattern: kyvernov1.GeneratePattern{
+				ResourceSpec: kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "{{element.kind}}", Name: "{{element.name}}"},
+			},
+		},
+		{
+			GeneratePattern: kyvernov1.GeneratePattern{
+				CloneList: kyvernov1.CloneList{Kinds: []string{"v1/Secret", "v1/ConfigMap"}},
+			},
+		},
+	}
+	targets := []kyvernov1.ResourceSpec{
+		{APIVersion: "networking.k8s.io/v1", Kind: "NetworkPolicy", Namespace: "ns-1", Name: "deny-ns-1"},
+		{APIVersion: "v1", Kind: "ServiceAccount", Namespace: "ns-1", Name: "builder"},
+	}
+	kinds := foreachKinds(forEach, targets)
+	assert.DeepEqual(t, kinds, []kyvernov1.ResourceSpec{
+		{APIVersion: "networking.k8s.io/v1", Kind: "NetworkPolicy"},
+		{APIVersion: "v1", Kind: "Secret"},
+		{APIVersion: "v1", Kind: "ConfigMap"},
+		{APIVersion: "v1", Kind: "ServiceAccount"},
+	})
+}
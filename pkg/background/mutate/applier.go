@@ -0,0 +1,215 @@
+package mutate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// applyTimeout is the timeout of a coalesced apply, it doesn't depend on the context of the waiting mutations
+const applyTimeout = 30 * time.Second
+
+// errConflictingMutation is returned for a mutation changing a field already changed by a pending mutation of the
+// same target, the mutation was computed from a stale target and must be computed again against the applied one
+var errConflictingMutation = errors.New("mutation conflicts with a pending mutation of the target")
+
+// Options configures how the mutations of existing resources are written
+type Options struct {
+	// ServerSideApply applies the mutations of the mutate existing rules with server-side apply,
+	// the serverSideApply field of a rule overrides it
+	ServerSideApply bool
+	// ApplyWindow is the time the server-side apply mutations of a target are collected before being
+	// applied at once, every mutation is applied on its own when it is zero
+	ApplyWindow time.Duration
+}
+
+// Applier applies the mutations of existing resources with server-side apply, the mutations of the same
+// target submitted within the apply window are coalesced into a single apply
+type Applier struct {
+	client  dclient.Interface
+	options Options
+	lock    sync.Mutex
+	pending map[string]*pendingApply
+}
+
+type pendingApply struct {
+	apiVersion  string
+	kind        string
+	namespace   string
+	name        string
+	subresource string
+	// patches are the merge patches of the coalesced mutations, in submission order
+	patches [][]byte
+	done    chan struct{}
+	err     error
+}
+
+// NewApplier returns an applier coalescing the mutations submitted within the apply window
+func NewApplier(client dclient.Interface, options Options) *Applier {
+	return &Applier{
+		client:  client,
+		options: options,
+		pending: map[string]*pendingApply{},
+	}
+}
+
+// UseServerSideApply returns true when the mutations of a rule are applied with server-side apply, the setting
+// of the rule takes precedence over the global option in both directions
+func (a *Applier) UseServerSideApply(mutation *kyvernov1.Mutation) bool {
+	if mutation != nil && mutation.ServerSideApply != nil {
+		return *mutation.ServerSideApply
+	}
+	return a.options.ServerSideApply
+}
+
+// Apply applies the mutation of a target and blocks until it's done. The mutation is recorded as a merge
+// patch against the current target so that the mutations computed from the same target version can be
+// combined, the combined mutation is applied once with the stable kyverno field manager. Only mutations
+// changing distinct fields are combined, a mutation conflicting with a pending one waits for it to be applied
+// and fails so that it is computed again against the updated target.
+func (a *Applier) Apply(ctx context.Context, apiVersion, kind string, patched *unstructured.Unstructured, subresource string) error {
+	if a.options.ApplyWindow <= 0 {
+		return applyTarget(ctx, a.client, apiVersion, kind, patched, subresource)
+	}
+	current, err := getTarget(ctx, a.client, apiVersion, kind, patched.GetNamespace(), patched.GetName(), subresource)
+	if err != nil {
+		return err
+	}
+	patch, err := mergePatch(current, patched)
+	if err != nil {
+		return err
+	}
+	key := strings.Join([]string{apiVersion, kind, patched.GetNamespace(), patched.GetName(), subresource}, "/")
+	return a.submit(ctx, key, &pendingApply{
+		apiVersion:  apiVersion,
+		kind:        kind,
+		namespace:   patched.GetNamespace(),
+		name:        patched.GetName(),
+		subresource: subresource,
+		done:        make(chan struct{}),
+	}, patch)
+}
+
+// submit adds the merge patch to the pending apply of the target, the given apply is scheduled
+// when none is pending
+func (a *Applier) submit(ctx context.Context, key string, apply *pendingApply, patch []byte) error {
+	a.lock.Lock()
+	pending, ok := a.pending[key]
+	if ok && pending.conflicts(patch) {
+		a.lock.Unlock()
+		select {
+		case <-pending.done:
+			return fmt.Errorf("%w: %s", errConflictingMutation, key)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if !ok {
+		pending = apply
+		a.pending[key] = pending
+		time.AfterFunc(a.options.ApplyWindow, func() { a.flush(key, pending) })
+	}
+	pending.patches = append(pending.patches, patch)
+	a.lock.Unlock()
+	select {
+	case <-pending.done:
+		return pending.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (a *Applier) flush(key string, pending *pendingApply) {
+	a.lock.Lock()
+	delete(a.pending, key)
+	a.lock.Unlock()
+	defer close(pending.done)
+	ctx, cancel := context.WithTimeout(context.Background(), applyTimeout)
+	defer cancel()
+	current, err := getTarget(ctx, a.client, pending.apiVersion, pending.kind, pending.namespace, pending.name, pending.subresource)
+	if err != nil {
+		pending.err = err
+		return
+	}
+	patched, err := mergeMutations(current, pending.patches)
+	if err != nil {
+		pending.err = err
+		return
+	}
+	pending.err = applyTargetFrom(ctx, a.client, pending.apiVersion, pending.kind, current, patched, pending.subresource)
+}
+
+// conflicts returns true when the merge patch changes a field changed by one of the pending patches
+func (p *pendingApply) conflicts(patch []byte) bool {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(patch, &fields); err != nil {
+		return true
+	}
+	for _, pending := range p.patches {
+		var pendingFields map[string]interface{}
+		if err := json.Unmarshal(pending, &pendingFields); err != nil || overlaps(fields, pendingFields) {
+			return true
+		}
+	}
+	return false
+}
+
+// overlaps returns true when two merge patches change the same field, lists are replaced as a whole
+// by merge patches so two patches changing the same list always overlap
+func overlaps(a, b map[string]interface{}) bool {
+	for key, valueA := range a {
+		valueB, ok := b[key]
+		if !ok {
+			continue
+		}
+		mapA, okA := valueA.(map[string]interface{})
+		mapB, okB := valueB.(map[string]interface{})
+		if !okA || !okB || overlaps(mapA, mapB) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergePatch returns the merge patch of a mutation, the resource version is not part of it
+func mergePatch(current, patched *unstructured.Unstructured) ([]byte, error) {
+	currentBytes, err := json.Marshal(current.Object)
+	if err != nil {
+		return nil, err
+	}
+	patched = patched.DeepCopy()
+	patched.SetResourceVersion(current.GetResourceVersion())
+	patchedBytes, err := json.Marshal(patched.Object)
+	if err != nil {
+		return nil, err
+	}
+	return jsonpatch.CreateMergePatch(currentBytes, patchedBytes)
+}
+
+// mergeMutations applies the merge patches of the coalesced mutations to the current target, the patches
+// don't overlap so the order they are applied in doesn't matter
+func mergeMutations(current *unstructured.Unstructured, patches [][]byte) (*unstructured.Unstructured, error) {
+	doc, err := json.Marshal(current.Object)
+	if err != nil {
+		return nil, err
+	}
+	for _, patch := range patches {
+		if doc, err = jsonpatch.MergePatch(doc, patch); err != nil {
+			return nil, err
+		}
+	}
+	var patched unstructured.Unstructured
+	if err := patched.UnmarshalJSON(doc); err != nil {
+		return nil, err
+	}
+	return &patched, nil
+}
@@ -0,0 +1,124 @@
+package mutate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+)
+
+func Test_mergeMutations(t *testing.T) {
+	current := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":            "test",
+			"namespace":       "default",
+			"resourceVersion": "42",
+		},
+		"data": map[string]interface{}{
+			"foo": "bar",
+			"old": "value",
+		},
+	}}
+	first := current.DeepCopy()
+	first.Object["data"] = map[string]interface{}{
+		"foo": "baz",
+	}
+	first.SetResourceVersion("41")
+	second := current.DeepCopy()
+	unstructured.SetNestedField(second.Object, "value", "data", "new")
+	var patches [][]byte
+	for _, patched := range []*unstructured.Unstructured{first, second} {
+		patch, err := mergePatch(current, patched)
+		assert.NilError(t, err)
+		patches = append(patches, patch)
+	}
+	assert.Equal(t, (&pendingApply{patches: patches[:1]}).conflicts(patches[1]), false)
+	merged, err := mergeMutations(current, patches)
+	assert.NilError(t, err)
+	assert.Equal(t, merged.GetResourceVersion(), "42")
+	assert.DeepEqual(t, merged.Object["data"], map[string]interface{}{
+		"foo": "baz",
+		"new": "value",
+	})
+}
+
+func Test_conflictingListMutations(t *testing.T) {
+	current := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]interface{}{
+			"name":      "test",
+			"namespace": "default",
+		},
+		"spec": map[string]interface{}{
+			"tolerations": []interface{}{
+				map[string]interface{}{"key": "a"},
+			},
+		},
+	}}
+	// two rules appending to the same list from the same target version
+	var patches [][]byte
+	for _, key := range []string{"b", "c"} {
+		patched := current.DeepCopy()
+		tolerations, _, _ := unstructured.NestedSlice(patched.Object, "spec", "tolerations")
+		tolerations = append(tolerations, map[string]interface{}{"key": key})
+		assert.NilError(t, unstructured.SetNestedSlice(patched.Object, tolerations, "spec", "tolerations"))
+		patch, err := mergePatch(current, patched)
+		assert.NilError(t, err)
+		patches = append(patches, patch)
+	}
+	// merging them would drop the first append, they must not be coalesced
+	pending := &pendingApply{patches: patches[:1], done: make(chan struct{})}
+	assert.Equal(t, pending.conflicts(patches[1]), true)
+	applier := NewApplier(nil, Options{ApplyWindow: time.Minute})
+	applier.pending["v1/Pod/default/test/"] = pending
+	errs := make(chan error)
+	go func() {
+		errs <- applier.submit(context.TODO(), "v1/Pod/default/test/", &pendingApply{done: make(chan struct{})}, patches[1])
+	}()
+	close(pending.done)
+	err := <-errs
+	assert.Assert(t, errors.Is(err, errConflictingMutation))
+	assert.Equal(t, len(pending.patches), 1)
+}
+
+func Test_UseServerSideApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		global   bool
+		mutation *kyvernov1.Mutation
+		want     bool
+	}{{
+		name: "global disabled",
+	}, {
+		name:   "global enabled",
+		global: true,
+		want:   true,
+	}, {
+		name:     "rule not set",
+		global:   true,
+		mutation: &kyvernov1.Mutation{},
+		want:     true,
+	}, {
+		name:     "rule enabled",
+		mutation: &kyvernov1.Mutation{ServerSideApply: ptr.To(true)},
+		want:     true,
+	}, {
+		name:     "rule disabled",
+		global:   true,
+		mutation: &kyvernov1.Mutation{ServerSideApply: ptr.To(false)},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applier := NewApplier(nil, Options{ServerSideApply: tt.global})
+			assert.Equal(t, applier.UseServerSideApply(tt.mutation), tt.want)
+		})
+	}
+}
@@ -0,0 +1,318 @@
+package mutate
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// fieldManager is the field manager used to apply mutations of existing resources,
+// the client prefixes it with "kyverno-".
+const fieldManager = "mutate-existing"
+
+// applyTarget applies the mutation of a target with server-side apply. The apply configuration only contains
+// the fields changed by the mutation and the fields previously applied by kyverno, so fields owned by other
+// managers are left untouched and fields applied in earlier reconciliations are not released. Fields removed
+// by the mutation are omitted from the apply configuration, the server removes them when kyverno owns them.
+func applyTarget(ctx context.Context, client dclient.Interface, apiVersion, kind string, patched *unstructured.Unstructured, subresource string) error {
+	current, err := getTarget(ctx, client, apiVersion, kind, patched.GetNamespace(), patched.GetName(), subresource)
+	if err != nil {
+		return err
+	}
+	return applyTargetFrom(ctx, client, apiVersion, kind, current, patched, subresource)
+}
+
+func getTarget(ctx context.Context, client dclient.Interface, apiVersion, kind, namespace, name, subresource string) (*unstructured.Unstructured, error) {
+	var subresources []string
+	if subresource != "" {
+		subresources = append(subresources, subresource)
+	}
+	return client.GetResource(ctx, apiVersion, kind, namespace, name, subresources...)
+}
+
+// applyTargetFrom applies the mutation of a target already fetched from the cluster
+func applyTargetFrom(ctx context.Context, client dclient.Interface, apiVersion, kind string, current, patched *unstructured.Unstructured, subresource string) error {
+	config, err := applyConfiguration(current, patched, subresource)
+	if err != nil {
+		return err
+	}
+	if subresource == "status" {
+		_, err = client.ApplyStatusResource(ctx, apiVersion, kind, patched.GetNamespace(), patched.GetName(), config.Object, false, fieldManager)
+	} else {
+		var subresources []string
+		if subresource != "" {
+			subresources = append(subresources, subresource)
+		}
+		_, err = client.ApplyResource(ctx, apiVersion, kind, patched.GetNamespace(), patched.GetName(), config.Object, false, fieldManager, subresources...)
+	}
+	return err
+}
+
+// applyConfiguration returns the apply configuration of the mutation, made of the fields it changed and
+// the fields kyverno applied before that are still set
+func applyConfiguration(current, patched *unstructured.Unstructured, subresource string) (*unstructured.Unstructured, error) {
+	// the managed fields of all the managers tell which lists are associative
+	var managed map[string]interface{}
+	var owned []map[string]interface{}
+	for _, entry := range current.GetManagedFields() {
+		if entry.FieldsV1 == nil {
+			continue
+		}
+		var fields map[string]interface{}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			return nil, err
+		}
+		managed = mergeFields(managed, fields)
+		if entry.Manager == "kyverno-"+fieldManager && entry.Operation == metav1.ManagedFieldsOperationApply && entry.Subresource == subresource {
+			owned = append(owned, fields)
+		}
+	}
+	config := changedFields(current.Object, patched.Object, managed)
+	for _, fields := range owned {
+		copyOwnedFields(fields, patched.Object, config)
+	}
+	// metadata generated by the server must not be part of the apply configuration
+	if metadata, ok := config["metadata"].(map[string]interface{}); ok {
+		for _, field := range []string{"managedFields", "resourceVersion", "generation", "uid", "creationTimestamp"} {
+			delete(metadata, field)
+		}
+	}
+	result := &unstructured.Unstructured{Object: config}
+	result.SetAPIVersion(patched.GetAPIVersion())
+	result.SetKind(patched.GetKind())
+	result.SetName(patched.GetName())
+	result.SetNamespace(patched.GetNamespace())
+	return result, nil
+}
+
+// mergeFields returns the union of two managed fields sets
+func mergeFields(dst, src map[string]interface{}) map[string]interface{} {
+	if dst == nil {
+		dst = map[string]interface{}{}
+	}
+	for key, value := range src {
+		srcMap, _ := value.(map[string]interface{})
+		dstMap, _ := dst[key].(map[string]interface{})
+		dst[key] = mergeFields(dstMap, srcMap)
+	}
+	return dst
+}
+
+// changedFields returns the fields of patched that differ from current. Maps are compared field by field and
+// associative lists item by item, only the changed items are returned with their keys. Other lists are atomic
+// and returned as a whole. Fields missing from patched are not returned.
+func changedFields(current, patched, fields map[string]interface{}) map[string]interface{} {
+	changed := map[string]interface{}{}
+	for key, value := range patched {
+		currentValue, ok := current[key]
+		if ok && datautils.DeepEqual(currentValue, value) {
+			continue
+		}
+		childFields, _ := fields["f:"+key].(map[string]interface{})
+		switch typed := value.(type) {
+		case map[string]interface{}:
+			if currentMap, ok := currentValue.(map[string]interface{}); ok {
+				if child := changedFields(currentMap, typed, childFields); len(child) != 0 {
+					changed[key] = child
+				}
+				continue
+			}
+		case []interface{}:
+			if currentList, ok := currentValue.([]interface{}); ok {
+				if keys := listKeys(childFields); len(keys) != 0 {
+					if items, ok := changedItems(currentList, typed, keys, childFields); ok {
+						if len(items) != 0 {
+							changed[key] = items
+						}
+						continue
+					}
+				}
+			}
+		}
+		changed[key] = runtime.DeepCopyJSONValue(value)
+	}
+	return changed
+}
+
+// changedItems returns the items of an associative list that differ from the current ones, it returns false
+// when an item doesn't have all the keys of the list
+func changedItems(current, patched []interface{}, keys []string, fields map[string]interface{}) ([]interface{}, bool) {
+	currentItems := map[string]map[string]interface{}{}
+	for _, item := range current {
+		itemMap, _ := item.(map[string]interface{})
+		if key, ok := itemKey(itemMap, keys); ok {
+			currentItems[key] = itemMap
+		}
+	}
+	itemFields := map[string]map[string]interface{}{}
+	for name, child := range fields {
+		if encoded, ok := strings.CutPrefix(name, "k:"); ok {
+			var keyValues map[string]interface{}
+			if err := json.Unmarshal([]byte(encoded), &keyValues); err == nil {
+				if key, ok := itemKey(keyValues, keys); ok {
+					itemFields[key], _ = child.(map[string]interface{})
+				}
+			}
+		}
+	}
+	var items []interface{}
+	for _, item := range patched {
+		itemMap, _ := item.(map[string]interface{})
+		key, ok := itemKey(itemMap, keys)
+		if !ok {
+			return nil, false
+		}
+		currentItem, ok := currentItems[key]
+		if !ok {
+			items = append(items, runtime.DeepCopyJSONValue(itemMap))
+			continue
+		}
+		if datautils.DeepEqual(currentItem, itemMap) {
+			continue
+		}
+		changed := changedFields(currentItem, itemMap, itemFields[key])
+		for _, name := range keys {
+			changed[name] = runtime.DeepCopyJSONValue(itemMap[name])
+		}
+		items = append(items, changed)
+	}
+	return items, true
+}
+
+// listKeys returns the names of the keys of an associative list from its managed fields,
+// associative list items are identified with k:{"name":"value"} entries
+func listKeys(fields map[string]interface{}) []string {
+	for name := range fields {
+		if encoded, ok := strings.CutPrefix(name, "k:"); ok {
+			var keyValues map[string]interface{}
+			if err := json.Unmarshal([]byte(encoded), &keyValues); err != nil {
+				return nil
+			}
+			keys := make([]string, 0, len(keyValues))
+			for key := range keyValues {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			return keys
+		}
+	}
+	return nil
+}
+
+// itemKey returns a canonical representation of the keys of an associative list item
+func itemKey(item map[string]interface{}, keys []string) (string, bool) {
+	if item == nil {
+		return "", false
+	}
+	values := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		value, ok := item[key]
+		if !ok {
+			return "", false
+		}
+		values[key] = value
+	}
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		return "", false
+	}
+	return string(encoded), true
+}
+
+// copyOwnedFields copies the fields described by a managed fields set from src to dst, only the owned items
+// of associative lists are copied and other lists are copied as a whole.
+func copyOwnedFields(fields map[string]interface{}, src, dst map[string]interface{}) {
+	for key, child := range fields {
+		name, ok := strings.CutPrefix(key, "f:")
+		if !ok {
+			continue
+		}
+		value, ok := src[name]
+		if !ok {
+			continue
+		}
+		childFields, _ := child.(map[string]interface{})
+		switch typed := value.(type) {
+		case map[string]interface{}:
+			if len(childFields) != 0 {
+				dstMap, ok := dst[name].(map[string]interface{})
+				if !ok {
+					dstMap = map[string]interface{}{}
+					dst[name] = dstMap
+				}
+				copyOwnedFields(childFields, typed, dstMap)
+				continue
+			}
+		case []interface{}:
+			if keys := listKeys(childFields); len(keys) != 0 {
+				if items, ok := copyOwnedItems(childFields, keys, typed, dst[name]); ok {
+					dst[name] = items
+					continue
+				}
+			}
+		}
+		if _, ok := dst[name]; !ok {
+			dst[name] = runtime.DeepCopyJSONValue(value)
+		}
+	}
+}
+
+// copyOwnedItems copies the owned items of an associative list into the items already in the apply configuration
+func copyOwnedItems(fields map[string]interface{}, keys []string, src []interface{}, dst interface{}) ([]interface{}, bool) {
+	dstItems, _ := dst.([]interface{})
+	if dst != nil && dstItems == nil {
+		return nil, false
+	}
+	srcItems := map[string]map[string]interface{}{}
+	for _, item := range src {
+		itemMap, _ := item.(map[string]interface{})
+		if key, ok := itemKey(itemMap, keys); ok {
+			srcItems[key] = itemMap
+		}
+	}
+	for name, child := range fields {
+		encoded, ok := strings.CutPrefix(name, "k:")
+		if !ok {
+			continue
+		}
+		var keyValues map[string]interface{}
+		if err := json.Unmarshal([]byte(encoded), &keyValues); err != nil {
+			return nil, false
+		}
+		key, ok := itemKey(keyValues, keys)
+		if !ok {
+			return nil, false
+		}
+		srcItem, ok := srcItems[key]
+		if !ok {
+			// the item was removed by the mutation
+			continue
+		}
+		var dstItem map[string]interface{}
+		for _, item := range dstItems {
+			if itemMap, _ := item.(map[string]interface{}); itemMap != nil {
+				if itemMapKey, ok := itemKey(itemMap, keys); ok && itemMapKey == key {
+					dstItem = itemMap
+					break
+				}
+			}
+		}
+		if dstItem == nil {
+			dstItem = map[string]interface{}{}
+			for _, name := range keys {
+				dstItem[name] = runtime.DeepCopyJSONValue(srcItem[name])
+			}
+			dstItems = append(dstItems, dstItem)
+		}
+		childFields, _ := child.(map[string]interface{})
+		copyOwnedFields(childFields, srcItem, dstItem)
+	}
+	return dstItems, true
+}
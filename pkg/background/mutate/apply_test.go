@@ -0,0 +1,118 @@
+package mutate
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_applyConfiguration(t *testing.T) {
+	current := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":            "test",
+			"namespace":       "default",
+			"resourceVersion": "42",
+			"labels": map[string]interface{}{
+				"owner": "kyverno",
+				"team":  "other",
+			},
+		},
+		"data": map[string]interface{}{
+			"foo": "bar",
+			"old": "value",
+		},
+	}}
+	current.SetManagedFields([]metav1.ManagedFieldsEntry{{
+		Manager:    "kyverno-" + fieldManager,
+		Operation:  metav1.ManagedFieldsOperationApply,
+		FieldsType: "FieldsV1",
+		FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{"f:owner":{}}}}`)},
+	}, {
+		Manager:    "other",
+		Operation:  metav1.ManagedFieldsOperationUpdate,
+		FieldsType: "FieldsV1",
+		FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{"f:team":{}}},"f:data":{"f:foo":{}}}`)},
+	}})
+	patched := current.DeepCopy()
+	patched.Object["data"] = map[string]interface{}{
+		"foo": "baz",
+	}
+
+	config, err := applyConfiguration(current, patched, "")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, config.Object, map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":      "test",
+			"namespace": "default",
+			"labels": map[string]interface{}{
+				"owner": "kyverno",
+			},
+		},
+		"data": map[string]interface{}{
+			"foo": "baz",
+		},
+	})
+}
+
+func Test_applyConfiguration_associativeList(t *testing.T) {
+	current := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]interface{}{
+			"name":      "test",
+			"namespace": "default",
+			"labels": map[string]interface{}{
+				"mutated": "true",
+			},
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "app", "image": "nginx"},
+				map[string]interface{}{"name": "sidecar", "image": "envoy", "securityContext": map[string]interface{}{"privileged": false}},
+			},
+		},
+	}}
+	current.SetManagedFields([]metav1.ManagedFieldsEntry{{
+		Manager:    "kyverno-" + fieldManager,
+		Operation:  metav1.ManagedFieldsOperationApply,
+		FieldsType: "FieldsV1",
+		FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{"f:mutated":{}}},"f:spec":{"f:containers":{"k:{\"name\":\"sidecar\"}":{".":{},"f:name":{},"f:securityContext":{"f:privileged":{}}}}}}`)},
+	}, {
+		Manager:    "other",
+		Operation:  metav1.ManagedFieldsOperationUpdate,
+		FieldsType: "FieldsV1",
+		FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:containers":{"k:{\"name\":\"app\"}":{".":{},"f:name":{},"f:image":{}},"k:{\"name\":\"sidecar\"}":{".":{},"f:name":{},"f:image":{}}}}}`)},
+	}})
+	patched := current.DeepCopy()
+	patched.Object["spec"] = map[string]interface{}{
+		"containers": []interface{}{
+			map[string]interface{}{"name": "app", "image": "nginx", "imagePullPolicy": "Always"},
+			map[string]interface{}{"name": "sidecar", "image": "envoy", "securityContext": map[string]interface{}{"privileged": false}},
+		},
+	}
+	// the label applied by kyverno before is removed by omitting it
+	unstructured.RemoveNestedField(patched.Object, "metadata", "labels")
+
+	config, err := applyConfiguration(current, patched, "")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, config.Object, map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]interface{}{
+			"name":      "test",
+			"namespace": "default",
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "app", "imagePullPolicy": "Always"},
+				map[string]interface{}{"name": "sidecar", "securityContext": map[string]interface{}{"privileged": false}},
+			},
+		},
+	})
+}
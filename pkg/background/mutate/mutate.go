@@ -16,6 +16,7 @@ import (
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/informers"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
@@ -23,7 +24,6 @@ import (
 	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 )
 
@@ -39,7 +39,7 @@ type mutateExistingController struct {
 	// listers
 	policyLister  kyvernov1listers.ClusterPolicyLister
 	npolicyLister kyvernov1listers.PolicyLister
-	nsLister      corev1listers.NamespaceLister
+	nsLabels      informers.NamespaceLabels
 
 	configuration config.Configuration
 	eventGen      event.Interface
@@ -49,6 +49,8 @@ type mutateExistingController struct {
 
 	reportsConfig  reportutils.ReportingConfiguration
 	reportsBreaker breaker.Breaker
+
+	applier *Applier
 }
 
 // NewMutateExistingController returns an instance of the MutateExistingController
@@ -59,13 +61,14 @@ func NewMutateExistingController(
 	engine engineapi.Engine,
 	policyLister kyvernov1listers.ClusterPolicyLister,
 	npolicyLister kyvernov1listers.PolicyLister,
-	nsLister corev1listers.NamespaceLister,
+	nsLabels informers.NamespaceLabels,
 	dynamicConfig config.Configuration,
 	eventGen event.Interface,
 	log logr.Logger,
 	jp jmespath.Interface,
 	reportsConfig reportutils.ReportingConfiguration,
 	reportsBreaker breaker.Breaker,
+	applier *Applier,
 ) *mutateExistingController {
 	c := mutateExistingController{
 		client:         client,
@@ -74,13 +77,14 @@ func NewMutateExistingController(
 		engine:         engine,
 		policyLister:   policyLister,
 		npolicyLister:  npolicyLister,
-		nsLister:       nsLister,
+		nsLabels:       nsLabels,
 		configuration:  dynamicConfig,
 		eventGen:       eventGen,
 		log:            log,
 		jp:             jp,
 		reportsConfig:  reportsConfig,
 		reportsBreaker: reportsBreaker,
+		applier:        applier,
 	}
 	return &c
 }
@@ -148,7 +152,7 @@ func (c *mutateExistingController) ProcessUR(ur *kyvernov2.UpdateRequest) error
 			}
 		}
 
-		namespaceLabels := engineutils.GetNamespaceSelectorsFromNamespaceLister(trigger.GetKind(), trigger.GetNamespace(), c.nsLister, logger)
+		namespaceLabels := engineutils.GetNamespaceLabels(trigger.GetKind(), trigger.GetNamespace(), c.nsLabels, logger)
 		policyContext, err := common.NewBackgroundContext(logger, c.client, ur.Spec.Context, policy, trigger, c.configuration, c.jp, namespaceLabels)
 		if err != nil {
 			logger.WithName(rule.Name).Error(err, "failed to build policy context")
@@ -195,7 +199,20 @@ func (c *mutateExistingController) ProcessUR(ur *kyvernov2.UpdateRequest) error
 
 				patchedNew.SetResourceVersion(patched.GetResourceVersion())
 				var updateErr error
-				if patchedSubresource == "status" {
+				if c.applier.UseServerSideApply(rule.Mutation) {
+					apiVersion, kind := patchedNew.GetAPIVersion(), patchedNew.GetKind()
+					if patchedSubresource != "" && patchedSubresource != "status" {
+						parentResourceGV := schema.GroupVersion{Group: parentGVR.Group, Version: parentGVR.Version}
+						parentResourceGVK, err := c.client.Discovery().GetGVKFromGVR(parentResourceGV.WithResource(parentGVR.Resource))
+						if err != nil {
+							logger.Error(err, "failed to get GVK from GVR", "GVR", parentGVR)
+							errs = append(errs, err)
+							continue
+						}
+						apiVersion, kind = parentResourceGV.String(), parentResourceGVK.Kind
+					}
+					updateErr = c.applier.Apply(context.TODO(), apiVersion, kind, patchedNew, patchedSubresource)
+				} else if patchedSubresource == "status" {
 					_, updateErr = c.client.UpdateStatusResource(context.TODO(), patchedNew.GetAPIVersion(), patchedNew.GetKind(), patchedNew.GetNamespace(), patchedNew.Object, false)
 				} else if patchedSubresource != "" {
 					parentResourceGVR := parentGVR
@@ -0,0 +1,48 @@
+package background
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// RetryPolicy configures how failed update requests are retried
+type RetryPolicy struct {
+	// MaxRetries is the number of retries before an update request is dead lettered
+	MaxRetries int
+	// InitialBackoff is the delay before the first retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between two retries
+	MaxBackoff time.Duration
+	// BackoffFactor multiplies the delay after every retry
+	BackoffFactor float64
+}
+
+// Validate checks the retry policy is consistent
+func (p RetryPolicy) Validate() error {
+	if p.MaxRetries < 0 {
+		return errors.New("invalid update request max retries, must not be negative")
+	}
+	if p.InitialBackoff < 0 {
+		return errors.New("invalid update request initial backoff, must not be negative")
+	}
+	if p.MaxBackoff < p.InitialBackoff {
+		return errors.New("invalid update request max backoff, must be greater than or equal to the initial backoff")
+	}
+	if p.BackoffFactor < 1 {
+		return errors.New("invalid update request backoff factor, must be greater than or equal to 1")
+	}
+	return nil
+}
+
+// backoff returns the delay before a retry, retries are counted from 1
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	if retry < 1 {
+		retry = 1
+	}
+	backoff := float64(p.InitialBackoff) * math.Pow(p.BackoffFactor, float64(retry-1))
+	if backoff > float64(p.MaxBackoff) {
+		return p.MaxBackoff
+	}
+	return time.Duration(backoff)
+}
@@ -0,0 +1,25 @@
+package background
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy_Validate(t *testing.T) {
+	assert.NoError(t, RetryPolicy{MaxRetries: 3, InitialBackoff: time.Second, MaxBackoff: time.Minute, BackoffFactor: 2}.Validate())
+	assert.Error(t, RetryPolicy{MaxRetries: -1, InitialBackoff: time.Second, MaxBackoff: time.Minute, BackoffFactor: 2}.Validate())
+	assert.Error(t, RetryPolicy{MaxRetries: 3, InitialBackoff: time.Minute, MaxBackoff: time.Second, BackoffFactor: 2}.Validate())
+	assert.Error(t, RetryPolicy{MaxRetries: 3, InitialBackoff: time.Second, MaxBackoff: time.Minute, BackoffFactor: 0.5}.Validate())
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 10, InitialBackoff: time.Second, MaxBackoff: 10 * time.Second, BackoffFactor: 2}
+	assert.Equal(t, time.Second, policy.backoff(0))
+	assert.Equal(t, time.Second, policy.backoff(1))
+	assert.Equal(t, 2*time.Second, policy.backoff(2))
+	assert.Equal(t, 8*time.Second, policy.backoff(4))
+	assert.Equal(t, 10*time.Second, policy.backoff(5))
+	assert.Equal(t, 10*time.Second, policy.backoff(100))
+}
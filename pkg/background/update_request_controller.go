@@ -3,6 +3,7 @@ package background
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
@@ -21,13 +22,19 @@ import (
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/informers"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"github.com/kyverno/kyverno/pkg/sharding"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	corev1informers "k8s.io/client-go/informers/core/v1"
-	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 )
@@ -52,7 +59,7 @@ type controller struct {
 	cpolLister kyvernov1listers.ClusterPolicyLister
 	polLister  kyvernov1listers.PolicyLister
 	urLister   kyvernov2listers.UpdateRequestNamespaceLister
-	nsLister   corev1listers.NamespaceLister
+	nsLabels   informers.NamespaceLabels
 
 	informersSynced []cache.InformerSynced
 
@@ -64,6 +71,18 @@ type controller struct {
 	jp             jmespath.Interface
 	reportsConfig  reportutils.ReportingConfiguration
 	reportsBreaker breaker.Breaker
+
+	// retries
+	retryPolicy RetryPolicy
+	retriesLock sync.Mutex
+	retries     map[string]time.Time
+	deadLetters metric.Int64Counter
+
+	// applier coalesces the mutations of existing resources
+	applier *mutate.Applier
+
+	// sharder assigns the update requests to the replicas, all of them are processed when it is nil
+	sharder sharding.Sharder
 }
 
 // NewController returns an instance of the Generate-Request Controller
@@ -75,11 +94,15 @@ func NewController(
 	polInformer kyvernov1informers.PolicyInformer,
 	urInformer kyvernov2informers.UpdateRequestInformer,
 	namespaceInformer corev1informers.NamespaceInformer,
+	nsLabels informers.NamespaceLabels,
 	eventGen event.Interface,
 	configuration config.Configuration,
 	jp jmespath.Interface,
 	reportsConfig reportutils.ReportingConfiguration,
 	reportsBreaker breaker.Breaker,
+	retryPolicy RetryPolicy,
+	mutateOptions mutate.Options,
+	sharder sharding.Sharder,
 ) Controller {
 	urLister := urInformer.Lister().UpdateRequests(config.KyvernoNamespace())
 	c := controller{
@@ -89,19 +112,36 @@ func NewController(
 		cpolLister:     cpolInformer.Lister(),
 		polLister:      polInformer.Lister(),
 		urLister:       urLister,
-		nsLister:       namespaceInformer.Lister(),
+		nsLabels:       nsLabels,
 		queue:          workqueue.NewNamedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[any](), "background"),
 		eventGen:       eventGen,
 		configuration:  configuration,
 		jp:             jp,
 		reportsConfig:  reportsConfig,
 		reportsBreaker: reportsBreaker,
+		retryPolicy:    retryPolicy,
+		retries:        map[string]time.Time{},
+		applier:        mutate.NewApplier(client, mutateOptions),
+		sharder:        sharder,
+	}
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	deadLetters, err := meter.Int64Counter(
+		"kyverno_update_requests_dead_lettered",
+		metric.WithDescription("can be used to track the number of update requests that exhausted their retries"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_update_requests_dead_lettered")
 	}
+	c.deadLetters = deadLetters
 	_, _ = urInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.addUR,
 		UpdateFunc: c.updateUR,
 	})
 
+	if sharder != nil {
+		// the update requests of the namespaces moving to this replica must be picked up
+		sharder.OnChange(c.enqueueAll)
+	}
 	c.informersSynced = []cache.InformerSynced{cpolInformer.Informer().HasSynced, polInformer.Informer().HasSynced, urInformer.Informer().HasSynced, namespaceInformer.Informer().HasSynced}
 
 	return &c
@@ -178,6 +218,12 @@ func (c *controller) syncUpdateRequest(key string) error {
 		return err
 	}
 
+	// the namespace may have moved to another replica since the update request was queued
+	if !c.owns(ur) {
+		logger.V(4).Info("skipping update request assigned to another replica", "key", key)
+		return nil
+	}
+
 	// Deep-copy otherwise we are mutating our cache.
 	ur = ur.DeepCopy()
 	if _, err := c.getPolicy(ur.Spec.Policy); err != nil && apierrors.IsNotFound(err) {
@@ -192,7 +238,7 @@ func (c *controller) syncUpdateRequest(key string) error {
 		}
 	}
 
-	urStatus, err := c.reconcileURStatus(ur)
+	urStatus, err := c.reconcileURStatus(key, ur)
 	if err != nil {
 		return err
 	}
@@ -211,14 +257,48 @@ func (c *controller) enqueueUpdateRequest(obj interface{}) {
 	c.queue.Add(key)
 }
 
+// owns returns true when the update request is assigned to this replica, by the namespace of its trigger
+func (c *controller) owns(ur *kyvernov2.UpdateRequest) bool {
+	if c.sharder == nil {
+		return true
+	}
+	namespace := ur.Spec.Resource.Namespace
+	if len(ur.Spec.RuleContext) != 0 {
+		namespace = ur.Spec.RuleContext[0].Trigger.Namespace
+	}
+	return c.sharder.Owns(namespace)
+}
+
+func (c *controller) enqueueAll() {
+	urs, err := c.urLister.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "failed to list update requests")
+		return
+	}
+	for _, ur := range urs {
+		if ur.Status.State == kyvernov2.Skip || ur.Status.State == kyvernov2.Completed || ur.Status.State == kyvernov2.DeadLetter {
+			continue
+		}
+		if c.owns(ur) {
+			c.enqueueUpdateRequest(ur)
+		}
+	}
+}
+
 func (c *controller) addUR(obj interface{}) {
 	ur := obj.(*kyvernov2.UpdateRequest)
+	if !c.owns(ur) {
+		return
+	}
 	c.enqueueUpdateRequest(ur)
 }
 
 func (c *controller) updateUR(_, cur interface{}) {
 	curUr := cur.(*kyvernov2.UpdateRequest)
-	if curUr.Status.State == kyvernov2.Skip || curUr.Status.State == kyvernov2.Completed {
+	if curUr.Status.State == kyvernov2.Skip || curUr.Status.State == kyvernov2.Completed || curUr.Status.State == kyvernov2.DeadLetter {
+		return
+	}
+	if !c.owns(curUr) {
 		return
 	}
 	c.enqueueUpdateRequest(curUr)
@@ -228,16 +308,16 @@ func (c *controller) processUR(ur *kyvernov2.UpdateRequest) error {
 	statusControl := common.NewStatusControl(c.kyvernoClient, c.urLister)
 	switch ur.Spec.GetRequestType() {
 	case kyvernov2.Mutate:
-		ctrl := mutate.NewMutateExistingController(c.client, c.kyvernoClient, statusControl, c.engine, c.cpolLister, c.polLister, c.nsLister, c.configuration, c.eventGen, logger, c.jp, c.reportsConfig, c.reportsBreaker)
+		ctrl := mutate.NewMutateExistingController(c.client, c.kyvernoClient, statusControl, c.engine, c.cpolLister, c.polLister, c.nsLabels, c.configuration, c.eventGen, logger, c.jp, c.reportsConfig, c.reportsBreaker, c.applier)
 		return ctrl.ProcessUR(ur)
 	case kyvernov2.Generate:
-		ctrl := generate.NewGenerateController(c.client, c.kyvernoClient, statusControl, c.engine, c.cpolLister, c.polLister, c.urLister, c.nsLister, c.configuration, c.eventGen, logger, c.jp, c.reportsConfig, c.reportsBreaker)
+		ctrl := generate.NewGenerateController(c.client, c.kyvernoClient, statusControl, c.engine, c.cpolLister, c.polLister, c.urLister, c.nsLabels, c.configuration, c.eventGen, logger, c.jp, c.reportsConfig, c.reportsBreaker)
 		return ctrl.ProcessUR(ur)
 	}
 	return nil
 }
 
-func (c *controller) reconcileURStatus(ur *kyvernov2.UpdateRequest) (kyvernov2.UpdateRequestState, error) {
+func (c *controller) reconcileURStatus(key string, ur *kyvernov2.UpdateRequest) (kyvernov2.UpdateRequestState, error) {
 	new, err := c.kyvernoClient.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).Get(context.TODO(), ur.GetName(), metav1.GetOptions{})
 	if err != nil {
 		logger.V(2).Info("cannot fetch latest UR, fallback to the existing one", "reason", err.Error())
@@ -249,12 +329,66 @@ func (c *controller) reconcileURStatus(ur *kyvernov2.UpdateRequest) (kyvernov2.U
 	case kyvernov2.Completed:
 		errUpdate = c.kyvernoClient.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).Delete(context.TODO(), ur.GetName(), metav1.DeleteOptions{})
 	case kyvernov2.Failed:
+		if new.Status.RetryCount > c.retryPolicy.MaxRetries {
+			errUpdate = c.deadLetter(new)
+			break
+		}
+		if delay := c.retryDelay(new); delay > 0 {
+			c.queue.AddAfter(key, delay)
+			break
+		}
 		new.Status.State = kyvernov2.Pending
 		_, errUpdate = c.kyvernoClient.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(context.TODO(), new, metav1.UpdateOptions{})
 	}
 	return new.Status.State, errUpdate
 }
 
+// retryDelay returns the time left before a failed update request is retried, the backoff starts
+// when the failure is first observed
+func (c *controller) retryDelay(ur *kyvernov2.UpdateRequest) time.Duration {
+	c.retriesLock.Lock()
+	defer c.retriesLock.Unlock()
+	retryAt, ok := c.retries[ur.GetName()]
+	if !ok {
+		retryAt = time.Now().Add(c.retryPolicy.backoff(ur.Status.RetryCount))
+		c.retries[ur.GetName()] = retryAt
+	}
+	if delay := time.Until(retryAt); delay > 0 {
+		return delay
+	}
+	delete(c.retries, ur.GetName())
+	return 0
+}
+
+// deadLetter stops retrying an update request, it is kept in the DeadLetter state so that the failure
+// remains visible until the update request is deleted
+func (c *controller) deadLetter(ur *kyvernov2.UpdateRequest) error {
+	c.retriesLock.Lock()
+	delete(c.retries, ur.GetName())
+	c.retriesLock.Unlock()
+	message := ur.Status.Message
+	ur.Status.State = kyvernov2.DeadLetter
+	if _, err := c.kyvernoClient.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(context.TODO(), ur, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	logger.Info("update request exhausted its retries", "name", ur.GetName(), "policy", ur.Spec.Policy, "retries", ur.Status.RetryCount-1, "message", message)
+	if c.deadLetters != nil {
+		c.deadLetters.Add(context.TODO(), 1, metric.WithAttributes(
+			attribute.String("policy_name", ur.Spec.Policy),
+			attribute.String("request_type", string(ur.Spec.GetRequestType())),
+		))
+	}
+	if policy, err := c.getPolicy(ur.Spec.Policy); err == nil {
+		source := event.GeneratePolicyController
+		if ur.Spec.GetRequestType() == kyvernov2.Mutate {
+			source = event.MutateExistingController
+		}
+		failure := fmt.Errorf("update request %s exhausted its retries: %s", ur.GetName(), message)
+		c.eventGen.Add(event.NewBackgroundFailedEvent(failure, policy, ur.Spec.Rule, source, ur.Spec.Resource)...)
+	}
+	return nil
+}
+
 func (c *controller) getPolicy(key string) (kyvernov1.PolicyInterface, error) {
 	namespace, name, err := cache.SplitMetaNamespaceKey(key)
 	if err != nil {
@@ -64,3 +64,60 @@ func (b *breaker) Do(ctx context.Context, inner func(context.Context) error) err
 	}
 	return inner(ctx)
 }
+
+// NewCounterBreaker returns a breaker opening when the counter isn't running or counts more than the limit,
+// the count, the limit and the state of the breaker are exposed as metrics
+func NewCounterBreaker(name string, counter Counter, limit int) *breaker {
+	return NewDynamicCounterBreaker(name, counter, func() int { return limit })
+}
+
+// NewDynamicCounterBreaker returns a counter breaker whose limit is read every time the breaker is checked
+func NewDynamicCounterBreaker(name string, counter Counter, limit func() int) *breaker {
+	logger := logging.WithName("circuit-breaker")
+	open := func(context.Context) bool {
+		count, isRunning := counter.Count()
+		if !isRunning {
+			return true
+		}
+		return count > limit()
+	}
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	state, err := meter.Int64ObservableGauge(
+		"kyverno_breaker_open",
+		sdkmetric.WithDescription("can be used to track whether the breaker is open (1) or closed (0)"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_breaker_open")
+	}
+	count, err := meter.Int64ObservableGauge(
+		"kyverno_breaker_count",
+		sdkmetric.WithDescription("can be used to track the number of resources counted by the breaker"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_breaker_count")
+	}
+	threshold, err := meter.Int64ObservableGauge(
+		"kyverno_breaker_limit",
+		sdkmetric.WithDescription("can be used to track the number of resources above which the breaker opens"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_breaker_limit")
+	}
+	if state != nil && count != nil && threshold != nil {
+		if _, err := meter.RegisterCallback(func(ctx context.Context, observer sdkmetric.Observer) error {
+			attributes := sdkmetric.WithAttributes(attribute.String("circuit_name", name))
+			var value int64
+			if open(ctx) {
+				value = 1
+			}
+			counted, _ := counter.Count()
+			observer.ObserveInt64(state, value, attributes)
+			observer.ObserveInt64(count, int64(counted), attributes)
+			observer.ObserveInt64(threshold, int64(limit()), attributes)
+			return nil
+		}, state, count, threshold); err != nil {
+			logger.Error(err, "failed to register callback")
+		}
+	}
+	return NewBreaker(name, open)
+}
@@ -75,3 +75,49 @@ func Test_breaker_Do(t *testing.T) {
 		})
 	}
 }
+
+type testCounter struct {
+	count     int
+	isRunning bool
+}
+
+func (c *testCounter) Count() (int, bool) {
+	return c.count, c.isRunning
+}
+
+func TestNewCounterBreaker(t *testing.T) {
+	counter := &testCounter{count: 10, isRunning: true}
+	subject := NewCounterBreaker("test", counter, 10)
+	called := false
+	inner := func(context.Context) error {
+		called = true
+		return nil
+	}
+	assert.NoError(t, subject.Do(context.TODO(), inner))
+	assert.True(t, called)
+	// the breaker opens above the limit
+	counter.count, called = 11, false
+	assert.NoError(t, subject.Do(context.TODO(), inner))
+	assert.False(t, called)
+	// and when the counter isn't running
+	counter.count, counter.isRunning = 0, false
+	assert.NoError(t, subject.Do(context.TODO(), inner))
+	assert.False(t, called)
+}
+
+func TestNewDynamicCounterBreaker(t *testing.T) {
+	counter := &testCounter{count: 10, isRunning: true}
+	limit := 5
+	subject := NewDynamicCounterBreaker("test", counter, func() int { return limit })
+	called := false
+	inner := func(context.Context) error {
+		called = true
+		return nil
+	}
+	assert.NoError(t, subject.Do(context.TODO(), inner))
+	assert.False(t, called)
+	// the limit is read when the breaker is checked
+	limit = 10
+	assert.NoError(t, subject.Do(context.TODO(), inner))
+	assert.True(t, called)
+}
@@ -0,0 +1,175 @@
+package breaker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// maxSamples bounds the memory used by the observation window
+	maxSamples = 2048
+	// evaluationPeriod is the minimum period between two evaluations of the thresholds
+	evaluationPeriod = time.Second
+)
+
+// LatencyObserver receives the latency and outcome of admission requests
+type LatencyObserver interface {
+	// Observe records the latency of a request and whether it failed
+	Observe(latency time.Duration, failed bool)
+}
+
+// LatencyOptions configures a latency tracker
+type LatencyOptions struct {
+	// Window is the observation window
+	Window time.Duration
+	// MinSamples is the number of samples required in the window before thresholds are evaluated
+	MinSamples int
+	// MaxP99Latency opens the breaker when the p99 latency exceeds it (disabled when zero)
+	MaxP99Latency time.Duration
+	// MaxErrorRate opens the breaker when the ratio of failed requests exceeds it (disabled when zero)
+	MaxErrorRate float64
+	// Cooldown is the time the breaker stays open before closing again
+	Cooldown time.Duration
+}
+
+type sample struct {
+	time    time.Time
+	latency time.Duration
+	failed  bool
+}
+
+// LatencyTracker opens when the p99 latency or the error rate observed over a sliding window crosses
+// the configured thresholds, it closes automatically when the cooldown period expires.
+type LatencyTracker struct {
+	name      string
+	options   LatencyOptions
+	onChange  func(open bool, reason string)
+	lock      sync.Mutex
+	samples   []sample
+	evaluated time.Time
+	openUntil time.Time
+	open      bool
+	trips     sdkmetric.Int64Counter
+	now       func() time.Time
+}
+
+// NewLatencyTracker creates a latency tracker, onChange is called when the tracker opens or closes
+func NewLatencyTracker(name string, options LatencyOptions, onChange func(open bool, reason string)) *LatencyTracker {
+	logger := logging.WithName("circuit-breaker")
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	trips, err := meter.Int64Counter(
+		"kyverno_breaker_trips",
+		sdkmetric.WithDescription("track the number of times the latency breaker opened"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_breaker_trips")
+	}
+	t := &LatencyTracker{
+		name:     name,
+		options:  options,
+		onChange: onChange,
+		trips:    trips,
+		now:      time.Now,
+	}
+	state, err := meter.Int64ObservableGauge(
+		"kyverno_breaker_open",
+		sdkmetric.WithDescription("can be used to track whether the breaker is open (1) or closed (0)"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_breaker_open")
+	} else if _, err := meter.RegisterCallback(func(_ context.Context, observer sdkmetric.Observer) error {
+		var value int64
+		if t.IsOpen(context.Background()) {
+			value = 1
+		}
+		observer.ObserveInt64(state, value, sdkmetric.WithAttributes(attribute.String("circuit_name", name)))
+		return nil
+	}, state); err != nil {
+		logger.Error(err, "failed to register callback")
+	}
+	return t
+}
+
+// IsOpen returns true when the tracker is open, it can be used as the open func of a breaker
+func (t *LatencyTracker) IsOpen(context.Context) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	now := t.now()
+	if t.open && !now.Before(t.openUntil) {
+		t.open = false
+		// samples observed during the overload must not reopen the breaker
+		t.samples = nil
+		t.notify(false, "cooldown expired")
+	}
+	return t.open
+}
+
+func (t *LatencyTracker) Observe(latency time.Duration, failed bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	now := t.now()
+	t.samples = append(t.samples, sample{time: now, latency: latency, failed: failed})
+	if len(t.samples) > maxSamples {
+		t.samples = t.samples[len(t.samples)-maxSamples:]
+	}
+	if t.open || now.Sub(t.evaluated) < evaluationPeriod {
+		return
+	}
+	t.evaluated = now
+	if reason := t.evaluate(now); reason != "" {
+		t.open = true
+		t.openUntil = now.Add(t.options.Cooldown)
+		if t.trips != nil {
+			t.trips.Add(context.Background(), 1, sdkmetric.WithAttributes(attribute.String("circuit_name", t.name)))
+		}
+		t.notify(true, reason)
+	}
+}
+
+// evaluate drops samples out of the window and returns the reason to open the breaker, if any
+func (t *LatencyTracker) evaluate(now time.Time) string {
+	start := 0
+	for start < len(t.samples) && now.Sub(t.samples[start].time) > t.options.Window {
+		start++
+	}
+	t.samples = t.samples[start:]
+	if len(t.samples) == 0 || len(t.samples) < t.options.MinSamples {
+		return ""
+	}
+	latencies := make([]time.Duration, 0, len(t.samples))
+	failures := 0
+	for _, s := range t.samples {
+		latencies = append(latencies, s.latency)
+		if s.failed {
+			failures++
+		}
+	}
+	if t.options.MaxErrorRate > 0 {
+		if rate := float64(failures) / float64(len(t.samples)); rate > t.options.MaxErrorRate {
+			return fmt.Sprintf("error rate %.2f exceeds %.2f", rate, t.options.MaxErrorRate)
+		}
+	}
+	if t.options.MaxP99Latency > 0 {
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		p99 := latencies[(len(latencies)*99-1)/100]
+		if p99 > t.options.MaxP99Latency {
+			return fmt.Sprintf("p99 latency %s exceeds %s", p99, t.options.MaxP99Latency)
+		}
+	}
+	return ""
+}
+
+func (t *LatencyTracker) notify(open bool, reason string) {
+	if t.onChange != nil {
+		t.onChange(open, reason)
+	}
+}
@@ -0,0 +1,80 @@
+package breaker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatencyTracker(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  LatencyOptions
+		latency  time.Duration
+		failed   bool
+		samples  int
+		wantOpen bool
+	}{{
+		name:     "not enough samples",
+		options:  LatencyOptions{Window: time.Minute, MinSamples: 10, MaxP99Latency: time.Second, Cooldown: time.Minute},
+		latency:  2 * time.Second,
+		samples:  5,
+		wantOpen: false,
+	}, {
+		name:     "latency below threshold",
+		options:  LatencyOptions{Window: time.Minute, MinSamples: 10, MaxP99Latency: time.Second, Cooldown: time.Minute},
+		latency:  100 * time.Millisecond,
+		samples:  20,
+		wantOpen: false,
+	}, {
+		name:     "latency above threshold",
+		options:  LatencyOptions{Window: time.Minute, MinSamples: 10, MaxP99Latency: time.Second, Cooldown: time.Minute},
+		latency:  2 * time.Second,
+		samples:  20,
+		wantOpen: true,
+	}, {
+		name:     "error rate above threshold",
+		options:  LatencyOptions{Window: time.Minute, MinSamples: 10, MaxErrorRate: 0.5, Cooldown: time.Minute},
+		latency:  100 * time.Millisecond,
+		failed:   true,
+		samples:  20,
+		wantOpen: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := NewLatencyTracker("test", tt.options, nil)
+			now := time.Now()
+			tracker.now = func() time.Time { return now }
+			for i := 0; i < tt.samples; i++ {
+				now = now.Add(evaluationPeriod)
+				tracker.Observe(tt.latency, tt.failed)
+			}
+			assert.Equal(t, tt.wantOpen, tracker.IsOpen(context.TODO()))
+		})
+	}
+}
+
+func TestLatencyTracker_Cooldown(t *testing.T) {
+	var changes []bool
+	tracker := NewLatencyTracker(
+		"test",
+		LatencyOptions{Window: time.Minute, MinSamples: 1, MaxP99Latency: time.Second, Cooldown: time.Minute},
+		func(open bool, _ string) { changes = append(changes, open) },
+	)
+	now := time.Now()
+	tracker.now = func() time.Time { return now }
+	tracker.Observe(2*time.Second, false)
+	assert.True(t, tracker.IsOpen(context.TODO()))
+	breaker := NewBreaker("test", tracker.IsOpen)
+	called := false
+	assert.NoError(t, breaker.Do(context.TODO(), func(context.Context) error {
+		called = true
+		return nil
+	}))
+	assert.False(t, called)
+	now = now.Add(time.Minute)
+	assert.False(t, tracker.IsOpen(context.TODO()))
+	assert.Equal(t, []bool{true, false}, changes)
+}
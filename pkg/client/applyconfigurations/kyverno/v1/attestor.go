@@ -28,6 +28,7 @@ type AttestorApplyConfiguration struct {
 	Keys               *StaticKeyAttestorApplyConfiguration   `json:"keys,omitempty"`
 	Certificates       *CertificateAttestorApplyConfiguration `json:"certificates,omitempty"`
 	Keyless            *KeylessAttestorApplyConfiguration     `json:"keyless,omitempty"`
+	Notary             *NotaryAttestorApplyConfiguration      `json:"notary,omitempty"`
 	Attestor           *apiextensionsv1.JSON                  `json:"attestor,omitempty"`
 	Annotations        map[string]string                      `json:"annotations,omitempty"`
 	Repository         *string                                `json:"repository,omitempty"`
@@ -64,6 +65,14 @@ func (b *AttestorApplyConfiguration) WithKeyless(value *KeylessAttestorApplyConf
 	return b
 }
 
+// WithNotary sets the Notary field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Notary field is set to the value of the last call.
+func (b *AttestorApplyConfiguration) WithNotary(value *NotaryAttestorApplyConfiguration) *AttestorApplyConfiguration {
+	b.Notary = value
+	return b
+}
+
 // WithAttestor sets the Attestor field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Attestor field is set to the value of the last call.
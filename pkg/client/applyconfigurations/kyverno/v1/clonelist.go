@@ -25,9 +25,10 @@ import (
 // CloneListApplyConfiguration represents an declarative configuration of the CloneList type for use
 // with apply.
 type CloneListApplyConfiguration struct {
-	Namespace *string           `json:"namespace,omitempty"`
-	Kinds     []string          `json:"kinds,omitempty"`
-	Selector  *v1.LabelSelector `json:"selector,omitempty"`
+	Namespace *string                           `json:"namespace,omitempty"`
+	Kinds     []string                          `json:"kinds,omitempty"`
+	Selector  *v1.LabelSelector                 `json:"selector,omitempty"`
+	Transform *CloneTransformApplyConfiguration `json:"transform,omitempty"`
 }
 
 // CloneListApplyConfiguration constructs an declarative configuration of the CloneList type for use with
@@ -61,3 +62,11 @@ func (b *CloneListApplyConfiguration) WithSelector(value v1.LabelSelector) *Clon
 	b.Selector = &value
 	return b
 }
+
+// WithTransform sets the Transform field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Transform field is set to the value of the last call.
+func (b *CloneListApplyConfiguration) WithTransform(value *CloneTransformApplyConfiguration) *CloneListApplyConfiguration {
+	b.Transform = value
+	return b
+}
@@ -21,6 +21,7 @@ package v1
 import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // ContextAPICallApplyConfiguration represents an declarative configuration of the ContextAPICall type for use
@@ -29,6 +30,7 @@ type ContextAPICallApplyConfiguration struct {
 	APICallApplyConfiguration `json:",inline"`
 	Default                   *apiextensionsv1.JSON `json:"default,omitempty"`
 	JMESPath                  *string               `json:"jmesPath,omitempty"`
+	CacheTTL                  *metav1.Duration      `json:"cacheTTL,omitempty"`
 }
 
 // ContextAPICallApplyConfiguration constructs an declarative configuration of the ContextAPICall type for use with
@@ -89,3 +91,11 @@ func (b *ContextAPICallApplyConfiguration) WithJMESPath(value string) *ContextAP
 	b.JMESPath = &value
 	return b
 }
+
+// WithCacheTTL sets the CacheTTL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CacheTTL field is set to the value of the last call.
+func (b *ContextAPICallApplyConfiguration) WithCacheTTL(value metav1.Duration) *ContextAPICallApplyConfiguration {
+	b.CacheTTL = &value
+	return b
+}
@@ -27,6 +27,9 @@ type ContextEntryApplyConfiguration struct {
 	ImageRegistry   *ImageRegistryApplyConfiguration               `json:"imageRegistry,omitempty"`
 	Variable        *VariableApplyConfiguration                    `json:"variable,omitempty"`
 	GlobalReference *GlobalContextEntryReferenceApplyConfiguration `json:"globalReference,omitempty"`
+	SecretStore     *SecretStoreApplyConfiguration                 `json:"secretStore,omitempty"`
+	GRPCCall        *GRPCCallApplyConfiguration                    `json:"grpcCall,omitempty"`
+	ExternalData    *ExternalDataApplyConfiguration                `json:"externalData,omitempty"`
 }
 
 // ContextEntryApplyConfiguration constructs an declarative configuration of the ContextEntry type for use with
@@ -82,3 +85,27 @@ func (b *ContextEntryApplyConfiguration) WithGlobalReference(value *GlobalContex
 	b.GlobalReference = value
 	return b
 }
+
+// WithSecretStore sets the SecretStore field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SecretStore field is set to the value of the last call.
+func (b *ContextEntryApplyConfiguration) WithSecretStore(value *SecretStoreApplyConfiguration) *ContextEntryApplyConfiguration {
+	b.SecretStore = value
+	return b
+}
+
+// WithGRPCCall sets the GRPCCall field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GRPCCall field is set to the value of the last call.
+func (b *ContextEntryApplyConfiguration) WithGRPCCall(value *GRPCCallApplyConfiguration) *ContextEntryApplyConfiguration {
+	b.GRPCCall = value
+	return b
+}
+
+// WithExternalData sets the ExternalData field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ExternalData field is set to the value of the last call.
+func (b *ContextEntryApplyConfiguration) WithExternalData(value *ExternalDataApplyConfiguration) *ContextEntryApplyConfiguration {
+	b.ExternalData = value
+	return b
+}
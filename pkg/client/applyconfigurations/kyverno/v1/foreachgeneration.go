@@ -20,6 +20,7 @@ package v1
 
 import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
 )
 
@@ -27,6 +28,7 @@ import (
 // with apply.
 type ForEachGenerationApplyConfiguration struct {
 	List                               *string                             `json:"list,omitempty"`
+	MapEntries                         *bool                               `json:"mapEntries,omitempty"`
 	Context                            []ContextEntryApplyConfiguration    `json:"context,omitempty"`
 	AnyAllConditions                   *AnyAllConditionsApplyConfiguration `json:"preconditions,omitempty"`
 	*GeneratePatternApplyConfiguration `json:"GeneratePattern,omitempty"`
@@ -46,6 +48,14 @@ func (b *ForEachGenerationApplyConfiguration) WithList(value string) *ForEachGen
 	return b
 }
 
+// WithMapEntries sets the MapEntries field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MapEntries field is set to the value of the last call.
+func (b *ForEachGenerationApplyConfiguration) WithMapEntries(value bool) *ForEachGenerationApplyConfiguration {
+	b.MapEntries = &value
+	return b
+}
+
 // WithContext adds the given value to the Context field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Context field.
@@ -118,6 +128,15 @@ func (b *ForEachGenerationApplyConfiguration) ensureResourceSpecApplyConfigurati
 	}
 }
 
+// WithNamespaceSelector sets the NamespaceSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NamespaceSelector field is set to the value of the last call.
+func (b *ForEachGenerationApplyConfiguration) WithNamespaceSelector(value v1.LabelSelector) *ForEachGenerationApplyConfiguration {
+	b.ensureGeneratePatternApplyConfigurationExists()
+	b.NamespaceSelector = &value
+	return b
+}
+
 // WithRawData sets the RawData field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the RawData field is set to the value of the last call.
@@ -27,6 +27,7 @@ import (
 // with apply.
 type ForEachMutationApplyConfiguration struct {
 	List                   *string                             `json:"list,omitempty"`
+	MapEntries             *bool                               `json:"mapEntries,omitempty"`
 	Order                  *v1.ForeachOrder                    `json:"order,omitempty"`
 	Context                []ContextEntryApplyConfiguration    `json:"context,omitempty"`
 	AnyAllConditions       *AnyAllConditionsApplyConfiguration `json:"preconditions,omitempty"`
@@ -49,6 +50,14 @@ func (b *ForEachMutationApplyConfiguration) WithList(value string) *ForEachMutat
 	return b
 }
 
+// WithMapEntries sets the MapEntries field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MapEntries field is set to the value of the last call.
+func (b *ForEachMutationApplyConfiguration) WithMapEntries(value bool) *ForEachMutationApplyConfiguration {
+	b.MapEntries = &value
+	return b
+}
+
 // WithOrder sets the Order field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Order field is set to the value of the last call.
@@ -27,7 +27,9 @@ import (
 // with apply.
 type ForEachValidationApplyConfiguration struct {
 	List              *string                             `json:"list,omitempty"`
+	MapEntries        *bool                               `json:"mapEntries,omitempty"`
 	ElementScope      *bool                               `json:"elementScope,omitempty"`
+	Concurrency       *int                                `json:"concurrency,omitempty"`
 	Context           []ContextEntryApplyConfiguration    `json:"context,omitempty"`
 	AnyAllConditions  *AnyAllConditionsApplyConfiguration `json:"preconditions,omitempty"`
 	RawPattern        *apiextensionsv1.JSON               `json:"pattern,omitempty"`
@@ -50,6 +52,14 @@ func (b *ForEachValidationApplyConfiguration) WithList(value string) *ForEachVal
 	return b
 }
 
+// WithMapEntries sets the MapEntries field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MapEntries field is set to the value of the last call.
+func (b *ForEachValidationApplyConfiguration) WithMapEntries(value bool) *ForEachValidationApplyConfiguration {
+	b.MapEntries = &value
+	return b
+}
+
 // WithElementScope sets the ElementScope field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ElementScope field is set to the value of the last call.
@@ -58,6 +68,14 @@ func (b *ForEachValidationApplyConfiguration) WithElementScope(value bool) *ForE
 	return b
 }
 
+// WithConcurrency sets the Concurrency field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Concurrency field is set to the value of the last call.
+func (b *ForEachValidationApplyConfiguration) WithConcurrency(value int) *ForEachValidationApplyConfiguration {
+	b.Concurrency = &value
+	return b
+}
+
 // WithContext adds the given value to the Context field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Context field.
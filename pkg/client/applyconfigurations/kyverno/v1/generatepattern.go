@@ -20,6 +20,7 @@ package v1
 
 import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
 )
 
@@ -27,6 +28,7 @@ import (
 // with apply.
 type GeneratePatternApplyConfiguration struct {
 	*ResourceSpecApplyConfiguration `json:"ResourceSpec,omitempty"`
+	NamespaceSelector               *v1.LabelSelector            `json:"namespaceSelector,omitempty"`
 	RawData                         *apiextensionsv1.JSON        `json:"data,omitempty"`
 	Clone                           *CloneFromApplyConfiguration `json:"clone,omitempty"`
 	CloneList                       *CloneListApplyConfiguration `json:"cloneList,omitempty"`
@@ -89,6 +91,14 @@ func (b *GeneratePatternApplyConfiguration) ensureResourceSpecApplyConfiguration
 	}
 }
 
+// WithNamespaceSelector sets the NamespaceSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NamespaceSelector field is set to the value of the last call.
+func (b *GeneratePatternApplyConfiguration) WithNamespaceSelector(value v1.LabelSelector) *GeneratePatternApplyConfiguration {
+	b.NamespaceSelector = &value
+	return b
+}
+
 // WithRawData sets the RawData field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the RawData field is set to the value of the last call.
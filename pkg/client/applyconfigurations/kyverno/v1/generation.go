@@ -20,15 +20,17 @@ package v1
 
 import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
 )
 
 // GenerationApplyConfiguration represents an declarative configuration of the Generation type for use
 // with apply.
 type GenerationApplyConfiguration struct {
-	GenerateExisting                   *bool `json:"generateExisting,omitempty"`
-	Synchronize                        *bool `json:"synchronize,omitempty"`
-	OrphanDownstreamOnPolicyDelete     *bool `json:"orphanDownstreamOnPolicyDelete,omitempty"`
+	GenerateExisting                   *bool                            `json:"generateExisting,omitempty"`
+	Synchronize                        *bool                            `json:"synchronize,omitempty"`
+	OrphanDownstreamOnPolicyDelete     *bool                            `json:"orphanDownstreamOnPolicyDelete,omitempty"`
+	Orphan                             *OrphanOptionsApplyConfiguration `json:"orphan,omitempty"`
 	*GeneratePatternApplyConfiguration `json:"GeneratePattern,omitempty"`
 	ForEachGeneration                  []ForEachGenerationApplyConfiguration `json:"foreach,omitempty"`
 }
@@ -63,6 +65,14 @@ func (b *GenerationApplyConfiguration) WithOrphanDownstreamOnPolicyDelete(value
 	return b
 }
 
+// WithOrphan sets the Orphan field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Orphan field is set to the value of the last call.
+func (b *GenerationApplyConfiguration) WithOrphan(value *OrphanOptionsApplyConfiguration) *GenerationApplyConfiguration {
+	b.Orphan = value
+	return b
+}
+
 // WithAPIVersion sets the APIVersion field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the APIVersion field is set to the value of the last call.
@@ -114,6 +124,15 @@ func (b *GenerationApplyConfiguration) ensureResourceSpecApplyConfigurationExist
 	}
 }
 
+// WithNamespaceSelector sets the NamespaceSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NamespaceSelector field is set to the value of the last call.
+func (b *GenerationApplyConfiguration) WithNamespaceSelector(value v1.LabelSelector) *GenerationApplyConfiguration {
+	b.ensureGeneratePatternApplyConfigurationExists()
+	b.NamespaceSelector = &value
+	return b
+}
+
 // WithRawData sets the RawData field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the RawData field is set to the value of the last call.
@@ -24,6 +24,7 @@ type ImageRegistryApplyConfiguration struct {
 	Reference                *string                                     `json:"reference,omitempty"`
 	JMESPath                 *string                                     `json:"jmesPath,omitempty"`
 	ImageRegistryCredentials *ImageRegistryCredentialsApplyConfiguration `json:"imageRegistryCredentials,omitempty"`
+	Referrers                *bool                                       `json:"referrers,omitempty"`
 }
 
 // ImageRegistryApplyConfiguration constructs an declarative configuration of the ImageRegistry type for use with
@@ -55,3 +56,11 @@ func (b *ImageRegistryApplyConfiguration) WithImageRegistryCredentials(value *Im
 	b.ImageRegistryCredentials = value
 	return b
 }
+
+// WithReferrers sets the Referrers field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Referrers field is set to the value of the last call.
+func (b *ImageRegistryApplyConfiguration) WithReferrers(value bool) *ImageRegistryApplyConfiguration {
+	b.Referrers = &value
+	return b
+}
@@ -40,6 +40,7 @@ type ImageVerificationApplyConfiguration struct {
 	Annotations              map[string]string                            `json:"annotations,omitempty"`
 	Repository               *string                                      `json:"repository,omitempty"`
 	CosignOCI11              *bool                                        `json:"cosignOCI11,omitempty"`
+	Bundles                  []BundleSourceApplyConfiguration             `json:"bundles,omitempty"`
 	MutateDigest             *bool                                        `json:"mutateDigest,omitempty"`
 	VerifyDigest             *bool                                        `json:"verifyDigest,omitempty"`
 	Validation               *ValidateImageVerificationApplyConfiguration `json:"validate,omitempty"`
@@ -200,6 +201,19 @@ func (b *ImageVerificationApplyConfiguration) WithCosignOCI11(value bool) *Image
 	return b
 }
 
+// WithBundles adds the given value to the Bundles field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Bundles field.
+func (b *ImageVerificationApplyConfiguration) WithBundles(values ...*BundleSourceApplyConfiguration) *ImageVerificationApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithBundles")
+		}
+		b.Bundles = append(b.Bundles, *values[i])
+	}
+	return b
+}
+
 // WithMutateDigest sets the MutateDigest field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the MutateDigest field is set to the value of the last call.
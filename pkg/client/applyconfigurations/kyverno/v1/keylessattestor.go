@@ -21,14 +21,15 @@ package v1
 // KeylessAttestorApplyConfiguration represents an declarative configuration of the KeylessAttestor type for use
 // with apply.
 type KeylessAttestorApplyConfiguration struct {
-	Rekor                *RekorApplyConfiguration `json:"rekor,omitempty"`
-	CTLog                *CTLogApplyConfiguration `json:"ctlog,omitempty"`
-	Issuer               *string                  `json:"issuer,omitempty"`
-	IssuerRegExp         *string                  `json:"issuerRegExp,omitempty"`
-	Subject              *string                  `json:"subject,omitempty"`
-	SubjectRegExp        *string                  `json:"subjectRegExp,omitempty"`
-	Roots                *string                  `json:"roots,omitempty"`
-	AdditionalExtensions map[string]string        `json:"additionalExtensions,omitempty"`
+	Rekor                *RekorApplyConfiguration           `json:"rekor,omitempty"`
+	CTLog                *CTLogApplyConfiguration           `json:"ctlog,omitempty"`
+	Issuer               *string                            `json:"issuer,omitempty"`
+	IssuerRegExp         *string                            `json:"issuerRegExp,omitempty"`
+	Subject              *string                            `json:"subject,omitempty"`
+	SubjectRegExp        *string                            `json:"subjectRegExp,omitempty"`
+	Roots                *string                            `json:"roots,omitempty"`
+	AdditionalExtensions map[string]string                  `json:"additionalExtensions,omitempty"`
+	Secret               *SecretReferenceApplyConfiguration `json:"secret,omitempty"`
 }
 
 // KeylessAttestorApplyConfiguration constructs an declarative configuration of the KeylessAttestor type for use with
@@ -106,3 +107,11 @@ func (b *KeylessAttestorApplyConfiguration) WithAdditionalExtensions(entries map
 	}
 	return b
 }
+
+// WithSecret sets the Secret field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Secret field is set to the value of the last call.
+func (b *KeylessAttestorApplyConfiguration) WithSecret(value *SecretReferenceApplyConfiguration) *KeylessAttestorApplyConfiguration {
+	b.Secret = value
+	return b
+}
@@ -26,6 +26,7 @@ import (
 // with apply.
 type MutationApplyConfiguration struct {
 	MutateExistingOnPolicyUpdate *bool                                  `json:"mutateExistingOnPolicyUpdate,omitempty"`
+	ServerSideApply              *bool                                  `json:"serverSideApply,omitempty"`
 	Targets                      []TargetResourceSpecApplyConfiguration `json:"targets,omitempty"`
 	RawPatchStrategicMerge       *apiextensionsv1.JSON                  `json:"patchStrategicMerge,omitempty"`
 	PatchesJSON6902              *string                                `json:"patchesJson6902,omitempty"`
@@ -46,6 +47,14 @@ func (b *MutationApplyConfiguration) WithMutateExistingOnPolicyUpdate(value bool
 	return b
 }
 
+// WithServerSideApply sets the ServerSideApply field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServerSideApply field is set to the value of the last call.
+func (b *MutationApplyConfiguration) WithServerSideApply(value bool) *MutationApplyConfiguration {
+	b.ServerSideApply = &value
+	return b
+}
+
 // WithTargets adds the given value to the Targets field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Targets field.
@@ -30,6 +30,7 @@ type PolicyStatusApplyConfiguration struct {
 	Autogen                   *AutogenStatusApplyConfiguration                   `json:"autogen,omitempty"`
 	RuleCount                 *RuleCountStatusApplyConfiguration                 `json:"rulecount,omitempty"`
 	ValidatingAdmissionPolicy *ValidatingAdmissionPolicyStatusApplyConfiguration `json:"validatingadmissionpolicy,omitempty"`
+	GenerateExisting          *GenerateExistingStatusApplyConfiguration          `json:"generateExisting,omitempty"`
 }
 
 // PolicyStatusApplyConfiguration constructs an declarative configuration of the PolicyStatus type for use with
@@ -79,3 +80,11 @@ func (b *PolicyStatusApplyConfiguration) WithValidatingAdmissionPolicy(value *Va
 	b.ValidatingAdmissionPolicy = value
 	return b
 }
+
+// WithGenerateExisting sets the GenerateExisting field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GenerateExisting field is set to the value of the last call.
+func (b *PolicyStatusApplyConfiguration) WithGenerateExisting(value *GenerateExistingStatusApplyConfiguration) *PolicyStatusApplyConfiguration {
+	b.GenerateExisting = value
+	return b
+}
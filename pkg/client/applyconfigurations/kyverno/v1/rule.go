@@ -28,6 +28,7 @@ import (
 type RuleApplyConfiguration struct {
 	Name                   *string                               `json:"name,omitempty"`
 	Context                []ContextEntryApplyConfiguration      `json:"context,omitempty"`
+	Outputs                []RuleOutputApplyConfiguration        `json:"outputs,omitempty"`
 	ReportProperties       map[string]string                     `json:"reportProperties,omitempty"`
 	MatchResources         *MatchResourcesApplyConfiguration     `json:"match,omitempty"`
 	ExcludeResources       *MatchResourcesApplyConfiguration     `json:"exclude,omitempty"`
@@ -68,6 +69,19 @@ func (b *RuleApplyConfiguration) WithContext(values ...*ContextEntryApplyConfigu
 	return b
 }
 
+// WithOutputs adds the given value to the Outputs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Outputs field.
+func (b *RuleApplyConfiguration) WithOutputs(values ...*RuleOutputApplyConfiguration) *RuleApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithOutputs")
+		}
+		b.Outputs = append(b.Outputs, *values[i])
+	}
+	return b
+}
+
 // WithReportProperties puts the entries into the ReportProperties field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, the entries provided by each call will be put on the ReportProperties field,
@@ -26,6 +26,7 @@ import (
 // with apply.
 type SpecApplyConfiguration struct {
 	Rules                            []RuleApplyConfiguration                            `json:"rules,omitempty"`
+	Variables                        []ContextEntryApplyConfiguration                    `json:"variables,omitempty"`
 	ApplyRules                       *kyvernov1.ApplyRulesType                           `json:"applyRules,omitempty"`
 	FailurePolicy                    *kyvernov1.FailurePolicyType                        `json:"failurePolicy,omitempty"`
 	ValidationFailureAction          *kyvernov1.ValidationFailureAction                  `json:"validationFailureAction,omitempty"`
@@ -39,6 +40,8 @@ type SpecApplyConfiguration struct {
 	GenerateExistingOnPolicyUpdate   *bool                                               `json:"generateExistingOnPolicyUpdate,omitempty"`
 	GenerateExisting                 *bool                                               `json:"generateExisting,omitempty"`
 	UseServerSideApply               *bool                                               `json:"useServerSideApply,omitempty"`
+	DryRun                           *bool                                               `json:"dryRun,omitempty"`
+	WatchCloneSources                *bool                                               `json:"watchCloneSources,omitempty"`
 	WebhookConfiguration             *WebhookConfigurationApplyConfiguration             `json:"webhookConfiguration,omitempty"`
 }
 
@@ -61,6 +64,19 @@ func (b *SpecApplyConfiguration) WithRules(values ...*RuleApplyConfiguration) *S
 	return b
 }
 
+// WithVariables adds the given value to the Variables field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Variables field.
+func (b *SpecApplyConfiguration) WithVariables(values ...*ContextEntryApplyConfiguration) *SpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithVariables")
+		}
+		b.Variables = append(b.Variables, *values[i])
+	}
+	return b
+}
+
 // WithApplyRules sets the ApplyRules field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ApplyRules field is set to the value of the last call.
@@ -170,6 +186,22 @@ func (b *SpecApplyConfiguration) WithUseServerSideApply(value bool) *SpecApplyCo
 	return b
 }
 
+// WithDryRun sets the DryRun field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DryRun field is set to the value of the last call.
+func (b *SpecApplyConfiguration) WithDryRun(value bool) *SpecApplyConfiguration {
+	b.DryRun = &value
+	return b
+}
+
+// WithWatchCloneSources sets the WatchCloneSources field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the WatchCloneSources field is set to the value of the last call.
+func (b *SpecApplyConfiguration) WithWatchCloneSources(value bool) *SpecApplyConfiguration {
+	b.WatchCloneSources = &value
+	return b
+}
+
 // WithWebhookConfiguration sets the WebhookConfiguration field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the WebhookConfiguration field is set to the value of the last call.
@@ -21,16 +21,22 @@ package v2
 import (
 	v1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v1"
 	v2beta1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v2beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // CleanupPolicySpecApplyConfiguration represents an declarative configuration of the CleanupPolicySpec type for use
 // with apply.
 type CleanupPolicySpecApplyConfiguration struct {
-	Context          []v1.ContextEntryApplyConfiguration       `json:"context,omitempty"`
-	MatchResources   *v2beta1.MatchResourcesApplyConfiguration `json:"match,omitempty"`
-	ExcludeResources *v2beta1.MatchResourcesApplyConfiguration `json:"exclude,omitempty"`
-	Schedule         *string                                   `json:"schedule,omitempty"`
-	Conditions       *AnyAllConditionsApplyConfiguration       `json:"conditions,omitempty"`
+	Context                   []v1.ContextEntryApplyConfiguration       `json:"context,omitempty"`
+	MatchResources            *v2beta1.MatchResourcesApplyConfiguration `json:"match,omitempty"`
+	ExcludeResources          *v2beta1.MatchResourcesApplyConfiguration `json:"exclude,omitempty"`
+	Schedule                  *string                                   `json:"schedule,omitempty"`
+	TimeZone                  *string                                   `json:"timeZone,omitempty"`
+	DryRun                    *bool                                     `json:"dryRun,omitempty"`
+	MaxDeletions              *int                                      `json:"maxDeletions,omitempty"`
+	DeletionDelay             *metav1.Duration                          `json:"deletionDelay,omitempty"`
+	DeletionPropagationPolicy *metav1.DeletionPropagation               `json:"deletionPropagationPolicy,omitempty"`
+	Conditions                *AnyAllConditionsApplyConfiguration       `json:"conditions,omitempty"`
 }
 
 // CleanupPolicySpecApplyConfiguration constructs an declarative configuration of the CleanupPolicySpec type for use with
@@ -76,6 +82,46 @@ func (b *CleanupPolicySpecApplyConfiguration) WithSchedule(value string) *Cleanu
 	return b
 }
 
+// WithTimeZone sets the TimeZone field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TimeZone field is set to the value of the last call.
+func (b *CleanupPolicySpecApplyConfiguration) WithTimeZone(value string) *CleanupPolicySpecApplyConfiguration {
+	b.TimeZone = &value
+	return b
+}
+
+// WithDryRun sets the DryRun field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DryRun field is set to the value of the last call.
+func (b *CleanupPolicySpecApplyConfiguration) WithDryRun(value bool) *CleanupPolicySpecApplyConfiguration {
+	b.DryRun = &value
+	return b
+}
+
+// WithMaxDeletions sets the MaxDeletions field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxDeletions field is set to the value of the last call.
+func (b *CleanupPolicySpecApplyConfiguration) WithMaxDeletions(value int) *CleanupPolicySpecApplyConfiguration {
+	b.MaxDeletions = &value
+	return b
+}
+
+// WithDeletionDelay sets the DeletionDelay field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeletionDelay field is set to the value of the last call.
+func (b *CleanupPolicySpecApplyConfiguration) WithDeletionDelay(value metav1.Duration) *CleanupPolicySpecApplyConfiguration {
+	b.DeletionDelay = &value
+	return b
+}
+
+// WithDeletionPropagationPolicy sets the DeletionPropagationPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeletionPropagationPolicy field is set to the value of the last call.
+func (b *CleanupPolicySpecApplyConfiguration) WithDeletionPropagationPolicy(value metav1.DeletionPropagation) *CleanupPolicySpecApplyConfiguration {
+	b.DeletionPropagationPolicy = &value
+	return b
+}
+
 // WithConditions sets the Conditions field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Conditions field is set to the value of the last call.
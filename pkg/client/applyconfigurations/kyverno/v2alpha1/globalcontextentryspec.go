@@ -23,6 +23,8 @@ package v2alpha1
 type GlobalContextEntrySpecApplyConfiguration struct {
 	KubernetesResource *KubernetesResourceApplyConfiguration `json:"kubernetesResource,omitempty"`
 	APICall            *ExternalAPICallApplyConfiguration    `json:"apiCall,omitempty"`
+	HTTPCall           *HTTPCallApplyConfiguration           `json:"httpCall,omitempty"`
+	Projection         *string                               `json:"projection,omitempty"`
 }
 
 // GlobalContextEntrySpecApplyConfiguration constructs an declarative configuration of the GlobalContextEntrySpec type for use with
@@ -46,3 +48,19 @@ func (b *GlobalContextEntrySpecApplyConfiguration) WithAPICall(value *ExternalAP
 	b.APICall = value
 	return b
 }
+
+// WithHTTPCall sets the HTTPCall field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HTTPCall field is set to the value of the last call.
+func (b *GlobalContextEntrySpecApplyConfiguration) WithHTTPCall(value *HTTPCallApplyConfiguration) *GlobalContextEntrySpecApplyConfiguration {
+	b.HTTPCall = value
+	return b
+}
+
+// WithProjection sets the Projection field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Projection field is set to the value of the last call.
+func (b *GlobalContextEntrySpecApplyConfiguration) WithProjection(value string) *GlobalContextEntrySpecApplyConfiguration {
+	b.Projection = &value
+	return b
+}
@@ -25,9 +25,13 @@ import (
 // GlobalContextEntryStatusApplyConfiguration represents an declarative configuration of the GlobalContextEntryStatus type for use
 // with apply.
 type GlobalContextEntryStatusApplyConfiguration struct {
-	Ready           *bool          `json:"ready,omitempty"`
-	Conditions      []v1.Condition `json:"conditions,omitempty"`
-	LastRefreshTime *v1.Time       `json:"lastRefreshTime,omitempty"`
+	Ready               *bool          `json:"ready,omitempty"`
+	Conditions          []v1.Condition `json:"conditions,omitempty"`
+	LastRefreshTime     *v1.Time       `json:"lastRefreshTime,omitempty"`
+	ItemCount           *int           `json:"itemCount,omitempty"`
+	Size                *int64         `json:"size,omitempty"`
+	LastRefreshDuration *v1.Duration   `json:"lastRefreshDuration,omitempty"`
+	RefreshErrorCount   *int           `json:"refreshErrorCount,omitempty"`
 }
 
 // GlobalContextEntryStatusApplyConfiguration constructs an declarative configuration of the GlobalContextEntryStatus type for use with
@@ -61,3 +65,35 @@ func (b *GlobalContextEntryStatusApplyConfiguration) WithLastRefreshTime(value v
 	b.LastRefreshTime = &value
 	return b
 }
+
+// WithItemCount sets the ItemCount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ItemCount field is set to the value of the last call.
+func (b *GlobalContextEntryStatusApplyConfiguration) WithItemCount(value int) *GlobalContextEntryStatusApplyConfiguration {
+	b.ItemCount = &value
+	return b
+}
+
+// WithSize sets the Size field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Size field is set to the value of the last call.
+func (b *GlobalContextEntryStatusApplyConfiguration) WithSize(value int64) *GlobalContextEntryStatusApplyConfiguration {
+	b.Size = &value
+	return b
+}
+
+// WithLastRefreshDuration sets the LastRefreshDuration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastRefreshDuration field is set to the value of the last call.
+func (b *GlobalContextEntryStatusApplyConfiguration) WithLastRefreshDuration(value v1.Duration) *GlobalContextEntryStatusApplyConfiguration {
+	b.LastRefreshDuration = &value
+	return b
+}
+
+// WithRefreshErrorCount sets the RefreshErrorCount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RefreshErrorCount field is set to the value of the last call.
+func (b *GlobalContextEntryStatusApplyConfiguration) WithRefreshErrorCount(value int) *GlobalContextEntryStatusApplyConfiguration {
+	b.RefreshErrorCount = &value
+	return b
+}
@@ -18,13 +18,19 @@ limitations under the License.
 
 package v2alpha1
 
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // KubernetesResourceApplyConfiguration represents an declarative configuration of the KubernetesResource type for use
 // with apply.
 type KubernetesResourceApplyConfiguration struct {
-	Group     *string `json:"group,omitempty"`
-	Version   *string `json:"version,omitempty"`
-	Resource  *string `json:"resource,omitempty"`
-	Namespace *string `json:"namespace,omitempty"`
+	Group        *string      `json:"group,omitempty"`
+	Version      *string      `json:"version,omitempty"`
+	Resource     *string      `json:"resource,omitempty"`
+	Namespace    *string      `json:"namespace,omitempty"`
+	MaxItems     *int         `json:"maxItems,omitempty"`
+	ResyncPeriod *v1.Duration `json:"resyncPeriod,omitempty"`
 }
 
 // KubernetesResourceApplyConfiguration constructs an declarative configuration of the KubernetesResource type for use with
@@ -64,3 +70,19 @@ func (b *KubernetesResourceApplyConfiguration) WithNamespace(value string) *Kube
 	b.Namespace = &value
 	return b
 }
+
+// WithMaxItems sets the MaxItems field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxItems field is set to the value of the last call.
+func (b *KubernetesResourceApplyConfiguration) WithMaxItems(value int) *KubernetesResourceApplyConfiguration {
+	b.MaxItems = &value
+	return b
+}
+
+// WithResyncPeriod sets the ResyncPeriod field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ResyncPeriod field is set to the value of the last call.
+func (b *KubernetesResourceApplyConfiguration) WithResyncPeriod(value v1.Duration) *KubernetesResourceApplyConfiguration {
+	b.ResyncPeriod = &value
+	return b
+}
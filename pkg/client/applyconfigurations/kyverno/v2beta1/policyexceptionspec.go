@@ -20,16 +20,19 @@ package v2beta1
 
 import (
 	v1 "github.com/kyverno/kyverno/pkg/client/applyconfigurations/kyverno/v1"
+	v1beta1 "k8s.io/api/admissionregistration/v1beta1"
 )
 
 // PolicyExceptionSpecApplyConfiguration represents an declarative configuration of the PolicyExceptionSpec type for use
 // with apply.
 type PolicyExceptionSpecApplyConfiguration struct {
-	Background  *bool                                      `json:"background,omitempty"`
-	Match       *MatchResourcesApplyConfiguration          `json:"match,omitempty"`
-	Conditions  *AnyAllConditionsApplyConfiguration        `json:"conditions,omitempty"`
-	Exceptions  []ExceptionApplyConfiguration              `json:"exceptions,omitempty"`
-	PodSecurity []v1.PodSecurityStandardApplyConfiguration `json:"podSecurity,omitempty"`
+	Background    *bool                                      `json:"background,omitempty"`
+	Match         *MatchResourcesApplyConfiguration          `json:"match,omitempty"`
+	Conditions    *AnyAllConditionsApplyConfiguration        `json:"conditions,omitempty"`
+	CELConditions []v1beta1.MatchCondition                   `json:"celConditions,omitempty"`
+	Exceptions    []ExceptionApplyConfiguration              `json:"exceptions,omitempty"`
+	PodSecurity   []v1.PodSecurityStandardApplyConfiguration `json:"podSecurity,omitempty"`
+	Images        []string                                   `json:"images,omitempty"`
 }
 
 // PolicyExceptionSpecApplyConfiguration constructs an declarative configuration of the PolicyExceptionSpec type for use with
@@ -62,6 +65,16 @@ func (b *PolicyExceptionSpecApplyConfiguration) WithConditions(value *AnyAllCond
 	return b
 }
 
+// WithCELConditions adds the given value to the CELConditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the CELConditions field.
+func (b *PolicyExceptionSpecApplyConfiguration) WithCELConditions(values ...v1beta1.MatchCondition) *PolicyExceptionSpecApplyConfiguration {
+	for i := range values {
+		b.CELConditions = append(b.CELConditions, values[i])
+	}
+	return b
+}
+
 // WithExceptions adds the given value to the Exceptions field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Exceptions field.
@@ -87,3 +100,13 @@ func (b *PolicyExceptionSpecApplyConfiguration) WithPodSecurity(values ...*v1.Po
 	}
 	return b
 }
+
+// WithImages adds the given value to the Images field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Images field.
+func (b *PolicyExceptionSpecApplyConfiguration) WithImages(values ...string) *PolicyExceptionSpecApplyConfiguration {
+	for i := range values {
+		b.Images = append(b.Images, values[i])
+	}
+	return b
+}
@@ -39,6 +39,7 @@ type ValidationApplyConfiguration struct {
 	PodSecurity            *kyvernov1.PodSecurityApplyConfiguration                      `json:"podSecurity,omitempty"`
 	CEL                    *kyvernov1.CELApplyConfiguration                              `json:"cel,omitempty"`
 	Assert                 *v1alpha1.Any                                                 `json:"assert,omitempty"`
+	Wasm                   *kyvernov1.WasmApplyConfiguration                             `json:"wasm,omitempty"`
 }
 
 // ValidationApplyConfiguration constructs an declarative configuration of the Validation type for use with
@@ -144,3 +145,11 @@ func (b *ValidationApplyConfiguration) WithAssert(value v1alpha1.Any) *Validatio
 	b.Assert = &value
 	return b
 }
+
+// WithWasm sets the Wasm field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Wasm field is set to the value of the last call.
+func (b *ValidationApplyConfiguration) WithWasm(value *kyvernov1.WasmApplyConfiguration) *ValidationApplyConfiguration {
+	b.Wasm = value
+	return b
+}
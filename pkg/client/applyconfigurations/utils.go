@@ -53,6 +53,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.AttestorSetApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("AutogenStatus"):
 		return &kyvernov1.AutogenStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("BundleSource"):
+		return &kyvernov1.BundleSourceApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("CEL"):
 		return &kyvernov1.CELApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("CertificateAttestor"):
@@ -61,6 +63,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.CloneFromApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("CloneList"):
 		return &kyvernov1.CloneListApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("CloneTransform"):
+		return &kyvernov1.CloneTransformApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ClusterPolicy"):
 		return &kyvernov1.ClusterPolicyApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Condition"):
@@ -77,12 +81,18 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.DenyApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("DryRunOption"):
 		return &kyvernov1.DryRunOptionApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ExternalData"):
+		return &kyvernov1.ExternalDataApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ForEachGeneration"):
 		return &kyvernov1.ForEachGenerationApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ForEachMutation"):
 		return &kyvernov1.ForEachMutationApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ForEachValidation"):
 		return &kyvernov1.ForEachValidationApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("GRPCCall"):
+		return &kyvernov1.GRPCCallApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("GenerateExistingStatus"):
+		return &kyvernov1.GenerateExistingStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("GeneratePattern"):
 		return &kyvernov1.GeneratePatternApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Generation"):
@@ -107,8 +117,12 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.MatchResourcesApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Mutation"):
 		return &kyvernov1.MutationApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("NotaryAttestor"):
+		return &kyvernov1.NotaryAttestorApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ObjectFieldBinding"):
 		return &kyvernov1.ObjectFieldBindingApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OrphanOptions"):
+		return &kyvernov1.OrphanOptionsApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("PodSecurity"):
 		return &kyvernov1.PodSecurityApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("PodSecurityStandard"):
@@ -131,10 +145,16 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.RuleApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RuleCountStatus"):
 		return &kyvernov1.RuleCountStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RuleOutput"):
+		return &kyvernov1.RuleOutputApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("SecretReference"):
 		return &kyvernov1.SecretReferenceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("SecretStore"):
+		return &kyvernov1.SecretStoreApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ServiceCall"):
 		return &kyvernov1.ServiceCallApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ServiceCallAuth"):
+		return &kyvernov1.ServiceCallAuthApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Spec"):
 		return &kyvernov1.SpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("StaticKeyAttestor"):
@@ -155,6 +175,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov1.ValidationFailureActionOverrideApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("Variable"):
 		return &kyvernov1.VariableApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("VaultSecret"):
+		return &kyvernov1.VaultSecretApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("Wasm"):
+		return &kyvernov1.WasmApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("WebhookConfiguration"):
 		return &kyvernov1.WebhookConfigurationApplyConfiguration{}
 
@@ -215,8 +239,14 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kyvernov2alpha1.GlobalContextEntrySpecApplyConfiguration{}
 	case v2alpha1.SchemeGroupVersion.WithKind("GlobalContextEntryStatus"):
 		return &kyvernov2alpha1.GlobalContextEntryStatusApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("HTTPCall"):
+		return &kyvernov2alpha1.HTTPCallApplyConfiguration{}
 	case v2alpha1.SchemeGroupVersion.WithKind("KubernetesResource"):
 		return &kyvernov2alpha1.KubernetesResourceApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("Provider"):
+		return &kyvernov2alpha1.ProviderApplyConfiguration{}
+	case v2alpha1.SchemeGroupVersion.WithKind("ProviderSpec"):
+		return &kyvernov2alpha1.ProviderSpecApplyConfiguration{}
 
 		// Group=kyverno.io, Version=v2beta1
 	case v2beta1.SchemeGroupVersion.WithKind("AnyAllConditions"):
@@ -261,6 +291,14 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationsreportsv1.EphemeralReportApplyConfiguration{}
 	case reportsv1.SchemeGroupVersion.WithKind("EphemeralReportSpec"):
 		return &applyconfigurationsreportsv1.EphemeralReportSpecApplyConfiguration{}
+	case reportsv1.SchemeGroupVersion.WithKind("PolicySummary"):
+		return &applyconfigurationsreportsv1.PolicySummaryApplyConfiguration{}
+	case reportsv1.SchemeGroupVersion.WithKind("ReportSummary"):
+		return &applyconfigurationsreportsv1.ReportSummaryApplyConfiguration{}
+	case reportsv1.SchemeGroupVersion.WithKind("ReportSummarySpec"):
+		return &applyconfigurationsreportsv1.ReportSummarySpecApplyConfiguration{}
+	case reportsv1.SchemeGroupVersion.WithKind("SeveritySummary"):
+		return &applyconfigurationsreportsv1.SeveritySummaryApplyConfiguration{}
 
 		// Group=wgpolicyk8s.io, Version=v1alpha2
 	case v1alpha2.SchemeGroupVersion.WithKind("ClusterPolicyReport"):
@@ -29,6 +29,7 @@ import (
 type KyvernoV2alpha1Interface interface {
 	RESTClient() rest.Interface
 	GlobalContextEntriesGetter
+	ProvidersGetter
 }
 
 // KyvernoV2alpha1Client is used to interact with features provided by the kyverno.io group.
@@ -40,6 +41,10 @@ func (c *KyvernoV2alpha1Client) GlobalContextEntries() GlobalContextEntryInterfa
 	return newGlobalContextEntries(c)
 }
 
+func (c *KyvernoV2alpha1Client) Providers() ProviderInterface {
+	return newProviders(c)
+}
+
 // NewForConfig creates a new KyvernoV2alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
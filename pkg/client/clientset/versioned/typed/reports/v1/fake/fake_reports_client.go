@@ -36,6 +36,10 @@ func (c *FakeReportsV1) EphemeralReports(namespace string) v1.EphemeralReportInt
 	return &FakeEphemeralReports{c, namespace}
 }
 
+func (c *FakeReportsV1) ReportSummaries(namespace string) v1.ReportSummaryInterface {
+	return &FakeReportSummaries{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeReportsV1) RESTClient() rest.Interface {
@@ -21,3 +21,5 @@ package v1
 type ClusterEphemeralReportExpansion interface{}
 
 type EphemeralReportExpansion interface{}
+
+type ReportSummaryExpansion interface{}
@@ -30,6 +30,7 @@ type ReportsV1Interface interface {
 	RESTClient() rest.Interface
 	ClusterEphemeralReportsGetter
 	EphemeralReportsGetter
+	ReportSummariesGetter
 }
 
 // ReportsV1Client is used to interact with features provided by the reports.kyverno.io group.
@@ -45,6 +46,10 @@ func (c *ReportsV1Client) EphemeralReports(namespace string) EphemeralReportInte
 	return newEphemeralReports(c, namespace)
 }
 
+func (c *ReportsV1Client) ReportSummaries(namespace string) ReportSummaryInterface {
+	return newReportSummaries(c, namespace)
+}
+
 // NewForConfig creates a new ReportsV1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
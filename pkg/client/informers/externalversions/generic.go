@@ -75,12 +75,16 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		// Group=kyverno.io, Version=v2alpha1
 	case v2alpha1.SchemeGroupVersion.WithResource("globalcontextentries"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().GlobalContextEntries().Informer()}, nil
+	case v2alpha1.SchemeGroupVersion.WithResource("providers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kyverno().V2alpha1().Providers().Informer()}, nil
 
 		// Group=reports.kyverno.io, Version=v1
 	case reportsv1.SchemeGroupVersion.WithResource("clusterephemeralreports"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Reports().V1().ClusterEphemeralReports().Informer()}, nil
 	case reportsv1.SchemeGroupVersion.WithResource("ephemeralreports"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Reports().V1().EphemeralReports().Informer()}, nil
+	case reportsv1.SchemeGroupVersion.WithResource("reportsummaries"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Reports().V1().ReportSummaries().Informer()}, nil
 
 		// Group=wgpolicyk8s.io, Version=v1alpha2
 	case v1alpha2.SchemeGroupVersion.WithResource("clusterpolicyreports"):
@@ -26,6 +26,8 @@ import (
 type Interface interface {
 	// GlobalContextEntries returns a GlobalContextEntryInformer.
 	GlobalContextEntries() GlobalContextEntryInformer
+	// Providers returns a ProviderInformer.
+	Providers() ProviderInformer
 }
 
 type version struct {
@@ -43,3 +45,8 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 func (v *version) GlobalContextEntries() GlobalContextEntryInformer {
 	return &globalContextEntryInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
+
+// Providers returns a ProviderInformer.
+func (v *version) Providers() ProviderInformer {
+	return &providerInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
@@ -28,6 +28,8 @@ type Interface interface {
 	ClusterEphemeralReports() ClusterEphemeralReportInformer
 	// EphemeralReports returns a EphemeralReportInformer.
 	EphemeralReports() EphemeralReportInformer
+	// ReportSummaries returns a ReportSummaryInformer.
+	ReportSummaries() ReportSummaryInformer
 }
 
 type version struct {
@@ -50,3 +52,8 @@ func (v *version) ClusterEphemeralReports() ClusterEphemeralReportInformer {
 func (v *version) EphemeralReports() EphemeralReportInformer {
 	return &ephemeralReportInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// ReportSummaries returns a ReportSummaryInformer.
+func (v *version) ReportSummaries() ReportSummaryInformer {
+	return &reportSummaryInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
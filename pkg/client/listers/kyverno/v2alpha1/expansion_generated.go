@@ -21,3 +21,7 @@ package v2alpha1
 // GlobalContextEntryListerExpansion allows custom methods to be added to
 // GlobalContextEntryLister.
 type GlobalContextEntryListerExpansion interface{}
+
+// ProviderListerExpansion allows custom methods to be added to
+// ProviderLister.
+type ProviderListerExpansion interface{}
@@ -29,3 +29,11 @@ type EphemeralReportListerExpansion interface{}
 // EphemeralReportNamespaceListerExpansion allows custom methods to be added to
 // EphemeralReportNamespaceLister.
 type EphemeralReportNamespaceListerExpansion interface{}
+
+// ReportSummaryListerExpansion allows custom methods to be added to
+// ReportSummaryLister.
+type ReportSummaryListerExpansion interface{}
+
+// ReportSummaryNamespaceListerExpansion allows custom methods to be added to
+// ReportSummaryNamespaceLister.
+type ReportSummaryNamespaceListerExpansion interface{}
@@ -5,6 +5,7 @@ import (
 	github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_reports_v1 "github.com/kyverno/kyverno/pkg/client/clientset/versioned/typed/reports/v1"
 	clusterephemeralreports "github.com/kyverno/kyverno/pkg/clients/kyverno/reportsv1/clusterephemeralreports"
 	ephemeralreports "github.com/kyverno/kyverno/pkg/clients/kyverno/reportsv1/ephemeralreports"
+	reportsummaries "github.com/kyverno/kyverno/pkg/clients/kyverno/reportsv1/reportsummaries"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"k8s.io/client-go/rest"
 )
@@ -38,6 +39,10 @@ func (c *withMetrics) EphemeralReports(namespace string) github_com_kyverno_kyve
 	recorder := metrics.NamespacedClientQueryRecorder(c.metrics, namespace, "EphemeralReport", c.clientType)
 	return ephemeralreports.WithMetrics(c.inner.EphemeralReports(namespace), recorder)
 }
+func (c *withMetrics) ReportSummaries(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_reports_v1.ReportSummaryInterface {
+	recorder := metrics.NamespacedClientQueryRecorder(c.metrics, namespace, "ReportSummary", c.clientType)
+	return reportsummaries.WithMetrics(c.inner.ReportSummaries(namespace), recorder)
+}
 
 type withTracing struct {
 	inner  github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_reports_v1.ReportsV1Interface
@@ -53,6 +58,9 @@ func (c *withTracing) ClusterEphemeralReports() github_com_kyverno_kyverno_pkg_c
 func (c *withTracing) EphemeralReports(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_reports_v1.EphemeralReportInterface {
 	return ephemeralreports.WithTracing(c.inner.EphemeralReports(namespace), c.client, "EphemeralReport")
 }
+func (c *withTracing) ReportSummaries(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_reports_v1.ReportSummaryInterface {
+	return reportsummaries.WithTracing(c.inner.ReportSummaries(namespace), c.client, "ReportSummary")
+}
 
 type withLogging struct {
 	inner  github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_reports_v1.ReportsV1Interface
@@ -68,3 +76,6 @@ func (c *withLogging) ClusterEphemeralReports() github_com_kyverno_kyverno_pkg_c
 func (c *withLogging) EphemeralReports(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_reports_v1.EphemeralReportInterface {
 	return ephemeralreports.WithLogging(c.inner.EphemeralReports(namespace), c.logger.WithValues("resource", "EphemeralReports").WithValues("namespace", namespace))
 }
+func (c *withLogging) ReportSummaries(namespace string) github_com_kyverno_kyverno_pkg_client_clientset_versioned_typed_reports_v1.ReportSummaryInterface {
+	return reportsummaries.WithLogging(c.inner.ReportSummaries(namespace), c.logger.WithValues("resource", "ReportSummaries").WithValues("namespace", namespace))
+}
@@ -3,10 +3,13 @@ package config
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 	"sync"
 
 	valid "github.com/asaskevich/govalidator"
+	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/ext/wildcard"
 	osutils "github.com/kyverno/kyverno/pkg/utils/os"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
@@ -26,6 +29,8 @@ const (
 	ExceptionValidatingWebhookConfigurationName = "kyverno-exception-validating-webhook-cfg"
 	// GlobalContextValidatingWebhookConfigurationName ...
 	GlobalContextValidatingWebhookConfigurationName = "kyverno-global-context-validating-webhook-cfg"
+	// ConfigValidatingWebhookConfigurationName ...
+	ConfigValidatingWebhookConfigurationName = "kyverno-config-validating-webhook-cfg"
 	// CleanupValidatingWebhookConfigurationName ...
 	CleanupValidatingWebhookConfigurationName = "kyverno-cleanup-validating-webhook-cfg"
 	// PolicyMutatingWebhookConfigurationName default policy mutating webhook configuration name
@@ -62,6 +67,8 @@ const (
 	ExceptionValidatingWebhookServicePath = "/exceptionvalidate"
 	// GlobalContextValidatingWebhookServicePath is the path for global context validation webhook(used to validate global context entries)
 	GlobalContextValidatingWebhookServicePath = "/globalcontextvalidate"
+	// ConfigValidatingWebhookServicePath is the path for config validation webhook(used to validate the kyverno config map)
+	ConfigValidatingWebhookServicePath = "/configvalidate"
 	// CleanupValidatingWebhookServicePath is the path for cleanup policy validation webhook(used to validate cleanup policy resource)
 	CleanupValidatingWebhookServicePath = "/validate"
 	// TtlValidatingWebhookServicePath is the path for validation of cleanup.kyverno.io/ttl label value
@@ -72,6 +79,12 @@ const (
 	MutatingWebhookServicePath = "/mutate"
 	// VerifyMutatingWebhookServicePath is the path for verify webhook(used to veryfing if admission control is enabled and active)
 	VerifyMutatingWebhookServicePath = "/verifymutate"
+	// AuditShardServicePath is the path for audit work forwarded by other replicas
+	AuditShardServicePath = "/auditshard"
+	// EvaluateServicePath is the path for side effect free evaluation of resources against the policies
+	EvaluateServicePath = "/evaluate"
+	// AuditEventsServicePath is the path for the audit events posted by the API server audit webhook backend
+	AuditEventsServicePath = "/auditevents"
 	// LivenessServicePath is the path for check liveness health
 	LivenessServicePath = "/health/liveness"
 	// ReadinessServicePath is the path for check readness health
@@ -85,6 +98,7 @@ const (
 // keys in config map
 const (
 	resourceFilters               = "resourceFilters"
+	resourceExclusions            = "resourceExclusions"
 	defaultRegistry               = "defaultRegistry"
 	enableDefaultRegistryMutation = "enableDefaultRegistryMutation"
 	excludeGroups                 = "excludeGroups"
@@ -97,6 +111,23 @@ const (
 	webhookLabels                 = "webhookLabels"
 	matchConditions               = "matchConditions"
 	updateRequestThreshold        = "updateRequestThreshold"
+	maxForeachIterations          = "maxForeachIterations"
+	maxContextSize                = "maxContextSize"
+	maxJMESPathDepth              = "maxJMESPathDepth"
+	maxJMESPathResultSize         = "maxJMESPathResultSize"
+	vaultServers                  = "vaultServers"
+	secretNamespaces              = "secretNamespaces"
+	skipPoliciesAnnotation        = "skipPoliciesAnnotation"
+	skipPoliciesUsernames         = "skipPoliciesUsernames"
+	skipPoliciesGroups            = "skipPoliciesGroups"
+	severityOverrides             = "severityOverrides"
+	globalContextAccess           = "globalContextAccess"
+	webhookTimeout                = "webhookTimeout"
+	omitEvents                    = "omitEvents"
+	dumpPayload                   = "dumpPayload"
+	maxAuditWorkers               = "maxAuditWorkers"
+	maxAuditCapacity              = "maxAuditCapacity"
+	maxAdmissionReports           = "maxAdmissionReports"
 )
 
 const UpdateRequestThreshold = 1000
@@ -114,6 +145,8 @@ var (
 	kyvernoServiceName = osutils.GetEnvWithFallback("KYVERNO_SVC", "kyverno-svc")
 	// kyvernoPodName is the Kyverno pod name
 	kyvernoPodName = osutils.GetEnvWithFallback("KYVERNO_POD_NAME", "kyverno")
+	// kyvernoPodIP is the Kyverno pod ip
+	kyvernoPodIP = osutils.GetEnvWithFallback("KYVERNO_POD_IP", "")
 	// kyvernoConfigMapName is the Kyverno configmap name
 	kyvernoConfigMapName = osutils.GetEnvWithFallback("INIT_CONFIG", "kyverno")
 	// kyvernoMetricsConfigMapName is the Kyverno metrics configmap name
@@ -150,6 +183,10 @@ func KyvernoPodName() string {
 	return kyvernoPodName
 }
 
+func KyvernoPodIP() string {
+	return kyvernoPodIP
+}
+
 func KyvernoConfigMapName() string {
 	return kyvernoConfigMapName
 }
@@ -172,6 +209,8 @@ type Configuration interface {
 	IsExcluded(username string, groups []string, roles []string, clusterroles []string) bool
 	// ToFilter checks if the given resource is set to be filtered in the configuration
 	ToFilter(kind schema.GroupVersionKind, subresource, namespace, name string) bool
+	// IsResourceExcluded checks if the given resource matches one of the resource exclusions in the configuration
+	IsResourceExcluded(request ExclusionRequest) bool
 	// GetGenerateSuccessEvents return if should generate success events
 	GetGenerateSuccessEvents() bool
 	// GetWebhook returns the webhook config
@@ -188,6 +227,43 @@ type Configuration interface {
 	OnChanged(func())
 	// GetUpdateRequestThreshold gets the threshold limit for the total number of updaterequests
 	GetUpdateRequestThreshold() int64
+	// GetMaxForeachIterations returns the maximum number of elements a foreach declaration can iterate over, 0 means unlimited
+	GetMaxForeachIterations() int64
+	// GetMaxContextSize returns the maximum size in bytes of the context entries loaded while processing a request, 0 means unlimited
+	GetMaxContextSize() int64
+	// GetMaxJMESPathDepth returns the maximum depth of a JMESPath expression, 0 means unlimited
+	GetMaxJMESPathDepth() int64
+	// GetMaxJMESPathResultSize returns the maximum size in bytes of the result of a JMESPath expression, 0 means unlimited
+	GetMaxJMESPathResultSize() int64
+	// GetVaultServer returns the Vault server with the given name, secret store entries can only use configured servers
+	GetVaultServer(name string) (VaultServer, bool)
+	// GetSecretNamespaces returns the namespaces cluster policies can reference secrets from, in addition to the Kyverno namespace
+	GetSecretNamespaces() []string
+	// GetSkipPoliciesAnnotation returns the annotation listing the policies to skip at admission time, disabled when empty
+	GetSkipPoliciesAnnotation() string
+	// CanSkipPolicies checks if the user is allowed to skip policies with the skip policies annotation
+	CanSkipPolicies(username string, groups []string) bool
+	// GetSeverityOverride returns the severity overriding the one set by the policy for the report results of a rule
+	GetSeverityOverride(policy, rule string) (string, bool)
+	// CanReferenceGlobalContextEntry checks if a policy is allowed to reference a global context entry, the namespace
+	// is empty for cluster policies and entries not matching any access rule can be referenced by all policies
+	CanReferenceGlobalContextEntry(entry, namespace, policy string) bool
+	// GetWebhookTimeout returns the timeout in seconds of the webhook configurations, it overrides the webhookTimeout flag when set
+	GetWebhookTimeout() (int32, bool)
+	// GetOmitEvents returns the reasons of the events that are not emitted, it overrides the omitEvents flag when set
+	GetOmitEvents() ([]string, bool)
+	// GetDumpPayload returns true if the admission payloads are dumped, it overrides the dumpPayload flag when set
+	GetDumpPayload() (bool, bool)
+	// GetMaxAuditWorkers returns the maximum number of audit workers, it overrides the maxAuditWorkers flag when set
+	GetMaxAuditWorkers() (int, bool)
+	// GetMaxAuditCapacity returns the capacity of the audit queue, it overrides the maxAuditCapacity flag when set
+	GetMaxAuditCapacity() (int, bool)
+	// GetMaxAdmissionReports returns the number of admission reports above which no new one is created, it overrides
+	// the maxAdmissionReports flag when set
+	GetMaxAdmissionReports() (int, bool)
+	// OnSettingsChanged adds a callback to be invoked with the settings overriding flags that changed when the configuration
+	// is reloaded, unlike OnChanged callbacks it is invoked once the configuration is unlocked and can read it
+	OnSettingsChanged(func([]SettingChange))
 }
 
 // configuration stores the configuration
@@ -198,6 +274,7 @@ type configuration struct {
 	exclusions                    match
 	inclusions                    match
 	filters                       []filter
+	resourceExclusions            []ResourceExclusion
 	generateSuccessEvents         bool
 	webhook                       WebhookConfig
 	webhookAnnotations            map[string]string
@@ -206,6 +283,23 @@ type configuration struct {
 	mux                           sync.RWMutex
 	callbacks                     []func()
 	updateRequestThreshold        int64
+	maxForeachIterations          int64
+	maxContextSize                int64
+	maxJMESPathDepth              int64
+	maxJMESPathResultSize         int64
+	vaultServers                  map[string]VaultServer
+	secretNamespaces              []string
+	skipPoliciesAnnotation        string
+	skipPolicies                  match
+	severityOverrides             []SeverityOverride
+	globalContextAccess           []GlobalContextAccess
+	webhookTimeout                *int32
+	omitEvents                    []string
+	dumpPayload                   *bool
+	maxAuditWorkers               *int
+	maxAuditCapacity              *int
+	maxAdmissionReports           *int
+	settingsCallbacks             []func([]SettingChange)
 }
 
 type match struct {
@@ -264,6 +358,12 @@ func (cd *configuration) OnChanged(callback func()) {
 	cd.callbacks = append(cd.callbacks, callback)
 }
 
+func (cd *configuration) OnSettingsChanged(callback func([]SettingChange)) {
+	cd.mux.Lock()
+	defer cd.mux.Unlock()
+	cd.settingsCallbacks = append(cd.settingsCallbacks, callback)
+}
+
 func (c *configuration) IsExcluded(username string, groups []string, roles []string, clusterroles []string) bool {
 	if c.inclusions.matches(username, groups, roles, clusterroles) {
 		return false
@@ -292,6 +392,17 @@ func (cd *configuration) ToFilter(gvk schema.GroupVersionKind, subresource, name
 	return false
 }
 
+func (cd *configuration) IsResourceExcluded(request ExclusionRequest) bool {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	if cd.skipResourceFilters {
+		return false
+	}
+	return slices.ContainsFunc(cd.resourceExclusions, func(exclusion ResourceExclusion) bool {
+		return exclusion.matches(request)
+	})
+}
+
 func (cd *configuration) GetDefaultRegistry() string {
 	cd.mux.RLock()
 	defer cd.mux.RUnlock()
@@ -340,12 +451,190 @@ func (cd *configuration) GetUpdateRequestThreshold() int64 {
 	return cd.updateRequestThreshold
 }
 
+func (cd *configuration) GetMaxForeachIterations() int64 {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.maxForeachIterations
+}
+
+func (cd *configuration) GetMaxContextSize() int64 {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.maxContextSize
+}
+
+func (cd *configuration) GetMaxJMESPathDepth() int64 {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.maxJMESPathDepth
+}
+
+func (cd *configuration) GetMaxJMESPathResultSize() int64 {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.maxJMESPathResultSize
+}
+
+func (cd *configuration) GetVaultServer(name string) (VaultServer, bool) {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	server, ok := cd.vaultServers[name]
+	return server, ok
+}
+
+func (cd *configuration) GetSecretNamespaces() []string {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.secretNamespaces
+}
+
+func (cd *configuration) GetSkipPoliciesAnnotation() string {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.skipPoliciesAnnotation
+}
+
+func (cd *configuration) CanSkipPolicies(username string, groups []string) bool {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.skipPolicies.matches(username, groups, nil, nil)
+}
+
+func (cd *configuration) GetSeverityOverride(policy, rule string) (string, bool) {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	// the first matching override wins
+	for _, override := range cd.severityOverrides {
+		if wildcard.Match(override.Policy, policy) && (override.Rule == "" || wildcard.Match(override.Rule, rule)) {
+			return override.Severity, true
+		}
+	}
+	return "", false
+}
+
+func (cd *configuration) CanReferenceGlobalContextEntry(entry, namespace, policy string) bool {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	restricted := false
+	for _, access := range cd.globalContextAccess {
+		if !wildcard.Match(access.Entry, entry) {
+			continue
+		}
+		if access.allows(namespace, policy) {
+			return true
+		}
+		restricted = true
+	}
+	return !restricted
+}
+
+func (cd *configuration) GetWebhookTimeout() (int32, bool) {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	if cd.webhookTimeout == nil {
+		return 0, false
+	}
+	return *cd.webhookTimeout, true
+}
+
+func (cd *configuration) GetOmitEvents() ([]string, bool) {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	return cd.omitEvents, cd.omitEvents != nil
+}
+
+func (cd *configuration) GetDumpPayload() (bool, bool) {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	if cd.dumpPayload == nil {
+		return false, false
+	}
+	return *cd.dumpPayload, true
+}
+
+func (cd *configuration) GetMaxAuditWorkers() (int, bool) {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	if cd.maxAuditWorkers == nil {
+		return 0, false
+	}
+	return *cd.maxAuditWorkers, true
+}
+
+func (cd *configuration) GetMaxAuditCapacity() (int, bool) {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	if cd.maxAuditCapacity == nil {
+		return 0, false
+	}
+	return *cd.maxAuditCapacity, true
+}
+
+func (cd *configuration) GetMaxAdmissionReports() (int, bool) {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	if cd.maxAdmissionReports == nil {
+		return 0, false
+	}
+	return *cd.maxAdmissionReports, true
+}
+
 func (cd *configuration) Load(cm *corev1.ConfigMap) {
+	previous := cd.settings()
 	if cm != nil {
 		cd.load(cm)
 	} else {
 		cd.unload()
 	}
+	cd.notifySettings(previous, cd.settings())
+}
+
+// settings returns the values of the settings overriding flags, settings that are not set are omitted
+func (cd *configuration) settings() map[string]string {
+	cd.mux.RLock()
+	defer cd.mux.RUnlock()
+	out := map[string]string{}
+	if cd.webhookTimeout != nil {
+		out[webhookTimeout] = strconv.Itoa(int(*cd.webhookTimeout))
+	}
+	if cd.omitEvents != nil {
+		// an empty list overrides the flag to emit all events
+		out[omitEvents] = strings.Join(cd.omitEvents, ",")
+		if len(cd.omitEvents) == 0 {
+			out[omitEvents] = "none"
+		}
+	}
+	if cd.dumpPayload != nil {
+		out[dumpPayload] = strconv.FormatBool(*cd.dumpPayload)
+	}
+	if cd.maxAuditWorkers != nil {
+		out[maxAuditWorkers] = strconv.Itoa(*cd.maxAuditWorkers)
+	}
+	if cd.maxAuditCapacity != nil {
+		out[maxAuditCapacity] = strconv.Itoa(*cd.maxAuditCapacity)
+	}
+	if cd.maxAdmissionReports != nil {
+		out[maxAdmissionReports] = strconv.Itoa(*cd.maxAdmissionReports)
+	}
+	return out
+}
+
+func (cd *configuration) notifySettings(previous, current map[string]string) {
+	var changes []SettingChange
+	for _, name := range []string{webhookTimeout, omitEvents, dumpPayload, maxAuditWorkers, maxAuditCapacity, maxAdmissionReports} {
+		if previous[name] != current[name] {
+			changes = append(changes, SettingChange{Name: name, Previous: previous[name], Current: current[name]})
+		}
+	}
+	if len(changes) == 0 {
+		return
+	}
+	cd.mux.RLock()
+	callbacks := slices.Clone(cd.settingsCallbacks)
+	cd.mux.RUnlock()
+	for _, callback := range callbacks {
+		callback(changes)
+	}
 }
 
 func (cd *configuration) load(cm *corev1.ConfigMap) {
@@ -363,15 +652,45 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 	cd.exclusions = match{}
 	cd.inclusions = match{}
 	cd.filters = []filter{}
+	cd.resourceExclusions = nil
 	cd.generateSuccessEvents = false
 	cd.webhook = WebhookConfig{}
 	cd.webhookAnnotations = nil
 	cd.webhookLabels = nil
 	cd.matchConditions = nil
+	cd.maxForeachIterations = 0
+	cd.maxContextSize = 0
+	cd.maxJMESPathDepth = 0
+	cd.maxJMESPathResultSize = 0
+	cd.vaultServers = nil
+	cd.secretNamespaces = nil
+	cd.skipPoliciesAnnotation = ""
+	cd.skipPolicies = match{}
+	cd.severityOverrides = nil
+	cd.globalContextAccess = nil
+	cd.webhookTimeout = nil
+	cd.omitEvents = nil
+	cd.dumpPayload = nil
+	cd.maxAuditWorkers = nil
+	cd.maxAuditCapacity = nil
+	cd.maxAdmissionReports = nil
 	// load filters
 	cd.filters = parseKinds(data[resourceFilters])
 	cd.updateRequestThreshold = UpdateRequestThreshold
 	logger.Info("filters configured", "filters", cd.filters)
+	// load resource exclusions
+	resourceExclusions, ok := data[resourceExclusions]
+	if !ok {
+		logger.Info("resourceExclusions not set")
+	} else {
+		resourceExclusions, err := parseResourceExclusions(resourceExclusions)
+		if err != nil {
+			logger.Error(err, "failed to parse resource exclusions")
+		} else {
+			cd.resourceExclusions = resourceExclusions
+			logger.Info("resourceExclusions configured", "exclusions", len(resourceExclusions))
+		}
+	}
 	// load defaultRegistry
 	defaultRegistry, ok := data[defaultRegistry]
 	if !ok {
@@ -514,6 +833,153 @@ func (cd *configuration) load(cm *corev1.ConfigMap) {
 			logger.Info("enableDefaultRegistryMutation configured")
 		}
 	}
+	// load engine limits
+	cd.maxForeachIterations = parseLimit(logger, data, maxForeachIterations)
+	cd.maxContextSize = parseLimit(logger, data, maxContextSize)
+	cd.maxJMESPathDepth = parseLimit(logger, data, maxJMESPathDepth)
+	cd.maxJMESPathResultSize = parseLimit(logger, data, maxJMESPathResultSize)
+	// load vault servers
+	vaultServers, ok := data[vaultServers]
+	if !ok {
+		logger.Info("vaultServers not set")
+	} else {
+		vaultServers, err := parseVaultServers(vaultServers)
+		if err != nil {
+			logger.Error(err, "failed to parse vault servers")
+		} else {
+			cd.vaultServers = vaultServers
+			logger.Info("vaultServers configured", "servers", len(vaultServers))
+		}
+	}
+	// load secret namespaces
+	secretNamespaces, ok := data[secretNamespaces]
+	if !ok {
+		logger.Info("secretNamespaces not set")
+	} else {
+		cd.secretNamespaces = parseList(secretNamespaces)
+		logger.Info("secretNamespaces configured", "secretNamespaces", cd.secretNamespaces)
+	}
+	// load skip policies annotation, it is only honored for the allowed usernames and groups
+	skipPoliciesAnnotation, ok := data[skipPoliciesAnnotation]
+	if !ok {
+		logger.Info("skipPoliciesAnnotation not set")
+	} else {
+		cd.skipPoliciesAnnotation = strings.TrimSpace(skipPoliciesAnnotation)
+		cd.skipPolicies.usernames = parseList(data[skipPoliciesUsernames])
+		cd.skipPolicies.groups = parseList(data[skipPoliciesGroups])
+		logger.Info("skipPoliciesAnnotation configured", "skipPoliciesAnnotation", cd.skipPoliciesAnnotation, "skipPoliciesUsernames", cd.skipPolicies.usernames, "skipPoliciesGroups", cd.skipPolicies.groups)
+	}
+	// load severity overrides
+	severityOverrides, ok := data[severityOverrides]
+	if !ok {
+		logger.Info("severityOverrides not set")
+	} else {
+		severityOverrides, err := parseSeverityOverrides(severityOverrides)
+		if err != nil {
+			logger.Error(err, "failed to parse severity overrides")
+		} else {
+			cd.severityOverrides = severityOverrides
+			logger.Info("severityOverrides configured", "overrides", len(severityOverrides))
+		}
+	}
+	// load global context access rules
+	globalContextAccess, ok := data[globalContextAccess]
+	if !ok {
+		logger.Info("globalContextAccess not set")
+	} else {
+		globalContextAccess, err := parseGlobalContextAccess(globalContextAccess)
+		if err != nil {
+			logger.Error(err, "failed to parse global context access")
+		} else {
+			cd.globalContextAccess = globalContextAccess
+			logger.Info("globalContextAccess configured", "rules", len(globalContextAccess))
+		}
+	}
+	// load the settings overriding flags
+	webhookTimeout, ok := data[webhookTimeout]
+	if !ok {
+		logger.Info("webhookTimeout not set")
+	} else {
+		logger := logger.WithValues("webhookTimeout", webhookTimeout)
+		timeout, err := parseWebhookTimeout(webhookTimeout)
+		if err != nil {
+			logger.Error(err, "failed to parse webhook timeout")
+		} else {
+			cd.webhookTimeout = &timeout
+			logger.Info("webhookTimeout configured")
+		}
+	}
+	omitEvents, ok := data[omitEvents]
+	if !ok {
+		logger.Info("omitEvents not set")
+	} else {
+		logger := logger.WithValues("omitEvents", omitEvents)
+		omitEvents, err := parseOmitEvents(omitEvents)
+		if err != nil {
+			logger.Error(err, "failed to parse omitted events")
+		} else {
+			cd.omitEvents = omitEvents
+			logger.Info("omitEvents configured")
+		}
+	}
+	dumpPayload, ok := data[dumpPayload]
+	if !ok {
+		logger.Info("dumpPayload not set")
+	} else {
+		logger := logger.WithValues("dumpPayload", dumpPayload)
+		dumpPayload, err := strconv.ParseBool(dumpPayload)
+		if err != nil {
+			logger.Error(err, "dumpPayload is not a boolean")
+		} else {
+			cd.dumpPayload = &dumpPayload
+			logger.Info("dumpPayload configured")
+		}
+	}
+	cd.maxAuditWorkers = parseCount(logger, data, maxAuditWorkers)
+	cd.maxAuditCapacity = parseCount(logger, data, maxAuditCapacity)
+	cd.maxAdmissionReports = parseCount(logger, data, maxAdmissionReports)
+}
+
+// parseLimit parses an engine limit, 0 is returned when the limit is not set or invalid
+func parseLimit(logger logr.Logger, data map[string]string, key string) int64 {
+	value, ok := data[key]
+	if !ok {
+		logger.Info(key + " not set")
+		return 0
+	}
+	logger = logger.WithValues(key, value)
+	limit, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		logger.Error(err, key+" is not an integer")
+		return 0
+	}
+	if limit < 0 {
+		logger.Error(errors.New("limit must not be negative"), "failed to configure "+key)
+		return 0
+	}
+	logger.Info(key + " configured")
+	return limit
+}
+
+// parseCount parses a setting that must be positive, nil is returned when the setting is not set or invalid
+func parseCount(logger logr.Logger, data map[string]string, key string) *int {
+	value, ok := data[key]
+	if !ok {
+		logger.Info(key + " not set")
+		return nil
+	}
+	logger = logger.WithValues(key, value)
+	count, err := strconv.Atoi(value)
+	if err != nil {
+		logger.Error(err, key+" is not an integer")
+		return nil
+	}
+	if count <= 0 {
+		logger.Error(errors.New("value must be positive"), "failed to configure "+key)
+		return nil
+	}
+	logger.Info(key + " configured")
+	return &count
 }
 
 func (cd *configuration) unload() {
@@ -525,10 +991,27 @@ func (cd *configuration) unload() {
 	cd.exclusions = match{}
 	cd.inclusions = match{}
 	cd.filters = []filter{}
+	cd.resourceExclusions = nil
 	cd.generateSuccessEvents = false
 	cd.webhook = WebhookConfig{}
 	cd.webhookAnnotations = nil
 	cd.webhookLabels = nil
+	cd.maxForeachIterations = 0
+	cd.maxContextSize = 0
+	cd.maxJMESPathDepth = 0
+	cd.maxJMESPathResultSize = 0
+	cd.vaultServers = nil
+	cd.secretNamespaces = nil
+	cd.skipPoliciesAnnotation = ""
+	cd.skipPolicies = match{}
+	cd.severityOverrides = nil
+	cd.globalContextAccess = nil
+	cd.webhookTimeout = nil
+	cd.omitEvents = nil
+	cd.dumpPayload = nil
+	cd.maxAuditWorkers = nil
+	cd.maxAuditCapacity = nil
+	cd.maxAdmissionReports = nil
 	logger.Info("configuration unloaded")
 }
 
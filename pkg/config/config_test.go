@@ -0,0 +1,61 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_configuration_OnSettingsChanged(t *testing.T) {
+	configuration := NewDefaultConfiguration(false)
+	var changes []SettingChange
+	configuration.OnSettingsChanged(func(changed []SettingChange) {
+		// the configuration can be read from the callback
+		configuration.GetWebhookTimeout()
+		changes = append(changes, changed...)
+	})
+	configuration.Load(&corev1.ConfigMap{
+		Data: map[string]string{
+			"webhookTimeout":  "15",
+			"omitEvents":      "",
+			"maxAuditWorkers": "0",
+		},
+	})
+	if timeout, ok := configuration.GetWebhookTimeout(); !ok || timeout != 15 {
+		t.Errorf("GetWebhookTimeout() = %v, %v, want 15, true", timeout, ok)
+	}
+	if omitEvents, ok := configuration.GetOmitEvents(); !ok || len(omitEvents) != 0 {
+		t.Errorf("GetOmitEvents() = %v, %v, want [], true", omitEvents, ok)
+	}
+	if _, ok := configuration.GetMaxAuditWorkers(); ok {
+		t.Errorf("GetMaxAuditWorkers() is set from an invalid value")
+	}
+	want := []SettingChange{
+		{Name: "webhookTimeout", Current: "15"},
+		{Name: "omitEvents", Current: "none"},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("OnSettingsChanged() = %v, want %v", changes, want)
+	}
+	// reloading the same settings doesn't invoke the callbacks
+	changes = nil
+	configuration.Load(&corev1.ConfigMap{
+		Data: map[string]string{
+			"webhookTimeout":  "15",
+			"omitEvents":      "",
+			"maxAuditWorkers": "0",
+		},
+	})
+	if len(changes) != 0 {
+		t.Errorf("OnSettingsChanged() = %v, want no change", changes)
+	}
+	configuration.Load(nil)
+	want = []SettingChange{
+		{Name: "webhookTimeout", Previous: "15"},
+		{Name: "omitEvents", Previous: "none"},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("OnSettingsChanged() = %v, want %v", changes, want)
+	}
+}
@@ -4,17 +4,30 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
+	"github.com/kyverno/kyverno/ext/wildcard"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 type WebhookConfig struct {
-	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
-	ObjectSelector    *metav1.LabelSelector `json:"objectSelector,omitempty"`
+	NamespaceSelector  *metav1.LabelSelector                           `json:"namespaceSelector,omitempty"`
+	ObjectSelector     *metav1.LabelSelector                           `json:"objectSelector,omitempty"`
+	ReinvocationPolicy *admissionregistrationv1.ReinvocationPolicyType `json:"reinvocationPolicy,omitempty"`
+}
+
+// GetReinvocationPolicy returns the reinvocation policy of the mutating webhooks, defaults to IfNeeded
+func (c WebhookConfig) GetReinvocationPolicy() admissionregistrationv1.ReinvocationPolicyType {
+	if c.ReinvocationPolicy != nil {
+		return *c.ReinvocationPolicy
+	}
+	return admissionregistrationv1.IfNeededReinvocationPolicy
 }
 
 func parseWebhooks(in string) (*WebhookConfig, error) {
@@ -22,6 +35,13 @@ func parseWebhooks(in string) (*WebhookConfig, error) {
 	if err := json.Unmarshal([]byte(in), &webhookCfg); err != nil {
 		return nil, err
 	}
+	if webhookCfg.ReinvocationPolicy != nil {
+		switch *webhookCfg.ReinvocationPolicy {
+		case admissionregistrationv1.NeverReinvocationPolicy, admissionregistrationv1.IfNeededReinvocationPolicy:
+		default:
+			return nil, fmt.Errorf("invalid reinvocation policy %q", *webhookCfg.ReinvocationPolicy)
+		}
+	}
 	return &webhookCfg, nil
 }
 
@@ -47,6 +67,16 @@ func parseExclusions(in string) (exclusions, inclusions []string) {
 	return
 }
 
+func parseList(in string) []string {
+	var out []string
+	for _, in := range strings.Split(in, ",") {
+		if in := strings.TrimSpace(in); in != "" {
+			out = append(out, in)
+		}
+	}
+	return out
+}
+
 func parseWebhookAnnotations(in string) (map[string]string, error) {
 	var out map[string]string
 	if err := json.Unmarshal([]byte(in), &out); err != nil {
@@ -71,6 +101,247 @@ func parseMatchConditions(in string) ([]admissionregistrationv1.MatchCondition,
 	return out, nil
 }
 
+// VaultServer is a Vault server secret store entries can fetch secrets from
+type VaultServer struct {
+	// Address is the Vault server URL
+	Address string `json:"address"`
+	// CABundle is a PEM encoded CA bundle used to validate the server certificate
+	CABundle string `json:"caBundle,omitempty"`
+	// AuthPath is the mount path of the Kubernetes auth method, defaults to kubernetes
+	AuthPath string `json:"authPath,omitempty"`
+	// Role is the Vault role Kyverno authenticates with
+	Role string `json:"role"`
+}
+
+// SeverityOverride overrides the severity of the report results of the matching policies and rules
+type SeverityOverride struct {
+	// Policy is the name of the policy, namespace/name for namespaced policies, wildcards are supported
+	Policy string `json:"policy"`
+	// Rule is the name of the rule, wildcards are supported and all the rules match when empty
+	Rule string `json:"rule,omitempty"`
+	// Severity is the severity set on the results, one of critical, high, medium, low or info
+	Severity string `json:"severity"`
+}
+
+func parseSeverityOverrides(in string) ([]SeverityOverride, error) {
+	var out []SeverityOverride
+	if err := json.Unmarshal([]byte(in), &out); err != nil {
+		return nil, err
+	}
+	for i, override := range out {
+		if override.Policy == "" {
+			return nil, fmt.Errorf("a policy is required for severity override %d", i)
+		}
+		switch override.Severity {
+		case "critical", "high", "medium", "low", "info":
+		default:
+			return nil, fmt.Errorf("invalid severity %q for severity override %d", override.Severity, i)
+		}
+	}
+	return out, nil
+}
+
+// GlobalContextAccess restricts the policies allowed to reference the matching global context entries
+type GlobalContextAccess struct {
+	// Entry is the name of the global context entry, wildcards are supported
+	Entry string `json:"entry"`
+	// Namespaces are the namespaces of the namespaced policies allowed to reference the entry, wildcards are supported
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Policies are the names of the policies allowed to reference the entry, namespace/name for namespaced policies,
+	// wildcards are supported
+	Policies []string `json:"policies,omitempty"`
+}
+
+func (a GlobalContextAccess) allows(namespace, policy string) bool {
+	if namespace != "" {
+		for _, pattern := range a.Namespaces {
+			if wildcard.Match(pattern, namespace) {
+				return true
+			}
+		}
+		policy = namespace + "/" + policy
+	}
+	for _, pattern := range a.Policies {
+		if wildcard.Match(pattern, policy) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseGlobalContextAccess(in string) ([]GlobalContextAccess, error) {
+	var out []GlobalContextAccess
+	if err := json.Unmarshal([]byte(in), &out); err != nil {
+		return nil, err
+	}
+	for i, access := range out {
+		if access.Entry == "" {
+			return nil, fmt.Errorf("an entry is required for global context access %d", i)
+		}
+	}
+	return out, nil
+}
+
+// ResourceExclusion excludes the resources matching all of its criteria from processing, unset criteria match
+// everything
+type ResourceExclusion struct {
+	// Kinds are the kinds of the resources, in the resource filters format (group/version/kind/subresource),
+	// wildcards are supported
+	Kinds []string `json:"kinds,omitempty"`
+	// Namespaces are the namespaces of the resources, the name for namespaces, wildcards are supported
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Names are the names of the resources, wildcards are supported
+	Names []string `json:"names,omitempty"`
+	// Selector is the label selector of the resources
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// Operations are the admission operations (CREATE, UPDATE, DELETE or CONNECT), they never match in background
+	Operations []string `json:"operations,omitempty"`
+	// Usernames and Groups are the users sending the request, the request matches when either of them matches,
+	// wildcards are supported and they never match in background
+	Usernames []string `json:"usernames,omitempty"`
+	Groups    []string `json:"groups,omitempty"`
+
+	kinds    []filter
+	selector labels.Selector
+}
+
+// ExclusionRequest is a resource checked against the resource exclusions, the operation and user are empty
+// in background
+type ExclusionRequest struct {
+	Kind        schema.GroupVersionKind
+	Subresource string
+	Namespace   string
+	Name        string
+	Labels      map[string]string
+	Operation   string
+	Username    string
+	Groups      []string
+}
+
+func (e ResourceExclusion) matches(request ExclusionRequest) bool {
+	if len(e.kinds) != 0 && !slices.ContainsFunc(e.kinds, func(f filter) bool {
+		return wildcard.Match(f.Group, request.Kind.Group) && wildcard.Match(f.Version, request.Kind.Version) &&
+			wildcard.Match(f.Kind, request.Kind.Kind) && wildcard.Match(f.Subresource, request.Subresource)
+	}) {
+		return false
+	}
+	namespace := request.Namespace
+	if request.Kind.Group == "" && request.Kind.Version == "v1" && request.Kind.Kind == "Namespace" {
+		namespace = request.Name
+	}
+	if len(e.Namespaces) != 0 && !wildcard.CheckPatterns(e.Namespaces, namespace) {
+		return false
+	}
+	if len(e.Names) != 0 && !wildcard.CheckPatterns(e.Names, request.Name) {
+		return false
+	}
+	if e.selector != nil && !e.selector.Matches(labels.Set(request.Labels)) {
+		return false
+	}
+	if len(e.Operations) != 0 && !slices.Contains(e.Operations, request.Operation) {
+		return false
+	}
+	if len(e.Usernames) != 0 || len(e.Groups) != 0 {
+		if request.Username == "" && len(request.Groups) == 0 {
+			return false
+		}
+		if !wildcard.CheckPatterns(e.Usernames, request.Username) && !wildcard.CheckPatterns(e.Groups, request.Groups...) {
+			return false
+		}
+	}
+	return true
+}
+
+// exclusionOperations are the operations resource exclusions can match
+var exclusionOperations = []string{"CREATE", "UPDATE", "DELETE", "CONNECT"}
+
+func parseResourceExclusions(in string) ([]ResourceExclusion, error) {
+	var out []ResourceExclusion
+	if err := json.Unmarshal([]byte(in), &out); err != nil {
+		return nil, err
+	}
+	for i := range out {
+		exclusion := &out[i]
+		if len(exclusion.Kinds) == 0 && len(exclusion.Namespaces) == 0 && len(exclusion.Names) == 0 && exclusion.Selector == nil &&
+			len(exclusion.Operations) == 0 && len(exclusion.Usernames) == 0 && len(exclusion.Groups) == 0 {
+			return nil, fmt.Errorf("resource exclusion %d has no criteria and would exclude everything", i)
+		}
+		for _, kind := range exclusion.Kinds {
+			if kind == "" {
+				return nil, fmt.Errorf("resource exclusion %d has an empty kind", i)
+			}
+			exclusion.kinds = append(exclusion.kinds, newFilter(kind, "", ""))
+		}
+		if exclusion.Selector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(exclusion.Selector)
+			if err != nil {
+				return nil, fmt.Errorf("resource exclusion %d has an invalid selector: %w", i, err)
+			}
+			exclusion.selector = selector
+		}
+		for _, operation := range exclusion.Operations {
+			if !slices.Contains(exclusionOperations, operation) {
+				return nil, fmt.Errorf("resource exclusion %d has an invalid operation %q", i, operation)
+			}
+		}
+	}
+	return out, nil
+}
+
+// SettingChange is a setting overriding a flag that changed when the configuration was reloaded, the values are
+// empty when the setting is not set and the flag applies
+type SettingChange struct {
+	// Name is the configmap key of the setting
+	Name string
+	// Previous is the value before the configuration was reloaded
+	Previous string
+	// Current is the value after the configuration was reloaded
+	Current string
+}
+
+// omittableEvents are the reasons of the events that can be omitted
+var omittableEvents = []string{"PolicyViolation", "PolicyApplied", "PolicyError", "PolicySkipped"}
+
+func parseOmitEvents(in string) ([]string, error) {
+	// the list is never nil so that an empty list overrides the flag
+	out := []string{}
+	for _, reason := range parseList(in) {
+		if !slices.Contains(omittableEvents, reason) {
+			return nil, fmt.Errorf("invalid event %q, must be one of %s", reason, strings.Join(omittableEvents, ", "))
+		}
+		out = append(out, reason)
+	}
+	return out, nil
+}
+
+func parseWebhookTimeout(in string) (int32, error) {
+	timeout, err := strconv.ParseInt(strings.TrimSpace(in), 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	// the API server only accepts timeouts between 1 and 30 seconds
+	if timeout < 1 || timeout > 30 {
+		return 0, fmt.Errorf("webhook timeout must be between 1 and 30 seconds, got %d", timeout)
+	}
+	return int32(timeout), nil
+}
+
+func parseVaultServers(in string) (map[string]VaultServer, error) {
+	var out map[string]VaultServer
+	if err := json.Unmarshal([]byte(in), &out); err != nil {
+		return nil, err
+	}
+	for name, server := range out {
+		if server.Address == "" {
+			return nil, fmt.Errorf("an address is required for vault server %s", name)
+		}
+		if server.Role == "" {
+			return nil, fmt.Errorf("a role is required for vault server %s", name)
+		}
+	}
+	return out, nil
+}
+
 type namespacesConfig struct {
 	IncludeNamespaces []string `json:"include,omitempty"`
 	ExcludeNamespaces []string `json:"exclude,omitempty"`
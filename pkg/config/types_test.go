@@ -4,6 +4,9 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func Test_parseExclusions(t *testing.T) {
@@ -65,6 +68,28 @@ func Test_parseExclusions(t *testing.T) {
 	}
 }
 
+func Test_parseList(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{{
+		in: "",
+	}, {
+		in: " , ",
+	}, {
+		in:   "security, team-*",
+		want: []string{"security", "team-*"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseList(tt.in); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseList() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_parseKinds(t *testing.T) {
 	type args struct {
 		in string
@@ -221,6 +246,44 @@ func Test_parseIncludeExcludeNamespacesFromNamespacesConfig(t *testing.T) {
 	}
 }
 
+func Test_parseWebhooks(t *testing.T) {
+	never := admissionregistrationv1.NeverReinvocationPolicy
+	tests := []struct {
+		name    string
+		in      string
+		want    *WebhookConfig
+		wantErr bool
+	}{{
+		name:    "invalid json",
+		in:      "hello",
+		wantErr: true,
+	}, {
+		name: "empty",
+		in:   "{}",
+		want: &WebhookConfig{},
+	}, {
+		name: "reinvocation policy",
+		in:   `{"reinvocationPolicy": "Never"}`,
+		want: &WebhookConfig{ReinvocationPolicy: &never},
+	}, {
+		name:    "invalid reinvocation policy",
+		in:      `{"reinvocationPolicy": "Always"}`,
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseWebhooks(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseWebhooks() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseWebhooks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_parseWebhookAnnotations(t *testing.T) {
 	type args struct {
 		in string
@@ -295,6 +358,312 @@ func Test_parseWebhookLabels(t *testing.T) {
 	}
 }
 
+func Test_parseSeverityOverrides(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    []SeverityOverride
+		wantErr bool
+	}{{
+		name:    "invalid json",
+		in:      "hello",
+		wantErr: true,
+	}, {
+		name:    "missing policy",
+		in:      `[{"severity": "low"}]`,
+		wantErr: true,
+	}, {
+		name:    "invalid severity",
+		in:      `[{"policy": "require-labels", "severity": "urgent"}]`,
+		wantErr: true,
+	}, {
+		name: "valid",
+		in:   `[{"policy": "require-labels", "rule": "check-team", "severity": "low"}, {"policy": "default/*", "severity": "info"}]`,
+		want: []SeverityOverride{
+			{Policy: "require-labels", Rule: "check-team", Severity: "low"},
+			{Policy: "default/*", Severity: "info"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSeverityOverrides(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseSeverityOverrides() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSeverityOverrides() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseGlobalContextAccess(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    []GlobalContextAccess
+		wantErr bool
+	}{{
+		name:    "invalid json",
+		in:      "hello",
+		wantErr: true,
+	}, {
+		name:    "missing entry",
+		in:      `[{"namespaces": ["platform"]}]`,
+		wantErr: true,
+	}, {
+		name: "valid",
+		in:   `[{"entry": "secrets-*", "namespaces": ["platform"], "policies": ["require-labels", "team-a/*"]}, {"entry": "nodes"}]`,
+		want: []GlobalContextAccess{
+			{Entry: "secrets-*", Namespaces: []string{"platform"}, Policies: []string{"require-labels", "team-a/*"}},
+			{Entry: "nodes"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseGlobalContextAccess(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseGlobalContextAccess() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseGlobalContextAccess() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseResourceExclusions(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    int
+		wantErr bool
+	}{{
+		name:    "invalid json",
+		in:      "hello",
+		wantErr: true,
+	}, {
+		name:    "no criteria",
+		in:      `[{"kinds": ["Pod"]}, {}]`,
+		wantErr: true,
+	}, {
+		name:    "empty kind",
+		in:      `[{"kinds": [""]}]`,
+		wantErr: true,
+	}, {
+		name:    "invalid selector",
+		in:      `[{"selector": {"matchExpressions": [{"key": "app", "operator": "Equals", "values": ["a"]}]}}]`,
+		wantErr: true,
+	}, {
+		name:    "invalid operation",
+		in:      `[{"operations": ["PATCH"]}]`,
+		wantErr: true,
+	}, {
+		name: "valid",
+		in:   `[{"namespaces": ["kube-system"], "selector": {"matchLabels": {"managed-by": "flux"}}}, {"kinds": ["apps/v1/Deployment"], "operations": ["UPDATE"]}]`,
+		want: 2,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseResourceExclusions(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseResourceExclusions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.want {
+				t.Errorf("parseResourceExclusions() = %v, want %d exclusions", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ResourceExclusion_matches(t *testing.T) {
+	pod := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	namespace := schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}
+	tests := []struct {
+		name      string
+		exclusion string
+		request   ExclusionRequest
+		want      bool
+	}{{
+		name:      "namespace and selector",
+		exclusion: `{"namespaces": ["kube-system"], "selector": {"matchLabels": {"managed-by": "flux"}}}`,
+		request:   ExclusionRequest{Kind: pod, Namespace: "kube-system", Name: "coredns", Labels: map[string]string{"managed-by": "flux"}},
+		want:      true,
+	}, {
+		name:      "selector doesn't match",
+		exclusion: `{"namespaces": ["kube-system"], "selector": {"matchLabels": {"managed-by": "flux"}}}`,
+		request:   ExclusionRequest{Kind: pod, Namespace: "kube-system", Name: "coredns", Labels: map[string]string{"managed-by": "helm"}},
+	}, {
+		name:      "namespace doesn't match",
+		exclusion: `{"namespaces": ["kube-system"], "selector": {"matchLabels": {"managed-by": "flux"}}}`,
+		request:   ExclusionRequest{Kind: pod, Namespace: "default", Name: "nginx", Labels: map[string]string{"managed-by": "flux"}},
+	}, {
+		name:      "namespace name",
+		exclusion: `{"namespaces": ["kube-*"]}`,
+		request:   ExclusionRequest{Kind: namespace, Name: "kube-system"},
+		want:      true,
+	}, {
+		name:      "kind and subresource",
+		exclusion: `{"kinds": ["Pod/*"], "names": ["nginx-*"]}`,
+		request:   ExclusionRequest{Kind: pod, Subresource: "status", Namespace: "default", Name: "nginx-1"},
+		want:      true,
+	}, {
+		name:      "kind doesn't match",
+		exclusion: `{"kinds": ["apps/v1/Deployment"]}`,
+		request:   ExclusionRequest{Kind: pod, Namespace: "default", Name: "nginx"},
+	}, {
+		name:      "operation",
+		exclusion: `{"kinds": ["Pod"], "operations": ["DELETE"]}`,
+		request:   ExclusionRequest{Kind: pod, Namespace: "default", Name: "nginx", Operation: "DELETE"},
+		want:      true,
+	}, {
+		name:      "operation in background",
+		exclusion: `{"kinds": ["Pod"], "operations": ["DELETE"]}`,
+		request:   ExclusionRequest{Kind: pod, Namespace: "default", Name: "nginx"},
+	}, {
+		name:      "group",
+		exclusion: `{"usernames": ["system:serviceaccount:flux-system:*"], "groups": ["system:masters"]}`,
+		request:   ExclusionRequest{Kind: pod, Namespace: "default", Name: "nginx", Username: "admin", Groups: []string{"system:authenticated", "system:masters"}},
+		want:      true,
+	}, {
+		name:      "username",
+		exclusion: `{"usernames": ["system:serviceaccount:flux-system:*"], "groups": ["system:masters"]}`,
+		request:   ExclusionRequest{Kind: pod, Namespace: "default", Name: "nginx", Username: "system:serviceaccount:flux-system:kustomize-controller"},
+		want:      true,
+	}, {
+		name:      "user in background",
+		exclusion: `{"usernames": ["*"]}`,
+		request:   ExclusionRequest{Kind: pod, Namespace: "default", Name: "nginx"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exclusions, err := parseResourceExclusions("[" + tt.exclusion + "]")
+			if err != nil {
+				t.Fatalf("parseResourceExclusions() error = %v", err)
+			}
+			if got := exclusions[0].matches(tt.request); got != tt.want {
+				t.Errorf("ResourceExclusion.matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseOmitEvents(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    []string
+		wantErr bool
+	}{{
+		name: "empty",
+		in:   "",
+		want: []string{},
+	}, {
+		name:    "invalid event",
+		in:      "PolicyViolation,Unknown",
+		wantErr: true,
+	}, {
+		name: "valid",
+		in:   "PolicyViolation, PolicyApplied",
+		want: []string{"PolicyViolation", "PolicyApplied"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseOmitEvents(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseOmitEvents() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseOmitEvents() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseWebhookTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    int32
+		wantErr bool
+	}{{
+		name:    "not an integer",
+		in:      "ten",
+		wantErr: true,
+	}, {
+		name:    "too low",
+		in:      "0",
+		wantErr: true,
+	}, {
+		name:    "too high",
+		in:      "31",
+		wantErr: true,
+	}, {
+		name: "valid",
+		in:   "15",
+		want: 15,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseWebhookTimeout(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseWebhookTimeout() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("parseWebhookTimeout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseVaultServers(t *testing.T) {
+	type args struct {
+		in string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    map[string]VaultServer
+		wantErr bool
+	}{{
+		args:    args{"hello"},
+		wantErr: true,
+	}, {
+		args: args{"null"},
+	}, {
+		args:    args{`{"corp": {"role": "kyverno"}}`},
+		wantErr: true,
+	}, {
+		args:    args{`{"corp": {"address": "https://vault.corp:8200"}}`},
+		wantErr: true,
+	}, {
+		args: args{`{"corp": {"address": "https://vault.corp:8200", "role": "kyverno"}}`},
+		want: map[string]VaultServer{
+			"corp": {
+				Address: "https://vault.corp:8200",
+				Role:    "kyverno",
+			},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseVaultServers(tt.args.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseVaultServers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseVaultServers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_parseBucketBoundariesConfig(t *testing.T) {
 	var emptyBoundaries []float64
 
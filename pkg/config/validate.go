@@ -0,0 +1,171 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	valid "github.com/asaskevich/govalidator"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// knownKeys are the keys supported in the config map
+var knownKeys = sets.New(
+	resourceFilters,
+	resourceExclusions,
+	defaultRegistry,
+	enableDefaultRegistryMutation,
+	excludeGroups,
+	excludeUsernames,
+	excludeRoles,
+	excludeClusterRoles,
+	generateSuccessEvents,
+	webhooks,
+	webhookAnnotations,
+	webhookLabels,
+	matchConditions,
+	updateRequestThreshold,
+	maxForeachIterations,
+	maxContextSize,
+	maxJMESPathDepth,
+	maxJMESPathResultSize,
+	vaultServers,
+	secretNamespaces,
+	skipPoliciesAnnotation,
+	skipPoliciesUsernames,
+	skipPoliciesGroups,
+	severityOverrides,
+	globalContextAccess,
+	webhookTimeout,
+	omitEvents,
+	dumpPayload,
+	maxAuditWorkers,
+	maxAuditCapacity,
+	maxAdmissionReports,
+)
+
+// IsKyvernoConfigMap returns true if the namespace and name are the ones of the kyverno config map
+func IsKyvernoConfigMap(namespace, name string) bool {
+	return namespace == KyvernoNamespace() && name == KyvernoConfigMapName()
+}
+
+// ValidateConfigMap validates the data of the kyverno config map, it returns an error for every unknown key
+// and every value that would be ignored when the config map is loaded
+func ValidateConfigMap(cm *corev1.ConfigMap) error {
+	var errs []error
+	for _, key := range sets.List(sets.KeySet(cm.Data)) {
+		if err := validateKey(key, cm.Data[key]); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func validateKey(key, value string) error {
+	switch key {
+	case resourceFilters:
+		return validateResourceFilters(value)
+	case resourceExclusions:
+		_, err := parseResourceExclusions(value)
+		return err
+	case defaultRegistry:
+		if !valid.IsDNSName(value) {
+			return errors.New("not a valid DNS hostname")
+		}
+	case enableDefaultRegistryMutation, generateSuccessEvents, dumpPayload:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return errors.New("not a boolean")
+		}
+	case webhooks:
+		return validateWebhooks(value)
+	case webhookAnnotations:
+		_, err := parseWebhookAnnotations(value)
+		return err
+	case webhookLabels:
+		_, err := parseWebhookLabels(value)
+		return err
+	case matchConditions:
+		_, err := parseMatchConditions(value)
+		return err
+	case updateRequestThreshold:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return errors.New("not an integer")
+		}
+	case maxForeachIterations, maxContextSize, maxJMESPathDepth, maxJMESPathResultSize:
+		limit, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return errors.New("not an integer")
+		}
+		if limit < 0 {
+			return errors.New("limit must not be negative")
+		}
+	case maxAuditWorkers, maxAuditCapacity, maxAdmissionReports:
+		count, err := strconv.Atoi(value)
+		if err != nil {
+			return errors.New("not an integer")
+		}
+		if count <= 0 {
+			return errors.New("value must be positive")
+		}
+	case vaultServers:
+		_, err := parseVaultServers(value)
+		return err
+	case severityOverrides:
+		_, err := parseSeverityOverrides(value)
+		return err
+	case globalContextAccess:
+		_, err := parseGlobalContextAccess(value)
+		return err
+	case webhookTimeout:
+		_, err := parseWebhookTimeout(value)
+		return err
+	case omitEvents:
+		_, err := parseOmitEvents(value)
+		return err
+	default:
+		if !knownKeys.Has(key) {
+			return errors.New("unknown key")
+		}
+	}
+	return nil
+}
+
+// validateResourceFilters checks that resource filters only contain [kind,namespace,name] entries, anything
+// else is silently ignored by parseKinds
+func validateResourceFilters(in string) error {
+	if rest := strings.TrimSpace(submatchallRegex.ReplaceAllString(in, "")); rest != "" {
+		return fmt.Errorf("unexpected %q outside of a [kind,namespace,name] filter", rest)
+	}
+	for _, element := range submatchallRegex.FindAllStringSubmatch(in, -1) {
+		elements := strings.Split(element[1], ",")
+		if len(elements) > 3 {
+			return fmt.Errorf("invalid filter %s, expected [kind,namespace,name]", element[0])
+		}
+		if strings.TrimSpace(elements[0]) == "" {
+			return fmt.Errorf("invalid filter %s, kind must not be empty", element[0])
+		}
+	}
+	return nil
+}
+
+// validateWebhooks checks that the webhooks configuration parses and that its selectors are valid
+func validateWebhooks(in string) error {
+	webhook, err := parseWebhooks(in)
+	if err != nil {
+		return err
+	}
+	if webhook.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(webhook.NamespaceSelector); err != nil {
+			return fmt.Errorf("invalid namespaceSelector: %w", err)
+		}
+	}
+	if webhook.ObjectSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(webhook.ObjectSelector); err != nil {
+			return fmt.Errorf("invalid objectSelector: %w", err)
+		}
+	}
+	return nil
+}
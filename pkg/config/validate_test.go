@@ -0,0 +1,71 @@
+package config
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestValidateConfigMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    map[string]string
+		wantErr bool
+	}{{
+		name: "empty",
+	}, {
+		name: "valid",
+		data: map[string]string{
+			resourceFilters:       "[Event,*,*] [*/*,kube-system,*][Node]",
+			webhooks:              `{"namespaceSelector":{"matchExpressions":[{"key":"kubernetes.io/metadata.name","operator":"NotIn","values":["kube-system"]}]}}`,
+			generateSuccessEvents: "false",
+			maxContextSize:        "0",
+			maxAuditWorkers:       "8",
+			excludeGroups:         "system:nodes",
+		},
+	}, {
+		name:    "unknown key",
+		data:    map[string]string{"resourceFilter": "[Event,*,*]"},
+		wantErr: true,
+	}, {
+		name:    "resource filter without brackets",
+		data:    map[string]string{resourceFilters: "[Event,*,*] Pod,default,*"},
+		wantErr: true,
+	}, {
+		name:    "resource filter with too many elements",
+		data:    map[string]string{resourceFilters: "[Pod,default,nginx,extra]"},
+		wantErr: true,
+	}, {
+		name:    "resource filter without kind",
+		data:    map[string]string{resourceFilters: "[,default,nginx]"},
+		wantErr: true,
+	}, {
+		name:    "invalid namespace selector",
+		data:    map[string]string{webhooks: `{"namespaceSelector":{"matchExpressions":[{"key":"team","operator":"Equals","values":["a"]}]}}`},
+		wantErr: true,
+	}, {
+		name:    "invalid object selector",
+		data:    map[string]string{webhooks: `{"objectSelector":{"matchLabels":{"team":"a b"}}}`},
+		wantErr: true,
+	}, {
+		name:    "invalid boolean",
+		data:    map[string]string{dumpPayload: "yes please"},
+		wantErr: true,
+	}, {
+		name:    "negative limit",
+		data:    map[string]string{maxJMESPathDepth: "-1"},
+		wantErr: true,
+	}, {
+		name:    "invalid default registry",
+		data:    map[string]string{defaultRegistry: "not a registry"},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateConfigMap(&corev1.ConfigMap{Data: tt.data})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConfigMap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,13 +2,19 @@ package certmanager
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/tls"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	retryutils "github.com/kyverno/kyverno/pkg/utils/retry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -22,10 +28,20 @@ const (
 	Workers        = 1
 	ControllerName = "certmanager-controller"
 	maxRetries     = 10
+
+	certificateTypeCA  = "ca"
+	certificateTypeTLS = "tls"
 )
 
+type certificateMetrics struct {
+	expiration metric.Int64ObservableGauge
+	renewals   metric.Int64Counter
+}
+
 type controller struct {
-	renewer tls.CertRenewer
+	renewer  tls.CertRenewer
+	eventGen event.Interface
+	metrics  certificateMetrics
 
 	// listers
 	caLister  corev1listers.SecretLister
@@ -39,12 +55,17 @@ type controller struct {
 	caSecretName  string
 	tlsSecretName string
 	namespace     string
+
+	// last observed expirations, used to detect rotations
+	lock        sync.Mutex
+	expirations map[string]time.Time
 }
 
 func NewController(
 	caInformer corev1informers.SecretInformer,
 	tlsInformer corev1informers.SecretInformer,
 	certRenewer tls.CertRenewer,
+	eventGen event.Interface,
 	caSecretName string,
 	tlsSecretName string,
 	namespace string,
@@ -54,6 +75,7 @@ func NewController(
 	tlsEnqueue, _, _ := controllerutils.AddDefaultEventHandlers(logger, tlsInformer.Informer(), queue)
 	c := controller{
 		renewer:       certRenewer,
+		eventGen:      eventGen,
 		caLister:      caInformer.Lister(),
 		tlsLister:     tlsInformer.Lister(),
 		queue:         queue,
@@ -62,10 +84,39 @@ func NewController(
 		caSecretName:  caSecretName,
 		tlsSecretName: tlsSecretName,
 		namespace:     namespace,
+		expirations:   map[string]time.Time{},
 	}
+	c.metrics = newCertificateMetrics(c.report)
 	return &c
 }
 
+func newCertificateMetrics(report func(context.Context, metric.Observer, metric.Int64ObservableGauge)) certificateMetrics {
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	expiration, err := meter.Int64ObservableGauge(
+		"kyverno_certificate_expiration_timestamp_seconds",
+		metric.WithDescription("can be used to track the expiration time of the certificates managed by kyverno, in seconds since epoch"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_certificate_expiration_timestamp_seconds")
+	} else if _, err := meter.RegisterCallback(func(ctx context.Context, observer metric.Observer) error {
+		report(ctx, observer, expiration)
+		return nil
+	}, expiration); err != nil {
+		logger.Error(err, "failed to register callback")
+	}
+	renewals, err := meter.Int64Counter(
+		"kyverno_certificate_renewals",
+		metric.WithDescription("can be used to track the number of certificate rotations and renewal failures"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_certificate_renewals")
+	}
+	return certificateMetrics{
+		expiration: expiration,
+		renewals:   renewals,
+	}
+}
+
 func (c *controller) Run(ctx context.Context, workers int) {
 	// we need to enqueue our secrets in case they don't exist yet in the cluster
 	// this way we ensure the reconcile happens (hence renewal/creation)
@@ -95,7 +146,11 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 	if name != c.caSecretName && name != c.tlsSecretName {
 		return nil
 	}
-	return c.renewCertificates(ctx)
+	if err := c.renewCertificates(ctx); err != nil {
+		return err
+	}
+	c.recordRotations(ctx)
+	return nil
 }
 
 func (c *controller) ticker(ctx context.Context, logger logr.Logger) {
@@ -136,10 +191,85 @@ func (c *controller) ticker(ctx context.Context, logger logr.Logger) {
 
 func (c *controller) renewCertificates(ctx context.Context) error {
 	if err := retryutils.RetryFunc(ctx, time.Second, 5*time.Second, logger, "failed to renew CA", c.renewer.RenewCA)(); err != nil {
+		c.recordFailure(ctx, certificateTypeCA, c.caSecretName, err)
 		return err
 	}
 	if err := retryutils.RetryFunc(ctx, time.Second, 5*time.Second, logger, "failed to renew TLS", c.renewer.RenewTLS)(); err != nil {
+		c.recordFailure(ctx, certificateTypeTLS, c.tlsSecretName, err)
 		return err
 	}
 	return nil
 }
+
+// expiration returns the expiration of the certificates stored in the secret of the given type
+func (c *controller) expiration(certificateType string) (string, time.Time, bool) {
+	if certificateType == certificateTypeCA {
+		secret, err := c.caLister.Secrets(c.namespace).Get(c.caSecretName)
+		if err != nil {
+			return c.caSecretName, time.Time{}, false
+		}
+		expiration, ok := tls.ReadCAExpiration(secret)
+		return c.caSecretName, expiration, ok
+	}
+	secret, err := c.tlsLister.Secrets(c.namespace).Get(c.tlsSecretName)
+	if err != nil {
+		return c.tlsSecretName, time.Time{}, false
+	}
+	expiration, ok := tls.ReadTLSExpiration(secret)
+	return c.tlsSecretName, expiration, ok
+}
+
+func (c *controller) report(_ context.Context, observer metric.Observer, gauge metric.Int64ObservableGauge) {
+	for _, certificateType := range []string{certificateTypeCA, certificateTypeTLS} {
+		if name, expiration, ok := c.expiration(certificateType); ok {
+			observer.ObserveInt64(
+				gauge,
+				expiration.Unix(),
+				metric.WithAttributes(
+					attribute.String("certificate_type", certificateType),
+					attribute.String("secret_name", name),
+				),
+			)
+		}
+	}
+}
+
+// recordRotations compares the expirations with the last observed ones, secrets are read from the listers so a
+// rotation is recorded when the informer notifies the update, the first observation is never a rotation
+func (c *controller) recordRotations(ctx context.Context) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, certificateType := range []string{certificateTypeCA, certificateTypeTLS} {
+		name, expiration, ok := c.expiration(certificateType)
+		if !ok {
+			continue
+		}
+		previous, known := c.expirations[certificateType]
+		c.expirations[certificateType] = expiration
+		if !known || previous.Equal(expiration) {
+			continue
+		}
+		logger.Info("certificate rotated", "type", certificateType, "name", name, "expiration", expiration)
+		if c.metrics.renewals != nil {
+			c.metrics.renewals.Add(ctx, 1, metric.WithAttributes(
+				attribute.String("certificate_type", certificateType),
+				attribute.String("status", "success"),
+			))
+		}
+		if c.eventGen != nil {
+			c.eventGen.Add(event.NewCertificateRotatedEvent(c.namespace, name, expiration))
+		}
+	}
+}
+
+func (c *controller) recordFailure(ctx context.Context, certificateType, name string, err error) {
+	if c.metrics.renewals != nil {
+		c.metrics.renewals.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("certificate_type", certificateType),
+			attribute.String("status", "failure"),
+		))
+	}
+	if c.eventGen != nil {
+		c.eventGen.Add(event.NewCertificateRenewalFailedEvent(c.namespace, name, err))
+	}
+}
@@ -0,0 +1,66 @@
+package certmanager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/tls"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeinformers "k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+type fakeEventGenerator struct {
+	events []event.Info
+}
+
+func (g *fakeEventGenerator) Add(infos ...event.Info) {
+	g.events = append(g.events, infos...)
+}
+
+func TestRecordRotations(t *testing.T) {
+	client := kubefake.NewSimpleClientset()
+	secrets := client.CoreV1().Secrets("kyverno")
+	renewer := tls.NewCertRenewer(secrets, tls.CertRenewalInterval, time.Hour, time.Hour, time.Minute, tls.DefaultKeyAlgorithm, "", "kyverno-svc", []string{"kyverno-svc.kyverno.svc"}, "kyverno", "ca", "tls")
+	informer := kubeinformers.NewSharedInformerFactory(client, 0).Core().V1().Secrets()
+	events := &fakeEventGenerator{}
+	c := NewController(informer, informer, renewer, events, "ca", "tls", "kyverno").(*controller)
+	sync := func() {
+		for _, name := range []string{"ca", "tls"} {
+			secret, err := secrets.Get(context.TODO(), name, metav1.GetOptions{})
+			assert.NoError(t, err)
+			assert.NoError(t, informer.Informer().GetIndexer().Add(secret))
+		}
+	}
+	assert.NoError(t, c.renewCertificates(context.TODO()))
+	sync()
+	// the first observation isn't a rotation
+	c.recordRotations(context.TODO())
+	assert.Empty(t, events.events)
+	assert.Len(t, c.expirations, 2)
+	// a new TLS certificate is a rotation
+	assert.NoError(t, secrets.Delete(context.TODO(), "tls", metav1.DeleteOptions{}))
+	time.Sleep(time.Second)
+	assert.NoError(t, renewer.RenewTLS(context.TODO()))
+	sync()
+	c.recordRotations(context.TODO())
+	assert.Len(t, events.events, 1)
+	assert.Equal(t, event.CertificateRotated, events.events[0].Reason)
+	assert.Equal(t, "tls", events.events[0].Regarding.Name)
+	assert.Equal(t, corev1.EventTypeNormal, events.events[0].Type)
+}
+
+func TestRecordFailure(t *testing.T) {
+	informer := kubeinformers.NewSharedInformerFactory(kubefake.NewSimpleClientset(), 0).Core().V1().Secrets()
+	events := &fakeEventGenerator{}
+	c := NewController(informer, informer, nil, events, "ca", "tls", "kyverno").(*controller)
+	c.recordFailure(context.TODO(), certificateTypeCA, "ca", assert.AnError)
+	assert.Len(t, events.events, 1)
+	assert.Equal(t, event.CertificateRenewalFailed, events.events[0].Reason)
+	assert.Equal(t, "ca", events.events[0].Regarding.Name)
+	assert.Equal(t, "Secret", events.events[0].Regarding.Kind)
+}
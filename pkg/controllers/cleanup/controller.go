@@ -17,6 +17,7 @@ import (
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/context/loaders"
 	"github.com/kyverno/kyverno/pkg/engine/factories"
+	"github.com/kyverno/kyverno/pkg/engine/grpccall"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/logging"
@@ -31,6 +32,7 @@ import (
 	"go.uber.org/multierr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/sets"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/util/workqueue"
@@ -57,6 +59,7 @@ type controller struct {
 	jp            jmespath.Interface
 	metrics       cleanupMetrics
 	gctxStore     loaders.Store
+	grpcClient    grpccall.Client
 }
 
 type cleanupMetrics struct {
@@ -81,6 +84,7 @@ func NewController(
 	jp jmespath.Interface,
 	eventGen event.Interface,
 	gctxStore loaders.Store,
+	grpcClient grpccall.Client,
 ) controllers.Controller {
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[any](), ControllerName)
 	keyFunc := controllerutils.MetaNamespaceKeyT[kyvernov2.CleanupPolicyInterface]
@@ -114,6 +118,7 @@ func NewController(
 		metrics:       newCleanupMetrics(logger),
 		jp:            jp,
 		gctxStore:     gctxStore,
+		grpcClient:    grpcClient,
 	}
 	if _, err := controllerutils.AddEventHandlersT(
 		cpolInformer.Informer(),
@@ -181,21 +186,25 @@ func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyv
 	kinds := sets.New(spec.MatchResources.GetKinds()...)
 	debug := logger.V(4)
 	var errs []error
+	// deleted counts the resources deleted (or that would be deleted) in this run
+	var deleted int
 
 	enginectx := enginecontext.NewContext(c.jp)
-	ctxFactory := factories.DefaultContextLoaderFactory(c.cmResolver, factories.WithGlobalContextStore(c.gctxStore))
+	ctxFactory := factories.DefaultContextLoaderFactory(
+		c.cmResolver,
+		factories.WithGlobalContextStore(c.gctxStore),
+		factories.WithConfiguration(c.configuration),
+		factories.WithGRPCClient(c.grpcClient),
+	)
 
-	loader := ctxFactory(nil, kyvernov1.Rule{})
-	if err := loader.Load(
-		ctx,
-		c.jp,
-		c.client,
-		nil,
-		spec.Context,
-		enginectx,
-	); err != nil {
-		return err
+	// cleanup policies are not kyverno policies, the namespace of namespaced ones restricts the secrets they can reference
+	var owner kyvernov1.PolicyInterface
+	if policy.GetNamespace() != "" {
+		owner = &kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Namespace: policy.GetNamespace(), Name: policy.GetName()}}
 	}
+	loader := ctxFactory(owner, kyvernov1.Rule{})
+	// context entries are loaded for every resource so that they can depend on the target resource
+	enginectx.Checkpoint()
 
 	for kind := range kinds {
 		commonLabels := []attribute.KeyValue{
@@ -288,6 +297,18 @@ func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyv
 						errs = append(errs, err)
 						continue
 					}
+					if err := loader.Load(
+						ctx,
+						c.jp,
+						c.client,
+						nil,
+						spec.Context,
+						enginectx,
+					); err != nil {
+						debug.Error(err, "failed to load context")
+						errs = append(errs, err)
+						continue
+					}
 					passed, err := conditions.CheckAnyAllConditions(logger, enginectx, *spec.Conditions)
 					if err != nil {
 						debug.Error(err, "failed to check condition")
@@ -299,11 +320,28 @@ func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyv
 						continue
 					}
 				}
+				if spec.MaxDeletions != nil && deleted >= *spec.MaxDeletions {
+					logger.Info("max deletions reached, remaining resources will be processed in the next run", "maxDeletions", *spec.MaxDeletions)
+					return multierr.Combine(errs...)
+				}
+				if deleted > 0 && spec.DeletionDelay != nil {
+					select {
+					case <-ctx.Done():
+						return multierr.Combine(append(errs, ctx.Err())...)
+					case <-time.After(spec.DeletionDelay.Duration):
+					}
+				}
+				deleted++
+				if spec.DryRun {
+					logger.WithValues("name", name, "namespace", namespace).Info("resource matched, it would be deleted (dry run)")
+					c.eventGen.Add(event.NewCleanupPolicyDryRunEvent(policy, resource))
+					continue
+				}
 				var labels []attribute.KeyValue
 				labels = append(labels, commonLabels...)
 				labels = append(labels, attribute.String("resource_namespace", namespace))
 				logger.WithValues("name", name, "namespace", namespace).Info("resource matched, it will be deleted...")
-				if err := c.client.DeleteResource(ctx, resource.GetAPIVersion(), resource.GetKind(), namespace, name, false); err != nil {
+				if err := c.delete(ctx, resource, spec.DeletionPropagationPolicy); err != nil {
 					if c.metrics.cleanupFailuresTotal != nil {
 						c.metrics.cleanupFailuresTotal.Add(ctx, 1, metric.WithAttributes(labels...))
 					}
@@ -325,6 +363,20 @@ func (c *controller) cleanup(ctx context.Context, logger logr.Logger, policy kyv
 	return multierr.Combine(errs...)
 }
 
+// delete deletes a resource, using the propagation policy when one is set
+func (c *controller) delete(ctx context.Context, resource unstructured.Unstructured, propagationPolicy *metav1.DeletionPropagation) error {
+	if propagationPolicy == nil {
+		return c.client.DeleteResource(ctx, resource.GetAPIVersion(), resource.GetKind(), resource.GetNamespace(), resource.GetName(), false)
+	}
+	gvr, err := c.client.Discovery().GetGVRFromGVK(resource.GroupVersionKind())
+	if err != nil {
+		return err
+	}
+	return c.client.GetDynamicInterface().Resource(gvr).Namespace(resource.GetNamespace()).Delete(ctx, resource.GetName(), metav1.DeleteOptions{
+		PropagationPolicy: propagationPolicy,
+	})
+}
+
 func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, namespace, name string) error {
 	policy, err := c.getPolicy(namespace, name)
 	if err != nil {
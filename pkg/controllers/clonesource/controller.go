@@ -0,0 +1,251 @@
+package clonesource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	generateutils "github.com/kyverno/kyverno/pkg/background/generate"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	"github.com/kyverno/kyverno/pkg/utils/generator"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 2
+	ControllerName = "clone-source-controller"
+	maxRetries     = 10
+)
+
+type controller struct {
+	// clients
+	client        dclient.Interface
+	kyvernoClient versioned.Interface
+
+	// listers
+	cpolLister    kyvernov1listers.ClusterPolicyLister
+	polLister     kyvernov1listers.PolicyLister
+	sourceListers map[string]cache.GenericLister
+
+	// queue
+	queue workqueue.TypedRateLimitingInterface[any]
+
+	urGenerator generator.UpdateRequestGenerator
+}
+
+// NewController returns a controller creating update requests to synchronize the downstream resources cloned
+// from a source as soon as the source changes, for the policies opting in with watchCloneSources.
+// The source informers are indexed by kind and should not include the resources managed by kyverno.
+func NewController(
+	client dclient.Interface,
+	kyvernoClient versioned.Interface,
+	cpolInformer kyvernov1informers.ClusterPolicyInformer,
+	polInformer kyvernov1informers.PolicyInformer,
+	sourceInformers map[string]informers.GenericInformer,
+	urGenerator generator.UpdateRequestGenerator,
+) controllers.Controller {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[any](), ControllerName)
+	c := &controller{
+		client:        client,
+		kyvernoClient: kyvernoClient,
+		cpolLister:    cpolInformer.Lister(),
+		polLister:     polInformer.Lister(),
+		sourceListers: map[string]cache.GenericLister{},
+		queue:         queue,
+		urGenerator:   urGenerator,
+	}
+	for kind, informer := range sourceInformers {
+		c.sourceListers[kind] = informer.Lister()
+		// only updates are watched, creations and deletions of the sources are handled when the triggers are processed
+		if _, err := controllerutils.AddEventHandlersT[metav1.Object](informer.Informer(), nil, c.updateSource(kind), nil); err != nil {
+			logger.Error(err, "failed to register event handlers", "kind", kind)
+		}
+	}
+	return c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger.V(3), ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) updateSource(kind string) func(old, obj metav1.Object) {
+	return func(old, obj metav1.Object) {
+		// resyncs don't change the resource version
+		if old.GetResourceVersion() == obj.GetResourceVersion() {
+			return
+		}
+		c.queue.Add(sourceKey(kind, obj.GetNamespace(), obj.GetName()))
+	}
+}
+
+func sourceKey(kind, namespace, name string) cache.ExplicitKey {
+	return cache.ExplicitKey(kind + "/" + namespace + "/" + name)
+}
+
+func parseSourceKey(key string) (string, string, string, error) {
+	parts := strings.SplitN(key, "/", 3)
+	if len(parts) != 3 {
+		return "", "", "", fmt.Errorf("invalid clone source key %s", key)
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+func (c *controller) getPolicy(namespace, name string) (kyvernov1.PolicyInterface, error) {
+	if namespace == "" {
+		cpolicy, err := c.cpolLister.Get(name)
+		if err != nil {
+			return nil, err
+		}
+		return cpolicy, nil
+	}
+	policy, err := c.polLister.Policies(namespace).Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// findDownstream returns the labels of the resources cloned from the source, by name or by uid
+func (c *controller) findDownstream(kind string, source metav1.Object) ([]map[string]string, error) {
+	selectors := []map[string]string{{
+		common.GenerateSourceGroupLabel:   "",
+		common.GenerateSourceVersionLabel: "v1",
+		common.GenerateSourceKindLabel:    kind,
+		common.GenerateSourceNSLabel:      source.GetNamespace(),
+		common.GenerateSourceNameLabel:    source.GetName(),
+	}, {
+		common.GenerateSourceGroupLabel:   "",
+		common.GenerateSourceVersionLabel: "v1",
+		common.GenerateSourceKindLabel:    kind,
+		common.GenerateSourceNSLabel:      source.GetNamespace(),
+		common.GenerateSourceUIDLabel:     string(source.GetUID()),
+	}}
+	var labelsList []map[string]string
+	for _, selector := range selectors {
+		downstreams, err := common.FindDownstream(c.client, "v1", kind, selector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list downstream resources: %w", err)
+		}
+		for i := range downstreams.Items {
+			labelsList = append(labelsList, downstreams.Items[i].GetLabels())
+		}
+	}
+	return labelsList, nil
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _, _ string) error {
+	kind, namespace, name, err := parseSourceKey(key)
+	if err != nil {
+		return err
+	}
+	lister, ok := c.sourceListers[kind]
+	if !ok {
+		return nil
+	}
+	obj, err := lister.ByNamespace(namespace).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	source, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	labelsList, err := c.findDownstream(kind, source)
+	if err != nil {
+		return err
+	}
+	// downstream resources are grouped by policy, a single update request is created per policy
+	var policies []kyvernov1.PolicyInterface
+	ruleContexts := map[string][]kyvernov2.RuleContext{}
+	seen := map[string]struct{}{}
+	for _, labels := range labelsList {
+		policy, err := c.getPolicy(labels[common.GeneratePolicyNamespaceLabel], labels[common.GeneratePolicyLabel])
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		spec := policy.GetSpec()
+		if !spec.WatchCloneSources || spec.DryRun {
+			continue
+		}
+		ruleName := labels[common.GenerateRuleLabel]
+		trigger := generateutils.TriggerFromLabels(labels)
+		for _, rule := range spec.Rules {
+			if rule.Name != ruleName || !rule.HasGenerate() || !rule.Generation.Synchronize {
+				continue
+			}
+			pKey := common.PolicyKey(policy.GetNamespace(), policy.GetName())
+			if _, ok := seen[pKey+"/"+ruleName+"/"+string(trigger.UID)]; ok {
+				continue
+			}
+			seen[pKey+"/"+ruleName+"/"+string(trigger.UID)] = struct{}{}
+			if _, ok := ruleContexts[pKey]; !ok {
+				policies = append(policies, policy)
+			}
+			ruleContexts[pKey] = append(ruleContexts[pKey], kyvernov2.RuleContext{
+				Rule:    ruleName,
+				Trigger: trigger,
+			})
+		}
+	}
+	for _, policy := range policies {
+		pKey := common.PolicyKey(policy.GetNamespace(), policy.GetName())
+		logger.V(3).Info("creating UR to synchronize the clone source downstream", "policy", pKey, "triggers", len(ruleContexts[pKey]))
+		if err := c.createUR(ctx, pKey, ruleContexts[pKey]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *controller) createUR(ctx context.Context, policyKey string, ruleContexts []kyvernov2.RuleContext) error {
+	ur := &kyvernov2.UpdateRequest{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: kyvernov2.SchemeGroupVersion.String(),
+			Kind:       "UpdateRequest",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "ur-",
+			Namespace:    config.KyvernoNamespace(),
+			Labels:       common.GenerateLabelsSet(policyKey),
+		},
+		Spec: kyvernov2.UpdateRequestSpec{
+			Type:        kyvernov2.Generate,
+			Policy:      policyKey,
+			RuleContext: ruleContexts,
+		},
+	}
+	created, err := c.urGenerator.Generate(ctx, c.kyvernoClient, ur, logger)
+	if err != nil {
+		return err
+	}
+	if created == nil {
+		return nil
+	}
+	updated := created.DeepCopy()
+	updated.Status.State = kyvernov2.Pending
+	_, err = c.kyvernoClient.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
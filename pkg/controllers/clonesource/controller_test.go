@@ -0,0 +1,36 @@
+package clonesource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func Test_parseSourceKey(t *testing.T) {
+	kind, namespace, name, err := parseSourceKey(string(sourceKey("Secret", "default", "tls")))
+	assert.NoError(t, err)
+	assert.Equal(t, "Secret", kind)
+	assert.Equal(t, "default", namespace)
+	assert.Equal(t, "tls", name)
+	_, _, _, err = parseSourceKey("Secret/tls")
+	assert.Error(t, err)
+}
+
+func Test_updateSource(t *testing.T) {
+	c := &controller{
+		queue: workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[any]()),
+	}
+	defer c.queue.ShutDown()
+	old := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tls", ResourceVersion: "1"}}
+	// resyncs are ignored
+	c.updateSource("Secret")(old, old.DeepCopy())
+	assert.Equal(t, 0, c.queue.Len())
+	updated := old.DeepCopy()
+	updated.ResourceVersion = "2"
+	c.updateSource("Secret")(old, updated)
+	assert.Equal(t, 1, c.queue.Len())
+	key, _ := c.queue.Get()
+	assert.Equal(t, sourceKey("Secret", "default", "tls"), key)
+}
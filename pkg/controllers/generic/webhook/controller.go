@@ -16,15 +16,12 @@ import (
 	"golang.org/x/exp/maps"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
-	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	admissionregistrationv1informers "k8s.io/client-go/informers/admissionregistration/v1"
 	appsv1informers "k8s.io/client-go/informers/apps/v1"
-	corev1informers "k8s.io/client-go/informers/core/v1"
 	admissionregistrationv1listers "k8s.io/client-go/listers/admissionregistration/v1"
 	appsv1listers "k8s.io/client-go/listers/apps/v1"
-	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/util/workqueue"
 )
 
@@ -47,7 +44,6 @@ type controller struct {
 
 	// listers
 	vwcLister        admissionregistrationv1listers.ValidatingWebhookConfigurationLister
-	secretLister     corev1listers.SecretNamespaceLister
 	deploymentLister appsv1listers.DeploymentNamespaceLister
 
 	// queue
@@ -66,7 +62,8 @@ type controller struct {
 	runtime             runtimeutils.Runtime
 	configuration       config.Configuration
 	labelSelector       *metav1.LabelSelector
-	caSecretName        string
+	namespaceSelector   *metav1.LabelSelector
+	certificates        tls.CertificateSource
 	webhooksDeleted     bool
 	autoDeleteWebhooks  bool
 	webhookCleanupSetup func(context.Context, logr.Logger) error
@@ -77,7 +74,7 @@ func NewController(
 	controllerName string,
 	vwcClient controllerutils.ObjectClient[*admissionregistrationv1.ValidatingWebhookConfiguration],
 	vwcInformer admissionregistrationv1informers.ValidatingWebhookConfigurationInformer,
-	secretInformer corev1informers.SecretInformer,
+	certificates tls.CertificateSource,
 	deploymentInformer appsv1informers.DeploymentInformer,
 	webhookName string,
 	path string,
@@ -85,11 +82,11 @@ func NewController(
 	servicePort int32,
 	webhookServerPort int32,
 	labelSelector *metav1.LabelSelector,
+	namespaceSelector *metav1.LabelSelector,
 	rules []admissionregistrationv1.RuleWithOperations,
 	failurePolicy *admissionregistrationv1.FailurePolicyType,
 	sideEffects *admissionregistrationv1.SideEffectClass,
 	configuration config.Configuration,
-	caSecretName string,
 	runtime runtimeutils.Runtime,
 	autoDeleteWebhooks bool,
 	webhookCleanupSetup func(context.Context, logr.Logger) error,
@@ -99,7 +96,6 @@ func NewController(
 	c := controller{
 		vwcClient:           vwcClient,
 		vwcLister:           vwcInformer.Lister(),
-		secretLister:        secretInformer.Lister().Secrets(config.KyvernoNamespace()),
 		deploymentLister:    deploymentInformer.Lister().Deployments(config.KyvernoNamespace()),
 		queue:               queue,
 		controllerName:      controllerName,
@@ -113,7 +109,8 @@ func NewController(
 		sideEffects:         sideEffects,
 		configuration:       configuration,
 		labelSelector:       labelSelector,
-		caSecretName:        caSecretName,
+		namespaceSelector:   namespaceSelector,
+		certificates:        certificates,
 		runtime:             runtime,
 		autoDeleteWebhooks:  autoDeleteWebhooks,
 		webhookCleanupSetup: webhookCleanupSetup,
@@ -122,26 +119,7 @@ func NewController(
 	if _, _, err := controllerutils.AddDefaultEventHandlers(c.logger, vwcInformer.Informer(), queue); err != nil {
 		c.logger.Error(err, "failed to register event handlers")
 	}
-	if _, err := controllerutils.AddEventHandlersT(
-		secretInformer.Informer(),
-		func(obj *corev1.Secret) {
-			if obj.GetNamespace() == config.KyvernoNamespace() && obj.GetName() == caSecretName {
-				c.enqueue()
-			}
-		},
-		func(_, obj *corev1.Secret) {
-			if obj.GetNamespace() == config.KyvernoNamespace() && obj.GetName() == caSecretName {
-				c.enqueue()
-			}
-		},
-		func(obj *corev1.Secret) {
-			if obj.GetNamespace() == config.KyvernoNamespace() && obj.GetName() == caSecretName {
-				c.enqueue()
-			}
-		},
-	); err != nil {
-		c.logger.Error(err, "failed to register event handlers")
-	}
+	certificates.AddEventHandler(c.enqueue)
 	if autoDeleteWebhooks {
 		if _, err := controllerutils.AddEventHandlersT(
 			deploymentInformer.Informer(),
@@ -199,7 +177,7 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _,
 	if key != c.webhookName {
 		return nil
 	}
-	caData, err := tls.ReadRootCASecret(c.caSecretName, config.KyvernoNamespace(), c.secretLister)
+	caData, err := c.certificates.CABundle()
 	if err != nil {
 		return err
 	}
@@ -281,6 +259,7 @@ func (c *controller) build(cfg config.Configuration, caBundle []byte) (*admissio
 				FailurePolicy:           c.failurePolicy,
 				SideEffects:             c.sideEffects,
 				AdmissionReviewVersions: []string{"v1"},
+				NamespaceSelector:       c.namespaceSelector,
 				ObjectSelector:          c.labelSelector,
 				MatchConditions:         cfg.GetMatchConditions(),
 			}},
@@ -2,6 +2,7 @@ package globalcontext
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -12,9 +13,14 @@ import (
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
 	"github.com/kyverno/kyverno/pkg/controllers"
 	"github.com/kyverno/kyverno/pkg/engine/adapters"
+	"github.com/kyverno/kyverno/pkg/engine/apicall"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/globalcontext/externalapi"
+	"github.com/kyverno/kyverno/pkg/globalcontext/invalid"
 	"github.com/kyverno/kyverno/pkg/globalcontext/k8sresource"
+	entrymetrics "github.com/kyverno/kyverno/pkg/globalcontext/metrics"
+	"github.com/kyverno/kyverno/pkg/globalcontext/snapshot"
 	"github.com/kyverno/kyverno/pkg/globalcontext/store"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
@@ -43,7 +49,9 @@ type controller struct {
 	kyvernoClient      versioned.Interface
 	store              store.Store
 	eventGen           event.Interface
-	maxResponseLength  int64
+	apiCallConfig      apicall.APICallConfiguration
+	jp                 jmespath.Interface
+	metrics            entrymetrics.Recorder
 	shouldUpdateStatus bool
 }
 
@@ -53,7 +61,8 @@ func NewController(
 	kyvernoClient versioned.Interface,
 	storage store.Store,
 	eventGen event.Interface,
-	maxResponseLength int64,
+	apiCallConfig apicall.APICallConfiguration,
+	jp jmespath.Interface,
 	shouldUpdateStatus bool,
 ) controllers.Controller {
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[any](), ControllerName)
@@ -64,7 +73,9 @@ func NewController(
 		kyvernoClient:      kyvernoClient,
 		store:              storage,
 		eventGen:           eventGen,
-		maxResponseLength:  maxResponseLength,
+		apiCallConfig:      apiCallConfig,
+		jp:                 jp,
+		metrics:            entrymetrics.NewRecorder(),
 		shouldUpdateStatus: shouldUpdateStatus,
 	}
 
@@ -112,6 +123,7 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _,
 		if apierrors.IsNotFound(err) {
 			// entry was deleted, remove it from the store
 			c.store.Delete(name)
+			c.metrics.Delete(name)
 			return nil
 		}
 		return err
@@ -122,6 +134,14 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _,
 	if err != nil {
 		return err
 	}
+	if entry == nil {
+		// the entry spec is invalid, retrying won't help
+		return nil
+	}
+	// keep serving the data loaded from the snapshot until the entry is refreshed
+	if previous, ok := c.store.Get(name); ok {
+		entry = snapshot.WithFallback(entry, previous)
+	}
 	c.store.Set(name, entry)
 	return nil
 }
@@ -131,6 +151,16 @@ func (c *controller) getEntry(name string) (*kyvernov2alpha1.GlobalContextEntry,
 }
 
 func (c *controller) makeStoreEntry(ctx context.Context, gce *kyvernov2alpha1.GlobalContextEntry) (store.Entry, error) {
+	var projection jmespath.Query
+	if gce.Spec.Projection != "" {
+		query, err := c.jp.Query(gce.Spec.Projection)
+		if err != nil {
+			logger.Error(err, "failed to compile projection", "name", gce.GetName())
+			c.store.Set(gce.GetName(), invalid.New(fmt.Errorf("invalid projection: %w", err)))
+			return nil, nil
+		}
+		projection = query
+	}
 	if gce.Spec.KubernetesResource != nil {
 		gvr := schema.GroupVersionResource{
 			Group:    gce.Spec.KubernetesResource.Group,
@@ -146,6 +176,31 @@ func (c *controller) makeStoreEntry(ctx context.Context, gce *kyvernov2alpha1.Gl
 			logger,
 			gvr,
 			gce.Spec.KubernetesResource.Namespace,
+			projection,
+			c.metrics,
+			c.shouldUpdateStatus,
+		)
+	}
+	if gce.Spec.HTTPCall != nil {
+		var jitter time.Duration
+		if gce.Spec.HTTPCall.Jitter != nil {
+			jitter = gce.Spec.HTTPCall.Jitter.Duration
+		}
+		return externalapi.New(
+			ctx,
+			gce,
+			c.eventGen,
+			c.kyvernoClient,
+			c.gceLister,
+			logger,
+			adapters.Client(c.dclient),
+			gce.Spec.HTTPCall.APICall(),
+			gce.Spec.HTTPCall.RefreshInterval.Duration,
+			jitter,
+			gce.Spec.HTTPCall.RetryLimit,
+			c.apiCallConfig,
+			projection,
+			c.metrics,
 			c.shouldUpdateStatus,
 		)
 	}
@@ -159,7 +214,11 @@ func (c *controller) makeStoreEntry(ctx context.Context, gce *kyvernov2alpha1.Gl
 		adapters.Client(c.dclient),
 		gce.Spec.APICall.APICall,
 		gce.Spec.APICall.RefreshInterval.Duration,
-		c.maxResponseLength,
+		0,
+		gce.Spec.APICall.RetryLimit,
+		c.apiCallConfig,
+		projection,
+		c.metrics,
 		c.shouldUpdateStatus,
 	)
 }
@@ -14,6 +14,7 @@ import (
 	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
 	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/controllers"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
@@ -47,6 +48,13 @@ type controller struct {
 	client  versioned.Interface
 	dclient dclient.Interface
 
+	// stores
+	reportStore          reportutils.Store
+	ephemeralReportStore reportutils.Store
+
+	// sinks
+	resultSink reportutils.ResultSink
+
 	// listers
 	polLister   kyvernov1listers.PolicyLister
 	cpolLister  kyvernov1listers.ClusterPolicyLister
@@ -57,6 +65,9 @@ type controller struct {
 	// queues
 	frontQueue workqueue.TypedRateLimitingInterface[any]
 	backQueue  workqueue.TypedRateLimitingInterface[any]
+
+	// config
+	config config.Configuration
 }
 
 type policyMapEntry struct {
@@ -67,24 +78,31 @@ type policyMapEntry struct {
 func NewController(
 	client versioned.Interface,
 	dclient dclient.Interface,
+	reportStore reportutils.Store,
+	resultSink reportutils.ResultSink,
 	metadataFactory metadatainformers.SharedInformerFactory,
 	polInformer kyvernov1informers.PolicyInformer,
 	cpolInformer kyvernov1informers.ClusterPolicyInformer,
 	vapInformer admissionregistrationv1beta1informers.ValidatingAdmissionPolicyInformer,
+	config config.Configuration,
 ) controllers.Controller {
 	ephrInformer := metadataFactory.ForResource(reportsv1.SchemeGroupVersion.WithResource("ephemeralreports"))
 	cephrInformer := metadataFactory.ForResource(reportsv1.SchemeGroupVersion.WithResource("clusterephemeralreports"))
 	polrInformer := metadataFactory.ForResource(policyreportv1alpha2.SchemeGroupVersion.WithResource("policyreports"))
 	cpolrInformer := metadataFactory.ForResource(policyreportv1alpha2.SchemeGroupVersion.WithResource("clusterpolicyreports"))
 	c := controller{
-		client:      client,
-		dclient:     dclient,
-		polLister:   polInformer.Lister(),
-		cpolLister:  cpolInformer.Lister(),
-		ephrLister:  ephrInformer.Lister(),
-		cephrLister: cephrInformer.Lister(),
-		frontQueue:  workqueue.NewNamedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[any](), ControllerName),
-		backQueue:   workqueue.NewNamedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[any](), ControllerName),
+		client:               client,
+		dclient:              dclient,
+		reportStore:          reportStore,
+		ephemeralReportStore: reportutils.NewClientStore(client),
+		resultSink:           resultSink,
+		polLister:            polInformer.Lister(),
+		cpolLister:           cpolInformer.Lister(),
+		ephrLister:           ephrInformer.Lister(),
+		cephrLister:          cephrInformer.Lister(),
+		frontQueue:           workqueue.NewNamedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[any](), ControllerName),
+		backQueue:            workqueue.NewNamedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[any](), ControllerName),
+		config:               config,
 	}
 	if _, _, err := controllerutils.AddDelayedDefaultEventHandlers(logger, ephrInformer.Informer(), c.frontQueue, enqueueDelay); err != nil {
 		logger.Error(err, "failed to register event handlers")
@@ -124,6 +142,10 @@ func NewController(
 	); err != nil {
 		logger.Error(err, "failed to register event handlers")
 	}
+	if config != nil {
+		// the severity overrides are applied when the reports are aggregated
+		config.OnChanged(enqueueAll)
+	}
 	if vapInformer != nil {
 		c.vapLister = vapInformer.Lister()
 		if _, err := controllerutils.AddEventHandlersT(
@@ -249,25 +271,7 @@ func (c *controller) findOwnedEphemeralReports(ctx context.Context, namespace, n
 }
 
 func (c *controller) getReport(ctx context.Context, namespace, name string) (reportsv1.ReportInterface, error) {
-	if namespace == "" {
-		report, err := c.client.Wgpolicyk8sV1alpha2().ClusterPolicyReports().Get(ctx, name, metav1.GetOptions{})
-		if err != nil {
-			if apierrors.IsNotFound(err) {
-				return nil, nil
-			}
-			return nil, err
-		}
-		return report, nil
-	} else {
-		report, err := c.client.Wgpolicyk8sV1alpha2().PolicyReports(namespace).Get(ctx, name, metav1.GetOptions{})
-		if err != nil {
-			if apierrors.IsNotFound(err) {
-				return nil, nil
-			}
-			return nil, err
-		}
-		return report, nil
-	}
+	return c.reportStore.Get(ctx, namespace, name)
 }
 
 func (c *controller) lookupEphemeralReportMeta(_ context.Context, namespace, name string) (*metav1.PartialObjectMetadata, error) {
@@ -350,7 +354,7 @@ func (c *controller) adopt(ctx context.Context, reportMeta *metav1.PartialObject
 	}
 	controllerutils.SetOwner(report, resource.GetAPIVersion(), resource.GetKind(), resource.GetName(), resource.GetUID())
 	reportutils.SetResourceUid(report, resource.GetUID())
-	if _, err := updateReport(ctx, report, c.client); err != nil {
+	if _, err := updateReport(ctx, report, c.ephemeralReportStore); err != nil {
 		return false, false
 	}
 	return true, false
@@ -411,7 +415,7 @@ func (c *controller) backReconcile(ctx context.Context, logger logr.Logger, _, n
 	defer func() {
 		if err == nil {
 			for _, ephemeralReport := range ephemeralReports {
-				if err := deleteReport(ctx, ephemeralReport, c.client); err != nil {
+				if err := deleteReport(ctx, ephemeralReport, c.ephemeralReportStore); err != nil {
 					logger.Error(err, "failed to delete ephemeral report")
 				}
 			}
@@ -433,9 +437,10 @@ func (c *controller) backReconcile(ctx context.Context, logger logr.Logger, _, n
 	for _, result := range merged {
 		results = append(results, result)
 	}
+	overrideSeverities(c.config, policyMap, results)
 	if len(results) == 0 {
 		if report != nil {
-			return deleteReport(ctx, report, c.client)
+			return deleteReport(ctx, report, c.reportStore)
 		}
 	} else {
 		if report == nil {
@@ -450,16 +455,20 @@ func (c *controller) backReconcile(ctx context.Context, logger logr.Logger, _, n
 			report = reportutils.NewPolicyReport(namespace, name, scope)
 			controllerutils.SetOwner(report, owner.APIVersion, owner.Kind, owner.Name, owner.UID)
 		}
+		previous := report.GetResults()
 		reportutils.SetResults(report, results...)
 		if report.GetResourceVersion() == "" {
-			if _, err := reportutils.CreateReport(ctx, report, c.client); err != nil {
+			if _, err := c.reportStore.Create(ctx, report); err != nil {
 				return err
 			}
 		} else {
-			if _, err := updateReport(ctx, report, c.client); err != nil {
+			if _, err := updateReport(ctx, report, c.reportStore); err != nil {
 				return err
 			}
 		}
+		if c.resultSink != nil {
+			c.resultSink.Send(resultEvents(report, changedResults(previous, report.GetResults())...)...)
+		}
 	}
 	return nil
 }
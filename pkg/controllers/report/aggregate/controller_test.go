@@ -11,6 +11,7 @@ import (
 	versionedfake "github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
 	kyvernoinformer "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
 	"github.com/kyverno/kyverno/pkg/controllers/report/aggregate"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metafake "k8s.io/client-go/metadata/fake"
@@ -59,7 +60,7 @@ func TestController(t *testing.T) {
 	metaClient.CreateFake(&metav1.PartialObjectMetadata{ObjectMeta: kyvernoPolr.ObjectMeta}, metav1.CreateOptions{})
 	metaClient.CreateFake(&metav1.PartialObjectMetadata{ObjectMeta: notKyvernoPolr.ObjectMeta}, metav1.CreateOptions{})
 
-	controller := aggregate.NewController(client, nil, metaFactory, polInformer, cpolInformer, nil)
+	controller := aggregate.NewController(client, nil, reportutils.NewClientStore(client), nil, metaFactory, polInformer, cpolInformer, nil, nil)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
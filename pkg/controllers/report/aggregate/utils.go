@@ -5,11 +5,14 @@ import (
 	"errors"
 	"time"
 
+	"github.com/kyverno/kyverno/api/kyverno"
 	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
 	reportsv1 "github.com/kyverno/kyverno/api/reports/v1"
-	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/config"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -45,18 +48,76 @@ func mergeReports(policyMap map[string]policyMapEntry, vapMap sets.Set[string],
 	}
 }
 
-func deleteReport(ctx context.Context, report reportsv1.ReportInterface, client versioned.Interface) error {
+// overrideSeverities applies the severities configured by the operators, they take precedence over the
+// severity set by the policy authors which is restored when the override is removed
+func overrideSeverities(config config.Configuration, policyMap map[string]policyMapEntry, results []policyreportv1alpha2.PolicyReportResult) {
+	if config == nil {
+		return
+	}
+	for i := range results {
+		if severity, ok := config.GetSeverityOverride(results[i].Policy, results[i].Rule); ok {
+			results[i].Severity = reportutils.SeverityFromString(severity)
+		} else if entry, ok := policyMap[results[i].Policy]; ok && results[i].Source != "ValidatingAdmissionPolicy" {
+			results[i].Severity = reportutils.SeverityFromString(entry.policy.GetAnnotations()[kyverno.AnnotationPolicySeverity])
+		}
+	}
+}
+
+func resultKey(result policyreportv1alpha2.PolicyReportResult) string {
+	return result.Source + "/" + result.Policy + "/" + result.Rule
+}
+
+// changedResults returns the current results that didn't exist or changed, timestamps are ignored as
+// background scans refresh them without changing the outcome
+func changedResults(previous, current []policyreportv1alpha2.PolicyReportResult) []policyreportv1alpha2.PolicyReportResult {
+	known := make(map[string]policyreportv1alpha2.PolicyReportResult, len(previous))
+	for _, result := range previous {
+		result.Timestamp = metav1.Timestamp{}
+		known[resultKey(result)] = result
+	}
+	var changed []policyreportv1alpha2.PolicyReportResult
+	for _, result := range current {
+		compared := result
+		compared.Timestamp = metav1.Timestamp{}
+		if old, ok := known[resultKey(result)]; !ok || !datautils.DeepEqual(old, compared) {
+			changed = append(changed, result)
+		}
+	}
+	return changed
+}
+
+func resultEvents(report reportsv1.ReportInterface, results ...policyreportv1alpha2.PolicyReportResult) []reportutils.ResultEvent {
+	var scope *corev1.ObjectReference
+	switch v := report.(type) {
+	case *policyreportv1alpha2.PolicyReport:
+		scope = v.Scope
+	case *policyreportv1alpha2.ClusterPolicyReport:
+		scope = v.Scope
+	}
+	events := make([]reportutils.ResultEvent, 0, len(results))
+	for _, result := range results {
+		events = append(events, reportutils.ResultEvent{
+			Namespace: report.GetNamespace(),
+			Report:    report.GetName(),
+			Scope:     scope,
+			Result:    result,
+		})
+	}
+	return events
+}
+
+func deleteReport(ctx context.Context, report reportsv1.ReportInterface, store reportutils.Store) error {
 	if !controllerutils.IsManagedByKyverno(report) {
 		return errors.New("can't delete report because it is not managed by kyverno")
 	}
-	return reportutils.DeleteReport(ctx, report, client)
+	return store.Delete(ctx, report)
 }
 
-func updateReport(ctx context.Context, report reportsv1.ReportInterface, client versioned.Interface) (reportsv1.ReportInterface, error) {
+func updateReport(ctx context.Context, report reportsv1.ReportInterface, store reportutils.Store) (reportsv1.ReportInterface, error) {
 	if !controllerutils.IsManagedByKyverno(report) {
 		return nil, errors.New("can't update report because it is not managed by kyverno")
 	}
-	return reportutils.UpdateReport(ctx, report, client)
+	return store.Update(ctx, report)
 }
 
 func isTooOld(reportMeta *metav1.PartialObjectMetadata) bool {
@@ -0,0 +1,66 @@
+package aggregate
+
+import (
+	"testing"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_changedResults(t *testing.T) {
+	unchanged := policyreportv1alpha2.PolicyReportResult{Policy: "pol", Rule: "unchanged", Result: policyreportv1alpha2.StatusPass, Timestamp: metav1.Timestamp{Seconds: 1}}
+	updated := policyreportv1alpha2.PolicyReportResult{Policy: "pol", Rule: "updated", Result: policyreportv1alpha2.StatusPass, Timestamp: metav1.Timestamp{Seconds: 1}}
+	previous := []policyreportv1alpha2.PolicyReportResult{unchanged, updated}
+	rescanned := unchanged
+	rescanned.Timestamp = metav1.Timestamp{Seconds: 2}
+	failed := updated
+	failed.Result = policyreportv1alpha2.StatusFail
+	created := policyreportv1alpha2.PolicyReportResult{Policy: "pol", Rule: "created", Result: policyreportv1alpha2.StatusWarn}
+	got := changedResults(previous, []policyreportv1alpha2.PolicyReportResult{rescanned, failed, created})
+	assert.Equal(t, []policyreportv1alpha2.PolicyReportResult{failed, created}, got)
+	assert.Empty(t, changedResults(previous, previous))
+	assert.Len(t, changedResults(nil, previous), 2)
+}
+
+func Test_resultEvents(t *testing.T) {
+	scope := &corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "nginx"}
+	report := &policyreportv1alpha2.PolicyReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "uid", Namespace: "default"},
+		Scope:      scope,
+	}
+	result := policyreportv1alpha2.PolicyReportResult{Policy: "pol", Rule: "rule", Result: policyreportv1alpha2.StatusFail}
+	events := resultEvents(report, result)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "default", events[0].Namespace)
+	assert.Equal(t, "uid", events[0].Report)
+	assert.Equal(t, scope, events[0].Scope)
+	assert.Equal(t, result, events[0].Result)
+	assert.Empty(t, resultEvents(report))
+}
+
+func Test_overrideSeverities(t *testing.T) {
+	configuration := config.NewDefaultConfiguration(false)
+	configuration.Load(&corev1.ConfigMap{Data: map[string]string{
+		"severityOverrides": `[{"policy": "require-*", "rule": "check-team", "severity": "low"}]`,
+	}})
+	policy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{
+		Name:        "require-labels",
+		Annotations: map[string]string{kyverno.AnnotationPolicySeverity: "high"},
+	}}
+	policyMap := map[string]policyMapEntry{"require-labels": {policy: policy}}
+	results := []policyreportv1alpha2.PolicyReportResult{
+		{Policy: "require-labels", Rule: "check-team", Severity: policyreportv1alpha2.SeverityHigh},
+		{Policy: "require-labels", Rule: "check-app", Severity: policyreportv1alpha2.SeverityLow},
+		{Policy: "unknown", Rule: "check-app", Severity: policyreportv1alpha2.SeverityMedium},
+	}
+	overrideSeverities(configuration, policyMap, results)
+	assert.Equal(t, policyreportv1alpha2.SeverityLow, results[0].Severity)
+	// the severity of the policy is restored once the override is removed
+	assert.Equal(t, policyreportv1alpha2.SeverityHigh, results[1].Severity)
+	assert.Equal(t, policyreportv1alpha2.SeverityMedium, results[2].Severity)
+}
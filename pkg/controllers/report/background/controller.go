@@ -72,6 +72,8 @@ type controller struct {
 	// cache
 	metadataCache resource.MetadataCache
 	forceDelay    time.Duration
+	fullResync    time.Duration
+	scanJitter    time.Duration
 
 	// config
 	config        config.Configuration
@@ -95,6 +97,8 @@ func NewController(
 	nsInformer corev1informers.NamespaceInformer,
 	metadataCache resource.MetadataCache,
 	forceDelay time.Duration,
+	fullResync time.Duration,
+	scanJitter time.Duration,
 	config config.Configuration,
 	jp jmespath.Interface,
 	eventGen event.Interface,
@@ -118,6 +122,8 @@ func NewController(
 		queue:          queue,
 		metadataCache:  metadataCache,
 		forceDelay:     forceDelay,
+		fullResync:     fullResync,
+		scanJitter:     scanJitter,
 		config:         config,
 		jp:             jp,
 		eventGen:       eventGen,
@@ -161,10 +167,19 @@ func NewController(
 }
 
 func (c *controller) Run(ctx context.Context, workers int) {
-	logger.Info("background scan", "interval", c.forceDelay.Abs().String())
+	logger.Info("background scan", "interval", c.forceDelay.Abs().String(), "fullResync", c.resyncInterval().String())
 	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
 }
 
+// resyncInterval returns the interval of the full rescans, the resources and policies changes are detected
+// every background scan interval and only the changed reports are rescanned in between
+func (c *controller) resyncInterval() time.Duration {
+	if c.fullResync > 0 {
+		return c.fullResync
+	}
+	return c.forceDelay
+}
+
 func (c *controller) addPolicy(obj kyvernov1.PolicyInterface) {
 	c.enqueueResources()
 }
@@ -251,33 +266,49 @@ func (c *controller) getMeta(namespace, name string) (metav1.Object, error) {
 	}
 }
 
-func (c *controller) needsReconcile(namespace, name, hash string, exceptions []kyvernov2.PolicyException, bindings []admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding, policies ...engineapi.GenericPolicy) (bool, bool, error) {
+func (c *controller) needsReconcile(namespace, name, hash string, exceptions []kyvernov2.PolicyException, bindings []admissionregistrationv1beta1.ValidatingAdmissionPolicyBinding, policies ...engineapi.GenericPolicy) (bool, scanPlan, error) {
+	now := time.Now()
+	uid := types.UID(name)
+	fullPlan := func() scanPlan {
+		return planScans(now, uid, true, now, nil, c.resyncInterval(), c.scanJitter, policies...)
+	}
 	// if the reportMetadata does not exist, we need a full reconcile
 	reportMetadata, err := c.getMeta(namespace, name)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			return true, true, nil
+			return true, fullPlan(), nil
 		}
-		return false, false, err
+		return false, scanPlan{}, err
 	}
 	// if the resource changed, we need a full reconcile
 	if !reportutils.CompareHash(reportMetadata, hash) {
-		return true, true, nil
+		return true, fullPlan(), nil
 	}
-	// if the last scan time is older than recomputation interval, we need a full reconcile
+	// if the last scan time of a policy is older than its recomputation interval or schedule, we need to rescan it
 	reportAnnotations := reportMetadata.GetAnnotations()
 	if reportAnnotations == nil || reportAnnotations[annotationLastScanTime] == "" {
-		return true, true, nil
-	} else {
-		annTime, err := time.Parse(time.RFC3339, reportAnnotations[annotationLastScanTime])
+		return true, fullPlan(), nil
+	}
+	lastScan, err := time.Parse(time.RFC3339, reportAnnotations[annotationLastScanTime])
+	if err != nil {
+		logger.Error(err, "failed to parse last scan time annotation", "namespace", namespace, "name", name, "hash", hash)
+		return true, fullPlan(), nil
+	}
+	// if the namespace labels changed, the policies selecting namespaces may apply differently
+	if namespace != "" {
+		ns, err := c.nsLister.Get(namespace)
 		if err != nil {
-			logger.Error(err, "failed to parse last scan time annotation", "namespace", namespace, "name", name, "hash", hash)
-			return true, true, nil
+			return false, scanPlan{}, err
 		}
-		if time.Now().After(annTime.Add(c.forceDelay)) {
-			return true, true, nil
+		// reports created before the hash was recorded are considered up to date
+		if hash, ok := reportAnnotations[annotationNamespaceLabelsHash]; ok && hash != namespaceLabelsHash(ns.GetLabels()) {
+			return true, fullPlan(), nil
 		}
 	}
+	plan := planScans(now, uid, false, lastScan, getScheduledScanTimes(reportAnnotations), c.resyncInterval(), c.scanJitter, policies...)
+	if plan.due.Len() != 0 {
+		return true, plan, nil
+	}
 	// if a policy or an exception changed, we need a partial reconcile
 	expected := map[string]string{}
 	for _, policy := range policies {
@@ -296,17 +327,17 @@ func (c *controller) needsReconcile(namespace, name, hash string, exceptions []k
 		}
 	}
 	if !datautils.DeepEqual(expected, actual) {
-		return true, false, nil
+		return true, plan, nil
 	}
 	// no need to reconcile
-	return false, false, nil
+	return false, plan, nil
 }
 
 func (c *controller) reconcileReport(
 	ctx context.Context,
 	namespace string,
 	name string,
-	full bool,
+	plan scanPlan,
 	uid types.UID,
 	gvk schema.GroupVersionKind,
 	resource resource.Resource,
@@ -354,7 +385,7 @@ func (c *controller) reconcileReport(
 		}
 	}
 	var ruleResults []policyreportv1alpha2.PolicyReportResult
-	if !full {
+	if !plan.full {
 		policyNameToLabel := map[string]string{}
 		for _, policy := range policies {
 			var key string
@@ -399,6 +430,10 @@ func (c *controller) reconcileReport(
 			}
 
 			label := policyNameToLabel[result.Policy]
+			// the results of the policies due for a rescan are recomputed
+			if label != "" && plan.due.Has(label) {
+				continue
+			}
 			vapBindingLabel := policyNameToLabel[result.Properties["binding"]]
 			if (label != "" && expected[label] == actual[label]) ||
 				(vapBindingLabel != "" && expected[vapBindingLabel] == actual[vapBindingLabel]) || keepResult {
@@ -424,7 +459,7 @@ func (c *controller) reconcileReport(
 				}
 			}
 		}
-		if full || reevaluate || actual[reportutils.PolicyLabel(policy)] != policy.GetResourceVersion() {
+		if plan.full || reevaluate || plan.due.Has(reportutils.PolicyLabel(policy)) || actual[reportutils.PolicyLabel(policy)] != policy.GetResourceVersion() {
 			scanner := utils.NewScanner(logger, c.engine, c.config, c.jp, c.client, c.reportsConfig)
 			for _, result := range scanner.ScanResource(ctx, *target, nsLabels, bindings, policy) {
 				if result.Error != nil {
@@ -453,8 +488,27 @@ func (c *controller) reconcileReport(
 	}
 	reportutils.SetResourceVersionLabels(desired, target)
 	reportutils.SetResults(desired, ruleResults...)
-	if full || !controllerutils.HasAnnotation(desired, annotationLastScanTime) {
-		controllerutils.SetAnnotation(desired, annotationLastScanTime, time.Now().Format(time.RFC3339))
+	if namespace != "" {
+		controllerutils.SetAnnotation(desired, annotationNamespaceLabelsHash, namespaceLabelsHash(nsLabels))
+	}
+	now := time.Now()
+	if plan.full || plan.periodic || !controllerutils.HasAnnotation(desired, annotationLastScanTime) {
+		controllerutils.SetAnnotation(desired, annotationLastScanTime, now.Format(time.RFC3339))
+	}
+	// keep the scan times of the scheduled policies, the policies no longer scheduled are dropped
+	observedScanTimes := getScheduledScanTimes(observed.GetAnnotations())
+	scanTimes := make(map[string]time.Time, plan.scheduled.Len())
+	for label := range plan.scheduled {
+		if plan.full || plan.due.Has(label) {
+			scanTimes[label] = now
+		} else if t, ok := observedScanTimes[label]; ok {
+			scanTimes[label] = t
+		}
+	}
+	if len(scanTimes) != 0 {
+		controllerutils.SetAnnotation(desired, annotationScheduledScanTimes, encodeScheduledScanTimes(scanTimes))
+	} else if annotations := desired.GetAnnotations(); annotations != nil {
+		delete(annotations, annotationScheduledScanTimes)
 	}
 	if c.policyReports {
 		return c.storeReport(ctx, observed, desired)
@@ -555,14 +609,15 @@ func (c *controller) reconcile(ctx context.Context, log logr.Logger, key, namesp
 		return err
 	}
 	// we have the resource, check if we need to reconcile
-	if needsReconcile, full, err := c.needsReconcile(namespace, name, resource.Hash, exceptions, vapBindings, policies...); err != nil {
+	if needsReconcile, plan, err := c.needsReconcile(namespace, name, resource.Hash, exceptions, vapBindings, policies...); err != nil {
 		return err
 	} else {
 		defer func() {
-			c.queue.AddAfter(key, c.forceDelay)
+			// requeue when the next scan is due, at most after the background scan interval
+			c.queue.AddAfter(key, min(max(time.Until(plan.next), 0), c.forceDelay))
 		}()
 		if needsReconcile {
-			return c.reconcileReport(ctx, namespace, name, full, uid, gvk, resource, exceptions, vapBindings, policies...)
+			return c.reconcileReport(ctx, namespace, name, plan, uid, gvk, resource, exceptions, vapBindings, policies...)
 		}
 	}
 	return nil
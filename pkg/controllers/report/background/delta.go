@@ -0,0 +1,22 @@
+package background
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"strconv"
+)
+
+// annotationNamespaceLabelsHash holds the hash of the namespace labels the report was computed with, the
+// policies selecting namespaces may apply differently when they change
+const annotationNamespaceLabelsHash = "audit.kyverno.io/namespace-labels-hash"
+
+func namespaceLabelsHash(labels map[string]string) string {
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	// map keys are sorted when encoded, the hash doesn't depend on the iteration order
+	data, _ := json.Marshal(labels)
+	hash := fnv.New64a()
+	hash.Write(data)
+	return strconv.FormatUint(hash.Sum64(), 16)
+}
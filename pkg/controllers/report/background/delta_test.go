@@ -0,0 +1,14 @@
+package background
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_namespaceLabelsHash(t *testing.T) {
+	a := namespaceLabelsHash(map[string]string{"team": "a", "env": "prod"})
+	assert.Equal(t, a, namespaceLabelsHash(map[string]string{"env": "prod", "team": "a"}))
+	assert.NotEqual(t, a, namespaceLabelsHash(map[string]string{"env": "dev", "team": "a"}))
+	assert.Equal(t, namespaceLabelsHash(nil), namespaceLabelsHash(map[string]string{}))
+}
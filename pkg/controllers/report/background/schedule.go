@@ -0,0 +1,137 @@
+package background
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"time"
+
+	"github.com/aptible/supercronic/cronexpr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// annotationScheduledScanTimes holds the last scan time of the policies scanned on schedule, keyed by policy label
+const annotationScheduledScanTimes = "audit.kyverno.io/scheduled-scan-times"
+
+// scanPlan tells which policies a reconcile has to evaluate
+type scanPlan struct {
+	// full rescans every policy, the report doesn't exist or the resource changed
+	full bool
+	// periodic is set when the policies without schedule are due for their interval scan
+	periodic bool
+	// due holds the labels of the policies whose periodic or scheduled scan is due
+	due sets.Set[string]
+	// scheduled holds the labels of the policies scanned on schedule
+	scheduled sets.Set[string]
+	// next is when the next periodic or scheduled scan of the resource is due
+	next time.Time
+}
+
+// scanSchedule returns the scan schedule of a policy, policies without a schedule are scanned every
+// background scan interval
+func scanSchedule(policy engineapi.GenericPolicy) *cronexpr.Expression {
+	if policy.GetType() != engineapi.KyvernoPolicyType {
+		return nil
+	}
+	value := policy.GetAnnotations()[kyverno.AnnotationPolicyScanSchedule]
+	if value == "" {
+		return nil
+	}
+	schedule, err := cronexpr.Parse(value)
+	if err != nil {
+		logger.Error(err, "invalid scan schedule, falling back to the background scan interval", "policy", policy.GetName())
+		return nil
+	}
+	return schedule
+}
+
+// scanJitter spreads the scheduled scans of the resources over the jitter window so that a schedule
+// doesn't rescan every resource at once, the offset is stable for a resource and a policy
+func scanJitter(uid types.UID, policy string, jitter time.Duration) time.Duration {
+	if jitter <= 0 {
+		return 0
+	}
+	hash := fnv.New64a()
+	hash.Write([]byte(string(uid) + "/" + policy))
+	return time.Duration(hash.Sum64() % uint64(jitter))
+}
+
+func getScheduledScanTimes(annotations map[string]string) map[string]time.Time {
+	times := map[string]time.Time{}
+	value := annotations[annotationScheduledScanTimes]
+	if value == "" {
+		return times
+	}
+	var raw map[string]string
+	if err := json.Unmarshal([]byte(value), &raw); err != nil {
+		logger.Error(err, "failed to parse scheduled scan times annotation")
+		return times
+	}
+	for label, value := range raw {
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			times[label] = t
+		}
+	}
+	return times
+}
+
+func encodeScheduledScanTimes(times map[string]time.Time) string {
+	raw := make(map[string]string, len(times))
+	for label, t := range times {
+		raw[label] = t.Format(time.RFC3339)
+	}
+	data, _ := json.Marshal(raw)
+	return string(data)
+}
+
+// planScans computes the policies due for a periodic or scheduled scan at now, policies without schedule
+// are due interval after the last scan of the report and policies with a schedule are due at the next
+// occurrence of their schedule after their last scan, shifted by the resource jitter
+func planScans(now time.Time, uid types.UID, full bool, lastScan time.Time, scanTimes map[string]time.Time, interval, jitter time.Duration, policies ...engineapi.GenericPolicy) scanPlan {
+	plan := scanPlan{
+		full:      full,
+		due:       sets.New[string](),
+		scheduled: sets.New[string](),
+		next:      now.Add(interval),
+	}
+	for _, policy := range policies {
+		label := reportutils.PolicyLabel(policy)
+		schedule := scanSchedule(policy)
+		nextScan := func(last time.Time) time.Time {
+			if schedule == nil {
+				return last.Add(interval)
+			}
+			next := schedule.Next(last)
+			if next.IsZero() {
+				// the schedule has no further occurrence
+				return next
+			}
+			return next.Add(scanJitter(uid, label, jitter))
+		}
+		last := lastScan
+		if schedule != nil {
+			plan.scheduled.Insert(label)
+			if t, ok := scanTimes[label]; ok {
+				last = t
+			}
+		}
+		at := now
+		if !full {
+			at = nextScan(last)
+		}
+		if !at.IsZero() && !now.Before(at) {
+			plan.due.Insert(label)
+			if schedule == nil {
+				plan.periodic = true
+			}
+			at = nextScan(now)
+		}
+		if !at.IsZero() && at.Before(plan.next) {
+			plan.next = at
+		}
+	}
+	return plan
+}
@@ -0,0 +1,71 @@
+package background
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newPolicy(name, schedule string) engineapi.GenericPolicy {
+	policy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if schedule != "" {
+		policy.SetAnnotations(map[string]string{kyverno.AnnotationPolicyScanSchedule: schedule})
+	}
+	return engineapi.NewKyvernoPolicy(policy)
+}
+
+func Test_planScans(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC)
+	hourly := newPolicy("cheap", "0 * * * *")
+	nightly := newPolicy("heavy", "0 2 * * *")
+	unscheduled := newPolicy("default", "")
+	policies := []engineapi.GenericPolicy{hourly, nightly, unscheduled}
+
+	t.Run("full", func(t *testing.T) {
+		plan := planScans(now, "uid", true, now, nil, 24*time.Hour, 0, policies...)
+		assert.True(t, plan.full)
+		assert.Equal(t, 3, plan.due.Len())
+		assert.True(t, plan.scheduled.Has("cpol.kyverno.io/cheap"))
+		assert.True(t, plan.scheduled.Has("cpol.kyverno.io/heavy"))
+		assert.Equal(t, time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC), plan.next)
+	})
+	t.Run("hourly due", func(t *testing.T) {
+		scanTimes := map[string]time.Time{
+			"cpol.kyverno.io/cheap": now.Add(-time.Hour),
+			"cpol.kyverno.io/heavy": now.Add(-time.Hour),
+		}
+		plan := planScans(now, "uid", false, now.Add(-time.Minute), scanTimes, 24*time.Hour, 0, policies...)
+		assert.False(t, plan.full)
+		assert.False(t, plan.periodic)
+		assert.Equal(t, []string{"cpol.kyverno.io/cheap"}, plan.due.UnsortedList())
+		assert.Equal(t, time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC), plan.next)
+	})
+	t.Run("interval due", func(t *testing.T) {
+		scanTimes := map[string]time.Time{
+			"cpol.kyverno.io/cheap": now,
+			"cpol.kyverno.io/heavy": now,
+		}
+		plan := planScans(now, "uid", false, now.Add(-25*time.Hour), scanTimes, 24*time.Hour, 0, policies...)
+		assert.True(t, plan.periodic)
+		assert.Equal(t, []string{"cpol.kyverno.io/default"}, plan.due.UnsortedList())
+	})
+}
+
+func Test_scanJitter(t *testing.T) {
+	assert.Zero(t, scanJitter("uid", "cpol.kyverno.io/cheap", 0))
+	jitter := scanJitter("uid", "cpol.kyverno.io/cheap", 10*time.Minute)
+	assert.Less(t, jitter, 10*time.Minute)
+	assert.Equal(t, jitter, scanJitter("uid", "cpol.kyverno.io/cheap", 10*time.Minute))
+}
+
+func Test_scheduledScanTimes(t *testing.T) {
+	times := map[string]time.Time{"cpol.kyverno.io/cheap": time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	annotations := map[string]string{annotationScheduledScanTimes: encodeScheduledScanTimes(times)}
+	assert.Equal(t, times, getScheduledScanTimes(annotations))
+	assert.Empty(t, getScheduledScanTimes(nil))
+}
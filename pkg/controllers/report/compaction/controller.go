@@ -0,0 +1,163 @@
+package compaction
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	metadatainformers "k8s.io/client-go/metadata/metadatainformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 2
+	ControllerName = "report-compaction-controller"
+	maxRetries     = 10
+)
+
+type controller struct {
+	// clients
+	dclient dclient.Interface
+
+	// stores
+	reportStore reportutils.Store
+
+	// listers
+	polrLister  cache.GenericLister
+	cpolrLister cache.GenericLister
+
+	// queue
+	queue workqueue.TypedRateLimitingInterface[any]
+
+	// config
+	interval       time.Duration
+	resultsTTL     time.Duration
+	compactOrphans bool
+}
+
+// NewController returns a controller compacting the policy reports every interval, results older than
+// resultsTTL are removed (a zero ttl never expires results) and, if compactOrphans is set, reports whose
+// resource no longer exists are deleted
+func NewController(
+	dclient dclient.Interface,
+	reportStore reportutils.Store,
+	metadataFactory metadatainformers.SharedInformerFactory,
+	interval time.Duration,
+	resultsTTL time.Duration,
+	compactOrphans bool,
+) controllers.Controller {
+	polrInformer := metadataFactory.ForResource(policyreportv1alpha2.SchemeGroupVersion.WithResource("policyreports"))
+	cpolrInformer := metadataFactory.ForResource(policyreportv1alpha2.SchemeGroupVersion.WithResource("clusterpolicyreports"))
+	c := controller{
+		dclient:        dclient,
+		reportStore:    reportStore,
+		polrLister:     polrInformer.Lister(),
+		cpolrLister:    cpolrInformer.Lister(),
+		queue:          workqueue.NewNamedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[any](), ControllerName),
+		interval:       interval,
+		resultsTTL:     resultsTTL,
+		compactOrphans: compactOrphans,
+	}
+	return &c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	c.enqueueAll()
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile, c.ticker)
+}
+
+func (c *controller) enqueueAll() {
+	selector := labels.SelectorFromSet(labels.Set{
+		kyverno.LabelAppManagedBy: kyverno.ValueKyvernoApp,
+	})
+	if list, err := c.polrLister.List(selector); err == nil {
+		for _, item := range list {
+			c.queue.Add(controllerutils.MetaObjectToName(item.(*metav1.PartialObjectMetadata)))
+		}
+	} else {
+		logger.Error(err, "failed to list policy reports")
+	}
+	if list, err := c.cpolrLister.List(selector); err == nil {
+		for _, item := range list {
+			c.queue.Add(controllerutils.MetaObjectToName(item.(*metav1.PartialObjectMetadata)))
+		}
+	} else {
+		logger.Error(err, "failed to list cluster policy reports")
+	}
+}
+
+func (c *controller) ticker(ctx context.Context, logger logr.Logger) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.enqueueAll()
+		}
+	}
+}
+
+// resourceExists checks that the resource referenced by the report still exists, a resource recreated
+// with the same name has a different uid and is considered as a different resource
+func (c *controller) resourceExists(ctx context.Context, scope *corev1.ObjectReference) (bool, error) {
+	resource, err := c.dclient.GetResource(ctx, scope.APIVersion, scope.Kind, scope.Namespace, scope.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return resource.GetUID() == scope.UID, nil
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, _, namespace, name string) error {
+	report, err := c.reportStore.Get(ctx, namespace, name)
+	if err != nil {
+		return err
+	}
+	if report == nil {
+		return nil
+	}
+	if c.compactOrphans {
+		if scope := getScope(report); scope != nil {
+			exists, err := c.resourceExists(ctx, scope)
+			if err != nil {
+				// the resource can't be checked (missing permission, unknown kind), keep the report
+				logger.V(3).Info("failed to get report resource", "error", err.Error())
+			} else if !exists {
+				logger.V(2).Info("deleting report, its resource no longer exists")
+				return c.reportStore.Delete(ctx, report)
+			}
+		}
+	}
+	if c.resultsTTL > 0 {
+		results := report.GetResults()
+		kept := expireResults(results, time.Now().Add(-c.resultsTTL))
+		if len(kept) == len(results) {
+			return nil
+		}
+		if len(kept) == 0 {
+			logger.V(2).Info("deleting report, all its results expired")
+			return c.reportStore.Delete(ctx, report)
+		}
+		logger.V(2).Info("removing expired results", "expired", len(results)-len(kept))
+		reportutils.SetResults(report, kept...)
+		_, err := c.reportStore.Update(ctx, report)
+		return err
+	}
+	return nil
+}
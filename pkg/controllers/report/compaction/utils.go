@@ -0,0 +1,33 @@
+package compaction
+
+import (
+	"time"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	reportsv1 "github.com/kyverno/kyverno/api/reports/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// expireResults returns the results that are not older than the given time, results without timestamp never expire
+func expireResults(results []policyreportv1alpha2.PolicyReportResult, before time.Time) []policyreportv1alpha2.PolicyReportResult {
+	kept := make([]policyreportv1alpha2.PolicyReportResult, 0, len(results))
+	for _, result := range results {
+		if result.Timestamp.Seconds != 0 && time.Unix(result.Timestamp.Seconds, int64(result.Timestamp.Nanos)).Before(before) {
+			continue
+		}
+		kept = append(kept, result)
+	}
+	return kept
+}
+
+// getScope returns the resource referenced by the report, or nil if the report doesn't reference a single resource
+func getScope(report reportsv1.ReportInterface) *corev1.ObjectReference {
+	switch v := report.(type) {
+	case *policyreportv1alpha2.PolicyReport:
+		return v.Scope
+	case *policyreportv1alpha2.ClusterPolicyReport:
+		return v.Scope
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,36 @@
+package compaction
+
+import (
+	"testing"
+	"time"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	reportsv1 "github.com/kyverno/kyverno/api/reports/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_expireResults(t *testing.T) {
+	results := []policyreportv1alpha2.PolicyReportResult{
+		{Policy: "expired", Timestamp: metav1.Timestamp{Seconds: 100}},
+		{Policy: "recent", Timestamp: metav1.Timestamp{Seconds: 300}},
+		{Policy: "no-timestamp"},
+	}
+	got := expireResults(results, time.Unix(200, 0))
+	assert.Equal(t, []policyreportv1alpha2.PolicyReportResult{results[1], results[2]}, got)
+	assert.Len(t, results, 3)
+}
+
+func Test_expireResults_empty(t *testing.T) {
+	got := expireResults(nil, time.Now())
+	assert.Empty(t, got)
+}
+
+func Test_getScope(t *testing.T) {
+	scope := &corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "nginx", UID: "uid"}
+	assert.Equal(t, scope, getScope(&policyreportv1alpha2.PolicyReport{Scope: scope}))
+	assert.Equal(t, scope, getScope(&policyreportv1alpha2.ClusterPolicyReport{Scope: scope}))
+	assert.Nil(t, getScope(&policyreportv1alpha2.PolicyReport{}))
+	assert.Nil(t, getScope(&reportsv1.EphemeralReport{}))
+}
@@ -0,0 +1,173 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/aptible/supercronic/cronexpr"
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "report-export-controller"
+	maxRetries     = 5
+	// exportKey is the single key of the queue, every export serializes all the reports
+	exportKey = "export"
+)
+
+// Config configures the periodic export of the policy reports to an object storage
+type Config struct {
+	// Schedule is the cron expression of the exports
+	Schedule string
+	// Format of the exported reports, json or sarif
+	Format string
+	// Provider of the object storage, s3, gcs or azure
+	Provider string
+	// Bucket receiving the exports, the container with the azure provider
+	Bucket string
+	// Prefix is prepended to the name of the exported objects
+	Prefix string
+	// Endpoint of the object storage, it defaults to the provider endpoint for s3 and gcs and is the
+	// storage account url with the azure provider
+	Endpoint string
+	// Region of the bucket, only used by the s3 provider
+	Region string
+	// Secret is the name of the secret holding the credentials, in the kyverno namespace
+	Secret string
+}
+
+// Validate checks the configuration, it fails fast at startup instead of failing every export
+func (c Config) Validate() error {
+	if _, err := cronexpr.Parse(c.Schedule); err != nil {
+		return fmt.Errorf("invalid export schedule %s: %w", c.Schedule, err)
+	}
+	if c.Format != FormatJSON && c.Format != FormatSARIF {
+		return fmt.Errorf("unsupported export format %s", c.Format)
+	}
+	switch c.Provider {
+	case ProviderS3, ProviderGCS:
+	case ProviderAzure:
+		if c.Endpoint == "" {
+			return errors.New("the azure export provider requires the storage account endpoint")
+		}
+	default:
+		return fmt.Errorf("unsupported export provider %s", c.Provider)
+	}
+	if c.Bucket == "" {
+		return errors.New("missing export bucket")
+	}
+	if c.Secret == "" {
+		return errors.New("missing export credentials secret")
+	}
+	return nil
+}
+
+func (c Config) newUploader() uploader {
+	switch c.Provider {
+	case ProviderAzure:
+		return newAzureUploader(c.Endpoint, c.Bucket)
+	case ProviderGCS:
+		endpoint := c.Endpoint
+		if endpoint == "" {
+			endpoint = "https://storage.googleapis.com"
+		}
+		return newS3Uploader(endpoint, "auto", c.Bucket)
+	default:
+		region := c.Region
+		if region == "" {
+			region = "us-east-1"
+		}
+		endpoint := c.Endpoint
+		if endpoint == "" {
+			endpoint = "https://s3." + region + ".amazonaws.com"
+		}
+		return newS3Uploader(endpoint, region, c.Bucket)
+	}
+}
+
+type controller struct {
+	// clients
+	client       versioned.Interface
+	secretClient corev1client.SecretInterface
+
+	// queue
+	queue workqueue.TypedRateLimitingInterface[any]
+
+	// config
+	config   Config
+	schedule *cronexpr.Expression
+	uploader uploader
+}
+
+// NewController returns a controller exporting the policy reports to an object storage on schedule,
+// the config is expected to be valid
+func NewController(
+	client versioned.Interface,
+	secretClient corev1client.SecretInterface,
+	config Config,
+) controllers.Controller {
+	c := controller{
+		client:       client,
+		secretClient: secretClient,
+		queue:        workqueue.NewNamedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[any](), ControllerName),
+		config:       config,
+		schedule:     cronexpr.MustParse(config.Schedule),
+		uploader:     config.newUploader(),
+	}
+	return &c
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	c.scheduleNext()
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) scheduleNext() {
+	next := c.schedule.Next(time.Now())
+	logger.V(2).Info("scheduled next export", "time", next)
+	c.queue.AddAfter(exportKey, time.Until(next))
+}
+
+func (c *controller) objectKey(now time.Time) string {
+	return path.Join(c.config.Prefix, "policy-reports-"+now.UTC().Format("20060102T150405Z")+extension(c.config.Format))
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, _, _ string) error {
+	// failed exports are retried by the queue, the next export is scheduled anyway
+	if c.queue.NumRequeues(key) == 0 {
+		defer c.scheduleNext()
+	}
+	polrs, err := c.client.Wgpolicyk8sV1alpha2().PolicyReports(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	cpolrs, err := c.client.Wgpolicyk8sV1alpha2().ClusterPolicyReports().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	data, err := encode(c.config.Format, polrs.Items, cpolrs.Items)
+	if err != nil {
+		return err
+	}
+	secret, err := c.secretClient.Get(ctx, c.config.Secret, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get export credentials: %w", err)
+	}
+	object := c.objectKey(time.Now())
+	if err := c.uploader.upload(ctx, secret.Data, object, contentType(c.config.Format), data); err != nil {
+		return err
+	}
+	logger.Info("exported policy reports", "object", object, "policyReports", len(polrs.Items), "clusterPolicyReports", len(cpolrs.Items))
+	return nil
+}
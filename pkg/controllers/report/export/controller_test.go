@@ -0,0 +1,33 @@
+package export
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	valid := Config{Schedule: "0 0 * * *", Format: FormatJSON, Provider: ProviderS3, Bucket: "reports", Secret: "credentials"}
+	assert.NoError(t, valid.Validate())
+	for name, mutate := range map[string]func(*Config){
+		"schedule":       func(c *Config) { c.Schedule = "every day" },
+		"format":         func(c *Config) { c.Format = "xml" },
+		"provider":       func(c *Config) { c.Provider = "ftp" },
+		"azure endpoint": func(c *Config) { c.Provider = ProviderAzure },
+		"bucket":         func(c *Config) { c.Bucket = "" },
+		"secret":         func(c *Config) { c.Secret = "" },
+	} {
+		config := valid
+		mutate(&config)
+		assert.Error(t, config.Validate(), name)
+	}
+}
+
+func Test_objectKey(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	c := controller{config: Config{Prefix: "clusters/prod/", Format: FormatSARIF}}
+	assert.Equal(t, "clusters/prod/policy-reports-20240301T123000Z.sarif", c.objectKey(now))
+	c = controller{config: Config{Format: FormatJSON}}
+	assert.Equal(t, "policy-reports-20240301T123000Z.json", c.objectKey(now))
+}
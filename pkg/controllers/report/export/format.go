@@ -0,0 +1,207 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// FormatJSON exports the reports as a JSON list of PolicyReports and ClusterPolicyReports
+	FormatJSON = "json"
+	// FormatSARIF exports the failures, warnings and errors of the reports as a SARIF log
+	FormatSARIF = "sarif"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+type reportList struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Items      []any  `json:"items"`
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules,omitempty"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+func extension(format string) string {
+	return "." + format
+}
+
+func contentType(format string) string {
+	if format == FormatSARIF {
+		return "application/sarif+json"
+	}
+	return "application/json"
+}
+
+func encode(format string, polrs []policyreportv1alpha2.PolicyReport, cpolrs []policyreportv1alpha2.ClusterPolicyReport) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		return encodeJSON(polrs, cpolrs)
+	case FormatSARIF:
+		return encodeSARIF(polrs, cpolrs)
+	default:
+		return nil, fmt.Errorf("unsupported export format %s", format)
+	}
+}
+
+func encodeJSON(polrs []policyreportv1alpha2.PolicyReport, cpolrs []policyreportv1alpha2.ClusterPolicyReport) ([]byte, error) {
+	list := reportList{
+		APIVersion: "v1",
+		Kind:       "List",
+		Items:      make([]any, 0, len(polrs)+len(cpolrs)),
+	}
+	// listed items don't carry their type meta
+	for _, report := range cpolrs {
+		report.APIVersion = policyreportv1alpha2.SchemeGroupVersion.String()
+		report.Kind = "ClusterPolicyReport"
+		list.Items = append(list.Items, report)
+	}
+	for _, report := range polrs {
+		report.APIVersion = policyreportv1alpha2.SchemeGroupVersion.String()
+		report.Kind = "PolicyReport"
+		list.Items = append(list.Items, report)
+	}
+	return json.Marshal(list)
+}
+
+func sarifLevel(result policyreportv1alpha2.PolicyResult) string {
+	switch result {
+	case policyreportv1alpha2.StatusFail, policyreportv1alpha2.StatusError:
+		return "error"
+	case policyreportv1alpha2.StatusWarn:
+		return "warning"
+	default:
+		return ""
+	}
+}
+
+func sarifLocations(scope *corev1.ObjectReference, result policyreportv1alpha2.PolicyReportResult) []sarifLocation {
+	resources := result.Resources
+	if len(resources) == 0 && scope != nil {
+		resources = []corev1.ObjectReference{*scope}
+	}
+	var locations []sarifLocation
+	for _, resource := range resources {
+		name := resource.Name
+		if resource.Namespace != "" {
+			name = resource.Namespace + "/" + name
+		}
+		locations = append(locations, sarifLocation{
+			LogicalLocations: []sarifLogicalLocation{{
+				Name:               resource.Name,
+				FullyQualifiedName: strings.TrimPrefix(resource.APIVersion+"/"+resource.Kind+"/"+name, "/"),
+				Kind:               "resource",
+			}},
+		})
+	}
+	return locations
+}
+
+func encodeSARIF(polrs []policyreportv1alpha2.PolicyReport, cpolrs []policyreportv1alpha2.ClusterPolicyReport) ([]byte, error) {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           "kyverno",
+				InformationURI: "https://kyverno.io",
+			},
+		},
+		Results: []sarifResult{},
+	}
+	rules := map[string]struct{}{}
+	add := func(scope *corev1.ObjectReference, results []policyreportv1alpha2.PolicyReportResult) {
+		for _, result := range results {
+			level := sarifLevel(result.Result)
+			// passed and skipped results are not findings
+			if level == "" {
+				continue
+			}
+			id := result.Policy
+			if result.Rule != "" {
+				id += "/" + result.Rule
+			}
+			if _, ok := rules[id]; !ok {
+				rules[id] = struct{}{}
+				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: id})
+			}
+			properties := map[string]string{
+				"result": string(result.Result),
+			}
+			if result.Category != "" {
+				properties["category"] = result.Category
+			}
+			if result.Severity != "" {
+				properties["severity"] = string(result.Severity)
+			}
+			run.Results = append(run.Results, sarifResult{
+				RuleID:     id,
+				Level:      level,
+				Message:    sarifMessage{Text: result.Message},
+				Locations:  sarifLocations(scope, result),
+				Properties: properties,
+			})
+		}
+	}
+	for _, report := range cpolrs {
+		add(report.Scope, report.Results)
+	}
+	for _, report := range polrs {
+		add(report.Scope, report.Results)
+	}
+	return json.Marshal(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	})
+}
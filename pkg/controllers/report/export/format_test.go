@@ -0,0 +1,78 @@
+package export
+
+import (
+	"encoding/json"
+	"testing"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	testPolicyReports = []policyreportv1alpha2.PolicyReport{{
+		ObjectMeta: metav1.ObjectMeta{Name: "uid", Namespace: "default"},
+		Scope:      &corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "nginx"},
+		Results: []policyreportv1alpha2.PolicyReportResult{{
+			Policy:   "require-labels",
+			Rule:     "check-team",
+			Result:   policyreportv1alpha2.StatusFail,
+			Severity: policyreportv1alpha2.SeverityHigh,
+			Message:  "label team is required",
+		}, {
+			Policy: "require-labels",
+			Rule:   "check-app",
+			Result: policyreportv1alpha2.StatusPass,
+		}},
+	}}
+	testClusterPolicyReports = []policyreportv1alpha2.ClusterPolicyReport{{
+		ObjectMeta: metav1.ObjectMeta{Name: "uid"},
+		Results: []policyreportv1alpha2.PolicyReportResult{{
+			Policy:    "disallow-default-namespace",
+			Result:    policyreportv1alpha2.StatusWarn,
+			Resources: []corev1.ObjectReference{{APIVersion: "v1", Kind: "Namespace", Name: "default"}},
+		}},
+	}}
+)
+
+func Test_encodeJSON(t *testing.T) {
+	data, err := encode(FormatJSON, testPolicyReports, testClusterPolicyReports)
+	assert.NoError(t, err)
+	var list struct {
+		Kind  string           `json:"kind"`
+		Items []map[string]any `json:"items"`
+	}
+	assert.NoError(t, json.Unmarshal(data, &list))
+	assert.Equal(t, "List", list.Kind)
+	assert.Len(t, list.Items, 2)
+	assert.Equal(t, "ClusterPolicyReport", list.Items[0]["kind"])
+	assert.Equal(t, "PolicyReport", list.Items[1]["kind"])
+	assert.Equal(t, "wgpolicyk8s.io/v1alpha2", list.Items[1]["apiVersion"])
+	// listed reports are not modified
+	assert.Empty(t, testPolicyReports[0].Kind)
+}
+
+func Test_encodeSARIF(t *testing.T) {
+	data, err := encode(FormatSARIF, testPolicyReports, testClusterPolicyReports)
+	assert.NoError(t, err)
+	var log sarifLog
+	assert.NoError(t, json.Unmarshal(data, &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	assert.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	assert.Equal(t, []sarifRule{{ID: "disallow-default-namespace"}, {ID: "require-labels/check-team"}}, run.Tool.Driver.Rules)
+	// passed results are not findings
+	assert.Len(t, run.Results, 2)
+	assert.Equal(t, "warning", run.Results[0].Level)
+	assert.Equal(t, "v1/Namespace/default", run.Results[0].Locations[0].LogicalLocations[0].FullyQualifiedName)
+	assert.Equal(t, "error", run.Results[1].Level)
+	assert.Equal(t, "label team is required", run.Results[1].Message.Text)
+	assert.Equal(t, "v1/Pod/default/nginx", run.Results[1].Locations[0].LogicalLocations[0].FullyQualifiedName)
+	assert.Equal(t, "high", run.Results[1].Properties["severity"])
+}
+
+func Test_encode_unsupported(t *testing.T) {
+	_, err := encode("xml", nil, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,134 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+const (
+	// ProviderS3 uploads the reports to an AWS S3 (or S3 compatible) bucket
+	ProviderS3 = "s3"
+	// ProviderGCS uploads the reports to a Google Cloud Storage bucket through its S3 compatible XML API,
+	// the credentials are GCS HMAC keys
+	ProviderGCS = "gcs"
+	// ProviderAzure uploads the reports to an Azure Blob Storage container with a SAS token
+	ProviderAzure = "azure"
+)
+
+const (
+	// secret keys holding the credentials
+	SecretAccessKeyID     = "accessKeyID"
+	SecretSecretAccessKey = "secretAccessKey"
+	SecretSessionToken    = "sessionToken"
+	SecretSASToken        = "sasToken"
+)
+
+const uploadTimeout = time.Minute
+
+// uploader writes an object to a bucket, credentials are resolved for every upload so that rotated
+// secrets are picked up without restarting
+type uploader interface {
+	upload(ctx context.Context, credentials map[string][]byte, key string, contentType string, data []byte) error
+}
+
+type s3Uploader struct {
+	endpoint string
+	region   string
+	bucket   string
+	signer   *v4.Signer
+	client   *http.Client
+}
+
+func newS3Uploader(endpoint, region, bucket string) *s3Uploader {
+	return &s3Uploader{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		region:   region,
+		bucket:   bucket,
+		signer:   v4.NewSigner(),
+		client:   &http.Client{Timeout: uploadTimeout},
+	}
+}
+
+func (u *s3Uploader) upload(ctx context.Context, credentials map[string][]byte, key string, contentType string, data []byte) error {
+	// objects are addressed with the path style, it is supported by every S3 compatible store
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, u.endpoint+"/"+url.PathEscape(u.bucket)+"/"+escapeKey(key), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	hash := sha256.Sum256(data)
+	payloadHash := hex.EncodeToString(hash[:])
+	request.Header.Set("Content-Type", contentType)
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	creds := aws.Credentials{
+		AccessKeyID:     string(credentials[SecretAccessKeyID]),
+		SecretAccessKey: string(credentials[SecretSecretAccessKey]),
+		SessionToken:    string(credentials[SecretSessionToken]),
+	}
+	if !creds.HasKeys() {
+		return fmt.Errorf("missing %s or %s in the export credentials", SecretAccessKeyID, SecretSecretAccessKey)
+	}
+	if err := u.signer.SignHTTP(ctx, creds, request, payloadHash, "s3", u.region, time.Now()); err != nil {
+		return err
+	}
+	return do(u.client, request)
+}
+
+type azureUploader struct {
+	endpoint  string
+	container string
+	client    *http.Client
+}
+
+func newAzureUploader(endpoint, container string) *azureUploader {
+	return &azureUploader{
+		endpoint:  strings.TrimSuffix(endpoint, "/"),
+		container: container,
+		client:    &http.Client{Timeout: uploadTimeout},
+	}
+}
+
+func (u *azureUploader) upload(ctx context.Context, credentials map[string][]byte, key string, contentType string, data []byte) error {
+	token := strings.TrimPrefix(string(credentials[SecretSASToken]), "?")
+	if token == "" {
+		return fmt.Errorf("missing %s in the export credentials", SecretSASToken)
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, u.endpoint+"/"+url.PathEscape(u.container)+"/"+escapeKey(key)+"?"+token, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", contentType)
+	request.Header.Set("X-Ms-Blob-Type", "BlockBlob")
+	return do(u.client, request)
+}
+
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i := range segments {
+		segments[i] = url.PathEscape(segments[i])
+	}
+	return strings.Join(segments, "/")
+}
+
+func do(client *http.Client, request *http.Request) error {
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return fmt.Errorf("failed to upload %s: %s %s", request.URL.Path, response.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package export
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type uploadRequest struct {
+	method string
+	path   string
+	query  string
+	header http.Header
+	body   string
+}
+
+func newTestStorageServer(t *testing.T, requests *[]uploadRequest) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		*requests = append(*requests, uploadRequest{
+			method: r.Method,
+			path:   r.URL.Path,
+			query:  r.URL.RawQuery,
+			header: r.Header.Clone(),
+			body:   string(body),
+		})
+		w.WriteHeader(http.StatusCreated)
+	}))
+}
+
+func TestS3Uploader(t *testing.T) {
+	var requests []uploadRequest
+	server := newTestStorageServer(t, &requests)
+	defer server.Close()
+	uploader := newS3Uploader(server.URL+"/", "eu-west-1", "reports")
+	credentials := map[string][]byte{
+		SecretAccessKeyID:     []byte("key"),
+		SecretSecretAccessKey: []byte("secret"),
+	}
+	err := uploader.upload(context.TODO(), credentials, "kyverno/policy-reports.json", "application/json", []byte("{}"))
+	assert.NoError(t, err)
+	assert.Len(t, requests, 1)
+	assert.Equal(t, http.MethodPut, requests[0].method)
+	assert.Equal(t, "/reports/kyverno/policy-reports.json", requests[0].path)
+	assert.Equal(t, "{}", requests[0].body)
+	assert.True(t, strings.HasPrefix(requests[0].header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=key/"))
+	assert.Contains(t, requests[0].header.Get("Authorization"), "/eu-west-1/s3/aws4_request")
+	assert.NotEmpty(t, requests[0].header.Get("X-Amz-Content-Sha256"))
+}
+
+func TestS3Uploader_missingCredentials(t *testing.T) {
+	uploader := newS3Uploader("http://localhost", "us-east-1", "reports")
+	err := uploader.upload(context.TODO(), nil, "policy-reports.json", "application/json", []byte("{}"))
+	assert.Error(t, err)
+}
+
+func TestAzureUploader(t *testing.T) {
+	var requests []uploadRequest
+	server := newTestStorageServer(t, &requests)
+	defer server.Close()
+	uploader := newAzureUploader(server.URL, "reports")
+	credentials := map[string][]byte{
+		SecretSASToken: []byte("?sv=2022-11-02&sig=signature"),
+	}
+	err := uploader.upload(context.TODO(), credentials, "policy-reports.sarif", "application/sarif+json", []byte("{}"))
+	assert.NoError(t, err)
+	assert.Len(t, requests, 1)
+	assert.Equal(t, "/reports/policy-reports.sarif", requests[0].path)
+	assert.Equal(t, "sv=2022-11-02&sig=signature", requests[0].query)
+	assert.Equal(t, "BlockBlob", requests[0].header.Get("X-Ms-Blob-Type"))
+	assert.Equal(t, "application/sarif+json", requests[0].header.Get("Content-Type"))
+}
+
+func TestUpload_error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("access denied"))
+	}))
+	defer server.Close()
+	uploader := newAzureUploader(server.URL, "reports")
+	err := uploader.upload(context.TODO(), map[string][]byte{SecretSASToken: []byte("sig=signature")}, "policy-reports.json", "application/json", []byte("{}"))
+	assert.ErrorContains(t, err, "access denied")
+	assert.NotContains(t, err.Error(), "signature")
+}
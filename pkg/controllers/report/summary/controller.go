@@ -0,0 +1,127 @@
+package summary
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	reportsv1 "github.com/kyverno/kyverno/api/reports/v1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	reportsv1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/reports/v1"
+	reportsv1listers "github.com/kyverno/kyverno/pkg/client/listers/reports/v1"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metadatainformers "k8s.io/client-go/metadata/metadatainformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 1
+	ControllerName = "report-summary-controller"
+	maxRetries     = 10
+	// enqueueDelay batches the policy report changes of a namespace in a single reconciliation
+	enqueueDelay = 30 * time.Second
+	// SummaryName is the name of the report summary maintained in each namespace
+	SummaryName = "policy-reports"
+)
+
+type controller struct {
+	// clients
+	client versioned.Interface
+
+	// listers
+	summaryLister reportsv1listers.ReportSummaryLister
+
+	// queue
+	queue workqueue.TypedRateLimitingInterface[any]
+}
+
+func NewController(
+	client versioned.Interface,
+	metadataFactory metadatainformers.SharedInformerFactory,
+	summaryInformer reportsv1informers.ReportSummaryInformer,
+) controllers.Controller {
+	polrInformer := metadataFactory.ForResource(policyreportv1alpha2.SchemeGroupVersion.WithResource("policyreports"))
+	c := controller{
+		client:        client,
+		summaryLister: summaryInformer.Lister(),
+		queue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[any](), ControllerName),
+	}
+	// the queue is keyed by namespace
+	if _, _, err := controllerutils.AddDelayedKeyedEventHandlers(logger, polrInformer.Informer(), c.queue, enqueueDelay, namespaceKey); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	if _, err := controllerutils.AddEventHandlersT(
+		summaryInformer.Informer(),
+		nil,
+		nil,
+		func(obj *reportsv1.ReportSummary) { c.queue.Add(obj.GetNamespace()) },
+	); err != nil {
+		logger.Error(err, "failed to register event handlers")
+	}
+	return &c
+}
+
+func namespaceKey(obj interface{}) (interface{}, error) {
+	meta, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return nil, err
+	}
+	namespace, _, err := cache.SplitMetaNamespaceKey(meta)
+	if err != nil {
+		return nil, err
+	}
+	return namespace, nil
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	controllerutils.Run(ctx, logger, ControllerName, time.Second, c.queue, workers, maxRetries, c.reconcile)
+}
+
+func (c *controller) reconcile(ctx context.Context, logger logr.Logger, namespace, _, _ string) error {
+	// policy reports are listed from the API server, the full reports are not kept in memory
+	reports, err := c.client.Wgpolicyk8sV1alpha2().PolicyReports(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	observed, err := c.summaryLister.ReportSummaries(namespace).Get(SummaryName)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		observed = nil
+	}
+	if len(reports.Items) == 0 {
+		if observed == nil || !controllerutils.IsManagedByKyverno(observed) {
+			return nil
+		}
+		logger.V(2).Info("deleting report summary, namespace has no policy reports")
+		return c.client.ReportsV1().ReportSummaries(namespace).Delete(ctx, SummaryName, metav1.DeleteOptions{})
+	}
+	spec := summarize(reports.Items...)
+	if observed == nil {
+		summary := &reportsv1.ReportSummary{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      SummaryName,
+				Namespace: namespace,
+			},
+			Spec: spec,
+		}
+		controllerutils.SetManagedByKyvernoLabel(summary)
+		_, err := c.client.ReportsV1().ReportSummaries(namespace).Create(ctx, summary, metav1.CreateOptions{})
+		return err
+	}
+	if datautils.DeepEqual(observed.Spec, spec) {
+		return nil
+	}
+	summary := observed.DeepCopy()
+	summary.Spec = spec
+	_, err = c.client.ReportsV1().ReportSummaries(namespace).Update(ctx, summary, metav1.UpdateOptions{})
+	return err
+}
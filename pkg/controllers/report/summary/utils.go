@@ -0,0 +1,99 @@
+package summary
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	reportsv1 "github.com/kyverno/kyverno/api/reports/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// severities lists the severities from the most to the least critical, results without severity come last
+var severities = []policyreportv1alpha2.PolicySeverity{
+	policyreportv1alpha2.SeverityCritical,
+	policyreportv1alpha2.SeverityHigh,
+	policyreportv1alpha2.SeverityMedium,
+	policyreportv1alpha2.SeverityLow,
+	policyreportv1alpha2.SeverityInfo,
+	"",
+}
+
+func count(summary *policyreportv1alpha2.PolicyReportSummary, result policyreportv1alpha2.PolicyResult) {
+	switch result {
+	case policyreportv1alpha2.StatusPass:
+		summary.Pass++
+	case policyreportv1alpha2.StatusFail:
+		summary.Fail++
+	case policyreportv1alpha2.StatusWarn:
+		summary.Warn++
+	case policyreportv1alpha2.StatusError:
+		summary.Error++
+	case policyreportv1alpha2.StatusSkip:
+		summary.Skip++
+	}
+}
+
+func toSeveritySummaries(counts map[policyreportv1alpha2.PolicySeverity]*policyreportv1alpha2.PolicyReportSummary) []reportsv1.SeveritySummary {
+	var result []reportsv1.SeveritySummary
+	for _, severity := range severities {
+		if summary, ok := counts[severity]; ok {
+			result = append(result, reportsv1.SeveritySummary{Severity: severity, Summary: *summary})
+		}
+	}
+	return result
+}
+
+// summarize computes the summary of the given policy reports, policies are sorted by name and severities
+// from the most to the least critical
+func summarize(reports ...policyreportv1alpha2.PolicyReport) reportsv1.ReportSummarySpec {
+	type policyCounts struct {
+		summary    policyreportv1alpha2.PolicyReportSummary
+		severities map[policyreportv1alpha2.PolicySeverity]*policyreportv1alpha2.PolicyReportSummary
+	}
+	spec := reportsv1.ReportSummarySpec{Reports: len(reports)}
+	bySeverity := map[policyreportv1alpha2.PolicySeverity]*policyreportv1alpha2.PolicyReportSummary{}
+	byPolicy := map[string]*policyCounts{}
+	var lastScan int64
+	for _, report := range reports {
+		for _, result := range report.Results {
+			count(&spec.Summary, result.Result)
+			if _, ok := bySeverity[result.Severity]; !ok {
+				bySeverity[result.Severity] = &policyreportv1alpha2.PolicyReportSummary{}
+			}
+			count(bySeverity[result.Severity], result.Result)
+			policy, ok := byPolicy[result.Policy]
+			if !ok {
+				policy = &policyCounts{severities: map[policyreportv1alpha2.PolicySeverity]*policyreportv1alpha2.PolicyReportSummary{}}
+				byPolicy[result.Policy] = policy
+			}
+			count(&policy.summary, result.Result)
+			if _, ok := policy.severities[result.Severity]; !ok {
+				policy.severities[result.Severity] = &policyreportv1alpha2.PolicyReportSummary{}
+			}
+			count(policy.severities[result.Severity], result.Result)
+			if result.Timestamp.Seconds > lastScan {
+				lastScan = result.Timestamp.Seconds
+			}
+		}
+	}
+	spec.Severities = toSeveritySummaries(bySeverity)
+	for name, policy := range byPolicy {
+		spec.Policies = append(spec.Policies, reportsv1.PolicySummary{
+			Policy:     name,
+			Summary:    policy.summary,
+			Severities: toSeveritySummaries(policy.severities),
+		})
+	}
+	slices.SortFunc(spec.Policies, func(a, b reportsv1.PolicySummary) int {
+		return cmp.Compare(a.Policy, b.Policy)
+	})
+	// the time is serialized with a second precision, nanoseconds are dropped so that the computed
+	// summary can be compared with the stored one
+	if lastScan != 0 {
+		t := metav1.NewTime(time.Unix(lastScan, 0))
+		spec.LastScanTime = &t
+	}
+	return spec
+}
@@ -0,0 +1,70 @@
+package summary
+
+import (
+	"testing"
+	"time"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	reportsv1 "github.com/kyverno/kyverno/api/reports/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_summarize(t *testing.T) {
+	reports := []policyreportv1alpha2.PolicyReport{{
+		Results: []policyreportv1alpha2.PolicyReportResult{{
+			Policy:    "require-labels",
+			Rule:      "check-team",
+			Result:    policyreportv1alpha2.StatusFail,
+			Severity:  policyreportv1alpha2.SeverityHigh,
+			Timestamp: metav1.Timestamp{Seconds: 100},
+		}, {
+			Policy:    "require-labels",
+			Rule:      "check-app",
+			Result:    policyreportv1alpha2.StatusPass,
+			Timestamp: metav1.Timestamp{Seconds: 200, Nanos: 42},
+		}},
+	}, {
+		Results: []policyreportv1alpha2.PolicyReportResult{{
+			Policy:    "default/disallow-latest",
+			Rule:      "check-tag",
+			Result:    policyreportv1alpha2.StatusPass,
+			Severity:  policyreportv1alpha2.SeverityCritical,
+			Timestamp: metav1.Timestamp{Seconds: 150},
+		}},
+	}}
+	lastScan := metav1.NewTime(time.Unix(200, 0))
+	want := reportsv1.ReportSummarySpec{
+		Reports:      2,
+		LastScanTime: &lastScan,
+		Summary:      policyreportv1alpha2.PolicyReportSummary{Pass: 2, Fail: 1},
+		Severities: []reportsv1.SeveritySummary{
+			{Severity: policyreportv1alpha2.SeverityCritical, Summary: policyreportv1alpha2.PolicyReportSummary{Pass: 1}},
+			{Severity: policyreportv1alpha2.SeverityHigh, Summary: policyreportv1alpha2.PolicyReportSummary{Fail: 1}},
+			{Summary: policyreportv1alpha2.PolicyReportSummary{Pass: 1}},
+		},
+		Policies: []reportsv1.PolicySummary{{
+			Policy:  "default/disallow-latest",
+			Summary: policyreportv1alpha2.PolicyReportSummary{Pass: 1},
+			Severities: []reportsv1.SeveritySummary{
+				{Severity: policyreportv1alpha2.SeverityCritical, Summary: policyreportv1alpha2.PolicyReportSummary{Pass: 1}},
+			},
+		}, {
+			Policy:  "require-labels",
+			Summary: policyreportv1alpha2.PolicyReportSummary{Pass: 1, Fail: 1},
+			Severities: []reportsv1.SeveritySummary{
+				{Severity: policyreportv1alpha2.SeverityHigh, Summary: policyreportv1alpha2.PolicyReportSummary{Fail: 1}},
+				{Summary: policyreportv1alpha2.PolicyReportSummary{Pass: 1}},
+			},
+		}},
+	}
+	assert.Equal(t, want, summarize(reports...))
+}
+
+func Test_summarize_empty(t *testing.T) {
+	spec := summarize(policyreportv1alpha2.PolicyReport{})
+	assert.Equal(t, 1, spec.Reports)
+	assert.Nil(t, spec.LastScanTime)
+	assert.Empty(t, spec.Policies)
+	assert.Empty(t, spec.Severities)
+}
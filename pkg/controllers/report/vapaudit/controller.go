@@ -0,0 +1,268 @@
+package vapaudit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sync"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	kyvernov1informers "github.com/kyverno/kyverno/pkg/client/informers/externalversions/kyverno/v1"
+	kyvernov1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	"k8s.io/client-go/metadata"
+)
+
+const (
+	// Workers is the number of workers for this controller
+	Workers        = 2
+	ControllerName = "vap-audit-controller"
+	// maxQueuedEvents is the maximum number of audit events waiting to be ingested
+	maxQueuedEvents = 1000
+	// annotationValidationFailure is the audit annotation set by the API server when a validating admission
+	// policy with the Audit action fails
+	annotationValidationFailure = "validation.policy.admission.k8s.io/validation_failure"
+	// propertyAction is the result property holding the validation actions of the failed binding
+	propertyAction = "vap-action"
+)
+
+// denied matches the status message of a request denied by a validating admission policy
+var denied = regexp.MustCompile(`^ValidatingAdmissionPolicy '([^']+)' with binding '([^']+)' denied request: (.*)$`)
+
+// validationFailure is an entry of the validation failure audit annotation
+type validationFailure struct {
+	Message           string                                     `json:"message"`
+	Policy            string                                     `json:"policy"`
+	Binding           string                                     `json:"binding"`
+	ExpressionIndex   int                                        `json:"expressionIndex"`
+	ValidationActions []admissionregistrationv1.ValidationAction `json:"validationActions"`
+}
+
+// Controller records the validations of the validating admission policies generated by kyverno, received from
+// the API server audit events, as results of the policies they were generated from
+type Controller interface {
+	controllers.Controller
+	// Ingest queues the audit events, it never blocks and drops events when the queue is full
+	Ingest(context.Context, ...auditv1.Event)
+}
+
+type controller struct {
+	// clients
+	metadataClient metadata.Interface
+	discovery      dclient.IDiscovery
+	reportsWriter  reportutils.ReportWriter
+
+	// listers
+	cpolLister kyvernov1listers.ClusterPolicyLister
+
+	// queue
+	events chan auditv1.Event
+}
+
+func NewController(
+	metadataClient metadata.Interface,
+	discovery dclient.IDiscovery,
+	reportsWriter reportutils.ReportWriter,
+	cpolInformer kyvernov1informers.ClusterPolicyInformer,
+) Controller {
+	return &controller{
+		metadataClient: metadataClient,
+		discovery:      discovery,
+		reportsWriter:  reportsWriter,
+		cpolLister:     cpolInformer.Lister(),
+		events:         make(chan auditv1.Event, maxQueuedEvents),
+	}
+}
+
+func (c *controller) Ingest(ctx context.Context, events ...auditv1.Event) {
+	for _, event := range events {
+		// the validations are known once the response is complete
+		if event.Stage != auditv1.StageResponseComplete {
+			continue
+		}
+		if _, ok := event.Annotations[annotationValidationFailure]; !ok && !isDenied(event) {
+			continue
+		}
+		select {
+		case c.events <- event:
+		default:
+			logger.Info("audit events queue is full, dropping event", "auditID", event.AuditID)
+		}
+	}
+}
+
+func (c *controller) Run(ctx context.Context, workers int) {
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case event := <-c.events:
+					if err := c.reconcile(ctx, event); err != nil {
+						logger.Error(err, "failed to ingest audit event", "auditID", event.AuditID)
+					}
+				}
+			}
+		}()
+	}
+	<-ctx.Done()
+	wg.Wait()
+}
+
+func isDenied(event auditv1.Event) bool {
+	return event.ResponseStatus != nil && denied.MatchString(event.ResponseStatus.Message)
+}
+
+// validationFailures returns the failed validations recorded in an audit event, both audited and denied
+func validationFailures(event auditv1.Event) ([]validationFailure, error) {
+	var failures []validationFailure
+	if value, ok := event.Annotations[annotationValidationFailure]; ok {
+		if err := json.Unmarshal([]byte(value), &failures); err != nil {
+			return nil, fmt.Errorf("invalid %s annotation: %w", annotationValidationFailure, err)
+		}
+	}
+	if event.ResponseStatus != nil {
+		if match := denied.FindStringSubmatch(event.ResponseStatus.Message); match != nil {
+			failures = append(failures, validationFailure{
+				Policy:            match[1],
+				Binding:           match[2],
+				Message:           match[3],
+				ValidationActions: []admissionregistrationv1.ValidationAction{admissionregistrationv1.Deny},
+			})
+		}
+	}
+	return failures, nil
+}
+
+// clusterPolicy returns the policy a validating admission policy was generated from, or nil when it
+// wasn't generated by kyverno
+func (c *controller) clusterPolicy(failure validationFailure) (*kyvernov1.ClusterPolicy, error) {
+	// generated policies and bindings are named after the kyverno policy
+	if failure.Binding != failure.Policy+"-binding" {
+		return nil, nil
+	}
+	cpol, err := c.cpolLister.Get(failure.Policy)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !cpol.GetStatus().ValidatingAdmissionPolicy.Generated || len(cpol.GetSpec().Rules) != 1 {
+		return nil, nil
+	}
+	return cpol, nil
+}
+
+// resourceUid returns the uid of the audited resource, from the event or from the cluster, it is empty
+// when the resource doesn't exist (e.g. a denied creation)
+func (c *controller) resourceUid(ctx context.Context, event auditv1.Event, gvr schema.GroupVersionResource) (types.UID, error) {
+	if event.ObjectRef.UID != "" {
+		return event.ObjectRef.UID, nil
+	}
+	for _, object := range []*runtime.Unknown{event.ResponseObject, event.RequestObject} {
+		if uid := objectUid(object); uid != "" {
+			return uid, nil
+		}
+	}
+	meta, err := c.metadataClient.Resource(gvr).Namespace(event.ObjectRef.Namespace).Get(ctx, event.ObjectRef.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return meta.GetUID(), nil
+}
+
+func (c *controller) reconcile(ctx context.Context, event auditv1.Event) error {
+	if event.ObjectRef == nil || event.ObjectRef.Name == "" || event.ObjectRef.Subresource != "" {
+		return nil
+	}
+	failures, err := validationFailures(event)
+	if err != nil {
+		return err
+	}
+	var policies []*kyvernov1.ClusterPolicy
+	var results []policyreportv1alpha2.PolicyReportResult
+	for _, failure := range failures {
+		cpol, err := c.clusterPolicy(failure)
+		if err != nil {
+			return err
+		}
+		if cpol == nil {
+			continue
+		}
+		rule := cpol.GetSpec().Rules[0].Name
+		annotations := cpol.GetAnnotations()
+		if reportutils.IsExcludedFromReports(annotations, rule) {
+			continue
+		}
+		result := reportutils.ToPolicyReportResult(
+			engineapi.KyvernoPolicyType,
+			cpol.GetName(),
+			*engineapi.RuleFail(rule, engineapi.Validation, failure.Message, nil),
+			annotations,
+			nil,
+		)
+		result.Timestamp = metav1.Timestamp{Seconds: event.StageTimestamp.Unix()}
+		if result.Properties == nil {
+			result.Properties = map[string]string{}
+		}
+		result.Properties["validatingadmissionpolicy"] = failure.Policy
+		result.Properties["binding"] = failure.Binding
+		result.Properties[propertyAction] = joinActions(failure.ValidationActions)
+		policies = append(policies, cpol)
+		results = append(results, result)
+	}
+	if len(results) == 0 {
+		return nil
+	}
+	gvr := schema.GroupVersionResource{
+		Group:    event.ObjectRef.APIGroup,
+		Version:  event.ObjectRef.APIVersion,
+		Resource: event.ObjectRef.Resource,
+	}
+	gvk, err := c.discovery.GetGVKFromGVR(gvr)
+	if err != nil {
+		return err
+	}
+	if !reportutils.IsGvkSupported(gvk) {
+		return nil
+	}
+	uid, err := c.resourceUid(ctx, event, gvr)
+	if err != nil {
+		return err
+	}
+	if uid == "" {
+		logger.V(4).Info("audited resource doesn't exist, skipping", "auditID", event.AuditID, "gvr", gvr, "name", event.ObjectRef.Name)
+		return nil
+	}
+	var resource unstructured.Unstructured
+	resource.SetUID(uid)
+	resource.SetNamespace(event.ObjectRef.Namespace)
+	resource.SetName(event.ObjectRef.Name)
+	report := reportutils.NewAdmissionReport(event.ObjectRef.Namespace, string(event.AuditID), gvr, gvk, resource)
+	for _, cpol := range policies {
+		reportutils.SetPolicyLabel(report, engineapi.NewKyvernoPolicy(cpol))
+	}
+	reportutils.SetResults(report, results...)
+	return c.reportsWriter.Write(ctx, report)
+}
@@ -0,0 +1,157 @@
+package vapaudit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	reportsv1 "github.com/kyverno/kyverno/api/reports/v1"
+	versionedfake "github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernoinformers "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"github.com/stretchr/testify/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+)
+
+type testWriter struct {
+	reports []reportsv1.ReportInterface
+}
+
+func (w *testWriter) Write(_ context.Context, report reportsv1.ReportInterface) error {
+	w.reports = append(w.reports, report)
+	return nil
+}
+
+func (w *testWriter) Close() {}
+
+func newTestController(t *testing.T, policies ...*kyvernov1.ClusterPolicy) (*controller, *testWriter) {
+	factory := kyvernoinformers.NewSharedInformerFactory(versionedfake.NewSimpleClientset(), 0)
+	cpolInformer := factory.Kyverno().V1().ClusterPolicies()
+	for _, policy := range policies {
+		assert.NoError(t, cpolInformer.Informer().GetIndexer().Add(policy))
+	}
+	writer := &testWriter{}
+	c := NewController(nil, dclient.NewFakeDiscoveryClient(nil), writer, cpolInformer)
+	return c.(*controller), writer
+}
+
+func testPolicy(name string, generated bool) *kyvernov1.ClusterPolicy {
+	return &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			ResourceVersion: "42",
+			Annotations: map[string]string{
+				"policies.kyverno.io/severity": "high",
+			},
+		},
+		Spec: kyvernov1.Spec{
+			Rules: []kyvernov1.Rule{{Name: "check-labels"}},
+		},
+		Status: kyvernov1.PolicyStatus{
+			ValidatingAdmissionPolicy: kyvernov1.ValidatingAdmissionPolicyStatus{Generated: generated},
+		},
+	}
+}
+
+func testEvent(annotation, status string) auditv1.Event {
+	event := auditv1.Event{
+		AuditID:        "audit-id",
+		Stage:          auditv1.StageResponseComplete,
+		StageTimestamp: metav1.NewMicroTime(time.Unix(1000, 0)),
+		ObjectRef: &auditv1.ObjectReference{
+			Resource:   "pods",
+			Namespace:  "default",
+			Name:       "nginx",
+			UID:        "pod-uid",
+			APIVersion: "v1",
+		},
+	}
+	if annotation != "" {
+		event.Annotations = map[string]string{annotationValidationFailure: annotation}
+	}
+	if status != "" {
+		event.ResponseStatus = &metav1.Status{Message: status}
+	}
+	return event
+}
+
+func Test_validationFailures(t *testing.T) {
+	failures, err := validationFailures(testEvent(
+		`[{"message":"label is required","policy":"require-labels","binding":"require-labels-binding","expressionIndex":0,"validationActions":["Audit"]}]`,
+		"ValidatingAdmissionPolicy 'disallow-latest' with binding 'disallow-latest-binding' denied request: latest tag is not allowed",
+	))
+	assert.NoError(t, err)
+	assert.Equal(t, []validationFailure{{
+		Message:           "label is required",
+		Policy:            "require-labels",
+		Binding:           "require-labels-binding",
+		ValidationActions: []admissionregistrationv1.ValidationAction{admissionregistrationv1.Audit},
+	}, {
+		Message:           "latest tag is not allowed",
+		Policy:            "disallow-latest",
+		Binding:           "disallow-latest-binding",
+		ValidationActions: []admissionregistrationv1.ValidationAction{admissionregistrationv1.Deny},
+	}}, failures)
+	_, err = validationFailures(testEvent("not json", ""))
+	assert.Error(t, err)
+	failures, err = validationFailures(testEvent("", "pods \"nginx\" is forbidden"))
+	assert.NoError(t, err)
+	assert.Empty(t, failures)
+}
+
+func Test_objectUid(t *testing.T) {
+	assert.Empty(t, objectUid(nil))
+	assert.Empty(t, objectUid(&runtime.Unknown{Raw: []byte(`{"kind":"Status","message":"denied"}`)}))
+	assert.Empty(t, objectUid(&runtime.Unknown{Raw: []byte(`not json`)}))
+	assert.Equal(t, "uid", string(objectUid(&runtime.Unknown{Raw: []byte(`{"kind":"Pod","metadata":{"name":"nginx","uid":"uid"}}`)})))
+}
+
+func TestIngest(t *testing.T) {
+	c, _ := newTestController(t)
+	received := testEvent(`[]`, "")
+	received.Stage = auditv1.StageRequestReceived
+	c.Ingest(context.TODO(), received, testEvent("", ""), testEvent(`[]`, ""), testEvent("", "ValidatingAdmissionPolicy 'a' with binding 'a-binding' denied request: no"))
+	assert.Len(t, c.events, 2)
+}
+
+func TestReconcile(t *testing.T) {
+	c, writer := newTestController(t, testPolicy("require-labels", true), testPolicy("not-generated", false))
+	event := testEvent(
+		`[{"message":"label is required","policy":"require-labels","binding":"require-labels-binding","validationActions":["Audit","Warn"]},`+
+			`{"message":"ignored","policy":"not-generated","binding":"not-generated-binding","validationActions":["Audit"]},`+
+			`{"message":"ignored","policy":"unknown","binding":"unknown-binding","validationActions":["Audit"]},`+
+			`{"message":"ignored","policy":"require-labels","binding":"other-binding","validationActions":["Audit"]}]`,
+		"",
+	)
+	assert.NoError(t, c.reconcile(context.TODO(), event))
+	assert.Len(t, writer.reports, 1)
+	report := writer.reports[0]
+	assert.Equal(t, "default", report.GetNamespace())
+	assert.Equal(t, "pod-uid", string(reportutils.GetResourceUid(report)))
+	assert.Equal(t, "42", report.GetLabels()[reportutils.LabelPrefixClusterPolicy+"require-labels"])
+	results := report.GetResults()
+	assert.Len(t, results, 1)
+	assert.Equal(t, "require-labels", results[0].Policy)
+	assert.Equal(t, "check-labels", results[0].Rule)
+	assert.Equal(t, "label is required", results[0].Message)
+	assert.Equal(t, policyreportv1alpha2.StatusFail, results[0].Result)
+	assert.Equal(t, policyreportv1alpha2.SeverityHigh, results[0].Severity)
+	assert.Equal(t, int64(1000), results[0].Timestamp.Seconds)
+	assert.Equal(t, "require-labels", results[0].Properties["validatingadmissionpolicy"])
+	assert.Equal(t, "require-labels-binding", results[0].Properties["binding"])
+	assert.Equal(t, "Audit,Warn", results[0].Properties[propertyAction])
+	// events without a kyverno generated policy don't create reports
+	writer.reports = nil
+	assert.NoError(t, c.reconcile(context.TODO(), testEvent(`[{"message":"ignored","policy":"unknown","binding":"unknown-binding"}]`, "")))
+	assert.Empty(t, writer.reports)
+	// subresources are ignored
+	event.ObjectRef.Subresource = "status"
+	assert.NoError(t, c.reconcile(context.TODO(), event))
+	assert.Empty(t, writer.reports)
+}
@@ -0,0 +1,5 @@
+package vapaudit
+
+import "github.com/kyverno/kyverno/pkg/logging"
+
+var logger = logging.ControllerLogger(ControllerName)
@@ -0,0 +1,34 @@
+package vapaudit
+
+import (
+	"encoding/json"
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// objectUid returns the uid of an object recorded in an audit event, it is empty when the object wasn't
+// recorded (the audit level is below RequestResponse) or isn't a kubernetes object (e.g. a status)
+func objectUid(object *runtime.Unknown) types.UID {
+	if object == nil || len(object.Raw) == 0 {
+		return ""
+	}
+	var meta struct {
+		Metadata metav1.ObjectMeta `json:"metadata"`
+	}
+	if err := json.Unmarshal(object.Raw, &meta); err != nil {
+		return ""
+	}
+	return meta.Metadata.UID
+}
+
+func joinActions(actions []admissionregistrationv1.ValidationAction) string {
+	var names []string
+	for _, action := range actions {
+		names = append(names, string(action))
+	}
+	return strings.Join(names, ",")
+}
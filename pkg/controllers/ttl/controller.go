@@ -15,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/tools/cache"
@@ -29,6 +30,7 @@ const (
 type controller struct {
 	name         string
 	client       metadata.Getter
+	dynamic      dynamic.NamespaceableResourceInterface
 	queue        workqueue.TypedRateLimitingInterface[any]
 	lister       cache.GenericLister
 	informer     cache.SharedIndexInformer
@@ -43,7 +45,7 @@ type ttlMetrics struct {
 	ttlFailureTotal     metric.Int64Counter
 }
 
-func newController(client metadata.Getter, metainformer informers.GenericInformer, logger logr.Logger, gvr schema.GroupVersionResource) (*controller, error) {
+func newController(client metadata.Getter, dynamicClient dynamic.NamespaceableResourceInterface, metainformer informers.GenericInformer, logger logr.Logger, gvr schema.GroupVersionResource) (*controller, error) {
 	name := gvr.Version + "/" + gvr.Resource
 	if gvr.Group != "" {
 		name = gvr.Group + "/" + name
@@ -52,6 +54,7 @@ func newController(client metadata.Getter, metainformer informers.GenericInforme
 	c := &controller{
 		name:     name,
 		client:   client,
+		dynamic:  dynamicClient,
 		queue:    queue,
 		lister:   metainformer.Lister(),
 		informer: metainformer.Informer(),
@@ -157,9 +160,18 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, itemKey
 		// No 'ttl' label present, no further action needed
 		return nil
 	}
+	referenceTime, err := getReferenceTime(ctx, c.dynamic, metaObj)
+	if err != nil {
+		logger.Error(err, "failed to get the ttl reference time")
+		return err
+	}
+	if referenceTime == nil {
+		// the referenced timestamp is not set yet, the resource will be updated when it is
+		return nil
+	}
 	var deletionTime time.Time
 	// Try parsing ttlValue as duration
-	if err := parseDeletionTime(metaObj, &deletionTime, ttlValue); err != nil {
+	if err := parseDeletionTimeFrom(*referenceTime, &deletionTime, ttlValue); err != nil {
 		logger.Error(err, "failed to parse label", "value", ttlValue)
 		return nil
 	}
@@ -20,6 +20,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/metadata/metadatainformer"
 	"k8s.io/client-go/tools/cache"
@@ -34,6 +35,7 @@ const (
 
 type manager struct {
 	metadataClient  metadata.Interface
+	dynamicClient   dynamic.Interface
 	discoveryClient discovery.DiscoveryInterface
 	checker         checker.AuthChecker
 	resController   map[schema.GroupVersionResource]stopFunc
@@ -46,6 +48,7 @@ type manager struct {
 
 func NewManager(
 	metadataInterface metadata.Interface,
+	dynamicInterface dynamic.Interface,
 	discoveryInterface discovery.DiscoveryInterface,
 	checker checker.AuthChecker,
 	timeInterval time.Duration,
@@ -63,6 +66,7 @@ func NewManager(
 	}
 	mgr := &manager{
 		metadataClient:  metadataInterface,
+		dynamicClient:   dynamicInterface,
 		discoveryClient: discoveryInterface,
 		checker:         checker,
 		resController:   map[schema.GroupVersionResource]stopFunc{},
@@ -167,7 +171,7 @@ func (m *manager) start(ctx context.Context, gvr schema.GroupVersionResource, wo
 		stopInformer()
 		return fmt.Errorf("failed to wait for cache sync: %s", gvr.Resource)
 	}
-	controller, err := newController(m.metadataClient.Resource(gvr), informer, logger, gvr)
+	controller, err := newController(m.metadataClient.Resource(gvr), m.dynamicClient.Resource(gvr), informer, logger, gvr)
 	if err != nil {
 		stopInformer()
 		return err
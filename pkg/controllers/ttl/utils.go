@@ -2,15 +2,19 @@ package ttl
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/api/kyverno"
 	checker "github.com/kyverno/kyverno/pkg/auth/checker"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/kube-openapi/pkg/validation/strfmt"
 )
 
@@ -52,10 +56,14 @@ func HasResourcePermissions(logger logr.Logger, resource schema.GroupVersionReso
 }
 
 func parseDeletionTime(metaObj metav1.Object, deletionTime *time.Time, ttlValue string) error {
+	return parseDeletionTimeFrom(metaObj.GetCreationTimestamp().Time, deletionTime, ttlValue)
+}
+
+// parseDeletionTimeFrom computes the deletion time, ttl durations are relative to the reference time
+func parseDeletionTimeFrom(referenceTime time.Time, deletionTime *time.Time, ttlValue string) error {
 	ttlDuration, err := strfmt.ParseDuration(ttlValue)
 	if err == nil {
-		creationTime := metaObj.GetCreationTimestamp().Time
-		*deletionTime = creationTime.Add(ttlDuration)
+		*deletionTime = referenceTime.Add(ttlDuration)
 	} else {
 		// Try parsing ttlValue as a time in ISO 8601 format
 		*deletionTime, err = time.Parse(kyverno.ValueTtlDateTimeLayout, ttlValue)
@@ -68,3 +76,40 @@ func parseDeletionTime(metaObj metav1.Object, deletionTime *time.Time, ttlValue
 	}
 	return nil
 }
+
+// getReferenceTime returns the time ttl durations are relative to, it is the creation time unless the
+// cleanup.kyverno.io/ttl-from annotation references another timestamp field, either an annotation
+// (metadata.annotations.<key>) or a field of the resource (e.g. status.completionTime).
+// It returns nil when the referenced field is not set yet.
+func getReferenceTime(ctx context.Context, client dynamic.NamespaceableResourceInterface, metaObj metav1.Object) (*time.Time, error) {
+	path, ok := metaObj.GetAnnotations()[kyverno.AnnotationCleanupTtlFrom]
+	if !ok || path == "" || path == "metadata.creationTimestamp" {
+		creationTime := metaObj.GetCreationTimestamp().Time
+		return &creationTime, nil
+	}
+	var value string
+	if key, ok := strings.CutPrefix(path, "metadata.annotations."); ok {
+		if value, ok = metaObj.GetAnnotations()[key]; !ok {
+			return nil, nil
+		}
+	} else {
+		// the metadata informer doesn't hold the other fields, the resource is fetched
+		obj, err := client.Namespace(metaObj.GetNamespace()).Get(ctx, metaObj.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		field, found, err := unstructured.NestedString(obj.Object, strings.Split(path, ".")...)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s annotation %s: %w", kyverno.AnnotationCleanupTtlFrom, path, err)
+		}
+		if !found || field == "" {
+			return nil, nil
+		}
+		value = field
+	}
+	referenceTime, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("field %s is not a timestamp: %w", path, err)
+	}
+	return &referenceTime, nil
+}
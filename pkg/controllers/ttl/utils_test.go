@@ -1,10 +1,16 @@
 package ttl
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/kyverno/kyverno/api/kyverno"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 )
 
 type mockMetaObj struct {
@@ -71,3 +77,81 @@ func TestParseDeletionTime(t *testing.T) {
 		}
 	}
 }
+
+func TestGetReferenceTime(t *testing.T) {
+	creationTime := time.Date(2023, 7, 18, 12, 0, 0, 0, time.UTC)
+	completionTime := time.Date(2023, 7, 18, 13, 0, 0, 0, time.UTC)
+	job := &unstructured.Unstructured{}
+	job.SetAPIVersion("batch/v1")
+	job.SetKind("Job")
+	job.SetNamespace("default")
+	job.SetName("job")
+	if err := unstructured.SetNestedField(job.Object, completionTime.Format(time.RFC3339), "status", "completionTime"); err != nil {
+		t.Fatal(err)
+	}
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), job).Resource(schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"})
+	tests := []struct {
+		name        string
+		ttlFrom     string
+		annotations map[string]string
+		expected    *time.Time
+		expectError bool
+	}{{
+		name:     "creation timestamp by default",
+		expected: &creationTime,
+	}, {
+		name:     "status field",
+		ttlFrom:  "status.completionTime",
+		expected: &completionTime,
+	}, {
+		name:     "missing status field",
+		ttlFrom:  "status.startTime",
+		expected: nil,
+	}, {
+		name:        "annotation",
+		ttlFrom:     "metadata.annotations.example.com/finished-at",
+		annotations: map[string]string{"example.com/finished-at": "2023-07-18T13:00:00Z"},
+		expected:    &completionTime,
+	}, {
+		name:        "invalid annotation value",
+		ttlFrom:     "metadata.annotations.example.com/finished-at",
+		annotations: map[string]string{"example.com/finished-at": "yesterday"},
+		expectError: true,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			annotations := map[string]string{}
+			for key, value := range test.annotations {
+				annotations[key] = value
+			}
+			if test.ttlFrom != "" {
+				annotations[kyverno.AnnotationCleanupTtlFrom] = test.ttlFrom
+			}
+			metaObj := &mockMetaObj{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:         "default",
+					Name:              "job",
+					CreationTimestamp: metav1.NewTime(creationTime),
+					Annotations:       annotations,
+				},
+			}
+			referenceTime, err := getReferenceTime(context.TODO(), client, metaObj)
+			if test.expectError {
+				if err == nil {
+					t.Errorf("Expected an error but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Expected no error but got: %v", err)
+			}
+			if test.expected == nil {
+				if referenceTime != nil {
+					t.Errorf("Expected no reference time but got: %v", referenceTime)
+				}
+			} else if referenceTime == nil || !referenceTime.Equal(*test.expected) {
+				t.Errorf("Expected reference time: %v but got: %v", test.expected, referenceTime)
+			}
+		})
+	}
+}
@@ -22,9 +22,11 @@ import (
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/controllers"
 	"github.com/kyverno/kyverno/pkg/tls"
+	"github.com/kyverno/kyverno/pkg/toggle"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
 	runtimeutils "github.com/kyverno/kyverno/pkg/utils/runtime"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
@@ -99,7 +101,6 @@ type controller struct {
 	cpolLister        kyvernov1listers.ClusterPolicyLister
 	polLister         kyvernov1listers.PolicyLister
 	deploymentLister  appsv1listers.DeploymentLister
-	secretLister      corev1listers.SecretLister
 	leaseLister       coordinationv1listers.LeaseLister
 	clusterroleLister rbacv1listers.ClusterRoleLister
 	gctxentryLister   kyvernov2alpha1listers.GlobalContextEntryLister
@@ -116,7 +117,7 @@ type controller struct {
 	admissionReports    bool
 	runtime             runtimeutils.Runtime
 	configuration       config.Configuration
-	caSecretName        string
+	certificates        tls.CertificateSource
 	webhooksDeleted     bool
 	webhookCleanupSetup func(context.Context, logr.Logger) error
 	postWebhookCleanup  func(context.Context, logr.Logger) error
@@ -137,7 +138,7 @@ func NewController(
 	cpolInformer kyvernov1informers.ClusterPolicyInformer,
 	polInformer kyvernov1informers.PolicyInformer,
 	deploymentInformer appsv1informers.DeploymentInformer,
-	secretInformer corev1informers.SecretInformer,
+	certificates tls.CertificateSource,
 	leaseInformer coordinationv1informers.LeaseInformer,
 	clusterroleInformer rbacv1informers.ClusterRoleInformer,
 	gctxentryInformer kyvernov2alpha1informers.GlobalContextEntryInformer,
@@ -150,7 +151,6 @@ func NewController(
 	admissionReports bool,
 	runtime runtimeutils.Runtime,
 	configuration config.Configuration,
-	caSecretName string,
 	webhookCleanupSetup func(context.Context, logr.Logger) error,
 	postWebhookCleanup func(context.Context, logr.Logger) error,
 ) controllers.Controller {
@@ -166,7 +166,6 @@ func NewController(
 		cpolLister:          cpolInformer.Lister(),
 		polLister:           polInformer.Lister(),
 		deploymentLister:    deploymentInformer.Lister(),
-		secretLister:        secretInformer.Lister(),
 		leaseLister:         leaseInformer.Lister(),
 		clusterroleLister:   clusterroleInformer.Lister(),
 		gctxentryLister:     gctxentryInformer.Lister(),
@@ -179,7 +178,7 @@ func NewController(
 		admissionReports:    admissionReports,
 		runtime:             runtime,
 		configuration:       configuration,
-		caSecretName:        caSecretName,
+		certificates:        certificates,
 		webhookCleanupSetup: webhookCleanupSetup,
 		postWebhookCleanup:  postWebhookCleanup,
 		policyState: map[string]sets.Set[string]{
@@ -193,26 +192,7 @@ func NewController(
 	if _, _, err := controllerutils.AddDefaultEventHandlers(logger, vwcInformer.Informer(), queue); err != nil {
 		logger.Error(err, "failed to register event handlers")
 	}
-	if _, err := controllerutils.AddEventHandlersT(
-		secretInformer.Informer(),
-		func(obj *corev1.Secret) {
-			if obj.GetNamespace() == config.KyvernoNamespace() && obj.GetName() == caSecretName {
-				c.enqueueAll()
-			}
-		},
-		func(_, obj *corev1.Secret) {
-			if obj.GetNamespace() == config.KyvernoNamespace() && obj.GetName() == caSecretName {
-				c.enqueueAll()
-			}
-		},
-		func(obj *corev1.Secret) {
-			if obj.GetNamespace() == config.KyvernoNamespace() && obj.GetName() == caSecretName {
-				c.enqueueAll()
-			}
-		},
-	); err != nil {
-		logger.Error(err, "failed to register event handlers")
-	}
+	certificates.AddEventHandler(c.enqueueAll)
 	if autoDeleteWebhooks {
 		if _, err := controllerutils.AddEventHandlersT(
 			deploymentInformer.Informer(),
@@ -457,7 +437,7 @@ func (c *controller) reconcileWebhookDeletion(ctx context.Context) error {
 }
 
 func (c *controller) reconcileValidatingWebhookConfiguration(ctx context.Context, autoUpdateWebhooks bool, build func(context.Context, config.Configuration, []byte) (*admissionregistrationv1.ValidatingWebhookConfiguration, error)) error {
-	caData, err := tls.ReadRootCASecret(c.caSecretName, config.KyvernoNamespace(), c.secretLister.Secrets(config.KyvernoNamespace()))
+	caData, err := c.certificates.CABundle()
 	if err != nil {
 		return err
 	}
@@ -487,7 +467,7 @@ func (c *controller) reconcileValidatingWebhookConfiguration(ctx context.Context
 }
 
 func (c *controller) reconcileMutatingWebhookConfiguration(ctx context.Context, autoUpdateWebhooks bool, build func(context.Context, config.Configuration, []byte) (*admissionregistrationv1.MutatingWebhookConfiguration, error)) error {
-	caData, err := tls.ReadRootCASecret(c.caSecretName, config.KyvernoNamespace(), c.secretLister.Secrets(config.KyvernoNamespace()))
+	caData, err := c.certificates.CABundle()
 	if err != nil {
 		return err
 	}
@@ -693,6 +673,14 @@ func (c *controller) reconcile(ctx context.Context, logger logr.Logger, key, nam
 	return nil
 }
 
+// webhookTimeout returns the timeout of the webhooks, the configuration overrides the default timeout
+func (c *controller) webhookTimeout(cfg config.Configuration) int32 {
+	if timeout, ok := cfg.GetWebhookTimeout(); ok {
+		return timeout
+	}
+	return c.defaultTimeout
+}
+
 func (c *controller) buildVerifyMutatingWebhookConfiguration(_ context.Context, cfg config.Configuration, caBundle []byte) (*admissionregistrationv1.MutatingWebhookConfiguration, error) {
 	return &admissionregistrationv1.MutatingWebhookConfiguration{
 			ObjectMeta: objectMeta(config.VerifyMutatingWebhookConfigurationName, cfg.GetWebhookAnnotations(), cfg.GetWebhookLabels(), c.buildOwner()...),
@@ -707,7 +695,7 @@ func (c *controller) buildVerifyMutatingWebhookConfiguration(_ context.Context,
 				}},
 				FailurePolicy:           &ignore,
 				SideEffects:             &noneOnDryRun,
-				TimeoutSeconds:          &c.defaultTimeout,
+				TimeoutSeconds:          ptr.To(c.webhookTimeout(cfg)),
 				ReinvocationPolicy:      &ifNeeded,
 				AdmissionReviewVersions: []string{"v1"},
 				ObjectSelector: &metav1.LabelSelector{
@@ -735,7 +723,7 @@ func (c *controller) buildPolicyMutatingWebhookConfiguration(_ context.Context,
 					},
 				}},
 				FailurePolicy:           &fail,
-				TimeoutSeconds:          &c.defaultTimeout,
+				TimeoutSeconds:          ptr.To(c.webhookTimeout(cfg)),
 				SideEffects:             &noneOnDryRun,
 				ReinvocationPolicy:      &ifNeeded,
 				AdmissionReviewVersions: []string{"v1"},
@@ -759,7 +747,7 @@ func (c *controller) buildPolicyValidatingWebhookConfiguration(_ context.Context
 					},
 				}},
 				FailurePolicy:           &fail,
-				TimeoutSeconds:          &c.defaultTimeout,
+				TimeoutSeconds:          ptr.To(c.webhookTimeout(cfg)),
 				SideEffects:             &none,
 				AdmissionReviewVersions: []string{"v1"},
 				MatchPolicy:             ptr.To(admissionregistrationv1.Equivalent),
@@ -769,6 +757,7 @@ func (c *controller) buildPolicyValidatingWebhookConfiguration(_ context.Context
 }
 
 func (c *controller) buildDefaultResourceMutatingWebhookConfiguration(_ context.Context, cfg config.Configuration, caBundle []byte) (*admissionregistrationv1.MutatingWebhookConfiguration, error) {
+	reinvocationPolicy := cfg.GetWebhook().GetReinvocationPolicy()
 	return &admissionregistrationv1.MutatingWebhookConfiguration{
 			ObjectMeta: objectMeta(config.MutatingWebhookConfigurationName, cfg.GetWebhookAnnotations(), cfg.GetWebhookLabels(), c.buildOwner()...),
 			Webhooks: []admissionregistrationv1.MutatingWebhook{{
@@ -788,8 +777,8 @@ func (c *controller) buildDefaultResourceMutatingWebhookConfiguration(_ context.
 				FailurePolicy:           &ignore,
 				SideEffects:             &noneOnDryRun,
 				AdmissionReviewVersions: []string{"v1"},
-				TimeoutSeconds:          &c.defaultTimeout,
-				ReinvocationPolicy:      &ifNeeded,
+				TimeoutSeconds:          ptr.To(c.webhookTimeout(cfg)),
+				ReinvocationPolicy:      &reinvocationPolicy,
 				MatchPolicy:             ptr.To(admissionregistrationv1.Equivalent),
 			}, {
 				Name:         config.MutatingWebhookName + "-fail",
@@ -808,8 +797,8 @@ func (c *controller) buildDefaultResourceMutatingWebhookConfiguration(_ context.
 				FailurePolicy:           &fail,
 				SideEffects:             &noneOnDryRun,
 				AdmissionReviewVersions: []string{"v1"},
-				TimeoutSeconds:          &c.defaultTimeout,
-				ReinvocationPolicy:      &ifNeeded,
+				TimeoutSeconds:          ptr.To(c.webhookTimeout(cfg)),
+				ReinvocationPolicy:      &reinvocationPolicy,
 				MatchPolicy:             ptr.To(admissionregistrationv1.Equivalent),
 			}},
 		},
@@ -823,8 +812,8 @@ func (c *controller) buildResourceMutatingWebhookConfiguration(ctx context.Conte
 	}
 	if c.watchdogCheck() {
 		webhookCfg := cfg.GetWebhook()
-		ignoreWebhook := newWebhook(c.defaultTimeout, ignore, cfg.GetMatchConditions())
-		failWebhook := newWebhook(c.defaultTimeout, fail, cfg.GetMatchConditions())
+		ignoreWebhook := newWebhook(c.webhookTimeout(cfg), ignore, cfg.GetMatchConditions())
+		failWebhook := newWebhook(c.webhookTimeout(cfg), fail, cfg.GetMatchConditions())
 		policies, err := c.getAllPolicies()
 		if err != nil {
 			return nil, err
@@ -836,17 +825,17 @@ func (c *controller) buildResourceMutatingWebhookConfiguration(ctx context.Conte
 				spec := p.GetSpec()
 				if spec.HasMutateStandard() || spec.HasVerifyImages() {
 					if spec.CustomWebhookMatchConditions() {
-						if spec.GetFailurePolicy(ctx) == kyvernov1.Ignore {
-							fineGrainedIgnore := newWebhookPerPolicy(c.defaultTimeout, ignore, cfg.GetMatchConditions(), p)
+						if policyutils.GetFailurePolicy(ctx, p) == kyvernov1.Ignore {
+							fineGrainedIgnore := newWebhookPerPolicy(c.webhookTimeout(cfg), ignore, cfg.GetMatchConditions(), p)
 							c.mergeWebhook(fineGrainedIgnore, p, false)
 							fineGrainedIgnoreList = append(fineGrainedIgnoreList, fineGrainedIgnore)
 						} else {
-							fineGrainedFail := newWebhookPerPolicy(c.defaultTimeout, fail, cfg.GetMatchConditions(), p)
+							fineGrainedFail := newWebhookPerPolicy(c.webhookTimeout(cfg), fail, cfg.GetMatchConditions(), p)
 							c.mergeWebhook(fineGrainedFail, p, false)
 							fineGrainedFailList = append(fineGrainedFailList, fineGrainedFail)
 						}
 					} else {
-						if spec.GetFailurePolicy(ctx) == kyvernov1.Ignore {
+						if policyutils.GetFailurePolicy(ctx, p) == kyvernov1.Ignore {
 							c.mergeWebhook(ignoreWebhook, p, false)
 						} else {
 							c.mergeWebhook(failWebhook, p, false)
@@ -858,19 +847,15 @@ func (c *controller) buildResourceMutatingWebhookConfiguration(ctx context.Conte
 		webhooks := []*webhook{ignoreWebhook, failWebhook}
 		webhooks = append(webhooks, fineGrainedIgnoreList...)
 		webhooks = append(webhooks, fineGrainedFailList...)
-		result.Webhooks = c.buildResourceMutatingWebhookRules(caBundle, webhookCfg, &noneOnDryRun, webhooks)
+		result.Webhooks = c.buildResourceMutatingWebhookRules(caBundle, webhookCfg, &noneOnDryRun, webhooks, toggle.FromContext(ctx).GenerateWebhookMatchConditions())
 	} else {
 		c.recordPolicyState(config.MutatingWebhookConfigurationName)
 	}
 	return &result, nil
 }
 
-func (c *controller) buildResourceMutatingWebhookRules(caBundle []byte, webhookCfg config.WebhookConfig, sideEffects *admissionregistrationv1.SideEffectClass, webhooks []*webhook) []admissionregistrationv1.MutatingWebhook {
+func (c *controller) buildResourceMutatingWebhookRules(caBundle []byte, webhookCfg config.WebhookConfig, sideEffects *admissionregistrationv1.SideEffectClass, webhooks []*webhook, generateMatchConditions bool) []admissionregistrationv1.MutatingWebhook {
 	var mutatingWebhooks []admissionregistrationv1.MutatingWebhook //nolint:prealloc
-	objectSelector := webhookCfg.ObjectSelector
-	if objectSelector == nil {
-		objectSelector = &metav1.LabelSelector{}
-	}
 	for _, webhook := range webhooks {
 		if webhook.isEmpty() {
 			continue
@@ -888,10 +873,10 @@ func (c *controller) buildResourceMutatingWebhookRules(caBundle []byte, webhookC
 				SideEffects:             sideEffects,
 				AdmissionReviewVersions: []string{"v1"},
 				NamespaceSelector:       webhookCfg.NamespaceSelector,
-				ObjectSelector:          objectSelector,
+				ObjectSelector:          webhook.buildObjectSelector(webhookCfg.ObjectSelector),
 				TimeoutSeconds:          &timeout,
-				ReinvocationPolicy:      &ifNeeded,
-				MatchConditions:         webhook.matchConditions,
+				ReinvocationPolicy:      webhook.buildReinvocationPolicy(webhookCfg.GetReinvocationPolicy()),
+				MatchConditions:         webhook.buildMatchConditions(generateMatchConditions),
 				MatchPolicy:             ptr.To(admissionregistrationv1.Equivalent),
 			},
 		)
@@ -925,7 +910,7 @@ func (c *controller) buildDefaultResourceValidatingWebhookConfiguration(_ contex
 				FailurePolicy:           &ignore,
 				SideEffects:             sideEffects,
 				AdmissionReviewVersions: []string{"v1"},
-				TimeoutSeconds:          &c.defaultTimeout,
+				TimeoutSeconds:          ptr.To(c.webhookTimeout(cfg)),
 				MatchPolicy:             ptr.To(admissionregistrationv1.Equivalent),
 			}, {
 				Name:         config.ValidatingWebhookName + "-fail",
@@ -946,7 +931,7 @@ func (c *controller) buildDefaultResourceValidatingWebhookConfiguration(_ contex
 				FailurePolicy:           &fail,
 				SideEffects:             sideEffects,
 				AdmissionReviewVersions: []string{"v1"},
-				TimeoutSeconds:          &c.defaultTimeout,
+				TimeoutSeconds:          ptr.To(c.webhookTimeout(cfg)),
 				MatchPolicy:             ptr.To(admissionregistrationv1.Equivalent),
 			}},
 		},
@@ -960,8 +945,8 @@ func (c *controller) buildResourceValidatingWebhookConfiguration(ctx context.Con
 	}
 	if c.watchdogCheck() {
 		webhookCfg := cfg.GetWebhook()
-		ignoreWebhook := newWebhook(c.defaultTimeout, ignore, cfg.GetMatchConditions())
-		failWebhook := newWebhook(c.defaultTimeout, fail, cfg.GetMatchConditions())
+		ignoreWebhook := newWebhook(c.webhookTimeout(cfg), ignore, cfg.GetMatchConditions())
+		failWebhook := newWebhook(c.webhookTimeout(cfg), fail, cfg.GetMatchConditions())
 		policies, err := c.getAllPolicies()
 		if err != nil {
 			return nil, err
@@ -974,17 +959,17 @@ func (c *controller) buildResourceValidatingWebhookConfiguration(ctx context.Con
 				spec := p.GetSpec()
 				if spec.HasValidate() || spec.HasGenerate() || spec.HasMutateExisting() || spec.HasVerifyImageChecks() || spec.HasVerifyManifests() {
 					if spec.CustomWebhookMatchConditions() {
-						if spec.GetFailurePolicy(ctx) == kyvernov1.Ignore {
-							fineGrainedIgnore := newWebhookPerPolicy(c.defaultTimeout, ignore, cfg.GetMatchConditions(), p)
+						if policyutils.GetFailurePolicy(ctx, p) == kyvernov1.Ignore {
+							fineGrainedIgnore := newWebhookPerPolicy(c.webhookTimeout(cfg), ignore, cfg.GetMatchConditions(), p)
 							c.mergeWebhook(fineGrainedIgnore, p, true)
 							fineGrainedIgnoreList = append(fineGrainedIgnoreList, fineGrainedIgnore)
 						} else {
-							fineGrainedFail := newWebhookPerPolicy(c.defaultTimeout, fail, cfg.GetMatchConditions(), p)
+							fineGrainedFail := newWebhookPerPolicy(c.webhookTimeout(cfg), fail, cfg.GetMatchConditions(), p)
 							c.mergeWebhook(fineGrainedFail, p, true)
 							fineGrainedFailList = append(fineGrainedFailList, fineGrainedFail)
 						}
 					} else {
-						if spec.GetFailurePolicy(ctx) == kyvernov1.Ignore {
+						if policyutils.GetFailurePolicy(ctx, p) == kyvernov1.Ignore {
 							c.mergeWebhook(ignoreWebhook, p, true)
 						} else {
 							c.mergeWebhook(failWebhook, p, true)
@@ -1000,19 +985,15 @@ func (c *controller) buildResourceValidatingWebhookConfiguration(ctx context.Con
 		webhooks := []*webhook{ignoreWebhook, failWebhook}
 		webhooks = append(webhooks, fineGrainedIgnoreList...)
 		webhooks = append(webhooks, fineGrainedFailList...)
-		result.Webhooks = c.buildResourceValidatingWebhookRules(caBundle, webhookCfg, sideEffects, webhooks)
+		result.Webhooks = c.buildResourceValidatingWebhookRules(caBundle, webhookCfg, sideEffects, webhooks, toggle.FromContext(ctx).GenerateWebhookMatchConditions())
 	} else {
 		c.recordPolicyState(config.MutatingWebhookConfigurationName)
 	}
 	return &result, nil
 }
 
-func (c *controller) buildResourceValidatingWebhookRules(caBundle []byte, webhookCfg config.WebhookConfig, sideEffects *admissionregistrationv1.SideEffectClass, webhooks []*webhook) []admissionregistrationv1.ValidatingWebhook {
+func (c *controller) buildResourceValidatingWebhookRules(caBundle []byte, webhookCfg config.WebhookConfig, sideEffects *admissionregistrationv1.SideEffectClass, webhooks []*webhook, generateMatchConditions bool) []admissionregistrationv1.ValidatingWebhook {
 	var validatingWebhooks []admissionregistrationv1.ValidatingWebhook //nolint:prealloc
-	objectSelector := webhookCfg.ObjectSelector
-	if objectSelector == nil {
-		objectSelector = &metav1.LabelSelector{}
-	}
 	for _, webhook := range webhooks {
 		if webhook.isEmpty() {
 			continue
@@ -1030,9 +1011,9 @@ func (c *controller) buildResourceValidatingWebhookRules(caBundle []byte, webhoo
 				SideEffects:             sideEffects,
 				AdmissionReviewVersions: []string{"v1"},
 				NamespaceSelector:       webhookCfg.NamespaceSelector,
-				ObjectSelector:          objectSelector,
+				ObjectSelector:          webhook.buildObjectSelector(webhookCfg.ObjectSelector),
 				TimeoutSeconds:          &timeout,
-				MatchConditions:         webhook.matchConditions,
+				MatchConditions:         webhook.buildMatchConditions(generateMatchConditions),
 				MatchPolicy:             ptr.To(admissionregistrationv1.Equivalent),
 			},
 		)
@@ -1094,6 +1075,11 @@ func (w webhookConfig) merge(other webhookConfig) {
 
 // mergeWebhook merges the matching kinds of the policy to webhook.rule
 func (c *controller) mergeWebhook(dst *webhook, policy kyvernov1.PolicyInterface, updateValidate bool) {
+	dst.addPolicyCondition(policyMatchCondition(policy, updateValidate))
+	dst.addPolicySelector(policyObjectSelector(policy, updateValidate))
+	if !updateValidate {
+		dst.addPolicyReinvocation(policyutils.GetReinvocationPolicy(policy))
+	}
 	matched := webhookConfig{}
 	for _, rule := range autogen.ComputeRules(policy, "") {
 		// matching kinds in generate policies need to be added to both webhooks
@@ -1182,10 +1168,10 @@ func (c *controller) mergeWebhook(dst *webhook, policy kyvernov1.PolicyInterface
 			dst.set(gvrs.group, gvrs.version, gvrs.resource, gvrs.subresource, gvrs.scope, ops.UnsortedList()...)
 		}
 	}
-	spec := policy.GetSpec()
-	webhookTimeoutSeconds := spec.GetWebhookTimeoutSeconds()
+	webhookTimeoutSeconds := policyutils.GetWebhookTimeoutSeconds(policy)
 	if webhookTimeoutSeconds != nil {
-		if dst.maxWebhookTimeout < *webhookTimeoutSeconds {
+		// a fine-grained webhook only covers the policy, its timeout can be lowered below the default
+		if dst.policyMeta.Name != "" || dst.maxWebhookTimeout < *webhookTimeoutSeconds {
 			dst.maxWebhookTimeout = *webhookTimeoutSeconds
 		}
 	}
@@ -0,0 +1,178 @@
+package webhook
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+)
+
+const (
+	// preconditionsMatchConditionName is the name of the match condition generated from policy preconditions
+	preconditionsMatchConditionName = "kyverno-policy-preconditions"
+	// maxPreconditionsExpressionLength caps the size of the generated expression,
+	// beyond that requests are sent to kyverno unconditionally
+	maxPreconditionsExpressionLength = 4096
+)
+
+// preconditionKeyRegex matches precondition keys that can be evaluated by the API server,
+// they always resolve to a string and never fail to evaluate
+var preconditionKeyRegex = regexp.MustCompile(`^\{\{\s*request\.(operation|namespace|name)\s*\}\}$`)
+
+// policyMatchCondition translates the preconditions of the policy rules handled by the webhook into a CEL
+// expression the API server can evaluate to filter out requests none of the rules can apply to.
+// It returns false when at least one rule has no preconditions or preconditions that can't be translated
+// with the exact same semantics, requests must then always be sent to kyverno.
+func policyMatchCondition(policy kyvernov1.PolicyInterface, updateValidate bool) (string, bool) {
+	var expressions []string
+	for _, rule := range autogen.ComputeRules(policy, "") {
+		// generate rules also process the generated resources, preconditions don't apply to them
+		if rule.HasGenerate() {
+			return "", false
+		}
+		if !(updateValidate && (rule.HasValidate() || rule.HasVerifyImageChecks() || rule.HasMutateExisting())) &&
+			!(!updateValidate && (rule.HasMutateStandard() || rule.HasVerifyImages() || rule.HasVerifyManifests())) {
+			continue
+		}
+		expression, ok := ruleMatchCondition(rule)
+		if !ok {
+			return "", false
+		}
+		if !slices.Contains(expressions, expression) {
+			expressions = append(expressions, expression)
+		}
+	}
+	if len(expressions) == 0 {
+		return "", false
+	}
+	return strings.Join(expressions, " || "), true
+}
+
+func ruleMatchCondition(rule kyvernov1.Rule) (string, bool) {
+	if len(rule.CELPreconditions) != 0 {
+		return "", false
+	}
+	conditions, err := engineutils.TransformConditions(rule.GetAnyAllConditions())
+	if err != nil {
+		return "", false
+	}
+	var allConditions, anyConditions []kyvernov1.Condition
+	switch typed := conditions.(type) {
+	case kyvernov1.AnyAllConditions:
+		allConditions, anyConditions = typed.AllConditions, typed.AnyConditions
+	case []kyvernov1.Condition:
+		allConditions = typed
+	default:
+		return "", false
+	}
+	if len(allConditions) == 0 && len(anyConditions) == 0 {
+		return "", false
+	}
+	var expressions []string
+	for _, condition := range allConditions {
+		expression, ok := conditionMatchCondition(condition)
+		if !ok {
+			return "", false
+		}
+		expressions = append(expressions, expression)
+	}
+	if len(anyConditions) != 0 {
+		var anyExpressions []string
+		for _, condition := range anyConditions {
+			expression, ok := conditionMatchCondition(condition)
+			if !ok {
+				return "", false
+			}
+			anyExpressions = append(anyExpressions, expression)
+		}
+		expressions = append(expressions, "("+strings.Join(anyExpressions, " || ")+")")
+	}
+	return "(" + strings.Join(expressions, " && ") + ")", true
+}
+
+func conditionMatchCondition(condition kyvernov1.Condition) (string, bool) {
+	key, ok := condition.GetKey().(string)
+	if !ok {
+		return "", false
+	}
+	match := preconditionKeyRegex.FindStringSubmatch(key)
+	if match == nil {
+		return "", false
+	}
+	field := "request." + match[1]
+	switch condition.Operator {
+	case "Equals", "Equal", "NotEquals", "NotEqual":
+		// string comparisons also parse durations and quantities, only operations are safe to compare
+		if match[1] != "operation" {
+			return "", false
+		}
+		value, ok := condition.GetValue().(string)
+		if !ok || !isLiteral(value) {
+			return "", false
+		}
+		operator := "=="
+		if condition.Operator == "NotEquals" || condition.Operator == "NotEqual" {
+			operator = "!="
+		}
+		return fmt.Sprintf("%s %s %s", field, operator, strconv.Quote(value)), true
+	case "In", "AnyIn", "AllIn", "NotIn", "AnyNotIn", "AllNotIn":
+		// single string values are also matched as ranges or JSON arrays, only lists are safe to translate
+		values, ok := condition.GetValue().([]interface{})
+		if !ok {
+			return "", false
+		}
+		quoted := make([]string, 0, len(values))
+		for _, value := range values {
+			value, ok := value.(string)
+			if !ok || !isLiteral(value) {
+				return "", false
+			}
+			quoted = append(quoted, strconv.Quote(value))
+		}
+		expression := fmt.Sprintf("%s in [%s]", field, strings.Join(quoted, ", "))
+		if strings.HasSuffix(string(condition.Operator), "NotIn") {
+			return "!(" + expression + ")", true
+		}
+		return expression, true
+	}
+	return "", false
+}
+
+// isLiteral returns true if the value contains neither variables nor wildcards
+func isLiteral(value string) bool {
+	return !strings.Contains(value, "{{") && !strings.ContainsAny(value, "*?")
+}
+
+// buildMatchConditions returns the match conditions of the webhook, including the condition
+// generated from policy preconditions when enabled and all the policies could be translated
+func (wh *webhook) buildMatchConditions(generate bool) []admissionregistrationv1.MatchCondition {
+	if !generate || wh.unconditional || len(wh.policyConditions) == 0 {
+		return wh.matchConditions
+	}
+	expression := strings.Join(wh.policyConditions, " || ")
+	if len(expression) > maxPreconditionsExpressionLength {
+		return wh.matchConditions
+	}
+	matchConditions := slices.Clone(wh.matchConditions)
+	return append(matchConditions, admissionregistrationv1.MatchCondition{
+		Name:       preconditionsMatchConditionName,
+		Expression: expression,
+	})
+}
+
+// addPolicyCondition records the condition under which a policy merged in the webhook can apply
+func (wh *webhook) addPolicyCondition(expression string, ok bool) {
+	if !ok {
+		wh.unconditional = true
+		return
+	}
+	if !slices.Contains(wh.policyConditions, expression) {
+		wh.policyConditions = append(wh.policyConditions, expression)
+	}
+}
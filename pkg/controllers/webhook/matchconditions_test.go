@@ -0,0 +1,169 @@
+package webhook
+
+import (
+	"encoding/json"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/stretchr/testify/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+)
+
+func Test_policyMatchCondition(t *testing.T) {
+	tests := []struct {
+		name           string
+		rules          string
+		updateValidate bool
+		want           string
+		wantOk         bool
+	}{{
+		name: "operation equals",
+		rules: `[{
+			"name": "r1",
+			"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+			"preconditions": {"all": [{"key": "{{ request.operation }}", "operator": "Equals", "value": "CREATE"}]},
+			"validate": {"pattern": {"metadata": {"name": "?*"}}}
+		}]`,
+		updateValidate: true,
+		want:           `(request.operation == "CREATE")`,
+		wantOk:         true,
+	}, {
+		name: "any and all conditions",
+		rules: `[{
+			"name": "r1",
+			"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+			"preconditions": {
+				"all": [{"key": "{{request.operation}}", "operator": "NotEquals", "value": "DELETE"}],
+				"any": [
+					{"key": "{{ request.namespace }}", "operator": "AnyNotIn", "value": ["kube-system", "kyverno"]},
+					{"key": "{{ request.name }}", "operator": "AnyIn", "value": ["critical"]}
+				]
+			},
+			"validate": {"pattern": {"metadata": {"name": "?*"}}}
+		}]`,
+		updateValidate: true,
+		want:           `(request.operation != "DELETE" && (!(request.namespace in ["kube-system", "kyverno"]) || request.name in ["critical"]))`,
+		wantOk:         true,
+	}, {
+		name: "legacy conditions list",
+		rules: `[{
+			"name": "r1",
+			"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+			"preconditions": [{"key": "{{ request.operation }}", "operator": "In", "value": ["CREATE", "UPDATE"]}],
+			"validate": {"pattern": {"metadata": {"name": "?*"}}}
+		}]`,
+		updateValidate: true,
+		want:           `(request.operation in ["CREATE", "UPDATE"])`,
+		wantOk:         true,
+	}, {
+		name: "rules are or'ed",
+		rules: `[{
+			"name": "r1",
+			"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+			"preconditions": {"all": [{"key": "{{ request.operation }}", "operator": "Equals", "value": "CREATE"}]},
+			"validate": {"pattern": {"metadata": {"name": "?*"}}}
+		}, {
+			"name": "r2",
+			"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+			"preconditions": {"all": [{"key": "{{ request.namespace }}", "operator": "AnyIn", "value": ["default"]}]},
+			"validate": {"pattern": {"metadata": {"name": "?*"}}}
+		}]`,
+		updateValidate: true,
+		want:           `(request.operation == "CREATE") || (request.namespace in ["default"])`,
+		wantOk:         true,
+	}, {
+		name: "rule without preconditions",
+		rules: `[{
+			"name": "r1",
+			"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+			"preconditions": {"all": [{"key": "{{ request.operation }}", "operator": "Equals", "value": "CREATE"}]},
+			"validate": {"pattern": {"metadata": {"name": "?*"}}}
+		}, {
+			"name": "r2",
+			"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+			"validate": {"pattern": {"metadata": {"name": "?*"}}}
+		}]`,
+		updateValidate: true,
+		wantOk:         false,
+	}, {
+		name: "rules of the other webhook are ignored",
+		rules: `[{
+			"name": "r1",
+			"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+			"preconditions": {"all": [{"key": "{{ request.operation }}", "operator": "Equals", "value": "CREATE"}]},
+			"validate": {"pattern": {"metadata": {"name": "?*"}}}
+		}, {
+			"name": "r2",
+			"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+			"mutate": {"patchStrategicMerge": {"metadata": {"labels": {"foo": "bar"}}}}
+		}]`,
+		updateValidate: true,
+		want:           `(request.operation == "CREATE")`,
+		wantOk:         true,
+	}, {
+		name: "unsupported key",
+		rules: `[{
+			"name": "r1",
+			"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+			"preconditions": {"all": [{"key": "{{ request.object.metadata.name }}", "operator": "Equals", "value": "foo"}]},
+			"validate": {"pattern": {"metadata": {"name": "?*"}}}
+		}]`,
+		updateValidate: true,
+		wantOk:         false,
+	}, {
+		name: "wildcard value",
+		rules: `[{
+			"name": "r1",
+			"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+			"preconditions": {"all": [{"key": "{{ request.namespace }}", "operator": "AnyNotIn", "value": ["kube-*"]}]},
+			"validate": {"pattern": {"metadata": {"name": "?*"}}}
+		}]`,
+		updateValidate: true,
+		wantOk:         false,
+	}, {
+		name: "namespace equals",
+		rules: `[{
+			"name": "r1",
+			"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+			"preconditions": {"all": [{"key": "{{ request.namespace }}", "operator": "Equals", "value": "default"}]},
+			"validate": {"pattern": {"metadata": {"name": "?*"}}}
+		}]`,
+		updateValidate: true,
+		wantOk:         false,
+	}, {
+		name: "generate rule",
+		rules: `[{
+			"name": "r1",
+			"match": {"any": [{"resources": {"kinds": ["Namespace"]}}]},
+			"preconditions": {"all": [{"key": "{{ request.operation }}", "operator": "Equals", "value": "CREATE"}]},
+			"generate": {"kind": "ConfigMap", "apiVersion": "v1", "name": "cm", "namespace": "{{ request.object.metadata.name }}", "data": {}}
+		}]`,
+		updateValidate: true,
+		wantOk:         false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rules []kyvernov1.Rule
+			assert.NoError(t, json.Unmarshal([]byte(tt.rules), &rules))
+			policy := &kyvernov1.ClusterPolicy{Spec: kyvernov1.Spec{Rules: rules}}
+			got, ok := policyMatchCondition(policy, tt.updateValidate)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_webhook_buildMatchConditions(t *testing.T) {
+	configured := []admissionregistrationv1.MatchCondition{{Name: "exclude-leases", Expression: `request.resource.resource != "leases"`}}
+	wh := newWebhook(DefaultWebhookTimeout, admissionregistrationv1.Ignore, configured)
+	wh.addPolicyCondition(`(request.operation == "CREATE")`, true)
+	wh.addPolicyCondition(`(request.operation == "CREATE")`, true)
+	wh.addPolicyCondition(`(request.namespace in ["default"])`, true)
+	assert.Equal(t, configured, wh.buildMatchConditions(false))
+	assert.Equal(t, []admissionregistrationv1.MatchCondition{
+		configured[0],
+		{Name: preconditionsMatchConditionName, Expression: `(request.operation == "CREATE") || (request.namespace in ["default"])`},
+	}, wh.buildMatchConditions(true))
+	wh.addPolicyCondition("", false)
+	assert.Equal(t, configured, wh.buildMatchConditions(true))
+}
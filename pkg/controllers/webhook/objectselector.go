@@ -0,0 +1,104 @@
+package webhook
+
+import (
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/autogen"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// policyObjectSelector returns the label selector shared by all the policy rules handled by the webhook.
+// It returns false when at least one rule can match objects regardless of their labels or when the rules
+// use different selectors, requests must then always be sent to kyverno.
+func policyObjectSelector(policy kyvernov1.PolicyInterface, updateValidate bool) (*metav1.LabelSelector, bool) {
+	var selector *metav1.LabelSelector
+	for _, rule := range autogen.ComputeRules(policy, "") {
+		// generate rules also process the generated resources, the selector doesn't apply to them
+		if rule.HasGenerate() {
+			return nil, false
+		}
+		if !(updateValidate && (rule.HasValidate() || rule.HasVerifyImageChecks() || rule.HasMutateExisting())) &&
+			!(!updateValidate && (rule.HasMutateStandard() || rule.HasVerifyImages() || rule.HasVerifyManifests())) {
+			continue
+		}
+		ruleSelector, ok := ruleObjectSelector(rule)
+		if !ok {
+			return nil, false
+		}
+		if selector == nil {
+			selector = ruleSelector
+		} else if !datautils.DeepEqual(selector, ruleSelector) {
+			return nil, false
+		}
+	}
+	return selector, selector != nil
+}
+
+func ruleObjectSelector(rule kyvernov1.Rule) (*metav1.LabelSelector, bool) {
+	var selectors []*metav1.LabelSelector
+	match := rule.MatchResources
+	if len(match.Any) != 0 {
+		// every filter must select the objects
+		for _, filter := range match.Any {
+			if filter.Selector == nil {
+				return nil, false
+			}
+			selectors = append(selectors, filter.Selector)
+		}
+	} else if len(match.All) != 0 {
+		// one filter selecting the objects is enough
+		for _, filter := range match.All {
+			if filter.Selector != nil {
+				selectors = append(selectors, filter.Selector)
+			}
+		}
+	} else if match.Selector != nil {
+		selectors = append(selectors, match.Selector)
+	}
+	if len(selectors) == 0 {
+		return nil, false
+	}
+	for _, selector := range selectors {
+		// wildcards are only supported by kyverno
+		if kubeutils.LabelSelectorContainsWildcard(selector) || !datautils.DeepEqual(selector, selectors[0]) {
+			return nil, false
+		}
+	}
+	return selectors[0], true
+}
+
+// addPolicySelector records the label selector of a policy merged in the webhook
+func (wh *webhook) addPolicySelector(selector *metav1.LabelSelector, ok bool) {
+	if !ok {
+		wh.unselective = true
+		return
+	}
+	if wh.policySelector == nil {
+		wh.policySelector = selector
+	} else if !datautils.DeepEqual(wh.policySelector, selector) {
+		wh.unselective = true
+	}
+}
+
+// buildObjectSelector returns the object selector of the webhook, the configured selector is
+// combined with the selector shared by all the merged policies when there is one
+func (wh *webhook) buildObjectSelector(configured *metav1.LabelSelector) *metav1.LabelSelector {
+	if configured == nil {
+		configured = &metav1.LabelSelector{}
+	}
+	if wh.unselective || wh.policySelector == nil {
+		return configured
+	}
+	selector := configured.DeepCopy()
+	// labels are converted to expressions to not conflict with the configured labels
+	for key, value := range wh.policySelector.MatchLabels {
+		selector.MatchExpressions = append(selector.MatchExpressions, metav1.LabelSelectorRequirement{
+			Key:      key,
+			Operator: metav1.LabelSelectorOpIn,
+			Values:   []string{value},
+		})
+	}
+	selector.MatchExpressions = append(selector.MatchExpressions, wh.policySelector.MatchExpressions...)
+	return selector
+}
@@ -0,0 +1,143 @@
+package webhook
+
+import (
+	"encoding/json"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/stretchr/testify/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_policyObjectSelector(t *testing.T) {
+	tests := []struct {
+		name           string
+		rules          string
+		updateValidate bool
+		want           *metav1.LabelSelector
+		wantOk         bool
+	}{{
+		name: "any filters",
+		rules: `[{
+			"name": "r1",
+			"match": {"any": [
+				{"resources": {"kinds": ["Pod"], "selector": {"matchLabels": {"app": "web"}}}},
+				{"resources": {"kinds": ["Deployment"], "selector": {"matchLabels": {"app": "web"}}}}
+			]},
+			"validate": {"pattern": {"metadata": {"name": "?*"}}}
+		}]`,
+		updateValidate: true,
+		want:           &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		wantOk:         true,
+	}, {
+		name: "any filter without selector",
+		rules: `[{
+			"name": "r1",
+			"match": {"any": [
+				{"resources": {"kinds": ["Pod"], "selector": {"matchLabels": {"app": "web"}}}},
+				{"resources": {"kinds": ["Deployment"]}}
+			]},
+			"validate": {"pattern": {"metadata": {"name": "?*"}}}
+		}]`,
+		updateValidate: true,
+		wantOk:         false,
+	}, {
+		name: "all filters",
+		rules: `[{
+			"name": "r1",
+			"match": {"all": [
+				{"resources": {"kinds": ["Pod"]}},
+				{"resources": {"selector": {"matchExpressions": [{"key": "app", "operator": "Exists"}]}}}
+			]},
+			"validate": {"pattern": {"metadata": {"name": "?*"}}}
+		}]`,
+		updateValidate: true,
+		want:           &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: metav1.LabelSelectorOpExists}}},
+		wantOk:         true,
+	}, {
+		name: "legacy resources",
+		rules: `[{
+			"name": "r1",
+			"match": {"resources": {"kinds": ["Pod"], "selector": {"matchLabels": {"app": "web"}}}},
+			"mutate": {"patchStrategicMerge": {"metadata": {"labels": {"foo": "bar"}}}}
+		}]`,
+		want:   &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		wantOk: true,
+	}, {
+		name: "different selectors",
+		rules: `[{
+			"name": "r1",
+			"match": {"any": [{"resources": {"kinds": ["Pod"], "selector": {"matchLabels": {"app": "web"}}}}]},
+			"validate": {"pattern": {"metadata": {"name": "?*"}}}
+		}, {
+			"name": "r2",
+			"match": {"any": [{"resources": {"kinds": ["Pod"], "selector": {"matchLabels": {"app": "db"}}}}]},
+			"validate": {"pattern": {"metadata": {"name": "?*"}}}
+		}]`,
+		updateValidate: true,
+		wantOk:         false,
+	}, {
+		name: "wildcard",
+		rules: `[{
+			"name": "r1",
+			"match": {"any": [{"resources": {"kinds": ["Pod"], "selector": {"matchLabels": {"app": "web-*"}}}}]},
+			"validate": {"pattern": {"metadata": {"name": "?*"}}}
+		}]`,
+		updateValidate: true,
+		wantOk:         false,
+	}, {
+		name: "rules of the other webhook are ignored",
+		rules: `[{
+			"name": "r1",
+			"match": {"any": [{"resources": {"kinds": ["Pod"], "selector": {"matchLabels": {"app": "web"}}}}]},
+			"validate": {"pattern": {"metadata": {"name": "?*"}}}
+		}, {
+			"name": "r2",
+			"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+			"mutate": {"patchStrategicMerge": {"metadata": {"labels": {"foo": "bar"}}}}
+		}]`,
+		updateValidate: true,
+		want:           &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		wantOk:         true,
+	}, {
+		name: "generate rule",
+		rules: `[{
+			"name": "r1",
+			"match": {"any": [{"resources": {"kinds": ["Namespace"], "selector": {"matchLabels": {"app": "web"}}}}]},
+			"generate": {"kind": "ConfigMap", "apiVersion": "v1", "name": "cm", "namespace": "{{ request.object.metadata.name }}", "data": {}}
+		}]`,
+		updateValidate: true,
+		wantOk:         false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rules []kyvernov1.Rule
+			assert.NoError(t, json.Unmarshal([]byte(tt.rules), &rules))
+			policy := &kyvernov1.ClusterPolicy{Spec: kyvernov1.Spec{Rules: rules}}
+			got, ok := policyObjectSelector(policy, tt.updateValidate)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_webhook_buildObjectSelector(t *testing.T) {
+	configured := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}
+	wh := newWebhook(DefaultWebhookTimeout, admissionregistrationv1.Ignore, nil)
+	assert.Equal(t, &metav1.LabelSelector{}, wh.buildObjectSelector(nil))
+	wh.addPolicySelector(&metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}, true)
+	wh.addPolicySelector(&metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}, true)
+	assert.Equal(t, &metav1.LabelSelector{
+		MatchLabels: map[string]string{"team": "a"},
+		MatchExpressions: []metav1.LabelSelectorRequirement{{
+			Key:      "app",
+			Operator: metav1.LabelSelectorOpIn,
+			Values:   []string{"web"},
+		}},
+	}, wh.buildObjectSelector(configured))
+	assert.Equal(t, map[string]string{"team": "a"}, configured.MatchLabels)
+	assert.Nil(t, configured.MatchExpressions)
+	wh.addPolicySelector(&metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}}, true)
+	assert.Equal(t, configured, wh.buildObjectSelector(configured))
+}
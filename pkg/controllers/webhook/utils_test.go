@@ -635,3 +635,47 @@ func Test_collectResourceDescriptions(t *testing.T) {
 		})
 	}
 }
+
+func Test_webhook_buildReinvocationPolicy(t *testing.T) {
+	never := admissionregistrationv1.NeverReinvocationPolicy
+	ifNeeded := admissionregistrationv1.IfNeededReinvocationPolicy
+	tests := []struct {
+		name       string
+		configured admissionregistrationv1.ReinvocationPolicyType
+		policies   []*admissionregistrationv1.ReinvocationPolicyType
+		want       admissionregistrationv1.ReinvocationPolicyType
+	}{{
+		name:       "configured",
+		configured: never,
+		want:       never,
+	}, {
+		name:       "policy not set",
+		configured: never,
+		policies:   []*admissionregistrationv1.ReinvocationPolicyType{nil},
+		want:       never,
+	}, {
+		name:       "one policy requests reinvocation",
+		configured: never,
+		policies:   []*admissionregistrationv1.ReinvocationPolicyType{nil, &ifNeeded, &never},
+		want:       ifNeeded,
+	}, {
+		name:       "all policies opt out",
+		configured: ifNeeded,
+		policies:   []*admissionregistrationv1.ReinvocationPolicyType{&never, &never},
+		want:       never,
+	}, {
+		name:       "some policies opt out",
+		configured: ifNeeded,
+		policies:   []*admissionregistrationv1.ReinvocationPolicyType{&never, nil},
+		want:       ifNeeded,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wh := newWebhook(DefaultWebhookTimeout, admissionregistrationv1.Ignore, nil)
+			for _, policy := range tt.policies {
+				wh.addPolicyReinvocation(policy)
+			}
+			assert.Equal(t, tt.want, *wh.buildReinvocationPolicy(tt.configured))
+		})
+	}
+}
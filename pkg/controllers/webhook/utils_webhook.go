@@ -6,6 +6,7 @@ import (
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	objectmeta "k8s.io/client-go/tools/cache"
 	"k8s.io/utils/ptr"
@@ -21,6 +22,16 @@ type webhook struct {
 	failurePolicy     admissionregistrationv1.FailurePolicyType
 	rules             sets.Set[ruleEntry]
 	matchConditions   []admissionregistrationv1.MatchCondition
+	// policyConditions are the conditions generated from the preconditions of the merged policies
+	policyConditions []string
+	// unconditional is set when at least one merged policy can't be translated into a condition
+	unconditional bool
+	// policySelector is the label selector shared by the merged policies
+	policySelector *metav1.LabelSelector
+	// unselective is set when at least one merged policy doesn't select objects by labels
+	unselective bool
+	// reinvocationPolicies are the reinvocation policies requested by the merged policies, empty when not requested
+	reinvocationPolicies sets.Set[admissionregistrationv1.ReinvocationPolicyType]
 }
 
 type ruleEntry struct {
@@ -67,10 +78,11 @@ func (r resourceOperations) operations() []admissionregistrationv1.OperationType
 
 func newWebhook(timeout int32, failurePolicy admissionregistrationv1.FailurePolicyType, matchConditions []admissionregistrationv1.MatchCondition) *webhook {
 	return &webhook{
-		maxWebhookTimeout: timeout,
-		failurePolicy:     failurePolicy,
-		rules:             sets.New[ruleEntry](),
-		matchConditions:   matchConditions,
+		maxWebhookTimeout:    timeout,
+		failurePolicy:        failurePolicy,
+		rules:                sets.New[ruleEntry](),
+		matchConditions:      matchConditions,
+		reinvocationPolicies: sets.New[admissionregistrationv1.ReinvocationPolicyType](),
 	}
 }
 
@@ -277,3 +289,24 @@ func (wh *webhook) key(separator string) string {
 	}
 	return p.Name
 }
+
+// addPolicyReinvocation records the reinvocation policy requested by a policy merged in the webhook
+func (wh *webhook) addPolicyReinvocation(reinvocationPolicy *admissionregistrationv1.ReinvocationPolicyType) {
+	if reinvocationPolicy == nil {
+		wh.reinvocationPolicies.Insert("")
+	} else {
+		wh.reinvocationPolicies.Insert(*reinvocationPolicy)
+	}
+}
+
+// buildReinvocationPolicy returns the reinvocation policy of the webhook, IfNeeded is used as soon as one
+// merged policy requests it and Never is only used if requested by all the merged policies
+func (wh *webhook) buildReinvocationPolicy(configured admissionregistrationv1.ReinvocationPolicyType) *admissionregistrationv1.ReinvocationPolicyType {
+	reinvocationPolicy := configured
+	if wh.reinvocationPolicies.Has(admissionregistrationv1.IfNeededReinvocationPolicy) {
+		reinvocationPolicy = admissionregistrationv1.IfNeededReinvocationPolicy
+	} else if wh.reinvocationPolicies.Len() != 0 && !wh.reinvocationPolicies.Has("") {
+		reinvocationPolicy = admissionregistrationv1.NeverReinvocationPolicy
+	}
+	return &reinvocationPolicy
+}
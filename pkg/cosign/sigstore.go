@@ -24,8 +24,21 @@ import (
 var (
 	maxLayerSize     = int64(10 * 1000 * 1000) // 10 MB
 	attestationlimit = 50
+	// staticTrustedRoot is used to verify bundles instead of the trusted root fetched from TUF when set
+	staticTrustedRoot *root.TrustedRoot
 )
 
+// SetTrustedRoot configures the sigstore trusted root used to verify bundles, this allows verifying
+// bundles without reaching a TUF repository.
+func SetTrustedRoot(data []byte) error {
+	trustedRoot, err := root.NewTrustedRootFromJSON(data)
+	if err != nil {
+		return fmt.Errorf("error creating trusted root: %w", err)
+	}
+	staticTrustedRoot = trustedRoot
+	return nil
+}
+
 type VerificationResult struct {
 	Bundle *Bundle
 	Result *verify.VerificationResult
@@ -49,7 +62,7 @@ func verifyBundleAndFetchAttestations(ctx context.Context, opts images.Options)
 		return nil, errors.Wrapf(err, "failed to create remote opts: %v", opts.ImageRef)
 	}
 
-	bundles, desc, err := fetchBundles(ref, attestationlimit, opts.Type, remoteOpts)
+	bundles, desc, err := fetchBundles(ref, attestationlimit, opts.Type, opts.Bundles, remoteOpts)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to fetch bundles: %v", opts.ImageRef)
 	}
@@ -92,26 +105,51 @@ func verifyBundles(bundles []*Bundle, desc *v1.Descriptor, trustedRoot *root.Tru
 	return verificationResults, nil
 }
 
-func fetchBundles(ref name.Reference, limit int, predicateType string, remoteOpts []remote.Option) ([]*Bundle, *v1.Descriptor, error) {
-	bundles := make([]*Bundle, 0)
-
+func fetchBundles(ref name.Reference, limit int, predicateType string, policyBundles []string, remoteOpts []remote.Option) ([]*Bundle, *v1.Descriptor, error) {
 	desc, err := remote.Head(ref, remoteOpts...)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	bundles, err := fetchReferrerBundles(ref, desc, limit, remoteOpts)
+	if err != nil {
+		// registries of air-gapped clusters may not serve referrers, bundles referenced in the policy are enough
+		if len(policyBundles) == 0 {
+			return nil, nil, err
+		}
+		logger.V(4).Info("failed to fetch sigstore bundles from referrers", "err", err.Error(), "image", ref.String())
+	}
+
+	for _, source := range policyBundles {
+		b, err := loadBundle(source)
+		if err != nil {
+			return nil, nil, err
+		}
+		bundles = append(bundles, &Bundle{ProtoBundle: b})
+	}
+
+	if predicateType != "" {
+		return filterBundles(bundles, predicateType), desc, nil
+	}
+
+	return bundles, desc, nil
+}
+
+func fetchReferrerBundles(ref name.Reference, desc *v1.Descriptor, limit int, remoteOpts []remote.Option) ([]*Bundle, error) {
+	bundles := make([]*Bundle, 0)
+
 	referrers, err := remote.Referrers(ref.Context().Digest(desc.Digest.String()), remoteOpts...)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	referrersDescs, err := referrers.IndexManifest()
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	if len(referrersDescs.Manifests) > limit {
-		return nil, nil, fmt.Errorf("failed to fetch referrers: to many referrers found, max limit is %d", limit)
+		return nil, fmt.Errorf("failed to fetch referrers: to many referrers found, max limit is %d", limit)
 	}
 
 	for _, manifestDesc := range referrersDescs.Manifests {
@@ -121,64 +159,75 @@ func fetchBundles(ref name.Reference, limit int, predicateType string, remoteOpt
 
 		refImg, err := remote.Image(ref.Context().Digest(manifestDesc.Digest.String()), remoteOpts...)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to fetch referrer image: %w", err)
+			return nil, fmt.Errorf("failed to fetch referrer image: %w", err)
 		}
 		layers, err := refImg.Layers()
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to fetch referrer layer: %w", err)
+			return nil, fmt.Errorf("failed to fetch referrer layer: %w", err)
 		}
 		if len(layers) == 0 {
-			return nil, nil, fmt.Errorf("layers not found")
+			return nil, fmt.Errorf("layers not found")
 		}
 		layer := layers[0]
 		layerSize, err := layer.Size()
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 		if layerSize > maxLayerSize {
-			return nil, nil, fmt.Errorf("layer size %d exceeds %d", layerSize, maxLayerSize)
+			return nil, fmt.Errorf("layer size %d exceeds %d", layerSize, maxLayerSize)
 		}
 		layerBytes, err := layer.Uncompressed()
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to fetch referrer layer: %w", err)
+			return nil, fmt.Errorf("failed to fetch referrer layer: %w", err)
 		}
 		bundleBytes, err := io.ReadAll(layerBytes)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to fetch referrer layer: %w", err)
+			return nil, fmt.Errorf("failed to fetch referrer layer: %w", err)
 		}
 		b := &bundle.Bundle{}
 		err = b.UnmarshalJSON(bundleBytes)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to unmarshal bundle: %w", err)
+			return nil, fmt.Errorf("failed to unmarshal bundle: %w", err)
 		}
 		bundles = append(bundles, &Bundle{ProtoBundle: b})
 	}
 
-	if predicateType != "" {
-		filteredBundles := make([]*Bundle, 0)
-		for _, b := range bundles {
-			dsseEnvelope := b.ProtoBundle.Bundle.GetDsseEnvelope()
-			if dsseEnvelope != nil {
-				if dsseEnvelope.PayloadType != "application/vnd.in-toto+json" {
-					continue
-				}
-				var intotoStatement in_toto.Statement //nolint:staticcheck
-				if err := json.Unmarshal(dsseEnvelope.Payload, &intotoStatement); err != nil {
-					continue
-				}
-
-				if intotoStatement.PredicateType == predicateType {
-					filteredBundles = append(filteredBundles, &Bundle{
-						ProtoBundle:   b.ProtoBundle,
-						DSSE_Envelope: &intotoStatement,
-					})
-				}
+	return bundles, nil
+}
+
+// loadBundle loads a sigstore bundle referenced in a policy, the source is either a JSON encoded bundle
+// or the path or URL of a bundle.
+// loadBundle parses a JSON encoded bundle, bundles are never loaded from files or URLs found in policies
+func loadBundle(data string) (*bundle.Bundle, error) {
+	b := &bundle.Bundle{}
+	if err := b.UnmarshalJSON([]byte(data)); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal bundle: %w", err)
+	}
+	return b, nil
+}
+
+func filterBundles(bundles []*Bundle, predicateType string) []*Bundle {
+	filteredBundles := make([]*Bundle, 0)
+	for _, b := range bundles {
+		dsseEnvelope := b.ProtoBundle.Bundle.GetDsseEnvelope()
+		if dsseEnvelope != nil {
+			if dsseEnvelope.PayloadType != "application/vnd.in-toto+json" {
+				continue
+			}
+			var intotoStatement in_toto.Statement //nolint:staticcheck
+			if err := json.Unmarshal(dsseEnvelope.Payload, &intotoStatement); err != nil {
+				continue
+			}
+
+			if intotoStatement.PredicateType == predicateType {
+				filteredBundles = append(filteredBundles, &Bundle{
+					ProtoBundle:   b.ProtoBundle,
+					DSSE_Envelope: &intotoStatement,
+				})
 			}
 		}
-		return filteredBundles, desc, nil
 	}
-
-	return bundles, desc, nil
+	return filteredBundles
 }
 
 func buildPolicy(desc *v1.Descriptor, opts images.Options) (verify.PolicyBuilder, error) {
@@ -209,6 +258,9 @@ func buildVerifyOptions(opts images.Options) []verify.VerifierOption {
 }
 
 func getTrustedRoot(ctx context.Context) (*root.TrustedRoot, error) {
+	if staticTrustedRoot != nil {
+		return staticTrustedRoot, nil
+	}
 	tufClient, err := tuf.NewFromEnv(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("initializing tuf: %w", err)
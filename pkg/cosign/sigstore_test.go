@@ -89,3 +89,18 @@ func TestSigstoreBundleAttestation(t *testing.T) {
 	assert.Assert(t, ok)
 	assert.Equal(t, buildType, "https://actions.github.io/buildtypes/workflow/v1")
 }
+
+func TestLoadBundle(t *testing.T) {
+	_, err := loadBundle(`{"mediaType": "invalid"}`)
+	assert.ErrorContains(t, err, "failed to unmarshal bundle")
+
+	// paths and URLs are not loaded
+	_, err = loadBundle("/etc/kubernetes/pki/ca.key")
+	assert.ErrorContains(t, err, "failed to unmarshal bundle")
+}
+
+func TestSetTrustedRoot(t *testing.T) {
+	err := SetTrustedRoot([]byte("{}"))
+	assert.ErrorContains(t, err, "error creating trusted root")
+	assert.Assert(t, staticTrustedRoot == nil)
+}
@@ -0,0 +1,97 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// crdSchemaTTL is the duration for which resolved schemas are cached,
+// it bounds the time needed to observe changes to custom resource definitions.
+const crdSchemaTTL = 5 * time.Minute
+
+type crdSchemaEntry struct {
+	schema  *apiextensionsv1.JSONSchemaProps
+	expires time.Time
+}
+
+type crdSchemaCache struct {
+	now     func() time.Time
+	lock    sync.Mutex
+	entries map[schema.GroupVersionKind]crdSchemaEntry
+}
+
+func newCRDSchemaCache() *crdSchemaCache {
+	return &crdSchemaCache{
+		now:     time.Now,
+		entries: map[schema.GroupVersionKind]crdSchemaEntry{},
+	}
+}
+
+func (c *crdSchemaCache) get(gvk schema.GroupVersionKind) (*apiextensionsv1.JSONSchemaProps, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry, ok := c.entries[gvk]
+	if !ok || !c.now().Before(entry.expires) {
+		return nil, false
+	}
+	return entry.schema, true
+}
+
+func (c *crdSchemaCache) set(gvk schema.GroupVersionKind, props *apiextensionsv1.JSONSchemaProps) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.entries[gvk] = crdSchemaEntry{schema: props, expires: c.now().Add(crdSchemaTTL)}
+}
+
+func (a *dclientAdapter) GetCustomResourceSchema(ctx context.Context, apiVersion, kind string) (*apiextensionsv1.JSONSchemaProps, error) {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	gvk := gv.WithKind(kind)
+	if props, ok := a.schemas.get(gvk); ok {
+		return props, nil
+	}
+	props, err := a.fetchCustomResourceSchema(ctx, gvk)
+	if err != nil {
+		return nil, err
+	}
+	// kinds without a custom resource definition are cached too, to avoid looking them up again
+	a.schemas.set(gvk, props)
+	return props, nil
+}
+
+func (a *dclientAdapter) fetchCustomResourceSchema(ctx context.Context, gvk schema.GroupVersionKind) (*apiextensionsv1.JSONSchemaProps, error) {
+	// custom resources can't be defined in the core group
+	if gvk.Group == "" {
+		return nil, nil
+	}
+	gvr, err := a.client.Discovery().GetGVRFromGVK(gvk)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := a.client.GetResource(ctx, "apiextensions.k8s.io/v1", "CustomResourceDefinition", "", gvr.Resource+"."+gvr.Group)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var crd apiextensionsv1.CustomResourceDefinition
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), &crd); err != nil {
+		return nil, fmt.Errorf("failed to convert custom resource definition %s: %w", obj.GetName(), err)
+	}
+	for _, version := range crd.Spec.Versions {
+		if version.Name == gvk.Version && version.Schema != nil {
+			return version.Schema.OpenAPIV3Schema, nil
+		}
+	}
+	return nil, nil
+}
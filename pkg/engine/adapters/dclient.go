@@ -14,11 +14,15 @@ import (
 )
 
 type dclientAdapter struct {
-	client dclient.Interface
+	client  dclient.Interface
+	schemas *crdSchemaCache
 }
 
 func Client(client dclient.Interface) engineapi.Client {
-	return &dclientAdapter{client}
+	return &dclientAdapter{
+		client:  client,
+		schemas: newCRDSchemaCache(),
+	}
 }
 
 func (a *dclientAdapter) RawAbsPath(ctx context.Context, path, method string, dataReader io.Reader) ([]byte, error) {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/google/go-containerregistry/pkg/name"
+	gcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/registryclient"
 )
@@ -66,3 +67,28 @@ func (a *rclientAdapter) ForRef(ctx context.Context, ref string) (*engineapi.Ima
 	}
 	return &data, nil
 }
+
+func (a *rclientAdapter) FetchReferrers(ctx context.Context, ref string) ([]byte, error) {
+	nameOpts := a.Client.NameOptions()
+	parsedRef, err := name.ParseReference(ref, nameOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse image reference: %s, error: %v", ref, err)
+	}
+	digest, ok := parsedRef.(name.Digest)
+	if !ok {
+		desc, err := a.Client.FetchImageDescriptor(ctx, ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch image descriptor: %s, error: %v", ref, err)
+		}
+		digest = parsedRef.Context().Digest(desc.Digest.String())
+	}
+	remoteOpts, err := a.Client.Options(ctx)
+	if err != nil {
+		return nil, err
+	}
+	index, err := gcrremote.Referrers(digest, remoteOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch referrers for image reference: %s, error: %v", ref, err)
+	}
+	return index.RawManifest()
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/name"
 	gcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -36,10 +37,17 @@ type ResourceClient interface {
 	IsNamespaced(group, version, kind string) (bool, error)
 }
 
+type SchemaClient interface {
+	// GetCustomResourceSchema returns the OpenAPI v3 schema of a custom resource kind,
+	// it returns nil if the kind is not defined by a custom resource definition.
+	GetCustomResourceSchema(ctx context.Context, apiVersion, kind string) (*apiextensionsv1.JSONSchemaProps, error)
+}
+
 type Client interface {
 	RawClient
 	AuthClient
 	ResourceClient
+	SchemaClient
 }
 
 type ImageData struct {
@@ -55,6 +63,7 @@ type ImageData struct {
 
 type ImageDataClient interface {
 	ForRef(ctx context.Context, ref string) (*ImageData, error)
+	FetchReferrers(ctx context.Context, ref string) ([]byte, error)
 	FetchImageDescriptor(context.Context, string) (*gcrremote.Descriptor, error)
 }
 
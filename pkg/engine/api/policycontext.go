@@ -29,4 +29,6 @@ type PolicyContext interface {
 
 	JSONContext() enginecontext.Interface
 	Copy() PolicyContext
+	// Fork returns a copy using an independent JSON context, it can be used concurrently with the original.
+	Fork() (PolicyContext, error)
 }
@@ -22,6 +22,18 @@ func (pr *PolicyResponse) Add(stats ExecutionStats, responses ...RuleResponse) {
 	}
 }
 
+// DeepCopy returns a copy of the policy response that doesn't share rule responses with it
+func (pr PolicyResponse) DeepCopy() PolicyResponse {
+	if pr.Rules != nil {
+		rules := make([]RuleResponse, 0, len(pr.Rules))
+		for _, rule := range pr.Rules {
+			rules = append(rules, rule.DeepCopy())
+		}
+		pr.Rules = rules
+	}
+	return pr
+}
+
 func NewPolicyResponse() PolicyResponse {
 	return PolicyResponse{}
 }
@@ -21,6 +21,9 @@ type NamespacedResourceResolver[T any] interface {
 // ConfigmapResolver is an abstract interface used to resolve configmaps
 type ConfigmapResolver = NamespacedResourceResolver[*corev1.ConfigMap]
 
+// SecretResolver is an abstract interface used to resolve secrets
+type SecretResolver = NamespacedResourceResolver[*corev1.Secret]
+
 // namespacedResourceResolverChain represents a chain of NamespacedResourceResolver
 type namespacedResourceResolverChain[T any] []NamespacedResourceResolver[T]
 
@@ -0,0 +1,9 @@
+package api
+
+// ResultCache is an abstract interface used to memoize policy responses computed by the engine
+type ResultCache interface {
+	// Get returns the policy response stored for a given key.
+	Get(string) (PolicyResponse, bool)
+	// Set stores a policy response for a given key.
+	Set(string, PolicyResponse)
+}
@@ -133,6 +133,43 @@ func (r RuleResponse) WithProperties(m map[string]string) *RuleResponse {
 	return &r
 }
 
+// DeepCopy returns a copy of the rule response that doesn't share resources, exceptions or properties with it
+func (r RuleResponse) DeepCopy() RuleResponse {
+	if r.generatedResources != nil {
+		resources := make([]*unstructured.Unstructured, 0, len(r.generatedResources))
+		for _, resource := range r.generatedResources {
+			resources = append(resources, resource.DeepCopy())
+		}
+		r.generatedResources = resources
+	}
+	if r.patchedTarget != nil {
+		r.patchedTarget = r.patchedTarget.DeepCopy()
+	}
+	if r.podSecurityChecks != nil {
+		checks := *r.podSecurityChecks
+		checks.Checks = append([]pssutils.PSSCheckResult(nil), checks.Checks...)
+		r.podSecurityChecks = &checks
+	}
+	if r.exceptions != nil {
+		exceptions := make([]kyvernov2.PolicyException, 0, len(r.exceptions))
+		for i := range r.exceptions {
+			exceptions = append(exceptions, *r.exceptions[i].DeepCopy())
+		}
+		r.exceptions = exceptions
+	}
+	if r.binding != nil {
+		r.binding = r.binding.DeepCopy()
+	}
+	if r.properties != nil {
+		properties := make(map[string]string, len(r.properties))
+		for key, value := range r.properties {
+			properties[key] = value
+		}
+		r.properties = properties
+	}
+	return r
+}
+
 func (r *RuleResponse) Stats() ExecutionStats {
 	return r.stats
 }
@@ -128,3 +128,20 @@ func TestRuleResponse_HasStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestPolicyResponse_DeepCopy(t *testing.T) {
+	response := PolicyResponse{
+		Rules: []RuleResponse{
+			*RuleFail("check", Validation, "failed", map[string]string{"key": "value"}),
+		},
+	}
+	copied := response.DeepCopy()
+	copied.Rules[0] = *copied.Rules[0].WithEmitWarning(false)
+	copied.Rules[0].Properties()["key"] = "other"
+	if !response.Rules[0].EmitWarning() {
+		t.Errorf("rule response was modified through its copy")
+	}
+	if response.Rules[0].Properties()["key"] != "value" {
+		t.Errorf("rule properties were modified through the copy")
+	}
+}
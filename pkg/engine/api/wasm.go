@@ -0,0 +1,21 @@
+package api
+
+import (
+	"context"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+)
+
+// WasmResult is the decision returned by a WASM module
+type WasmResult struct {
+	// Allowed is true when the resource passes the validation
+	Allowed bool `json:"allowed"`
+	// Message explains the decision
+	Message string `json:"message,omitempty"`
+}
+
+// WasmEvaluator is an abstract interface used to run the WASM modules referenced by validate.wasm rules
+type WasmEvaluator interface {
+	// Evaluate calls the module function with the JSON encoded input.
+	Evaluate(ctx context.Context, module kyvernov1.Wasm, input []byte) (*WasmResult, error)
+}
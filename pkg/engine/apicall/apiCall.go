@@ -13,11 +13,12 @@ import (
 )
 
 type apiCall struct {
-	logger   logr.Logger
-	jp       jmespath.Interface
-	entry    kyvernov1.ContextEntry
-	jsonCtx  enginecontext.Interface
-	executor Executor
+	logger    logr.Logger
+	jp        jmespath.Interface
+	entry     kyvernov1.ContextEntry
+	jsonCtx   enginecontext.Interface
+	executor  Executor
+	responses *responseCache
 }
 
 func New(
@@ -27,19 +28,21 @@ func New(
 	jsonCtx enginecontext.Interface,
 	client ClientInterface,
 	apiCallConfig APICallConfiguration,
+	policy kyvernov1.PolicyInterface,
 ) (*apiCall, error) {
 	if entry.APICall == nil {
 		return nil, fmt.Errorf("missing APICall in context entry %v", entry)
 	}
 
-	executor := NewExecutor(logger, entry.Name, client, apiCallConfig)
+	executor := NewExecutor(logger, entry.Name, client, apiCallConfig, policy)
 
 	return &apiCall{
-		logger:   logger,
-		jp:       jp,
-		entry:    entry,
-		jsonCtx:  jsonCtx,
-		executor: executor,
+		logger:    logger,
+		jp:        jp,
+		entry:     entry,
+		jsonCtx:   jsonCtx,
+		executor:  executor,
+		responses: apiCallConfig.responses,
 	}, nil
 }
 
@@ -62,7 +65,7 @@ func (a *apiCall) Fetch(ctx context.Context) ([]byte, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to substitute variables in context entry %s %s: %v", a.entry.Name, a.entry.APICall.URLPath, err)
 	}
-	data, err := a.Execute(ctx, &call.APICall)
+	data, err := a.executeCached(ctx, &call.APICall)
 	if err != nil {
 		if data == nil && a.entry.APICall.Default != nil {
 			data = a.entry.APICall.Default.Raw
@@ -86,6 +89,29 @@ func (a *apiCall) Execute(ctx context.Context, call *kyvernov1.APICall) ([]byte,
 	return a.executor.Execute(ctx, call)
 }
 
+// executeCached executes the call, reusing a previous response for the same resolved
+// request when the context entry has a cache TTL.
+func (a *apiCall) executeCached(ctx context.Context, call *kyvernov1.APICall) ([]byte, error) {
+	ttl := a.entry.APICall.CacheTTL
+	if ttl == nil || ttl.Duration <= 0 || a.responses == nil {
+		return a.Execute(ctx, call)
+	}
+	key, err := json.Marshal(call)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute cache key for APICall %s: %w", a.entry.Name, err)
+	}
+	if data, ok := a.responses.get(string(key)); ok {
+		a.logger.V(4).Info("using cached APICall response", "name", a.entry.Name, "len", len(data))
+		return data, nil
+	}
+	data, err := a.Execute(ctx, call)
+	if err != nil {
+		return data, err
+	}
+	a.responses.set(string(key), data, ttl.Duration)
+	return data, nil
+}
+
 func (a *apiCall) transformAndStore(jsonData []byte) ([]byte, error) {
 	if a.entry.APICall.Default != nil {
 		if string(jsonData) == string(a.entry.APICall.Default.Raw) {
@@ -3,10 +3,12 @@ package apicall
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
@@ -14,7 +16,9 @@ import (
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var (
@@ -86,7 +90,7 @@ func Test_serviceGetRequest(t *testing.T) {
 		entry := kyvernov1.ContextEntry{}
 		ctx := enginecontext.NewContext(jp)
 
-		_, err := New(logr.Discard(), jp, entry, ctx, nil, apiConfig)
+		_, err := New(logr.Discard(), jp, entry, ctx, nil, apiConfig, nil)
 		assert.ErrorContains(t, err, "missing APICall")
 
 		entry.Name = "test"
@@ -102,19 +106,19 @@ func Test_serviceGetRequest(t *testing.T) {
 			},
 		}
 
-		call, err := New(logr.Discard(), jp, entry, ctx, nil, apiConfig)
+		call, err := New(logr.Discard(), jp, entry, ctx, nil, apiConfig, nil)
 		assert.NilError(t, err)
 		_, err = call.FetchAndLoad(context.TODO())
 		assert.ErrorContains(t, err, "invalid request type")
 
 		entry.APICall.Method = "GET"
-		call, err = New(logr.Discard(), jp, entry, ctx, nil, apiConfig)
+		call, err = New(logr.Discard(), jp, entry, ctx, nil, apiConfig, nil)
 		assert.NilError(t, err)
 		_, err = call.FetchAndLoad(context.TODO())
 		assert.ErrorContains(t, err, "HTTP 404")
 
 		entry.APICall.Service.URL = s.URL + "/resource"
-		call, err = New(logr.Discard(), jp, entry, ctx, nil, apiConfig)
+		call, err = New(logr.Discard(), jp, entry, ctx, nil, apiConfig, nil)
 		assert.NilError(t, err)
 
 		data, err := call.FetchAndLoad(context.TODO())
@@ -122,12 +126,12 @@ func Test_serviceGetRequest(t *testing.T) {
 		assert.Assert(t, data != nil, "nil data")
 		assert.Equal(t, string(serverResponse), string(data))
 
-		call, err = New(logr.Discard(), jp, entry, ctx, nil, apiConfigMaxSizeExceed)
+		call, err = New(logr.Discard(), jp, entry, ctx, nil, apiConfigMaxSizeExceed, nil)
 		assert.NilError(t, err)
 		_, err = call.FetchAndLoad(context.TODO())
 		assert.ErrorContains(t, err, "response length must be less than max allowed response length of 10")
 
-		call, err = New(logr.Discard(), jp, entry, ctx, nil, apiConfigWithoutSecurityCheck)
+		call, err = New(logr.Discard(), jp, entry, ctx, nil, apiConfigWithoutSecurityCheck, nil)
 		assert.NilError(t, err)
 		data, err = call.FetchAndLoad(context.TODO())
 		assert.NilError(t, err)
@@ -157,7 +161,7 @@ func Test_servicePostRequest(t *testing.T) {
 	}
 
 	ctx := enginecontext.NewContext(jp)
-	call, err := New(logr.Discard(), jp, entry, ctx, nil, apiConfig)
+	call, err := New(logr.Discard(), jp, entry, ctx, nil, apiConfig, nil)
 	assert.NilError(t, err)
 	data, err := call.FetchAndLoad(context.TODO())
 	assert.NilError(t, err)
@@ -205,7 +209,7 @@ func Test_servicePostRequest(t *testing.T) {
 		},
 	}
 
-	call, err = New(logr.Discard(), jp, entry, ctx, nil, apiConfig)
+	call, err = New(logr.Discard(), jp, entry, ctx, nil, apiConfig, nil)
 	assert.NilError(t, err)
 	data, err = call.FetchAndLoad(context.TODO())
 	assert.NilError(t, err)
@@ -240,7 +244,7 @@ func Test_fallbackToDefault(t *testing.T) {
 	}
 
 	entry.APICall.Method = "GET"
-	call, err := New(logr.Discard(), jp, entry, ctx, nil, apiConfig)
+	call, err := New(logr.Discard(), jp, entry, ctx, nil, apiConfig, nil)
 	assert.NilError(t, err)
 
 	jsonData, err := call.Fetch(context.TODO())
@@ -292,7 +296,7 @@ func Test_serviceHeaders(t *testing.T) {
 	}
 
 	entry.APICall.Service.URL = s.URL + "/resource"
-	call, err := New(logr.Discard(), jp, entry, ctx, nil, apiConfig)
+	call, err := New(logr.Discard(), jp, entry, ctx, nil, apiConfig, nil)
 	assert.NilError(t, err)
 	data, err := call.FetchAndLoad(context.TODO())
 	assert.NilError(t, err)
@@ -305,3 +309,123 @@ func Test_serviceHeaders(t *testing.T) {
 	assert.Equal(t, "application/json", responseHeaders["Content-Type"][0])
 	assert.Equal(t, "CustomVal", responseHeaders["Custom-Key"][0])
 }
+
+type testSecretResolver map[string]*corev1.Secret
+
+func (r testSecretResolver) Get(_ context.Context, namespace, name string) (*corev1.Secret, error) {
+	if secret, ok := r[namespace+"/"+name]; ok {
+		return secret, nil
+	}
+	return nil, fmt.Errorf("secret %s/%s not found", namespace, name)
+}
+
+func Test_serviceAuthSecret(t *testing.T) {
+	s := buildEchoHeaderTestServer()
+	defer s.Close()
+
+	namespace := config.KyvernoNamespace()
+	secrets := testSecretResolver{
+		namespace + "/token": {
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "token"},
+			Data:       map[string][]byte{"token": []byte("secret-token\n")},
+		},
+		namespace + "/empty": {
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "empty"},
+		},
+	}
+
+	newEntry := func(secretName string) kyvernov1.ContextEntry {
+		return kyvernov1.ContextEntry{
+			Name: "test",
+			APICall: &kyvernov1.ContextAPICall{
+				APICall: kyvernov1.APICall{
+					Method: "GET",
+					Service: &kyvernov1.ServiceCall{
+						URL:  s.URL + "/resource",
+						Auth: &kyvernov1.ServiceCallAuth{SecretName: secretName},
+					},
+				},
+			},
+		}
+	}
+
+	callConfig := NewAPICallConfiguration(1*1000*1000, WithAuthSecrets(secrets, config.NewDefaultConfiguration(false)))
+	cpol := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cpol"}}
+	call, err := New(logr.Discard(), jp, newEntry("token"), enginecontext.NewContext(jp), nil, callConfig, cpol)
+	assert.NilError(t, err)
+	data, err := call.FetchAndLoad(context.TODO())
+	assert.NilError(t, err)
+	var responseHeaders map[string][]string
+	assert.NilError(t, json.Unmarshal(data, &responseHeaders))
+	assert.Equal(t, "Bearer secret-token", responseHeaders["Authorization"][0])
+
+	call, err = New(logr.Discard(), jp, newEntry("empty"), enginecontext.NewContext(jp), nil, callConfig, cpol)
+	assert.NilError(t, err)
+	_, err = call.FetchAndLoad(context.TODO())
+	assert.ErrorContains(t, err, "must contain a token key")
+
+	call, err = New(logr.Discard(), jp, newEntry("missing"), enginecontext.NewContext(jp), nil, callConfig, cpol)
+	assert.NilError(t, err)
+	_, err = call.FetchAndLoad(context.TODO())
+	assert.ErrorContains(t, err, "failed to get auth secret missing")
+
+	// namespaced policies can't reference secrets from another namespace
+	pol := &kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "pol"}}
+	call, err = New(logr.Discard(), jp, newEntry("token"), enginecontext.NewContext(jp), nil, callConfig, pol)
+	assert.NilError(t, err)
+	_, err = call.FetchAndLoad(context.TODO())
+	assert.ErrorContains(t, err, "policy team-a/pol can't reference secrets from namespace "+namespace)
+
+	call, err = New(logr.Discard(), jp, newEntry("token"), enginecontext.NewContext(jp), nil, apiConfig, cpol)
+	assert.NilError(t, err)
+	_, err = call.FetchAndLoad(context.TODO())
+	assert.ErrorContains(t, err, "authentication secrets are not supported")
+}
+
+func Test_cacheTTL(t *testing.T) {
+	calls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/resource", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		json.NewEncoder(w).Encode(map[string]interface{}{"calls": calls, "query": r.URL.RawQuery})
+	})
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	newEntry := func(query string, ttl *metav1.Duration) kyvernov1.ContextEntry {
+		return kyvernov1.ContextEntry{
+			Name: "test",
+			APICall: &kyvernov1.ContextAPICall{
+				APICall: kyvernov1.APICall{
+					Method: "GET",
+					Service: &kyvernov1.ServiceCall{
+						URL: s.URL + "/resource?" + query,
+					},
+				},
+				JMESPath: "calls",
+				CacheTTL: ttl,
+			},
+		}
+	}
+	fetch := func(config APICallConfiguration, entry kyvernov1.ContextEntry) string {
+		call, err := New(logr.Discard(), jp, entry, enginecontext.NewContext(jp), nil, config, nil)
+		assert.NilError(t, err)
+		data, err := call.FetchAndLoad(context.TODO())
+		assert.NilError(t, err)
+		return string(data)
+	}
+
+	config := NewAPICallConfiguration(1 * 1000 * 1000)
+	ttl := &metav1.Duration{Duration: time.Minute}
+	assert.Equal(t, "1", fetch(config, newEntry("a", ttl)))
+	assert.Equal(t, "1", fetch(config, newEntry("a", ttl)))
+	// a different resolved request is not served from the cache
+	assert.Equal(t, "2", fetch(config, newEntry("b", ttl)))
+	// entries without a TTL always call the service
+	assert.Equal(t, "3", fetch(config, newEntry("a", nil)))
+
+	now := time.Now()
+	config.responses.now = func() time.Time { return now.Add(2 * time.Minute) }
+	assert.Equal(t, "4", fetch(config, newEntry("a", ttl)))
+	assert.Equal(t, "4", fetch(config, newEntry("a", ttl)))
+}
@@ -0,0 +1,53 @@
+package apicall
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// tokenKey is the secret key holding a bearer token.
+const tokenKey = "token"
+
+type serviceAuth struct {
+	token       string
+	certificate *tls.Certificate
+}
+
+// loadAuth resolves the credentials referenced by a service call, the secret is fetched from the Kyverno namespace
+// and namespaced policies outside of it are not allowed to reference it.
+func (a *executor) loadAuth(ctx context.Context, auth *kyvernov1.ServiceCallAuth) (*serviceAuth, error) {
+	if auth == nil {
+		return nil, nil
+	}
+	if a.config.secrets == nil {
+		return nil, fmt.Errorf("authentication secrets are not supported for APICall %s", a.name)
+	}
+	data, err := engineutils.FetchSecretData(ctx, a.config.secrets, a.config.configuration, a.policy, config.KyvernoNamespace(), auth.SecretName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auth secret %s for APICall %s: %w", auth.SecretName, a.name, err)
+	}
+	var result serviceAuth
+	if token, ok := data[tokenKey]; ok {
+		result.token = strings.TrimSpace(string(token))
+	}
+	cert, hasCert := data[corev1.TLSCertKey]
+	key, hasKey := data[corev1.TLSPrivateKeyKey]
+	if hasCert || hasKey {
+		certificate, err := tls.X509KeyPair(cert, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate from secret %s for APICall %s: %w", auth.SecretName, a.name, err)
+		}
+		result.certificate = &certificate
+	}
+	if result.token == "" && result.certificate == nil {
+		return nil, fmt.Errorf("auth secret %s for APICall %s must contain a %s key or %s and %s keys", auth.SecretName, a.name, tokenKey, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+	}
+	return &result, nil
+}
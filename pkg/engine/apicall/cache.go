@@ -0,0 +1,61 @@
+package apicall
+
+import (
+	"time"
+
+	"github.com/dgraph-io/ristretto"
+)
+
+const (
+	// maxCachedResponsesSize bounds the total size in bytes of the cached responses,
+	// the least valuable entries are evicted when exceeded
+	maxCachedResponsesSize = 64 << 20
+	// expectedCachedResponses is the expected number of cached responses, used to size the admission counters
+	expectedCachedResponses = 10000
+)
+
+type cachedResponse struct {
+	data    []byte
+	expires time.Time
+}
+
+// responseCache holds API call responses shared across admission requests,
+// entries expire after their TTL and the cache is bounded by the size of the responses.
+type responseCache struct {
+	now   func() time.Time
+	cache *ristretto.Cache
+}
+
+func newResponseCache() (*responseCache, error) {
+	cache, err := ristretto.NewCache(&ristretto.Config{
+		MaxCost:     maxCachedResponsesSize,
+		NumCounters: 10 * expectedCachedResponses,
+		BufferItems: 64,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &responseCache{
+		now:   time.Now,
+		cache: cache,
+	}, nil
+}
+
+func (c *responseCache) get(key string) ([]byte, bool) {
+	value, ok := c.cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+	entry, ok := value.(cachedResponse)
+	if !ok || !c.now().Before(entry.expires) {
+		c.cache.Del(key)
+		return nil, false
+	}
+	return entry.data, true
+}
+
+func (c *responseCache) set(key string, data []byte, ttl time.Duration) {
+	c.cache.SetWithTTL(key, cachedResponse{data: data, expires: c.now().Add(ttl)}, int64(len(data))+int64(len(key)), ttl)
+	// make the response visible to the next calls
+	c.cache.Wait()
+}
@@ -0,0 +1,30 @@
+package apicall
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func Test_responseCache(t *testing.T) {
+	cache, err := newResponseCache()
+	assert.NilError(t, err)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	cache.set("a", []byte("response"), time.Minute)
+	data, ok := cache.get("a")
+	assert.Assert(t, ok)
+	assert.Equal(t, "response", string(data))
+
+	// responses larger than the cache are not kept
+	cache.set("b", make([]byte, maxCachedResponsesSize+1), time.Minute)
+	_, ok = cache.get("b")
+	assert.Assert(t, !ok)
+
+	// expired responses are dropped
+	cache.now = func() time.Time { return now.Add(2 * time.Minute) }
+	_, ok = cache.get("a")
+	assert.Assert(t, !ok)
+}
@@ -1,11 +1,38 @@
 package apicall
 
+import (
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+)
+
 type APICallConfiguration struct {
 	maxAPICallResponseLength int64
+	secrets                  engineapi.SecretResolver
+	configuration            config.Configuration
+	responses                *responseCache
 }
 
-func NewAPICallConfiguration(maxLen int64) APICallConfiguration {
-	return APICallConfiguration{
+type APICallConfigurationOption func(*APICallConfiguration)
+
+// WithAuthSecrets sets the resolver used to fetch the secrets referenced by service calls for authentication,
+// secrets are fetched from the Kyverno namespace and only for the policies allowed to reference them.
+func WithAuthSecrets(resolver engineapi.SecretResolver, configuration config.Configuration) APICallConfigurationOption {
+	return func(c *APICallConfiguration) {
+		c.secrets = resolver
+		c.configuration = configuration
+	}
+}
+
+func NewAPICallConfiguration(maxLen int64, opts ...APICallConfigurationOption) APICallConfiguration {
+	config := APICallConfiguration{
 		maxAPICallResponseLength: maxLen,
 	}
+	// responses are not cached when the cache can't be created
+	if responses, err := newResponseCache(); err == nil {
+		config.responses = responses
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return config
 }
@@ -26,6 +26,7 @@ type executor struct {
 	name   string
 	client ClientInterface
 	config APICallConfiguration
+	policy kyvernov1.PolicyInterface
 }
 
 func NewExecutor(
@@ -33,12 +34,14 @@ func NewExecutor(
 	name string,
 	client ClientInterface,
 	apiCallConfig APICallConfiguration,
+	policy kyvernov1.PolicyInterface,
 ) *executor {
 	return &executor{
 		logger: logger,
 		name:   name,
 		client: client,
 		config: apiCallConfig,
+		policy: policy,
 	}
 }
 
@@ -67,12 +70,17 @@ func (a *executor) executeServiceCall(ctx context.Context, apiCall *kyvernov1.AP
 		return nil, fmt.Errorf("missing service for APICall %s", a.name)
 	}
 
-	client, err := a.buildHTTPClient(apiCall.Service)
+	auth, err := a.loadAuth(ctx, apiCall.Service.Auth)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := a.buildHTTPRequest(ctx, apiCall)
+	client, err := a.buildHTTPClient(apiCall.Service, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := a.buildHTTPRequest(ctx, apiCall, auth)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build HTTP request for APICall %s: %w", a.name, err)
 	}
@@ -110,7 +118,7 @@ func (a *executor) executeServiceCall(ctx context.Context, apiCall *kyvernov1.AP
 	return body, nil
 }
 
-func (a *executor) buildHTTPRequest(ctx context.Context, apiCall *kyvernov1.APICall) (*http.Request, error) {
+func (a *executor) buildHTTPRequest(ctx context.Context, apiCall *kyvernov1.APICall, auth *serviceAuth) (*http.Request, error) {
 	if apiCall.Service == nil {
 		return nil, fmt.Errorf("missing service")
 	}
@@ -133,20 +141,26 @@ func (a *executor) buildHTTPRequest(ctx context.Context, apiCall *kyvernov1.APIC
 		return nil, fmt.Errorf("failed to build request for APICall %s: %w", a.name, err)
 	}
 
-	if err := a.addHTTPHeaders(req, apiCall.Service.Headers); err != nil {
+	if err := a.addHTTPHeaders(req, apiCall.Service.Headers, auth); err != nil {
 		return nil, fmt.Errorf("failed to add headers for APICall %s: %w", a.name, err)
 	}
 
 	return req, nil
 }
 
-func (a *executor) addHTTPHeaders(req *http.Request, headers []kyvernov1.HTTPHeader) error {
+func (a *executor) addHTTPHeaders(req *http.Request, headers []kyvernov1.HTTPHeader, auth *serviceAuth) error {
 	for _, header := range headers {
 		req.Header.Add(header.Key, header.Value)
 	}
 
 	if req.Header.Get("Authorization") == "" {
-		token := a.getToken()
+		// when credentials are configured, the service account token is never sent
+		var token string
+		if auth != nil {
+			token = auth.token
+		} else {
+			token = a.getToken()
+		}
 		if token != "" {
 			req.Header.Add("Authorization", "Bearer "+token)
 		}
@@ -166,19 +180,26 @@ func (a *executor) getToken() string {
 	return string(b)
 }
 
-func (a *executor) buildHTTPClient(service *kyvernov1.ServiceCall) (*http.Client, error) {
-	if service == nil || service.CABundle == "" {
+func (a *executor) buildHTTPClient(service *kyvernov1.ServiceCall, auth *serviceAuth) (*http.Client, error) {
+	hasCertificate := auth != nil && auth.certificate != nil
+	if service == nil || (service.CABundle == "" && !hasCertificate) {
 		return http.DefaultClient, nil
 	}
-	caCertPool := x509.NewCertPool()
-	if ok := caCertPool.AppendCertsFromPEM([]byte(service.CABundle)); !ok {
-		return nil, fmt.Errorf("failed to parse PEM CA bundle for APICall %s", a.name)
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if service.CABundle != "" {
+		caCertPool := x509.NewCertPool()
+		if ok := caCertPool.AppendCertsFromPEM([]byte(service.CABundle)); !ok {
+			return nil, fmt.Errorf("failed to parse PEM CA bundle for APICall %s", a.name)
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+	if hasCertificate {
+		tlsConfig.Certificates = []tls.Certificate{*auth.certificate}
 	}
 	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{
-			RootCAs:    caCertPool,
-			MinVersion: tls.VersionTLS12,
-		},
+		TLSClientConfig: tlsConfig,
 	}
 	return &http.Client{
 		Transport: tracing.Transport(transport, otelhttp.WithFilter(tracing.RequestFilterIsInSpan)),
@@ -264,3 +264,76 @@ func Test_Conditions(t *testing.T) {
 	assert.NilError(t, err)
 	assert.Equal(t, pass, true)
 }
+
+func Test_Conditions_SBOM(t *testing.T) {
+	conditions := []v1.AnyAllConditions{
+		{
+			AllConditions: []v1.Condition{
+				{
+					RawKey:   &apiextv1.JSON{Raw: []byte("\"{{ sbom.licenses }}\"")},
+					Operator: "AnyNotIn",
+					RawValue: &apiextv1.JSON{Raw: []byte("[\"GPL-3.0-only\"]")},
+				},
+				{
+					RawKey:   &apiextv1.JSON{Raw: []byte("\"{{ sbom.components[?name == 'openssl'].version | [0] }}\"")},
+					Operator: "Equals",
+					RawValue: &apiextv1.JSON{Raw: []byte("\"3.0.7\"")},
+				},
+			},
+		},
+	}
+
+	ctx := context.NewContext(jmespath.New(config.NewDefaultConfiguration(false)))
+	statement := map[string]interface{}{
+		"type": "https://cyclonedx.org/bom",
+		"predicate": map[string]interface{}{
+			"bomFormat":   "CycloneDX",
+			"specVersion": "1.4",
+			"components": []interface{}{
+				map[string]interface{}{
+					"name":    "openssl",
+					"version": "3.0.7",
+					"licenses": []interface{}{
+						map[string]interface{}{"license": map[string]interface{}{"id": "Apache-2.0"}},
+					},
+				},
+			},
+		},
+	}
+
+	pass, _, err := internal.EvaluateConditions(conditions, ctx, statement, logr.Discard())
+	assert.NilError(t, err)
+	assert.Equal(t, pass, true)
+}
+
+func Test_ConditionsOnRawPredicateOfInvalidSBOM(t *testing.T) {
+	conditions := []v1.AnyAllConditions{
+		{
+			AllConditions: []v1.Condition{
+				{
+					RawKey:   &apiextv1.JSON{Raw: []byte("\"{{ bomFormat }}\"")},
+					Operator: "Equals",
+					RawValue: &apiextv1.JSON{Raw: []byte("\"CycloneDX\"")},
+				},
+			},
+		},
+	}
+	statement := map[string]interface{}{
+		"type": "https://cyclonedx.org/bom",
+		"predicate": map[string]interface{}{
+			"bomFormat":  "CycloneDX",
+			"components": "invalid",
+		},
+	}
+
+	ctx := context.NewContext(jmespath.New(config.NewDefaultConfiguration(false)))
+	pass, _, err := internal.EvaluateConditions(conditions, ctx, statement, logr.Discard())
+	assert.NilError(t, err)
+	assert.Equal(t, pass, true)
+
+	// conditions referencing the SBOM still require a valid document
+	conditions[0].AllConditions[0].RawKey = &apiextv1.JSON{Raw: []byte("\"{{ sbom.format }}\"")}
+	ctx = context.NewContext(jmespath.New(config.NewDefaultConfiguration(false)))
+	_, _, err = internal.EvaluateConditions(conditions, ctx, statement, logr.Discard())
+	assert.ErrorContains(t, err, "failed to parse SBOM")
+}
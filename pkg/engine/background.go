@@ -8,6 +8,7 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/autogen"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/internal"
 	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
@@ -51,6 +52,8 @@ func (e *engine) filterRule(
 	policyContext engineapi.PolicyContext,
 ) *engineapi.RuleResponse {
 	if !rule.HasGenerate() && !rule.HasMutateExisting() {
+		// rules processed at admission can export outputs used by the next rules
+		e.exportRuleOutputs(rule, logger, policyContext)
 		return nil
 	}
 
@@ -65,6 +68,11 @@ func (e *engine) filterRule(
 		logger.Error(err, "failed to get exceptions")
 		return nil
 	}
+	resource := policyContext.NewResource()
+	if resource.Object == nil {
+		resource = policyContext.OldResource()
+	}
+	exceptions = e.filterCELExceptions(context.TODO(), logger, policyContext, resource, exceptions)
 	// check if there are policy exceptions that match the incoming resource
 	matchedExceptions := engineutils.MatchesException(exceptions, policyContext, logger)
 	if len(matchedExceptions) > 0 {
@@ -100,15 +108,32 @@ func (e *engine) filterRule(
 		return nil
 	}
 
+	enginecontext.SetLimits(policyContext.JSONContext(), enginecontext.Limits{
+		MaxForeachIterations: e.configuration.GetMaxForeachIterations(),
+		MaxContextSize:       e.configuration.GetMaxContextSize(),
+	})
+	var outputs map[string]interface{}
 	policyContext.JSONContext().Checkpoint()
-	defer policyContext.JSONContext().Restore()
+	defer func() {
+		policyContext.JSONContext().Restore()
+		// outputs are added after restoring the context so that subsequent rules can use them
+		if err := internal.AddRuleOutputs(policyContext.JSONContext(), outputs); err != nil {
+			logger.Error(err, "failed to add rule outputs in the json context")
+		}
+	}()
 
+	// policy variables are loaded before the rule context entries, which may reference them
+	contextEntries := append(append([]kyvernov1.ContextEntry{}, policy.GetSpec().Variables...), rule.Context...)
 	contextLoader := e.ContextLoader(policyContext.Policy(), rule)
-	if err := contextLoader(context.TODO(), rule.Context, policyContext.JSONContext()); err != nil {
+	if err := contextLoader(context.TODO(), contextEntries, policyContext.JSONContext()); err != nil {
 		logger.V(4).Info("cannot add external data to the context", "reason", err.Error())
 		return nil
 	}
 
+	if outputs, err = internal.EvaluateRuleOutputs(logger, rule, policyContext.JSONContext()); err != nil {
+		return engineapi.RuleError(rule.Name, ruleType, "failed to evaluate outputs", err, rule.ReportProperties)
+	}
+
 	// operate on the copy of the conditions, as we perform variable substitution
 	copyConditions, err := engineutils.TransformConditions(rule.GetAnyAllConditions())
 	if err != nil {
@@ -122,18 +147,28 @@ func (e *engine) filterRule(
 		return engineapi.RuleError(rule.Name, ruleType, "failed to evaluate conditions", err, rule.ReportProperties)
 	}
 
+	// evaluate CEL pre-conditions
+	if pass {
+		pass, msg, err = internal.CheckCELPreconditions(context.TODO(), e.client, policyContext, newResource, policyContext.Policy(), rule.Name, rule.CELPreconditions)
+		if err != nil {
+			return engineapi.RuleError(rule.Name, ruleType, "failed to evaluate CEL preconditions", err, rule.ReportProperties)
+		}
+	}
+
 	if pass {
 		return engineapi.RulePass(rule.Name, ruleType, "", rule.ReportProperties)
 	}
 
-	if policyContext.OldResource().Object != nil {
-		if err = policyContext.JSONContext().AddResource(policyContext.OldResource().Object); err != nil {
+	if oldResource.Object != nil {
+		if err = policyContext.JSONContext().AddResource(oldResource.Object); err != nil {
 			return engineapi.RuleError(rule.Name, ruleType, "failed to update JSON context for old resource", err, rule.ReportProperties)
 		}
 		if val, msg, err := variables.EvaluateConditions(logger, policyContext.JSONContext(), copyConditions); err != nil {
 			return engineapi.RuleError(rule.Name, ruleType, "failed to evaluate conditions for old resource", err, rule.ReportProperties)
-		} else {
-			if val {
+		} else if val {
+			if val, _, err := internal.CheckCELPreconditions(context.TODO(), e.client, policyContext, oldResource, policyContext.Policy(), rule.Name, rule.CELPreconditions); err != nil {
+				return engineapi.RuleError(rule.Name, ruleType, "failed to evaluate CEL preconditions for old resource", err, rule.ReportProperties)
+			} else if val {
 				return engineapi.RuleFail(rule.Name, ruleType, msg, rule.ReportProperties)
 			}
 		}
@@ -142,3 +177,43 @@ func (e *engine) filterRule(
 	logger.V(4).Info("skip rule as preconditions are not met", "rule", rule.Name, "message", msg)
 	return engineapi.RuleSkip(rule.Name, ruleType, "", rule.ReportProperties)
 }
+
+// exportRuleOutputs evaluates the outputs of a rule matching the resource and adds them to the context
+func (e *engine) exportRuleOutputs(
+	rule kyvernov1.Rule,
+	logger logr.Logger,
+	policyContext engineapi.PolicyContext,
+) {
+	if len(rule.Outputs) == 0 {
+		return
+	}
+	policy := policyContext.Policy()
+	gvk, subresource := policyContext.ResourceKind()
+	if err := engineutils.MatchesResourceDescription(policyContext.NewResource(), rule, policyContext.AdmissionInfo(), policyContext.NamespaceLabels(), policy.GetNamespace(), gvk, subresource, policyContext.Operation()); err != nil {
+		return
+	}
+	enginecontext.SetLimits(policyContext.JSONContext(), enginecontext.Limits{
+		MaxForeachIterations: e.configuration.GetMaxForeachIterations(),
+		MaxContextSize:       e.configuration.GetMaxContextSize(),
+	})
+	var outputs map[string]interface{}
+	policyContext.JSONContext().Checkpoint()
+	defer func() {
+		policyContext.JSONContext().Restore()
+		if err := internal.AddRuleOutputs(policyContext.JSONContext(), outputs); err != nil {
+			logger.Error(err, "failed to add rule outputs in the json context")
+		}
+	}()
+	contextEntries := append(append([]kyvernov1.ContextEntry{}, policy.GetSpec().Variables...), rule.Context...)
+	contextLoader := e.ContextLoader(policy, rule)
+	if err := contextLoader(context.TODO(), contextEntries, policyContext.JSONContext()); err != nil {
+		logger.V(4).Info("cannot add external data to the context", "reason", err.Error())
+		return
+	}
+	values, err := internal.EvaluateRuleOutputs(logger, rule, policyContext.JSONContext())
+	if err != nil {
+		logger.V(4).Info("failed to evaluate outputs", "reason", err.Error())
+		return
+	}
+	outputs = values
+}
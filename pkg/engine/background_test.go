@@ -0,0 +1,92 @@
+package engine
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/factories"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"gotest.tools/assert"
+)
+
+func newBackgroundTestEngine() *engine {
+	return NewEngine(
+		cfg,
+		config.NewDefaultMetricsConfiguration(),
+		jp,
+		nil,
+		nil,
+		imageverifycache.DisabledImageVerifyCache(),
+		factories.DefaultContextLoaderFactory(nil),
+		nil,
+		nil,
+		nil,
+		nil,
+	).(*engine)
+}
+
+func Test_filterRuleCELPreconditions(t *testing.T) {
+	var policy kyvernov1.ClusterPolicy
+	rawPolicy := []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"generate-quota"},"spec":{"rules":[{"name":"quota","match":{"any":[{"resources":{"kinds":["Namespace"]}}]},"celPreconditions":[{"name":"team","expression":"object.metadata.labels['team'] == 'payments'"}],"generate":{"apiVersion":"v1","kind":"ResourceQuota","name":"quota","namespace":"{{request.object.metadata.name}}","data":{"spec":{"hard":{"pods":"10"}}}}}]}}`)
+	assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+	e := newBackgroundTestEngine()
+	tests := []struct {
+		name   string
+		team   string
+		status engineapi.RuleStatus
+	}{{
+		name:   "precondition met",
+		team:   "payments",
+		status: engineapi.RuleStatusPass,
+	}, {
+		name:   "precondition not met",
+		team:   "platform",
+		status: engineapi.RuleStatusSkip,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, err := kubeutils.BytesToUnstructured([]byte(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"test","labels":{"team":"` + tt.team + `"}}}`))
+			assert.NilError(t, err)
+			policyContext := newPolicyContext(t, *resource, kyvernov1.Create, nil).WithPolicy(&policy)
+			response := e.filterRule(policy.Spec.Rules[0], logr.Discard(), policyContext)
+			assert.Assert(t, response != nil)
+			assert.Equal(t, response.Status(), tt.status)
+		})
+	}
+}
+
+func Test_filterRulesOutputs(t *testing.T) {
+	var policy kyvernov1.ClusterPolicy
+	rawPolicy := []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"generate-quota"},"spec":{"rules":[{"name":"team","match":{"any":[{"resources":{"kinds":["Namespace"]}}]},"context":[{"name":"team","variable":{"jmesPath":"request.object.metadata.labels.team"}}],"outputs":[{"name":"team","jmesPath":"team"}],"validate":{"pattern":{"metadata":{"name":"?*"}}}},{"name":"quota","match":{"any":[{"resources":{"kinds":["Namespace"]}}]},"preconditions":{"all":[{"key":"{{outputs.team}}","operator":"Equals","value":"payments"}]},"generate":{"apiVersion":"v1","kind":"ResourceQuota","name":"quota","namespace":"{{request.object.metadata.name}}","data":{"spec":{"hard":{"pods":"10"}}}}}]}}`)
+	assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+	e := newBackgroundTestEngine()
+	tests := []struct {
+		name   string
+		team   string
+		status engineapi.RuleStatus
+	}{{
+		name:   "output matches",
+		team:   "payments",
+		status: engineapi.RuleStatusPass,
+	}, {
+		name:   "output doesn't match",
+		team:   "platform",
+		status: engineapi.RuleStatusSkip,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, err := kubeutils.BytesToUnstructured([]byte(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"test","labels":{"team":"` + tt.team + `"}}}`))
+			assert.NilError(t, err)
+			policyContext := newPolicyContext(t, *resource, kyvernov1.Create, nil).WithPolicy(&policy)
+			response := e.filterRules(policyContext, logr.Discard())
+			assert.Equal(t, len(response.Rules), 1)
+			assert.Equal(t, response.Rules[0].Name(), "quota")
+			assert.Equal(t, response.Rules[0].Status(), tt.status)
+		})
+	}
+}
@@ -110,8 +110,23 @@ type Interface interface {
 	// Reset sets the internal state to the last checkpoint, but does not remove the checkpoint.
 	Reset()
 
+	// Fork returns an independent copy of the current state that can be used concurrently with this context.
+	// Pending deferred loaders are executed first, as they store their data in this context.
+	Fork() (Interface, error)
+
 	// AddJSON  merges the json map with context
 	addJSON(dataMap map[string]interface{}, overwriteMaps bool) error
+
+	// limits returns the limits configured on the context
+	limits() *Limits
+}
+
+// Limits bounds the resources consumed when evaluating policies with a context, zero values mean unlimited.
+type Limits struct {
+	// MaxForeachIterations is the maximum number of elements a foreach declaration can iterate over.
+	MaxForeachIterations int64
+	// MaxContextSize is the maximum size in bytes of the context entries added to the context.
+	MaxContextSize int64
 }
 
 // Context stores the data resources as JSON
@@ -122,6 +137,25 @@ type context struct {
 	images             map[string]map[string]apiutils.ImageInfo
 	operation          kyvernov1.AdmissionOperation
 	deferred           DeferredLoaders
+	limit              Limits
+	// size is the total size in bytes of the context entries added to the context
+	size entriesSize
+	// sizeCheckpoints stores the size of the context along with the JSON checkpoints
+	sizeCheckpoints []entriesSize
+}
+
+// entriesSize tracks the size in bytes of the context entries, by name so that replaced entries are not counted twice
+type entriesSize struct {
+	total   int64
+	entries map[string]int64
+}
+
+func (s entriesSize) copy() entriesSize {
+	entries := make(map[string]int64, len(s.entries))
+	for name, size := range s.entries {
+		entries[name] = size
+	}
+	return entriesSize{total: s.total, entries: entries}
 }
 
 // NewContext returns a new context
@@ -186,7 +220,29 @@ func (ctx *context) AddVariable(key string, value interface{}) error {
 	}
 }
 
+func (ctx *context) limits() *Limits {
+	return &ctx.limit
+}
+
+// reserve accounts for the size of a context entry, the size of an entry with the same name is released first.
+// It fails if the size of the context exceeds the limit.
+func (ctx *context) reserve(name string, dataRaw []byte) error {
+	size := ctx.size.total - ctx.size.entries[name] + int64(len(dataRaw))
+	if max := ctx.limit.MaxContextSize; max > 0 && size > max {
+		return fmt.Errorf("failed to add context entry %s: context size %d exceeds the limit of %d bytes", name, size, max)
+	}
+	if ctx.size.entries == nil {
+		ctx.size.entries = map[string]int64{}
+	}
+	ctx.size.total = size
+	ctx.size.entries[name] = int64(len(dataRaw))
+	return nil
+}
+
 func (ctx *context) AddContextEntry(name string, dataRaw []byte) error {
+	if err := ctx.reserve(name, dataRaw); err != nil {
+		return err
+	}
 	var data interface{}
 	if err := json.Unmarshal(dataRaw, &data); err != nil {
 		logger.Error(err, "failed to unmarshal the resource")
@@ -196,6 +252,9 @@ func (ctx *context) AddContextEntry(name string, dataRaw []byte) error {
 }
 
 func (ctx *context) ReplaceContextEntry(name string, dataRaw []byte) error {
+	if err := ctx.reserve(name, dataRaw); err != nil {
+		return err
+	}
 	var data interface{}
 	if err := json.Unmarshal(dataRaw, &data); err != nil {
 		logger.Error(err, "failed to unmarshal the resource")
@@ -411,6 +470,7 @@ func (ctx *context) ImageInfo() map[string]map[string]apiutils.ImageInfo {
 func (ctx *context) Checkpoint() {
 	jsonRawCheckpoint := ctx.copyContext(ctx.jsonRaw)
 	ctx.jsonRawCheckpoints = append(ctx.jsonRawCheckpoints, jsonRawCheckpoint)
+	ctx.sizeCheckpoints = append(ctx.sizeCheckpoints, ctx.size.copy())
 }
 
 func (ctx *context) copyContext(in map[string]interface{}) map[string]interface{} {
@@ -449,17 +509,43 @@ func (ctx *context) resetCheckpoint(restore bool) bool {
 
 	n := len(ctx.jsonRawCheckpoints) - 1
 	jsonRawCheckpoint := ctx.jsonRawCheckpoints[n]
+	sizeCheckpoint := ctx.sizeCheckpoints[n]
 
 	if restore {
 		ctx.jsonRawCheckpoints = ctx.jsonRawCheckpoints[:n]
+		ctx.sizeCheckpoints = ctx.sizeCheckpoints[:n]
 		ctx.jsonRaw = jsonRawCheckpoint
+		ctx.size = sizeCheckpoint
 	} else {
 		ctx.jsonRaw = ctx.copyContext(jsonRawCheckpoint)
+		ctx.size = sizeCheckpoint.copy()
 	}
 
 	return true
 }
 
+func (ctx *context) Fork() (Interface, error) {
+	forked := &context{
+		jp:                 ctx.jp,
+		jsonRawCheckpoints: make([]map[string]interface{}, 0),
+		images:             ctx.images,
+		operation:          ctx.operation,
+		limit:              ctx.limit,
+	}
+	// loaders are copied without being executed, the ones that can't be copied load their data before it is copied
+	deferred, err := ctx.deferred.Fork(forked)
+	if err != nil {
+		return nil, err
+	}
+	forked.deferred = deferred
+	forked.jsonRaw = make(map[string]interface{}, len(ctx.jsonRaw))
+	for k, v := range ctx.jsonRaw {
+		forked.jsonRaw[k] = runtime.DeepCopyJSONValue(v)
+	}
+	forked.size = ctx.size.copy()
+	return forked, nil
+}
+
 func (ctx *context) AddDeferredLoader(dl DeferredLoader) error {
 	ctx.deferred.Add(dl, len(ctx.jsonRawCheckpoints))
 	return nil
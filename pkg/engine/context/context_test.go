@@ -284,3 +284,39 @@ func Test_ImageInfoLoader_OnDirectCall(t *testing.T) {
 	imageinfos := newctx.ImageInfo()
 	assert.Equal(t, imageinfos["containers"]["test_container"].Name, "nginx")
 }
+
+func Test_MaxContextSize(t *testing.T) {
+	ctx := NewContext(jp)
+	SetLimits(ctx, Limits{MaxContextSize: 20})
+
+	assert.NoError(t, ctx.AddContextEntry("first", []byte(`{"a": "0123456"}`)))
+	err := ctx.ReplaceContextEntry("second", []byte(`{"b": 1}`))
+	assert.EqualError(t, err, "failed to add context entry second: context size 24 exceeds the limit of 20 bytes")
+
+	fork, err := ctx.Fork()
+	assert.NoError(t, err)
+	assert.Equal(t, Limits{MaxContextSize: 20}, GetLimits(fork))
+	assert.NoError(t, fork.AddContextEntry("third", []byte(`1`)))
+	assert.Error(t, fork.AddContextEntry("fourth", []byte(`{"c": "0123"}`)))
+}
+
+func Test_MaxContextSizeCheckpoint(t *testing.T) {
+	ctx := NewContext(jp)
+	SetLimits(ctx, Limits{MaxContextSize: 20})
+
+	assert.NoError(t, ctx.AddContextEntry("first", []byte(`{"a": "0123456"}`)))
+	// replacing an entry releases the size of the previous value
+	assert.NoError(t, ctx.ReplaceContextEntry("first", []byte(`{"a": "0123456789"}`)))
+	assert.NoError(t, ctx.ReplaceContextEntry("first", []byte(`{"a": 1}`)))
+
+	ctx.Checkpoint()
+	assert.NoError(t, ctx.AddContextEntry("second", []byte(`{"b": "01"}`)))
+	assert.Error(t, ctx.AddContextEntry("third", []byte(`{"c": 1}`)))
+	// reset rolls the size back to the checkpoint
+	ctx.Reset()
+	assert.NoError(t, ctx.AddContextEntry("third", []byte(`{"c": 1}`)))
+	// restore rolls the size back and removes the checkpoint
+	ctx.Restore()
+	assert.NoError(t, ctx.AddContextEntry("fourth", []byte(`{"d": "0"}`)))
+	assert.Error(t, ctx.AddContextEntry("fifth", []byte(`123`)))
+}
@@ -152,6 +152,44 @@ func (d *deferredLoaders) LoadMatching(query string, level int) error {
 	return nil
 }
 
+func (d *deferredLoaders) LoadAll() error {
+	for i, l := range d.loaders {
+		if l.loader.HasLoaded() {
+			continue
+		}
+		if err := d.loadData(l, i); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *deferredLoaders) Fork(ctx Interface) (DeferredLoaders, error) {
+	forked := NewDeferredLoaders()
+	for i, l := range d.loaders {
+		if l.loader.HasLoaded() {
+			continue
+		}
+		if dl, ok := l.loader.(*deferredLoader); ok {
+			if loader, ok := dl.loader.(ForkableLoader); ok {
+				// the forked context has no checkpoint, loaders are declared at its base level
+				forked.Add(&deferredLoader{
+					name:    dl.name,
+					matcher: dl.matcher,
+					loader:  loader.Fork(ctx),
+					logger:  dl.logger,
+				}, 0)
+				continue
+			}
+		}
+		if err := d.loadData(l, i); err != nil {
+			return nil, err
+		}
+	}
+	return forked, nil
+}
+
 func (d *deferredLoaders) loadData(l *leveledLoader, index int) error {
 	d.setLevelAndIndex(l.level, index)
 	defer d.setLevelAndIndex(-1, -1)
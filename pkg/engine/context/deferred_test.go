@@ -412,3 +412,57 @@ func TestDeferredLoadMatchingError(t *testing.T) {
 	err := ctx.deferred.LoadMatching("value", len(ctx.jsonRawCheckpoints))
 	assert.ErrorContains(t, err, `failed to load data`)
 }
+
+func TestDeferredFork(t *testing.T) {
+	ctx := newContext()
+	mockLoader, _ := AddMockDeferredLoader(ctx, "one", "1")
+	assert.Equal(t, 0, mockLoader.invocations)
+
+	fork, err := ctx.Fork()
+	assert.NilError(t, err)
+	assert.Equal(t, 0, mockLoader.invocations)
+
+	// the copied loader loads into the fork only
+	val, err := fork.Query("one")
+	assert.NilError(t, err)
+	assert.Equal(t, "1", val)
+	assert.Equal(t, 0, mockLoader.invocations)
+	assert.Equal(t, false, mockLoader.HasLoaded())
+
+	val, err = ctx.Query("one")
+	assert.NilError(t, err)
+	assert.Equal(t, "1", val)
+	assert.Equal(t, 1, mockLoader.invocations)
+
+	// updates to the fork are not visible in the parent
+	assert.NilError(t, fork.AddContextEntry("two", []byte(`"2"`)))
+	val, err = ctx.Query("two")
+	assert.NilError(t, err)
+	assert.Equal(t, nil, val)
+	val, err = fork.Query("two")
+	assert.NilError(t, err)
+	assert.Equal(t, "2", val)
+}
+
+// unforkableLoader only exposes the Loader methods of the wrapped loader
+type unforkableLoader struct {
+	Loader
+}
+
+func TestDeferredForkUnforkable(t *testing.T) {
+	ctx := newContext()
+	loader := &mockLoader{name: "one", value: "1", ctx: ctx}
+	d, err := NewDeferredLoader("one", &unforkableLoader{loader}, logger)
+	assert.NilError(t, err)
+	assert.NilError(t, ctx.AddDeferredLoader(d))
+
+	// loaders that can't be copied load their data before the fork
+	fork, err := ctx.Fork()
+	assert.NilError(t, err)
+	assert.Equal(t, 1, loader.invocations)
+
+	val, err := fork.Query("one")
+	assert.NilError(t, err)
+	assert.Equal(t, "1", val)
+	assert.Equal(t, 1, loader.invocations)
+}
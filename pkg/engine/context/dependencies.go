@@ -0,0 +1,167 @@
+package context
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/engine/variables/regex"
+)
+
+// ContextDependency is a context entry with the entries it depends on
+type ContextDependency struct {
+	// Index is the position of the entry in the declared entries
+	Index int
+	// Name is the context entry name
+	Name string
+	// DependsOn contains the names of the entries referenced by this entry
+	DependsOn []string
+}
+
+func (d ContextDependency) String() string {
+	if len(d.DependsOn) == 0 {
+		return d.Name
+	}
+	return fmt.Sprintf("%s(%s)", d.Name, strings.Join(d.DependsOn, ","))
+}
+
+// entryReferences returns the expressions of a context entry that are evaluated against the context
+func entryReferences(entry kyvernov1.ContextEntry) (string, error) {
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return "", err
+	}
+	var refs []string
+	for _, match := range regex.RegexVariables.FindAllStringSubmatch(string(raw), -1) {
+		refs = append(refs, match[2])
+	}
+	// the jmespath of a variable is evaluated against the context, other entries apply it to the fetched data
+	if entry.Variable != nil && entry.Variable.JMESPath != "" {
+		refs = append(refs, entry.Variable.JMESPath)
+	}
+	return strings.Join(refs, "\n"), nil
+}
+
+// ResolveLoadOrder builds the dependency graph of context entries and returns the entries in load order.
+// An entry depends on another one when one of its expressions references the other entry name, using
+// the same matching rules as the deferred loaders. Entries are loaded after the entries they depend on
+// and in declaration order otherwise, an error is returned for circular dependencies.
+func ResolveLoadOrder(entries []kyvernov1.ContextEntry) ([]ContextDependency, error) {
+	matchers := make([]*regexp.Regexp, len(entries))
+	for i, entry := range entries {
+		matcher, err := regexp.Compile(`(?:\A|\z|\s|[^.0-9A-Za-z])` + regexp.QuoteMeta(entry.Name) + `\b`)
+		if err != nil {
+			return nil, err
+		}
+		matchers[i] = matcher
+	}
+	// edges[i] contains the indexes of the entries i depends on
+	edges := make([][]int, len(entries))
+	for i, entry := range entries {
+		refs, err := entryReferences(entry)
+		if err != nil {
+			return nil, err
+		}
+		if refs == "" {
+			continue
+		}
+		seen := map[string]bool{}
+		// a name declared several times resolves to the closest prior declaration, or to the next one otherwise
+		for j := i - 1; j >= 0; j-- {
+			if !seen[entries[j].Name] && matchers[j].MatchString(refs) {
+				edges[i] = append(edges[i], j)
+			}
+			seen[entries[j].Name] = true
+		}
+		for j := i + 1; j < len(entries); j++ {
+			if !seen[entries[j].Name] && matchers[j].MatchString(refs) {
+				edges[i] = append(edges[i], j)
+			}
+			seen[entries[j].Name] = true
+		}
+	}
+	if cycle := findCycle(edges); cycle != nil {
+		var names []string
+		for _, i := range cycle {
+			names = append(names, entries[i].Name)
+		}
+		return nil, fmt.Errorf("circular dependency between context entries: %s", strings.Join(names, " -> "))
+	}
+	var order []ContextDependency
+	for _, i := range sortTopologically(edges) {
+		dependency := ContextDependency{Index: i, Name: entries[i].Name}
+		for _, j := range edges[i] {
+			dependency.DependsOn = append(dependency.DependsOn, entries[j].Name)
+		}
+		order = append(order, dependency)
+	}
+	return order, nil
+}
+
+// sortTopologically returns the nodes of an acyclic graph with every node after the nodes it depends on,
+// nodes are kept in index order otherwise
+func sortTopologically(edges [][]int) []int {
+	visited := make([]bool, len(edges))
+	order := make([]int, 0, len(edges))
+	var visit func(int)
+	visit = func(i int) {
+		visited[i] = true
+		dependencies := append([]int{}, edges[i]...)
+		sort.Ints(dependencies)
+		for _, j := range dependencies {
+			if !visited[j] {
+				visit(j)
+			}
+		}
+		order = append(order, i)
+	}
+	for i := range edges {
+		if !visited[i] {
+			visit(i)
+		}
+	}
+	return order
+}
+
+// findCycle returns the nodes of a cycle in the graph, the first node is repeated at the end
+func findCycle(edges [][]int) []int {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(edges))
+	var path []int
+	var visit func(int) []int
+	visit = func(i int) []int {
+		state[i] = visiting
+		path = append(path, i)
+		for _, j := range edges[i] {
+			switch state[j] {
+			case visiting:
+				for k, n := range path {
+					if n == j {
+						return append(append([]int{}, path[k:]...), j)
+					}
+				}
+			case unvisited:
+				if cycle := visit(j); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = visited
+		return nil
+	}
+	for i := range edges {
+		if state[i] == unvisited {
+			if cycle := visit(i); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package context
+
+import (
+	"testing"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func variableEntry(name string, value string, jmesPath string) kyvernov1.ContextEntry {
+	entry := kyvernov1.ContextEntry{
+		Name:     name,
+		Variable: &kyvernov1.Variable{JMESPath: jmesPath},
+	}
+	if value != "" {
+		entry.Variable.Value = kyverno.ToAny(value)
+	}
+	return entry
+}
+
+func TestResolveLoadOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []kyvernov1.ContextEntry
+		want    []string
+		wantErr string
+	}{{
+		name: "independent entries",
+		entries: []kyvernov1.ContextEntry{
+			variableEntry("a", "{{ request.object.metadata.name }}", ""),
+			variableEntry("b", "", "request.namespace"),
+		},
+		want: []string{"a", "b"},
+	}, {
+		name: "dependencies",
+		entries: []kyvernov1.ContextEntry{
+			variableEntry("a", "foo", ""),
+			variableEntry("b", "{{ a }}-bar", ""),
+			variableEntry("c", "", "join('-', [a, b])"),
+		},
+		want: []string{"a", "b(a)", "c(b,a)"},
+	}, {
+		name: "field with the same name",
+		entries: []kyvernov1.ContextEntry{
+			variableEntry("name", "", "request.object.metadata.name"),
+		},
+		want: []string{"name"},
+	}, {
+		name: "redeclared entry",
+		entries: []kyvernov1.ContextEntry{
+			variableEntry("a", "foo", ""),
+			variableEntry("a", "{{ a }}-bar", ""),
+		},
+		want: []string{"a", "a(a)"},
+	}, {
+		name: "self reference",
+		entries: []kyvernov1.ContextEntry{
+			variableEntry("a", "", "a || 'default'"),
+		},
+		want: []string{"a"},
+	}, {
+		name: "cycle",
+		entries: []kyvernov1.ContextEntry{
+			variableEntry("a", "{{ b }}", ""),
+			variableEntry("b", "{{ a }}", ""),
+		},
+		wantErr: "circular dependency between context entries: a -> b -> a",
+	}, {
+		name: "forward reference",
+		entries: []kyvernov1.ContextEntry{
+			variableEntry("a", "{{ b }}", ""),
+			variableEntry("b", "foo", ""),
+			variableEntry("c", "bar", ""),
+		},
+		want: []string{"b", "a(b)", "c"},
+	}, {
+		name: "forward cycle",
+		entries: []kyvernov1.ContextEntry{
+			variableEntry("a", "{{ c }}", ""),
+			variableEntry("b", "foo", ""),
+			variableEntry("c", "{{ a }}", ""),
+		},
+		wantErr: "circular dependency between context entries: a -> c -> a",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, err := ResolveLoadOrder(tt.entries)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			var got []string
+			for _, dependency := range order {
+				got = append(got, dependency.String())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -15,6 +15,13 @@ type Loader interface {
 	HasLoaded() bool
 }
 
+// ForkableLoader is a Loader that can be copied to a forked context without being executed.
+type ForkableLoader interface {
+	Loader
+	// Fork returns a copy of the loader that stores data in the given context
+	Fork(Interface) Loader
+}
+
 // DeferredLoader wraps a Loader and implements context specific behaviors.
 // A `level` is used to track the checkpoint level at which the loader was
 // created. If the level when loading occurs matches the loader's creation
@@ -39,5 +46,9 @@ type LeveledLoader interface {
 type DeferredLoaders interface {
 	Add(loader DeferredLoader, level int)
 	LoadMatching(query string, level int) error
+	LoadAll() error
 	Reset(removeCheckpoint bool, level int)
+	// Fork returns the loaders that have not been executed, bound to the given context. Loaders that
+	// can't be bound to another context are executed first.
+	Fork(Interface) (DeferredLoaders, error)
 }
@@ -20,6 +20,7 @@ type apiLoader struct {
 	jp        jmespath.Interface
 	client    engineapi.RawClient
 	config    apicall.APICallConfiguration
+	policy    kyvernov1.PolicyInterface
 	data      []byte
 }
 
@@ -31,6 +32,7 @@ func NewAPILoader(
 	jp jmespath.Interface,
 	client engineapi.RawClient,
 	apiCallConfig apicall.APICallConfiguration,
+	policy kyvernov1.PolicyInterface,
 ) enginecontext.Loader {
 	return &apiLoader{
 		ctx:       ctx,
@@ -40,15 +42,22 @@ func NewAPILoader(
 		jp:        jp,
 		client:    client,
 		config:    apiCallConfig,
+		policy:    policy,
 	}
 }
 
+func (a *apiLoader) Fork(enginectx enginecontext.Interface) enginecontext.Loader {
+	forked := *a
+	forked.enginectx = enginectx
+	return &forked
+}
+
 func (a *apiLoader) HasLoaded() bool {
 	return a.data != nil
 }
 
 func (a *apiLoader) LoadData() error {
-	executor, err := apicall.New(a.logger, a.jp, a.entry, a.enginectx, a.client, a.config)
+	executor, err := apicall.New(a.logger, a.jp, a.entry, a.enginectx, a.client, a.config, a.policy)
 	if err != nil {
 		return fmt.Errorf("failed to initiaize APICal: %w", err)
 	}
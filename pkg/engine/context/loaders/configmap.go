@@ -37,6 +37,12 @@ func NewConfigMapLoader(
 	}
 }
 
+func (cml *configMapLoader) Fork(enginectx enginecontext.Interface) enginecontext.Loader {
+	forked := *cml
+	forked.enginectx = enginectx
+	return &forked
+}
+
 func (cml *configMapLoader) HasLoaded() bool {
 	return cml.data != nil
 }
@@ -0,0 +1,104 @@
+package loaders
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/externaldata"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+)
+
+type externalDataLoader struct {
+	ctx       context.Context //nolint:containedctx
+	logger    logr.Logger
+	entry     kyvernov1.ContextEntry
+	enginectx enginecontext.Interface
+	jp        jmespath.Interface
+	client    externaldata.Client
+	data      []byte
+}
+
+func NewExternalDataLoader(
+	ctx context.Context,
+	logger logr.Logger,
+	entry kyvernov1.ContextEntry,
+	enginectx enginecontext.Interface,
+	jp jmespath.Interface,
+	client externaldata.Client,
+) enginecontext.Loader {
+	return &externalDataLoader{
+		ctx:       ctx,
+		logger:    logger,
+		entry:     entry,
+		enginectx: enginectx,
+		jp:        jp,
+		client:    client,
+	}
+}
+
+func (e *externalDataLoader) Fork(enginectx enginecontext.Interface) enginecontext.Loader {
+	forked := *e
+	forked.enginectx = enginectx
+	return &forked
+}
+
+func (e *externalDataLoader) HasLoaded() bool {
+	return e.data != nil
+}
+
+func (e *externalDataLoader) LoadData() error {
+	if e.client == nil {
+		return fmt.Errorf("an external data client is required")
+	}
+	if e.data == nil {
+		data, err := e.fetch()
+		if err != nil {
+			return fmt.Errorf("failed to fetch external data for context entry %s: %w", e.entry.Name, err)
+		}
+		e.data = data
+	}
+	if err := e.enginectx.AddContextEntry(e.entry.Name, e.data); err != nil {
+		return fmt.Errorf("failed to add external data for context entry %s: %w", e.entry.Name, err)
+	}
+	e.logger.V(4).Info("added context data", "name", e.entry.Name, "len", len(e.data))
+	return nil
+}
+
+func (e *externalDataLoader) fetch() ([]byte, error) {
+	call, err := variables.SubstituteAllInType(e.logger, e.enginectx, e.entry.ExternalData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to substitute variables in context entry %s: %w", e.entry.Name, err)
+	}
+	items, err := e.client.Query(e.ctx, call.Provider, call.Keys)
+	if err != nil {
+		if !externaldata.IsIgnored(err) {
+			return nil, err
+		}
+		e.logger.V(2).Info("failed to query provider, ignoring", "name", e.entry.Name, "provider", call.Provider, "error", err)
+		if call.Default != nil {
+			return call.Default.Raw, nil
+		}
+		return []byte("null"), nil
+	}
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal items: %w", err)
+	}
+	if call.JMESPath == "" {
+		return data, nil
+	}
+	var response interface{}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal items: %w", err)
+	}
+	results, err := e.jp.Search(call.JMESPath, response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply JMESPath %s for context entry %s: %w", call.JMESPath, e.entry.Name, err)
+	}
+	return json.Marshal(results)
+}
@@ -7,8 +7,10 @@ import (
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"github.com/kyverno/kyverno/pkg/globalcontext/store"
 )
@@ -18,13 +20,15 @@ type Store interface {
 }
 
 type gctxLoader struct {
-	ctx       context.Context //nolint:containedctx
-	logger    logr.Logger
-	entry     kyvernov1.ContextEntry
-	enginectx enginecontext.Interface
-	jp        jmespath.Interface
-	gctxStore Store
-	data      []byte
+	ctx           context.Context //nolint:containedctx
+	logger        logr.Logger
+	entry         kyvernov1.ContextEntry
+	enginectx     enginecontext.Interface
+	jp            jmespath.Interface
+	gctxStore     Store
+	configuration config.Configuration
+	policy        kyvernov1.PolicyInterface
+	data          []byte
 }
 
 func NewGCTXLoader(
@@ -34,17 +38,27 @@ func NewGCTXLoader(
 	enginectx enginecontext.Interface,
 	jp jmespath.Interface,
 	gctxStore Store,
+	configuration config.Configuration,
+	policy kyvernov1.PolicyInterface,
 ) enginecontext.Loader {
 	return &gctxLoader{
-		ctx:       ctx,
-		logger:    logger,
-		entry:     entry,
-		enginectx: enginectx,
-		jp:        jp,
-		gctxStore: gctxStore,
+		ctx:           ctx,
+		logger:        logger,
+		entry:         entry,
+		enginectx:     enginectx,
+		jp:            jp,
+		gctxStore:     gctxStore,
+		configuration: configuration,
+		policy:        policy,
 	}
 }
 
+func (g *gctxLoader) Fork(enginectx enginecontext.Interface) enginecontext.Loader {
+	forked := *g
+	forked.enginectx = enginectx
+	return &forked
+}
+
 func (g *gctxLoader) HasLoaded() bool {
 	data, ok := g.gctxStore.Get(g.entry.Name)
 	if !ok {
@@ -88,6 +102,11 @@ func (g *gctxLoader) loadGctxData() ([]byte, error) {
 	}
 	g.logger.V(6).Info("variables substituted", "resourcecache", rc)
 
+	// the entry name can come from variables, access is checked once it is resolved
+	if err := engineutils.CheckGlobalContextAccess(g.configuration, g.policy, rc.Name); err != nil {
+		return nil, err
+	}
+
 	storeEntry, ok := g.gctxStore.Get(rc.Name)
 	if !ok {
 		err := fmt.Errorf("failed to fetch entry key=%s", rc.Name)
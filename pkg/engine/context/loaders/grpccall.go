@@ -0,0 +1,70 @@
+package loaders
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/grpccall"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+)
+
+type grpcLoader struct {
+	ctx       context.Context //nolint:containedctx
+	logger    logr.Logger
+	entry     kyvernov1.ContextEntry
+	enginectx enginecontext.Interface
+	jp        jmespath.Interface
+	client    grpccall.Client
+	policy    kyvernov1.PolicyInterface
+	data      []byte
+}
+
+func NewGRPCLoader(
+	ctx context.Context,
+	logger logr.Logger,
+	entry kyvernov1.ContextEntry,
+	enginectx enginecontext.Interface,
+	jp jmespath.Interface,
+	client grpccall.Client,
+	policy kyvernov1.PolicyInterface,
+) enginecontext.Loader {
+	return &grpcLoader{
+		ctx:       ctx,
+		logger:    logger,
+		entry:     entry,
+		enginectx: enginectx,
+		jp:        jp,
+		client:    client,
+		policy:    policy,
+	}
+}
+
+func (g *grpcLoader) Fork(enginectx enginecontext.Interface) enginecontext.Loader {
+	forked := *g
+	forked.enginectx = enginectx
+	return &forked
+}
+
+func (g *grpcLoader) HasLoaded() bool {
+	return g.data != nil
+}
+
+func (g *grpcLoader) LoadData() error {
+	executor, err := grpccall.New(g.logger, g.jp, g.entry, g.enginectx, g.client, g.policy)
+	if err != nil {
+		return fmt.Errorf("failed to initialize GRPCCall: %w", err)
+	}
+	if g.data == nil {
+		var err error
+		if g.data, err = executor.Fetch(g.ctx); err != nil {
+			return fmt.Errorf("failed to fetch data for GRPCCall: %w", err)
+		}
+	}
+	if _, err := executor.Store(g.data); err != nil {
+		return fmt.Errorf("failed to store data for GRPCCall: %w", err)
+	}
+	return nil
+}
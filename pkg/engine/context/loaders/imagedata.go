@@ -45,6 +45,12 @@ func (idl *imageDataLoader) LoadData() error {
 	return idl.loadImageData()
 }
 
+func (cml *imageDataLoader) Fork(enginectx enginecontext.Interface) enginecontext.Loader {
+	forked := *cml
+	forked.enginectx = enginectx
+	return &forked
+}
+
 func (cml *imageDataLoader) HasLoaded() bool {
 	return cml.data != nil
 }
@@ -91,7 +97,7 @@ func (idl *imageDataLoader) fetchImageData() (interface{}, error) {
 		return nil, fmt.Errorf("failed to get registry client %s: %v", entry.Name, err)
 	}
 
-	imageData, err := idl.fetchImageDataMap(client, refString)
+	imageData, err := idl.fetchImageDataMap(client, refString, entry.ImageRegistry.Referrers)
 	if err != nil {
 		return nil, err
 	}
@@ -107,7 +113,7 @@ func (idl *imageDataLoader) fetchImageData() (interface{}, error) {
 }
 
 // FetchImageDataMap fetches image information from the remote registry.
-func (idl *imageDataLoader) fetchImageDataMap(client engineapi.ImageDataClient, ref string) (interface{}, error) {
+func (idl *imageDataLoader) fetchImageDataMap(client engineapi.ImageDataClient, ref string, fetchReferrers bool) (interface{}, error) {
 	desc, err := client.ForRef(context.Background(), ref)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch image descriptor: %s, error: %v", ref, err)
@@ -139,6 +145,20 @@ func (idl *imageDataLoader) fetchImageDataMap(client engineapi.ImageDataClient,
 		"manifestList":  manifestList,
 		"manifest":      manifest,
 		"configData":    configData,
+		"labels":        imageLabels(desc.Config),
+		"layers":        layerDigests(desc.Manifest),
+	}
+
+	if fetchReferrers {
+		rawReferrers, err := client.FetchReferrers(context.Background(), desc.ResolvedImage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch referrers for image reference: %s, error: %v", ref, err)
+		}
+		referrers, err := referrerDescriptors(rawReferrers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode referrers for image reference: %s, error: %v", ref, err)
+		}
+		data["referrers"] = referrers
 	}
 
 	// we need to do the conversion from struct types to an interface type so that jmespath
@@ -158,3 +178,54 @@ func (idl *imageDataLoader) fetchImageDataMap(client engineapi.ImageDataClient,
 
 	return untyped, nil
 }
+
+// imageLabels returns the labels set in the image config.
+func imageLabels(rawConfig []byte) map[string]string {
+	var config struct {
+		Config struct {
+			Labels map[string]string `json:"Labels"`
+		} `json:"config"`
+	}
+	if err := json.Unmarshal(rawConfig, &config); err != nil {
+		return nil
+	}
+	return config.Config.Labels
+}
+
+// layerDigests returns the digests of the image layers, base image layers come first.
+func layerDigests(rawManifest []byte) []string {
+	var manifest struct {
+		Layers []struct {
+			Digest string `json:"digest"`
+		} `json:"layers"`
+	}
+	if err := json.Unmarshal(rawManifest, &manifest); err != nil {
+		return nil
+	}
+	digests := make([]string, 0, len(manifest.Layers))
+	for _, layer := range manifest.Layers {
+		digests = append(digests, layer.Digest)
+	}
+	return digests
+}
+
+type referrer struct {
+	Digest       string            `json:"digest"`
+	MediaType    string            `json:"mediaType"`
+	ArtifactType string            `json:"artifactType,omitempty"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+}
+
+// referrerDescriptors returns the descriptors listed in a referrers index.
+func referrerDescriptors(rawIndex []byte) ([]referrer, error) {
+	var index struct {
+		Manifests []referrer `json:"manifests"`
+	}
+	if err := json.Unmarshal(rawIndex, &index); err != nil {
+		return nil, err
+	}
+	if index.Manifests == nil {
+		return []referrer{}, nil
+	}
+	return index.Manifests, nil
+}
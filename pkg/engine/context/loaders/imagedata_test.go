@@ -0,0 +1,108 @@
+package loaders
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	gcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeImageDataClient struct {
+	data      *engineapi.ImageData
+	referrers []byte
+	err       error
+	fetched   []string
+}
+
+func (c *fakeImageDataClient) ForRef(context.Context, string) (*engineapi.ImageData, error) {
+	return c.data, nil
+}
+
+func (c *fakeImageDataClient) FetchReferrers(_ context.Context, ref string) ([]byte, error) {
+	c.fetched = append(c.fetched, ref)
+	return c.referrers, c.err
+}
+
+func (c *fakeImageDataClient) FetchImageDescriptor(context.Context, string) (*gcrremote.Descriptor, error) {
+	return nil, errors.New("not implemented")
+}
+
+const (
+	testManifest = `{"schemaVersion":2,"layers":[{"digest":"sha256:base"},{"digest":"sha256:app"}]}`
+	testConfig   = `{"config":{"Labels":{"org.opencontainers.image.source":"https://github.com/kyverno/kyverno"}}}`
+	testIndex    = `{"schemaVersion":2,"manifests":[{"digest":"sha256:sbom","mediaType":"application/vnd.oci.image.manifest.v1+json","artifactType":"application/spdx+json","annotations":{"created":"today"}}]}`
+)
+
+func newTestImageData() *engineapi.ImageData {
+	return &engineapi.ImageData{
+		Image:         "ghcr.io/kyverno/kyverno:latest",
+		ResolvedImage: "ghcr.io/kyverno/kyverno@sha256:image",
+		Registry:      "ghcr.io",
+		Repository:    "kyverno/kyverno",
+		Identifier:    "latest",
+		Manifest:      []byte(testManifest),
+		Config:        []byte(testConfig),
+	}
+}
+
+func Test_imageLabels(t *testing.T) {
+	assert.Equal(t, map[string]string{"org.opencontainers.image.source": "https://github.com/kyverno/kyverno"}, imageLabels([]byte(testConfig)))
+	assert.Nil(t, imageLabels([]byte(`{"config":{}}`)))
+	assert.Nil(t, imageLabels([]byte(`invalid`)))
+}
+
+func Test_layerDigests(t *testing.T) {
+	assert.Equal(t, []string{"sha256:base", "sha256:app"}, layerDigests([]byte(testManifest)))
+	assert.Equal(t, []string{}, layerDigests([]byte(`{"schemaVersion":2}`)))
+	assert.Nil(t, layerDigests([]byte(`invalid`)))
+}
+
+func Test_referrerDescriptors(t *testing.T) {
+	referrers, err := referrerDescriptors([]byte(testIndex))
+	assert.NoError(t, err)
+	assert.Equal(t, []referrer{{
+		Digest:       "sha256:sbom",
+		MediaType:    "application/vnd.oci.image.manifest.v1+json",
+		ArtifactType: "application/spdx+json",
+		Annotations:  map[string]string{"created": "today"},
+	}}, referrers)
+
+	referrers, err = referrerDescriptors([]byte(`{"schemaVersion":2}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []referrer{}, referrers)
+
+	_, err = referrerDescriptors([]byte(`invalid`))
+	assert.Error(t, err)
+}
+
+func Test_fetchImageDataMap(t *testing.T) {
+	loader := &imageDataLoader{}
+
+	client := &fakeImageDataClient{data: newTestImageData(), referrers: []byte(testIndex)}
+	data, err := loader.fetchImageDataMap(client, "ghcr.io/kyverno/kyverno:latest", false)
+	assert.NoError(t, err)
+	fields := data.(map[string]interface{})
+	assert.Equal(t, []interface{}{"sha256:base", "sha256:app"}, fields["layers"])
+	assert.Equal(t, map[string]interface{}{"org.opencontainers.image.source": "https://github.com/kyverno/kyverno"}, fields["labels"])
+	assert.NotContains(t, fields, "referrers")
+	assert.Empty(t, client.fetched)
+
+	data, err = loader.fetchImageDataMap(client, "ghcr.io/kyverno/kyverno:latest", true)
+	assert.NoError(t, err)
+	fields = data.(map[string]interface{})
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"digest":       "sha256:sbom",
+		"mediaType":    "application/vnd.oci.image.manifest.v1+json",
+		"artifactType": "application/spdx+json",
+		"annotations":  map[string]interface{}{"created": "today"},
+	}}, fields["referrers"])
+	// referrers are fetched for the resolved image digest
+	assert.Equal(t, []string{"ghcr.io/kyverno/kyverno@sha256:image"}, client.fetched)
+
+	client = &fakeImageDataClient{data: newTestImageData(), err: errors.New("referrers API not supported")}
+	_, err = loader.fetchImageDataMap(client, "ghcr.io/kyverno/kyverno:latest", true)
+	assert.ErrorContains(t, err, "failed to fetch referrers")
+}
@@ -0,0 +1,94 @@
+package loaders
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/secretstore"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+)
+
+type secretStoreLoader struct {
+	ctx       context.Context //nolint:containedctx
+	logger    logr.Logger
+	entry     kyvernov1.ContextEntry
+	enginectx enginecontext.Interface
+	jp        jmespath.Interface
+	client    secretstore.Client
+	policy    kyvernov1.PolicyInterface
+	data      []byte
+}
+
+func NewSecretStoreLoader(
+	ctx context.Context,
+	logger logr.Logger,
+	entry kyvernov1.ContextEntry,
+	enginectx enginecontext.Interface,
+	jp jmespath.Interface,
+	client secretstore.Client,
+	policy kyvernov1.PolicyInterface,
+) enginecontext.Loader {
+	return &secretStoreLoader{
+		ctx:       ctx,
+		logger:    logger,
+		entry:     entry,
+		enginectx: enginectx,
+		jp:        jp,
+		client:    client,
+		policy:    policy,
+	}
+}
+
+func (s *secretStoreLoader) Fork(enginectx enginecontext.Interface) enginecontext.Loader {
+	forked := *s
+	forked.enginectx = enginectx
+	return &forked
+}
+
+func (s *secretStoreLoader) HasLoaded() bool {
+	return s.data != nil
+}
+
+func (s *secretStoreLoader) LoadData() error {
+	if s.client == nil {
+		return fmt.Errorf("a secret store client is required")
+	}
+	if s.data == nil {
+		data, err := s.fetch()
+		if err != nil {
+			return fmt.Errorf("failed to retrieve secret for context entry %s: %w", s.entry.Name, err)
+		}
+		s.data = data
+	}
+	if err := s.enginectx.AddContextEntry(s.entry.Name, s.data); err != nil {
+		return fmt.Errorf("failed to add secret for context entry %s: %w", s.entry.Name, err)
+	}
+	// secret values must never be logged, only the entry name is
+	s.logger.V(6).Info("added context data", "name", s.entry.Name)
+	return nil
+}
+
+func (s *secretStoreLoader) fetch() ([]byte, error) {
+	store, err := variables.SubstituteAllInType(s.logger, s.enginectx, s.entry.SecretStore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to substitute variables in context entry %s: %w", s.entry.Name, err)
+	}
+	data, err := s.client.Fetch(s.ctx, s.policy, *store)
+	if err != nil {
+		return nil, err
+	}
+	if store.JMESPath == "" {
+		return json.Marshal(data)
+	}
+	results, err := s.jp.Search(store.JMESPath, data)
+	if err != nil {
+		// errors can embed fetched values, they must not leak in responses and logs
+		return nil, fmt.Errorf("failed to apply JMESPath %s for context entry %s: %s", store.JMESPath, s.entry.Name, secretstore.Redact(err.Error(), data))
+	}
+	return json.Marshal(results)
+}
@@ -34,6 +34,12 @@ func NewVariableLoader(
 	}
 }
 
+func (vl *variableLoader) Fork(enginectx enginecontext.Interface) enginecontext.Loader {
+	forked := *vl
+	forked.enginectx = enginectx
+	return &forked
+}
+
 func (vl *variableLoader) HasLoaded() bool {
 	return vl.data != nil
 }
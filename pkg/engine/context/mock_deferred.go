@@ -50,6 +50,12 @@ func (ml *mockLoader) LoadData() error {
 	return nil
 }
 
+func (ml *mockLoader) Fork(ctx Interface) Loader {
+	forked := *ml
+	forked.ctx = ctx
+	return &forked
+}
+
 func (ml *mockLoader) executeQuery() error {
 	if ml.query == "" {
 		return nil
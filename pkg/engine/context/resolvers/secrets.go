@@ -0,0 +1,44 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+)
+
+// secretGetTimeout is the maximum time spent reading a secret from the API server
+const secretGetTimeout = 5 * time.Second
+
+type secretResolver struct {
+	lister corev1listers.SecretNamespaceLister
+	client kubernetes.Interface
+}
+
+// NewSecretResolver returns a resolver serving the secrets of the Kyverno namespace from the lister, secrets from
+// other namespaces are read from the API server and require the service account to be granted get on them.
+// Callers are expected to restrict the namespaces secrets can be requested from.
+func NewSecretResolver(lister corev1listers.SecretNamespaceLister, client kubernetes.Interface) (engineapi.SecretResolver, error) {
+	if client == nil {
+		return nil, errors.New("client must not be nil")
+	}
+	return &secretResolver{
+		lister: lister,
+		client: client,
+	}, nil
+}
+
+func (r *secretResolver) Get(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+	if r.lister != nil && namespace == config.KyvernoNamespace() {
+		return r.lister.Get(name)
+	}
+	ctx, cancel := context.WithTimeout(ctx, secretGetTimeout)
+	defer cancel()
+	return r.client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+}
@@ -0,0 +1,47 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/config"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestSecretResolver(t *testing.T) {
+	kyvernoSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: config.KyvernoNamespace(), Name: "trust"},
+		Data:       map[string][]byte{"ca.crt": []byte("kyverno")},
+	}
+	client := kubefake.NewSimpleClientset(kyvernoSecret, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "trust"},
+		Data:       map[string][]byte{"ca.crt": []byte("pem")},
+	})
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NilError(t, indexer.Add(kyvernoSecret))
+	lister := corev1listers.NewSecretLister(indexer).Secrets(config.KyvernoNamespace())
+	resolver, err := NewSecretResolver(lister, client)
+	assert.NilError(t, err)
+	// secrets of the kyverno namespace are served from the lister, not from the api server
+	secret, err := resolver.Get(context.TODO(), config.KyvernoNamespace(), "trust")
+	assert.NilError(t, err)
+	assert.Equal(t, string(secret.Data["ca.crt"]), "kyverno")
+	assert.Equal(t, len(client.Actions()), 0)
+	// secrets of other namespaces are read from the api server
+	secret, err = resolver.Get(context.TODO(), "team-a", "trust")
+	assert.NilError(t, err)
+	assert.Equal(t, string(secret.Data["ca.crt"]), "pem")
+	assert.Equal(t, len(client.Actions()), 1)
+	_, err = resolver.Get(context.TODO(), "team-b", "trust")
+	assert.Error(t, err, "secrets \"trust\" not found")
+}
+
+func TestNewSecretResolver(t *testing.T) {
+	_, err := NewSecretResolver(nil, nil)
+	assert.Error(t, err, "client must not be nil")
+}
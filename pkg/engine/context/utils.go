@@ -11,6 +11,16 @@ func AddJSONObject(ctx Interface, data map[string]interface{}) error {
 	return ctx.addJSON(data, false)
 }
 
+// SetLimits sets the limits of the context, forks inherit the limits of the context they are created from
+func SetLimits(ctx Interface, limits Limits) {
+	*ctx.limits() = limits
+}
+
+// GetLimits returns the limits of the context
+func GetLimits(ctx Interface) Limits {
+	return *ctx.limits()
+}
+
 func AddResource(ctx Interface, dataRaw []byte) error {
 	var data map[string]interface{}
 	if err := json.Unmarshal(dataRaw, &data); err != nil {
@@ -2,7 +2,9 @@ package engine
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -23,6 +25,7 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/multierr"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -35,6 +38,9 @@ type engine struct {
 	ivCache              imageverifycache.Client
 	contextLoader        engineapi.ContextLoaderFactory
 	exceptionSelector    engineapi.PolicyExceptionSelector
+	resultCache          engineapi.ResultCache
+	wasmEvaluator        engineapi.WasmEvaluator
+	secretResolver       engineapi.SecretResolver
 	// metrics
 	resultCounter     metric.Int64Counter
 	durationHistogram metric.Float64Histogram
@@ -51,6 +57,9 @@ func NewEngine(
 	ivCache imageverifycache.Client,
 	contextLoader engineapi.ContextLoaderFactory,
 	exceptionSelector engineapi.PolicyExceptionSelector,
+	resultCache engineapi.ResultCache,
+	wasmEvaluator engineapi.WasmEvaluator,
+	secretResolver engineapi.SecretResolver,
 ) engineapi.Engine {
 	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
 	resultCounter, err := meter.Int64Counter(
@@ -76,6 +85,9 @@ func NewEngine(
 		ivCache:              ivCache,
 		contextLoader:        contextLoader,
 		exceptionSelector:    exceptionSelector,
+		resultCache:          resultCache,
+		wasmEvaluator:        wasmEvaluator,
+		secretResolver:       secretResolver,
 		resultCounter:        resultCounter,
 		durationHistogram:    durationHistogram,
 	}
@@ -89,7 +101,19 @@ func (e *engine) Validate(
 	response := engineapi.NewEngineResponseFromPolicyContext(policyContext)
 	logger := internal.LoggerWithPolicyContext(logging.WithName("engine.validate"), policyContext)
 	if internal.MatchPolicyContext(logger, e.client, policyContext, e.configuration) {
-		policyResponse := e.validate(ctx, logger, policyContext)
+		key, cacheable := e.resultCacheKeyFor(policyContext)
+		policyResponse, cached := engineapi.PolicyResponse{}, false
+		if cacheable {
+			policyResponse, cached = e.resultCache.Get(key)
+		}
+		if cached {
+			logger.V(4).Info("using cached policy response")
+		} else {
+			policyResponse = e.validate(ctx, logger, policyContext)
+			if cacheable {
+				e.resultCache.Set(key, policyResponse)
+			}
+		}
 		response = response.WithPolicyResponse(policyResponse)
 	}
 	response = response.WithStats(engineapi.NewExecutionStats(startTime, time.Now()))
@@ -183,6 +207,40 @@ func (e *engine) ContextLoader(
 	}
 }
 
+// setRuleSpanStatus records the rule responses status on the rule span
+func setRuleSpanStatus(span trace.Span, responses []engineapi.RuleResponse) {
+	if len(responses) == 0 {
+		return
+	}
+	var statuses []string
+	var errs []error
+	for i := range responses {
+		statuses = append(statuses, string(responses[i].Status()))
+		if responses[i].Status() == engineapi.RuleStatusError {
+			errs = append(errs, errors.New(responses[i].Message()))
+		}
+	}
+	span.SetAttributes(tracing.RuleStatusKey.StringSlice(statuses))
+	tracing.SetSpanStatus(span, multierr.Combine(errs...))
+}
+
+// policyVariablesLoader returns a function loading the policy variables in the json context.
+// The variables are loaded at most once, callers must invoke it after the policy level checkpoint
+// so that the variables are available to all the rules of the policy.
+func (e *engine) policyVariablesLoader(
+	ctx context.Context,
+	policyContext engineapi.PolicyContext,
+) func() error {
+	policy := policyContext.Policy()
+	return sync.OnceValue(func() error {
+		if policy == nil || len(policy.GetSpec().Variables) == 0 {
+			return nil
+		}
+		contextLoader := e.ContextLoader(policy, kyvernov1.Rule{})
+		return contextLoader(ctx, policy.GetSpec().Variables, policyContext.JSONContext())
+	})
+}
+
 // matches checks if either the new or old resource satisfies the filter conditions defined in the rule
 func (e *engine) matches(
 	rule kyvernov1.Rule,
@@ -236,12 +294,23 @@ func (e *engine) invokeRuleHandler(
 	resource unstructured.Unstructured,
 	rule kyvernov1.Rule,
 	ruleType engineapi.RuleType,
+	loadVariables func() error,
 ) (unstructured.Unstructured, []engineapi.RuleResponse) {
 	return tracing.ChildSpan2(
 		ctx,
 		"pkg/engine",
 		fmt.Sprintf("RULE %s", rule.Name),
 		func(ctx context.Context, span trace.Span) (patchedResource unstructured.Unstructured, results []engineapi.RuleResponse) {
+			span.SetAttributes(
+				tracing.PolicyKindKey.String(policyContext.Policy().GetKind()),
+				tracing.PolicyNameKey.String(policyContext.Policy().GetName()),
+				tracing.PolicyNamespaceKey.String(policyContext.Policy().GetNamespace()),
+				tracing.RuleNameKey.String(rule.Name),
+				tracing.RuleTypeKey.String(string(ruleType)),
+			)
+			defer func() {
+				setRuleSpanStatus(span, results)
+			}()
 			// check if resource and rule match
 			if err := e.matches(rule, policyContext, resource); err != nil {
 				logger.V(4).Info("rule not matched", "reason", err.Error())
@@ -252,6 +321,23 @@ func (e *engine) invokeRuleHandler(
 			} else if handler, err := handlerFactory(); err != nil {
 				return resource, handlers.WithError(rule, ruleType, "failed to instantiate handler", err)
 			} else if handler != nil {
+				var outputs map[string]interface{}
+				enginecontext.SetLimits(policyContext.JSONContext(), enginecontext.Limits{
+					MaxForeachIterations: e.configuration.GetMaxForeachIterations(),
+					MaxContextSize:       e.configuration.GetMaxContextSize(),
+				})
+				contextStart := time.Now()
+				// load policy variables before the rule checkpoint so that they are kept for the next rules
+				if loadVariables != nil {
+					if err := loadVariables(); err != nil {
+						if _, ok := err.(gojmespath.NotFoundError); ok {
+							logger.V(3).Info("failed to load policy variables", "reason", err.Error())
+						} else {
+							logger.Error(err, "failed to load policy variables")
+						}
+						return resource, handlers.WithError(rule, ruleType, "failed to load policy variables", err)
+					}
+				}
 				policyContext.JSONContext().Checkpoint()
 				defer func() {
 					policyContext.JSONContext().Restore()
@@ -260,6 +346,10 @@ func (e *engine) invokeRuleHandler(
 							logger.Error(err, "failed to add resource in the json context")
 						}
 					}
+					// outputs are added after restoring the context so that subsequent rules can use them
+					if err := internal.AddRuleOutputs(policyContext.JSONContext(), outputs); err != nil {
+						logger.Error(err, "failed to add rule outputs in the json context")
+					}
 				}()
 				// load rule context
 				contextLoader := e.ContextLoader(policyContext.Policy(), rule)
@@ -271,6 +361,17 @@ func (e *engine) invokeRuleHandler(
 					}
 					return resource, handlers.WithError(rule, ruleType, "failed to load context", err)
 				}
+				// evaluate rule outputs
+				if values, err := internal.EvaluateRuleOutputs(logger, rule, policyContext.JSONContext()); err != nil {
+					return resource, handlers.WithError(rule, ruleType, "failed to evaluate outputs", err)
+				} else {
+					outputs = values
+				}
+				evaluationStart := time.Now()
+				span.SetAttributes(tracing.RuleContextDurationKey.Float64(evaluationStart.Sub(contextStart).Seconds()))
+				defer func() {
+					span.SetAttributes(tracing.RuleEvaluationDurationKey.Float64(time.Since(evaluationStart).Seconds()))
+				}()
 				// check preconditions
 				preconditionsPassed, msg, err := internal.CheckPreconditions(logger, policyContext.JSONContext(), rule.GetAnyAllConditions())
 				if err != nil {
@@ -280,6 +381,17 @@ func (e *engine) invokeRuleHandler(
 					s := stringutils.JoinNonEmpty([]string{"preconditions not met", msg}, "; ")
 					return resource, handlers.WithSkip(rule, ruleType, s)
 				}
+				// check CEL preconditions, validate.cel subrules evaluate them together with the validation expressions
+				if !rule.HasValidateCEL() {
+					preconditionsPassed, msg, err := internal.CheckCELPreconditions(ctx, e.client, policyContext, resource, policyContext.Policy(), rule.Name, rule.CELPreconditions)
+					if err != nil {
+						return resource, handlers.WithError(rule, ruleType, "failed to evaluate CEL preconditions", err)
+					}
+					if !preconditionsPassed {
+						s := stringutils.JoinNonEmpty([]string{"preconditions not met", msg}, "; ")
+						return resource, handlers.WithSkip(rule, ruleType, s)
+					}
+				}
 				// substitute properties
 				if err := internal.SubstitutePropertiesInRule(logger, &rule, policyContext.JSONContext()); err != nil {
 					logger.Error(err, "failed to substitute variables in rule properties")
@@ -290,6 +402,7 @@ func (e *engine) invokeRuleHandler(
 					logger.Error(err, "failed to get exceptions")
 					return resource, nil
 				}
+				exceptions = e.filterCELExceptions(ctx, logger, policyContext, resource, exceptions)
 				// process handler
 				resource, ruleResponses := handler.Process(ctx, logger, policyContext, resource, rule, contextLoader, exceptions)
 				return resource, ruleResponses
@@ -1,8 +1,14 @@
 package engine
 
 import (
+	"context"
+
+	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/internal"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/tools/cache"
 )
 
@@ -16,3 +22,29 @@ func (e *engine) GetPolicyExceptions(
 	}
 	return e.exceptionSelector.Find(cache.MetaObjectToName(policy).String(), rule)
 }
+
+// filterCELExceptions returns the exceptions whose CEL conditions are met by the resource.
+func (e *engine) filterCELExceptions(
+	ctx context.Context,
+	logger logr.Logger,
+	policyContext engineapi.PolicyContext,
+	resource unstructured.Unstructured,
+	exceptions []*kyvernov2.PolicyException,
+) []*kyvernov2.PolicyException {
+	var filtered []*kyvernov2.PolicyException
+	for _, exception := range exceptions {
+		if len(exception.Spec.CELConditions) != 0 {
+			passed, msg, err := internal.CheckCELPreconditions(ctx, e.client, policyContext, resource, exception, "", exception.Spec.CELConditions)
+			if err != nil {
+				logger.Error(err, "failed to evaluate exception CEL conditions", "namespace", exception.GetNamespace(), "name", exception.GetName())
+				continue
+			}
+			if !passed {
+				logger.V(4).Info("exception CEL conditions not met", "namespace", exception.GetNamespace(), "name", exception.GetName(), "msg", msg)
+				continue
+			}
+		}
+		filtered = append(filtered, exception)
+	}
+	return filtered
+}
@@ -0,0 +1,55 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"gotest.tools/assert"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_filterCELExceptions(t *testing.T) {
+	var policy kyvernov1.ClusterPolicy
+	rawPolicy := []byte(`{"apiVersion":"kyverno.io\/v1","kind":"ClusterPolicy","metadata":{"name":"disallow-latest"},"spec":{"rules":[{"name":"check-image","match":{"resources":{"kinds":["Pod"]}},"validate":{"pattern":{"spec":{"containers":[{"image":"!*:latest"}]}}}}]}}`)
+	assert.NilError(t, json.Unmarshal(rawPolicy, &policy))
+	rawResource := []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"pod","labels":{"team":"payments"}},"spec":{"containers":[{"name":"nginx","image":"registry.io/nginx:latest"}]}}`)
+	resource, err := kubeutils.BytesToUnstructured(rawResource)
+	assert.NilError(t, err)
+	newException := func(name string, conditions ...admissionregistrationv1beta1.MatchCondition) *kyvernov2.PolicyException {
+		return &kyvernov2.PolicyException{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: kyvernov2.PolicyExceptionSpec{
+				CELConditions: conditions,
+			},
+		}
+	}
+	exceptions := []*kyvernov2.PolicyException{
+		newException("no-conditions"),
+		newException("registry", admissionregistrationv1beta1.MatchCondition{
+			Name:       "registry",
+			Expression: "object.spec.containers.all(c, c.image.startsWith('registry.io/'))",
+		}),
+		newException("other-team", admissionregistrationv1beta1.MatchCondition{
+			Name:       "team",
+			Expression: "object.metadata.labels['team'] == 'platform'",
+		}),
+		newException("invalid", admissionregistrationv1beta1.MatchCondition{
+			Name:       "invalid",
+			Expression: "object.metadata.name",
+		}),
+	}
+	policyContext := newPolicyContext(t, *resource, kyvernov1.Create, nil).WithPolicy(&policy)
+	e := &engine{}
+	filtered := e.filterCELExceptions(context.TODO(), logr.Discard(), policyContext, *resource, exceptions)
+	var names []string
+	for _, exception := range filtered {
+		names = append(names, exception.GetName())
+	}
+	assert.DeepEqual(t, names, []string{"no-conditions", "registry"})
+}
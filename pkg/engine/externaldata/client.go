@@ -0,0 +1,296 @@
+package externaldata
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dgraph-io/ristretto"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/grpccall"
+	"google.golang.org/grpc/credentials"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// maxResponseSize limits the size of provider responses
+const maxResponseSize = 10 * 1024 * 1024
+
+// Client queries external data providers.
+type Client interface {
+	Query(ctx context.Context, provider string, keys []string) ([]Item, error)
+}
+
+type ignoredError struct {
+	err error
+}
+
+func (e ignoredError) Error() string { return e.err.Error() }
+
+func (e ignoredError) Unwrap() error { return e.err }
+
+// IsIgnored returns true when the error was returned by a provider with the Ignore failure policy.
+func IsIgnored(err error) bool {
+	var ignored ignoredError
+	return errors.As(err, &ignored)
+}
+
+const (
+	// maxCacheEntries bounds the number of cached items
+	maxCacheEntries = 10000
+	// maxTransports bounds the number of cached provider transports
+	maxTransports = 100
+)
+
+type cacheEntry struct {
+	item    Item
+	expires time.Time
+}
+
+// transport holds the clients of a provider, it is rebuilt when the provider or its TLS secret change
+type transport struct {
+	version string
+	http    *http.Client
+	grpc    grpccall.Credentials
+}
+
+type client struct {
+	providers kyvernov2alpha1listers.ProviderLister
+	secrets   engineapi.SecretResolver
+	waitSync  func(context.Context) bool
+	synced    atomic.Bool
+	grpc      grpccall.Client
+	now       func() time.Time
+	cache     *ristretto.Cache
+	lock      sync.Mutex
+	clients   map[string]*transport
+}
+
+// NewClient returns a Client querying the providers known to the lister.
+// The waitSync function is called until the lister is synced, it starts the informer of the lister lazily
+// and returns true once it is synced. It can be nil when the lister is already synced.
+// Client certificates are loaded from the provider TLS secret, items are cached per provider
+// version and key for the provider cache TTL.
+func NewClient(providers kyvernov2alpha1listers.ProviderLister, secrets engineapi.SecretResolver, waitSync func(context.Context) bool) (Client, error) {
+	cache, err := ristretto.NewCache(&ristretto.Config{
+		MaxCost:     maxCacheEntries,
+		NumCounters: 10 * maxCacheEntries,
+		BufferItems: 64,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &client{
+		providers: providers,
+		secrets:   secrets,
+		waitSync:  waitSync,
+		grpc:      grpccall.NewClient(nil, nil),
+		now:       time.Now,
+		cache:     cache,
+		clients:   map[string]*transport{},
+	}, nil
+}
+
+func (c *client) Query(ctx context.Context, name string, keys []string) ([]Item, error) {
+	if c.waitSync != nil && !c.synced.Load() {
+		if !c.waitSync(ctx) {
+			return nil, fmt.Errorf("failed to get provider %s: providers are not synced", name)
+		}
+		c.synced.Store(true)
+	}
+	provider, err := c.providers.Get(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provider %s: %w", name, err)
+	}
+	items, err := c.query(ctx, provider, keys)
+	if err != nil && provider.Spec.GetFailurePolicy() == kyvernov1.Ignore {
+		return nil, ignoredError{err: err}
+	}
+	return items, err
+}
+
+func (c *client) query(ctx context.Context, provider *kyvernov2alpha1.Provider, keys []string) ([]Item, error) {
+	items := make([]Item, len(keys))
+	var missing []string
+	indexes := map[string][]int{}
+	for i, key := range keys {
+		if value, ok := c.cache.Get(cacheKey(provider, key)); ok {
+			if entry := value.(cacheEntry); c.now().Before(entry.expires) {
+				items[i] = entry.item
+				continue
+			}
+		}
+		if _, ok := indexes[key]; !ok {
+			missing = append(missing, key)
+		}
+		indexes[key] = append(indexes[key], i)
+	}
+	if len(missing) == 0 {
+		return items, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, provider.Spec.GetTimeout())
+	defer cancel()
+	response, err := c.call(ctx, provider, missing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query provider %s: %w", provider.Name, err)
+	}
+	if response.SystemError != "" {
+		return nil, fmt.Errorf("provider %s returned an error: %s", provider.Name, response.SystemError)
+	}
+	ttl := provider.Spec.GetCacheTTL()
+	for _, item := range response.Items {
+		for _, i := range indexes[item.Key] {
+			items[i] = item
+		}
+		// errors are never cached so that they get retried
+		if ttl > 0 && item.Error == "" {
+			c.cache.SetWithTTL(cacheKey(provider, item.Key), cacheEntry{item: item, expires: c.now().Add(ttl)}, 1, ttl)
+		}
+		delete(indexes, item.Key)
+	}
+	c.cache.Wait()
+	for _, key := range missing {
+		if _, ok := indexes[key]; ok {
+			return nil, fmt.Errorf("provider %s returned no item for key %s", provider.Name, key)
+		}
+	}
+	return items, nil
+}
+
+func (c *client) call(ctx context.Context, provider *kyvernov2alpha1.Provider, keys []string) (*Response, error) {
+	transport, err := c.transport(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+	if provider.Spec.GetProtocol() == kyvernov2alpha1.ProviderProtocolGRPC {
+		request, err := json.Marshal(Request{Keys: keys})
+		if err != nil {
+			return nil, err
+		}
+		data, err := c.grpc.Invoke(ctx, provider.Spec.URL, GRPCMethod, transport.grpc, request)
+		if err != nil {
+			return nil, err
+		}
+		var response Response
+		if err := json.Unmarshal(data, &response); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+		return &response, nil
+	}
+	request, err := json.Marshal(ProviderRequest{
+		APIVersion: APIVersion,
+		Kind:       RequestKind,
+		Request:    Request{Keys: keys},
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, provider.Spec.URL, bytes.NewReader(request))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := transport.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	var response ProviderResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if response.APIVersion != APIVersion || response.Kind != ResponseKind {
+		return nil, fmt.Errorf("unexpected response %s %s", response.APIVersion, response.Kind)
+	}
+	return &response.Response, nil
+}
+
+// transport returns the clients of the provider, they are cached until the provider or its TLS secret change
+func (c *client) transport(ctx context.Context, provider *kyvernov2alpha1.Provider) (*transport, error) {
+	var secret *corev1.Secret
+	if ref := provider.Spec.TLSSecret; ref != nil {
+		if c.secrets == nil {
+			return nil, fmt.Errorf("a secret resolver is required to load TLS secret %s/%s", ref.Namespace, ref.Name)
+		}
+		var err error
+		if secret, err = c.secrets.Get(ctx, ref.Namespace, ref.Name); err != nil {
+			return nil, fmt.Errorf("failed to get TLS secret %s/%s: %w", ref.Namespace, ref.Name, err)
+		}
+	}
+	version := provider.ResourceVersion
+	if secret != nil {
+		version += "|" + secret.ResourceVersion
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if t, ok := c.clients[provider.Name]; ok && t.version == version {
+		return t, nil
+	}
+	config, err := tlsConfig(provider, secret)
+	if err != nil {
+		return nil, err
+	}
+	if t, ok := c.clients[provider.Name]; ok {
+		t.http.CloseIdleConnections()
+	} else if len(c.clients) >= maxTransports {
+		for name, t := range c.clients {
+			t.http.CloseIdleConnections()
+			delete(c.clients, name)
+			break
+		}
+	}
+	t := &transport{
+		version: version,
+		http:    &http.Client{Transport: &http.Transport{TLSClientConfig: config}},
+		grpc: grpccall.Credentials{
+			Key: provider.Name + "|" + version,
+			New: func() (credentials.TransportCredentials, error) { return credentials.NewTLS(config), nil },
+		},
+	}
+	c.clients[provider.Name] = t
+	return t, nil
+}
+
+// tlsConfig builds the TLS configuration of the provider, the server certificate is validated against
+// the provider CA bundle when set and the client certificate is loaded from the provider TLS secret.
+func tlsConfig(provider *kyvernov2alpha1.Provider, secret *corev1.Secret) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if provider.Spec.CABundle != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(provider.Spec.CABundle)) {
+			return nil, fmt.Errorf("failed to load CA bundle of provider %s", provider.Name)
+		}
+		config.RootCAs = pool
+	}
+	if secret != nil {
+		cert, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate from secret %s/%s: %w", secret.Namespace, secret.Name, err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+func cacheKey(provider *kyvernov2alpha1.Provider, key string) string {
+	return strings.Join([]string{provider.Name, provider.ResourceVersion, key}, "|")
+}
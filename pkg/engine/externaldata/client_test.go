@@ -0,0 +1,140 @@
+package externaldata
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newProviderServer(t *testing.T, calls *int) *httptest.Server {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request ProviderRequest
+		assert.NilError(t, json.NewDecoder(r.Body).Decode(&request))
+		*calls++
+		response := ProviderResponse{APIVersion: APIVersion, Kind: ResponseKind}
+		for _, key := range request.Request.Keys {
+			if key == "fail" {
+				response.Response.SystemError = "backend unavailable"
+				continue
+			}
+			response.Response.Items = append(response.Response.Items, Item{Key: key, Value: "scanned:" + key})
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestClient(t *testing.T, now func() time.Time, providers ...*kyvernov2alpha1.Provider) *client {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, provider := range providers {
+		assert.NilError(t, indexer.Add(provider))
+	}
+	c, err := NewClient(kyvernov2alpha1listers.NewProviderLister(indexer), nil, nil)
+	assert.NilError(t, err)
+	c.(*client).now = now
+	return c.(*client)
+}
+
+func newProvider(server *httptest.Server, name string, failurePolicy kyvernov1.FailurePolicyType) *kyvernov2alpha1.Provider {
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	return &kyvernov2alpha1.Provider{
+		ObjectMeta: metav1.ObjectMeta{Name: name, ResourceVersion: "1"},
+		Spec: kyvernov2alpha1.ProviderSpec{
+			URL:           server.URL,
+			CABundle:      string(caBundle),
+			CacheTTL:      &metav1.Duration{Duration: time.Minute},
+			FailurePolicy: &failurePolicy,
+		},
+	}
+}
+
+func TestClient_Query(t *testing.T) {
+	calls := 0
+	server := newProviderServer(t, &calls)
+	now := time.Now()
+	c := newTestClient(t, func() time.Time { return now },
+		newProvider(server, "scanner", kyvernov1.Fail),
+		newProvider(server, "optional-scanner", kyvernov1.Ignore),
+	)
+	items, err := c.Query(context.TODO(), "scanner", []string{"nginx", "busybox"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, items, []Item{{Key: "nginx", Value: "scanned:nginx"}, {Key: "busybox", Value: "scanned:busybox"}})
+	assert.Equal(t, calls, 1)
+	// served from cache
+	items, err = c.Query(context.TODO(), "scanner", []string{"busybox"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, items, []Item{{Key: "busybox", Value: "scanned:busybox"}})
+	assert.Equal(t, calls, 1)
+	// expired
+	now = now.Add(2 * time.Minute)
+	_, err = c.Query(context.TODO(), "scanner", []string{"busybox"})
+	assert.NilError(t, err)
+	assert.Equal(t, calls, 2)
+	// failure policies
+	_, err = c.Query(context.TODO(), "scanner", []string{"fail"})
+	assert.ErrorContains(t, err, "backend unavailable")
+	assert.Assert(t, !IsIgnored(err))
+	_, err = c.Query(context.TODO(), "optional-scanner", []string{"fail"})
+	assert.ErrorContains(t, err, "backend unavailable")
+	assert.Assert(t, IsIgnored(err))
+	// unknown provider
+	_, err = c.Query(context.TODO(), "unknown", []string{"nginx"})
+	assert.ErrorContains(t, err, "failed to get provider unknown")
+}
+
+func TestClient_Transport(t *testing.T) {
+	calls := 0
+	server := newProviderServer(t, &calls)
+	provider := newProvider(server, "scanner", kyvernov1.Fail)
+	c := newTestClient(t, time.Now, provider)
+	first, err := c.transport(context.TODO(), provider)
+	assert.NilError(t, err)
+	second, err := c.transport(context.TODO(), provider)
+	assert.NilError(t, err)
+	assert.Assert(t, first == second)
+	// a new provider version rebuilds the transport
+	updated := provider.DeepCopy()
+	updated.ResourceVersion = "2"
+	third, err := c.transport(context.TODO(), updated)
+	assert.NilError(t, err)
+	assert.Assert(t, first != third)
+	assert.Equal(t, len(c.clients), 1)
+	// TLS secrets require a resolver
+	updated.Spec.TLSSecret = &kyvernov1.SecretReference{Namespace: "kyverno", Name: "tls"}
+	_, err = c.transport(context.TODO(), updated)
+	assert.ErrorContains(t, err, "a secret resolver is required")
+}
+
+func TestClient_WaitSync(t *testing.T) {
+	calls := 0
+	server := newProviderServer(t, &calls)
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NilError(t, indexer.Add(newProvider(server, "scanner", kyvernov1.Fail)))
+	synced := false
+	waits := 0
+	c, err := NewClient(kyvernov2alpha1listers.NewProviderLister(indexer), nil, func(context.Context) bool {
+		waits++
+		return synced
+	})
+	assert.NilError(t, err)
+	_, err = c.Query(context.TODO(), "scanner", []string{"nginx"})
+	assert.ErrorContains(t, err, "providers are not synced")
+	synced = true
+	_, err = c.Query(context.TODO(), "scanner", []string{"nginx"})
+	assert.NilError(t, err)
+	_, err = c.Query(context.TODO(), "scanner", []string{"busybox"})
+	assert.NilError(t, err)
+	assert.Equal(t, waits, 2)
+}
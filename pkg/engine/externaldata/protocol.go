@@ -0,0 +1,46 @@
+package externaldata
+
+const (
+	// APIVersion is the api version of provider requests and responses
+	APIVersion = "externaldata.kyverno.io/v1alpha1"
+	// RequestKind is the kind of provider requests
+	RequestKind = "ProviderRequest"
+	// ResponseKind is the kind of provider responses
+	ResponseKind = "ProviderResponse"
+	// GRPCMethod is the method called on providers using the GRPC protocol
+	GRPCMethod = "kyverno.externaldata.v1alpha1.Provider/Query"
+)
+
+// ProviderRequest is the request sent to HTTP providers.
+type ProviderRequest struct {
+	APIVersion string  `json:"apiVersion"`
+	Kind       string  `json:"kind"`
+	Request    Request `json:"request"`
+}
+
+// Request holds the keys a provider is queried for.
+type Request struct {
+	Keys []string `json:"keys"`
+}
+
+// ProviderResponse is the response returned by HTTP providers.
+type ProviderResponse struct {
+	APIVersion string   `json:"apiVersion"`
+	Kind       string   `json:"kind"`
+	Response   Response `json:"response"`
+}
+
+// Response holds the items returned by a provider.
+// SystemError is set when the provider failed to process the whole request.
+type Response struct {
+	Items       []Item `json:"items,omitempty"`
+	SystemError string `json:"systemError,omitempty"`
+}
+
+// Item is the value returned by a provider for a given key.
+// Error is set when the provider failed to process the key.
+type Item struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value,omitempty"`
+	Error string      `json:"error,omitempty"`
+}
@@ -6,22 +6,32 @@ import (
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/apicall"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/context/loaders"
+	"github.com/kyverno/kyverno/pkg/engine/externaldata"
+	"github.com/kyverno/kyverno/pkg/engine/grpccall"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/secretstore"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/toggle"
+	"github.com/kyverno/kyverno/pkg/tracing"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type ContextLoaderFactoryOptions func(*contextLoader)
 
 func DefaultContextLoaderFactory(cmResolver engineapi.ConfigmapResolver, opts ...ContextLoaderFactoryOptions) engineapi.ContextLoaderFactory {
-	return func(_ kyvernov1.PolicyInterface, _ kyvernov1.Rule) engineapi.ContextLoader {
+	// shared by all loaders so that gRPC connections are reused, TLS secrets can't be loaded without WithGRPCClient
+	grpcClient := grpccall.NewClient(nil, nil)
+	return func(policy kyvernov1.PolicyInterface, _ kyvernov1.Rule) engineapi.ContextLoader {
 		cl := &contextLoader{
 			logger:     logging.WithName("DefaultContextLoaderFactory"),
 			cmResolver: cmResolver,
+			policy:     policy,
+			grpcClient: grpcClient,
 		}
 		for _, o := range opts {
 			o(cl)
@@ -48,12 +58,41 @@ func WithGlobalContextStore(gctxStore loaders.Store) ContextLoaderFactoryOptions
 	}
 }
 
+func WithConfiguration(configuration config.Configuration) ContextLoaderFactoryOptions {
+	return func(cl *contextLoader) {
+		cl.configuration = configuration
+	}
+}
+
+func WithSecretStore(client secretstore.Client) ContextLoaderFactoryOptions {
+	return func(cl *contextLoader) {
+		cl.secretStore = client
+	}
+}
+
+func WithExternalDataClient(client externaldata.Client) ContextLoaderFactoryOptions {
+	return func(cl *contextLoader) {
+		cl.externalData = client
+	}
+}
+
+func WithGRPCClient(client grpccall.Client) ContextLoaderFactoryOptions {
+	return func(cl *contextLoader) {
+		cl.grpcClient = client
+	}
+}
+
 type contextLoader struct {
 	logger        logr.Logger
 	cmResolver    engineapi.ConfigmapResolver
 	initializers  []engineapi.Initializer
 	apiCallConfig apicall.APICallConfiguration
 	gctxStore     loaders.Store
+	secretStore   secretstore.Client
+	externalData  externaldata.Client
+	grpcClient    grpccall.Client
+	configuration config.Configuration
+	policy        kyvernov1.PolicyInterface
 }
 
 func (l *contextLoader) Load(
@@ -69,6 +108,30 @@ func (l *contextLoader) Load(
 			return err
 		}
 	}
+	if len(contextEntries) > 0 {
+		// dependency errors are reported at policy admission, entries are loaded as declared when they can't be resolved
+		order, err := enginecontext.ResolveLoadOrder(contextEntries)
+		if err != nil {
+			if tracing.IsInSpan(ctx) {
+				tracing.CurrentSpan(ctx).AddEvent("failed to resolve context dependencies", trace.WithAttributes(
+					tracing.ContextLoadOrderKey.String(tracing.StringValue(err.Error())),
+				))
+			}
+		} else {
+			ordered := make([]kyvernov1.ContextEntry, 0, len(order))
+			var names []string
+			for _, dependency := range order {
+				ordered = append(ordered, contextEntries[dependency.Index])
+				names = append(names, dependency.String())
+			}
+			contextEntries = ordered
+			if tracing.IsInSpan(ctx) {
+				tracing.CurrentSpan(ctx).AddEvent("context dependencies resolved", trace.WithAttributes(
+					tracing.ContextLoadOrderKey.StringSlice(names),
+				))
+			}
+		}
+	}
 	for _, entry := range contextEntries {
 		loader, err := l.newLoader(ctx, jp, client, rclientFactory, entry, jsonContext, l.gctxStore)
 		if err != nil {
@@ -108,7 +171,7 @@ func (l *contextLoader) newLoader(
 		}
 	} else if entry.APICall != nil {
 		if client != nil {
-			ldr := loaders.NewAPILoader(ctx, l.logger, entry, jsonContext, jp, client, l.apiCallConfig)
+			ldr := loaders.NewAPILoader(ctx, l.logger, entry, jsonContext, jp, client, l.apiCallConfig, l.policy)
 			return enginecontext.NewDeferredLoader(entry.Name, ldr, l.logger)
 		} else {
 			l.logger.Info("disabled loading of APICall context entry", "name", entry.Name)
@@ -116,7 +179,7 @@ func (l *contextLoader) newLoader(
 		}
 	} else if entry.GlobalReference != nil {
 		if gctx != nil {
-			ldr := loaders.NewGCTXLoader(ctx, l.logger, entry, jsonContext, jp, gctx)
+			ldr := loaders.NewGCTXLoader(ctx, l.logger, entry, jsonContext, jp, gctx, l.configuration, l.policy)
 			return enginecontext.NewDeferredLoader(entry.Name, ldr, l.logger)
 		} else {
 			l.logger.Info("disabled loading of GlobalContext context entry", "name", entry.Name)
@@ -133,6 +196,25 @@ func (l *contextLoader) newLoader(
 	} else if entry.Variable != nil {
 		ldr := loaders.NewVariableLoader(l.logger, entry, jsonContext, jp)
 		return enginecontext.NewDeferredLoader(entry.Name, ldr, l.logger)
+	} else if entry.SecretStore != nil {
+		if l.secretStore != nil {
+			ldr := loaders.NewSecretStoreLoader(ctx, l.logger, entry, jsonContext, jp, l.secretStore, l.policy)
+			return enginecontext.NewDeferredLoader(entry.Name, ldr, l.logger)
+		} else {
+			l.logger.Info("disabled loading of SecretStore context entry", "name", entry.Name)
+			return nil, nil
+		}
+	} else if entry.GRPCCall != nil {
+		ldr := loaders.NewGRPCLoader(ctx, l.logger, entry, jsonContext, jp, l.grpcClient, l.policy)
+		return enginecontext.NewDeferredLoader(entry.Name, ldr, l.logger)
+	} else if entry.ExternalData != nil {
+		if l.externalData != nil {
+			ldr := loaders.NewExternalDataLoader(ctx, l.logger, entry, jsonContext, jp, l.externalData)
+			return enginecontext.NewDeferredLoader(entry.Name, ldr, l.logger)
+		} else {
+			l.logger.Info("disabled loading of ExternalData context entry", "name", entry.Name)
+			return nil, nil
+		}
 	}
-	return nil, fmt.Errorf("missing ConfigMap|APICall|ImageRegistry|Variable in context entry %s", entry.Name)
+	return nil, fmt.Errorf("missing ConfigMap|APICall|ImageRegistry|Variable|SecretStore|GRPCCall|ExternalData in context entry %s", entry.Name)
 }
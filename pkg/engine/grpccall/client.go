@@ -0,0 +1,258 @@
+package grpccall
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// maxConnections limits the number of cached connections, the least recently used one is closed when exceeded
+	maxConnections = 100
+	// descriptorTTL is the duration method descriptors resolved with server reflection are cached
+	descriptorTTL = 5 * time.Minute
+)
+
+// Credentials are the transport credentials of a connection, Key identifies them in the connection cache
+// and New is only called when no connection exists for the target and key.
+type Credentials struct {
+	Key string
+	New func() (credentials.TransportCredentials, error)
+}
+
+// Client performs unary gRPC calls, connections and method descriptors are cached per target and credentials.
+type Client interface {
+	// Call invokes the method of a context entry, the TLS secret of the call is checked against the policy.
+	Call(ctx context.Context, policy kyvernov1.PolicyInterface, call *kyvernov1.GRPCCall) ([]byte, error)
+	// Invoke performs a unary call of the given method, request and response are JSON encoded.
+	Invoke(ctx context.Context, target, method string, creds Credentials, request []byte) ([]byte, error)
+}
+
+type connection struct {
+	conn     *grpc.ClientConn
+	lastUsed time.Time
+	// refs is the number of calls using the connection and evicted is set once it is removed from the cache,
+	// both are guarded by the client lock. Evicted connections are closed when the last call releases them.
+	refs    int
+	evicted bool
+	lock    sync.Mutex
+	methods map[string]*method
+}
+
+type method struct {
+	desc    protoreflect.MethodDescriptor
+	types   *dynamicpb.Types
+	expires time.Time
+}
+
+type client struct {
+	secretResolver engineapi.SecretResolver
+	configuration  config.Configuration
+	now            func() time.Time
+	lock           sync.Mutex
+	conns          map[string]*connection
+}
+
+// NewClient returns a Client, TLS secrets are fetched with the secret resolver and their namespace is checked
+// against the configuration.
+func NewClient(secretResolver engineapi.SecretResolver, configuration config.Configuration) Client {
+	return &client{
+		secretResolver: secretResolver,
+		configuration:  configuration,
+		now:            time.Now,
+		conns:          map[string]*connection{},
+	}
+}
+
+func (c *client) Call(ctx context.Context, policy kyvernov1.PolicyInterface, call *kyvernov1.GRPCCall) ([]byte, error) {
+	creds, err := c.transportCredentials(ctx, policy, call)
+	if err != nil {
+		return nil, err
+	}
+	var request []byte
+	if call.Request != nil {
+		request = call.Request.Raw
+	}
+	return c.Invoke(ctx, call.Target, call.Method, creds, request)
+}
+
+func (c *client) Invoke(ctx context.Context, target, method string, creds Credentials, request []byte) ([]byte, error) {
+	service, name, ok := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	if !ok || service == "" || name == "" {
+		return nil, fmt.Errorf("invalid method %s, expected <service>/<method>", method)
+	}
+	conn, err := c.acquire(target, creds)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release(conn)
+	m, err := conn.method(ctx, service, name, c.now())
+	if err != nil {
+		return nil, err
+	}
+	in := dynamicpb.NewMessage(m.desc.Input())
+	if len(request) != 0 {
+		if err := (protojson.UnmarshalOptions{Resolver: m.types}).Unmarshal(request, in); err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+	out := dynamicpb.NewMessage(m.desc.Output())
+	if err := conn.conn.Invoke(ctx, "/"+service+"/"+name, in, out); err != nil {
+		return nil, err
+	}
+	return protojson.MarshalOptions{Resolver: m.types}.Marshal(out)
+}
+
+// acquire returns the connection of a target and credentials, it must be released once the call is done
+func (c *client) acquire(target string, creds Credentials) (*connection, error) {
+	key := target + "|" + creds.Key
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if conn, ok := c.conns[key]; ok {
+		conn.lastUsed = c.now()
+		conn.refs++
+		return conn, nil
+	}
+	transportCredentials, err := creds.New()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(transportCredentials))
+	if err != nil {
+		return nil, err
+	}
+	if len(c.conns) >= maxConnections {
+		c.evict()
+	}
+	c.conns[key] = &connection{
+		conn:     conn,
+		lastUsed: c.now(),
+		refs:     1,
+		methods:  map[string]*method{},
+	}
+	return c.conns[key], nil
+}
+
+// release releases a connection returned by acquire, evicted connections are closed by their last call
+func (c *client) release(conn *connection) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	conn.refs--
+	if conn.evicted && conn.refs == 0 {
+		_ = conn.conn.Close()
+	}
+}
+
+// evict removes the least recently used connection from the cache, it is closed right away when no call uses it.
+// It must be called with the lock held.
+func (c *client) evict() {
+	var oldest string
+	for key, conn := range c.conns {
+		if oldest == "" || conn.lastUsed.Before(c.conns[oldest].lastUsed) {
+			oldest = key
+		}
+	}
+	if conn, ok := c.conns[oldest]; ok {
+		conn.evicted = true
+		if conn.refs == 0 {
+			_ = conn.conn.Close()
+		}
+		delete(c.conns, oldest)
+	}
+}
+
+// method returns the descriptor of a method, descriptors are resolved with server reflection once per descriptorTTL
+func (c *connection) method(ctx context.Context, service, name string, now time.Time) (*method, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	key := service + "/" + name
+	if m, ok := c.methods[key]; ok && now.Before(m.expires) {
+		return m, nil
+	}
+	md, files, err := resolveMethod(ctx, c.conn, service, name)
+	if err != nil {
+		return nil, err
+	}
+	m := &method{
+		desc:    md,
+		types:   dynamicpb.NewTypes(files),
+		expires: now.Add(descriptorTTL),
+	}
+	c.methods[key] = m
+	return m, nil
+}
+
+// transportCredentials builds the connection credentials, client certificates and CA bundle
+// are loaded from the referenced TLS secret.
+func (c *client) transportCredentials(ctx context.Context, policy kyvernov1.PolicyInterface, call *kyvernov1.GRPCCall) (Credentials, error) {
+	if call.Insecure {
+		return Credentials{
+			Key: "insecure",
+			New: func() (credentials.TransportCredentials, error) { return insecure.NewCredentials(), nil },
+		}, nil
+	}
+	if call.TLSSecret == nil {
+		return Credentials{
+			Key: "tls",
+			New: func() (credentials.TransportCredentials, error) { return newTLSCredentials(nil) },
+		}, nil
+	}
+	ref := call.TLSSecret
+	data, err := engineutils.FetchSecretData(ctx, c.secretResolver, c.configuration, policy, ref.Namespace, ref.Name)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("failed to get TLS secret %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+	// the secret content is part of the key so that rotated certificates open a new connection
+	hash := sha256.New()
+	for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey, "ca.crt"} {
+		hash.Write(data[key])
+		hash.Write([]byte{0})
+	}
+	return Credentials{
+		Key: fmt.Sprintf("secret|%s/%s|%s", ref.Namespace, ref.Name, hex.EncodeToString(hash.Sum(nil))),
+		New: func() (credentials.TransportCredentials, error) {
+			creds, err := newTLSCredentials(data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load TLS secret %s/%s: %w", ref.Namespace, ref.Name, err)
+			}
+			return creds, nil
+		},
+	}, nil
+}
+
+func newTLSCredentials(data map[string][]byte) (credentials.TransportCredentials, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(data[corev1.TLSCertKey]) != 0 || len(data[corev1.TLSPrivateKeyKey]) != 0 {
+		cert, err := tls.X509KeyPair(data[corev1.TLSCertKey], data[corev1.TLSPrivateKeyKey])
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	if ca := data["ca.crt"]; len(ca) != 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("failed to load CA bundle")
+		}
+		config.RootCAs = pool
+	}
+	return credentials.NewTLS(config), nil
+}
@@ -0,0 +1,215 @@
+package grpccall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newServer(t *testing.T, withReflection bool) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	server := grpc.NewServer()
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("kyverno", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+	if withReflection {
+		reflection.Register(server)
+	}
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+var insecureCredentials = Credentials{
+	Key: "insecure",
+	New: func() (credentials.TransportCredentials, error) { return insecure.NewCredentials(), nil },
+}
+
+type fakeSecretResolver map[string]*corev1.Secret
+
+func (r fakeSecretResolver) Get(_ context.Context, namespace, name string) (*corev1.Secret, error) {
+	if secret, ok := r[namespace+"/"+name]; ok {
+		return secret, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func TestInvoke(t *testing.T) {
+	target := newServer(t, true)
+	noReflection := newServer(t, false)
+	tests := []struct {
+		name    string
+		target  string
+		method  string
+		request string
+		want    string
+		wantErr bool
+	}{{
+		name:   "empty request",
+		target: target,
+		method: "grpc.health.v1.Health/Check",
+		want:   `{"status":"SERVING"}`,
+	}, {
+		name:    "request",
+		target:  target,
+		method:  "grpc.health.v1.Health/Check",
+		request: `{"service":"kyverno"}`,
+		want:    `{"status":"NOT_SERVING"}`,
+	}, {
+		name:    "invalid request",
+		target:  target,
+		method:  "grpc.health.v1.Health/Check",
+		request: `{"unknown":"field"}`,
+		wantErr: true,
+	}, {
+		name:    "invalid method",
+		target:  target,
+		method:  "grpc.health.v1.Health",
+		wantErr: true,
+	}, {
+		name:    "unknown method",
+		target:  target,
+		method:  "grpc.health.v1.Health/Unknown",
+		wantErr: true,
+	}, {
+		name:    "streaming method",
+		target:  target,
+		method:  "grpc.health.v1.Health/Watch",
+		wantErr: true,
+	}, {
+		name:    "no reflection",
+		target:  noReflection,
+		method:  "grpc.health.v1.Health/Check",
+		wantErr: true,
+	}}
+	client := NewClient(nil, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.Invoke(context.TODO(), tt.target, tt.method, insecureCredentials, []byte(tt.request))
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, string(got), tt.want)
+		})
+	}
+}
+
+func TestInvokeCachesConnections(t *testing.T) {
+	target := newServer(t, true)
+	c := NewClient(nil, nil).(*client)
+	for i := 0; i < 3; i++ {
+		_, err := c.Invoke(context.TODO(), target, "grpc.health.v1.Health/Check", insecureCredentials, nil)
+		assert.NilError(t, err)
+	}
+	assert.Equal(t, len(c.conns), 1)
+	for _, conn := range c.conns {
+		assert.Equal(t, len(conn.methods), 1)
+	}
+}
+
+func TestInvokeEvictsConnections(t *testing.T) {
+	c := NewClient(nil, nil).(*client)
+	for i := 0; i <= maxConnections; i++ {
+		conn, err := c.acquire(fmt.Sprintf("127.0.0.1:%d", 10000+i), insecureCredentials)
+		assert.NilError(t, err)
+		c.release(conn)
+	}
+	assert.Equal(t, len(c.conns), maxConnections)
+}
+
+func TestEvictDuringCall(t *testing.T) {
+	target := newServer(t, true)
+	c := NewClient(nil, nil).(*client)
+	now := time.Now()
+	c.now = func() time.Time {
+		now = now.Add(time.Millisecond)
+		return now
+	}
+	conn, err := c.acquire(target, insecureCredentials)
+	assert.NilError(t, err)
+	// the connection in use is the least recently used one and gets evicted
+	for i := 0; i < maxConnections; i++ {
+		other, err := c.acquire(fmt.Sprintf("127.0.0.1:%d", 10000+i), insecureCredentials)
+		assert.NilError(t, err)
+		c.release(other)
+	}
+	assert.Equal(t, conn.evicted, true)
+	assert.Assert(t, conn.conn.GetState() != connectivity.Shutdown)
+	m, err := conn.method(context.TODO(), "grpc.health.v1.Health", "Check", now)
+	assert.NilError(t, err)
+	out := dynamicpb.NewMessage(m.desc.Output())
+	assert.NilError(t, conn.conn.Invoke(context.TODO(), "/grpc.health.v1.Health/Check", dynamicpb.NewMessage(m.desc.Input()), out))
+	// the last call closes it
+	c.release(conn)
+	assert.Equal(t, conn.conn.GetState(), connectivity.Shutdown)
+}
+
+func TestConcurrentCallsAndEvictions(t *testing.T) {
+	target := newServer(t, true)
+	c := NewClient(nil, nil).(*client)
+	var wg sync.WaitGroup
+	errs := make(chan error, 100)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				if _, err := c.Invoke(context.TODO(), target, "grpc.health.v1.Health/Check", insecureCredentials, nil); err != nil {
+					errs <- err
+				}
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 5*maxConnections; i++ {
+			conn, err := c.acquire(fmt.Sprintf("127.0.0.1:%d", 10000+i), insecureCredentials)
+			if err != nil {
+				errs <- err
+				continue
+			}
+			c.release(conn)
+		}
+	}()
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.NilError(t, err)
+	}
+}
+
+func TestCallTLSSecretNamespace(t *testing.T) {
+	resolver := fakeSecretResolver{
+		"team-b/tls": &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Name: "tls"}},
+	}
+	c := NewClient(resolver, config.NewDefaultConfiguration(false))
+	policy := &kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "policy"}}
+	_, err := c.Call(context.TODO(), policy, &kyvernov1.GRPCCall{
+		Target:    "127.0.0.1:1",
+		Method:    "grpc.health.v1.Health/Check",
+		TLSSecret: &kyvernov1.SecretReference{Namespace: "team-b", Name: "tls"},
+	})
+	assert.ErrorContains(t, err, "can't reference secrets from namespace team-b")
+}
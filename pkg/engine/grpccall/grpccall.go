@@ -0,0 +1,101 @@
+package grpccall
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/kyverno/kyverno/pkg/engine/variables"
+)
+
+type grpcCall struct {
+	logger  logr.Logger
+	jp      jmespath.Interface
+	entry   kyvernov1.ContextEntry
+	jsonCtx enginecontext.Interface
+	client  Client
+	policy  kyvernov1.PolicyInterface
+}
+
+func New(
+	logger logr.Logger,
+	jp jmespath.Interface,
+	entry kyvernov1.ContextEntry,
+	jsonCtx enginecontext.Interface,
+	client Client,
+	policy kyvernov1.PolicyInterface,
+) (*grpcCall, error) {
+	if entry.GRPCCall == nil {
+		return nil, fmt.Errorf("missing GRPCCall in context entry %v", entry)
+	}
+	return &grpcCall{
+		logger:  logger,
+		jp:      jp,
+		entry:   entry,
+		jsonCtx: jsonCtx,
+		client:  client,
+		policy:  policy,
+	}, nil
+}
+
+func (g *grpcCall) Fetch(ctx context.Context) ([]byte, error) {
+	call, err := variables.SubstituteAllInType(g.logger, g.jsonCtx, g.entry.GRPCCall)
+	if err != nil {
+		return nil, fmt.Errorf("failed to substitute variables in context entry %s %s: %v", g.entry.Name, g.entry.GRPCCall.Method, err)
+	}
+	data, err := g.Execute(ctx, call)
+	if err != nil {
+		if g.entry.GRPCCall.Default != nil {
+			g.logger.V(4).Info("failed to call gRPC method, using default value", "default", g.entry.GRPCCall.Default.Raw, "name", g.entry.Name, "method", g.entry.GRPCCall.Method, "error", err)
+			return g.entry.GRPCCall.Default.Raw, nil
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+func (g *grpcCall) Execute(ctx context.Context, call *kyvernov1.GRPCCall) ([]byte, error) {
+	if g.client == nil {
+		return nil, fmt.Errorf("a client is required to call gRPC method %s on %s", call.Method, call.Target)
+	}
+	data, err := g.client.Call(ctx, g.policy, call)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call gRPC method %s on %s: %w", call.Method, call.Target, err)
+	}
+	g.logger.V(4).Info("executed gRPC call", "name", g.entry.Name, "method", call.Method, "target", call.Target, "len", len(data))
+	return data, nil
+}
+
+func (g *grpcCall) Store(data []byte) ([]byte, error) {
+	if g.entry.GRPCCall.JMESPath == "" || (g.entry.GRPCCall.Default != nil && string(data) == string(g.entry.GRPCCall.Default.Raw)) {
+		if err := g.jsonCtx.AddContextEntry(g.entry.Name, data); err != nil {
+			return nil, fmt.Errorf("failed to add gRPC response to context entry %s: %w", g.entry.Name, err)
+		}
+		return data, nil
+	}
+	path, err := variables.SubstituteAll(g.logger, g.jsonCtx, g.entry.GRPCCall.JMESPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to substitute variables in context entry %s JMESPath %s: %w", g.entry.Name, g.entry.GRPCCall.JMESPath, err)
+	}
+	var response interface{}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %s, error: %w", string(data), err)
+	}
+	results, err := g.jp.Search(path.(string), response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply JMESPath %s for context entry %s: %w", path, g.entry.Name, err)
+	}
+	contextData, err := json.Marshal(results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshall GRPCCall data for context entry %s: %w", g.entry.Name, err)
+	}
+	if err := g.jsonCtx.AddContextEntry(g.entry.Name, contextData); err != nil {
+		return nil, fmt.Errorf("failed to add GRPCCall results for context entry %s: %w", g.entry.Name, err)
+	}
+	g.logger.V(4).Info("added context data", "name", g.entry.Name, "len", len(contextData))
+	return contextData, nil
+}
@@ -0,0 +1,106 @@
+package grpccall
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// resolveMethod resolves the descriptor of a unary method using the server reflection service.
+func resolveMethod(ctx context.Context, conn grpc.ClientConnInterface, service, method string) (protoreflect.MethodDescriptor, *protoregistry.Files, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open reflection stream: %w", err)
+	}
+	defer func() { _ = stream.CloseSend() }()
+	files := map[string]*descriptorpb.FileDescriptorProto{}
+	if err := fetchFiles(stream, &reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: service},
+	}, files); err != nil {
+		return nil, nil, err
+	}
+	for {
+		missing := missingDependency(files)
+		if missing == "" {
+			break
+		}
+		// well known types are usually compiled in, only ask the server for the others
+		if fd, err := protoregistry.GlobalFiles.FindFileByPath(missing); err == nil {
+			files[missing] = protodesc.ToFileDescriptorProto(fd)
+			continue
+		}
+		if err := fetchFiles(stream, &reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: missing},
+		}, files); err != nil {
+			return nil, nil, err
+		}
+		if _, ok := files[missing]; !ok {
+			return nil, nil, fmt.Errorf("failed to resolve file %s", missing)
+		}
+	}
+	set := &descriptorpb.FileDescriptorSet{}
+	for _, fd := range files {
+		set.File = append(set.File, fd)
+	}
+	registry, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build file descriptors: %w", err)
+	}
+	desc, err := registry.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find service %s: %w", service, err)
+	}
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, nil, fmt.Errorf("%s is not a service", service)
+	}
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return nil, nil, fmt.Errorf("method %s not found in service %s", method, service)
+	}
+	if md.IsStreamingClient() || md.IsStreamingServer() {
+		return nil, nil, fmt.Errorf("method %s/%s is not unary", service, method)
+	}
+	return md, registry, nil
+}
+
+func fetchFiles(stream reflectionpb.ServerReflection_ServerReflectionInfoClient, request *reflectionpb.ServerReflectionRequest, files map[string]*descriptorpb.FileDescriptorProto) error {
+	if err := stream.Send(request); err != nil {
+		return fmt.Errorf("failed to send reflection request: %w", err)
+	}
+	response, err := stream.Recv()
+	if err != nil {
+		return fmt.Errorf("failed to receive reflection response: %w", err)
+	}
+	if e := response.GetErrorResponse(); e != nil {
+		return fmt.Errorf("reflection request failed: %s", e.GetErrorMessage())
+	}
+	for _, raw := range response.GetFileDescriptorResponse().GetFileDescriptorProto() {
+		var fd descriptorpb.FileDescriptorProto
+		if err := proto.Unmarshal(raw, &fd); err != nil {
+			return fmt.Errorf("failed to decode file descriptor: %w", err)
+		}
+		files[fd.GetName()] = &fd
+	}
+	return nil
+}
+
+func missingDependency(files map[string]*descriptorpb.FileDescriptorProto) string {
+	for _, fd := range files {
+		for _, dep := range fd.GetDependency() {
+			if _, ok := files[dep]; !ok {
+				return dep
+			}
+		}
+	}
+	return ""
+}
@@ -10,9 +10,12 @@ import (
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/internal"
 	"github.com/kyverno/kyverno/pkg/engine/mutate"
+	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
 	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kustomize/kyaml/openapi"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
 )
 
 type forEachMutator struct {
@@ -23,13 +26,14 @@ type forEachMutator struct {
 	resource      resourceInfo
 	nesting       int
 	contextLoader engineapi.EngineContextLoader
+	schema        *openapi.ResourceSchema
 }
 
 func (f *forEachMutator) mutateForEach(ctx context.Context) *mutate.Response {
 	var applyCount int
 
 	for _, foreach := range f.foreach {
-		elements, err := engineutils.EvaluateList(foreach.List, f.policyContext.JSONContext())
+		elements, err := engineutils.EvaluateForEachList(foreach.List, foreach.GetMapEntries(), f.policyContext.JSONContext())
 		if err != nil {
 			msg := fmt.Sprintf("failed to evaluate list %s: %v", foreach.List, err)
 			return mutate.NewErrorResponse(msg, err)
@@ -119,11 +123,12 @@ func (f *forEachMutator) mutateElements(ctx context.Context, foreach kyvernov1.F
 				foreach:       fem,
 				nesting:       f.nesting + 1,
 				contextLoader: f.contextLoader,
+				schema:        f.schema,
 			}
 
 			mutateResp = m.mutateForEach(ctx)
 		} else {
-			mutateResp = mutate.ForEach(f.rule.Name, foreach, policyContext, patchedResource.unstructured, element, f.logger)
+			mutateResp = mutate.ForEach(f.rule.Name, foreach, policyContext, patchedResource.unstructured, element, f.schema, f.logger)
 		}
 
 		if mutateResp.Status == engineapi.RuleStatusFail || mutateResp.Status == engineapi.RuleStatusError {
@@ -142,6 +147,29 @@ func (f *forEachMutator) mutateElements(ctx context.Context, foreach kyvernov1.F
 	return mutate.NewResponse(engineapi.RuleStatusSkip, patchedResource.unstructured, "no patches applied")
 }
 
+// resolveSchema returns the schema used to strategic merge patch the given resource,
+// it is only resolved for kinds unknown to kustomize, a nil schema falls back to the built-in ones.
+func resolveSchema(ctx context.Context, client engineapi.SchemaClient, resource unstructured.Unstructured, logger logr.Logger) *openapi.ResourceSchema {
+	if client == nil {
+		return nil
+	}
+	apiVersion, kind := resource.GetAPIVersion(), resource.GetKind()
+	if openapi.SchemaForResourceType(yaml.TypeMeta{APIVersion: apiVersion, Kind: kind}) != nil {
+		return nil
+	}
+	props, err := client.GetCustomResourceSchema(ctx, apiVersion, kind)
+	if err != nil {
+		logger.Error(err, "failed to get custom resource schema", "apiVersion", apiVersion, "kind", kind)
+		return nil
+	}
+	schema, err := patch.CustomResourceSchema(props)
+	if err != nil {
+		logger.Error(err, "failed to convert custom resource schema", "apiVersion", apiVersion, "kind", kind)
+		return nil
+	}
+	return schema
+}
+
 func buildRuleResponse(rule *kyvernov1.Rule, mutateResp *mutate.Response, info resourceInfo) *engineapi.RuleResponse {
 	message := mutateResp.Message
 	if mutateResp.Status == engineapi.RuleStatusPass {
@@ -94,6 +94,7 @@ func (h mutateExistingHandler) Process(
 			continue
 		}
 
+		schema := resolveSchema(ctx, h.client, target.unstructured, logger)
 		// logger.V(4).Info("apply rule to resource", "resource namespace", patchedResource.unstructured.GetNamespace(), "resource name", patchedResource.unstructured.GetName())
 		var mutateResp *mutate.Response
 		if rule.Mutation.ForEachMutation != nil {
@@ -105,10 +106,11 @@ func (h mutateExistingHandler) Process(
 				logger:        logger,
 				contextLoader: contextLoader,
 				nesting:       0,
+				schema:        schema,
 			}
 			mutateResp = m.mutateForEach(ctx)
 		} else {
-			mutateResp = mutate.Mutate(&rule, policyContext.JSONContext(), target.unstructured, logger)
+			mutateResp = mutate.Mutate(&rule, policyContext.JSONContext(), target.unstructured, schema, logger)
 		}
 		if ruleResponse := buildRuleResponse(&rule, mutateResp, target.resourceInfo); ruleResponse != nil {
 			responses = append(responses, *ruleResponse)
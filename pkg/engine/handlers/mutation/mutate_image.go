@@ -26,8 +26,10 @@ import (
 
 type mutateImageHandler struct {
 	configuration  config.Configuration
+	client         engineapi.Client
 	rclientFactory engineapi.RegistryClientFactory
 	ivCache        imageverifycache.Client
+	secretResolver engineapi.SecretResolver
 	ivm            *engineapi.ImageVerificationMetadata
 	images         []apiutils.ImageInfo
 }
@@ -37,8 +39,10 @@ func NewMutateImageHandler(
 	resource unstructured.Unstructured,
 	rule kyvernov1.Rule,
 	configuration config.Configuration,
+	client engineapi.Client,
 	rclientFactory engineapi.RegistryClientFactory,
 	ivCache imageverifycache.Client,
+	secretResolver engineapi.SecretResolver,
 	ivm *engineapi.ImageVerificationMetadata,
 ) (handlers.Handler, error) {
 	if len(rule.VerifyImages) == 0 {
@@ -53,9 +57,11 @@ func NewMutateImageHandler(
 	}
 	return mutateImageHandler{
 		configuration:  configuration,
+		client:         client,
 		rclientFactory: rclientFactory,
 		ivm:            ivm,
 		ivCache:        ivCache,
+		secretResolver: secretResolver,
 		images:         ruleImages,
 	}, nil
 }
@@ -88,6 +94,36 @@ func (h mutateImageHandler) Process(
 		)
 	}
 
+	// exceptions restricted to images only exclude the matching images from the verification
+	images := h.images
+	if imageExceptions := engineutils.MatchesImageException(exceptions, policyContext, logger); len(imageExceptions) > 0 {
+		images = make([]apiutils.ImageInfo, 0, len(h.images))
+		for _, imageInfo := range h.images {
+			image := imageInfo.String()
+			if engineutils.IsImageExcepted(image, imageExceptions) {
+				logger.V(3).Info("image verification is skipped due to policy exceptions", "image", image)
+				h.ivm.Add(image, engineapi.ImageVerificationSkip)
+				continue
+			}
+			images = append(images, imageInfo)
+		}
+		if len(images) == 0 {
+			var keys []string
+			for i, exception := range imageExceptions {
+				key, err := cache.MetaNamespaceKeyFunc(&imageExceptions[i])
+				if err != nil {
+					logger.Error(err, "failed to compute policy exception key", "namespace", exception.GetNamespace(), "name", exception.GetName())
+					return resource, handlers.WithError(rule, engineapi.Mutation, "failed to compute exception key", err)
+				}
+				keys = append(keys, key)
+			}
+			logger.V(3).Info("policy rule is skipped due to policy exceptions", "exceptions", keys)
+			return resource, handlers.WithResponses(
+				engineapi.RuleSkip(rule.Name, engineapi.Mutation, "rule is skipped due to policy exceptions"+strings.Join(keys, ", "), rule.ReportProperties).WithExceptions(imageExceptions),
+			)
+		}
+	}
+
 	jsonContext := policyContext.JSONContext()
 	ruleCopy, err := substituteVariables(rule, jsonContext, logger)
 	if err != nil {
@@ -105,8 +141,8 @@ func (h mutateImageHandler) Process(
 				engineapi.RuleError(rule.Name, engineapi.ImageVerify, "failed to fetch secrets", err, rule.ReportProperties),
 			)
 		}
-		iv := internal.NewImageVerifier(logger, rclient, h.ivCache, policyContext, *ruleCopy, h.ivm)
-		patch, ruleResponse := iv.Verify(ctx, imageVerify, h.images, h.configuration)
+		iv := internal.NewImageVerifier(logger, rclient, h.ivCache, h.secretResolver, h.configuration, policyContext, *ruleCopy, h.ivm)
+		patch, ruleResponse := iv.Verify(ctx, imageVerify, images, h.configuration)
 		patches = append(patches, patch...)
 		engineResponses = append(engineResponses, ruleResponse...)
 	}
@@ -16,10 +16,16 @@ import (
 	"k8s.io/client-go/tools/cache"
 )
 
-type mutateResourceHandler struct{}
+type mutateResourceHandler struct {
+	client engineapi.SchemaClient
+}
 
-func NewMutateResourceHandler() (handlers.Handler, error) {
-	return mutateResourceHandler{}, nil
+func NewMutateResourceHandler(
+	client engineapi.SchemaClient,
+) (handlers.Handler, error) {
+	return mutateResourceHandler{
+		client: client,
+	}, nil
 }
 
 func (h mutateResourceHandler) Process(
@@ -61,6 +67,7 @@ func (h mutateResourceHandler) Process(
 		subresource:       subresource,
 		parentResourceGVR: parentResourceGVR,
 	}
+	schema := resolveSchema(ctx, h.client, resource, logger)
 	// logger.V(4).Info("apply rule to resource", "resource namespace", patchedResource.unstructured.GetNamespace(), "resource name", patchedResource.unstructured.GetName())
 	var mutateResp *mutate.Response
 	if rule.Mutation.ForEachMutation != nil {
@@ -72,10 +79,11 @@ func (h mutateResourceHandler) Process(
 			logger:        logger,
 			contextLoader: contextLoader,
 			nesting:       0,
+			schema:        schema,
 		}
 		mutateResp = m.mutateForEach(ctx)
 	} else {
-		mutateResp = mutate.Mutate(&rule, policyContext.JSONContext(), resource, logger)
+		mutateResp = mutate.Mutate(&rule, policyContext.JSONContext(), resource, schema, logger)
 	}
 	if mutateResp == nil {
 		return resource, nil
@@ -66,6 +66,7 @@ func (h validateImageHandler) Process(
 		)
 	}
 
+	imageExceptions := engineutils.MatchesImageException(exceptions, policyContext, logger)
 	skippedImages := make([]string, 0)
 	passedImages := make([]string, 0)
 	for _, v := range rule.VerifyImages {
@@ -79,6 +80,12 @@ func (h validateImageHandler) Process(
 					return resource, nil
 				}
 
+				if engineutils.IsImageExcepted(image, imageExceptions) {
+					logger.V(3).Info("image validation is skipped due to policy exceptions", "image", image)
+					skippedImages = append(skippedImages, image)
+					continue
+				}
+
 				logger.V(4).Info("validating image", "image", image)
 				if v, err := validateImage(policyContext, imageVerify, imageInfo, logger); err != nil {
 					return resource, handlers.WithFail(rule, engineapi.ImageVerify, err.Error())
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/go-logr/logr"
 	gojmespath "github.com/kyverno/go-jmespath"
@@ -56,7 +57,17 @@ func (h validateResourceHandler) Process(
 			engineapi.RuleSkip(rule.Name, engineapi.Validation, "rule is skipped due to policy exceptions"+strings.Join(keys, ", "), rule.ReportProperties).WithExceptions(matchedExceptions),
 		)
 	}
+	// exceptions restricted to images skip the rule when all the images are excepted,
+	// foreach loops skip the containers running an excepted image
+	imageExceptions := engineutils.MatchesImageException(exceptions, policyContext, logger)
+	if len(imageExceptions) > 0 && engineutils.AllImagesExcepted(policyContext.JSONContext().ImageInfo(), imageExceptions) {
+		logger.V(3).Info("policy rule is skipped due to image policy exceptions")
+		return resource, handlers.WithResponses(
+			engineapi.RuleSkip(rule.Name, engineapi.Validation, "rule is skipped due to image policy exceptions", rule.ReportProperties).WithExceptions(imageExceptions),
+		)
+	}
 	v := newValidator(logger, contextLoader, policyContext, rule)
+	v.imageExceptions = imageExceptions
 	return resource, handlers.WithResponses(v.validate(ctx))
 }
 
@@ -72,6 +83,7 @@ type validator struct {
 	forEach          []kyvernov1.ForEachValidation
 	contextLoader    engineapi.EngineContextLoader
 	nesting          int
+	imageExceptions  []kyvernov2.PolicyException
 }
 
 func newValidator(log logr.Logger, contextLoader engineapi.EngineContextLoader, ctx engineapi.PolicyContext, rule kyvernov1.Rule) *validator {
@@ -215,11 +227,21 @@ func (v *validator) validateOldObject(ctx context.Context) (resp *engineapi.Rule
 func (v *validator) validateForEach(ctx context.Context) *engineapi.RuleResponse {
 	applyCount := 0
 	for _, foreach := range v.forEach {
-		elements, err := engineutils.EvaluateList(foreach.List, v.policyContext.JSONContext())
+		elements, err := engineutils.EvaluateForEachList(foreach.List, foreach.GetMapEntries(), v.policyContext.JSONContext())
 		if err != nil {
 			v.log.V(2).Info("failed to evaluate list", "list", foreach.List, "error", err.Error())
 			continue
 		}
+		if len(v.imageExceptions) > 0 {
+			images := v.policyContext.JSONContext().ImageInfo()
+			elements = append([]interface{}{}, elements...)
+			for i, element := range elements {
+				if engineutils.IsContainerExcepted(element, images, v.imageExceptions) {
+					v.log.V(3).Info("foreach element is skipped due to image policy exceptions", "index", i)
+					elements[i] = nil
+				}
+			}
+		}
 		resp, count := v.validateElements(ctx, foreach, elements, foreach.ElementScope)
 		if resp.Status() != engineapi.RuleStatusPass {
 			return resp
@@ -233,6 +255,10 @@ func (v *validator) validateForEach(ctx context.Context) *engineapi.RuleResponse
 }
 
 func (v *validator) validateElements(ctx context.Context, foreach kyvernov1.ForEachValidation, elements []interface{}, elementScope *bool) (*engineapi.RuleResponse, int) {
+	if concurrency := foreach.GetConcurrency(); concurrency > 1 && len(elements) > 1 {
+		return v.validateElementsConcurrently(ctx, foreach, elements, elementScope, concurrency)
+	}
+
 	v.policyContext.JSONContext().Checkpoint()
 	defer v.policyContext.JSONContext().Restore()
 	applyCount := 0
@@ -243,45 +269,127 @@ func (v *validator) validateElements(ctx context.Context, foreach kyvernov1.ForE
 		}
 
 		v.policyContext.JSONContext().Reset()
-		policyContext := v.policyContext.Copy()
-		if err := engineutils.AddElementToContext(policyContext, element, index, v.nesting, elementScope); err != nil {
-			v.log.Error(err, "failed to add element to context")
-			return engineapi.RuleError(v.rule.Name, engineapi.Validation, "failed to process foreach", err, v.rule.ReportProperties), applyCount
+		r, done := v.validateElement(ctx, foreach, v.policyContext, element, index, elementScope)
+		if done {
+			return r, applyCount
+		}
+		if resp, applied := v.elementResponse(r, index, len(elements)); resp != nil {
+			return resp, applyCount
+		} else if applied {
+			applyCount++
 		}
+	}
 
-		foreachValidator, err := newForEachValidator(foreach, v.contextLoader, v.nesting+1, v.rule, policyContext, v.log)
+	return engineapi.RulePass(v.rule.Name, engineapi.Validation, "", v.rule.ReportProperties), applyCount
+}
+
+// validateElementsConcurrently validates elements with a bounded number of workers, each worker
+// uses its own fork of the policy context. Responses are processed in list order once all elements
+// have been validated so that the result doesn't depend on scheduling.
+func (v *validator) validateElementsConcurrently(ctx context.Context, foreach kyvernov1.ForEachValidation, elements []interface{}, elementScope *bool, concurrency int) (*engineapi.RuleResponse, int) {
+	type elementResult struct {
+		response *engineapi.RuleResponse
+		done     bool
+	}
+	if concurrency > len(elements) {
+		concurrency = len(elements)
+	}
+	forks := make([]engineapi.PolicyContext, 0, concurrency)
+	for i := 0; i < concurrency; i++ {
+		fork, err := v.policyContext.Fork()
 		if err != nil {
-			v.log.Error(err, "failed to create foreach validator")
-			return engineapi.RuleError(v.rule.Name, engineapi.Validation, "failed to create foreach validator", err, v.rule.ReportProperties), applyCount
+			v.log.Error(err, "failed to fork policy context")
+			return engineapi.RuleError(v.rule.Name, engineapi.Validation, "failed to process foreach", err, v.rule.ReportProperties), 0
+		}
+		forks = append(forks, fork)
+	}
+	results := make([]elementResult, len(elements))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for _, fork := range forks {
+		wg.Add(1)
+		go func(policyContext engineapi.PolicyContext) {
+			defer wg.Done()
+			policyContext.JSONContext().Checkpoint()
+			defer policyContext.JSONContext().Restore()
+			for index := range indexes {
+				policyContext.JSONContext().Reset()
+				r, done := v.validateElement(ctx, foreach, policyContext, elements[index], index, elementScope)
+				results[index] = elementResult{response: r, done: done}
+			}
+		}(fork)
+	}
+	for index, element := range elements {
+		if element != nil {
+			indexes <- index
 		}
+	}
+	close(indexes)
+	wg.Wait()
 
-		r := foreachValidator.validate(ctx)
-		if r == nil {
-			v.log.V(2).Info("skip rule due to empty result")
+	applyCount := 0
+	for index, element := range elements {
+		if element == nil {
 			continue
 		}
-		status := r.Status()
-		if status == engineapi.RuleStatusSkip {
-			v.log.V(2).Info("skip rule", "reason", r.Message())
-			continue
-		} else if status != engineapi.RuleStatusPass {
-			if status == engineapi.RuleStatusError {
-				if index < len(elements)-1 {
-					continue
-				}
-				msg := fmt.Sprintf("validation failure: %v", r.Message())
-				return engineapi.NewRuleResponse(v.rule.Name, engineapi.Validation, msg, status, v.rule.ReportProperties), applyCount
-			}
-			msg := fmt.Sprintf("validation failure: %v", r.Message())
-			return engineapi.NewRuleResponse(v.rule.Name, engineapi.Validation, msg, status, v.rule.ReportProperties), applyCount
+		result := results[index]
+		if result.done {
+			return result.response, applyCount
+		}
+		if resp, applied := v.elementResponse(result.response, index, len(elements)); resp != nil {
+			return resp, applyCount
+		} else if applied {
+			applyCount++
 		}
-
-		applyCount++
 	}
 
 	return engineapi.RulePass(v.rule.Name, engineapi.Validation, "", v.rule.ReportProperties), applyCount
 }
 
+// validateElement validates a single element, done is true when the element could not be processed
+// and the returned response must be used as the foreach result.
+func (v *validator) validateElement(ctx context.Context, foreach kyvernov1.ForEachValidation, parent engineapi.PolicyContext, element interface{}, index int, elementScope *bool) (*engineapi.RuleResponse, bool) {
+	policyContext := parent.Copy()
+	if err := engineutils.AddElementToContext(policyContext, element, index, v.nesting, elementScope); err != nil {
+		v.log.Error(err, "failed to add element to context")
+		return engineapi.RuleError(v.rule.Name, engineapi.Validation, "failed to process foreach", err, v.rule.ReportProperties), true
+	}
+
+	foreachValidator, err := newForEachValidator(foreach, v.contextLoader, v.nesting+1, v.rule, policyContext, v.log)
+	if err != nil {
+		v.log.Error(err, "failed to create foreach validator")
+		return engineapi.RuleError(v.rule.Name, engineapi.Validation, "failed to create foreach validator", err, v.rule.ReportProperties), true
+	}
+	foreachValidator.imageExceptions = v.imageExceptions
+
+	return foreachValidator.validate(ctx), false
+}
+
+// elementResponse returns the response ending the foreach for an element response, if any,
+// and whether the element was applied.
+func (v *validator) elementResponse(r *engineapi.RuleResponse, index, count int) (*engineapi.RuleResponse, bool) {
+	if r == nil {
+		v.log.V(2).Info("skip rule due to empty result")
+		return nil, false
+	}
+	status := r.Status()
+	if status == engineapi.RuleStatusSkip {
+		v.log.V(2).Info("skip rule", "reason", r.Message())
+		return nil, false
+	} else if status != engineapi.RuleStatusPass {
+		if status == engineapi.RuleStatusError {
+			if index < count-1 {
+				return nil, false
+			}
+			msg := fmt.Sprintf("validation failure: %v", r.Message())
+			return engineapi.NewRuleResponse(v.rule.Name, engineapi.Validation, msg, status, v.rule.ReportProperties), false
+		}
+		msg := fmt.Sprintf("validation failure: %v", r.Message())
+		return engineapi.NewRuleResponse(v.rule.Name, engineapi.Validation, msg, status, v.rule.ReportProperties), false
+	}
+	return nil, true
+}
+
 func (v *validator) loadContext(ctx context.Context) error {
 	if err := v.contextLoader(ctx, v.contextEntries, v.policyContext.JSONContext()); err != nil {
 		if _, ok := err.(gojmespath.NotFoundError); ok {
@@ -2,6 +2,8 @@ package validation
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -51,6 +53,47 @@ func Test_validateOldObjectForeach(t *testing.T) {
 	assert.Equal(t, api.RuleStatusSkip, resp.Status())
 }
 
+func Test_validateForeachConcurrency(t *testing.T) {
+	mockCL := func(ctx context.Context, contextEntries []kyvernov1.ContextEntry, jsonContext enginecontext.Interface) error {
+		return nil
+	}
+
+	tests := []struct {
+		name   string
+		images []string
+		status api.RuleStatus
+	}{{
+		name:   "all elements pass",
+		images: []string{"ghcr.io/a", "ghcr.io/b", "ghcr.io/c", "ghcr.io/d", "ghcr.io/e"},
+		status: api.RuleStatusPass,
+	}, {
+		name:   "some elements fail",
+		images: []string{"ghcr.io/a", "docker.io/b", "ghcr.io/c", "docker.io/d", "ghcr.io/e"},
+		status: api.RuleStatusFail,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var containers []string
+			for i, image := range tt.images {
+				containers = append(containers, fmt.Sprintf(`{"name": "c%d", "image": "%s"}`, i, image))
+			}
+			pod := fmt.Sprintf(`{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "test"}, "spec": {"containers": [%s]}}`, strings.Join(containers, ","))
+			var responses []*api.RuleResponse
+			for _, concurrency := range []int{1, 3} {
+				policyContext := buildContext(t, kyvernov1.Create, validateForeachConcurrencyPolicy, pod, "")
+				rule := *policyContext.Policy().GetSpec().Rules[0].DeepCopy()
+				rule.Validation.ForEachValidation[0].Concurrency = &concurrency
+				v := newValidator(logr.Discard(), mockCL, policyContext, rule)
+				resp := v.validate(context.TODO())
+				assert.NotNil(t, resp)
+				assert.Equal(t, tt.status, resp.Status())
+				responses = append(responses, resp)
+			}
+			assert.Equal(t, responses[0].Message(), responses[1].Message())
+		})
+	}
+}
+
 var (
 	validateDenyPolicy = `{
 		"apiVersion": "kyverno.io/v1",
@@ -176,6 +219,53 @@ var (
 }
 	`
 
+	validateForeachConcurrencyPolicy = `{
+  "apiVersion": "kyverno.io/v1",
+  "kind": "ClusterPolicy",
+  "metadata": {
+    "name": "validate-image-registry"
+  },
+  "spec": {
+    "rules": [
+      {
+        "match": {
+          "any": [
+            {
+              "resources": {
+                "kinds": [
+                  "Pod"
+                ]
+              }
+            }
+          ]
+        },
+        "name": "check-image",
+        "validate": {
+          "failureAction": "Enforce",
+          "foreach": [
+            {
+              "deny": {
+                "conditions": {
+                  "all": [
+                    {
+                      "key": "{{ starts_with(element, 'ghcr.io/') }}",
+                      "operator": "Equals",
+                      "value": false
+                    }
+                  ]
+                }
+              },
+              "list": "request.object.spec.containers[].image"
+            }
+          ],
+          "message": "images must begin with ghcr.io"
+        }
+      }
+    ]
+  }
+}
+`
+
 	resource = `{
 		"apiVersion": "v1",
 		"kind": "Pod",
@@ -0,0 +1,89 @@
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/handlers"
+	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+)
+
+type validateWasmHandler struct {
+	evaluator engineapi.WasmEvaluator
+}
+
+func NewValidateWasmHandler(evaluator engineapi.WasmEvaluator) (handlers.Handler, error) {
+	return validateWasmHandler{
+		evaluator: evaluator,
+	}, nil
+}
+
+func (h validateWasmHandler) Process(
+	ctx context.Context,
+	logger logr.Logger,
+	policyContext engineapi.PolicyContext,
+	resource unstructured.Unstructured,
+	rule kyvernov1.Rule,
+	_ engineapi.EngineContextLoader,
+	exceptions []*kyvernov2.PolicyException,
+) (unstructured.Unstructured, []engineapi.RuleResponse) {
+	// check if there are policy exceptions that match the incoming resource
+	matchedExceptions := engineutils.MatchesException(exceptions, policyContext, logger)
+	if len(matchedExceptions) > 0 {
+		var keys []string
+		for i, exception := range matchedExceptions {
+			key, err := cache.MetaNamespaceKeyFunc(&matchedExceptions[i])
+			if err != nil {
+				logger.Error(err, "failed to compute policy exception key", "namespace", exception.GetNamespace(), "name", exception.GetName())
+				return resource, handlers.WithError(rule, engineapi.Validation, "failed to compute exception key", err)
+			}
+			keys = append(keys, key)
+		}
+		logger.V(3).Info("policy rule is skipped due to policy exceptions", "exceptions", keys)
+		return resource, handlers.WithResponses(
+			engineapi.RuleSkip(rule.Name, engineapi.Validation, "rule is skipped due to policy exceptions"+strings.Join(keys, ", "), rule.ReportProperties).WithExceptions(matchedExceptions),
+		)
+	}
+	if h.evaluator == nil {
+		return resource, handlers.WithError(rule, engineapi.Validation, "failed to evaluate wasm module", errors.New("wasm modules are disabled"))
+	}
+	gvk, subResource := policyContext.ResourceKind()
+	input, err := json.Marshal(map[string]any{
+		"object":          policyContext.NewResource().Object,
+		"oldObject":       policyContext.OldResource().Object,
+		"operation":       policyContext.Operation(),
+		"admissionInfo":   policyContext.AdmissionInfo(),
+		"namespaceLabels": policyContext.NamespaceLabels(),
+		"resourceKind": map[string]any{
+			"group":       gvk.Group,
+			"version":     gvk.Version,
+			"kind":        gvk.Kind,
+			"subResource": subResource,
+		},
+	})
+	if err != nil {
+		return resource, handlers.WithError(rule, engineapi.Validation, "failed to marshal wasm input", err)
+	}
+	result, err := h.evaluator.Evaluate(ctx, *rule.Validation.Wasm, input)
+	if err != nil {
+		return resource, handlers.WithError(rule, engineapi.Validation, "failed to evaluate wasm module", err)
+	}
+	if !result.Allowed {
+		msg := rule.Validation.Message
+		if msg == "" {
+			msg = result.Message
+		}
+		return resource, handlers.WithFail(rule, engineapi.Validation, msg)
+	}
+	msg := fmt.Sprintf("Validation rule '%s' passed.", rule.Name)
+	return resource, handlers.WithPass(rule, engineapi.Validation, msg)
+}
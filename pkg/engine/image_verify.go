@@ -27,6 +27,7 @@ func (e *engine) verifyAndPatchImages(
 
 	policyContext.JSONContext().Checkpoint()
 	defer policyContext.JSONContext().Restore()
+	loadVariables := e.policyVariablesLoader(ctx, policyContext)
 
 	for _, rule := range autogen.ComputeRules(policy, "") {
 		startTime := time.Now()
@@ -40,8 +41,10 @@ func (e *engine) verifyAndPatchImages(
 				matchedResource,
 				rule,
 				e.configuration,
+				e.client,
 				e.rclientFactory,
 				e.ivCache,
+				e.secretResolver,
 				&ivm,
 			)
 		}
@@ -53,6 +56,7 @@ func (e *engine) verifyAndPatchImages(
 			matchedResource,
 			rule,
 			engineapi.ImageVerify,
+			loadVariables,
 		)
 		matchedResource = resource
 		resp.Add(engineapi.NewExecutionStats(startTime, time.Now()), ruleResp...)
@@ -324,6 +324,9 @@ func testVerifyAndPatchImages(
 		imageverifycache.DisabledImageVerifyCache(),
 		factories.DefaultContextLoaderFactory(cmResolver),
 		nil,
+		nil,
+		nil,
+		nil,
 	)
 	return e.VerifyAndPatchImages(
 		ctx,
@@ -1059,6 +1062,9 @@ func testImageVerifyCache(
 		ivCache,
 		factories.DefaultContextLoaderFactory(cmResolver),
 		nil,
+		nil,
+		nil,
+		nil,
 	)
 	return e.VerifyAndPatchImages(
 		ctx,
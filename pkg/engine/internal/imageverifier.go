@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"regexp"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -15,10 +16,12 @@ import (
 	"github.com/kyverno/kyverno/pkg/cosign"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
+	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"github.com/kyverno/kyverno/pkg/images"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
 	"github.com/kyverno/kyverno/pkg/notary"
+	"github.com/kyverno/kyverno/pkg/sbom"
 	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
 	"github.com/kyverno/kyverno/pkg/utils/jsonpointer"
 	stringutils "github.com/kyverno/kyverno/pkg/utils/strings"
@@ -27,30 +30,43 @@ import (
 	"gomodules.xyz/jsonpatch/v2"
 )
 
+// keys of the sigstore trust material stored in the secret of a keyless attestor
+const (
+	fulcioRootsKey = "fulcio_v1.crt.pem"
+	rekorPubKeyKey = "rekor.pub"
+	ctLogPubKeyKey = "ctfe.pub"
+)
+
 type ImageVerifier struct {
-	logger        logr.Logger
-	rclient       engineapi.RegistryClient
-	ivCache       imageverifycache.Client
-	policyContext engineapi.PolicyContext
-	rule          kyvernov1.Rule
-	ivm           *engineapi.ImageVerificationMetadata
+	logger         logr.Logger
+	rclient        engineapi.RegistryClient
+	ivCache        imageverifycache.Client
+	secretResolver engineapi.SecretResolver
+	configuration  config.Configuration
+	policyContext  engineapi.PolicyContext
+	rule           kyvernov1.Rule
+	ivm            *engineapi.ImageVerificationMetadata
 }
 
 func NewImageVerifier(
 	logger logr.Logger,
 	rclient engineapi.RegistryClient,
 	ivCache imageverifycache.Client,
+	secretResolver engineapi.SecretResolver,
+	configuration config.Configuration,
 	policyContext engineapi.PolicyContext,
 	rule kyvernov1.Rule,
 	ivm *engineapi.ImageVerificationMetadata,
 ) *ImageVerifier {
 	return &ImageVerifier{
-		logger:        logger,
-		rclient:       rclient,
-		ivCache:       ivCache,
-		policyContext: policyContext,
-		rule:          rule,
-		ivm:           ivm,
+		logger:         logger,
+		rclient:        rclient,
+		ivCache:        ivCache,
+		secretResolver: secretResolver,
+		configuration:  configuration,
+		policyContext:  policyContext,
+		rule:           rule,
+		ivm:            ivm,
 	}
 }
 
@@ -153,6 +169,18 @@ func EvaluateConditions(
 	if err := enginecontext.AddJSONObject(ctx, predicate); err != nil {
 		return false, "", fmt.Errorf("failed to add Statement to the context %v: %w", s, err)
 	}
+	// the normalized SBOM is only built when conditions use it, other conditions work on the raw predicate
+	if predicateType, ok := s["type"].(string); ok && referencesSBOM(conditions) {
+		if format := sbom.Format(predicateType); format != "" {
+			doc, err := sbom.Parse(format, predicate)
+			if err != nil {
+				return false, "", fmt.Errorf("failed to parse SBOM referenced in attestation conditions: %w", err)
+			}
+			if err := ctx.AddVariable("sbom", doc); err != nil {
+				return false, "", fmt.Errorf("failed to add SBOM to the context: %w", err)
+			}
+		}
+	}
 	c, err := variables.SubstituteAllInConditions(log, ctx, conditions)
 	if err != nil {
 		return false, "", fmt.Errorf("failed to substitute variables in attestation conditions: %w", err)
@@ -160,6 +188,17 @@ func EvaluateConditions(
 	return variables.EvaluateAnyAllConditions(log, ctx, c)
 }
 
+var sbomVariable = regexp.MustCompile(`\{\{[^}]*\bsbom\b`)
+
+// referencesSBOM returns true when a condition references the sbom variable
+func referencesSBOM(conditions []kyvernov1.AnyAllConditions) bool {
+	data, err := json.Marshal(conditions)
+	if err != nil {
+		return true
+	}
+	return sbomVariable.Match(data)
+}
+
 func getRawResp(statements []map[string]interface{}) ([]byte, error) {
 	for _, statement := range statements {
 		predicate, ok := statement["predicate"].(map[string]interface{})
@@ -369,7 +408,12 @@ func (iv *ImageVerifier) verifyAttestations(
 			for _, a := range attestor.Entries {
 				var attestationError error
 				entryPath := fmt.Sprintf("%s.entries[%d]", attestorPath, i)
-				v, opts, subPath := iv.buildVerifier(a, imageVerify, image, &imageVerify.Attestations[i])
+				v, opts, subPath, err := iv.buildVerifier(ctx, a, imageVerify, image, &imageVerify.Attestations[i])
+				if err != nil {
+					iv.logger.Error(err, "failed to build verifier", "image", image)
+					errorList = append(errorList, err)
+					continue
+				}
 				cosignResp, err := v.FetchAttestations(ctx, *opts)
 				if err != nil {
 					iv.logger.Error(err, "failed to fetch attestations", "image", image)
@@ -472,10 +516,14 @@ func (iv *ImageVerifier) verifyAttestorSet(
 				cosignResp, entryError = iv.verifyAttestorSet(ctx, *nestedAttestorSet, imageVerify, imageInfo, attestorPath)
 			}
 		} else {
-			v, opts, subPath := iv.buildVerifier(a, imageVerify, image, nil)
-			cosignResp, entryError = v.VerifySignature(ctx, *opts)
-			if entryError != nil {
-				entryError = fmt.Errorf("%s: %w", attestorPath+subPath, entryError)
+			v, opts, subPath, err := iv.buildVerifier(ctx, a, imageVerify, image, nil)
+			if err != nil {
+				entryError = fmt.Errorf("%s: %w", attestorPath+subPath, err)
+			} else {
+				cosignResp, entryError = v.VerifySignature(ctx, *opts)
+				if entryError != nil {
+					entryError = fmt.Errorf("%s: %w", attestorPath+subPath, entryError)
+				}
 			}
 		}
 
@@ -496,25 +544,27 @@ func (iv *ImageVerifier) verifyAttestorSet(
 }
 
 func (iv *ImageVerifier) buildVerifier(
+	ctx context.Context,
 	attestor kyvernov1.Attestor,
 	imageVerify kyvernov1.ImageVerification,
 	image string,
 	attestation *kyvernov1.Attestation,
-) (images.ImageVerifier, *images.Options, string) {
+) (images.ImageVerifier, *images.Options, string, error) {
 	switch imageVerify.Type {
 	case kyvernov1.Notary:
-		return iv.buildNotaryVerifier(attestor, image, attestation)
+		return iv.buildNotaryVerifier(ctx, attestor, image, attestation)
 	default:
-		return iv.buildCosignVerifier(attestor, imageVerify, image, attestation)
+		return iv.buildCosignVerifier(ctx, attestor, imageVerify, image, attestation)
 	}
 }
 
 func (iv *ImageVerifier) buildCosignVerifier(
+	ctx context.Context,
 	attestor kyvernov1.Attestor,
 	imageVerify kyvernov1.ImageVerification,
 	image string,
 	attestation *kyvernov1.Attestation,
-) (images.ImageVerifier, *images.Options, string) {
+) (images.ImageVerifier, *images.Options, string, error) {
 	path := ""
 	opts := &images.Options{
 		ImageRef:           image,
@@ -527,6 +577,11 @@ func (iv *ImageVerifier) buildCosignVerifier(
 
 	if imageVerify.Type == kyvernov1.SigstoreBundle {
 		opts.SigstoreBundle = true
+		bundles, err := iv.resolveBundles(ctx, imageVerify.Bundles)
+		if err != nil {
+			return nil, nil, "", err
+		}
+		opts.Bundles = bundles
 	}
 
 	if imageVerify.Roots != "" {
@@ -616,6 +671,22 @@ func (iv *ImageVerifier) buildCosignVerifier(
 		opts.Subject = attestor.Keyless.Subject
 		opts.SubjectRegExp = attestor.Keyless.SubjectRegExp
 		opts.AdditionalExtensions = attestor.Keyless.AdditionalExtensions
+
+		if attestor.Keyless.Secret != nil {
+			data, err := iv.fetchSecretData(ctx, *attestor.Keyless.Secret)
+			if err != nil {
+				return nil, nil, path, fmt.Errorf("failed to fetch sigstore trust material: %w", err)
+			}
+			if roots := data[fulcioRootsKey]; roots != "" {
+				opts.Roots = roots
+			}
+			if pubKey := data[rekorPubKeyKey]; pubKey != "" {
+				opts.RekorPubKey = pubKey
+			}
+			if pubKey := data[ctLogPubKeyKey]; pubKey != "" {
+				opts.CTLogsPubKey = pubKey
+			}
+		}
 	}
 
 	if attestor.Repository != "" {
@@ -627,20 +698,32 @@ func (iv *ImageVerifier) buildCosignVerifier(
 	}
 
 	iv.logger.V(4).Info("cosign verifier built", "ignoreTlog", opts.IgnoreTlog, "ignoreSCT", opts.IgnoreSCT, "image", image)
-	return cosign.NewVerifier(), opts, path
+	return cosign.NewVerifier(), opts, path, nil
 }
 
 func (iv *ImageVerifier) buildNotaryVerifier(
+	ctx context.Context,
 	attestor kyvernov1.Attestor,
 	image string,
 	attestation *kyvernov1.Attestation,
-) (images.ImageVerifier, *images.Options, string) {
+) (images.ImageVerifier, *images.Options, string, error) {
 	path := ""
 	opts := &images.Options{
-		ImageRef:  image,
-		Cert:      attestor.Certificates.Certificate,
-		CertChain: attestor.Certificates.CertificateChain,
-		Client:    iv.rclient,
+		ImageRef: image,
+		Client:   iv.rclient,
+	}
+
+	if attestor.Certificates != nil {
+		opts.Cert = attestor.Certificates.Certificate
+		opts.CertChain = attestor.Certificates.CertificateChain
+	} else if attestor.Notary != nil {
+		path = path + ".notary"
+		trustStores, err := iv.fetchSecretData(ctx, attestor.Notary.TrustStore)
+		if err != nil {
+			return nil, nil, path, fmt.Errorf("failed to fetch trust stores: %w", err)
+		}
+		opts.TrustPolicy = attestor.Notary.TrustPolicy
+		opts.TrustStores = trustStores
 	}
 
 	if attestation != nil {
@@ -661,7 +744,41 @@ func (iv *ImageVerifier) buildNotaryVerifier(
 		opts.Annotations = attestor.Annotations
 	}
 
-	return notary.NewVerifier(), opts, path
+	return notary.NewVerifier(), opts, path, nil
+}
+
+// fetchSecretData returns the data of a secret, used for trust stores and sigstore trust material.
+// Secrets are served from informers and the namespace must be allowed for the policy.
+func (iv *ImageVerifier) fetchSecretData(ctx context.Context, ref kyvernov1.SecretReference) (map[string]string, error) {
+	data, err := engineutils.FetchSecretData(ctx, iv.secretResolver, iv.configuration, iv.policyContext.Policy(), ref.Namespace, ref.Name)
+	if err != nil {
+		return nil, err
+	}
+	decoded := make(map[string]string, len(data))
+	for key, value := range data {
+		decoded[key] = string(value)
+	}
+	return decoded, nil
+}
+
+func (iv *ImageVerifier) resolveBundles(ctx context.Context, sources []kyvernov1.BundleSource) ([]string, error) {
+	bundles := make([]string, 0, len(sources))
+	for _, source := range sources {
+		if source.Secret == nil {
+			bundles = append(bundles, source.Data)
+			continue
+		}
+		data, err := iv.fetchSecretData(ctx, *source.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch bundle from secret %s/%s: %w", source.Secret.Namespace, source.Secret.Name, err)
+		}
+		bundle, ok := data["bundle.json"]
+		if !ok {
+			return nil, fmt.Errorf("secret %s/%s does not contain a bundle.json key", source.Secret.Namespace, source.Secret.Name)
+		}
+		bundles = append(bundles, bundle)
+	}
+	return bundles, nil
 }
 
 func (iv *ImageVerifier) verifyAttestation(statements []map[string]interface{}, attestation kyvernov1.Attestation, imageInfo apiutils.ImageInfo) error {
@@ -29,6 +29,10 @@ func MatchPolicyContext(logger logr.Logger, client engineapi.Client, policyConte
 		logger.V(4).Info("configuration resource filters doesn't match resource")
 		return false
 	}
+	if !checkResourceExclusions(configuration, policyContext, gvk, subresource, new, old) {
+		logger.V(4).Info("configuration resource exclusions match resource")
+		return false
+	}
 
 	if policy.GetSpec().GetMatchConditions() != nil {
 		if !checkMatchConditions(logger, policyContext, gvk, subresource) {
@@ -51,6 +55,31 @@ func checkResourceFilters(configuration config.Configuration, gvk schema.GroupVe
 	return true
 }
 
+func checkResourceExclusions(configuration config.Configuration, policyContext engineapi.PolicyContext, gvk schema.GroupVersionKind, subresource string, resources ...unstructured.Unstructured) bool {
+	request := config.ExclusionRequest{
+		Kind:        gvk,
+		Subresource: subresource,
+	}
+	// the operation and user are only known at admission time
+	if policyContext.AdmissionOperation() {
+		userInfo := policyContext.AdmissionInfo().AdmissionUserInfo
+		request.Operation = string(policyContext.Operation())
+		request.Username = userInfo.Username
+		request.Groups = userInfo.Groups
+	}
+	for _, resource := range resources {
+		if resource.Object != nil {
+			request.Namespace = resource.GetNamespace()
+			request.Name = resource.GetName()
+			request.Labels = resource.GetLabels()
+			if configuration.IsResourceExcluded(request) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 func checkNamespacedPolicy(policy kyvernov1.PolicyInterface, resources ...unstructured.Unstructured) bool {
 	if policy.IsNamespaced() {
 		policyNamespace := policy.GetNamespace()
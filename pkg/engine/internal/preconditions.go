@@ -1,13 +1,28 @@
 package internal
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
+	"github.com/dgraph-io/ristretto"
 	"github.com/go-logr/logr"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
 	"github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/engine/variables"
+	celutils "github.com/kyverno/kyverno/pkg/utils/cel"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/admission/plugin/cel"
+	"k8s.io/apiserver/pkg/admission/plugin/webhook/matchconditions"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
 )
 
 func CheckPreconditions(logger logr.Logger, jsonContext enginecontext.Interface, anyAllConditions apiextensions.JSON) (bool, string, error) {
@@ -27,3 +42,101 @@ func CheckDenyPreconditions(logger logr.Logger, jsonContext enginecontext.Interf
 
 	return variables.EvaluateConditions(logger, jsonContext, typeConditions)
 }
+
+// maxCompiledPreconditions limits the number of compiled CEL preconditions kept in memory
+const maxCompiledPreconditions = 1000
+
+var compiledPreconditions = newCompiledPreconditionsCache()
+
+func newCompiledPreconditionsCache() *ristretto.Cache {
+	cache, err := ristretto.NewCache(&ristretto.Config{
+		MaxCost:     maxCompiledPreconditions,
+		NumCounters: 10 * maxCompiledPreconditions,
+		BufferItems: 64,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return cache
+}
+
+// compileCELPreconditions compiles CEL preconditions, compiled programs are cached for every version of the
+// policy or exception declaring them so that they are compiled once and not for every resource.
+// Objects without a resource version, like policies built in memory, are cached by expressions.
+func compileCELPreconditions(owner metav1.Object, name string, conditions []admissionregistrationv1beta1.MatchCondition) (cel.Filter, error) {
+	var key string
+	if owner != nil && owner.GetUID() != "" && owner.GetResourceVersion() != "" {
+		key = strings.Join([]string{string(owner.GetUID()), owner.GetResourceVersion(), name}, "/")
+	} else {
+		data, err := json.Marshal(conditions)
+		if err != nil {
+			return nil, err
+		}
+		key = string(data)
+	}
+	if filter, ok := compiledPreconditions.Get(key); ok {
+		return filter.(cel.Filter), nil
+	}
+	filter, err := celutils.CompileMatchConditions(conditions)
+	if err != nil {
+		return nil, err
+	}
+	compiledPreconditions.Set(key, filter, 1)
+	return filter, nil
+}
+
+// CheckCELPreconditions evaluates preconditions written as CEL expressions. The expressions have access
+// to the same variables as the match conditions of validate.cel subrules (object, oldObject, request and authorizer).
+// The owner is the policy or exception declaring the preconditions, name identifies them within the owner.
+func CheckCELPreconditions(
+	ctx context.Context,
+	client engineapi.Client,
+	policyContext engineapi.PolicyContext,
+	resource unstructured.Unstructured,
+	owner metav1.Object,
+	name string,
+	conditions []admissionregistrationv1beta1.MatchCondition,
+) (bool, string, error) {
+	if len(conditions) == 0 {
+		return true, "", nil
+	}
+	filter, err := compileCELPreconditions(owner, name, conditions)
+	if err != nil {
+		return false, "", err
+	}
+	policy := policyContext.Policy()
+	matcher := matchconditions.NewMatcher(filter, nil, policy.GetKind(), "", policy.GetName())
+
+	gvr := schema.GroupVersionResource(policyContext.RequestResource())
+	gvk, _ := policyContext.ResourceKind()
+	var object, oldObject runtime.Object
+	oldResource := policyContext.OldResource()
+	ns, name := oldResource.GetNamespace(), oldResource.GetName()
+	if oldResource.Object != nil {
+		oldObject = oldResource.DeepCopyObject()
+	}
+	if resource.Object != nil {
+		ns, name = resource.GetNamespace(), resource.GetName()
+		object = resource.DeepCopyObject()
+	}
+	requestInfo := policyContext.AdmissionInfo()
+	userInfo := NewUser(requestInfo.AdmissionUserInfo.Username, requestInfo.AdmissionUserInfo.UID, requestInfo.AdmissionUserInfo.Groups)
+	attr := admission.NewAttributesRecord(object, oldObject, gvk, ns, name, gvr, "", admission.Operation(policyContext.Operation()), nil, false, &userInfo)
+	versionedAttr, err := admission.NewVersionedAttributes(attr, attr.GetKind(), admission.NewObjectInterfacesFromScheme(runtime.NewScheme()))
+	if err != nil {
+		return false, "", fmt.Errorf("failed to create versioned attributes: %w", err)
+	}
+	var authz authorizer.Authorizer
+	if client != nil {
+		a := NewAuthorizer(client, gvk)
+		authz = &a
+	}
+	result := matcher.Match(ctx, versionedAttr, nil, authz)
+	if result.Error != nil {
+		return false, "", fmt.Errorf("failed to evaluate CEL preconditions: %w", result.Error)
+	}
+	if !result.Matches {
+		return false, fmt.Sprintf("CEL precondition %s not met", result.FailedConditionName), nil
+	}
+	return true, "", nil
+}
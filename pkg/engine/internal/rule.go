@@ -1,6 +1,8 @@
 package internal
 
 import (
+	"fmt"
+
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
@@ -19,3 +21,37 @@ func SubstitutePropertiesInRule(log logr.Logger, rule *kyvernov1.Rule, jsonConte
 	rule.ReportProperties = *updatedProperties
 	return nil
 }
+
+// EvaluateRuleOutputs evaluates the outputs exported by a rule against the rule context.
+func EvaluateRuleOutputs(log logr.Logger, rule kyvernov1.Rule, jsonContext enginecontext.Interface) (map[string]interface{}, error) {
+	if len(rule.Outputs) == 0 {
+		return nil, nil
+	}
+	outputs := make(map[string]interface{}, len(rule.Outputs))
+	for _, output := range rule.Outputs {
+		path, err := variables.SubstituteAll(log, jsonContext, output.JMESPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to substitute variables in output %s: %w", output.Name, err)
+		}
+		jmesPath, ok := path.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid JMESPath %v for output %s", path, output.Name)
+		}
+		value, err := jsonContext.Query(jmesPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate output %s: %w", output.Name, err)
+		}
+		outputs[output.Name] = value
+	}
+	return outputs, nil
+}
+
+// AddRuleOutputs adds the outputs exported by a rule to the context as `outputs.<name>`.
+func AddRuleOutputs(jsonContext enginecontext.Interface, outputs map[string]interface{}) error {
+	for name, value := range outputs {
+		if err := jsonContext.AddVariable("outputs."+name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -26,6 +26,9 @@ type FunctionEntry struct {
 	gojmespath.FunctionEntry
 	Note       string
 	ReturnType []jpType
+	// NonDeterministic is true when the result of the function depends on something else than its arguments,
+	// like the current time, randomness or external data
+	NonDeterministic bool
 }
 
 func (f FunctionEntry) String() string {
@@ -29,6 +29,7 @@ import (
 	"github.com/kyverno/kyverno/ext/wildcard"
 	"github.com/kyverno/kyverno/pkg/config"
 	imageutils "github.com/kyverno/kyverno/pkg/utils/image"
+	semverutils "github.com/kyverno/kyverno/pkg/utils/semver"
 	regen "github.com/zach-klippenstein/goregen"
 	"golang.org/x/crypto/cryptobyte"
 	cryptobyte_asn1 "golang.org/x/crypto/cryptobyte/asn1"
@@ -69,6 +70,7 @@ var (
 	pathCanonicalize       = "path_canonicalize"
 	truncate               = "truncate"
 	semverCompare          = "semver_compare"
+	semverSatisfies        = "semver_satisfies"
 	parseJson              = "parse_json"
 	parseYAML              = "parse_yaml"
 	lookup                 = "lookup"
@@ -81,7 +83,7 @@ var (
 	SHA256                 = "sha256"
 )
 
-func GetFunctions(configuration config.Configuration) []FunctionEntry {
+func getKyvernoFunctions(configuration config.Configuration) []FunctionEntry {
 	return []FunctionEntry{{
 		FunctionEntry: gojmespath.FunctionEntry{
 			Name: compare,
@@ -356,22 +358,25 @@ func GetFunctions(configuration config.Configuration) []FunctionEntry {
 			},
 			Handler: jpTimeSince,
 		},
-		ReturnType: []jpType{jpString},
-		Note:       "calculate the difference between a start and end period of time where the end may either be a static definition or the then-current time",
+		ReturnType:       []jpType{jpString},
+		Note:             "calculate the difference between a start and end period of time where the end may either be a static definition or the then-current time",
+		NonDeterministic: true,
 	}, {
 		FunctionEntry: gojmespath.FunctionEntry{
 			Name:    timeNow,
 			Handler: jpTimeNow,
 		},
-		ReturnType: []jpType{jpString},
-		Note:       "returns current time in RFC 3339 format",
+		ReturnType:       []jpType{jpString},
+		Note:             "returns current time in RFC 3339 format",
+		NonDeterministic: true,
 	}, {
 		FunctionEntry: gojmespath.FunctionEntry{
 			Name:    timeNowUtc,
 			Handler: jpTimeNowUtc,
 		},
-		ReturnType: []jpType{jpString},
-		Note:       "returns current UTC time in RFC 3339 format",
+		ReturnType:       []jpType{jpString},
+		Note:             "returns current UTC time in RFC 3339 format",
+		NonDeterministic: true,
 	}, {
 		FunctionEntry: gojmespath.FunctionEntry{
 			Name: pathCanonicalize,
@@ -404,6 +409,17 @@ func GetFunctions(configuration config.Configuration) []FunctionEntry {
 		},
 		ReturnType: []jpType{jpBool},
 		Note:       "compares two strings which comply with the semantic versioning schema and outputs a boolean response as to the position of the second relative to the first",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: semverSatisfies,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpSemverSatisfies,
+		},
+		ReturnType: []jpType{jpBool},
+		Note:       "checks if a version satisfies a range like '>=1.25 <1.30', a leading 'v' and partial versions are accepted",
 	}, {
 		FunctionEntry: gojmespath.FunctionEntry{
 			Name: parseJson,
@@ -466,8 +482,9 @@ func GetFunctions(configuration config.Configuration) []FunctionEntry {
 			},
 			Handler: jpRandom,
 		},
-		ReturnType: []jpType{jpString},
-		Note:       "Generates a random sequence of characters",
+		ReturnType:       []jpType{jpString},
+		Note:             "Generates a random sequence of characters",
+		NonDeterministic: true,
 	}, {
 		FunctionEntry: gojmespath.FunctionEntry{
 			Name: x509_decode,
@@ -606,6 +623,102 @@ func GetFunctions(configuration config.Configuration) []FunctionEntry {
 		},
 		ReturnType: []jpType{jpString},
 		Note:       "generate unique resources name if length exceeds the limit",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: jwtDecode,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpJwtDecode,
+		},
+		ReturnType: []jpType{jpObject},
+		Note:       "decodes a JSON Web Token to an object with the header, payload and signature, the signature is not verified",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: jwtVerify,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpJwtVerify,
+		},
+		ReturnType:       []jpType{jpBool},
+		Note:             "verifies the signature of a JSON Web Token with a JWKS, a JWK or a PEM encoded public key, returns false if the signature is invalid or the token is expired",
+		NonDeterministic: true,
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: cidrContains,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpCidrContains,
+		},
+		ReturnType: []jpType{jpBool},
+		Note:       "checks if a CIDR contains an IP address or another CIDR",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: cidrOverlaps,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpCidrOverlaps,
+		},
+		ReturnType: []jpType{jpBool},
+		Note:       "checks if two CIDRs have at least one address in common",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: cidrExpand,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpCidrExpand,
+		},
+		ReturnType: []jpType{jpArray},
+		Note:       "returns the list of IP addresses in a CIDR, the CIDR must not contain more than 65536 addresses",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: cidrSize,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpCidrSize,
+		},
+		ReturnType: []jpType{jpNumber},
+		Note:       "returns the number of IP addresses in a CIDR",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: ipNormalize,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpIPNormalize,
+		},
+		ReturnType: []jpType{jpString},
+		Note:       "returns the canonical form of an IP address or CIDR, IPv6 addresses are compressed and IPv4-mapped addresses are unmapped",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: x509DecodeBundle,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpX509DecodeBundle,
+		},
+		ReturnType: []jpType{jpArray},
+		Note:       "decodes all the x.509 certificates of a PEM bundle, each certificate exposes its subject, issuer, validity, SANs, key usages and algorithms",
+	}, {
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: x509VerifyChain,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+				{Types: []jpType{jpString}},
+			},
+			Handler: jpX509VerifyChain,
+		},
+		ReturnType:       []jpType{jpBool},
+		Note:             "verifies a PEM bundle, the leaf certificate first followed by intermediates, against the CA certificates of a second PEM bundle",
+		NonDeterministic: true,
 	}}
 }
 
@@ -981,6 +1094,22 @@ func jpTruncate(arguments []interface{}) (interface{}, error) {
 	return trunc.Truncator(str.String(), int(normalizedLength), trunc.CutStrategy{}), nil
 }
 
+func jpSemverSatisfies(arguments []interface{}) (interface{}, error) {
+	v, err := validateArg(semverSatisfies, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	r, err := validateArg(semverSatisfies, arguments, 1, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	satisfied, err := semverutils.Satisfies(v.String(), r.String())
+	if err != nil {
+		return nil, formatError(genericError, semverSatisfies, err.Error())
+	}
+	return satisfied, nil
+}
+
 func jpSemverCompare(arguments []interface{}) (interface{}, error) {
 	var err error
 	v, err := validateArg(semverCompare, arguments, 0, reflect.String)
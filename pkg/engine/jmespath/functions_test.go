@@ -846,6 +846,43 @@ func Test_SemverCompare(t *testing.T) {
 	}
 }
 
+func Test_SemverSatisfies(t *testing.T) {
+	testCases := []struct {
+		jmesPath       string
+		expectedResult bool
+	}{
+		{
+			jmesPath:       "semver_satisfies('v1.27.3','>=1.25 <1.30')",
+			expectedResult: true,
+		},
+		{
+			jmesPath:       "semver_satisfies('1.30','>=1.25 <1.30')",
+			expectedResult: false,
+		},
+		{
+			jmesPath:       "semver_satisfies('v2.1','<2.0 || >=2.1')",
+			expectedResult: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.jmesPath, func(t *testing.T) {
+			jp, err := jmespathInterface.Query(tc.jmesPath)
+			assert.NilError(t, err)
+
+			result, err := jp.Search("")
+			assert.NilError(t, err)
+
+			res, ok := result.(bool)
+			assert.Assert(t, ok)
+			assert.Equal(t, res, tc.expectedResult)
+		})
+	}
+	jp, err := jmespathInterface.Query("semver_satisfies('latest','>=1.25')")
+	assert.NilError(t, err)
+	_, err = jp.Search("")
+	assert.ErrorContains(t, err, semverSatisfies)
+}
+
 func Test_Lookup(t *testing.T) {
 	testCases := []struct {
 		collection     string
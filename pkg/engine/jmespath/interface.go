@@ -1,6 +1,9 @@
 package jmespath
 
 import (
+	"sync"
+	"sync/atomic"
+
 	gojmespath "github.com/kyverno/go-jmespath"
 	"github.com/kyverno/kyverno/pkg/config"
 )
@@ -12,10 +15,29 @@ type Query interface {
 type Interface interface {
 	Query(string) (Query, error)
 	Search(string, interface{}) (interface{}, error)
+	// Register registers additional functions in the registry shared by all implementations, see RegisterFunctions.
+	// It returns an error if a function name is already in use.
+	Register(...FunctionEntry) error
+	// Functions returns the functions available to queries.
+	Functions() []FunctionEntry
 }
 
 type implementation struct {
-	functionCaller *gojmespath.FunctionCaller
+	configuration config.Configuration
+	callers       *callers
+	limits        limits
+}
+
+// callers holds the function caller of an implementation, it is rebuilt when functions are registered
+// so that callers used by running queries are never modified
+type callers struct {
+	sync.Mutex
+	current atomic.Pointer[versionedCaller]
+}
+
+type versionedCaller struct {
+	version uint64
+	caller  *gojmespath.FunctionCaller
 }
 
 func New(configuration config.Configuration) Interface {
@@ -23,9 +45,37 @@ func New(configuration config.Configuration) Interface {
 }
 
 func (i implementation) Query(query string) (Query, error) {
-	return newJMESPath(query, i.functionCaller)
+	return newJMESPath(query, i.functionCaller(), i.limits)
 }
 
 func (i implementation) Search(query string, data interface{}) (interface{}, error) {
-	return newExecution(i.functionCaller, query, data)
+	return newExecution(i.functionCaller(), query, data, i.limits)
+}
+
+func (i implementation) Register(entries ...FunctionEntry) error {
+	return RegisterFunctions(entries...)
+}
+
+func (i implementation) Functions() []FunctionEntry {
+	return GetFunctions(i.configuration)
+}
+
+// functionCaller returns a caller knowing all the functions of the registry
+func (i implementation) functionCaller() *gojmespath.FunctionCaller {
+	version := registryVersion()
+	if current := i.callers.current.Load(); current != nil && current.version == version {
+		return current.caller
+	}
+	i.callers.Lock()
+	defer i.callers.Unlock()
+	if current := i.callers.current.Load(); current != nil && current.version == version {
+		return current.caller
+	}
+	functions, version := getFunctions(i.configuration)
+	caller := gojmespath.NewFunctionCaller()
+	for _, f := range functions {
+		caller.Register(f.FunctionEntry)
+	}
+	i.callers.current.Store(&versionedCaller{version: version, caller: caller})
+	return caller
 }
@@ -0,0 +1,162 @@
+package jmespath
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+)
+
+// function names
+var (
+	jwtDecode = "jwt_decode"
+	jwtVerify = "jwt_verify"
+)
+
+// jwtSignatureAlgorithms are the asymmetric algorithms accepted by jwt_verify
+var jwtSignatureAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+func decodeJWTSegment(segment string) (map[string]interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(segment, "="))
+	if err != nil {
+		return nil, err
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func jpJwtDecode(arguments []interface{}) (interface{}, error) {
+	token, err := validateArg(jwtDecode, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	parts := strings.Split(strings.TrimSpace(token.String()), ".")
+	if len(parts) != 3 {
+		return nil, formatError(genericError, jwtDecode, "token must have three segments")
+	}
+	header, err := decodeJWTSegment(parts[0])
+	if err != nil {
+		return nil, formatError(genericError, jwtDecode, fmt.Sprintf("failed to decode header: %s", err))
+	}
+	payload, err := decodeJWTSegment(parts[1])
+	if err != nil {
+		return nil, formatError(genericError, jwtDecode, fmt.Sprintf("failed to decode payload: %s", err))
+	}
+	return map[string]interface{}{
+		"header":    header,
+		"payload":   payload,
+		"signature": parts[2],
+	}, nil
+}
+
+// parseJWTKeys parses a JSON Web Key Set, a single JSON Web Key or a PEM encoded public key or certificate
+func parseJWTKeys(input string) ([]jose.JSONWebKey, error) {
+	input = strings.TrimSpace(input)
+	if block, _ := pem.Decode([]byte(input)); block != nil {
+		switch block.Type {
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			return []jose.JSONWebKey{{Key: cert.PublicKey}}, nil
+		case "PUBLIC KEY":
+			key, err := x509.ParsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			return []jose.JSONWebKey{{Key: key}}, nil
+		default:
+			return nil, fmt.Errorf("unsupported PEM block type %s", block.Type)
+		}
+	}
+	var jwks jose.JSONWebKeySet
+	if err := json.Unmarshal([]byte(input), &jwks); err == nil && len(jwks.Keys) > 0 {
+		return jwks.Keys, nil
+	}
+	var jwk jose.JSONWebKey
+	if err := json.Unmarshal([]byte(input), &jwk); err != nil {
+		return nil, err
+	}
+	return []jose.JSONWebKey{jwk}, nil
+}
+
+// checkJWTTimeClaims returns an error if the token is expired or not valid yet
+func checkJWTTimeClaims(payload []byte, now time.Time) error {
+	var claims struct {
+		Expiry    *json.Number `json:"exp,omitempty"`
+		NotBefore *json.Number `json:"nbf,omitempty"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return err
+	}
+	if claims.Expiry != nil {
+		exp, err := claims.Expiry.Float64()
+		if err != nil {
+			return err
+		}
+		if now.After(time.Unix(int64(exp), 0)) {
+			return errors.New("token is expired")
+		}
+	}
+	if claims.NotBefore != nil {
+		nbf, err := claims.NotBefore.Float64()
+		if err != nil {
+			return err
+		}
+		if now.Before(time.Unix(int64(nbf), 0)) {
+			return errors.New("token is not valid yet")
+		}
+	}
+	return nil
+}
+
+func jpJwtVerify(arguments []interface{}) (interface{}, error) {
+	token, err := validateArg(jwtVerify, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	keyArg, err := validateArg(jwtVerify, arguments, 1, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	jws, err := jose.ParseSigned(strings.TrimSpace(token.String()), jwtSignatureAlgorithms)
+	if err != nil {
+		return nil, formatError(genericError, jwtVerify, fmt.Sprintf("failed to parse token: %s", err))
+	}
+	keys, err := parseJWTKeys(keyArg.String())
+	if err != nil {
+		return nil, formatError(genericError, jwtVerify, fmt.Sprintf("failed to parse keys: %s", err))
+	}
+	var kid string
+	if len(jws.Signatures) > 0 {
+		kid = jws.Signatures[0].Header.KeyID
+	}
+	for _, key := range keys {
+		// when both the token and the key carry a key id they must match
+		if kid != "" && key.KeyID != "" && kid != key.KeyID {
+			continue
+		}
+		payload, err := jws.Verify(key.Key)
+		if err != nil {
+			continue
+		}
+		return checkJWTTimeClaims(payload, time.Now()) == nil, nil
+	}
+	return false, nil
+}
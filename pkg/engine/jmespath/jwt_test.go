@@ -0,0 +1,124 @@
+package jmespath
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"gotest.tools/assert"
+)
+
+func signJWT(t *testing.T, key *ecdsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	opts := (&jose.SignerOptions{}).WithType("JWT")
+	if kid != "" {
+		opts = opts.WithHeader(jose.HeaderKey("kid"), kid)
+	}
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key}, opts)
+	assert.NilError(t, err)
+	payload, err := json.Marshal(claims)
+	assert.NilError(t, err)
+	jws, err := signer.Sign(payload)
+	assert.NilError(t, err)
+	token, err := jws.CompactSerialize()
+	assert.NilError(t, err)
+	return token
+}
+
+func Test_JwtDecode(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	token := signJWT(t, key, "key-1", map[string]interface{}{"iss": "https://kubernetes.default.svc", "aud": []string{"vault"}})
+
+	query, err := jmespathInterface.Query("jwt_decode('" + token + "')")
+	assert.NilError(t, err)
+	res, err := query.Search("")
+	assert.NilError(t, err)
+	result, ok := res.(map[string]interface{})
+	assert.Assert(t, ok)
+	assert.Equal(t, result["header"].(map[string]interface{})["kid"], "key-1")
+	assert.Equal(t, result["header"].(map[string]interface{})["alg"], "ES256")
+	assert.Equal(t, result["payload"].(map[string]interface{})["iss"], "https://kubernetes.default.svc")
+	assert.DeepEqual(t, result["payload"].(map[string]interface{})["aud"], []interface{}{"vault"})
+
+	_, err = jpJwtDecode([]interface{}{"not-a-token"})
+	assert.ErrorContains(t, err, "three segments")
+}
+
+func Test_JwtVerify(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.NilError(t, err)
+	pemKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	jwks, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+		{Key: &other.PublicKey, KeyID: "key-0", Algorithm: string(jose.ES256), Use: "sig"},
+		{Key: &key.PublicKey, KeyID: "key-1", Algorithm: string(jose.ES256), Use: "sig"},
+	}})
+	assert.NilError(t, err)
+	jwk, err := json.Marshal(jose.JSONWebKey{Key: &key.PublicKey, Algorithm: string(jose.ES256), Use: "sig"})
+	assert.NilError(t, err)
+
+	now := time.Now()
+	valid := signJWT(t, key, "key-1", map[string]interface{}{"exp": now.Add(time.Hour).Unix(), "nbf": now.Add(-time.Hour).Unix()})
+	expired := signJWT(t, key, "key-1", map[string]interface{}{"exp": now.Add(-time.Hour).Unix()})
+	notYetValid := signJWT(t, key, "", map[string]interface{}{"nbf": now.Add(time.Hour).Unix()})
+	wrongKey := signJWT(t, other, "key-1", map[string]interface{}{"iss": "test"})
+
+	testCases := []struct {
+		name  string
+		token string
+		key   string
+		want  bool
+	}{{
+		name:  "pem public key",
+		token: valid,
+		key:   pemKey,
+		want:  true,
+	}, {
+		name:  "jwks",
+		token: valid,
+		key:   string(jwks),
+		want:  true,
+	}, {
+		name:  "single jwk",
+		token: valid,
+		key:   string(jwk),
+		want:  true,
+	}, {
+		name:  "expired",
+		token: expired,
+		key:   pemKey,
+		want:  false,
+	}, {
+		name:  "not yet valid",
+		token: notYetValid,
+		key:   string(jwks),
+		want:  false,
+	}, {
+		name:  "key id mismatch",
+		token: wrongKey,
+		key:   string(jwks),
+		want:  false,
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := jpJwtVerify([]interface{}{tc.token, tc.key})
+			assert.NilError(t, err)
+			assert.Equal(t, res, tc.want)
+		})
+	}
+
+	_, err = jpJwtVerify([]interface{}{valid, "not a key"})
+	assert.ErrorContains(t, err, "failed to parse keys")
+	_, err = jpJwtVerify([]interface{}{"not-a-token", pemKey})
+	assert.ErrorContains(t, err, "failed to parse token")
+}
@@ -0,0 +1,76 @@
+package jmespath
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/kyverno/kyverno/pkg/config"
+)
+
+// limits enforces the JMESPath limits of the configuration, zero values mean unlimited
+type limits struct {
+	configuration config.Configuration
+}
+
+func (l limits) checkQuery(query string) error {
+	if l.configuration == nil {
+		return nil
+	}
+	if max := l.configuration.GetMaxJMESPathDepth(); max > 0 {
+		if depth := expressionDepth(query); int64(depth) > max {
+			return fmt.Errorf("JMESPath expression %s has a depth of %d, exceeding the limit of %d", query, depth, max)
+		}
+	}
+	return nil
+}
+
+func (l limits) checkResult(query string, result interface{}) error {
+	if l.configuration == nil {
+		return nil
+	}
+	if max := l.configuration.GetMaxJMESPathResultSize(); max > 0 {
+		data, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		if size := len(data); int64(size) > max {
+			return fmt.Errorf("result of JMESPath expression %s has a size of %d bytes, exceeding the limit of %d bytes", query, size, max)
+		}
+	}
+	return nil
+}
+
+// expressionDepth returns the maximum nesting of brackets, parentheses and braces in an expression,
+// literals and quoted identifiers are ignored
+func expressionDepth(query string) int {
+	depth, maxDepth := 0, 0
+	var quote rune
+	escaped := false
+	for _, c := range query {
+		if quote != 0 {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == quote:
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '\'', '"', '`':
+			quote = c
+		case '(', '[', '{':
+			depth++
+			if depth > maxDepth {
+				maxDepth = depth
+			}
+		case ')', ']', '}':
+			if depth > 0 {
+				depth--
+			}
+		}
+	}
+	return maxDepth
+}
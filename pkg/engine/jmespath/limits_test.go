@@ -0,0 +1,60 @@
+package jmespath
+
+import (
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/config"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_expressionDepth(t *testing.T) {
+	testCases := []struct {
+		query string
+		depth int
+	}{
+		{query: "request.object.metadata.name", depth: 0},
+		{query: "request.object.spec.containers[].image", depth: 1},
+		{query: "length(request.object.spec.containers[?name == 'nginx'])", depth: 2},
+		{query: "{a: to_string(values(@)[0])}", depth: 3},
+		{query: "contains('((([[[', `\"[{\"`)", depth: 1},
+		{query: `"quoted(identifier"[0]`, depth: 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			assert.Equal(t, expressionDepth(tc.query), tc.depth)
+		})
+	}
+}
+
+func Test_Limits(t *testing.T) {
+	cfg := config.NewDefaultConfiguration(false)
+	cfg.Load(&corev1.ConfigMap{Data: map[string]string{
+		"maxJMESPathDepth":      "2",
+		"maxJMESPathResultSize": "10",
+	}})
+	jp := New(cfg)
+	data := map[string]interface{}{
+		"short": []interface{}{"a", "b"},
+		"long":  "0123456789",
+	}
+
+	result, err := jp.Search("length(short[?@ == 'a'])", data)
+	assert.NilError(t, err)
+	assert.Equal(t, result, 1.0)
+
+	_, err = jp.Search("length(short[?contains(@, 'a')])", data)
+	assert.Error(t, err, "JMESPath expression length(short[?contains(@, 'a')]) has a depth of 3, exceeding the limit of 2")
+
+	_, err = jp.Query("length(short[?contains(@, 'a')])")
+	assert.Error(t, err, "JMESPath expression length(short[?contains(@, 'a')]) has a depth of 3, exceeding the limit of 2")
+
+	query, err := jp.Query("long")
+	assert.NilError(t, err)
+	_, err = query.Search(data)
+	assert.Error(t, err, "result of JMESPath expression long has a size of 12 bytes, exceeding the limit of 10 bytes")
+
+	cfg.Load(nil)
+	_, err = query.Search(data)
+	assert.NilError(t, err)
+}
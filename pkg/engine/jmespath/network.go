@@ -0,0 +1,122 @@
+package jmespath
+
+import (
+	"fmt"
+	"math/big"
+	"net/netip"
+	"reflect"
+	"strings"
+)
+
+// function names
+var (
+	cidrContains = "cidr_contains"
+	cidrOverlaps = "cidr_overlaps"
+	cidrExpand   = "cidr_expand"
+	cidrSize     = "cidr_size"
+	ipNormalize  = "ip_normalize"
+)
+
+// maxCidrExpand is the maximum number of addresses returned by cidr_expand
+const maxCidrExpand = 65536
+
+// parsePrefix parses a CIDR, a single IP address is treated as a host prefix
+func parsePrefix(f string, value string) (netip.Prefix, error) {
+	value = strings.TrimSpace(value)
+	if !strings.Contains(value, "/") {
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return netip.Prefix{}, formatError(genericError, f, err.Error())
+		}
+		addr = addr.Unmap()
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+	prefix, err := netip.ParsePrefix(value)
+	if err != nil {
+		return netip.Prefix{}, formatError(genericError, f, err.Error())
+	}
+	if prefix.Addr().Is4In6() && prefix.Bits() >= 96 {
+		prefix = netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()-96)
+	}
+	return prefix.Masked(), nil
+}
+
+func getPrefixArg(f string, arguments []interface{}, index int) (netip.Prefix, error) {
+	arg, err := validateArg(f, arguments, index, reflect.String)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return parsePrefix(f, arg.String())
+}
+
+func jpCidrContains(arguments []interface{}) (interface{}, error) {
+	cidr, err := getPrefixArg(cidrContains, arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	other, err := getPrefixArg(cidrContains, arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+	return cidr.Bits() <= other.Bits() && cidr.Contains(other.Addr()), nil
+}
+
+func jpCidrOverlaps(arguments []interface{}) (interface{}, error) {
+	a, err := getPrefixArg(cidrOverlaps, arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	b, err := getPrefixArg(cidrOverlaps, arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+	return a.Overlaps(b), nil
+}
+
+func prefixSize(prefix netip.Prefix) *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), uint(prefix.Addr().BitLen()-prefix.Bits()))
+}
+
+func jpCidrExpand(arguments []interface{}) (interface{}, error) {
+	prefix, err := getPrefixArg(cidrExpand, arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	if size := prefixSize(prefix); size.Cmp(big.NewInt(int64(maxCidrExpand))) > 0 {
+		return nil, formatError(genericError, cidrExpand, fmt.Sprintf("range %s has %s addresses, the maximum is %d", prefix, size, maxCidrExpand))
+	}
+	var out []interface{}
+	for addr := prefix.Addr(); addr.IsValid() && prefix.Contains(addr); addr = addr.Next() {
+		out = append(out, addr.String())
+	}
+	return out, nil
+}
+
+func jpCidrSize(arguments []interface{}) (interface{}, error) {
+	prefix, err := getPrefixArg(cidrSize, arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	size, _ := new(big.Float).SetInt(prefixSize(prefix)).Float64()
+	return size, nil
+}
+
+func jpIPNormalize(arguments []interface{}) (interface{}, error) {
+	arg, err := validateArg(ipNormalize, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	value := strings.TrimSpace(arg.String())
+	if strings.Contains(value, "/") {
+		prefix, err := parsePrefix(ipNormalize, value)
+		if err != nil {
+			return nil, err
+		}
+		return prefix.String(), nil
+	}
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return nil, formatError(genericError, ipNormalize, err.Error())
+	}
+	return addr.Unmap().WithZone("").String(), nil
+}
@@ -0,0 +1,52 @@
+package jmespath
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func Test_NetworkFunctions(t *testing.T) {
+	testCases := []struct {
+		test           string
+		expectedResult interface{}
+		err            bool
+	}{
+		{test: "cidr_contains('10.0.0.0/8', '10.1.2.3')", expectedResult: true},
+		{test: "cidr_contains('10.0.0.0/8', '11.1.2.3')", expectedResult: false},
+		{test: "cidr_contains('10.0.0.0/8', '10.1.0.0/16')", expectedResult: true},
+		{test: "cidr_contains('10.1.0.0/16', '10.0.0.0/8')", expectedResult: false},
+		{test: "cidr_contains('2001:db8::/32', '2001:db8:0:1::5')", expectedResult: true},
+		{test: "cidr_contains('10.0.0.0/8', '::ffff:10.0.0.1')", expectedResult: true},
+		{test: "cidr_contains('10.0.0.0/8', '2001:db8::1')", expectedResult: false},
+		{test: "cidr_contains('10.0.0.0/33', '10.0.0.1')", err: true},
+		{test: "cidr_overlaps('10.0.0.0/16', '10.0.128.0/17')", expectedResult: true},
+		{test: "cidr_overlaps('10.0.0.0/16', '10.1.0.0/16')", expectedResult: false},
+		{test: "cidr_overlaps('192.168.1.7', '192.168.1.0/24')", expectedResult: true},
+		{test: "cidr_size('10.0.0.0/24')", expectedResult: 256.0},
+		{test: "cidr_size('10.0.0.1')", expectedResult: 1.0},
+		{test: "cidr_size('2001:db8::/64')", expectedResult: 18446744073709551616.0},
+		{test: "cidr_expand('192.168.0.4/30')", expectedResult: []interface{}{"192.168.0.4", "192.168.0.5", "192.168.0.6", "192.168.0.7"}},
+		{test: "cidr_expand('192.168.0.9/30')", expectedResult: []interface{}{"192.168.0.8", "192.168.0.9", "192.168.0.10", "192.168.0.11"}},
+		{test: "cidr_expand('2001:db8::/126')", expectedResult: []interface{}{"2001:db8::", "2001:db8::1", "2001:db8::2", "2001:db8::3"}},
+		{test: "cidr_expand('10.0.0.0/8')", err: true},
+		{test: "ip_normalize('2001:0DB8:0000:0000:0000:0000:0000:0001')", expectedResult: "2001:db8::1"},
+		{test: "ip_normalize('::ffff:192.168.0.1')", expectedResult: "192.168.0.1"},
+		{test: "ip_normalize('fe80::1%eth0')", expectedResult: "fe80::1"},
+		{test: "ip_normalize('2001:DB8:0:0:1::5/64')", expectedResult: "2001:db8::/64"},
+		{test: "ip_normalize('not-an-ip')", err: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.test, func(t *testing.T) {
+			query, err := jmespathInterface.Query(tc.test)
+			assert.NilError(t, err)
+			res, err := query.Search("")
+			if tc.err {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, res, tc.expectedResult)
+		})
+	}
+}
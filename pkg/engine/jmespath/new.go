@@ -6,37 +6,57 @@ import (
 )
 
 type QueryProxy struct {
+	query          string
 	jmesPath       *gojmespath.JMESPath
 	functionCaller *gojmespath.FunctionCaller
+	limits         limits
 }
 
 func (q *QueryProxy) Search(data interface{}) (interface{}, error) {
-	return q.jmesPath.Search(data, gojmespath.WithFunctionCaller(q.functionCaller))
+	result, err := q.jmesPath.Search(data, gojmespath.WithFunctionCaller(q.functionCaller))
+	if err != nil {
+		return nil, err
+	}
+	if err := q.limits.checkResult(q.query, result); err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
-func newJMESPath(query string, functionCaller *gojmespath.FunctionCaller) (*QueryProxy, error) {
+func newJMESPath(query string, functionCaller *gojmespath.FunctionCaller, limits limits) (*QueryProxy, error) {
+	if err := limits.checkQuery(query); err != nil {
+		return nil, err
+	}
 	jmesPath, err := gojmespath.Compile(query)
 	if err != nil {
 		return nil, err
 	}
 	return &QueryProxy{
-		jmesPath,
-		functionCaller,
+		query:          query,
+		jmesPath:       jmesPath,
+		functionCaller: functionCaller,
+		limits:         limits,
 	}, nil
 }
 
 func newImplementation(configuration config.Configuration) Interface {
-	functionCaller := gojmespath.NewFunctionCaller()
-	functions := GetFunctions(configuration)
-	for _, f := range functions {
-		functionCaller.Register(f.FunctionEntry)
-	}
-
 	return implementation{
-		functionCaller,
+		configuration: configuration,
+		callers:       &callers{},
+		limits:        limits{configuration: configuration},
 	}
 }
 
-func newExecution(fCall *gojmespath.FunctionCaller, query string, data interface{}) (interface{}, error) {
-	return gojmespath.Search(query, data, gojmespath.WithFunctionCaller(fCall))
+func newExecution(fCall *gojmespath.FunctionCaller, query string, data interface{}, limits limits) (interface{}, error) {
+	if err := limits.checkQuery(query); err != nil {
+		return nil, err
+	}
+	result, err := gojmespath.Search(query, data, gojmespath.WithFunctionCaller(fCall))
+	if err != nil {
+		return nil, err
+	}
+	if err := limits.checkResult(query, result); err != nil {
+		return nil, err
+	}
+	return result, nil
 }
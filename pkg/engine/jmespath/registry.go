@@ -0,0 +1,106 @@
+package jmespath
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/kyverno/kyverno/pkg/config"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// builtinFunctions are the functions defined by the JMESPath specification
+var builtinFunctions = sets.New(
+	"abs", "avg", "ceil", "contains", "ends_with", "floor", "join", "keys", "length", "map", "max", "max_by",
+	"merge", "min", "min_by", "not_null", "reverse", "sort", "sort_by", "starts_with", "sum", "to_array",
+	"to_number", "to_string", "type", "values",
+)
+
+// functionCall matches the name of a function called in an expression
+var functionCall = regexp.MustCompile(`([a-zA-Z_][a-zA-Z0-9_]*)\s*\(`)
+
+// registry holds the functions registered on top of the Kyverno functions, it is shared by all implementations
+// and its version changes every time functions are registered
+var registry struct {
+	sync.RWMutex
+	version   uint64
+	functions []FunctionEntry
+}
+
+// GetFunctions returns the Kyverno functions and the functions registered with RegisterFunctions.
+func GetFunctions(configuration config.Configuration) []FunctionEntry {
+	functions, _ := getFunctions(configuration)
+	return functions
+}
+
+// getFunctions returns the available functions along with the registry version they were read at
+func getFunctions(configuration config.Configuration) ([]FunctionEntry, uint64) {
+	functions := getKyvernoFunctions(configuration)
+	registry.RLock()
+	defer registry.RUnlock()
+	return append(functions, registry.functions...), registry.version
+}
+
+// NonDeterministicFunctions returns the names of the Kyverno and registered functions flagged as non deterministic.
+func NonDeterministicFunctions(configuration config.Configuration) sets.Set[string] {
+	names := sets.New[string]()
+	for _, function := range GetFunctions(configuration) {
+		if function.NonDeterministic {
+			names.Insert(function.Name)
+		}
+	}
+	return names
+}
+
+// FunctionCalls returns the names of the functions called in the given string, it can be a single expression
+// or a string holding several {{ }} variables. Raw string literals are not skipped, the result may contain
+// names that are not actually called.
+func FunctionCalls(in string) sets.Set[string] {
+	names := sets.New[string]()
+	for _, match := range functionCall.FindAllStringSubmatch(in, -1) {
+		names.Insert(match[1])
+	}
+	return names
+}
+
+// registryVersion returns the current version of the registry
+func registryVersion() uint64 {
+	registry.RLock()
+	defer registry.RUnlock()
+	return registry.version
+}
+
+// RegisterFunctions registers additional functions, they are available to all implementations
+// and listed by the CLI. It is meant to be called at startup by programs embedding Kyverno.
+func RegisterFunctions(functions ...FunctionEntry) error {
+	registry.Lock()
+	defer registry.Unlock()
+	existing := append(getKyvernoFunctions(nil), registry.functions...)
+	if err := checkFunctions(existing, functions...); err != nil {
+		return err
+	}
+	registry.functions = append(registry.functions, functions...)
+	registry.version++
+	return nil
+}
+
+// checkFunctions returns an error if a function is invalid or if its name collides with an existing function.
+func checkFunctions(existing []FunctionEntry, functions ...FunctionEntry) error {
+	names := sets.New[string]()
+	for _, function := range existing {
+		names.Insert(function.Name)
+	}
+	for _, function := range functions {
+		if function.Name == "" {
+			return fmt.Errorf("function name must not be empty")
+		}
+		if function.Handler == nil {
+			return fmt.Errorf("function %s must have a handler", function.Name)
+		}
+		if builtinFunctions.Has(function.Name) || names.Has(function.Name) {
+			return fmt.Errorf("function %s is already registered", function.Name)
+		}
+		names.Insert(function.Name)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package jmespath
+
+import (
+	"testing"
+
+	gojmespath "github.com/kyverno/go-jmespath"
+	"github.com/kyverno/kyverno/pkg/config"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func newTestFunction(name string) FunctionEntry {
+	return FunctionEntry{
+		FunctionEntry: gojmespath.FunctionEntry{
+			Name: name,
+			Arguments: []argSpec{
+				{Types: []jpType{jpString}},
+			},
+			Handler: func(arguments []interface{}) (interface{}, error) {
+				return "hello " + arguments[0].(string), nil
+			},
+		},
+		ReturnType: []jpType{jpString},
+		Note:       "greets the given name",
+	}
+}
+
+func Test_checkFunctions(t *testing.T) {
+	tests := []struct {
+		name      string
+		functions []FunctionEntry
+		wantErr   bool
+	}{{
+		name:      "valid",
+		functions: []FunctionEntry{newTestFunction("test_valid")},
+	}, {
+		name:      "empty name",
+		functions: []FunctionEntry{newTestFunction("")},
+		wantErr:   true,
+	}, {
+		name:      "no handler",
+		functions: []FunctionEntry{{FunctionEntry: gojmespath.FunctionEntry{Name: "test_no_handler"}}},
+		wantErr:   true,
+	}, {
+		name:      "builtin collision",
+		functions: []FunctionEntry{newTestFunction("length")},
+		wantErr:   true,
+	}, {
+		name:      "kyverno collision",
+		functions: []FunctionEntry{newTestFunction(truncate)},
+		wantErr:   true,
+	}, {
+		name:      "duplicate",
+		functions: []FunctionEntry{newTestFunction("test_duplicate"), newTestFunction("test_duplicate")},
+		wantErr:   true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkFunctions(getKyvernoFunctions(nil), tt.functions...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkFunctions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegisterFunctions(t *testing.T) {
+	assert.NilError(t, RegisterFunctions(newTestFunction("test_registered")))
+	assert.ErrorContains(t, RegisterFunctions(newTestFunction("test_registered")), "already registered")
+	jp := New(config.NewDefaultConfiguration(false))
+	result, err := jp.Search("test_registered('kyverno')", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, result, "hello kyverno")
+	var found bool
+	for _, function := range GetFunctions(nil) {
+		if function.Name == "test_registered" {
+			found = true
+		}
+	}
+	assert.Assert(t, found)
+}
+
+func Test_implementation_Register(t *testing.T) {
+	jp := New(config.NewDefaultConfiguration(false))
+	other := New(config.NewDefaultConfiguration(false))
+	// compile a query before registering to make sure the caller is refreshed
+	_, err := other.Search("to_upper('kyverno')", nil)
+	assert.NilError(t, err)
+	count := len(jp.Functions())
+	assert.NilError(t, jp.Register(newTestFunction("test_instance")))
+	assert.ErrorContains(t, jp.Register(newTestFunction("test_instance")), "already registered")
+	result, err := jp.Search("test_instance('kyverno')", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, result, "hello kyverno")
+	assert.Equal(t, len(jp.Functions()), count+1)
+	// the registry is shared by all implementations and listed by the CLI
+	result, err = other.Search("test_instance('kyverno')", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, result, "hello kyverno")
+	var found bool
+	for _, function := range GetFunctions(nil) {
+		if function.Name == "test_instance" {
+			found = true
+		}
+	}
+	assert.Assert(t, found)
+}
+
+func TestNonDeterministicFunctions(t *testing.T) {
+	function := newTestFunction("test_non_deterministic")
+	function.NonDeterministic = true
+	assert.NilError(t, RegisterFunctions(function))
+	functions := NonDeterministicFunctions(nil)
+	for _, name := range []string{timeNow, timeNowUtc, timeSince, random, jwtVerify, x509VerifyChain, "test_non_deterministic"} {
+		assert.Assert(t, functions.Has(name), name)
+	}
+	assert.Assert(t, !functions.Has(toUpper))
+}
+
+func TestFunctionCalls(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{in: "request.object.metadata.name"},
+		{in: "to_upper(request.object.metadata.name)", want: []string{"to_upper"}},
+		{in: "{{ time_now_utc() }} and {{ x509_verify_chain (a, b) }}", want: []string{"time_now_utc", "x509_verify_chain"}},
+		{in: "length(keys(request.object.metadata.labels))", want: []string{"keys", "length"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			assert.DeepEqual(t, sets.List(FunctionCalls(tt.in)), append([]string{}, tt.want...))
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package jmespath
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"reflect"
+	"time"
+)
+
+// function names
+var (
+	x509DecodeBundle = "x509_decode_bundle"
+	x509VerifyChain  = "x509_verify_chain"
+)
+
+var keyUsageNames = []struct {
+	usage x509.KeyUsage
+	name  string
+}{
+	{x509.KeyUsageDigitalSignature, "DigitalSignature"},
+	{x509.KeyUsageContentCommitment, "ContentCommitment"},
+	{x509.KeyUsageKeyEncipherment, "KeyEncipherment"},
+	{x509.KeyUsageDataEncipherment, "DataEncipherment"},
+	{x509.KeyUsageKeyAgreement, "KeyAgreement"},
+	{x509.KeyUsageCertSign, "CertSign"},
+	{x509.KeyUsageCRLSign, "CRLSign"},
+	{x509.KeyUsageEncipherOnly, "EncipherOnly"},
+	{x509.KeyUsageDecipherOnly, "DecipherOnly"},
+}
+
+var extKeyUsageNames = map[x509.ExtKeyUsage]string{
+	x509.ExtKeyUsageAny:                            "Any",
+	x509.ExtKeyUsageServerAuth:                     "ServerAuth",
+	x509.ExtKeyUsageClientAuth:                     "ClientAuth",
+	x509.ExtKeyUsageCodeSigning:                    "CodeSigning",
+	x509.ExtKeyUsageEmailProtection:                "EmailProtection",
+	x509.ExtKeyUsageIPSECEndSystem:                 "IPSECEndSystem",
+	x509.ExtKeyUsageIPSECTunnel:                    "IPSECTunnel",
+	x509.ExtKeyUsageIPSECUser:                      "IPSECUser",
+	x509.ExtKeyUsageTimeStamping:                   "TimeStamping",
+	x509.ExtKeyUsageOCSPSigning:                    "OCSPSigning",
+	x509.ExtKeyUsageMicrosoftServerGatedCrypto:     "MicrosoftServerGatedCrypto",
+	x509.ExtKeyUsageNetscapeServerGatedCrypto:      "NetscapeServerGatedCrypto",
+	x509.ExtKeyUsageMicrosoftCommercialCodeSigning: "MicrosoftCommercialCodeSigning",
+	x509.ExtKeyUsageMicrosoftKernelCodeSigning:     "MicrosoftKernelCodeSigning",
+}
+
+// parseCertificates parses all the CERTIFICATE blocks of a PEM bundle, other blocks are ignored
+func parseCertificates(f string, data string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, formatError(genericError, f, err.Error())
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, formatError(genericError, f, "no certificate found in PEM data")
+	}
+	return certs, nil
+}
+
+func certificateSummary(cert *x509.Certificate) map[string]interface{} {
+	keyUsages := []interface{}{}
+	for _, ku := range keyUsageNames {
+		if cert.KeyUsage&ku.usage != 0 {
+			keyUsages = append(keyUsages, ku.name)
+		}
+	}
+	extKeyUsages := []interface{}{}
+	for _, eku := range cert.ExtKeyUsage {
+		if name, ok := extKeyUsageNames[eku]; ok {
+			extKeyUsages = append(extKeyUsages, name)
+		}
+	}
+	toList := func(values []string) []interface{} {
+		out := []interface{}{}
+		for _, value := range values {
+			out = append(out, value)
+		}
+		return out
+	}
+	var ips, uris []string
+	for _, ip := range cert.IPAddresses {
+		ips = append(ips, ip.String())
+	}
+	for _, uri := range cert.URIs {
+		uris = append(uris, uri.String())
+	}
+	return map[string]interface{}{
+		"subject":            cert.Subject.String(),
+		"issuer":             cert.Issuer.String(),
+		"serialNumber":       cert.SerialNumber.String(),
+		"notBefore":          cert.NotBefore.UTC().Format(time.RFC3339),
+		"notAfter":           cert.NotAfter.UTC().Format(time.RFC3339),
+		"isCA":               cert.IsCA,
+		"dnsNames":           toList(cert.DNSNames),
+		"ipAddresses":        toList(ips),
+		"emailAddresses":     toList(cert.EmailAddresses),
+		"uris":               toList(uris),
+		"keyUsage":           keyUsages,
+		"extKeyUsage":        extKeyUsages,
+		"signatureAlgorithm": cert.SignatureAlgorithm.String(),
+		"publicKeyAlgorithm": cert.PublicKeyAlgorithm.String(),
+	}
+}
+
+func jpX509DecodeBundle(arguments []interface{}) (interface{}, error) {
+	input, err := validateArg(x509DecodeBundle, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	certs, err := parseCertificates(x509DecodeBundle, input.String())
+	if err != nil {
+		return nil, err
+	}
+	var out []interface{}
+	for _, cert := range certs {
+		out = append(out, certificateSummary(cert))
+	}
+	return out, nil
+}
+
+func jpX509VerifyChain(arguments []interface{}) (interface{}, error) {
+	input, err := validateArg(x509VerifyChain, arguments, 0, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	caInput, err := validateArg(x509VerifyChain, arguments, 1, reflect.String)
+	if err != nil {
+		return nil, err
+	}
+	certs, err := parseCertificates(x509VerifyChain, input.String())
+	if err != nil {
+		return nil, err
+	}
+	cas, err := parseCertificates(x509VerifyChain, caInput.String())
+	if err != nil {
+		return nil, err
+	}
+	roots := x509.NewCertPool()
+	for _, ca := range cas {
+		roots.AddCert(ca)
+	}
+	// the first certificate is the leaf, the next ones are intermediates
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err = certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	return err == nil, nil
+}
@@ -0,0 +1,116 @@
+package jmespath
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func newTestCertificate(t *testing.T, template *x509.Certificate, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	assert.NilError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NilError(t, err)
+	return cert, key, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func Test_X509Chain(t *testing.T) {
+	now := time.Now()
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "root-ca"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+	ca, caKey, caPEM := newTestCertificate(t, caTemplate, nil, nil)
+	intermediate, intermediateKey, intermediatePEM := newTestCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: "intermediate-ca"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, ca, caKey)
+	_, _, leafPEM := newTestCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "webhook.kyverno.svc"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(time.Hour),
+		DNSNames:     []string{"webhook.kyverno.svc", "webhook.kyverno.svc.cluster.local"},
+		IPAddresses:  []net.IP{net.ParseIP("10.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, intermediate, intermediateKey)
+	_, _, otherCAPEM := newTestCertificate(t, caTemplate, nil, nil)
+
+	res, err := jpX509DecodeBundle([]interface{}{leafPEM + intermediatePEM})
+	assert.NilError(t, err)
+	certs := res.([]interface{})
+	assert.Equal(t, len(certs), 2)
+	leaf := certs[0].(map[string]interface{})
+	assert.Equal(t, leaf["subject"], "CN=webhook.kyverno.svc")
+	assert.Equal(t, leaf["issuer"], "CN=intermediate-ca")
+	assert.Equal(t, leaf["isCA"], false)
+	assert.DeepEqual(t, leaf["dnsNames"], []interface{}{"webhook.kyverno.svc", "webhook.kyverno.svc.cluster.local"})
+	assert.DeepEqual(t, leaf["ipAddresses"], []interface{}{"10.0.0.1"})
+	assert.DeepEqual(t, leaf["keyUsage"], []interface{}{"DigitalSignature", "KeyEncipherment"})
+	assert.DeepEqual(t, leaf["extKeyUsage"], []interface{}{"ServerAuth"})
+	assert.Equal(t, leaf["signatureAlgorithm"], "ECDSA-SHA256")
+	assert.Equal(t, leaf["publicKeyAlgorithm"], "ECDSA")
+	assert.Equal(t, certs[1].(map[string]interface{})["isCA"], true)
+
+	_, err = jpX509DecodeBundle([]interface{}{"not a certificate"})
+	assert.ErrorContains(t, err, "no certificate found")
+
+	testCases := []struct {
+		name   string
+		bundle string
+		ca     string
+		want   bool
+	}{{
+		name:   "full chain",
+		bundle: leafPEM + intermediatePEM,
+		ca:     caPEM,
+		want:   true,
+	}, {
+		name:   "missing intermediate",
+		bundle: leafPEM,
+		ca:     caPEM,
+		want:   false,
+	}, {
+		name:   "intermediate as trust anchor",
+		bundle: leafPEM,
+		ca:     intermediatePEM,
+		want:   true,
+	}, {
+		name:   "other ca",
+		bundle: leafPEM + intermediatePEM,
+		ca:     otherCAPEM,
+		want:   false,
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := jpX509VerifyChain([]interface{}{tc.bundle, tc.ca})
+			assert.NilError(t, err)
+			assert.Equal(t, res, tc.want)
+		})
+	}
+}
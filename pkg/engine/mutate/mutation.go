@@ -12,6 +12,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/variables"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kustomize/kyaml/openapi"
 )
 
 type Response struct {
@@ -35,7 +36,7 @@ func NewErrorResponse(msg string, err error) *Response {
 	return NewResponse(engineapi.RuleStatusError, unstructured.Unstructured{}, msg)
 }
 
-func Mutate(rule *kyvernov1.Rule, ctx context.Interface, resource unstructured.Unstructured, logger logr.Logger) *Response {
+func Mutate(rule *kyvernov1.Rule, ctx context.Interface, resource unstructured.Unstructured, schema *openapi.ResourceSchema, logger logr.Logger) *Response {
 	updatedRule, err := variables.SubstituteAllInRule(logger, ctx, *rule)
 	if err != nil {
 		return NewErrorResponse("variable substitution failed", err)
@@ -44,7 +45,7 @@ func Mutate(rule *kyvernov1.Rule, ctx context.Interface, resource unstructured.U
 	if mutation == nil {
 		return NewErrorResponse("empty mutate rule", nil)
 	}
-	patcher := NewPatcher(mutation.GetPatchStrategicMerge(), mutation.PatchesJSON6902)
+	patcher := NewPatcher(mutation.GetPatchStrategicMerge(), mutation.PatchesJSON6902, schema)
 	if patcher == nil {
 		return NewErrorResponse("empty mutate rule", nil)
 	}
@@ -72,13 +73,13 @@ func Mutate(rule *kyvernov1.Rule, ctx context.Interface, resource unstructured.U
 	return NewResponse(engineapi.RuleStatusPass, *patchedResource, "resource patched")
 }
 
-func ForEach(name string, foreach kyvernov1.ForEachMutation, policyContext engineapi.PolicyContext, resource unstructured.Unstructured, element interface{}, logger logr.Logger) *Response {
+func ForEach(name string, foreach kyvernov1.ForEachMutation, policyContext engineapi.PolicyContext, resource unstructured.Unstructured, element interface{}, schema *openapi.ResourceSchema, logger logr.Logger) *Response {
 	ctx := policyContext.JSONContext()
 	fe, err := substituteAllInForEach(foreach, ctx, logger)
 	if err != nil {
 		return NewErrorResponse("variable substitution failed", err)
 	}
-	patcher := NewPatcher(fe["patchStrategicMerge"], fe["patchesJson6902"].(string))
+	patcher := NewPatcher(fe["patchStrategicMerge"], fe["patchesJson6902"].(string), schema)
 	if patcher == nil {
 		return NewErrorResponse("empty mutate rule", nil)
 	}
@@ -120,9 +121,9 @@ func substituteAllInForEach(fe kyvernov1.ForEachMutation, ctx context.Interface,
 	return typedMap, nil
 }
 
-func NewPatcher(strategicMergePatch apiextensions.JSON, jsonPatch string) patch.Patcher {
+func NewPatcher(strategicMergePatch apiextensions.JSON, jsonPatch string, schema *openapi.ResourceSchema) patch.Patcher {
 	if strategicMergePatch != nil {
-		return patch.NewPatchStrategicMerge(strategicMergePatch)
+		return patch.NewPatchStrategicMerge(strategicMergePatch, schema)
 	}
 	if len(jsonPatch) > 0 {
 		return patch.NewPatchesJSON6902(jsonPatch)
@@ -0,0 +1,88 @@
+package patch
+
+import (
+	"encoding/json"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"sigs.k8s.io/kustomize/kyaml/openapi"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+	"sigs.k8s.io/kustomize/kyaml/yaml/merge2"
+	"sigs.k8s.io/kustomize/kyaml/yaml/walk"
+)
+
+const (
+	listTypeExtension      = "x-kubernetes-list-type"
+	listMapKeysExtension   = "x-kubernetes-list-map-keys"
+	patchStrategyExtension = "x-kubernetes-patch-strategy"
+	patchMergeKeyExtension = "x-kubernetes-patch-merge-key"
+)
+
+// CustomResourceSchema converts the OpenAPI v3 schema of a custom resource definition to a schema
+// usable for strategic merge patches. Custom resource definitions declare list merge keys with
+// `x-kubernetes-list-type: map`, they are translated to the patch strategy extensions used by kustomize.
+func CustomResourceSchema(props *apiextensionsv1.JSONSchemaProps) (*openapi.ResourceSchema, error) {
+	if props == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(props)
+	if err != nil {
+		return nil, err
+	}
+	var schema spec.Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, err
+	}
+	addPatchExtensions(&schema)
+	return &openapi.ResourceSchema{Schema: &schema}, nil
+}
+
+func addPatchExtensions(schema *spec.Schema) {
+	if listType, ok := schema.Extensions.GetString(listTypeExtension); ok && listType == "map" {
+		if keys, ok := schema.Extensions.GetStringSlice(listMapKeysExtension); ok && len(keys) != 0 {
+			schema.AddExtension(patchStrategyExtension, "merge")
+			schema.AddExtension(patchMergeKeyExtension, keys[0])
+		}
+	}
+	for name, property := range schema.Properties {
+		addPatchExtensions(&property)
+		schema.Properties[name] = property
+	}
+	if schema.Items != nil {
+		if schema.Items.Schema != nil {
+			addPatchExtensions(schema.Items.Schema)
+		}
+		for i := range schema.Items.Schemas {
+			addPatchExtensions(&schema.Items.Schemas[i])
+		}
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		addPatchExtensions(schema.AdditionalProperties.Schema)
+	}
+}
+
+// schemaMergeFilter merges a patch like patchstrategicmerge.Filter does but resolves list merge keys
+// and patch strategies from the given schema instead of the built-in kubernetes schemas.
+type schemaMergeFilter struct {
+	patch  *yaml.RNode
+	schema *openapi.ResourceSchema
+}
+
+func (f schemaMergeFilter) Filter(nodes []*yaml.RNode) ([]*yaml.RNode, error) {
+	var result []*yaml.RNode
+	for i := range nodes {
+		r, err := walk.Walker{
+			Sources:      []*yaml.RNode{nodes[i], f.patch},
+			Visitor:      merge2.Merger{},
+			Schema:       f.schema,
+			MergeOptions: yaml.MergeOptions{ListIncreaseDirection: yaml.MergeOptionsListAppend},
+		}.Walk()
+		if err != nil {
+			return nil, err
+		}
+		if r != nil {
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,70 @@
+package patch
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"gotest.tools/assert"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func Test_CustomResourceSchema(t *testing.T) {
+	schema, err := CustomResourceSchema(nil)
+	assert.NilError(t, err)
+	assert.Assert(t, schema == nil)
+
+	listType := "map"
+	schema, err = CustomResourceSchema(&apiextensionsv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"spec": {
+				Type: "object",
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"rules": {
+						Type:         "array",
+						XListType:    &listType,
+						XListMapKeys: []string{"id"},
+						Items: &apiextensionsv1.JSONSchemaPropsOrArray{
+							Schema: &apiextensionsv1.JSONSchemaProps{
+								Type: "object",
+								Properties: map[string]apiextensionsv1.JSONSchemaProps{
+									"id":    {Type: "string"},
+									"value": {Type: "string"},
+								},
+							},
+						},
+					},
+					"hosts": {
+						Type: "array",
+						Items: &apiextensionsv1.JSONSchemaPropsOrArray{
+							Schema: &apiextensionsv1.JSONSchemaProps{Type: "string"},
+						},
+					},
+				},
+			},
+		},
+	})
+	assert.NilError(t, err)
+	rules := schema.Schema.Properties["spec"].Properties["rules"]
+	strategy, _ := rules.Extensions.GetString(patchStrategyExtension)
+	assert.Equal(t, strategy, "merge")
+	key, _ := rules.Extensions.GetString(patchMergeKeyExtension)
+	assert.Equal(t, key, "id")
+	hosts := schema.Schema.Properties["spec"].Properties["hosts"]
+	_, ok := hosts.Extensions.GetString(patchStrategyExtension)
+	assert.Assert(t, !ok)
+
+	base := `{"apiVersion":"example.com/v1","kind":"Config","metadata":{"name":"test"},"spec":{"rules":[{"id":"a","value":"1"},{"id":"b","value":"2"}],"hosts":["foo"]}}`
+	overlay := `{"spec":{"rules":[{"id":"b","value":"3"}],"hosts":["bar"]}}`
+	expected := []byte(`{"apiVersion":"example.com/v1","kind":"Config","metadata":{"name":"test"},"spec":{"rules":[{"id":"a","value":"1"},{"id":"b","value":"3"}],"hosts":["bar"]}}`)
+
+	// without a schema lists are replaced
+	out, err := strategicMergePatch(logr.Discard(), base, overlay)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, toJSON(t, []byte(`{"apiVersion":"example.com/v1","kind":"Config","metadata":{"name":"test"},"spec":{"rules":[{"id":"b","value":"3"}],"hosts":["bar"]}}`)), toJSON(t, out))
+
+	// with a schema list elements are merged by key
+	out, err = strategicMergePatchWithSchema(logr.Discard(), base, overlay, schema)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, toJSON(t, expected), toJSON(t, out))
+}
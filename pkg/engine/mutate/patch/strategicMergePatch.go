@@ -8,17 +8,20 @@ import (
 	"github.com/go-logr/logr"
 	"sigs.k8s.io/kustomize/api/filters/patchstrategicmerge"
 	filtersutil "sigs.k8s.io/kustomize/kyaml/filtersutil"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/openapi"
 	yaml "sigs.k8s.io/kustomize/kyaml/yaml"
 )
 
 // ProcessStrategicMergePatch ...
-func ProcessStrategicMergePatch(logger logr.Logger, overlay interface{}, resource resource) (resource, error) {
+// The schema is optional, it is used to resolve list merge keys for custom resources.
+func ProcessStrategicMergePatch(logger logr.Logger, overlay interface{}, resource resource, schema *openapi.ResourceSchema) (resource, error) {
 	overlayBytes, err := json.Marshal(overlay)
 	if err != nil {
 		logger.Error(err, "failed to marshal resource")
 		return nil, err
 	}
-	patchedBytes, err := strategicMergePatch(logger, string(resource), string(overlayBytes))
+	patchedBytes, err := strategicMergePatchWithSchema(logger, string(resource), string(overlayBytes), schema)
 	if err != nil {
 		logger.Error(err, "failed to apply patchStrategicMerge")
 		return nil, err
@@ -27,6 +30,10 @@ func ProcessStrategicMergePatch(logger logr.Logger, overlay interface{}, resourc
 }
 
 func strategicMergePatch(logger logr.Logger, base, overlay string) ([]byte, error) {
+	return strategicMergePatchWithSchema(logger, base, overlay, nil)
+}
+
+func strategicMergePatchWithSchema(logger logr.Logger, base, overlay string, schema *openapi.ResourceSchema) ([]byte, error) {
 	preprocessedYaml, err := preProcessStrategicMergePatch(logger, overlay, base)
 	if err != nil {
 		_, isConditionError := err.(ConditionError)
@@ -43,9 +50,15 @@ func strategicMergePatch(logger logr.Logger, base, overlay string) ([]byte, erro
 
 	patchStr, _ := preprocessedYaml.String()
 	logger.V(3).Info("applying strategic merge patch", "patch", patchStr)
-	f := patchstrategicmerge.Filter{
+	var f kio.Filter = patchstrategicmerge.Filter{
 		Patch: preprocessedYaml,
 	}
+	if schema != nil {
+		f = schemaMergeFilter{
+			patch:  preprocessedYaml,
+			schema: schema,
+		}
+	}
 
 	baseObj := buffer{Buffer: bytes.NewBufferString(base)}
 	err = filtersutil.ApplyToJSON(f, baseObj)
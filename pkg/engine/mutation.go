@@ -28,6 +28,7 @@ func (e *engine) mutate(
 
 	policyContext.JSONContext().Checkpoint()
 	defer policyContext.JSONContext().Restore()
+	loadVariables := e.policyVariablesLoader(ctx, policyContext)
 
 	for _, rule := range autogen.ComputeRules(policy, "") {
 		startTime := time.Now()
@@ -42,7 +43,7 @@ func (e *engine) mutate(
 				}
 				return mutation.NewMutateExistingHandler(e.client)
 			}
-			return mutation.NewMutateResourceHandler()
+			return mutation.NewMutateResourceHandler(e.client)
 		}
 		resource, ruleResp := e.invokeRuleHandler(
 			ctx,
@@ -52,6 +53,7 @@ func (e *engine) mutate(
 			matchedResource,
 			rule,
 			engineapi.Mutation,
+			loadVariables,
 		)
 		matchedResource = resource
 		resp.Add(engineapi.NewExecutionStats(startTime, time.Now()), ruleResp...)
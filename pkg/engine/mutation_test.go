@@ -42,6 +42,9 @@ func testMutate(
 		imageverifycache.DisabledImageVerifyCache(),
 		contextLoader,
 		nil,
+		nil,
+		nil,
+		nil,
 	)
 	return e.Mutate(
 		ctx,
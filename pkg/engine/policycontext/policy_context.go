@@ -138,6 +138,15 @@ func (c PolicyContext) Copy() engineapi.PolicyContext {
 	return &c
 }
 
+func (c PolicyContext) Fork() (engineapi.PolicyContext, error) {
+	jsonContext, err := c.jsonContext.Fork()
+	if err != nil {
+		return nil, err
+	}
+	c.jsonContext = jsonContext
+	return &c, nil
+}
+
 // Mutators
 
 func (c PolicyContext) WithPolicy(policy kyvernov1.PolicyInterface) *PolicyContext {
@@ -0,0 +1,152 @@
+package engine
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/kyverno/kyverno/pkg/autogen"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// isCacheable returns false when the results of a policy depend on something else than the admission request.
+// It walks the policy spec: context entries other than variables load external data, CEL params are fetched
+// from the cluster, the InTimeWindow operator compares with the current time, and expressions calling a
+// JMESPath function flagged as non deterministic can't be replayed either.
+func isCacheable(spec interface{}, nonDeterministic sets.Set[string]) bool {
+	switch typed := spec.(type) {
+	case map[string]interface{}:
+		for key, value := range typed {
+			switch key {
+			case "context":
+				if entries, ok := value.([]interface{}); ok {
+					for _, entry := range entries {
+						if !isVariableEntry(entry) {
+							return false
+						}
+					}
+				}
+			case "paramKind", "paramRef":
+				return false
+			case "operator":
+				if value == "InTimeWindow" {
+					return false
+				}
+			}
+			if !isCacheable(value, nonDeterministic) {
+				return false
+			}
+		}
+	case []interface{}:
+		for _, value := range typed {
+			if !isCacheable(value, nonDeterministic) {
+				return false
+			}
+		}
+	case string:
+		return !jmespath.FunctionCalls(typed).HasAny(nonDeterministic.UnsortedList()...)
+	}
+	return true
+}
+
+// isVariableEntry returns true when the context entry only declares a variable
+func isVariableEntry(entry interface{}) bool {
+	fields, ok := entry.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for field := range fields {
+		if field != "name" && field != "variable" {
+			return false
+		}
+	}
+	return true
+}
+
+type resultCacheKey struct {
+	Policy          policyVersion      `json:"policy"`
+	Exceptions      []exceptionVersion `json:"exceptions,omitempty"`
+	NamespaceLabels map[string]string  `json:"namespaceLabels,omitempty"`
+	Request         interface{}        `json:"request"`
+}
+
+type policyVersion struct {
+	Kind            string `json:"kind"`
+	Namespace       string `json:"namespace,omitempty"`
+	Name            string `json:"name"`
+	UID             string `json:"uid,omitempty"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+	Spec            []byte `json:"spec,omitempty"`
+}
+
+type exceptionVersion struct {
+	Rule            string `json:"rule"`
+	Namespace       string `json:"namespace"`
+	Name            string `json:"name"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+// resultCacheKeyFor computes the key used to memoize the response of a policy for a given policy context.
+// The key covers the policy version, the exceptions applying to its rules, the namespace labels and the
+// admission request held in the JSON context. The request carries its uid, the key is unique to a given
+// admission request and its reinvocations, and changes whenever a mutation modified the object.
+// It returns false when the response can't be cached.
+func (e *engine) resultCacheKeyFor(policyContext engineapi.PolicyContext) (string, bool) {
+	if e.resultCache == nil || !policyContext.AdmissionOperation() {
+		return "", false
+	}
+	policy := policyContext.Policy()
+	spec, err := json.Marshal(policy.GetSpec())
+	if err != nil {
+		return "", false
+	}
+	var fields interface{}
+	if err := json.Unmarshal(spec, &fields); err != nil {
+		return "", false
+	}
+	if !isCacheable(fields, jmespath.NonDeterministicFunctions(e.configuration)) {
+		return "", false
+	}
+	request, err := policyContext.JSONContext().Query("request")
+	if err != nil || request == nil {
+		return "", false
+	}
+	key := resultCacheKey{
+		Policy: policyVersion{
+			Kind:            policy.GetKind(),
+			Namespace:       policy.GetNamespace(),
+			Name:            policy.GetName(),
+			UID:             string(policy.GetUID()),
+			ResourceVersion: policy.GetResourceVersion(),
+		},
+		NamespaceLabels: policyContext.NamespaceLabels(),
+		Request:         request,
+	}
+	// policies built in memory have no resource version, the spec is used instead
+	if key.Policy.ResourceVersion == "" {
+		key.Policy.Spec = spec
+	}
+	gvk, _ := policyContext.ResourceKind()
+	for _, rule := range autogen.ComputeRules(policy, gvk.Kind) {
+		exceptions, err := e.GetPolicyExceptions(policy, rule.Name)
+		if err != nil {
+			return "", false
+		}
+		for _, exception := range exceptions {
+			key.Exceptions = append(key.Exceptions, exceptionVersion{
+				Rule:            rule.Name,
+				Namespace:       exception.GetNamespace(),
+				Name:            exception.GetName(),
+				ResourceVersion: exception.GetResourceVersion(),
+			})
+		}
+	}
+	data, err := json.Marshal(key)
+	if err != nil {
+		return "", false
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), true
+}
@@ -0,0 +1,58 @@
+package resultcache
+
+import (
+	"time"
+
+	"github.com/dgraph-io/ristretto"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+)
+
+const (
+	defaultTTL     = 10 * time.Second
+	defaultMaxSize = 1000
+)
+
+type cache struct {
+	cache *ristretto.Cache
+	ttl   time.Duration
+}
+
+// New returns an in-memory result cache holding up to maxSize policy responses for the given ttl.
+// The ttl is expected to be short, entries only need to survive the lifetime of an admission request
+// and its reinvocations.
+func New(maxSize int64, ttl time.Duration) (engineapi.ResultCache, error) {
+	if maxSize == 0 {
+		maxSize = defaultMaxSize
+	}
+	if ttl == 0 {
+		ttl = defaultTTL
+	}
+	config := ristretto.Config{
+		MaxCost:     maxSize,
+		NumCounters: 10 * maxSize,
+		BufferItems: 64,
+	}
+	c, err := ristretto.NewCache(&config)
+	if err != nil {
+		return nil, err
+	}
+	return &cache{cache: c, ttl: ttl}, nil
+}
+
+func (c *cache) Get(key string) (engineapi.PolicyResponse, bool) {
+	value, found := c.cache.Get(key)
+	if !found {
+		return engineapi.PolicyResponse{}, false
+	}
+	response, ok := value.(engineapi.PolicyResponse)
+	if !ok {
+		return engineapi.PolicyResponse{}, false
+	}
+	// callers may modify the response, the cached one must not be shared
+	return response.DeepCopy(), true
+}
+
+func (c *cache) Set(key string, response engineapi.PolicyResponse) {
+	c.cache.SetWithTTL(key, response.DeepCopy(), 1, c.ttl)
+	c.cache.Wait()
+}
@@ -0,0 +1,219 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/factories"
+	"github.com/kyverno/kyverno/pkg/imageverifycache"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+type fakeResultCache struct {
+	entries map[string]engineapi.PolicyResponse
+	hits    int
+}
+
+func (c *fakeResultCache) Get(key string) (engineapi.PolicyResponse, bool) {
+	response, found := c.entries[key]
+	if found {
+		c.hits++
+	}
+	return response, found
+}
+
+func (c *fakeResultCache) Set(key string, response engineapi.PolicyResponse) {
+	c.entries[key] = response
+}
+
+func TestValidate_ResultCache(t *testing.T) {
+	rawPolicy := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "require-labels"},
+		"spec": {
+			"rules": [{
+				"name": "check-app-label",
+				"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+				"validate": {
+					"message": "label app is required",
+					"pattern": {"metadata": {"labels": {"app": "?*"}}}
+				}
+			}]
+		}
+	}`)
+	rawTimePolicy := []byte(`{
+		"apiVersion": "kyverno.io/v1",
+		"kind": "ClusterPolicy",
+		"metadata": {"name": "require-labels"},
+		"spec": {
+			"rules": [{
+				"name": "check-app-label",
+				"match": {"any": [{"resources": {"kinds": ["Pod"]}}]},
+				"context": [{"name": "now", "variable": {"jmesPath": "time_now_utc()"}}],
+				"validate": {
+					"message": "label app is required",
+					"pattern": {"metadata": {"labels": {"app": "?*"}}}
+				}
+			}]
+		}
+	}`)
+	rawResource := []byte(`{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {"name": "test", "namespace": "default"},
+		"spec": {"containers": [{"name": "nginx", "image": "nginx"}]}
+	}`)
+	rawLabelledResource := []byte(`{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {"name": "test", "namespace": "default", "labels": {"app": "nginx"}},
+		"spec": {"containers": [{"name": "nginx", "image": "nginx"}]}
+	}`)
+	tests := []struct {
+		name         string
+		policy       []byte
+		resources    [][]byte
+		admission    bool
+		wantHits     int
+		wantStatuses []engineapi.RuleStatus
+	}{{
+		name:         "same object is evaluated once",
+		policy:       rawPolicy,
+		resources:    [][]byte{rawResource, rawResource},
+		admission:    true,
+		wantHits:     1,
+		wantStatuses: []engineapi.RuleStatus{engineapi.RuleStatusFail, engineapi.RuleStatusFail},
+	}, {
+		name:         "modified object is evaluated again",
+		policy:       rawPolicy,
+		resources:    [][]byte{rawResource, rawLabelledResource},
+		admission:    true,
+		wantHits:     0,
+		wantStatuses: []engineapi.RuleStatus{engineapi.RuleStatusFail, engineapi.RuleStatusPass},
+	}, {
+		name:         "background evaluations are not cached",
+		policy:       rawPolicy,
+		resources:    [][]byte{rawResource, rawResource},
+		admission:    false,
+		wantHits:     0,
+		wantStatuses: []engineapi.RuleStatus{engineapi.RuleStatusFail, engineapi.RuleStatusFail},
+	}, {
+		name:         "time dependent policies are not cached",
+		policy:       rawTimePolicy,
+		resources:    [][]byte{rawResource, rawResource},
+		admission:    true,
+		wantHits:     0,
+		wantStatuses: []engineapi.RuleStatus{engineapi.RuleStatusFail, engineapi.RuleStatusFail},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var policy kyvernov1.ClusterPolicy
+			assert.NilError(t, json.Unmarshal(tt.policy, &policy))
+			resultCache := &fakeResultCache{entries: map[string]engineapi.PolicyResponse{}}
+			e := NewEngine(
+				cfg,
+				config.NewDefaultMetricsConfiguration(),
+				jp,
+				nil,
+				nil,
+				imageverifycache.DisabledImageVerifyCache(),
+				factories.DefaultContextLoaderFactory(nil),
+				nil,
+				resultCache,
+				nil,
+				nil,
+			)
+			for i, raw := range tt.resources {
+				resource, err := kubeutils.BytesToUnstructured(raw)
+				assert.NilError(t, err)
+				policyContext := newPolicyContext(t, *resource, kyvernov1.Create, nil).
+					WithPolicy(&policy).
+					WithAdmissionOperation(tt.admission)
+				response := e.Validate(context.TODO(), policyContext)
+				assert.Equal(t, len(response.PolicyResponse.Rules), 1)
+				assert.Equal(t, response.PolicyResponse.Rules[0].Status(), tt.wantStatuses[i])
+			}
+			assert.Equal(t, resultCache.hits, tt.wantHits)
+		})
+	}
+}
+
+func TestResultCacheKeyFor_Uncacheable(t *testing.T) {
+	rawResource := []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"test","namespace":"default"},"spec":{"containers":[{"name":"nginx","image":"nginx"}]}}`)
+	tests := []struct {
+		name   string
+		policy []byte
+	}{{
+		name:   "jwt verification",
+		policy: []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"jwt"},"spec":{"rules":[{"name":"check-token","match":{"any":[{"resources":{"kinds":["Pod"]}}]},"context":[{"name":"token","variable":{"jmesPath":"jwt_verify(request.object.metadata.annotations.token, 'key')"}}],"validate":{"deny":{}}}]}}`),
+	}, {
+		name:   "time window",
+		policy: []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"time-window"},"spec":{"rules":[{"name":"business-hours","match":{"any":[{"resources":{"kinds":["Pod"]}}]},"preconditions":{"all":[{"key":"{{ time_now_utc() }}","operator":"InTimeWindow","value":"09:00-17:00"}]},"validate":{"deny":{}}}]}}`),
+	}, {
+		name:   "x509 chain verification",
+		policy: []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"x509"},"spec":{"rules":[{"name":"check-chain","match":{"any":[{"resources":{"kinds":["Pod"]}}]},"validate":{"deny":{"conditions":{"any":[{"key":"{{ x509_verify_chain(request.object.metadata.annotations.chain, request.object.metadata.annotations.ca) }}","operator":"Equals","value":false}]}}}}]}}`),
+	}, {
+		name:   "foreach api call",
+		policy: []byte(`{"apiVersion":"kyverno.io/v1","kind":"ClusterPolicy","metadata":{"name":"foreach"},"spec":{"rules":[{"name":"check-images","match":{"any":[{"resources":{"kinds":["Pod"]}}]},"validate":{"foreach":[{"list":"request.object.spec.containers","context":[{"name":"image","apiCall":{"service":{"url":"https://images.example.com"}}}],"deny":{}}]}}]}}`),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var policy kyvernov1.ClusterPolicy
+			assert.NilError(t, json.Unmarshal(tt.policy, &policy))
+			resource, err := kubeutils.BytesToUnstructured(rawResource)
+			assert.NilError(t, err)
+			policyContext := newPolicyContext(t, *resource, kyvernov1.Create, nil).
+				WithPolicy(&policy).
+				WithAdmissionOperation(true)
+			e := &engine{resultCache: &fakeResultCache{entries: map[string]engineapi.PolicyResponse{}}}
+			_, cacheable := e.resultCacheKeyFor(policyContext)
+			assert.Equal(t, cacheable, false)
+		})
+	}
+}
+
+func Test_isCacheable(t *testing.T) {
+	nonDeterministic := sets.New("time_now_utc", "x509_verify_chain")
+	tests := []struct {
+		name string
+		spec string
+		want bool
+	}{{
+		name: "variables",
+		spec: `{"rules":[{"context":[{"name":"image","variable":{"jmesPath":"request.object.spec.containers[0].image"}}],"validate":{"message":"apiCall: is not used","deny":{}}}]}`,
+		want: true,
+	}, {
+		name: "deterministic function",
+		spec: `{"rules":[{"validate":{"message":"{{ to_upper(request.object.metadata.name) }}","deny":{}}}]}`,
+		want: true,
+	}, {
+		name: "config map",
+		spec: `{"rules":[{"context":[{"name":"cm","configMap":{"name":"config","namespace":"default"}}]}]}`,
+	}, {
+		name: "nested foreach context",
+		spec: `{"rules":[{"validate":{"foreach":[{"list":"a","foreach":[{"list":"b","context":[{"name":"data","globalReference":{"name":"data"}}]}]}]}}]}`,
+	}, {
+		name: "cel params",
+		spec: `{"rules":[{"validate":{"cel":{"paramKind":{"apiVersion":"v1","kind":"ConfigMap"},"expressions":[{"expression":"true"}]}}}]}`,
+	}, {
+		name: "time window",
+		spec: `{"rules":[{"preconditions":{"all":[{"key":"2024-01-01T00:00:00Z","operator":"InTimeWindow","value":"09:00-17:00"}]}}]}`,
+	}, {
+		name: "non deterministic function",
+		spec: `{"rules":[{"validate":{"deny":{"conditions":{"any":[{"key":"{{ x509_verify_chain (request.object.data.chain, request.object.data.ca) }}","operator":"Equals","value":false}]}}}}]}`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var spec interface{}
+			assert.NilError(t, json.Unmarshal([]byte(tt.spec), &spec))
+			assert.Equal(t, isCacheable(spec, nonDeterministic), tt.want)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package secretstore
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/ristretto"
+	vaultapi "github.com/hashicorp/vault/api"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+)
+
+const (
+	defaultAuthPath   = "kubernetes"
+	defaultMount      = "secret"
+	defaultTTL        = 5 * time.Minute
+	maxCacheEntries   = 1000
+	serviceAccountJWT = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+)
+
+// Client fetches secret data from an external secret store.
+type Client interface {
+	Fetch(ctx context.Context, policy kyvernov1.PolicyInterface, store kyvernov1.SecretStore) (map[string]interface{}, error)
+}
+
+type cacheEntry struct {
+	data    map[string]interface{}
+	expires time.Time
+}
+
+type vaultClient struct {
+	configuration config.Configuration
+	tokenPath     string
+	now           func() time.Time
+	cache         *ristretto.Cache
+}
+
+// NewClient returns a Client fetching secrets from Vault KV version 2 secrets engines.
+// Only the Vault servers configured in the Kyverno ConfigMap can be used, Kyverno authenticates to them
+// with the pod service account token using the Vault Kubernetes auth method.
+// Namespaced policies can only read the secrets stored under a path named after their namespace.
+// Fetched data is cached for the TTL of the secret store.
+func NewClient(configuration config.Configuration) (Client, error) {
+	cache, err := ristretto.NewCache(&ristretto.Config{
+		MaxCost:     maxCacheEntries,
+		NumCounters: 10 * maxCacheEntries,
+		BufferItems: 64,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &vaultClient{
+		configuration: configuration,
+		tokenPath:     serviceAccountJWT,
+		now:           time.Now,
+		cache:         cache,
+	}, nil
+}
+
+func (c *vaultClient) Fetch(ctx context.Context, policy kyvernov1.PolicyInterface, store kyvernov1.SecretStore) (map[string]interface{}, error) {
+	if store.Vault == nil {
+		return nil, fmt.Errorf("a vault is required for secret store")
+	}
+	vault := *store.Vault
+	vault.Path = strings.TrimPrefix(path.Clean("/"+vault.Path), "/")
+	if err := checkPath(policy, vault.Path); err != nil {
+		return nil, err
+	}
+	server, ok := c.configuration.GetVaultServer(vault.Server)
+	if !ok {
+		return nil, fmt.Errorf("vault server %s is not configured", vault.Server)
+	}
+	if server.AuthPath == "" {
+		server.AuthPath = defaultAuthPath
+	}
+	if vault.Mount == "" {
+		vault.Mount = defaultMount
+	}
+	ttl := defaultTTL
+	if vault.TTL != nil {
+		ttl = vault.TTL.Duration
+	}
+	// the resolved server is part of the key so that configuration changes are picked up immediately
+	key := strings.Join([]string{server.Address, server.AuthPath, server.Role, vault.Mount, vault.Path}, "|")
+	if cached, ok := c.cache.Get(key); ok {
+		if entry := cached.(cacheEntry); c.now().Before(entry.expires) {
+			return entry.data, nil
+		}
+	}
+	data, err := c.read(ctx, server, vault)
+	if err != nil {
+		return nil, err
+	}
+	expires := c.now().Add(ttl)
+	c.cache.SetWithTTL(key, cacheEntry{data: data, expires: expires}, 1, ttl)
+	c.cache.Wait()
+	return data, nil
+}
+
+// checkPath returns an error when the policy is not allowed to read the secret stored at the given clean path,
+// namespaced policies can only read the secrets stored under a path named after their namespace.
+func checkPath(policy kyvernov1.PolicyInterface, secretPath string) error {
+	if policy == nil || !policy.IsNamespaced() {
+		return nil
+	}
+	if !strings.HasPrefix(secretPath, policy.GetNamespace()+"/") {
+		return fmt.Errorf("policy %s/%s can't read vault secret %s outside of path %s/", policy.GetNamespace(), policy.GetName(), secretPath, policy.GetNamespace())
+	}
+	return nil
+}
+
+func (c *vaultClient) read(ctx context.Context, server config.VaultServer, vault kyvernov1.VaultSecret) (map[string]interface{}, error) {
+	vaultConfig := vaultapi.DefaultConfig()
+	vaultConfig.Address = server.Address
+	if server.CABundle != "" {
+		if err := vaultConfig.ConfigureTLS(&vaultapi.TLSConfig{CACertBytes: []byte(server.CABundle)}); err != nil {
+			return nil, fmt.Errorf("failed to configure vault TLS: %w", err)
+		}
+	}
+	client, err := vaultapi.NewClient(vaultConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vault client: %w", err)
+	}
+	jwt, err := os.ReadFile(c.tokenPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service account token: %w", err)
+	}
+	auth, err := client.Logical().WriteWithContext(ctx, "auth/"+strings.Trim(server.AuthPath, "/")+"/login", map[string]interface{}{
+		"role": server.Role,
+		"jwt":  strings.TrimSpace(string(jwt)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to login to vault: %w", err)
+	}
+	if auth == nil || auth.Auth == nil {
+		return nil, fmt.Errorf("failed to login to vault: no auth info returned")
+	}
+	client.SetToken(auth.Auth.ClientToken)
+	secret, err := client.KVv2(strings.Trim(vault.Mount, "/")).Get(ctx, strings.Trim(vault.Path, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vault secret %s: %w", vault.Path, err)
+	}
+	return secret.Data, nil
+}
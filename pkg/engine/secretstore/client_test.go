@@ -0,0 +1,167 @@
+package secretstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newVaultServer(t *testing.T, reads *int) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/auth/kubernetes/login", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		assert.NilError(t, json.NewDecoder(r.Body).Decode(&body))
+		if body["role"] != "kyverno" || body["jwt"] != "sa-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"auth": map[string]interface{}{
+				"client_token": "vault-token",
+			},
+		})
+	})
+	mux.HandleFunc("/v1/secret/data/allowlists/registries", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		*reads++
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"data": map[string]interface{}{
+					"registries": []interface{}{"registry.corp.example"},
+				},
+				"metadata": map[string]interface{}{
+					"version": 1,
+				},
+			},
+		})
+	})
+	return httptest.NewServer(mux)
+}
+
+func newTestClient(t *testing.T, servers string, now func() time.Time) *vaultClient {
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	assert.NilError(t, os.WriteFile(tokenPath, []byte("sa-token\n"), 0o600))
+	configuration := config.NewDefaultConfiguration(false)
+	configuration.Load(&corev1.ConfigMap{
+		Data: map[string]string{
+			"vaultServers": servers,
+		},
+	})
+	client, err := NewClient(configuration)
+	assert.NilError(t, err)
+	vault := client.(*vaultClient)
+	vault.tokenPath = tokenPath
+	vault.now = now
+	return vault
+}
+
+func Test_vaultClient_Fetch(t *testing.T) {
+	reads := 0
+	server := newVaultServer(t, &reads)
+	defer server.Close()
+	now := time.Now()
+	servers := fmt.Sprintf(`{"corp": {"address": %q, "role": "kyverno"}, "other": {"address": %q, "role": "other"}}`, server.URL, server.URL)
+	client := newTestClient(t, servers, func() time.Time { return now })
+	store := kyvernov1.SecretStore{
+		Vault: &kyvernov1.VaultSecret{
+			Server: "corp",
+			Path:   "allowlists/registries",
+			TTL:    &metav1.Duration{Duration: time.Minute},
+		},
+	}
+	data, err := client.Fetch(context.TODO(), nil, store)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, data, map[string]interface{}{"registries": []interface{}{"registry.corp.example"}})
+	assert.Equal(t, reads, 1)
+	// served from cache
+	_, err = client.Fetch(context.TODO(), nil, store)
+	assert.NilError(t, err)
+	assert.Equal(t, reads, 1)
+	// cache expired
+	now = now.Add(2 * time.Minute)
+	_, err = client.Fetch(context.TODO(), nil, store)
+	assert.NilError(t, err)
+	assert.Equal(t, reads, 2)
+	// login failure
+	store.Vault.Server = "other"
+	store.Vault.Path = "allowlists/other"
+	_, err = client.Fetch(context.TODO(), nil, store)
+	assert.ErrorContains(t, err, "failed to login to vault")
+	// servers that are not configured are never contacted
+	store.Vault.Server = "unknown"
+	_, err = client.Fetch(context.TODO(), nil, store)
+	assert.ErrorContains(t, err, "vault server unknown is not configured")
+}
+
+func Test_vaultClient_Fetch_namespacedPolicy(t *testing.T) {
+	reads := 0
+	server := newVaultServer(t, &reads)
+	defer server.Close()
+	client := newTestClient(t, fmt.Sprintf(`{"corp": {"address": %q, "role": "kyverno"}}`, server.URL), time.Now)
+	newStore := func(path string) kyvernov1.SecretStore {
+		return kyvernov1.SecretStore{Vault: &kyvernov1.VaultSecret{Server: "corp", Path: path}}
+	}
+	// namespaced policies can read the secrets under their namespace path
+	allowed := &kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Namespace: "allowlists", Name: "pol"}}
+	_, err := client.Fetch(context.TODO(), allowed, newStore("/allowlists/registries"))
+	assert.NilError(t, err)
+	assert.Equal(t, reads, 1)
+	// but nothing else
+	denied := &kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "pol"}}
+	_, err = client.Fetch(context.TODO(), denied, newStore("allowlists/registries"))
+	assert.ErrorContains(t, err, "policy team-a/pol can't read vault secret allowlists/registries outside of path team-a/")
+	_, err = client.Fetch(context.TODO(), denied, newStore("team-a/../allowlists/registries"))
+	assert.ErrorContains(t, err, "can't read vault secret allowlists/registries")
+	_, err = client.Fetch(context.TODO(), denied, newStore("team-a"))
+	assert.ErrorContains(t, err, "can't read vault secret team-a")
+	assert.Equal(t, reads, 1)
+}
+
+func TestRedact(t *testing.T) {
+	data := map[string]interface{}{
+		"registries": []interface{}{"registry.corp.example", "registry.corp.example.mirror"},
+		"enabled":    true,
+		"empty":      "",
+	}
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{{
+		name: "empty",
+		in:   "",
+		want: "",
+	}, {
+		name: "no secret",
+		in:   "image nginx is not allowed",
+		want: "image nginx is not allowed",
+	}, {
+		name: "secret",
+		in:   "allowed registries are [registry.corp.example]",
+		want: "allowed registries are [**REDACTED**]",
+	}, {
+		name: "longest match first",
+		in:   "registry.corp.example.mirror/nginx is not allowed",
+		want: "**REDACTED**/nginx is not allowed",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, Redact(tt.in, data), tt.want)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package secretstore
+
+import (
+	"sort"
+	"strings"
+)
+
+const redacted = "**REDACTED**"
+
+func collect(data interface{}, values *[]string) {
+	switch typed := data.(type) {
+	case string:
+		if typed != "" {
+			*values = append(*values, typed)
+		}
+	case map[string]interface{}:
+		for _, value := range typed {
+			collect(value, values)
+		}
+	case []interface{}:
+		for _, value := range typed {
+			collect(value, values)
+		}
+	}
+}
+
+// Redact masks the string values of the given secret data found in the given string.
+func Redact(in string, data interface{}) string {
+	if in == "" {
+		return in
+	}
+	var values []string
+	collect(data, &values)
+	// replace longer values first so that a value containing another one is fully masked
+	sort.Slice(values, func(i, j int) bool {
+		return len(values[i]) > len(values[j])
+	})
+	for _, value := range values {
+		in = strings.ReplaceAll(in, value, redacted)
+	}
+	return in
+}
@@ -6,6 +6,7 @@ import (
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
 	kyvernov2beta1 "github.com/kyverno/kyverno/api/kyverno/v2beta1"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
 	"github.com/kyverno/kyverno/pkg/utils/conditions"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	matched "github.com/kyverno/kyverno/pkg/utils/match"
@@ -14,8 +15,60 @@ import (
 )
 
 // MatchesException takes a list of exceptions and checks if there is an exception applies to the incoming resource.
-// It returns the matched policy exception.
+// It returns the matched policy exception. Exceptions restricted to images are ignored, see MatchesImageException.
 func MatchesException(polexs []*kyvernov2.PolicyException, policyContext engineapi.PolicyContext, logger logr.Logger) []kyvernov2.PolicyException {
+	return matchesException(polexs, policyContext, logger, false)
+}
+
+// MatchesImageException takes a list of exceptions and returns the ones restricted to images that apply to the incoming resource.
+func MatchesImageException(polexs []*kyvernov2.PolicyException, policyContext engineapi.PolicyContext, logger logr.Logger) []kyvernov2.PolicyException {
+	return matchesException(polexs, policyContext, logger, true)
+}
+
+// IsImageExcepted checks if the image matches one of the images of the given exceptions.
+func IsImageExcepted(image string, polexs []kyvernov2.PolicyException) bool {
+	for _, polex := range polexs {
+		if ImageMatches(image, polex.Spec.Images) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllImagesExcepted checks if the resource has images and all of them match one of the images of the given exceptions.
+func AllImagesExcepted(images map[string]map[string]apiutils.ImageInfo, polexs []kyvernov2.PolicyException) bool {
+	var found bool
+	for _, infoMap := range images {
+		for _, imageInfo := range infoMap {
+			if !IsImageExcepted(imageInfo.String(), polexs) {
+				return false
+			}
+			found = true
+		}
+	}
+	return found
+}
+
+// IsContainerExcepted checks if a foreach element is a container whose image matches one of the images of the given
+// exceptions, the image is resolved from the resource images so that it is matched like in verifyImages rules.
+func IsContainerExcepted(element interface{}, images map[string]map[string]apiutils.ImageInfo, polexs []kyvernov2.PolicyException) bool {
+	container, ok := element.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	name, _ := container["name"].(string)
+	if image, _ := container["image"].(string); name == "" || image == "" {
+		return false
+	}
+	for _, infoMap := range images {
+		if imageInfo, ok := infoMap[name]; ok && IsImageExcepted(imageInfo.String(), polexs) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesException(polexs []*kyvernov2.PolicyException, policyContext engineapi.PolicyContext, logger logr.Logger, images bool) []kyvernov2.PolicyException {
 	var matchedExceptions []kyvernov2.PolicyException
 	gvk, subresource := policyContext.ResourceKind()
 	resource := policyContext.NewResource()
@@ -23,6 +76,9 @@ func MatchesException(polexs []*kyvernov2.PolicyException, policyContext enginea
 		resource = policyContext.OldResource()
 	}
 	for _, polex := range polexs {
+		if polex.HasImages() != images {
+			continue
+		}
 		match := checkMatchesResources(
 			resource,
 			polex.Spec.Match,
@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"testing"
+
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	apiutils "github.com/kyverno/kyverno/pkg/utils/api"
+	imageutils "github.com/kyverno/kyverno/pkg/utils/image"
+	"gotest.tools/assert"
+)
+
+func TestIsImageExcepted(t *testing.T) {
+	polexs := []kyvernov2.PolicyException{{
+		Spec: kyvernov2.PolicyExceptionSpec{
+			Images: []string{"ghcr.io/kyverno/legacy:*", "docker.io/library/nginx@sha256:*"},
+		},
+	}, {
+		Spec: kyvernov2.PolicyExceptionSpec{},
+	}}
+	tests := []struct {
+		name  string
+		image string
+		want  bool
+	}{{
+		name:  "tag wildcard",
+		image: "ghcr.io/kyverno/legacy:v1.0.0",
+		want:  true,
+	}, {
+		name:  "digest wildcard",
+		image: "docker.io/library/nginx@sha256:1ee494ebb83ba0b7e2e1ab8d3b13c5c3a02e0a4d6b0fa1c6f6e0c1b7b8f1d9c2",
+		want:  true,
+	}, {
+		name:  "other tag",
+		image: "docker.io/library/nginx:latest",
+		want:  false,
+	}, {
+		name:  "other image",
+		image: "ghcr.io/kyverno/kyverno:v1.0.0",
+		want:  false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, IsImageExcepted(tt.image, polexs), tt.want)
+		})
+	}
+	assert.Equal(t, IsImageExcepted("ghcr.io/kyverno/legacy:v1.0.0", nil), false)
+}
+
+func TestImageExceptions(t *testing.T) {
+	polexs := []kyvernov2.PolicyException{{
+		Spec: kyvernov2.PolicyExceptionSpec{
+			Images: []string{"ghcr.io/kyverno/legacy:*"},
+		},
+	}}
+	legacy := apiutils.ImageInfo{ImageInfo: imageutils.ImageInfo{Registry: "ghcr.io", Path: "kyverno/legacy", Tag: "v1.0.0"}}
+	nginx := apiutils.ImageInfo{ImageInfo: imageutils.ImageInfo{Registry: "docker.io", Path: "library/nginx", Tag: "latest"}}
+	images := map[string]map[string]apiutils.ImageInfo{
+		"containers":     {"legacy": legacy, "nginx": nginx},
+		"initContainers": {"init": legacy},
+	}
+	assert.Equal(t, AllImagesExcepted(images, polexs), false)
+	assert.Equal(t, AllImagesExcepted(map[string]map[string]apiutils.ImageInfo{"containers": {"legacy": legacy}}, polexs), true)
+	assert.Equal(t, AllImagesExcepted(nil, polexs), false)
+	assert.Equal(t, IsContainerExcepted(map[string]interface{}{"name": "legacy", "image": "ghcr.io/kyverno/legacy:v1.0.0"}, images, polexs), true)
+	assert.Equal(t, IsContainerExcepted(map[string]interface{}{"name": "nginx", "image": "nginx"}, images, polexs), false)
+	assert.Equal(t, IsContainerExcepted("legacy", images, polexs), false)
+}
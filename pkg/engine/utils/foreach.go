@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	"sort"
 
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	enginecontext "github.com/kyverno/kyverno/pkg/engine/context"
@@ -33,6 +34,55 @@ func EvaluateList(jmesPath string, ctx enginecontext.EvalInterface) ([]interface
 	return l, nil
 }
 
+// EvaluateForEachList evaluates the list of a foreach declaration, when mapEntries is true
+// the expression must return an object whose entries are returned instead.
+// An error is returned if the list exceeds the foreach iterations limit of the context.
+func EvaluateForEachList(jmesPath string, mapEntries bool, ctx enginecontext.Interface) ([]interface{}, error) {
+	var elements []interface{}
+	var err error
+	if mapEntries {
+		elements, err = EvaluateMapEntries(jmesPath, ctx)
+	} else {
+		elements, err = EvaluateList(jmesPath, ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if max := enginecontext.GetLimits(ctx).MaxForeachIterations; max > 0 && int64(len(elements)) > max {
+		return nil, fmt.Errorf("foreach list %s has %d elements, exceeding the limit of %d iterations", jmesPath, len(elements), max)
+	}
+	return elements, nil
+}
+
+// EvaluateMapEntries evaluates the context using the given JMESPath expression and returns the entries
+// of the resulting object sorted by key, each entry is a map with a `key` and a `value`.
+func EvaluateMapEntries(jmesPath string, ctx enginecontext.EvalInterface) ([]interface{}, error) {
+	i, err := ctx.Query(jmesPath)
+	if err != nil {
+		return nil, err
+	}
+	if i == nil {
+		return nil, nil
+	}
+	m, ok := i.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected an object for map entries of %s, got type=%T", jmesPath, i)
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	entries := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		entries = append(entries, map[string]interface{}{
+			"key":   k,
+			"value": m[k],
+		})
+	}
+	return entries, nil
+}
+
 // InvertElements inverts the order of elements for patchStrategicMerge policies
 // as kustomize patch reverses the order of patch resources.
 func InvertElements(elements []interface{}) []interface{} {
@@ -88,3 +88,80 @@ func Test_EvaluateList(t *testing.T) {
 		})
 	}
 }
+
+func Test_EvaluateMapEntries(t *testing.T) {
+	entryName := "test_object"
+	cases := []struct {
+		name     string
+		rawData  []byte
+		jmesPath string
+		expected []interface{}
+		wantErr  bool
+	}{
+		{
+			name: "map data",
+			rawData: []byte(`
+				{
+					"test-key-2": {"nested": true},
+					"test-key-1": "test-value-1"
+				}
+			`),
+			jmesPath: entryName,
+			expected: []interface{}{
+				map[string]interface{}{
+					"key":   "test-key-1",
+					"value": "test-value-1",
+				},
+				map[string]interface{}{
+					"key":   "test-key-2",
+					"value": map[string]interface{}{"nested": true},
+				},
+			},
+		},
+		{
+			name:     "missing data",
+			rawData:  []byte(`{}`),
+			jmesPath: entryName + ".missing",
+			expected: nil,
+		},
+		{
+			name:     "slice data",
+			rawData:  []byte(`["test-value-1", "test-value-2"]`),
+			jmesPath: entryName,
+			wantErr:  true,
+		},
+	}
+
+	cfg := config.NewDefaultConfiguration(false)
+	jp := jmespath.New(cfg)
+
+	for _, item := range cases {
+		t.Run(item.name, func(t *testing.T) {
+			ctx := context.NewContext(jp)
+			assert.NoError(t, ctx.AddContextEntry(entryName, item.rawData))
+
+			entries, err := EvaluateForEachList(item.jmesPath, true, ctx)
+			if item.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, item.expected, entries)
+		})
+	}
+}
+
+func Test_EvaluateForEachList_Limit(t *testing.T) {
+	jp := jmespath.New(config.NewDefaultConfiguration(false))
+	ctx := context.NewContext(jp)
+	assert.NoError(t, ctx.AddContextEntry("list", []byte(`["a", "b", "c"]`)))
+
+	context.SetLimits(ctx, context.Limits{MaxForeachIterations: 3})
+	elements, err := EvaluateForEachList("list", false, ctx)
+	assert.NoError(t, err)
+	assert.Len(t, elements, 3)
+
+	context.SetLimits(ctx, context.Limits{MaxForeachIterations: 2})
+	_, err = EvaluateForEachList("list", false, ctx)
+	assert.EqualError(t, err, "foreach list list has 3 elements, exceeding the limit of 2 iterations")
+}
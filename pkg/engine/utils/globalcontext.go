@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"fmt"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+)
+
+// CheckGlobalContextAccess returns an error when the policy is not allowed to reference the given global context entry
+// by the global context access rules of the configuration. Entries are not restricted without a configuration or a policy.
+func CheckGlobalContextAccess(configuration config.Configuration, policy kyvernov1.PolicyInterface, entry string) error {
+	if configuration == nil || policy == nil {
+		return nil
+	}
+	if configuration.CanReferenceGlobalContextEntry(entry, policy.GetNamespace(), policy.GetName()) {
+		return nil
+	}
+	if policy.IsNamespaced() {
+		return fmt.Errorf("policy %s/%s is not allowed to reference global context entry %s", policy.GetNamespace(), policy.GetName(), entry)
+	}
+	return fmt.Errorf("cluster policy %s is not allowed to reference global context entry %s", policy.GetName(), entry)
+}
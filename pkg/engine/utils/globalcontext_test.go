@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckGlobalContextAccess(t *testing.T) {
+	configuration := config.NewDefaultConfiguration(false)
+	configuration.Load(&corev1.ConfigMap{
+		Data: map[string]string{
+			"globalContextAccess": `[{"entry": "secrets-*", "namespaces": ["platform"], "policies": ["require-*", "team-a/audit"]}]`,
+		},
+	})
+	tests := []struct {
+		name    string
+		policy  kyvernov1.PolicyInterface
+		entry   string
+		wantErr bool
+	}{{
+		name:   "unrestricted entry",
+		policy: &kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "policy"}},
+		entry:  "deployments",
+	}, {
+		name:   "allowed namespace",
+		policy: &kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Namespace: "platform", Name: "policy"}},
+		entry:  "secrets-metadata",
+	}, {
+		name:   "allowed policy",
+		policy: &kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "audit"}},
+		entry:  "secrets-metadata",
+	}, {
+		name:    "other policy",
+		policy:  &kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "policy"}},
+		entry:   "secrets-metadata",
+		wantErr: true,
+	}, {
+		name:    "cluster policy names don't match namespaced policies",
+		policy:  &kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "require-labels"}},
+		entry:   "secrets-metadata",
+		wantErr: true,
+	}, {
+		name:   "allowed cluster policy",
+		policy: &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "require-labels"}},
+		entry:  "secrets-metadata",
+	}, {
+		name:    "other cluster policy",
+		policy:  &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy"}},
+		entry:   "secrets-metadata",
+		wantErr: true,
+	}, {
+		name:  "no policy",
+		entry: "secrets-metadata",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckGlobalContextAccess(configuration, tt.policy, tt.entry)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/ext/wildcard"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+)
+
+// CheckSecretNamespace returns an error when the policy is not allowed to reference secrets from the given namespace.
+// Namespaced policies can only reference secrets from their own namespace, cluster policies can reference secrets
+// from the Kyverno namespace and from the namespaces allowed in the configuration.
+func CheckSecretNamespace(configuration config.Configuration, policy kyvernov1.PolicyInterface, namespace string) error {
+	if policy != nil && policy.IsNamespaced() {
+		if namespace != policy.GetNamespace() {
+			return fmt.Errorf("policy %s/%s can't reference secrets from namespace %s", policy.GetNamespace(), policy.GetName(), namespace)
+		}
+		return nil
+	}
+	if namespace == config.KyvernoNamespace() {
+		return nil
+	}
+	if configuration != nil {
+		for _, pattern := range configuration.GetSecretNamespaces() {
+			if wildcard.Match(pattern, namespace) {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("cluster policies can't reference secrets from namespace %s", namespace)
+}
+
+// FetchSecretData returns the data of a secret referenced by a policy, the namespace of the secret is checked
+// with CheckSecretNamespace before the secret is resolved.
+func FetchSecretData(
+	ctx context.Context,
+	resolver engineapi.SecretResolver,
+	configuration config.Configuration,
+	policy kyvernov1.PolicyInterface,
+	namespace string,
+	name string,
+) (map[string][]byte, error) {
+	if resolver == nil {
+		return nil, fmt.Errorf("a secret resolver is required to fetch secret %s/%s", namespace, name)
+	}
+	if err := CheckSecretNamespace(configuration, policy, namespace); err != nil {
+		return nil, err
+	}
+	secret, err := resolver.Get(ctx, namespace, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch secret %s/%s: %w", namespace, name, err)
+	}
+	return secret.Data, nil
+}
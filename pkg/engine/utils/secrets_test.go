@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fakeSecretResolver map[string]*corev1.Secret
+
+func (r fakeSecretResolver) Get(_ context.Context, namespace, name string) (*corev1.Secret, error) {
+	if secret, ok := r[namespace+"/"+name]; ok {
+		return secret, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func TestCheckSecretNamespace(t *testing.T) {
+	configuration := config.NewDefaultConfiguration(false)
+	configuration.Load(&corev1.ConfigMap{
+		Data: map[string]string{
+			"secretNamespaces": "security, team-*",
+		},
+	})
+	policy := &kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "policy"}}
+	clusterPolicy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy"}}
+	tests := []struct {
+		name      string
+		policy    kyvernov1.PolicyInterface
+		namespace string
+		wantErr   bool
+	}{{
+		name:      "policy namespace",
+		policy:    policy,
+		namespace: "team-a",
+	}, {
+		name:      "other namespace from policy",
+		policy:    policy,
+		namespace: "team-b",
+		wantErr:   true,
+	}, {
+		name:      "allowed namespaces don't apply to policies",
+		policy:    policy,
+		namespace: "security",
+		wantErr:   true,
+	}, {
+		name:      "kyverno namespace from cluster policy",
+		policy:    clusterPolicy,
+		namespace: config.KyvernoNamespace(),
+	}, {
+		name:      "allowed namespace from cluster policy",
+		policy:    clusterPolicy,
+		namespace: "security",
+	}, {
+		name:      "allowed wildcard from cluster policy",
+		policy:    clusterPolicy,
+		namespace: "team-b",
+	}, {
+		name:      "other namespace from cluster policy",
+		policy:    clusterPolicy,
+		namespace: "kube-system",
+		wantErr:   true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckSecretNamespace(configuration, tt.policy, tt.namespace)
+			if tt.wantErr {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
+
+func TestFetchSecretData(t *testing.T) {
+	var resolver engineapi.SecretResolver = fakeSecretResolver{
+		"team-a/trust": {Data: map[string][]byte{"ca.crt": []byte("pem")}},
+		"team-b/trust": {Data: map[string][]byte{"ca.crt": []byte("other")}},
+	}
+	policy := &kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "policy"}}
+	data, err := FetchSecretData(context.TODO(), resolver, nil, policy, "team-a", "trust")
+	assert.NilError(t, err)
+	assert.Equal(t, string(data["ca.crt"]), "pem")
+	_, err = FetchSecretData(context.TODO(), resolver, nil, policy, "team-b", "trust")
+	assert.ErrorContains(t, err, "can't reference secrets from namespace team-b")
+	_, err = FetchSecretData(context.TODO(), resolver, nil, policy, "team-a", "missing")
+	assert.ErrorContains(t, err, "failed to fetch secret team-a/missing")
+	_, err = FetchSecretData(context.TODO(), nil, nil, policy, "team-a", "trust")
+	assert.ErrorContains(t, err, "a secret resolver is required")
+}
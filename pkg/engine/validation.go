@@ -25,6 +25,7 @@ func (e *engine) validate(
 
 	policyContext.JSONContext().Checkpoint()
 	defer policyContext.JSONContext().Restore()
+	loadVariables := e.policyVariablesLoader(ctx, policyContext)
 
 	gvk, _ := policyContext.ResourceKind()
 	for _, rule := range autogen.ComputeRules(policy, gvk.Kind) {
@@ -43,6 +44,7 @@ func (e *engine) validate(
 				hasVerifyManifest := rule.HasVerifyManifests()
 				hasValidatePss := rule.HasValidatePodSecurity()
 				hasValidateCEL := rule.HasValidateCEL()
+				hasValidateWasm := rule.HasValidateWasm()
 				if hasVerifyManifest {
 					return validation.NewValidateManifestHandler(
 						policyContext,
@@ -52,6 +54,8 @@ func (e *engine) validate(
 					return validation.NewValidatePssHandler()
 				} else if hasValidateCEL {
 					return validation.NewValidateCELHandler(e.client)
+				} else if hasValidateWasm {
+					return validation.NewValidateWasmHandler(e.wasmEvaluator)
 				} else {
 					return validation.NewValidateResourceHandler()
 				}
@@ -73,6 +77,7 @@ func (e *engine) validate(
 			matchedResource,
 			rule,
 			engineapi.Validation,
+			loadVariables,
 		)
 		matchedResource = resource
 		resp.Add(engineapi.NewExecutionStats(startTime, time.Now()), ruleResp...)
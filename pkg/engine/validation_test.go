@@ -41,6 +41,9 @@ func testValidate(
 		imageverifycache.DisabledImageVerifyCache(),
 		contextLoader,
 		nil,
+		nil,
+		nil,
+		nil,
 	)
 	return e.Validate(
 		ctx,
@@ -3310,3 +3313,50 @@ func Test_ValidatePattern_anyPattern(t *testing.T) {
 		})
 	}
 }
+
+func Test_ValidatePattern_CELPreconditions(t *testing.T) {
+	var policy kyvernov1.ClusterPolicy
+	rawPolicy := []byte(`{"apiVersion":"kyverno.io\/v1","kind":"ClusterPolicy","metadata":{"name":"require-team-label"},"spec":{"validationFailureAction":"enforce","rules":[{"name":"check-team-label","match":{"resources":{"kinds":["Pod"]}},"celPreconditions":[{"name":"production","expression":"object.metadata.name.startsWith('prod-')"}],"validate":{"message":"The label 'team' is required.","pattern":{"metadata":{"labels":{"team":"?*"}}}}}]}}`)
+	testCases := []struct {
+		description     string
+		rawResource     []byte
+		expectedFailed  bool
+		expectedSkipped bool
+		expectedSuccess bool
+	}{
+		{
+			description:     "skip",
+			rawResource:     []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"dev-pod"},"spec":{"containers":[{"name":"nginx","image":"nginx"}]}}`),
+			expectedSkipped: true,
+		},
+		{
+			description:    "fail",
+			rawResource:    []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"prod-pod"},"spec":{"containers":[{"name":"nginx","image":"nginx"}]}}`),
+			expectedFailed: true,
+		},
+		{
+			description:     "success",
+			rawResource:     []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"prod-pod","labels":{"team":"payments"}},"spec":{"containers":[{"name":"nginx","image":"nginx"}]}}`),
+			expectedSuccess: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := json.Unmarshal(rawPolicy, &policy)
+			assert.NilError(t, err)
+
+			resourceUnstructured, err := kubeutils.BytesToUnstructured(tc.rawResource)
+			assert.NilError(t, err)
+
+			er := testValidate(context.TODO(), registryclient.NewOrDie(), newPolicyContext(t, *resourceUnstructured, kyvernov1.Create, nil).WithPolicy(&policy), cfg, nil)
+			if tc.expectedFailed {
+				assert.Assert(t, er.IsFailed())
+			} else if tc.expectedSkipped {
+				assert.Assert(t, er.IsSkipped())
+			} else if tc.expectedSuccess {
+				assert.Assert(t, er.IsSuccessful())
+			}
+		})
+	}
+}
@@ -375,6 +375,12 @@ func TestEvaluate(t *testing.T) {
 		{kyverno.Condition{RawKey: kyverno.ToJSON("5"), Operator: kyverno.ConditionOperators["AnyNotIn"], RawValue: kyverno.ToJSON("1-3")}, true},
 		{kyverno.Condition{RawKey: kyverno.ToJSON([]interface{}{1, 5, 11}), Operator: kyverno.ConditionOperators["AnyNotIn"], RawValue: kyverno.ToJSON("0-10")}, true},
 		{kyverno.Condition{RawKey: kyverno.ToJSON([]interface{}{1, 5, 7}), Operator: kyverno.ConditionOperators["AnyNotIn"], RawValue: kyverno.ToJSON("0-10")}, false},
+		// SemverSatisfies
+		{kyverno.Condition{RawKey: kyverno.ToJSON("v1.27.3"), Operator: kyverno.ConditionOperators["SemverSatisfies"], RawValue: kyverno.ToJSON(">=1.25 <1.30")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("1.30.0"), Operator: kyverno.ConditionOperators["SemverSatisfies"], RawValue: kyverno.ToJSON(">=1.25 <1.30")}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("1.24"), Operator: kyverno.ConditionOperators["SemverSatisfies"], RawValue: kyverno.ToJSON("<1.25 || >=2.0")}, true},
+		{kyverno.Condition{RawKey: kyverno.ToJSON("latest"), Operator: kyverno.ConditionOperators["SemverSatisfies"], RawValue: kyverno.ToJSON(">=1.25")}, false},
+		{kyverno.Condition{RawKey: kyverno.ToJSON(1.25), Operator: kyverno.ConditionOperators["SemverSatisfies"], RawValue: kyverno.ToJSON(">=1.25")}, false},
 	}
 
 	ctx := context.NewContext(jmespath.New(config.NewDefaultConfiguration(false)))
@@ -0,0 +1,68 @@
+package operator
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/engine/context"
+	semverutils "github.com/kyverno/kyverno/pkg/utils/semver"
+)
+
+// NewSemverSatisfiesHandler returns handler to manage SemverSatisfies operations
+func NewSemverSatisfiesHandler(log logr.Logger, ctx context.EvalInterface) OperatorHandler {
+	return SemverSatisfiesHandler{
+		ctx: ctx,
+		log: log,
+	}
+}
+
+// SemverSatisfiesHandler provides implementation to handle SemverSatisfies operator
+type SemverSatisfiesHandler struct {
+	ctx context.EvalInterface
+	log logr.Logger
+}
+
+// Evaluate evaluates expression with SemverSatisfies operator
+func (sh SemverSatisfiesHandler) Evaluate(key, value interface{}) bool {
+	switch typedKey := key.(type) {
+	case string:
+		return sh.validateValueWithStringPattern(typedKey, value)
+	default:
+		sh.log.V(2).Info("Unsupported type", "value", typedKey, "type", fmt.Sprintf("%T", typedKey))
+		return false
+	}
+}
+
+func (sh SemverSatisfiesHandler) validateValueWithStringPattern(key string, value interface{}) bool {
+	constraint, ok := value.(string)
+	if !ok {
+		sh.log.V(2).Info("Expected type string", "value", value, "type", fmt.Sprintf("%T", value))
+		return false
+	}
+	satisfied, err := semverutils.Satisfies(key, constraint)
+	if err != nil {
+		sh.log.V(2).Info("failed to evaluate semver range", "version", key, "range", constraint, "error", err.Error())
+		return false
+	}
+	return satisfied
+}
+
+func (sh SemverSatisfiesHandler) validateValueWithBoolPattern(_ bool, _ interface{}) bool {
+	return false
+}
+
+func (sh SemverSatisfiesHandler) validateValueWithIntPattern(_ int64, _ interface{}) bool {
+	return false
+}
+
+func (sh SemverSatisfiesHandler) validateValueWithFloatPattern(_ float64, _ interface{}) bool {
+	return false
+}
+
+func (sh SemverSatisfiesHandler) validateValueWithMapPattern(_ map[string]interface{}, _ interface{}) bool {
+	return false
+}
+
+func (sh SemverSatisfiesHandler) validateValueWithSlicePattern(_ []interface{}, _ interface{}) bool {
+	return false
+}
@@ -0,0 +1,201 @@
+package operator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aptible/supercronic/cronexpr"
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/engine/context"
+)
+
+// NewTimeWindowHandler returns handler to manage InTimeWindow operations
+func NewTimeWindowHandler(log logr.Logger, ctx context.EvalInterface) OperatorHandler {
+	return TimeWindowHandler{
+		ctx: ctx,
+		log: log,
+	}
+}
+
+// TimeWindowHandler provides implementation to handle InTimeWindow operator.
+// The key is a time in RFC3339 format, the value is a window or a list of windows.
+// A window is either a cron expression matching minutes, e.g. "* 0-6 * * *", or a
+// weekday window with an optional time range, e.g. "Sat,Sun" or "Mon-Fri 09:00-17:00".
+// Both forms accept a "TZ=<location>" prefix, UTC is used by default.
+type TimeWindowHandler struct {
+	ctx context.EvalInterface
+	log logr.Logger
+}
+
+// Evaluate evaluates expression with InTimeWindow operator
+func (th TimeWindowHandler) Evaluate(key, value interface{}) bool {
+	switch typedKey := key.(type) {
+	case string:
+		return th.validateValueWithStringPattern(typedKey, value)
+	default:
+		th.log.V(2).Info("Unsupported type", "value", typedKey, "type", fmt.Sprintf("%T", typedKey))
+		return false
+	}
+}
+
+func (th TimeWindowHandler) validateValueWithStringPattern(key string, value interface{}) bool {
+	t, err := time.Parse(time.RFC3339, key)
+	if err != nil {
+		th.log.V(2).Info("failed to parse time", "value", key, "error", err.Error())
+		return false
+	}
+	var windows []string
+	switch typedValue := value.(type) {
+	case string:
+		windows = append(windows, typedValue)
+	case []interface{}:
+		for _, v := range typedValue {
+			window, ok := v.(string)
+			if !ok {
+				th.log.V(2).Info("Expected type string", "value", v, "type", fmt.Sprintf("%T", v))
+				return false
+			}
+			windows = append(windows, window)
+		}
+	default:
+		th.log.V(2).Info("Expected type string or []interface{}", "value", value, "type", fmt.Sprintf("%T", value))
+		return false
+	}
+	for _, window := range windows {
+		in, err := InTimeWindow(t, window)
+		if err != nil {
+			th.log.V(2).Info("failed to evaluate time window", "window", window, "error", err.Error())
+			return false
+		}
+		if in {
+			return true
+		}
+	}
+	return false
+}
+
+func (th TimeWindowHandler) validateValueWithBoolPattern(_ bool, _ interface{}) bool {
+	return false
+}
+
+func (th TimeWindowHandler) validateValueWithIntPattern(_ int64, _ interface{}) bool {
+	return false
+}
+
+func (th TimeWindowHandler) validateValueWithFloatPattern(_ float64, _ interface{}) bool {
+	return false
+}
+
+func (th TimeWindowHandler) validateValueWithMapPattern(_ map[string]interface{}, _ interface{}) bool {
+	return false
+}
+
+func (th TimeWindowHandler) validateValueWithSlicePattern(_ []interface{}, _ interface{}) bool {
+	return false
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// InTimeWindow checks if a time falls within a cron or weekday window
+func InTimeWindow(t time.Time, window string) (bool, error) {
+	fields := strings.Fields(window)
+	loc := time.UTC
+	if len(fields) > 0 && strings.HasPrefix(fields[0], "TZ=") {
+		l, err := time.LoadLocation(strings.TrimPrefix(fields[0], "TZ="))
+		if err != nil {
+			return false, err
+		}
+		loc, fields = l, fields[1:]
+	}
+	t = t.In(loc)
+	switch len(fields) {
+	case 5:
+		expr, err := cronexpr.Parse(strings.Join(fields, " "))
+		if err != nil {
+			return false, err
+		}
+		minute := t.Truncate(time.Minute)
+		return expr.Next(minute.Add(-time.Second)).Equal(minute), nil
+	case 1, 2:
+		days, err := parseWeekdays(fields[0])
+		if err != nil {
+			return false, err
+		}
+		if len(fields) == 1 {
+			return days[t.Weekday()], nil
+		}
+		start, end, err := parseTimeRange(fields[1])
+		if err != nil {
+			return false, err
+		}
+		current := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+		if start <= end {
+			return days[t.Weekday()] && current >= start && current < end, nil
+		}
+		// the range wraps past midnight, it belongs to the day it starts on
+		previous := (t.Weekday() + 6) % 7
+		return (days[t.Weekday()] && current >= start) || (days[previous] && current < end), nil
+	default:
+		return false, fmt.Errorf("invalid time window %q", window)
+	}
+}
+
+func parseWeekdays(value string) (map[time.Weekday]bool, error) {
+	days := map[time.Weekday]bool{}
+	for _, part := range strings.Split(value, ",") {
+		bounds := strings.SplitN(part, "-", 2)
+		first, ok := weekdays[strings.ToLower(bounds[0])]
+		if !ok {
+			return nil, fmt.Errorf("invalid weekday %q", bounds[0])
+		}
+		last := first
+		if len(bounds) == 2 {
+			if last, ok = weekdays[strings.ToLower(bounds[1])]; !ok {
+				return nil, fmt.Errorf("invalid weekday %q", bounds[1])
+			}
+		}
+		for day := first; ; day = (day + 1) % 7 {
+			days[day] = true
+			if day == last {
+				break
+			}
+		}
+	}
+	return days, nil
+}
+
+func parseTimeRange(value string) (time.Duration, time.Duration, error) {
+	bounds := strings.SplitN(value, "-", 2)
+	if len(bounds) != 2 {
+		return 0, 0, fmt.Errorf("invalid time range %q", value)
+	}
+	parse := func(s string) (time.Duration, error) {
+		t, err := time.Parse("15:04", s)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+	}
+	start, err := parse(bounds[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := parse(bounds[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	// 24:00 is not accepted by the parser, 00:00 as end means the end of the day
+	if end == 0 {
+		end = 24 * time.Hour
+	}
+	return start, end, nil
+}
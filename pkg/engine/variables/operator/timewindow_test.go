@@ -0,0 +1,107 @@
+package operator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInTimeWindow(t *testing.T) {
+	// 2024-06-01 is a Saturday
+	saturday := time.Date(2024, 6, 1, 10, 30, 0, 0, time.UTC)
+	monday := time.Date(2024, 6, 3, 8, 15, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		time    time.Time
+		window  string
+		want    bool
+		wantErr bool
+	}{{
+		name:   "weekend",
+		time:   saturday,
+		window: "Sat,Sun",
+		want:   true,
+	}, {
+		name:   "weekday range",
+		time:   saturday,
+		window: "Mon-Fri",
+		want:   false,
+	}, {
+		name:   "weekday range wrapping the week",
+		time:   monday,
+		window: "Fri-Mon",
+		want:   true,
+	}, {
+		name:   "business hours before opening",
+		time:   monday,
+		window: "Mon-Fri 09:00-17:00",
+		want:   false,
+	}, {
+		name:   "business hours with time zone",
+		time:   monday,
+		window: "TZ=Europe/Paris Mon-Fri 09:00-17:00",
+		want:   true,
+	}, {
+		name:   "overnight range on the next day",
+		time:   time.Date(2024, 6, 1, 2, 0, 0, 0, time.UTC),
+		window: "Fri 22:00-06:00",
+		want:   true,
+	}, {
+		name:   "overnight range on the same day",
+		time:   time.Date(2024, 6, 1, 23, 0, 0, 0, time.UTC),
+		window: "Fri 22:00-06:00",
+		want:   false,
+	}, {
+		name:   "cron weekend",
+		time:   saturday,
+		window: "* * * * 6,0",
+		want:   true,
+	}, {
+		name:   "cron night hours",
+		time:   saturday,
+		window: "* 0-6 * * *",
+		want:   false,
+	}, {
+		name:   "cron with time zone",
+		time:   saturday,
+		window: "TZ=America/New_York * 6 * * *",
+		want:   true,
+	}, {
+		name:    "invalid weekday",
+		time:    saturday,
+		window:  "Someday",
+		wantErr: true,
+	}, {
+		name:    "invalid time zone",
+		time:    saturday,
+		window:  "TZ=Mars/Olympus Sat",
+		wantErr: true,
+	}, {
+		name:    "invalid window",
+		time:    saturday,
+		window:  "* * *",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := InTimeWindow(tt.time, tt.window)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTimeWindowHandler_Evaluate(t *testing.T) {
+	handler := NewTimeWindowHandler(logr.Discard(), nil)
+	assert.True(t, handler.Evaluate("2024-06-01T10:30:00Z", "Sat,Sun"))
+	assert.True(t, handler.Evaluate("2024-06-01T10:30:00Z", []interface{}{"Mon-Fri", "Sat"}))
+	assert.False(t, handler.Evaluate("2024-06-03T10:30:00Z", []interface{}{"Sat,Sun"}))
+	assert.False(t, handler.Evaluate("not a time", "Sat,Sun"))
+	assert.False(t, handler.Evaluate(1717237800, "Sat,Sun"))
+}
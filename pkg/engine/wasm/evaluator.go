@@ -0,0 +1,285 @@
+package wasm
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+)
+
+const (
+	// allocateFunction is the function exported by modules to reserve memory for the input
+	allocateFunction = "allocate"
+	// maxModuleSize is the maximum size of a module fetched from a registry
+	maxModuleSize = 16 * 1024 * 1024
+)
+
+var wasmMagic = []byte{0x00, 0x61, 0x73, 0x6d}
+
+// Limits bound the resources a module can use during an evaluation
+type Limits struct {
+	// MemoryPages is the maximum number of 64KiB memory pages a module can allocate
+	MemoryPages uint32
+	// Timeout is the maximum duration of an evaluation
+	Timeout time.Duration
+}
+
+type compiledModule struct {
+	digest [sha256.Size]byte
+	module wazero.CompiledModule
+}
+
+type evaluator struct {
+	runtime           wazero.Runtime
+	configMaps        corev1listers.ConfigMapNamespaceLister
+	configMapName     string
+	rclientFactory    engineapi.RegistryClientFactory
+	allowedRegistries []string
+	limits            Limits
+	lock              sync.Mutex
+	modules           map[string]compiledModule
+}
+
+// NewEvaluator returns a WASM evaluator loading modules from the given ConfigMap or from OCI artifacts.
+// Artifacts must be referenced by digest and are only pulled from the allowed registries, no artifact
+// can be pulled when the list is empty. Modules run without access to the filesystem, the network or
+// the environment.
+func NewEvaluator(
+	ctx context.Context,
+	configMaps corev1listers.ConfigMapNamespaceLister,
+	configMapName string,
+	rclientFactory engineapi.RegistryClientFactory,
+	allowedRegistries []string,
+	limits Limits,
+) (engineapi.WasmEvaluator, error) {
+	config := wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(limits.MemoryPages).
+		WithCloseOnContextDone(true)
+	// registries are normalized the way references are, docker.io is stored as index.docker.io
+	registries := make([]string, 0, len(allowedRegistries))
+	for _, allowed := range allowedRegistries {
+		registry, err := name.NewRegistry(allowed)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed registry %s: %w", allowed, err)
+		}
+		registries = append(registries, registry.RegistryStr())
+	}
+	runtime := wazero.NewRuntimeWithConfig(ctx, config)
+	// modules built with wasi targets import these functions, no directories or sockets are granted
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, runtime); err != nil {
+		return nil, err
+	}
+	return &evaluator{
+		runtime:           runtime,
+		configMaps:        configMaps,
+		configMapName:     configMapName,
+		rclientFactory:    rclientFactory,
+		allowedRegistries: registries,
+		limits:            limits,
+		modules:           map[string]compiledModule{},
+	}, nil
+}
+
+func (e *evaluator) Evaluate(ctx context.Context, module kyvernov1.Wasm, input []byte) (*engineapi.WasmResult, error) {
+	compiled, err := e.load(ctx, module)
+	if err != nil {
+		return nil, err
+	}
+	if e.limits.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.limits.Timeout)
+		defer cancel()
+	}
+	// every evaluation gets a fresh instance, no state is shared between calls
+	instance, err := e.runtime.InstantiateModule(ctx, compiled, wazero.NewModuleConfig().WithName("").WithStartFunctions("_initialize"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate module: %w", err)
+	}
+	defer instance.Close(ctx)
+	allocate := instance.ExportedFunction(allocateFunction)
+	if allocate == nil {
+		return nil, fmt.Errorf("module doesn't export function %s", allocateFunction)
+	}
+	function := instance.ExportedFunction(module.GetFunction())
+	if function == nil {
+		return nil, fmt.Errorf("module doesn't export function %s", module.GetFunction())
+	}
+	results, err := allocate.Call(ctx, uint64(len(input)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate module memory: %w", err)
+	}
+	ptr := uint32(results[0])
+	if !instance.Memory().Write(ptr, input) {
+		return nil, errors.New("failed to write input to module memory")
+	}
+	results, err = function.Call(ctx, uint64(ptr), uint64(len(input)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to call function %s: %w", module.GetFunction(), err)
+	}
+	// the result packs the pointer and the length of the JSON output
+	output, ok := instance.Memory().Read(uint32(results[0]>>32), uint32(results[0]))
+	if !ok {
+		return nil, errors.New("failed to read output from module memory")
+	}
+	var result engineapi.WasmResult
+	if err := json.Unmarshal(output, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode module output: %w", err)
+	}
+	return &result, nil
+}
+
+func (e *evaluator) load(ctx context.Context, module kyvernov1.Wasm) (wazero.CompiledModule, error) {
+	if module.Module != "" {
+		return e.loadConfigMap(ctx, module.Module)
+	}
+	if module.Image != "" {
+		return e.loadImage(ctx, module.Image)
+	}
+	return nil, errors.New("one of module or image must be set")
+}
+
+func (e *evaluator) loadConfigMap(ctx context.Context, name string) (wazero.CompiledModule, error) {
+	if e.configMaps == nil || e.configMapName == "" {
+		return nil, errors.New("no WASM modules ConfigMap is configured")
+	}
+	configMap, err := e.configMaps.Get(e.configMapName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get WASM modules ConfigMap: %w", err)
+	}
+	data, ok := configMap.BinaryData[name]
+	if !ok {
+		return nil, fmt.Errorf("module %s not found in ConfigMap %s", name, e.configMapName)
+	}
+	return e.compile(ctx, "module:"+name, data)
+}
+
+func (e *evaluator) loadImage(ctx context.Context, ref string) (wazero.CompiledModule, error) {
+	digest, err := e.checkImage(ref)
+	if err != nil {
+		return nil, err
+	}
+	// artifacts are pinned by digest, their content never changes once compiled
+	key := "image:" + ref
+	e.lock.Lock()
+	cached, ok := e.modules[key]
+	e.lock.Unlock()
+	if ok {
+		return cached.module, nil
+	}
+	data, err := e.fetchImage(ctx, ref, digest)
+	if err != nil {
+		return nil, err
+	}
+	return e.compile(ctx, key, data)
+}
+
+// checkImage returns the digest of the given reference, it fails when the reference is not pinned by
+// digest or when its registry is not allowed
+func (e *evaluator) checkImage(ref string) (string, error) {
+	digest, err := parseImage(ref)
+	if err != nil {
+		return "", err
+	}
+	if registry := digest.Context().RegistryStr(); !slices.Contains(e.allowedRegistries, registry) {
+		return "", fmt.Errorf("module %s is not pulled from an allowed registry, registry %s is not allowed", ref, registry)
+	}
+	return digest.DigestStr(), nil
+}
+
+func (e *evaluator) fetchImage(ctx context.Context, ref string, digest string) ([]byte, error) {
+	if e.rclientFactory == nil {
+		return nil, errors.New("no registry client is configured")
+	}
+	rclient, err := e.rclientFactory.GetClient(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	desc, err := rclient.FetchImageDescriptor(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch module %s: %w", ref, err)
+	}
+	if desc.Digest.String() != digest {
+		return nil, fmt.Errorf("module %s digest mismatch, got %s", ref, desc.Digest)
+	}
+	img, err := desc.Image()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read module %s: %w", ref, err)
+	}
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read module %s: %w", ref, err)
+	}
+	if len(layers) != 1 {
+		return nil, fmt.Errorf("module %s must have exactly one layer, found %d", ref, len(layers))
+	}
+	// wasm artifacts store the module as is, the blob is not compressed
+	reader, err := layers[0].Compressed()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read module %s: %w", ref, err)
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(io.LimitReader(reader, maxModuleSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read module %s: %w", ref, err)
+	}
+	if len(data) > maxModuleSize {
+		return nil, fmt.Errorf("module %s exceeds %d bytes", ref, maxModuleSize)
+	}
+	layerDigest, err := layers[0].Digest()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read module %s: %w", ref, err)
+	}
+	if layerDigest.Algorithm != "sha256" || layerDigest.Hex != fmt.Sprintf("%x", sha256.Sum256(data)) {
+		return nil, fmt.Errorf("module %s layer digest mismatch, expected %s", ref, layerDigest)
+	}
+	return data, nil
+}
+
+// parseImage parses a module reference, modules must be referenced by digest
+func parseImage(ref string) (name.Digest, error) {
+	digest, err := name.NewDigest(ref)
+	if err != nil {
+		return name.Digest{}, fmt.Errorf("module %s must be referenced by digest: %w", ref, err)
+	}
+	return digest, nil
+}
+
+// compile returns the compiled module for the given source, the module is only recompiled when its content changes
+func (e *evaluator) compile(ctx context.Context, key string, data []byte) (wazero.CompiledModule, error) {
+	if !bytes.HasPrefix(data, wasmMagic) {
+		return nil, fmt.Errorf("%s is not a WASM module", key)
+	}
+	digest := sha256.Sum256(data)
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if cached, ok := e.modules[key]; ok {
+		if cached.digest == digest {
+			return cached.module, nil
+		}
+		// instances already running keep a reference on the previous module
+		defer cached.module.Close(ctx)
+	}
+	compiled, err := e.runtime.CompileModule(ctx, data)
+	if err != nil {
+		delete(e.modules, key)
+		return nil, fmt.Errorf("failed to compile %s: %w", key, err)
+	}
+	e.modules[key] = compiledModule{
+		digest: digest,
+		module: compiled,
+	}
+	return compiled, nil
+}
@@ -0,0 +1,139 @@
+package wasm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// denyModule exports `allocate` and `validate`, the latter always returns {"allowed":false,"message":"denied"}
+var denyModule = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00, 0x01, 0x0c, 0x02, 0x60, 0x01, 0x7f, 0x01, 0x7f,
+	0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7e, 0x03, 0x03, 0x02, 0x00, 0x01, 0x05, 0x03, 0x01, 0x00, 0x01,
+	0x07, 0x20, 0x03, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x02, 0x00, 0x08, 0x61, 0x6c, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x65, 0x00, 0x00, 0x08, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x00, 0x01, 0x0a, 0x0c, 0x02, 0x05, 0x00, 0x41, 0x80, 0x08, 0x0b, 0x04, 0x00, 0x42, 0x24, 0x0b,
+	0x0b, 0x2a, 0x01, 0x00, 0x41, 0x00, 0x0b, 0x24, 0x7b, 0x22, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65,
+	0x64, 0x22, 0x3a, 0x66, 0x61, 0x6c, 0x73, 0x65, 0x2c, 0x22, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0x3a, 0x22, 0x64, 0x65, 0x6e, 0x69, 0x65, 0x64, 0x22, 0x7d,
+}
+
+func newConfigMapLister(t *testing.T, data map[string][]byte) corev1listers.ConfigMapNamespaceLister {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	assert.NoError(t, indexer.Add(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kyverno", Name: "kyverno-wasm-modules"},
+		BinaryData: data,
+	}))
+	return corev1listers.NewConfigMapLister(indexer).ConfigMaps("kyverno")
+}
+
+func TestEvaluate(t *testing.T) {
+	ctx := context.TODO()
+	lister := newConfigMapLister(t, map[string][]byte{
+		"deny":    denyModule,
+		"invalid": []byte("not a module"),
+	})
+	evaluator, err := NewEvaluator(ctx, lister, "kyverno-wasm-modules", nil, nil, Limits{MemoryPages: 16, Timeout: time.Second})
+	assert.NoError(t, err)
+	tests := []struct {
+		name    string
+		module  kyvernov1.Wasm
+		want    bool
+		message string
+		wantErr bool
+	}{{
+		name:    "deny",
+		module:  kyvernov1.Wasm{Module: "deny"},
+		want:    false,
+		message: "denied",
+	}, {
+		name:    "missing module",
+		module:  kyvernov1.Wasm{Module: "missing"},
+		wantErr: true,
+	}, {
+		name:    "invalid module",
+		module:  kyvernov1.Wasm{Module: "invalid"},
+		wantErr: true,
+	}, {
+		name:    "missing function",
+		module:  kyvernov1.Wasm{Module: "deny", Function: "mutate"},
+		wantErr: true,
+	}, {
+		name:    "image not allowed",
+		module:  kyvernov1.Wasm{Image: "ghcr.io/kyverno/wasm:latest"},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := evaluator.Evaluate(ctx, tt.module, []byte(`{"object":{}}`))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, result.Allowed)
+			assert.Equal(t, tt.message, result.Message)
+		})
+	}
+}
+
+func TestEvaluateMemoryLimit(t *testing.T) {
+	ctx := context.TODO()
+	lister := newConfigMapLister(t, map[string][]byte{"deny": denyModule})
+	evaluator, err := NewEvaluator(ctx, lister, "kyverno-wasm-modules", nil, nil, Limits{MemoryPages: 1, Timeout: time.Second})
+	assert.NoError(t, err)
+	// the module allocates at offset 1024, an input larger than a page doesn't fit
+	_, err = evaluator.Evaluate(ctx, kyvernov1.Wasm{Module: "deny"}, make([]byte, 64*1024))
+	assert.Error(t, err)
+}
+
+func TestEvaluateImage(t *testing.T) {
+	ctx := context.TODO()
+	lister := newConfigMapLister(t, nil)
+	evaluator, err := NewEvaluator(ctx, lister, "kyverno-wasm-modules", nil, []string{"ghcr.io", "docker.io"}, Limits{MemoryPages: 16, Timeout: time.Second})
+	assert.NoError(t, err)
+	digest := "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	tests := []struct {
+		name  string
+		image string
+		err   string
+	}{{
+		name:  "referenced by tag",
+		image: "ghcr.io/kyverno/wasm:latest",
+		err:   "must be referenced by digest",
+	}, {
+		name:  "registry not allowed",
+		image: "quay.io/kyverno/wasm@" + digest,
+		err:   "registry quay.io is not allowed",
+	}, {
+		name:  "docker hub allowed",
+		image: "kyverno/wasm@" + digest,
+		err:   "no registry client is configured",
+	}, {
+		// the reference passes the checks, the evaluator has no client to pull it
+		name:  "allowed",
+		image: "ghcr.io/kyverno/wasm@" + digest,
+		err:   "no registry client is configured",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := evaluator.Evaluate(ctx, kyvernov1.Wasm{Image: tt.image}, []byte(`{"object":{}}`))
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestEvaluateImageNoAllowedRegistries(t *testing.T) {
+	ctx := context.TODO()
+	evaluator, err := NewEvaluator(ctx, newConfigMapLister(t, nil), "kyverno-wasm-modules", nil, nil, Limits{MemoryPages: 16, Timeout: time.Second})
+	assert.NoError(t, err)
+	_, err = evaluator.Evaluate(ctx, kyvernov1.Wasm{Image: "ghcr.io/kyverno/wasm@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"}, []byte(`{"object":{}}`))
+	assert.ErrorContains(t, err, "registry ghcr.io is not allowed")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -40,6 +41,7 @@ type Interface interface {
 type controller struct {
 	logger               logr.Logger
 	eventsClient         v1.EventsV1Interface
+	omitLock             sync.RWMutex
 	omitEvents           sets.Set[string]
 	queue                workqueue.TypedRateLimitingInterface[any]
 	clock                clock.Clock
@@ -72,6 +74,19 @@ func NewEventGenerator(eventsClient v1.EventsV1Interface, logger logr.Logger, ma
 	}
 }
 
+// SetOmitEvents replaces the reasons of the events that are not emitted
+func (gen *controller) SetOmitEvents(omitEvents ...string) {
+	gen.omitLock.Lock()
+	defer gen.omitLock.Unlock()
+	gen.omitEvents = sets.New(omitEvents...)
+}
+
+func (gen *controller) isOmitted(reason Reason) bool {
+	gen.omitLock.RLock()
+	defer gen.omitLock.RUnlock()
+	return gen.omitEvents.Has(string(reason))
+}
+
 // Add queues an event for generation
 func (gen *controller) Add(infos ...Info) {
 	logger := gen.logger
@@ -86,7 +101,7 @@ func (gen *controller) Add(infos ...Info) {
 			logger.V(3).Info("skipping event creation for resource without a name", "kind", info.Regarding.Kind, "name", info.Regarding.Name, "namespace", info.Regarding.Namespace)
 			continue
 		}
-		if gen.omitEvents.Has(string(info.Reason)) {
+		if gen.isOmitted(info.Reason) {
 			logger.V(6).Info("omitting event", "kind", info.Regarding.Kind, "name", info.Regarding.Name, "namespace", info.Regarding.Namespace, "reason", info.Reason)
 			continue
 		}
@@ -144,7 +159,7 @@ func (gen *controller) emitEvent(key Info) {
 	eventType := corev1.EventTypeWarning
 	if key.Type != "" {
 		eventType = key.Type
-	} else if key.Reason == PolicyApplied || key.Reason == PolicySkipped {
+	} else if key.Reason == PolicyApplied || key.Reason == PolicySkipped || key.Reason == AdmissionRecovered {
 		eventType = corev1.EventTypeNormal
 	}
 
@@ -52,3 +52,21 @@ func TestEventGenerator(t *testing.T) {
 		t.Fatal("event not created")
 	}
 }
+
+func TestEventGenerator_SetOmitEvents(t *testing.T) {
+	eventGenerator := NewEventGenerator(fake.NewSimpleClientset().EventsV1(), logr.Discard(), 1000, string(PolicyApplied))
+	info := Info{
+		Regarding: corev1.ObjectReference{Kind: "Pod", Name: "pod", Namespace: "default"},
+		Reason:    PolicyApplied,
+		Source:    PolicyController,
+	}
+	eventGenerator.Add(info)
+	if eventGenerator.queue.Len() != 0 {
+		t.Fatal("omitted event queued")
+	}
+	eventGenerator.SetOmitEvents()
+	eventGenerator.Add(info)
+	if eventGenerator.queue.Len() != 1 {
+		t.Fatal("event not queued")
+	}
+}
@@ -3,9 +3,11 @@ package event
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/config"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -227,6 +229,40 @@ func NewBackgroundSuccessEvent(source Source, policy kyvernov1.PolicyInterface,
 	return events
 }
 
+// NewDryRunEvents builds the events reporting the resources a policy in dry run mode would have mutated or generated
+func NewDryRunEvents(source Source, policy kyvernov1.PolicyInterface, resources []*unstructured.Unstructured) []Info {
+	events := make([]Info, 0, len(resources))
+	msg := "resource would be generated (dry run)"
+	if source == MutateExistingController {
+		msg = "resource would be mutated (dry run)"
+	}
+	regarding := corev1.ObjectReference{
+		// TODO: iirc it's not safe to assume api version is set
+		APIVersion: "kyverno.io/v1",
+		Kind:       policy.GetKind(),
+		Name:       policy.GetName(),
+		Namespace:  policy.GetNamespace(),
+		UID:        policy.GetUID(),
+	}
+	for _, res := range resources {
+		events = append(events, Info{
+			Regarding: regarding,
+			Related: &corev1.ObjectReference{
+				APIVersion: res.GetAPIVersion(),
+				Kind:       res.GetKind(),
+				Name:       res.GetName(),
+				Namespace:  res.GetNamespace(),
+				UID:        res.GetUID(),
+			},
+			Source:  source,
+			Reason:  PolicyApplied,
+			Message: msg,
+			Action:  None,
+		})
+	}
+	return events
+}
+
 func NewPolicyExceptionEvents(engineResponse engineapi.EngineResponse, ruleResp engineapi.RuleResponse, source Source) []Info {
 	var exceptionMessage string
 	exceptions := ruleResp.Exceptions()
@@ -335,6 +371,29 @@ func NewCleanupPolicyEvent(policy kyvernov2.CleanupPolicyInterface, resource uns
 	}
 }
 
+func NewCleanupPolicyDryRunEvent(policy kyvernov2.CleanupPolicyInterface, resource unstructured.Unstructured) Info {
+	return Info{
+		Regarding: corev1.ObjectReference{
+			// TODO: iirc it's not safe to assume api version is set
+			APIVersion: "kyverno.io/v2",
+			Kind:       policy.GetKind(),
+			Name:       policy.GetName(),
+			Namespace:  policy.GetNamespace(),
+			UID:        policy.GetUID(),
+		},
+		Related: &corev1.ObjectReference{
+			APIVersion: resource.GetAPIVersion(),
+			Kind:       resource.GetKind(),
+			Namespace:  resource.GetNamespace(),
+			Name:       resource.GetName(),
+		},
+		Source:  CleanupController,
+		Action:  None,
+		Reason:  CleanupDryRun,
+		Message: fmt.Sprintf("the target resource %v/%v/%v would be cleaned up (dry run)", resource.GetKind(), resource.GetNamespace(), resource.GetName()),
+	}
+}
+
 func NewValidatingAdmissionPolicyEvent(policy kyvernov1.PolicyInterface, vapName, vapBindingName string) []Info {
 	regarding := corev1.ObjectReference{
 		// TODO: iirc it's not safe to assume api version is set
@@ -400,3 +459,88 @@ func resourceKey(resource unstructured.Unstructured) string {
 
 	return strings.Join([]string{resource.GetKind(), resource.GetName()}, "/")
 }
+
+// NewPolicySkippedEvent builds the event reporting a policy skipped at admission time because of the skip policies annotation
+func NewPolicySkippedEvent(source Source, policy kyvernov1.PolicyInterface, resource corev1.ObjectReference, annotation, username string) Info {
+	var res string
+	if resource.Namespace != "" {
+		res = fmt.Sprintf("%s %s/%s", resource.Kind, resource.Namespace, resource.Name)
+	} else {
+		res = fmt.Sprintf("%s %s", resource.Kind, resource.Name)
+	}
+	regarding := corev1.ObjectReference{
+		// TODO: iirc it's not safe to assume api version is set
+		APIVersion: "kyverno.io/v1",
+		Kind:       policy.GetKind(),
+		Name:       policy.GetName(),
+		Namespace:  policy.GetNamespace(),
+		UID:        policy.GetUID(),
+	}
+	return Info{
+		Regarding: regarding,
+		Related:   &resource,
+		Source:    source,
+		Reason:    PolicySkipped,
+		Message:   fmt.Sprintf("%s: policy skipped by annotation %s set by %s", res, annotation, username),
+		Action:    None,
+	}
+}
+
+func secretReference(namespace, name string) corev1.ObjectReference {
+	return corev1.ObjectReference{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Namespace:  namespace,
+		Name:       name,
+	}
+}
+
+func NewCertificateRotatedEvent(namespace, name string, expiration time.Time) Info {
+	return Info{
+		Regarding: secretReference(namespace, name),
+		Source:    CertManagerController,
+		Action:    None,
+		Reason:    CertificateRotated,
+		Type:      corev1.EventTypeNormal,
+		Message:   fmt.Sprintf("certificate rotated, it expires at %s", expiration.UTC().Format(time.RFC3339)),
+	}
+}
+
+func NewCertificateRenewalFailedEvent(namespace, name string, err error) Info {
+	return Info{
+		Regarding: secretReference(namespace, name),
+		Source:    CertManagerController,
+		Action:    None,
+		Reason:    CertificateRenewalFailed,
+		Type:      corev1.EventTypeWarning,
+		Message:   fmt.Sprintf("failed to renew certificate: %v", err),
+	}
+}
+
+// NewSettingsChangedEvent returns an event reporting the settings changed in a configmap, settings that are not set are
+// reported as using the flag value
+func NewSettingsChangedEvent(source Source, namespace, name string, changes ...config.SettingChange) Info {
+	value := func(in string) string {
+		if in == "" {
+			return "flag value"
+		}
+		return in
+	}
+	var messages []string
+	for _, change := range changes {
+		messages = append(messages, fmt.Sprintf("%s changed from %s to %s", change.Name, value(change.Previous), value(change.Current)))
+	}
+	return Info{
+		Regarding: corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+			Namespace:  namespace,
+			Name:       name,
+		},
+		Source:  source,
+		Action:  None,
+		Reason:  SettingsChanged,
+		Type:    corev1.EventTypeNormal,
+		Message: fmt.Sprintf("settings reloaded: %s", strings.Join(messages, ", ")),
+	}
+}
@@ -8,4 +8,16 @@ const (
 	PolicyApplied   Reason = "PolicyApplied"
 	PolicyError     Reason = "PolicyError"
 	PolicySkipped   Reason = "PolicySkipped"
+	// AdmissionDegraded is emitted when audit policies are skipped because the admission controller is overloaded
+	AdmissionDegraded Reason = "AdmissionDegraded"
+	// AdmissionRecovered is emitted when audit policies are processed again
+	AdmissionRecovered Reason = "AdmissionRecovered"
+	// CleanupDryRun is emitted for every resource a cleanup policy in dry run mode would delete
+	CleanupDryRun Reason = "CleanupDryRun"
+	// CertificateRotated is emitted when a certificate managed by kyverno is rotated
+	CertificateRotated Reason = "CertificateRotated"
+	// CertificateRenewalFailed is emitted when a certificate managed by kyverno can't be renewed
+	CertificateRenewalFailed Reason = "CertificateRenewalFailed"
+	// SettingsChanged is emitted when settings overriding flags are changed in the kyverno configmap
+	SettingsChanged Reason = "SettingsChanged"
 )
@@ -14,4 +14,6 @@ const (
 	MutateExistingController Source = "kyverno-mutate"
 	// CleanupController : event generated for cleanup policies
 	CleanupController Source = "kyverno-cleanup"
+	// CertManagerController : event generated for certificates managed by kyverno
+	CertManagerController Source = "kyverno-certmanager"
 )
@@ -2,6 +2,7 @@ package externalapi
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sync"
 	"time"
@@ -12,8 +13,10 @@ import (
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/pkg/engine/apicall"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
 	entryevent "github.com/kyverno/kyverno/pkg/globalcontext/event"
+	entrymetrics "github.com/kyverno/kyverno/pkg/globalcontext/metrics"
 	"github.com/kyverno/kyverno/pkg/globalcontext/store"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	corev1 "k8s.io/api/core/v1"
@@ -39,7 +42,11 @@ func New(
 	client apicall.ClientInterface,
 	call kyvernov1.APICall,
 	period time.Duration,
-	maxResponseLength int64,
+	jitter time.Duration,
+	retryLimit int,
+	config apicall.APICallConfiguration,
+	projection jmespath.Query,
+	recorder entrymetrics.Recorder,
 	shouldUpdateStatus bool,
 ) (store.Entry, error) {
 	var group wait.Group
@@ -55,15 +62,16 @@ func New(
 	}
 
 	group.StartWithContext(ctx, func(ctx context.Context) {
-		config := apicall.NewAPICallConfiguration(maxResponseLength)
-		caller := apicall.NewExecutor(logger, "globalcontext", client, config)
+		caller := apicall.NewExecutor(logger, "globalcontext", client, config, nil)
 
-		wait.UntilWithContext(ctx, func(ctx context.Context) {
-			if data, err := doCall(ctx, caller, call, gce.Spec.APICall.RetryLimit); err != nil {
+		wait.JitterUntilWithContext(ctx, func(ctx context.Context) {
+			start := time.Now()
+			if data, err := fetch(ctx, caller, call, retryLimit, projection); err != nil {
 				e.setData(nil, err)
 
 				logger.Error(err, "failed to get data from api caller")
 
+				recorder.RecordError(ctx, gce.Name)
 				eventGen.Add(entryevent.NewErrorEvent(corev1.ObjectReference{
 					APIVersion: gce.APIVersion,
 					Kind:       gce.Kind,
@@ -73,22 +81,34 @@ func New(
 				}, err))
 
 				if shouldUpdateStatus {
-					if updateErr := updateStatus(ctx, gce, kyvernoClient, false, entryevent.ReasonAPICallFailure); updateErr != nil {
+					if updateErr := updateStatus(ctx, gce, kyvernoClient, func(status *kyvernov2alpha1.GlobalContextEntryStatus) {
+						status.SetReady(false, entryevent.ReasonAPICallFailure)
+						status.SetRefreshFailed(err.Error())
+					}); updateErr != nil {
 						logger.Error(updateErr, "failed to update status")
 					}
 				}
 			} else {
+				latency := time.Since(start)
 				e.setData(data, nil)
 
 				logger.V(4).Info("api call success", "data", data)
 
+				size := dataSize(data)
+				recorder.RecordRefresh(ctx, gce.Name, latency)
+				recorder.RecordSize(gce.Name, size)
+
 				if shouldUpdateStatus {
-					if updateErr := updateStatus(ctx, gce, kyvernoClient, true, "APICallSuccess"); updateErr != nil {
+					if updateErr := updateStatus(ctx, gce, kyvernoClient, func(status *kyvernov2alpha1.GlobalContextEntryStatus) {
+						status.SetReady(true, "APICallSuccess")
+						status.SetRefreshed(latency)
+						status.Size = size
+					}); updateErr != nil {
 						logger.Error(updateErr, "failed to update status")
 					}
 				}
 			}
-		}, period)
+		}, period, jitterFactor(period, jitter), true)
 	})
 
 	return e, nil
@@ -127,6 +147,47 @@ func (e *entry) setData(data any, err error) {
 	}
 }
 
+// jitterFactor returns the factor of the period used to spread the calls by at most jitter
+func jitterFactor(period, jitter time.Duration) float64 {
+	if period <= 0 || jitter <= 0 {
+		return 0
+	}
+	return float64(jitter) / float64(period)
+}
+
+// dataSize returns the approximate size in bytes of the data returned by the api call
+func dataSize(data any) int64 {
+	if raw, ok := data.([]byte); ok {
+		return int64(len(raw))
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return 0
+	}
+	return int64(len(raw))
+}
+
+// fetch executes the api call and applies the projection to the response
+func fetch(ctx context.Context, caller apicall.Executor, call kyvernov1.APICall, retryLimit int, projection jmespath.Query) (any, error) {
+	data, err := doCall(ctx, caller, call, retryLimit)
+	if err != nil || projection == nil {
+		return data, err
+	}
+	var value any
+	if raw, ok := data.([]byte); ok {
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal api call response: %w", err)
+		}
+	} else {
+		value = data
+	}
+	projected, err := projection.Search(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply projection: %w", err)
+	}
+	return json.Marshal(projected)
+}
+
 func doCall(ctx context.Context, caller apicall.Executor, call kyvernov1.APICall, retryLimit int) (any, error) {
 	var result any
 	backoff := wait.Backoff{
@@ -147,7 +208,7 @@ func doCall(ctx context.Context, caller apicall.Executor, call kyvernov1.APICall
 	return result, retryError
 }
 
-func updateStatus(ctx context.Context, gce *kyvernov2alpha1.GlobalContextEntry, kyvernoClient versioned.Interface, ready bool, reason string) error {
+func updateStatus(ctx context.Context, gce *kyvernov2alpha1.GlobalContextEntry, kyvernoClient versioned.Interface, update func(*kyvernov2alpha1.GlobalContextEntryStatus)) error {
 	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		latestGCE, getErr := kyvernoClient.KyvernoV2alpha1().GlobalContextEntries().Get(ctx, gce.GetName(), metav1.GetOptions{})
 		if getErr != nil {
@@ -158,10 +219,7 @@ func updateStatus(ctx context.Context, gce *kyvernov2alpha1.GlobalContextEntry,
 			if latest == nil {
 				return fmt.Errorf("failed to update status: %s", gce.GetName())
 			}
-			latest.Status.SetReady(ready, reason)
-			if ready {
-				latest.Status.UpdateRefreshTime()
-			}
+			update(&latest.Status)
 			return nil
 		}, nil)
 	})
@@ -2,31 +2,54 @@ package k8sresource
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-logr/logr"
 	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
 	entryevent "github.com/kyverno/kyverno/pkg/globalcontext/event"
+	entrymetrics "github.com/kyverno/kyverno/pkg/globalcontext/metrics"
 	"github.com/kyverno/kyverno/pkg/globalcontext/store"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/dynamic"
-	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/retry"
 )
 
+const (
+	// projectionField is the field holding the projected data of a resource in the informer cache
+	projectionField = "projection"
+	// listPageSize is the number of resources fetched by every list call when the informer syncs
+	listPageSize = 500
+	// sizeStatusInterval is the interval at which the entry size is reported in the status
+	sizeStatusInterval = time.Minute
+)
+
+// errMaxItemsExceeded is returned when listing more resources than the entry limit
+var errMaxItemsExceeded = errors.New("max items exceeded")
+
 type entry struct {
-	lister   cache.GenericLister
-	stop     func()
-	gce      *kyvernov2alpha1.GlobalContextEntry
-	eventGen event.Interface
+	lister     cache.GenericLister
+	sizes      *sizeTracker
+	stop       func()
+	gce        *kyvernov2alpha1.GlobalContextEntry
+	eventGen   event.Interface
+	projection jmespath.Query
 }
 
 // TODO: Handle Kyverno Pod Ready State
@@ -39,6 +62,8 @@ func New(
 	logger logr.Logger,
 	gvr schema.GroupVersionResource,
 	namespace string,
+	projection jmespath.Query,
+	recorder entrymetrics.Recorder,
 	shouldUpdateStatus bool,
 ) (store.Entry, error) {
 	indexers := cache.Indexers{
@@ -47,7 +72,18 @@ func New(
 	if namespace == "" {
 		namespace = metav1.NamespaceAll
 	}
-	informer := dynamicinformer.NewFilteredDynamicInformer(client, gvr, namespace, 0, indexers, nil)
+	listWatch := newListWatch(client.Resource(gvr).Namespace(namespace), gce.Spec.KubernetesResource.MaxItems)
+	var resyncPeriod time.Duration
+	if gce.Spec.KubernetesResource.ResyncPeriod != nil {
+		resyncPeriod = gce.Spec.KubernetesResource.ResyncPeriod.Duration
+	}
+	informer := cache.NewSharedIndexInformer(listWatch, &unstructured.Unstructured{}, resyncPeriod, indexers)
+	if projection != nil {
+		if err := informer.SetTransform(projectionTransform(logger, projection)); err != nil {
+			logger.Error(err, "failed to set projection transform")
+			return nil, err
+		}
+	}
 	var group wait.Group
 	ctx, cancel := context.WithCancel(ctx)
 	stop := func() {
@@ -56,14 +92,25 @@ func New(
 		// Wait for the group to terminate
 		group.Wait()
 	}
-	err := informer.Informer().SetWatchErrorHandler(func(r *cache.Reflector, err error) {
+	var exceeded atomic.Bool
+	failureReason := func() string {
+		if exceeded.Load() {
+			return "MaxItemsExceeded"
+		}
+		return "CacheSyncFailure"
+	}
+	err := informer.SetWatchErrorHandler(func(r *cache.Reflector, err error) {
+		if errors.Is(err, errMaxItemsExceeded) {
+			exceeded.Store(true)
+		}
+		recorder.RecordError(ctx, gce.Name)
 		if shouldUpdateStatus {
-			if err := updateStatus(ctx, gce, kyvernoClient, false, "CacheSyncFailure"); err != nil {
+			if err := updateStatus(ctx, gce, kyvernoClient, false, failureReason(), 0); err != nil {
 				logger.Error(err, "failed to update status")
 			}
 		}
 
-		eventErr := fmt.Errorf("failed to run informer for %s", gvr)
+		eventErr := fmt.Errorf("failed to run informer for %s: %w", gvr, err)
 		eventGen.Add(entryevent.NewErrorEvent(corev1.ObjectReference{
 			APIVersion: gce.APIVersion,
 			Kind:       gce.Kind,
@@ -78,15 +125,22 @@ func New(
 		logger.Error(err, "failed to set watch error handler")
 		return nil, err
 	}
+	sizes := newSizeTracker()
+	if _, err := informer.AddEventHandler(sizes); err != nil {
+		logger.Error(err, "failed to add size event handler")
+		return nil, err
+	}
 
+	start := time.Now()
 	group.StartWithContext(ctx, func(ctx context.Context) {
-		informer.Informer().Run(ctx.Done())
+		informer.Run(ctx.Done())
 	})
-	if !cache.WaitForCacheSync(ctx.Done(), informer.Informer().HasSynced) {
+	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
 		stop()
 
+		recorder.RecordError(ctx, gce.Name)
 		if shouldUpdateStatus {
-			if err := updateStatus(ctx, gce, kyvernoClient, false, "CacheSyncFailure"); err != nil {
+			if err := updateStatus(ctx, gce, kyvernoClient, false, failureReason(), 0); err != nil {
 				logger.Error(err, "failed to update status")
 			}
 		}
@@ -103,17 +157,84 @@ func New(
 		return nil, err
 	}
 
+	e := &entry{
+		lister:     cache.NewGenericLister(informer.GetIndexer(), gvr.GroupResource()),
+		sizes:      sizes,
+		stop:       stop,
+		gce:        gce,
+		eventGen:   eventGen,
+		projection: projection,
+	}
+
+	latency := time.Since(start)
+	recorder.RecordRefresh(ctx, gce.Name, latency)
 	if shouldUpdateStatus {
-		if err := updateStatus(ctx, gce, kyvernoClient, true, "CacheSyncSuccess"); err != nil {
+		if err := updateStatus(ctx, gce, kyvernoClient, true, "CacheSyncSuccess", latency); err != nil {
 			logger.Error(err, "failed to update status")
 		}
 	}
+	group.StartWithContext(ctx, func(ctx context.Context) {
+		wait.UntilWithContext(ctx, func(ctx context.Context) {
+			_, size := sizes.size()
+			recorder.RecordSize(gce.Name, size)
+			if !shouldUpdateStatus {
+				return
+			}
+			if err := e.updateSizeStatus(ctx, kyvernoClient); err != nil {
+				logger.Error(err, "failed to update size status")
+			}
+		}, sizeStatusInterval)
+	})
 
-	return &entry{
-		lister:   informer.Lister(),
-		stop:     stop,
-		eventGen: eventGen,
-	}, nil
+	return e, nil
+}
+
+// newListWatch returns the list and watch functions of the entry informer.
+// Lists fail once they return more resources than maxItems so that the cache never holds more than the limit.
+func newListWatch(client dynamic.ResourceInterface, maxItems int) *cache.ListWatch {
+	var synced atomic.Bool
+	var listed int
+	return &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			// pages of the same list are fetched sequentially by the reflector
+			if options.Continue == "" {
+				listed = 0
+			}
+			paginate(&options, !synced.Load())
+			list, err := client.List(context.TODO(), options)
+			if err != nil {
+				return nil, err
+			}
+			listed += len(list.Items)
+			if maxItems > 0 && listed > maxItems {
+				return nil, fmt.Errorf("%w: listed more than %d items", errMaxItemsExceeded, maxItems)
+			}
+			if list.GetContinue() == "" {
+				synced.Store(true)
+			}
+			return list, nil
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return client.Watch(context.TODO(), options)
+		},
+	}
+}
+
+// paginate makes the informer list resources in pages.
+// Lists at resource version 0 are served from the api server watch cache in a single response whatever the limit,
+// the resource version of the initial list is cleared so that it is fetched page by page following the continue tokens.
+// Relists keep reading from the watch cache to avoid hitting etcd every time a watch expires.
+func paginate(options *metav1.ListOptions, initial bool) {
+	if options.Watch {
+		return
+	}
+	if options.Limit == 0 {
+		options.Limit = listPageSize
+	}
+	if initial && options.ResourceVersion == "0" && options.Continue == "" {
+		options.ResourceVersion = ""
+		options.ResourceVersionMatch = ""
+	}
 }
 
 func (e *entry) Get() (any, error) {
@@ -128,14 +249,158 @@ func (e *entry) Get() (any, error) {
 		}, err))
 		return nil, err
 	}
-	return obj, nil
+	if maxItems := e.gce.Spec.KubernetesResource.MaxItems; maxItems > 0 && len(obj) > maxItems {
+		return nil, fmt.Errorf("entry holds %d items, exceeding the limit of %d", len(obj), maxItems)
+	}
+	if e.projection == nil {
+		return obj, nil
+	}
+	projected := make([]any, 0, len(obj))
+	for _, o := range obj {
+		if u, ok := o.(*unstructured.Unstructured); ok {
+			projected = append(projected, u.Object[projectionField])
+		}
+	}
+	return projected, nil
+}
+
+// projectionTransform returns an informer transform replacing resources with the result of the projection,
+// only the metadata needed by the informer cache is kept alongside the projected data
+func projectionTransform(logger logr.Logger, projection jmespath.Query) cache.TransformFunc {
+	return func(obj any) (any, error) {
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return obj, nil
+		}
+		projected := &unstructured.Unstructured{Object: map[string]any{}}
+		projected.SetAPIVersion(u.GetAPIVersion())
+		projected.SetKind(u.GetKind())
+		projected.SetNamespace(u.GetNamespace())
+		projected.SetName(u.GetName())
+		projected.SetUID(u.GetUID())
+		projected.SetResourceVersion(u.GetResourceVersion())
+		data, err := projection.Search(u.Object)
+		if err != nil {
+			logger.Error(err, "failed to apply projection", "namespace", u.GetNamespace(), "name", u.GetName())
+			return projected, nil
+		}
+		// round trip through json to make sure the data can be deep copied by the informer
+		raw, err := json.Marshal(data)
+		if err != nil {
+			logger.Error(err, "failed to marshal projection", "namespace", u.GetNamespace(), "name", u.GetName())
+			return projected, nil
+		}
+		var value any
+		if err := json.Unmarshal(raw, &value); err != nil {
+			logger.Error(err, "failed to unmarshal projection", "namespace", u.GetNamespace(), "name", u.GetName())
+			return projected, nil
+		}
+		projected.Object[projectionField] = value
+		return projected, nil
+	}
 }
 
 func (e *entry) Stop() {
 	e.stop()
 }
 
-func updateStatus(ctx context.Context, gce *kyvernov2alpha1.GlobalContextEntry, kyvernoClient versioned.Interface, ready bool, reason string) error {
+// sizeTracker keeps the approximate size in bytes of the resources held by the informer cache,
+// resources are measured once when they are added or updated instead of walking the whole cache
+type sizeTracker struct {
+	lock  sync.Mutex
+	sizes map[string]int64
+	total int64
+}
+
+func newSizeTracker() *sizeTracker {
+	return &sizeTracker{
+		sizes: map[string]int64{},
+	}
+}
+
+func (t *sizeTracker) OnAdd(obj any, _ bool) {
+	t.set(obj)
+}
+
+func (t *sizeTracker) OnUpdate(_, obj any) {
+	t.set(obj)
+}
+
+func (t *sizeTracker) OnDelete(obj any) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.total -= t.sizes[key]
+	delete(t.sizes, key)
+}
+
+func (t *sizeTracker) set(obj any) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	key, err := cache.MetaNamespaceKeyFunc(u)
+	if err != nil {
+		return
+	}
+	data, err := json.Marshal(u.Object)
+	if err != nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.total += int64(len(data)) - t.sizes[key]
+	t.sizes[key] = int64(len(data))
+}
+
+// size returns the number of items held by the entry and their approximate size in bytes
+func (t *sizeTracker) size() (int, int64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return len(t.sizes), t.total
+}
+
+func (e *entry) updateSizeStatus(ctx context.Context, kyvernoClient versioned.Interface) error {
+	itemCount, size := e.sizes.size()
+	maxItems := e.gce.Spec.KubernetesResource.MaxItems
+	exceeded := maxItems > 0 && itemCount > maxItems
+	wasReady := false
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latestGCE, getErr := kyvernoClient.KyvernoV2alpha1().GlobalContextEntries().Get(ctx, e.gce.GetName(), metav1.GetOptions{})
+		if getErr != nil {
+			return getErr
+		}
+		wasReady = latestGCE.Status.IsReady()
+		return controllerutils.UpdateStatus(ctx, latestGCE, kyvernoClient.KyvernoV2alpha1().GlobalContextEntries(), func(latest *kyvernov2alpha1.GlobalContextEntry) error {
+			if latest == nil {
+				return fmt.Errorf("failed to update status: %s", e.gce.GetName())
+			}
+			latest.Status.SetSize(itemCount, size)
+			if exceeded {
+				latest.Status.SetReady(false, "MaxItemsExceeded")
+			} else {
+				latest.Status.SetReady(true, "CacheSyncSuccess")
+			}
+			return nil
+		}, nil)
+	})
+	// only report the limit being exceeded once, when the entry becomes not ready
+	if exceeded && wasReady {
+		e.eventGen.Add(entryevent.NewErrorEvent(corev1.ObjectReference{
+			APIVersion: e.gce.APIVersion,
+			Kind:       e.gce.Kind,
+			Name:       e.gce.Name,
+			Namespace:  e.gce.Namespace,
+			UID:        e.gce.UID,
+		}, fmt.Errorf("entry holds %d items, exceeding the limit of %d", itemCount, maxItems)))
+	}
+	return err
+}
+
+func updateStatus(ctx context.Context, gce *kyvernov2alpha1.GlobalContextEntry, kyvernoClient versioned.Interface, ready bool, reason string, latency time.Duration) error {
 	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		latestGCE, getErr := kyvernoClient.KyvernoV2alpha1().GlobalContextEntries().Get(ctx, gce.GetName(), metav1.GetOptions{})
 		if getErr != nil {
@@ -147,6 +412,11 @@ func updateStatus(ctx context.Context, gce *kyvernov2alpha1.GlobalContextEntry,
 				return fmt.Errorf("failed to update status: %s", gce.GetName())
 			}
 			latest.Status.SetReady(ready, reason)
+			if ready {
+				latest.Status.SetRefreshed(latency)
+			} else {
+				latest.Status.SetRefreshFailed(reason)
+			}
 			return nil
 		}, nil)
 		return updateErr
@@ -0,0 +1,155 @@
+package k8sresource
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/engine/jmespath"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestProjectionTransform(t *testing.T) {
+	jp := jmespath.New(config.NewDefaultConfiguration(false))
+	pod := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]any{
+			"name":            "test",
+			"namespace":       "default",
+			"resourceVersion": "42",
+			"labels":          map[string]any{"app": "nginx"},
+		},
+		"spec": map[string]any{
+			"containers": []any{map[string]any{"name": "nginx", "image": "nginx"}},
+		},
+	}}
+	tests := []struct {
+		name       string
+		projection string
+		obj        any
+		want       any
+	}{{
+		name:       "fields",
+		projection: "{name: metadata.name, labels: metadata.labels}",
+		obj:        pod,
+		want:       map[string]any{"name": "test", "labels": map[string]any{"app": "nginx"}},
+	}, {
+		name:       "missing field",
+		projection: "metadata.annotations",
+		obj:        pod,
+		want:       nil,
+	}, {
+		name:       "list",
+		projection: "spec.containers[].image",
+		obj:        pod,
+		want:       []any{"nginx"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := jp.Query(tt.projection)
+			assert.NoError(t, err)
+			out, err := projectionTransform(logr.Discard(), query)(tt.obj)
+			assert.NoError(t, err)
+			projected, ok := out.(*unstructured.Unstructured)
+			assert.True(t, ok)
+			assert.Equal(t, "default", projected.GetNamespace())
+			assert.Equal(t, "test", projected.GetName())
+			assert.Equal(t, "42", projected.GetResourceVersion())
+			assert.Nil(t, projected.Object["spec"])
+			assert.Equal(t, tt.want, projected.Object[projectionField])
+		})
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options metav1.ListOptions
+		initial bool
+		want    metav1.ListOptions
+	}{{
+		name:    "initial list",
+		options: metav1.ListOptions{ResourceVersion: "0"},
+		initial: true,
+		want:    metav1.ListOptions{Limit: listPageSize},
+	}, {
+		name:    "continued list",
+		options: metav1.ListOptions{Limit: listPageSize, Continue: "token"},
+		initial: true,
+		want:    metav1.ListOptions{Limit: listPageSize, Continue: "token"},
+	}, {
+		name:    "relist from the watch cache",
+		options: metav1.ListOptions{ResourceVersion: "0"},
+		want:    metav1.ListOptions{ResourceVersion: "0", Limit: listPageSize},
+	}, {
+		name:    "relist from a resource version",
+		options: metav1.ListOptions{ResourceVersion: "42"},
+		want:    metav1.ListOptions{ResourceVersion: "42", Limit: listPageSize},
+	}, {
+		name:    "watch",
+		options: metav1.ListOptions{Watch: true, ResourceVersion: "42"},
+		want:    metav1.ListOptions{Watch: true, ResourceVersion: "42"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := tt.options
+			paginate(&options, tt.initial)
+			assert.Equal(t, tt.want, options)
+		})
+	}
+}
+
+func newConfigMap(name string, data map[string]any) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{"data": data}}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetNamespace("default")
+	obj.SetName(name)
+	return obj
+}
+
+func TestListWatchMaxItems(t *testing.T) {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		gvr: "ConfigMapList",
+	}, newConfigMap("a", nil), newConfigMap("b", nil), newConfigMap("c", nil))
+
+	_, err := newListWatch(client.Resource(gvr), 3).List(metav1.ListOptions{ResourceVersion: "0"})
+	assert.NoError(t, err)
+
+	_, err = newListWatch(client.Resource(gvr), 2).List(metav1.ListOptions{ResourceVersion: "0"})
+	assert.ErrorIs(t, err, errMaxItemsExceeded)
+
+	_, err = newListWatch(client.Resource(gvr), 0).List(metav1.ListOptions{ResourceVersion: "0"})
+	assert.NoError(t, err)
+}
+
+func TestSizeTracker(t *testing.T) {
+	tracker := newSizeTracker()
+	small := newConfigMap("a", map[string]any{"key": "value"})
+	large := newConfigMap("a", map[string]any{"key": "a much longer value"})
+	other := newConfigMap("b", nil)
+
+	tracker.OnAdd(small, true)
+	tracker.OnAdd(other, true)
+	count, smallSize := tracker.size()
+	assert.Equal(t, 2, count)
+
+	tracker.OnUpdate(small, large)
+	count, largeSize := tracker.size()
+	assert.Equal(t, 2, count)
+	assert.Greater(t, largeSize, smallSize)
+
+	tracker.OnDelete(cache.DeletedFinalStateUnknown{Key: "default/a", Obj: large})
+	tracker.OnDelete(other)
+	count, size := tracker.size()
+	assert.Equal(t, 0, count)
+	assert.Equal(t, int64(0), size)
+}
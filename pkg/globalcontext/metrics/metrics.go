@@ -0,0 +1,133 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/metric"
+)
+
+var logger = logging.WithName("globalcontext-metrics")
+
+// Recorder records the metrics of the global context entries
+type Recorder interface {
+	// RecordRefresh records a successful refresh of the entry data and updates its last refresh time
+	RecordRefresh(ctx context.Context, name string, latency time.Duration)
+	// RecordError records a failed refresh of the entry data
+	RecordError(ctx context.Context, name string)
+	// RecordSize records the approximate size in bytes of the entry data
+	RecordSize(name string, size int64)
+	// Delete stops reporting the metrics of the entry
+	Delete(name string)
+}
+
+type entryState struct {
+	size        int64
+	lastRefresh time.Time
+}
+
+type recorder struct {
+	lock     sync.Mutex
+	entries  map[string]*entryState
+	duration sdkmetric.Float64Histogram
+	errors   sdkmetric.Int64Counter
+}
+
+// NewRecorder returns a recorder reporting the entry size, last refresh timestamp, refresh latency and
+// refresh errors of every global context entry
+func NewRecorder() Recorder {
+	r := &recorder{
+		entries: map[string]*entryState{},
+	}
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	size, err := meter.Int64ObservableGauge(
+		"kyverno_global_context_entry_size_bytes",
+		sdkmetric.WithDescription("can be used to track the approximate size in bytes of the data held by a global context entry"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_global_context_entry_size_bytes")
+	}
+	lastRefresh, err := meter.Float64ObservableGauge(
+		"kyverno_global_context_entry_last_refresh_timestamp_seconds",
+		sdkmetric.WithDescription("can be used to track the unix time in seconds of the last successful refresh of a global context entry"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_global_context_entry_last_refresh_timestamp_seconds")
+	}
+	if size != nil && lastRefresh != nil {
+		if _, err := meter.RegisterCallback(func(_ context.Context, observer sdkmetric.Observer) error {
+			r.report(observer, size, lastRefresh)
+			return nil
+		}, size, lastRefresh); err != nil {
+			logger.Error(err, "failed to register callback")
+		}
+	}
+	r.duration, err = meter.Float64Histogram(
+		"kyverno_global_context_entry_refresh_duration_seconds",
+		sdkmetric.WithDescription("can be used to track the latencies (in seconds) of the global context entries refreshes"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_global_context_entry_refresh_duration_seconds")
+	}
+	r.errors, err = meter.Int64Counter(
+		"kyverno_global_context_entry_refresh_errors",
+		sdkmetric.WithDescription("can be used to track the number of failed global context entries refreshes"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_global_context_entry_refresh_errors")
+	}
+	return r
+}
+
+func (r *recorder) report(observer sdkmetric.Observer, size sdkmetric.Int64ObservableGauge, lastRefresh sdkmetric.Float64ObservableGauge) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for name, state := range r.entries {
+		attributes := sdkmetric.WithAttributes(attribute.String("entry", name))
+		observer.ObserveInt64(size, state.size, attributes)
+		if !state.lastRefresh.IsZero() {
+			observer.ObserveFloat64(lastRefresh, float64(state.lastRefresh.UnixNano())/float64(time.Second), attributes)
+		}
+	}
+}
+
+func (r *recorder) state(name string) *entryState {
+	state := r.entries[name]
+	if state == nil {
+		state = &entryState{}
+		r.entries[name] = state
+	}
+	return state
+}
+
+func (r *recorder) RecordRefresh(ctx context.Context, name string, latency time.Duration) {
+	if r.duration != nil {
+		r.duration.Record(ctx, latency.Seconds(), sdkmetric.WithAttributes(attribute.String("entry", name)))
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.state(name).lastRefresh = time.Now()
+}
+
+func (r *recorder) RecordError(ctx context.Context, name string) {
+	if r.errors != nil {
+		r.errors.Add(ctx, 1, sdkmetric.WithAttributes(attribute.String("entry", name)))
+	}
+}
+
+func (r *recorder) RecordSize(name string, size int64) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.state(name).size = size
+}
+
+func (r *recorder) Delete(name string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.entries, name)
+}
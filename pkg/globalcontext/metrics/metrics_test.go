@@ -0,0 +1,23 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorder(t *testing.T) {
+	r := NewRecorder().(*recorder)
+	r.RecordError(context.TODO(), "allowlist")
+	assert.Empty(t, r.entries)
+	r.RecordSize("allowlist", 42)
+	assert.Equal(t, int64(42), r.entries["allowlist"].size)
+	assert.True(t, r.entries["allowlist"].lastRefresh.IsZero())
+	r.RecordRefresh(context.TODO(), "allowlist", time.Second)
+	assert.False(t, r.entries["allowlist"].lastRefresh.IsZero())
+	assert.Equal(t, int64(42), r.entries["allowlist"].size)
+	r.Delete("allowlist")
+	assert.Empty(t, r.entries)
+}
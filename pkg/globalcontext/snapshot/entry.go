@@ -0,0 +1,70 @@
+package snapshot
+
+import (
+	"sync/atomic"
+
+	"github.com/kyverno/kyverno/pkg/globalcontext/store"
+)
+
+// staleEntry serves the data of an entry loaded from a snapshot until the entry is refreshed
+type staleEntry struct {
+	data []byte
+}
+
+func newStaleEntry(data []byte) store.Entry {
+	return &staleEntry{
+		data: data,
+	}
+}
+
+func (e *staleEntry) Get() (any, error) {
+	return e.data, nil
+}
+
+func (e *staleEntry) Stop() {}
+
+// fallbackEntry serves the data of a stale entry until the entry it wraps serves data for the first time
+type fallbackEntry struct {
+	store.Entry
+	stale     store.Entry
+	refreshed atomic.Bool
+}
+
+// WithFallback returns an entry serving the data of previous while entry has no data yet, when previous
+// is not a stale entry loaded from a snapshot the entry is returned as is.
+func WithFallback(entry, previous store.Entry) store.Entry {
+	if entry == nil || !IsStale(previous) {
+		return entry
+	}
+	if fallback, ok := previous.(*fallbackEntry); ok {
+		previous = fallback.stale
+	}
+	return &fallbackEntry{
+		Entry: entry,
+		stale: previous,
+	}
+}
+
+func (e *fallbackEntry) Get() (any, error) {
+	data, err := e.Entry.Get()
+	if err == nil {
+		e.refreshed.Store(true)
+		return data, nil
+	}
+	if !e.refreshed.Load() {
+		return e.stale.Get()
+	}
+	return nil, err
+}
+
+// IsStale returns true when the entry serves data loaded from a snapshot
+func IsStale(entry store.Entry) bool {
+	switch entry := entry.(type) {
+	case *staleEntry:
+		return true
+	case *fallbackEntry:
+		return !entry.refreshed.Load()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,180 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/controllers"
+	"github.com/kyverno/kyverno/pkg/globalcontext/store"
+	datautils "github.com/kyverno/kyverno/pkg/utils/data"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// maxSnapshotSize is the maximum size of the snapshot data, secrets are limited to 1MiB
+const maxSnapshotSize = 900 * 1024
+
+// snapshotEntry is the data of an entry stored in the snapshot secret
+type snapshotEntry struct {
+	// Generation is the generation of the entry the data was fetched for
+	Generation int64 `json:"generation"`
+	// Data is the JSON data of the entry
+	Data json.RawMessage `json:"data"`
+}
+
+// Load sets the entries stored in the snapshot secret in the store, they are marked stale until the
+// controller refreshes them. Entries which don't exist anymore or changed since the snapshot was taken
+// are ignored.
+func Load(ctx context.Context, logger logr.Logger, client corev1client.SecretInterface, name string, gceLister kyvernov2alpha1listers.GlobalContextEntryLister, storage store.Store) error {
+	secret, err := client.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	for key, value := range secret.Data {
+		var entry snapshotEntry
+		if err := json.Unmarshal(value, &entry); err != nil {
+			logger.Error(err, "failed to decode snapshot entry", "name", key)
+			continue
+		}
+		gce, err := gceLister.Get(key)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+			continue
+		}
+		if gce.GetGeneration() != entry.Generation {
+			logger.V(2).Info("snapshot entry is outdated, skipping", "name", key)
+			continue
+		}
+		storage.Set(key, newStaleEntry(entry.Data))
+		logger.V(2).Info("loaded snapshot entry", "name", key)
+	}
+	return nil
+}
+
+type controller struct {
+	client    corev1client.SecretInterface
+	name      string
+	gceLister kyvernov2alpha1listers.GlobalContextEntryLister
+	store     store.Store
+	interval  time.Duration
+	logger    logr.Logger
+}
+
+// NewController returns a controller writing the data of the entries in the store to the snapshot secret
+// at every interval. Several replicas can write the same secret, conflicts are retried at the next interval.
+func NewController(
+	client corev1client.SecretInterface,
+	name string,
+	gceLister kyvernov2alpha1listers.GlobalContextEntryLister,
+	storage store.Store,
+	interval time.Duration,
+	logger logr.Logger,
+) controllers.Controller {
+	return &controller{
+		client:    client,
+		name:      name,
+		gceLister: gceLister,
+		store:     storage,
+		interval:  interval,
+		logger:    logger,
+	}
+}
+
+func (c *controller) Run(ctx context.Context, _ int) {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := c.write(ctx); err != nil {
+			c.logger.Error(err, "failed to write global context snapshot", "secret", c.name)
+		}
+	}, c.interval)
+}
+
+// snapshot returns the data of the entries serving data, entries are dropped once the snapshot size is reached
+func (c *controller) snapshot() map[string][]byte {
+	keys := c.store.Keys()
+	sort.Strings(keys)
+	data := map[string][]byte{}
+	size := 0
+	for _, key := range keys {
+		entry, ok := c.store.Get(key)
+		if !ok {
+			continue
+		}
+		gce, err := c.gceLister.Get(key)
+		if err != nil {
+			continue
+		}
+		value, err := entry.Get()
+		if err != nil {
+			continue
+		}
+		raw, ok := value.([]byte)
+		if !ok {
+			if raw, err = json.Marshal(value); err != nil {
+				c.logger.Error(err, "failed to encode entry data", "name", key)
+				continue
+			}
+		}
+		encoded, err := json.Marshal(snapshotEntry{Generation: gce.GetGeneration(), Data: raw})
+		if err != nil {
+			c.logger.Error(err, "failed to encode snapshot entry", "name", key)
+			continue
+		}
+		if size+len(encoded) > maxSnapshotSize {
+			c.logger.Info("global context snapshot is full, entry is not persisted", "name", key, "size", len(encoded))
+			continue
+		}
+		size += len(encoded)
+		data[key] = encoded
+	}
+	return data
+}
+
+func (c *controller) write(ctx context.Context) error {
+	data := c.snapshot()
+	secret, err := c.client.Get(ctx, c.name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: c.name,
+				Labels: map[string]string{
+					kyverno.LabelAppManagedBy: kyverno.ValueKyvernoApp,
+				},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: data,
+		}
+		_, err = c.client.Create(ctx, secret, metav1.CreateOptions{})
+	} else {
+		if (len(secret.Data) == 0 && len(data) == 0) || datautils.DeepEqual(secret.Data, data) {
+			return nil
+		}
+		secret = secret.DeepCopy()
+		secret.Data = data
+		_, err = c.client.Update(ctx, secret, metav1.UpdateOptions{})
+	}
+	if apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err) {
+		c.logger.V(2).Info("global context snapshot was written concurrently, retrying later", "secret", c.name)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write secret %s: %w", c.name, err)
+	}
+	return nil
+}
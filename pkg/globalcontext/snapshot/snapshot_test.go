@@ -0,0 +1,94 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov2alpha1 "github.com/kyverno/kyverno/api/kyverno/v2alpha1"
+	versionedfake "github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernoinformers "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
+	kyvernov2alpha1listers "github.com/kyverno/kyverno/pkg/client/listers/kyverno/v2alpha1"
+	"github.com/kyverno/kyverno/pkg/globalcontext/store"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+type testEntry struct {
+	data any
+	err  error
+}
+
+func (e *testEntry) Get() (any, error) {
+	return e.data, e.err
+}
+
+func (e *testEntry) Stop() {}
+
+func newTestLister(t *testing.T, generations map[string]int64) kyvernov2alpha1listers.GlobalContextEntryLister {
+	factory := kyvernoinformers.NewSharedInformerFactory(versionedfake.NewSimpleClientset(), 0)
+	informer := factory.Kyverno().V2alpha1().GlobalContextEntries()
+	for name, generation := range generations {
+		assert.NoError(t, informer.Informer().GetIndexer().Add(&kyvernov2alpha1.GlobalContextEntry{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Generation: generation},
+		}))
+	}
+	return informer.Lister()
+}
+
+func TestSnapshot(t *testing.T) {
+	client := kubefake.NewSimpleClientset().CoreV1().Secrets("kyverno")
+	lister := newTestLister(t, map[string]int64{"allowlist": 1, "deployments": 2, "failing": 1})
+	storage := store.New()
+	storage.Set("allowlist", &testEntry{data: []byte(`["a","b"]`)})
+	storage.Set("deployments", &testEntry{data: []any{map[string]any{"name": "nginx"}}})
+	storage.Set("failing", &testEntry{err: errors.New("failed")})
+	storage.Set("deleted", &testEntry{data: []byte(`{}`)})
+	c := NewController(client, "snapshot", lister, storage, time.Minute, logr.Discard()).(*controller)
+	assert.NoError(t, c.write(context.TODO()))
+	secret, err := client.Get(context.TODO(), "snapshot", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, secret.Data, 2)
+	assert.JSONEq(t, `{"generation":1,"data":["a","b"]}`, string(secret.Data["allowlist"]))
+	assert.JSONEq(t, `{"generation":2,"data":[{"name":"nginx"}]}`, string(secret.Data["deployments"]))
+	// writing the same data again is a no-op
+	assert.NoError(t, c.write(context.TODO()))
+
+	// entries are loaded stale unless they changed since the snapshot was taken
+	loaded := store.New()
+	assert.NoError(t, Load(context.TODO(), logr.Discard(), client, "snapshot", newTestLister(t, map[string]int64{"allowlist": 1, "deployments": 3}), loaded))
+	assert.ElementsMatch(t, []string{"allowlist"}, loaded.Keys())
+	entry, ok := loaded.Get("allowlist")
+	assert.True(t, ok)
+	assert.True(t, IsStale(entry))
+	data, err := entry.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`["a","b"]`), data)
+
+	// a missing snapshot is not an error
+	assert.NoError(t, Load(context.TODO(), logr.Discard(), client, "missing", lister, store.New()))
+}
+
+func TestWithFallback(t *testing.T) {
+	stale := newStaleEntry([]byte(`["stale"]`))
+	fresh := &testEntry{err: errors.New("no data available")}
+	entry := WithFallback(fresh, stale)
+	data, err := entry.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`["stale"]`), data)
+	assert.True(t, IsStale(entry))
+	fresh.data, fresh.err = []byte(`["fresh"]`), nil
+	data, err = entry.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`["fresh"]`), data)
+	assert.False(t, IsStale(entry))
+	// once refreshed, errors are not hidden by the stale data anymore
+	fresh.data, fresh.err = nil, errors.New("failed")
+	_, err = entry.Get()
+	assert.Error(t, err)
+	// entries replacing fresh entries don't fall back
+	assert.Equal(t, store.Entry(fresh), WithFallback(fresh, &testEntry{}))
+}
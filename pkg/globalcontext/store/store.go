@@ -8,6 +8,7 @@ type Store interface {
 	Set(key string, val Entry)
 	Get(key string) (Entry, bool)
 	Delete(key string)
+	Keys() []string
 }
 
 type store struct {
@@ -48,3 +49,13 @@ func (l *store) Delete(key string) {
 	}
 	delete(l.store, key)
 }
+
+func (l *store) Keys() []string {
+	l.RLock()
+	defer l.RUnlock()
+	keys := make([]string, 0, len(l.store))
+	for key := range l.store {
+		keys = append(keys, key)
+	}
+	return keys
+}
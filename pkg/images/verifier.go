@@ -24,6 +24,7 @@ type Client interface {
 
 type Options struct {
 	SigstoreBundle       bool
+	Bundles              []string
 	ImageRef             string
 	Client               Client
 	FetchAttestations    bool
@@ -49,6 +50,8 @@ type Options struct {
 	PredicateType        string
 	Type                 string
 	Identities           string
+	TrustPolicy          string
+	TrustStores          map[string]string
 }
 
 type Response struct {
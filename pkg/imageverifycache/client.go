@@ -4,7 +4,6 @@ import (
 	"context"
 	"time"
 
-	"github.com/dgraph-io/ristretto"
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 )
@@ -19,7 +18,7 @@ type cache struct {
 	isCacheEnabled bool
 	maxSize        int64
 	ttl            time.Duration
-	cache          *ristretto.Cache
+	backend        Backend
 }
 
 type Option = func(*cache) error
@@ -31,16 +30,13 @@ func New(options ...Option) (Client, error) {
 			return nil, err
 		}
 	}
-	config := ristretto.Config{
-		MaxCost:     cache.maxSize,
-		NumCounters: 10 * cache.maxSize,
-		BufferItems: 64,
-	}
-	rcache, err := ristretto.NewCache(&config)
-	if err != nil {
-		return nil, err
+	if cache.backend == nil {
+		backend, err := NewMemoryBackend(cache.maxSize)
+		if err != nil {
+			return nil, err
+		}
+		cache.backend = backend
 	}
-	cache.cache = rcache
 	return cache, nil
 }
 
@@ -87,6 +83,14 @@ func WithTTLDuration(t time.Duration) Option {
 	}
 }
 
+// WithBackend configures the backend storing cache entries, an in-memory backend is used by default.
+func WithBackend(b Backend) Option {
+	return func(c *cache) error {
+		c.backend = b
+		return nil
+	}
+}
+
 func generateKey(policy kyvernov1.PolicyInterface, ruleName string, imageRef string) string {
 	return string(policy.GetUID()) + ";" + policy.GetResourceVersion() + ";" + ruleName + ";" + imageRef
 }
@@ -101,12 +105,7 @@ func (c *cache) Set(ctx context.Context, policy kyvernov1.PolicyInterface, ruleN
 	}
 	key := generateKey(policy, ruleName, imageRef)
 
-	stored := c.cache.SetWithTTL(key, nil, 1, c.ttl)
-	c.cache.Wait()
-	if stored {
-		return true, nil
-	}
-	return false, nil
+	return c.backend.Set(ctx, key, c.ttl)
 }
 
 func (c *cache) Get(ctx context.Context, policy kyvernov1.PolicyInterface, ruleName string, imageRef string, useCache bool) (bool, error) {
@@ -118,9 +117,5 @@ func (c *cache) Get(ctx context.Context, policy kyvernov1.PolicyInterface, ruleN
 		return false, nil
 	}
 	key := generateKey(policy, ruleName, imageRef)
-	_, found := c.cache.Get(key)
-	if found {
-		return true, nil
-	}
-	return false, nil
+	return c.backend.Get(ctx, key)
 }
@@ -2,6 +2,7 @@ package imageverifycache
 
 import (
 	"context"
+	"time"
 
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 )
@@ -16,3 +17,13 @@ type Client interface {
 	// Returns true when the cache entry is found
 	Get(ctx context.Context, policy kyvernov1.PolicyInterface, ruleName string, imagerRef string, useCache bool) (bool, error)
 }
+
+// Backend stores the entries of the cache
+type Backend interface {
+	// Set adds a key to the backend, the key expires after the given ttl
+	// Returns true when the key is added
+	Set(ctx context.Context, key string, ttl time.Duration) (bool, error)
+
+	// Get returns true when the key is found in the backend and has not expired
+	Get(ctx context.Context, key string) (bool, error)
+}
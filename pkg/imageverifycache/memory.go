@@ -0,0 +1,41 @@
+package imageverifycache
+
+import (
+	"context"
+	"time"
+
+	"github.com/dgraph-io/ristretto"
+)
+
+type memoryBackend struct {
+	cache *ristretto.Cache
+}
+
+// NewMemoryBackend returns an in-memory backend holding up to maxSize entries,
+// entries are lost on restart and are not shared across replicas.
+func NewMemoryBackend(maxSize int64) (Backend, error) {
+	if maxSize == 0 {
+		maxSize = defaultMaxSize
+	}
+	config := ristretto.Config{
+		MaxCost:     maxSize,
+		NumCounters: 10 * maxSize,
+		BufferItems: 64,
+	}
+	cache, err := ristretto.NewCache(&config)
+	if err != nil {
+		return nil, err
+	}
+	return &memoryBackend{cache: cache}, nil
+}
+
+func (b *memoryBackend) Set(_ context.Context, key string, ttl time.Duration) (bool, error) {
+	stored := b.cache.SetWithTTL(key, nil, 1, ttl)
+	b.cache.Wait()
+	return stored, nil
+}
+
+func (b *memoryBackend) Get(_ context.Context, key string) (bool, error) {
+	_, found := b.cache.Get(key)
+	return found, nil
+}
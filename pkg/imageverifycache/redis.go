@@ -0,0 +1,201 @@
+package imageverifycache
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// redisIndexKey is the sorted set holding the names of the cache entries scored by their expiration time
+	redisIndexKey = "kyverno:imageverifycache"
+	// redisEntryPrefix prefixes the names of the cache entries
+	redisEntryPrefix = "kyverno:imageverifycache:"
+	redisTimeout     = 5 * time.Second
+	// redisRetryInterval is the interval during which calls fail fast after a connection failure
+	redisRetryInterval = 30 * time.Second
+)
+
+// redisEvictScript pops the entries closest to expiration above the max size and deletes them
+const redisEvictScript = `local excess = redis.call('ZCARD', KEYS[1]) - tonumber(ARGV[1])
+if excess > 0 then
+  local members = redis.call('ZPOPMIN', KEYS[1], excess)
+  for i = 1, #members, 2 do
+    redis.call('DEL', members[i])
+  end
+end
+return 0`
+
+type redisBackend struct {
+	client  *redis.Client
+	options *redis.Options
+	maxSize int64
+	hmacKey []byte
+	now     func() time.Time
+
+	lock      sync.Mutex
+	retryAt   time.Time
+	lastError error
+}
+
+// NewRedisBackend returns a backend storing entries in Redis so that they survive restarts and are shared
+// across replicas. The url has the form redis://host[:port][/db], use the rediss scheme for TLS, the password
+// must be passed separately. Every entry is signed with the given HMAC key, entries that were not written
+// by Kyverno are ignored. Entries closest to expiration are evicted first when the cache holds more than
+// maxSize entries. Connections are pooled and every call is bounded by the deadline of its context and by
+// the redis timeout.
+func NewRedisBackend(redisURL string, password string, hmacKey []byte, maxSize int64) (Backend, error) {
+	u, err := url.Parse(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse redis url: %w", err)
+	}
+	if u.Scheme != "redis" && u.Scheme != "rediss" {
+		return nil, fmt.Errorf("unsupported redis url scheme %s", u.Scheme)
+	}
+	if u.User != nil {
+		return nil, errors.New("redis url must not contain credentials")
+	}
+	if len(hmacKey) == 0 {
+		return nil, errors.New("an HMAC key is required")
+	}
+	if maxSize == 0 {
+		maxSize = defaultMaxSize
+	}
+	options := &redis.Options{
+		Addr:                  u.Host,
+		Password:              password,
+		Protocol:              2,
+		DialTimeout:           redisTimeout,
+		ReadTimeout:           redisTimeout,
+		WriteTimeout:          redisTimeout,
+		PoolTimeout:           redisTimeout,
+		ContextTimeoutEnabled: true,
+		// a call is retried once on a new connection when the pooled one was dropped by the server
+		MaxRetries:       1,
+		DisableIndentity: true,
+	}
+	if u.Port() == "" {
+		options.Addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if db := strings.TrimPrefix(u.Path, "/"); db != "" {
+		options.DB, err = strconv.Atoi(db)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redis database %s: %w", db, err)
+		}
+	}
+	if u.Scheme == "rediss" {
+		options.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	return &redisBackend{
+		client:  redis.NewClient(options),
+		options: options,
+		maxSize: maxSize,
+		hmacKey: hmacKey,
+		now:     time.Now,
+	}, nil
+}
+
+// entryName returns the name of the redis key holding the cache entry
+func entryName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return redisEntryPrefix + hex.EncodeToString(sum[:])
+}
+
+// sign returns the HMAC of the cache entry
+func (b *redisBackend) sign(key string, expiration int64) string {
+	mac := hmac.New(sha256.New, b.hmacKey)
+	mac.Write([]byte(key))
+	mac.Write([]byte{0})
+	mac.Write([]byte(strconv.FormatInt(expiration, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (b *redisBackend) Set(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	if err := b.available(); err != nil {
+		return false, err
+	}
+	now := b.now()
+	expiration := now.Add(ttl).UnixMilli()
+	name := entryName(key)
+	value := strconv.FormatInt(expiration, 10) + ":" + b.sign(key, expiration)
+	_, err := b.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, name, value, ttl)
+		pipe.ZAdd(ctx, redisIndexKey, redis.Z{Score: float64(expiration), Member: name})
+		// drop expired entries from the index, then the entries closest to expiration above the max size
+		pipe.ZRemRangeByScore(ctx, redisIndexKey, "-inf", strconv.FormatInt(now.UnixMilli(), 10))
+		pipe.Eval(ctx, redisEvictScript, []string{redisIndexKey}, b.maxSize)
+		return nil
+	})
+	if err != nil {
+		return false, b.failed(err)
+	}
+	return true, nil
+}
+
+func (b *redisBackend) Get(ctx context.Context, key string) (bool, error) {
+	if err := b.available(); err != nil {
+		return false, err
+	}
+	value, err := b.client.Get(ctx, entryName(key)).Result()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, b.failed(err)
+	}
+	return b.verify(key, value)
+}
+
+// verify checks the signature and the expiration of a cache entry
+func (b *redisBackend) verify(key string, value string) (bool, error) {
+	expiration, signature, ok := strings.Cut(value, ":")
+	if !ok {
+		return false, errors.New("invalid cache entry")
+	}
+	expires, err := strconv.ParseInt(expiration, 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("invalid cache entry expiration %s: %w", expiration, err)
+	}
+	if !hmac.Equal([]byte(signature), []byte(b.sign(key, expires))) {
+		return false, errors.New("invalid cache entry signature")
+	}
+	return expires > b.now().UnixMilli(), nil
+}
+
+// available returns an error while calls fail fast after a connection failure, so that calls don't wait
+// on the dial timeout while redis is unreachable
+func (b *redisBackend) available() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.now().Before(b.retryAt) {
+		return fmt.Errorf("redis is unavailable: %w", b.lastError)
+	}
+	return nil
+}
+
+// failed records connection failures, calls fail fast during the retry interval after redis couldn't be reached.
+// Other failures, like a dropped connection, are returned as is, the pool dials a new connection on the next call.
+func (b *redisBackend) failed(err error) error {
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) || opErr.Op != "dial" {
+		return err
+	}
+	err = fmt.Errorf("failed to connect to redis: %w", err)
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.retryAt = b.now().Add(redisRetryInterval)
+	b.lastError = err
+	return err
+}
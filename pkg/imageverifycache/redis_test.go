@@ -0,0 +1,190 @@
+package imageverifycache
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gotest.tools/assert"
+)
+
+func TestNewRedisBackend(t *testing.T) {
+	testCases := []struct {
+		name     string
+		url      string
+		password string
+		hmacKey  []byte
+		maxSize  int64
+		expected *redisBackend
+		wantErr  bool
+	}{{
+		name:     "default port",
+		url:      "redis://redis.kyverno.svc",
+		hmacKey:  []byte("key"),
+		maxSize:  10,
+		expected: &redisBackend{options: &redis.Options{Addr: "redis.kyverno.svc:6379"}, maxSize: 10},
+	}, {
+		name:     "password, database and tls",
+		url:      "rediss://redis.kyverno.svc:6380/2",
+		password: "secret",
+		hmacKey:  []byte("key"),
+		expected: &redisBackend{options: &redis.Options{Addr: "redis.kyverno.svc:6380", Password: "secret", DB: 2, TLSConfig: &tls.Config{}}, maxSize: defaultMaxSize},
+	}, {
+		name:    "credentials in url",
+		url:     "redis://:secret@redis.kyverno.svc",
+		hmacKey: []byte("key"),
+		wantErr: true,
+	}, {
+		name:    "no hmac key",
+		url:     "redis://redis.kyverno.svc",
+		wantErr: true,
+	}, {
+		name:    "invalid scheme",
+		url:     "memcached://memcached.kyverno.svc",
+		hmacKey: []byte("key"),
+		wantErr: true,
+	}, {
+		name:    "invalid database",
+		url:     "redis://redis.kyverno.svc/foo",
+		hmacKey: []byte("key"),
+		wantErr: true,
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			backend, err := NewRedisBackend(tc.url, tc.password, tc.hmacKey, tc.maxSize)
+			if tc.wantErr {
+				assert.Assert(t, err != nil)
+				return
+			}
+			assert.NilError(t, err)
+			cache := backend.(*redisBackend)
+			assert.Equal(t, cache.options.Addr, tc.expected.options.Addr)
+			assert.Equal(t, cache.options.Password, tc.expected.options.Password)
+			assert.Equal(t, cache.options.DB, tc.expected.options.DB)
+			assert.Equal(t, cache.options.TLSConfig != nil, tc.expected.options.TLSConfig != nil)
+			assert.Equal(t, cache.maxSize, tc.expected.maxSize)
+		})
+	}
+}
+
+func TestRedisBackendVerify(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	backend, err := NewRedisBackend("redis://redis.kyverno.svc", "", []byte("key"), 0)
+	assert.NilError(t, err)
+	cache := backend.(*redisBackend)
+	cache.now = func() time.Time { return now }
+	expiration := now.Add(time.Minute).UnixMilli()
+	value := strconv.FormatInt(expiration, 10) + ":" + cache.sign("uid;1;rule;nginx", expiration)
+	found, err := cache.verify("uid;1;rule;nginx", value)
+	assert.NilError(t, err)
+	assert.Assert(t, found)
+	// entries can't be reused for another key
+	_, err = cache.verify("uid;1;rule;evil", value)
+	assert.Error(t, err, "invalid cache entry signature")
+	// expiration can't be extended
+	_, err = cache.verify("uid;1;rule;nginx", strconv.FormatInt(expiration+1, 10)+value[len(strconv.FormatInt(expiration, 10)):])
+	assert.Error(t, err, "invalid cache entry signature")
+	// entries signed with another key are rejected
+	other, err := NewRedisBackend("redis://redis.kyverno.svc", "", []byte("other"), 0)
+	assert.NilError(t, err)
+	_, err = cache.verify("uid;1;rule;nginx", strconv.FormatInt(expiration, 10)+":"+other.(*redisBackend).sign("uid;1;rule;nginx", expiration))
+	assert.Error(t, err, "invalid cache entry signature")
+	// expired entries are not found
+	now = now.Add(2 * time.Minute)
+	found, err = cache.verify("uid;1;rule;nginx", value)
+	assert.NilError(t, err)
+	assert.Assert(t, !found)
+}
+
+func TestRedisBackendUnavailable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	address := listener.Addr().String()
+	assert.NilError(t, listener.Close())
+	backend, err := NewRedisBackend("redis://"+address, "", []byte("key"), 0)
+	assert.NilError(t, err)
+	_, err = backend.Get(context.TODO(), "uid;1;rule;nginx")
+	assert.ErrorContains(t, err, "failed to connect to redis")
+	// subsequent calls fail fast until the retry interval elapses
+	_, err = backend.Get(context.TODO(), "uid;1;rule;nginx")
+	assert.ErrorContains(t, err, "redis is unavailable")
+}
+
+// serveRedis accepts connections on the listener and answers every GET with a null reply, the connection is
+// closed by the server after the first GET, other commands are answered with an error
+func serveRedis(listener net.Listener, accepted *int32) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		atomic.AddInt32(accepted, 1)
+		go func() {
+			defer conn.Close()
+			reader := bufio.NewReader(conn)
+			for {
+				command, err := readCommand(reader)
+				if err != nil {
+					return
+				}
+				if strings.EqualFold(command[0], "GET") {
+					_, _ = conn.Write([]byte("$-1\r\n"))
+					return
+				}
+				_, _ = fmt.Fprintf(conn, "-ERR unknown command '%s'\r\n", command[0])
+			}
+		}()
+	}
+}
+
+// readCommand decodes a command sent as a RESP array of bulk strings
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(line, "*"), "\r\n"))
+	if err != nil || count < 1 {
+		return nil, fmt.Errorf("invalid command %q", line)
+	}
+	command := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		if _, err := reader.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		arg, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		command = append(command, strings.TrimSuffix(arg, "\r\n"))
+	}
+	return command, nil
+}
+
+func TestRedisBackendReconnect(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	defer listener.Close()
+	var accepted int32
+	go serveRedis(listener, &accepted)
+	backend, err := NewRedisBackend("redis://"+listener.Addr().String(), "", []byte("key"), 0)
+	assert.NilError(t, err)
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Second)
+	defer cancel()
+	found, err := backend.Get(ctx, "uid;1;rule;nginx")
+	assert.NilError(t, err)
+	assert.Assert(t, !found)
+	// the server dropped the connection, the next call uses a new one
+	found, err = backend.Get(ctx, "uid;1;rule;nginx")
+	assert.NilError(t, err)
+	assert.Assert(t, !found)
+	assert.Equal(t, atomic.LoadInt32(&accepted), int32(2))
+}
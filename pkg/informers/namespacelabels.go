@@ -0,0 +1,76 @@
+package informers
+
+import (
+	"maps"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// NamespaceLabels is a thread-safe index of namespace labels maintained from a namespace informer,
+// it resolves the labels used for namespace selector matching without hitting the API server.
+type NamespaceLabels interface {
+	// Get returns a copy of the labels of the namespace, the boolean is false if the namespace is unknown.
+	Get(name string) (map[string]string, bool)
+}
+
+type namespaceLabels struct {
+	lock   sync.RWMutex
+	labels map[string]map[string]string
+}
+
+// NewNamespaceLabels returns a NamespaceLabels index fed by the events of the given informer.
+func NewNamespaceLabels(informer corev1informers.NamespaceInformer) (NamespaceLabels, error) {
+	index := &namespaceLabels{
+		labels: map[string]map[string]string{},
+	}
+	_, err := informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    index.set,
+		UpdateFunc: func(_, obj interface{}) { index.set(obj) },
+		DeleteFunc: index.delete,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+func (i *namespaceLabels) Get(name string) (map[string]string, bool) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	labels, ok := i.labels[name]
+	if !ok {
+		return nil, false
+	}
+	return maps.Clone(labels), true
+}
+
+func (i *namespaceLabels) set(obj interface{}) {
+	namespace, ok := obj.(*corev1.Namespace)
+	if !ok {
+		return
+	}
+	// the stored map is never modified in place, readers get copies
+	labels := maps.Clone(namespace.GetLabels())
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.labels[namespace.GetName()] = labels
+}
+
+func (i *namespaceLabels) delete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	namespace, ok := obj.(*corev1.Namespace)
+	if !ok {
+		return
+	}
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	delete(i.labels, namespace.GetName())
+}
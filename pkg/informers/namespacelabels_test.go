@@ -0,0 +1,55 @@
+package informers
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestNamespaceLabels(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := fake.NewSimpleClientset(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Labels: map[string]string{"team": "payments"}},
+	})
+	factory := kubeinformers.NewSharedInformerFactory(client, 0)
+	informer := factory.Core().V1().Namespaces()
+	nsLabels, err := NewNamespaceLabels(informer)
+	assert.NilError(t, err)
+	factory.Start(ctx.Done())
+	assert.Assert(t, cache.WaitForCacheSync(ctx.Done(), informer.Informer().HasSynced))
+
+	labels, ok := nsLabels.Get("test")
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, labels, map[string]string{"team": "payments"})
+	// callers get copies of the stored labels
+	labels["team"] = "platform"
+	labels, _ = nsLabels.Get("test")
+	assert.Equal(t, labels["team"], "payments")
+
+	_, ok = nsLabels.Get("missing")
+	assert.Assert(t, !ok)
+}
+
+func TestNamespaceLabelsEvents(t *testing.T) {
+	index := &namespaceLabels{labels: map[string]map[string]string{}}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+	index.set(namespace)
+	labels, ok := index.Get("test")
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, labels, map[string]string{})
+	namespace = namespace.DeepCopy()
+	namespace.Labels = map[string]string{"team": "payments"}
+	index.set(namespace)
+	labels, _ = index.Get("test")
+	assert.DeepEqual(t, labels, map[string]string{"team": "payments"})
+	index.delete(cache.DeletedFinalStateUnknown{Key: "test", Obj: namespace})
+	_, ok = index.Get("test")
+	assert.Assert(t, !ok)
+}
@@ -0,0 +1,124 @@
+package limiter
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/metric"
+	"golang.org/x/time/rate"
+)
+
+// Priority is the priority of the admission work
+type Priority string
+
+const (
+	// PriorityHigh is used for work blocking admission requests (enforce validation, mutation)
+	PriorityHigh Priority = "high"
+	// PriorityLow is used for non blocking work (audit validation, reports)
+	PriorityLow Priority = "low"
+)
+
+// ErrShed is returned when low priority work is rejected
+var ErrShed = errors.New("admission work shed by the limiter")
+
+// Limiter limits concurrent admission work, high priority work waits for a slot
+// while low priority work is rejected when the limiter is saturated
+type Limiter interface {
+	// Acquire reserves a slot for the given priority, the returned func must be called to release it
+	Acquire(context.Context, Priority) (func(), error)
+}
+
+type limiter struct {
+	slots       chan struct{}
+	lowMax      int
+	lowRate     *rate.Limiter
+	drops       sdkmetric.Int64Counter
+	total       sdkmetric.Int64Counter
+	releaseSlot func()
+}
+
+// NewLimiter creates a limiter allowing maxConcurrent units of work, low priority work only uses
+// up to lowPriorityRatio of the slots and is rate limited to lowPriorityQPS (unlimited when zero).
+// A nil limiter is returned when maxConcurrent is zero, meaning the work is not limited.
+func NewLimiter(maxConcurrent int, lowPriorityRatio float64, lowPriorityQPS float64, lowPriorityBurst int) Limiter {
+	if maxConcurrent <= 0 {
+		return nil
+	}
+	logger := logging.WithName("admission-limiter")
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	drops, err := meter.Int64Counter(
+		"kyverno_admission_limiter_drops",
+		sdkmetric.WithDescription("track the number of admission work units shed by the limiter"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_admission_limiter_drops")
+	}
+	total, err := meter.Int64Counter(
+		"kyverno_admission_limiter_total",
+		sdkmetric.WithDescription("track the number of admission work units submitted to the limiter"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_admission_limiter_total")
+	}
+	lowMax := int(float64(maxConcurrent) * lowPriorityRatio)
+	if lowMax < 1 {
+		lowMax = 1
+	}
+	if lowMax > maxConcurrent {
+		lowMax = maxConcurrent
+	}
+	l := &limiter{
+		slots:  make(chan struct{}, maxConcurrent),
+		lowMax: lowMax,
+		drops:  drops,
+		total:  total,
+	}
+	if lowPriorityQPS > 0 {
+		if lowPriorityBurst < 1 {
+			lowPriorityBurst = 1
+		}
+		l.lowRate = rate.NewLimiter(rate.Limit(lowPriorityQPS), lowPriorityBurst)
+	}
+	l.releaseSlot = func() { <-l.slots }
+	return l
+}
+
+func (l *limiter) Acquire(ctx context.Context, priority Priority) (func(), error) {
+	attributes := sdkmetric.WithAttributes(
+		attribute.String("priority", string(priority)),
+	)
+	if l.total != nil {
+		l.total.Add(ctx, 1, attributes)
+	}
+	if priority == PriorityHigh {
+		select {
+		case l.slots <- struct{}{}:
+			return l.releaseSlot, nil
+		case <-ctx.Done():
+			l.drop(ctx, attributes)
+			return nil, ctx.Err()
+		}
+	}
+	// low priority work never waits, it leaves the remaining slots to high priority work
+	if len(l.slots) >= l.lowMax || (l.lowRate != nil && !l.lowRate.Allow()) {
+		l.drop(ctx, attributes)
+		return nil, ErrShed
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return l.releaseSlot, nil
+	default:
+		l.drop(ctx, attributes)
+		return nil, ErrShed
+	}
+}
+
+func (l *limiter) drop(ctx context.Context, attributes sdkmetric.MeasurementOption) {
+	if l.drops != nil {
+		l.drops.Add(ctx, 1, attributes)
+	}
+}
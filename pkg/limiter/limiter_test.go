@@ -0,0 +1,56 @@
+package limiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLimiter(t *testing.T) {
+	assert.Nil(t, NewLimiter(0, 0.5, 0, 0))
+	assert.NotNil(t, NewLimiter(4, 0.5, 0, 0))
+}
+
+func TestLimiter_Acquire(t *testing.T) {
+	l := NewLimiter(4, 0.5, 0, 0)
+	// low priority work uses at most half of the slots
+	lowRelease1, err := l.Acquire(context.TODO(), PriorityLow)
+	assert.NoError(t, err)
+	_, err = l.Acquire(context.TODO(), PriorityLow)
+	assert.NoError(t, err)
+	_, err = l.Acquire(context.TODO(), PriorityLow)
+	assert.ErrorIs(t, err, ErrShed)
+	// high priority work uses the remaining slots
+	_, err = l.Acquire(context.TODO(), PriorityHigh)
+	assert.NoError(t, err)
+	_, err = l.Acquire(context.TODO(), PriorityHigh)
+	assert.NoError(t, err)
+	// high priority work waits for a slot
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+	_, err = l.Acquire(ctx, PriorityHigh)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	lowRelease1()
+	_, err = l.Acquire(context.TODO(), PriorityHigh)
+	assert.NoError(t, err)
+	// low priority work is shed when the limiter is saturated
+	_, err = l.Acquire(context.TODO(), PriorityLow)
+	assert.ErrorIs(t, err, ErrShed)
+}
+
+func TestLimiter_AcquireRate(t *testing.T) {
+	l := NewLimiter(10, 1, 0.001, 2)
+	for i := 0; i < 2; i++ {
+		release, err := l.Acquire(context.TODO(), PriorityLow)
+		assert.NoError(t, err)
+		release()
+	}
+	_, err := l.Acquire(context.TODO(), PriorityLow)
+	assert.ErrorIs(t, err, ErrShed)
+	// high priority work is not rate limited
+	release, err := l.Acquire(context.TODO(), PriorityHigh)
+	assert.NoError(t, err)
+	release()
+}
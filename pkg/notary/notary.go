@@ -18,11 +18,13 @@ import (
 	notationlog "github.com/notaryproject/notation-go/log"
 	"github.com/notaryproject/notation-go/verifier"
 	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"github.com/notaryproject/notation-go/verifier/truststore"
 	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 	"github.com/sigstore/sigstore/pkg/cryptoutils"
 	"go.uber.org/multierr"
+	"sigs.k8s.io/yaml"
 )
 
 var (
@@ -43,17 +45,9 @@ type notaryVerifier struct {
 func (v *notaryVerifier) VerifySignature(ctx context.Context, opts images.Options) (*images.Response, error) {
 	v.log.V(2).Info("verifying image", "reference", opts.ImageRef)
 
-	certsPEM := combineCerts(opts)
-	certs, err := cryptoutils.LoadCertificatesFromPEM(bytes.NewReader([]byte(certsPEM)))
+	notationVerifier, err := v.buildVerifier(opts)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to parse certificates")
-	}
-
-	trustStore := NewTrustStore("kyverno", certs)
-	policyDoc := v.buildPolicy()
-	notationVerifier, err := verifier.New(policyDoc, trustStore, nil)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to created verifier")
+		return nil, err
 	}
 
 	v.log.V(4).Info("creating notation repo", "reference", opts.ImageRef)
@@ -101,6 +95,47 @@ func combineCerts(opts images.Options) string {
 	return certs
 }
 
+// buildVerifier creates a notation verifier from the trust policy and trust stores provided in the options,
+// when no trust policy is provided the certificates are trusted for all registries.
+func (v *notaryVerifier) buildVerifier(opts images.Options) (notation.Verifier, error) {
+	var policyDoc *trustpolicy.Document
+	var trustStore truststore.X509TrustStore
+	if opts.TrustPolicy != "" {
+		doc, err := parseTrustPolicy(opts.TrustPolicy)
+		if err != nil {
+			return nil, err
+		}
+		stores, err := NewTrustStores(opts.TrustStores)
+		if err != nil {
+			return nil, err
+		}
+		policyDoc, trustStore = doc, stores
+	} else {
+		certsPEM := combineCerts(opts)
+		certs, err := cryptoutils.LoadCertificatesFromPEM(bytes.NewReader([]byte(certsPEM)))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse certificates")
+		}
+		policyDoc, trustStore = v.buildPolicy(), NewTrustStore("kyverno", certs)
+	}
+	notationVerifier, err := verifier.New(policyDoc, trustStore, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to created verifier")
+	}
+	return notationVerifier, nil
+}
+
+func parseTrustPolicy(data string) (*trustpolicy.Document, error) {
+	var doc trustpolicy.Document
+	if err := yaml.Unmarshal([]byte(data), &doc); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse trust policy")
+	}
+	if err := doc.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid trust policy")
+	}
+	return &doc, nil
+}
+
 func (v *notaryVerifier) buildPolicy() *trustpolicy.Document {
 	return &trustpolicy.Document{
 		Version: "1.0",
@@ -213,7 +248,7 @@ func (v *notaryVerifier) FetchAttestations(ctx context.Context, opts images.Opti
 
 func verifyAttestators(ctx context.Context, v *notaryVerifier, ref name.Reference, opts images.Options, desc v1.Descriptor) (ocispec.Descriptor, error) {
 	v.log.V(2).Info("verifying attestations", "reference", opts.ImageRef, "opts", opts)
-	if opts.Cert == "" && opts.CertChain == "" {
+	if opts.Cert == "" && opts.CertChain == "" && opts.TrustPolicy == "" {
 		// skips the checks when no attestor is provided
 		v1Desc := ocispec.Descriptor{
 			MediaType:   string(desc.MediaType),
@@ -225,20 +260,10 @@ func verifyAttestators(ctx context.Context, v *notaryVerifier, ref name.Referenc
 		}
 		return v1Desc, nil
 	}
-	certsPEM := combineCerts(opts)
-	certs, err := cryptoutils.LoadCertificatesFromPEM(bytes.NewReader([]byte(certsPEM)))
-	if err != nil {
-		v.log.V(4).Info("failed to parse certificates", "err", err)
-		return ocispec.Descriptor{}, errors.Wrapf(err, "failed to parse certificates")
-	}
-
-	v.log.V(4).Info("parsed certificates")
-	trustStore := NewTrustStore("kyverno", certs)
-	policyDoc := v.buildPolicy()
-	notationVerifier, err := verifier.New(policyDoc, trustStore, nil)
+	notationVerifier, err := v.buildVerifier(opts)
 	if err != nil {
 		v.log.V(4).Info("failed to created verifier", "err", err)
-		return ocispec.Descriptor{}, errors.Wrapf(err, "failed to created verifier")
+		return ocispec.Descriptor{}, err
 	}
 
 	v.log.V(4).Info("created verifier")
@@ -70,3 +70,39 @@ func TestNotaryImageVerification(t *testing.T) {
 	_, err = verifier.VerifySignature(context.TODO(), opts)
 	assert.NilError(t, err)
 }
+
+func TestParseTrustPolicy(t *testing.T) {
+	policy := `
+version: "1.0"
+trustPolicies:
+- name: acme
+  registryScopes:
+  - ghcr.io/kyverno/test-verify-image
+  signatureVerification:
+    level: strict
+  trustStores:
+  - ca:acme
+  trustedIdentities:
+  - "*"
+`
+	doc, err := parseTrustPolicy(policy)
+	assert.NilError(t, err)
+	assert.Equal(t, len(doc.TrustPolicies), 1)
+	assert.Equal(t, doc.TrustPolicies[0].TrustStores[0], "ca:acme")
+
+	_, err = parseTrustPolicy(`{"version": "1.0", "trustPolicies": []}`)
+	assert.ErrorContains(t, err, "invalid trust policy")
+}
+
+func TestNewTrustStores(t *testing.T) {
+	stores, err := NewTrustStores(map[string]string{"acme": cert})
+	assert.NilError(t, err)
+	certs, err := stores.GetCertificates(context.Background(), "ca", "acme")
+	assert.NilError(t, err)
+	assert.Equal(t, len(certs), 1)
+	_, err = stores.GetCertificates(context.Background(), "ca", "unknown")
+	assert.ErrorContains(t, err, "trust store ca:unknown not found")
+
+	_, err = NewTrustStores(map[string]string{"invalid": "not a certificate"})
+	assert.ErrorContains(t, err, "failed to parse certificates of trust store invalid")
+}
@@ -1,11 +1,13 @@
 package notary
 
 import (
+	"bytes"
 	"context"
 	"crypto/x509"
 
 	"github.com/notaryproject/notation-go/verifier/truststore"
 	"github.com/pkg/errors"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
 )
 
 type simpleTrustStore struct {
@@ -33,3 +35,29 @@ func (ts *simpleTrustStore) GetCertificates(ctx context.Context, storeType trust
 
 	return ts.certs, nil
 }
+
+type namedTrustStores struct {
+	certs map[string][]*x509.Certificate
+}
+
+// NewTrustStores returns a trust store serving the certificates of the given PEM encoded trust stores,
+// keyed by trust store name. The trust store type is defined by the trust policy.
+func NewTrustStores(stores map[string]string) (truststore.X509TrustStore, error) {
+	certs := make(map[string][]*x509.Certificate, len(stores))
+	for name, data := range stores {
+		parsed, err := cryptoutils.LoadCertificatesFromPEM(bytes.NewReader([]byte(data)))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse certificates of trust store %s", name)
+		}
+		certs[name] = parsed
+	}
+	return &namedTrustStores{certs: certs}, nil
+}
+
+func (ts *namedTrustStores) GetCertificates(ctx context.Context, storeType truststore.Type, name string) ([]*x509.Certificate, error) {
+	certs, ok := ts.certs[name]
+	if !ok {
+		return nil, errors.Errorf("trust store %s:%s not found", storeType, name)
+	}
+	return certs, nil
+}
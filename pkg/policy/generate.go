@@ -3,7 +3,9 @@ package policy
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/api/kyverno"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
@@ -12,12 +14,17 @@ import (
 	backgroundcommon "github.com/kyverno/kyverno/pkg/background/common"
 	generateutils "github.com/kyverno/kyverno/pkg/background/generate"
 	"github.com/kyverno/kyverno/pkg/config"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
+	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	"go.uber.org/multierr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/util/retry"
 )
 
 func (pc *policyController) handleGenerate(policyKey string, policy kyvernov1.PolicyInterface) error {
@@ -82,30 +89,149 @@ func (pc *policyController) syncDataPolicyChanges(policy kyvernov1.PolicyInterfa
 }
 
 func (pc *policyController) handleGenerateForExisting(policy kyvernov1.PolicyInterface) error {
+	logger := pc.log.WithName("handleGenerateForExisting")
+	return pc.createURForExistingTriggers(policy, logger, func(rule kyvernov1.Rule) bool {
+		// check if the rule sets the generateExisting field.
+		// if not, use the policy level setting
+		if generateExisting := rule.Generation.GenerateExisting; generateExisting != nil {
+			return *generateExisting
+		}
+		return policy.GetSpec().GenerateExisting
+	}, func(processed, total int) {
+		if err := pc.updateGenerateExistingStatus(policy, processed, total); err != nil {
+			logger.Error(err, "failed to update the generate existing status", "policy", policy.GetName())
+		}
+	})
+}
+
+// updateGenerateExistingStatus reports the progress of the generateExisting rollout in the policy status
+func (pc *policyController) updateGenerateExistingStatus(policy kyvernov1.PolicyInterface, processed, total int) error {
+	setStatus := func(status *kyvernov1.PolicyStatus) {
+		status.GenerateExisting = &kyvernov1.GenerateExistingStatus{
+			Total:     total,
+			Processed: processed,
+		}
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if policy.IsNamespaced() {
+			latest, err := pc.kyvernoClient.KyvernoV1().Policies(policy.GetNamespace()).Get(context.TODO(), policy.GetName(), metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			return controllerutils.UpdateStatus(context.TODO(), latest, pc.kyvernoClient.KyvernoV1().Policies(policy.GetNamespace()), func(policy *kyvernov1.Policy) error {
+				setStatus(policy.GetStatus())
+				return nil
+			}, nil)
+		}
+		latest, err := pc.kyvernoClient.KyvernoV1().ClusterPolicies().Get(context.TODO(), policy.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		return controllerutils.UpdateStatus(context.TODO(), latest, pc.kyvernoClient.KyvernoV1().ClusterPolicies(), func(policy *kyvernov1.ClusterPolicy) error {
+			setStatus(policy.GetStatus())
+			return nil
+		}, nil)
+	})
+}
+
+// syncNamespace applies the rules generating into namespaces selected by label to their existing triggers when they
+// select the namespace, and deletes the synchronized resources they generated into it when they no longer select it
+func (pc *policyController) syncNamespace(name string) error {
+	logger := pc.log.WithName("syncNamespace").WithValues("namespace", name)
+	ns, err := pc.nsInformer.Lister().Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	cpols, err := pc.pLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	nsLabels := labels.Set(ns.GetLabels())
+	var errs []error
+	for _, cpol := range cpols {
+		if !cpol.GetSpec().HasGenerate() || cpol.GetSpec().DryRun {
+			continue
+		}
+		err := pc.createURForExistingTriggers(cpol, logger, func(rule kyvernov1.Rule) bool {
+			return selectsNamespace(rule, nsLabels)
+		}, nil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to create UR for policy %s: %w", cpol.GetName(), err))
+		}
+		if err := pc.deleteUnselectedDownstream(cpol, name, nsLabels); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete downstream resources of policy %s: %w", cpol.GetName(), err))
+		}
+	}
+	return multierr.Combine(errs...)
+}
+
+// deleteUnselectedDownstream deletes the resources generated into the namespace by the synchronized rules
+// whose namespace selector does not match the namespace labels anymore
+func (pc *policyController) deleteUnselectedDownstream(policy kyvernov1.PolicyInterface, namespace string, nsLabels labels.Set) error {
+	var errs []error
+	for _, rule := range autogen.ComputeRules(policy, "") {
+		if !rule.HasGenerate() || !rule.Generation.Synchronize {
+			continue
+		}
+		selector := &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				common.GeneratePolicyLabel:          policy.GetName(),
+				common.GeneratePolicyNamespaceLabel: policy.GetNamespace(),
+				common.GenerateRuleLabel:            rule.Name,
+				kyverno.LabelAppManagedBy:           kyverno.ValueKyvernoApp,
+			},
+		}
+		for _, pattern := range generatePatterns(rule) {
+			if pattern.NamespaceSelector == nil {
+				continue
+			}
+			nsSelector, err := metav1.LabelSelectorAsSelector(pattern.NamespaceSelector)
+			if err != nil || nsSelector.Matches(nsLabels) {
+				continue
+			}
+			kinds := pattern.CloneList.Kinds
+			if pattern.GetKind() != "" {
+				kinds = []string{pattern.GetAPIVersion() + "/" + pattern.GetKind()}
+			}
+			for _, kind := range kinds {
+				apiVersion, kind := kubeutils.GetKindFromGVK(kind)
+				downstreams, err := pc.client.ListResource(context.TODO(), apiVersion, kind, namespace, selector)
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				for _, downstream := range downstreams.Items {
+					pc.log.V(4).Info("deleting downstream resource from unselected namespace", "policy", policy.GetName(), "rule", rule.Name, "kind", downstream.GetKind(), "namespace", namespace, "name", downstream.GetName())
+					err := pc.client.DeleteResource(context.TODO(), downstream.GetAPIVersion(), downstream.GetKind(), namespace, downstream.GetName(), false)
+					if err != nil && !apierrors.IsNotFound(err) {
+						errs = append(errs, err)
+					}
+				}
+			}
+		}
+	}
+	return multierr.Combine(errs...)
+}
+
+// createURForExistingTriggers creates generate URs for the existing triggers of the rules accepted by the filter,
+// the progress callback is invoked after every created UR when it is not nil
+func (pc *policyController) createURForExistingTriggers(policy kyvernov1.PolicyInterface, logger logr.Logger, filter func(kyvernov1.Rule) bool, progress func(processed, total int)) error {
 	var errors []error
 	var triggers []*unstructured.Unstructured
 	policyNew := policy.CreateDeepCopy()
 	policyNew.GetSpec().Rules = nil
 	ur := newGenerateUR(policy)
-	logger := pc.log.WithName("handleGenerateForExisting")
 	for _, rule := range policy.GetSpec().Rules {
-		if !rule.HasGenerate() {
-			continue
-		}
-		// check if the rule sets the generateExisting field.
-		// if not, use the policy level setting
-		generateExisting := rule.Generation.GenerateExisting
-		if generateExisting != nil {
-			if !*generateExisting {
-				continue
-			}
-		} else if !policy.GetSpec().GenerateExisting {
+		if !rule.HasGenerate() || !filter(rule) {
 			continue
 		}
 		triggers = getTriggers(pc.client, rule, policy.IsNamespaced(), policy.GetNamespace(), pc.log)
 		policyNew.GetSpec().SetRules([]kyvernov1.Rule{rule})
 		for _, trigger := range triggers {
-			namespaceLabels := engineutils.GetNamespaceSelectorsFromNamespaceLister(trigger.GetKind(), trigger.GetNamespace(), pc.nsLister, pc.log)
+			namespaceLabels := engineutils.GetNamespaceLabels(trigger.GetKind(), trigger.GetNamespace(), pc.nsLabels, pc.log)
 			policyContext, err := common.NewBackgroundContext(pc.log, pc.client, ur.Spec.Context, policy, trigger, pc.configuration, pc.jp, namespaceLabels)
 			if err != nil {
 				errors = append(errors, fmt.Errorf("failed to build policy context for rule %s: %w", rule.Name, err))
@@ -124,26 +250,43 @@ func (pc *policyController) handleGenerateForExisting(policy kyvernov1.PolicyInt
 		return multierr.Combine(errors...)
 	}
 
-	logger.V(4).Info("creating new UR for generate")
-	created, err := pc.urGenerator.Generate(context.TODO(), pc.kyvernoClient, ur, pc.log)
-	if err != nil {
-		errors = append(errors, err)
-		return multierr.Combine(errors...)
+	// the rule contexts are split into batches to avoid spiking the API server when there are many triggers
+	ruleContexts := ur.Spec.RuleContext
+	batchSize := pc.rollout.BatchSize
+	if batchSize <= 0 {
+		batchSize = len(ruleContexts)
 	}
-	if created != nil {
-		updated := created.DeepCopy()
-		updated.Status.State = kyvernov2.Pending
-		_, err = pc.kyvernoClient.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(context.TODO(), updated, metav1.UpdateOptions{})
+	for start := 0; start < len(ruleContexts); start += batchSize {
+		if start > 0 && pc.rollout.BatchDelay > 0 {
+			time.Sleep(pc.rollout.BatchDelay)
+		}
+		end := min(start+batchSize, len(ruleContexts))
+		batch := ur.DeepCopy()
+		batch.Spec.RuleContext = ruleContexts[start:end]
+		logger.V(4).Info("creating new UR for generate", "triggers", end-start)
+		created, err := pc.urGenerator.Generate(context.TODO(), pc.kyvernoClient, batch, pc.log)
 		if err != nil {
 			errors = append(errors, err)
 			return multierr.Combine(errors...)
 		}
-		pc.log.V(4).Info("successfully created UR on policy update", "policy", policyNew.GetName())
+		if created != nil {
+			updated := created.DeepCopy()
+			updated.Status.State = kyvernov2.Pending
+			_, err = pc.kyvernoClient.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).UpdateStatus(context.TODO(), updated, metav1.UpdateOptions{})
+			if err != nil {
+				errors = append(errors, err)
+				return multierr.Combine(errors...)
+			}
+			pc.log.V(4).Info("successfully created UR on policy update", "policy", policyNew.GetName())
+		}
+		if progress != nil {
+			progress(end, len(ruleContexts))
+		}
 	}
 	return multierr.Combine(errors...)
 }
 
-func (pc *policyController) createURForDownstreamDeletion(policy kyvernov1.PolicyInterface) error {
+func (pc *policyController) createURForDownstreamDeletion(policy kyvernov1.PolicyInterface, policyDeletion bool) error {
 	var errs []error
 	var err error
 	rules := autogen.ComputeRules(policy, "")
@@ -157,9 +300,19 @@ func (pc *policyController) createURForDownstreamDeletion(policy kyvernov1.Polic
 			continue
 		}
 
-		sync, orphanDownstreamOnPolicyDelete := r.GetSyncAndOrphanDownstream()
+		orphan := generate.OrphanOnRuleDelete()
+		if policyDeletion {
+			orphan = generate.OrphanOnPolicyDelete()
+		}
+		if orphan {
+			if err := pc.labelOrphans(policy, r); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
 		if generate.GetData() != nil {
-			if sync && (generate.GetType() == kyvernov1.Data) && !orphanDownstreamOnPolicyDelete {
+			if generate.GetType() == kyvernov1.Data {
 				if ur, err = pc.buildUrForDataRuleChanges(policy, ur, r.Name, r.Generation.GeneratePattern, true, true); err != nil {
 					errs = append(errs, err)
 				}
@@ -168,7 +321,7 @@ func (pc *policyController) createURForDownstreamDeletion(policy kyvernov1.Polic
 
 		for _, foreach := range generate.ForEachGeneration {
 			if foreach.GetData() != nil {
-				if sync && (foreach.GetType() == kyvernov1.Data) && !orphanDownstreamOnPolicyDelete {
+				if foreach.GetType() == kyvernov1.Data {
 					if ur, err = pc.buildUrForDataRuleChanges(policy, ur, r.Name, foreach.GeneratePattern, true, true); err != nil {
 						errs = append(errs, err)
 					}
@@ -198,6 +351,44 @@ func (pc *policyController) createURForDownstreamDeletion(policy kyvernov1.Polic
 	return multierr.Combine(errs...)
 }
 
+// labelOrphans adds the orphan labels of the rule to its downstream resources kept after the rule or policy deletion
+func (pc *policyController) labelOrphans(policy kyvernov1.PolicyInterface, rule kyvernov1.Rule) error {
+	orphanLabels := rule.Generation.GetOrphanLabels()
+	if len(orphanLabels) == 0 {
+		return nil
+	}
+	selector := map[string]string{
+		common.GeneratePolicyLabel:          policy.GetName(),
+		common.GeneratePolicyNamespaceLabel: policy.GetNamespace(),
+		common.GenerateRuleLabel:            rule.Name,
+		kyverno.LabelAppManagedBy:           kyverno.ValueKyvernoApp,
+	}
+	patterns := []kyvernov1.GeneratePattern{rule.Generation.GeneratePattern}
+	for _, foreach := range rule.Generation.ForEachGeneration {
+		patterns = append(patterns, foreach.GeneratePattern)
+	}
+	var errs []error
+	for _, pattern := range patterns {
+		kinds := pattern.CloneList.Kinds
+		if pattern.GetKind() != "" {
+			kinds = []string{pattern.GetAPIVersion() + "/" + pattern.GetKind()}
+		}
+		for _, kind := range kinds {
+			apiVersion, kind := kubeutils.GetKindFromGVK(kind)
+			downstreams, err := common.FindDownstream(pc.client, apiVersion, kind, selector)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			pc.log.V(4).Info("labelling orphaned downstream resources", "policy", policy.GetName(), "rule", rule.Name, "kind", kind, "count", len(downstreams.Items))
+			if err := common.LabelOrphans(pc.client, downstreams.Items, orphanLabels); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return multierr.Combine(errs...)
+}
+
 func (pc *policyController) buildUrForDataRuleChanges(policy kyvernov1.PolicyInterface, ur *kyvernov2.UpdateRequest, ruleName string, pattern kyvernov1.GeneratePattern, deleteDownstream, policyDeletion bool) (*kyvernov2.UpdateRequest, error) {
 	labels := map[string]string{
 		common.GeneratePolicyLabel:          policy.GetName(),
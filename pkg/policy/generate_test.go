@@ -0,0 +1,88 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func newDownstream(namespace, name, policy, rule string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetLabels(map[string]string{
+		common.GeneratePolicyLabel:          policy,
+		common.GeneratePolicyNamespaceLabel: "",
+		common.GenerateRuleLabel:            rule,
+		kyverno.LabelAppManagedBy:           kyverno.ValueKyvernoApp,
+	})
+	return obj
+}
+
+func Test_deleteUnselectedDownstream(t *testing.T) {
+	policy := &kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "generate-cm"},
+		Spec: kyvernov1.Spec{
+			Rules: []kyvernov1.Rule{{
+				Name: "selected",
+				Generation: &kyvernov1.Generation{
+					Synchronize: true,
+					GeneratePattern: kyvernov1.GeneratePattern{
+						ResourceSpec:      kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "ConfigMap", Name: "cm"},
+						NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+					},
+				},
+			}, {
+				Name: "unsynchronized",
+				Generation: &kyvernov1.Generation{
+					GeneratePattern: kyvernov1.GeneratePattern{
+						ResourceSpec:      kyvernov1.ResourceSpec{APIVersion: "v1", Kind: "ConfigMap", Name: "cm"},
+						NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+					},
+				},
+			}},
+		},
+	}
+	gvrToListKind := map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "configmaps"}: "ConfigMapList",
+	}
+	objects := []runtime.Object{
+		newDownstream("team-a", "synchronized", "generate-cm", "selected"),
+		newDownstream("team-a", "unsynchronized", "generate-cm", "unsynchronized"),
+		newDownstream("team-b", "other-namespace", "generate-cm", "selected"),
+		newDownstream("team-a", "other-policy", "other", "selected"),
+	}
+	client, err := dclient.NewFakeClient(runtime.NewScheme(), gvrToListKind, objects...)
+	assert.NoError(t, err)
+	client.SetDiscovery(dclient.NewFakeDiscoveryClient(nil))
+	pc := &policyController{client: client, log: logr.Discard()}
+
+	// the namespace is still selected, nothing is deleted
+	assert.NoError(t, pc.deleteUnselectedDownstream(policy, "team-a", labels.Set{"team": "payments"}))
+	list, err := client.ListResource(context.TODO(), "v1", "ConfigMap", "", nil)
+	assert.NoError(t, err)
+	assert.Len(t, list.Items, 4)
+
+	// the namespace stopped matching the selector, the synchronized resource generated into it is deleted
+	assert.NoError(t, pc.deleteUnselectedDownstream(policy, "team-a", labels.Set{"team": "billing"}))
+	list, err = client.ListResource(context.TODO(), "v1", "ConfigMap", "", nil)
+	assert.NoError(t, err)
+	var names []string
+	for _, item := range list.Items {
+		names = append(names, item.GetName())
+	}
+	assert.ElementsMatch(t, []string{"unsynchronized", "other-namespace", "other-policy"}, names)
+}
@@ -21,12 +21,14 @@ import (
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/informers"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
 	"github.com/kyverno/kyverno/pkg/utils/generator"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
 	policyvalidation "github.com/kyverno/kyverno/pkg/validation/policy"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -34,7 +36,6 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	corev1informers "k8s.io/client-go/informers/core/v1"
-	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/workqueue"
@@ -58,6 +59,7 @@ type policyController struct {
 
 	pInformer  kyvernov1informers.ClusterPolicyInformer
 	npInformer kyvernov1informers.PolicyInformer
+	nsInformer corev1informers.NamespaceInformer
 
 	eventGen      event.Interface
 	eventRecorder events.EventRecorder
@@ -74,8 +76,8 @@ type policyController struct {
 	// urLister can list/get update request from the shared informer's store
 	urLister kyvernov2listers.UpdateRequestLister
 
-	// nsLister can list/get namespaces from the shared informer's store
-	nsLister corev1listers.NamespaceLister
+	// nsLabels resolves namespace labels from the shared informer's store
+	nsLabels informers.NamespaceLabels
 
 	informersSynced []cache.InformerSynced
 
@@ -91,6 +93,16 @@ type policyController struct {
 	jp jmespath.Interface
 
 	urGenerator generator.UpdateRequestGenerator
+
+	rollout GenerateExistingRollout
+}
+
+// GenerateExistingRollout throttles the creation of the update requests applying generate rules to existing triggers
+type GenerateExistingRollout struct {
+	// BatchSize is the maximum number of triggers per update request, a single update request is created when zero
+	BatchSize int
+	// BatchDelay is the time waited between the creation of two update requests
+	BatchDelay time.Duration
 }
 
 // NewPolicyController create a new PolicyController
@@ -104,11 +116,13 @@ func NewPolicyController(
 	configuration config.Configuration,
 	eventGen event.Interface,
 	namespaces corev1informers.NamespaceInformer,
+	nsLabels informers.NamespaceLabels,
 	log logr.Logger,
 	reconcilePeriod time.Duration,
 	metricsConfig metrics.MetricsConfigManager,
 	jp jmespath.Interface,
 	urGenerator generator.UpdateRequestGenerator,
+	rollout GenerateExistingRollout,
 ) (*policyController, error) {
 	// Event broad caster
 	eventInterface := client.GetEventsInterface()
@@ -127,6 +141,7 @@ func NewPolicyController(
 		engine:          engine,
 		pInformer:       pInformer,
 		npInformer:      npInformer,
+		nsInformer:      namespaces,
 		eventGen:        eventGen,
 		eventRecorder:   eventBroadcaster.NewRecorder(scheme.Scheme, "policy_controller"),
 		queue:           workqueue.NewNamedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[any](), "policy"),
@@ -136,11 +151,12 @@ func NewPolicyController(
 		log:             log,
 		jp:              jp,
 		urGenerator:     urGenerator,
+		rollout:         rollout,
 	}
 
 	pc.pLister = pInformer.Lister()
 	pc.npLister = npInformer.Lister()
-	pc.nsLister = namespaces.Lister()
+	pc.nsLabels = nsLabels
 	pc.urLister = urInformer.Lister()
 
 	pc.informersSynced = []cache.InformerSynced{pInformer.Informer().HasSynced, npInformer.Informer().HasSynced, urInformer.Informer().HasSynced, namespaces.Informer().HasSynced}
@@ -155,6 +171,12 @@ func (pc *policyController) canBackgroundProcess(p kyvernov1.PolicyInterface) bo
 		return false
 	}
 
+	// dry run policies report their side effects through events and never create update requests
+	if p.GetSpec().DryRun {
+		logger.V(4).Info("policy is in dry run mode, background processing is skipped")
+		return false
+	}
+
 	if err := policyvalidation.ValidateVariables(p, true); err != nil {
 		logger.V(4).Info("policy cannot be processed in the background")
 		return false
@@ -202,7 +224,7 @@ func (pc *policyController) updatePolicy(old, cur interface{}) {
 
 	logger.V(2).Info("updating policy", "name", oldP.GetName())
 	if deleted, ok, selector := ruleChange(oldP, curP); ok {
-		err := pc.createURForDownstreamDeletion(deleted)
+		err := pc.createURForDownstreamDeletion(deleted, false)
 		if err != nil {
 			utilruntime.HandleError(fmt.Errorf("failed to create UR on rule deletion, clean up downstream resource may be failed: %v", err))
 		}
@@ -228,12 +250,63 @@ func (pc *policyController) deletePolicy(obj interface{}) {
 	}
 
 	logger.Info("policy deleted", "uid", p.GetUID(), "kind", p.GetKind(), "namespace", p.GetNamespace(), "name", p.GetName())
-	err := pc.createURForDownstreamDeletion(p)
+	if p.GetSpec().DryRun {
+		return
+	}
+	err := pc.createURForDownstreamDeletion(p, true)
 	if err != nil {
 		utilruntime.HandleError(fmt.Errorf("failed to create UR on policy deletion, clean up downstream resource may be failed: %v", err))
 	}
 }
 
+func (pc *policyController) addNamespace(obj interface{}, isInInitialList bool) {
+	// namespaces existing at startup are handled by the policies reconciliation
+	if isInInitialList {
+		return
+	}
+	ns, ok := obj.(*corev1.Namespace)
+	if !ok {
+		return
+	}
+	pc.enqueueNamespace(nil, ns)
+}
+
+func (pc *policyController) updateNamespace(old, cur interface{}) {
+	oldNs, ok := old.(*corev1.Namespace)
+	if !ok {
+		return
+	}
+	curNs, ok := cur.(*corev1.Namespace)
+	if !ok {
+		return
+	}
+	if datautils.DeepEqual(oldNs.GetLabels(), curNs.GetLabels()) {
+		return
+	}
+	pc.enqueueNamespace(oldNs, curNs)
+}
+
+// namespaceKey is the queue key of a namespace whose selection by generate rules changed
+type namespaceKey string
+
+// enqueueNamespace queues the namespace when a generate rule starts or stops selecting it, oldNs is nil for a created namespace
+func (pc *policyController) enqueueNamespace(oldNs, ns *corev1.Namespace) {
+	cpols, err := pc.pLister.List(labels.Everything())
+	if err != nil {
+		pc.log.Error(err, "unable to list ClusterPolicies")
+		return
+	}
+	var oldLabels labels.Set
+	if oldNs != nil {
+		oldLabels = oldNs.GetLabels()
+	}
+	if !namespaceSelectionChanged(cpols, oldLabels, ns.GetLabels(), oldNs == nil) {
+		return
+	}
+	pc.log.V(4).Info("queuing namespace for generate processing", "name", ns.GetName())
+	pc.queue.Add(namespaceKey(ns.GetName()))
+}
+
 func (pc *policyController) enqueuePolicy(policy kyvernov1.PolicyInterface) {
 	logger := pc.log
 	key, err := cache.MetaNamespaceKeyFunc(policy)
@@ -270,6 +343,11 @@ func (pc *policyController) Run(ctx context.Context, workers int) {
 		DeleteFunc: pc.deletePolicy,
 	})
 
+	_, _ = pc.nsInformer.Informer().AddEventHandler(cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc:    pc.addNamespace,
+		UpdateFunc: pc.updateNamespace,
+	})
+
 	for i := 0; i < workers; i++ {
 		go wait.UntilWithContext(ctx, pc.worker, time.Second)
 	}
@@ -292,7 +370,13 @@ func (pc *policyController) processNextWorkItem() bool {
 		return false
 	}
 	defer pc.queue.Done(key)
-	err := pc.syncPolicy(key.(string))
+	var err error
+	switch key := key.(type) {
+	case namespaceKey:
+		err = pc.syncNamespace(string(key))
+	case string:
+		err = pc.syncPolicy(key)
+	}
 	pc.handleErr(err, key)
 
 	return true
@@ -330,6 +414,9 @@ func (pc *policyController) syncPolicy(key string) error {
 			return nil
 		}
 		return err
+	} else if policy.GetSpec().DryRun {
+		logger.V(4).Info("policy is in dry run mode, skipping", "key", key)
+		return nil
 	} else {
 		err = pc.handleMutate(key, policy)
 		if err != nil {
@@ -399,7 +486,7 @@ func (pc *policyController) requeuePolicies() {
 }
 
 func (pc *policyController) handleUpdateRequest(ur *kyvernov2.UpdateRequest, triggerResource *unstructured.Unstructured, ruleName string, policy kyvernov1.PolicyInterface) (skip bool, err error) {
-	namespaceLabels := engineutils.GetNamespaceSelectorsFromNamespaceLister(triggerResource.GetKind(), triggerResource.GetNamespace(), pc.nsLister, pc.log)
+	namespaceLabels := engineutils.GetNamespaceLabels(triggerResource.GetKind(), triggerResource.GetNamespace(), pc.nsLabels, pc.log)
 	policyContext, err := backgroundcommon.NewBackgroundContext(pc.log, pc.client, ur.Spec.Context, policy, triggerResource, pc.configuration, pc.jp, namespaceLabels)
 	if err != nil {
 		return false, fmt.Errorf("failed to build policy context for rule %s: %w", ruleName, err)
@@ -3,7 +3,9 @@ package policy
 import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/ext/wildcard"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 func resourceMatches(match kyvernov1.ResourceDescription, res unstructured.Unstructured, isNamespacedPolicy bool) bool {
@@ -30,6 +32,59 @@ func resourceMatches(match kyvernov1.ResourceDescription, res unstructured.Unstr
 	return true
 }
 
+// selectsNamespace returns true if the generate rule targets namespaces selected by label
+// and one of its selectors matches the given namespace labels
+func selectsNamespace(rule kyvernov1.Rule, nsLabels labels.Set) bool {
+	if !rule.HasGenerate() {
+		return false
+	}
+	for _, pattern := range generatePatterns(rule) {
+		if pattern.NamespaceSelector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pattern.NamespaceSelector)
+		if err != nil {
+			continue
+		}
+		if selector.Matches(nsLabels) {
+			return true
+		}
+	}
+	return false
+}
+
+// namespaceSelectionChanged returns true if a generate rule of the policies starts or stops selecting a namespace
+// whose labels changed from oldLabels to newLabels, a created namespace only counts when it is selected
+func namespaceSelectionChanged(policies []*kyvernov1.ClusterPolicy, oldLabels, newLabels labels.Set, created bool) bool {
+	for _, policy := range policies {
+		if !policy.GetSpec().HasGenerate() || policy.GetSpec().DryRun {
+			continue
+		}
+		for _, rule := range policy.GetSpec().Rules {
+			selected := selectsNamespace(rule, newLabels)
+			if created {
+				if selected {
+					return true
+				}
+				continue
+			}
+			if selected != selectsNamespace(rule, oldLabels) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// generatePatterns returns the patterns of a generate rule, including the ones of its foreach declarations
+func generatePatterns(rule kyvernov1.Rule) []kyvernov1.GeneratePattern {
+	patterns := []kyvernov1.GeneratePattern{rule.Generation.GeneratePattern}
+	for _, foreach := range rule.Generation.ForEachGeneration {
+		patterns = append(patterns, foreach.GeneratePattern)
+	}
+	return patterns
+}
+
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
 		if s == item {
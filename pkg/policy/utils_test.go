@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	kyverno "github.com/kyverno/kyverno/api/kyverno/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 func Test_resourceMatches(t *testing.T) {
@@ -249,3 +251,146 @@ func Test_resourceMatches(t *testing.T) {
 		})
 	}
 }
+
+func Test_selectsNamespace(t *testing.T) {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}}
+	tests := []struct {
+		name     string
+		rule     kyverno.Rule
+		nsLabels labels.Set
+		want     bool
+	}{
+		{
+			name:     "Not a generate rule",
+			rule:     kyverno.Rule{Name: "validate"},
+			nsLabels: labels.Set{"team": "payments"},
+			want:     false,
+		},
+		{
+			name: "Generate rule without namespace selector",
+			rule: kyverno.Rule{
+				Name: "generate",
+				Generation: &kyverno.Generation{
+					GeneratePattern: kyverno.GeneratePattern{
+						ResourceSpec: kyverno.ResourceSpec{APIVersion: "v1", Kind: "ConfigMap", Name: "cm", Namespace: "default"},
+					},
+				},
+			},
+			nsLabels: labels.Set{"team": "payments"},
+			want:     false,
+		},
+		{
+			name: "Matching namespace selector",
+			rule: kyverno.Rule{
+				Name: "generate",
+				Generation: &kyverno.Generation{
+					GeneratePattern: kyverno.GeneratePattern{
+						ResourceSpec:      kyverno.ResourceSpec{APIVersion: "v1", Kind: "ConfigMap", Name: "cm"},
+						NamespaceSelector: selector,
+					},
+				},
+			},
+			nsLabels: labels.Set{"team": "payments", "env": "prod"},
+			want:     true,
+		},
+		{
+			name: "Non-matching namespace selector",
+			rule: kyverno.Rule{
+				Name: "generate",
+				Generation: &kyverno.Generation{
+					GeneratePattern: kyverno.GeneratePattern{
+						ResourceSpec:      kyverno.ResourceSpec{APIVersion: "v1", Kind: "ConfigMap", Name: "cm"},
+						NamespaceSelector: selector,
+					},
+				},
+			},
+			nsLabels: labels.Set{"team": "billing"},
+			want:     false,
+		},
+		{
+			name: "Matching foreach namespace selector",
+			rule: kyverno.Rule{
+				Name: "generate",
+				Generation: &kyverno.Generation{
+					ForEachGeneration: []kyverno.ForEachGeneration{{
+						List: "request.object.data",
+						GeneratePattern: kyverno.GeneratePattern{
+							ResourceSpec:      kyverno.ResourceSpec{APIVersion: "v1", Kind: "ConfigMap", Name: "cm"},
+							NamespaceSelector: selector,
+						},
+					}},
+				},
+			},
+			nsLabels: labels.Set{"team": "payments"},
+			want:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := selectsNamespace(tt.rule, tt.nsLabels); got != tt.want {
+				t.Errorf("selectsNamespace() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_namespaceSelectionChanged(t *testing.T) {
+	policies := []*kyverno.ClusterPolicy{{
+		Spec: kyverno.Spec{
+			Rules: []kyverno.Rule{{
+				Name: "generate",
+				Generation: &kyverno.Generation{
+					GeneratePattern: kyverno.GeneratePattern{
+						ResourceSpec:      kyverno.ResourceSpec{APIVersion: "v1", Kind: "ConfigMap", Name: "cm"},
+						NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+					},
+				},
+			}},
+		},
+	}}
+	tests := []struct {
+		name      string
+		oldLabels labels.Set
+		newLabels labels.Set
+		created   bool
+		want      bool
+	}{
+		{
+			name:      "Created namespace selected",
+			newLabels: labels.Set{"team": "payments"},
+			created:   true,
+			want:      true,
+		},
+		{
+			name:      "Created namespace not selected",
+			newLabels: labels.Set{"team": "billing"},
+			created:   true,
+			want:      false,
+		},
+		{
+			name:      "Namespace starts matching",
+			oldLabels: labels.Set{"team": "billing"},
+			newLabels: labels.Set{"team": "payments"},
+			want:      true,
+		},
+		{
+			name:      "Namespace stops matching",
+			oldLabels: labels.Set{"team": "payments"},
+			newLabels: labels.Set{"team": "billing"},
+			want:      true,
+		},
+		{
+			name:      "Namespace still matching",
+			oldLabels: labels.Set{"team": "payments"},
+			newLabels: labels.Set{"team": "payments", "env": "prod"},
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := namespaceSelectionChanged(policies, tt.oldLabels, tt.newLabels, tt.created); got != tt.want {
+				t.Errorf("namespaceSelectionChanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/google/go-containerregistry/pkg/name"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/ext/wildcard"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
@@ -141,6 +142,17 @@ func (v *Validate) Validate(ctx context.Context, _ []string) (warnings []string,
 		}
 	}
 
+	if v.validationRule.Wasm != nil {
+		if (v.validationRule.Wasm.Module == "") == (v.validationRule.Wasm.Image == "") {
+			return nil, "wasm", fmt.Errorf("one of wasm.module or wasm.image must be set")
+		}
+		if v.validationRule.Wasm.Image != "" {
+			if _, err := name.NewDigest(v.validationRule.Wasm.Image); err != nil {
+				return nil, "wasm.image", fmt.Errorf("wasm.image must be referenced by digest: %w", err)
+			}
+		}
+	}
+
 	if w, err := v.validateAuth(ctx); err != nil {
 		return nil, "", err
 	} else if len(w) > 0 {
@@ -221,6 +233,10 @@ func validationElemCount(v *kyvernov1.Validation) int {
 		count++
 	}
 
+	if v.Wasm != nil {
+		count++
+	}
+
 	return count
 }
 
@@ -380,3 +380,35 @@ func Test_Validate_Validate_Unsupported(t *testing.T) {
 	}
 
 }
+
+func Test_Validate_Wasm(t *testing.T) {
+	testcases := []struct {
+		name    string
+		wasm    kyverno.Wasm
+		wantErr bool
+	}{{
+		name: "module",
+		wasm: kyverno.Wasm{Module: "check"},
+	}, {
+		name: "image pinned by digest",
+		wasm: kyverno.Wasm{Image: "ghcr.io/kyverno/wasm@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"},
+	}, {
+		name:    "image referenced by tag",
+		wasm:    kyverno.Wasm{Image: "ghcr.io/kyverno/wasm:latest"},
+		wantErr: true,
+	}, {
+		name:    "module and image",
+		wasm:    kyverno.Wasm{Module: "check", Image: "ghcr.io/kyverno/wasm@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"},
+		wantErr: true,
+	}, {
+		name:    "empty",
+		wantErr: true,
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := NewMockValidateFactory(&kyverno.Rule{Validation: &kyverno.Validation{Wasm: &tc.wasm}})
+			_, _, err := checker.Validate(context.TODO(), nil)
+			assert.Equal(t, tc.wantErr, err != nil)
+		})
+	}
+}
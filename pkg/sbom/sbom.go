@@ -0,0 +1,203 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	FormatCycloneDX = "CycloneDX"
+	FormatSPDX      = "SPDX"
+
+	// PredicateTypeCycloneDX is the in-toto predicate type of CycloneDX attestations, versioned
+	// predicate types like https://cyclonedx.org/bom/v1.4 are also supported.
+	PredicateTypeCycloneDX = "https://cyclonedx.org/bom"
+	// PredicateTypeSPDX is the in-toto predicate type of SPDX attestations.
+	PredicateTypeSPDX = "https://spdx.dev/Document"
+)
+
+// Document is a format agnostic view of an SBOM, it is added to the context under the `sbom` key
+// when evaluating conditions of SBOM attestations.
+type Document struct {
+	// Format is the format of the SBOM, either CycloneDX or SPDX.
+	Format string `json:"format"`
+	// SpecVersion is the version of the SBOM specification.
+	SpecVersion string `json:"specVersion,omitempty"`
+	// Components are the components (CycloneDX) or packages (SPDX) listed in the SBOM.
+	Components []Component `json:"components"`
+	// Licenses is the deduplicated list of licenses of all components.
+	Licenses []string `json:"licenses"`
+}
+
+// Component is a software component listed in an SBOM.
+type Component struct {
+	Name     string   `json:"name"`
+	Version  string   `json:"version,omitempty"`
+	PURL     string   `json:"purl,omitempty"`
+	Licenses []string `json:"licenses"`
+}
+
+// Format returns the SBOM format of a predicate type, or an empty string if the predicate type
+// is not a known SBOM predicate type.
+func Format(predicateType string) string {
+	lower := strings.ToLower(predicateType)
+	switch {
+	case lower == PredicateTypeCycloneDX || strings.HasPrefix(lower, PredicateTypeCycloneDX+"/"):
+		return FormatCycloneDX
+	case lower == strings.ToLower(PredicateTypeSPDX) || strings.HasPrefix(lower, strings.ToLower(PredicateTypeSPDX)+"/"):
+		return FormatSPDX
+	}
+	return ""
+}
+
+// Parse parses an SBOM predicate of the given format.
+func Parse(format string, predicate map[string]interface{}) (*Document, error) {
+	// predicates that were not valid JSON when attested are wrapped in a Data field
+	if data, ok := predicate["Data"].(string); ok && len(predicate) == 1 {
+		var unwrapped map[string]interface{}
+		if err := json.Unmarshal([]byte(data), &unwrapped); err != nil {
+			return nil, fmt.Errorf("failed to decode %s predicate: %w", format, err)
+		}
+		predicate = unwrapped
+	}
+	raw, err := json.Marshal(predicate)
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case FormatCycloneDX:
+		return parseCycloneDX(raw)
+	case FormatSPDX:
+		return parseSPDX(raw)
+	}
+	return nil, fmt.Errorf("unsupported SBOM format %s", format)
+}
+
+type cycloneDXLicense struct {
+	License struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"license"`
+	Expression string `json:"expression"`
+}
+
+type cycloneDXComponent struct {
+	Name       string               `json:"name"`
+	Version    string               `json:"version"`
+	PURL       string               `json:"purl"`
+	Licenses   []cycloneDXLicense   `json:"licenses"`
+	Components []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXDocument struct {
+	BOMFormat   string               `json:"bomFormat"`
+	SpecVersion string               `json:"specVersion"`
+	Components  []cycloneDXComponent `json:"components"`
+}
+
+func parseCycloneDX(raw []byte) (*Document, error) {
+	var bom cycloneDXDocument
+	if err := json.Unmarshal(raw, &bom); err != nil {
+		return nil, fmt.Errorf("failed to parse CycloneDX document: %w", err)
+	}
+	if bom.BOMFormat != "" && bom.BOMFormat != FormatCycloneDX {
+		return nil, fmt.Errorf("invalid CycloneDX document, unexpected bomFormat %s", bom.BOMFormat)
+	}
+	doc := &Document{
+		Format:      FormatCycloneDX,
+		SpecVersion: bom.SpecVersion,
+	}
+	var flatten func([]cycloneDXComponent)
+	flatten = func(components []cycloneDXComponent) {
+		for _, c := range components {
+			component := Component{
+				Name:     c.Name,
+				Version:  c.Version,
+				PURL:     c.PURL,
+				Licenses: []string{},
+			}
+			for _, l := range c.Licenses {
+				switch {
+				case l.License.ID != "":
+					component.Licenses = append(component.Licenses, l.License.ID)
+				case l.License.Name != "":
+					component.Licenses = append(component.Licenses, l.License.Name)
+				case l.Expression != "":
+					component.Licenses = append(component.Licenses, l.Expression)
+				}
+			}
+			doc.Components = append(doc.Components, component)
+			flatten(c.Components)
+		}
+	}
+	flatten(bom.Components)
+	doc.complete()
+	return doc, nil
+}
+
+type spdxPackage struct {
+	Name             string `json:"name"`
+	VersionInfo      string `json:"versionInfo"`
+	LicenseConcluded string `json:"licenseConcluded"`
+	LicenseDeclared  string `json:"licenseDeclared"`
+	ExternalRefs     []struct {
+		ReferenceType    string `json:"referenceType"`
+		ReferenceLocator string `json:"referenceLocator"`
+	} `json:"externalRefs"`
+}
+
+type spdxDocument struct {
+	SPDXVersion string        `json:"spdxVersion"`
+	Packages    []spdxPackage `json:"packages"`
+}
+
+func parseSPDX(raw []byte) (*Document, error) {
+	var spdx spdxDocument
+	if err := json.Unmarshal(raw, &spdx); err != nil {
+		return nil, fmt.Errorf("failed to parse SPDX document: %w", err)
+	}
+	doc := &Document{
+		Format:      FormatSPDX,
+		SpecVersion: strings.TrimPrefix(spdx.SPDXVersion, "SPDX-"),
+	}
+	for _, p := range spdx.Packages {
+		component := Component{
+			Name:     p.Name,
+			Version:  p.VersionInfo,
+			Licenses: []string{},
+		}
+		// the concluded license takes precedence over the declared one
+		for _, license := range []string{p.LicenseConcluded, p.LicenseDeclared} {
+			if license != "" && license != "NOASSERTION" && license != "NONE" {
+				component.Licenses = append(component.Licenses, license)
+				break
+			}
+		}
+		for _, ref := range p.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				component.PURL = ref.ReferenceLocator
+				break
+			}
+		}
+		doc.Components = append(doc.Components, component)
+	}
+	doc.complete()
+	return doc, nil
+}
+
+func (d *Document) complete() {
+	if d.Components == nil {
+		d.Components = []Component{}
+	}
+	d.Licenses = []string{}
+	seen := map[string]bool{}
+	for _, c := range d.Components {
+		for _, l := range c.Licenses {
+			if !seen[l] {
+				seen[l] = true
+				d.Licenses = append(d.Licenses, l)
+			}
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package sbom
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestFormat(t *testing.T) {
+	testCases := []struct {
+		predicateType string
+		want          string
+	}{
+		{predicateType: "https://cyclonedx.org/bom", want: FormatCycloneDX},
+		{predicateType: "https://cyclonedx.org/bom/v1.4", want: FormatCycloneDX},
+		{predicateType: "https://cyclonedx.org/BOM/v1", want: FormatCycloneDX},
+		{predicateType: "https://spdx.dev/Document", want: FormatSPDX},
+		{predicateType: "https://spdx.dev/Document/v2.3", want: FormatSPDX},
+		{predicateType: "https://slsa.dev/provenance/v0.2", want: ""},
+		{predicateType: "https://cyclonedx.org/bomb", want: ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.predicateType, func(t *testing.T) {
+			assert.Equal(t, Format(tc.predicateType), tc.want)
+		})
+	}
+}
+
+var cycloneDXPredicate = `{
+	"bomFormat": "CycloneDX",
+	"specVersion": "1.4",
+	"components": [
+		{
+			"name": "openssl",
+			"version": "3.0.7",
+			"purl": "pkg:apk/alpine/openssl@3.0.7",
+			"licenses": [{"license": {"id": "Apache-2.0"}}],
+			"components": [
+				{"name": "libcrypto3", "version": "3.0.7", "licenses": [{"expression": "Apache-2.0 OR MIT"}]}
+			]
+		},
+		{
+			"name": "busybox",
+			"version": "1.35.0",
+			"licenses": [{"license": {"name": "GPL-2.0-only"}}, {"license": {"id": "Apache-2.0"}}]
+		}
+	]
+}`
+
+var spdxPredicate = `{
+	"spdxVersion": "SPDX-2.3",
+	"packages": [
+		{
+			"name": "openssl",
+			"versionInfo": "3.0.7",
+			"licenseConcluded": "Apache-2.0",
+			"licenseDeclared": "MIT",
+			"externalRefs": [
+				{"referenceType": "cpe23Type", "referenceLocator": "cpe:2.3:a:openssl:openssl:3.0.7"},
+				{"referenceType": "purl", "referenceLocator": "pkg:apk/alpine/openssl@3.0.7"}
+			]
+		},
+		{
+			"name": "busybox",
+			"versionInfo": "1.35.0",
+			"licenseConcluded": "NOASSERTION",
+			"licenseDeclared": "GPL-2.0-only"
+		}
+	]
+}`
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name      string
+		format    string
+		predicate string
+		want      *Document
+		wantErr   bool
+	}{{
+		name:      "cyclonedx",
+		format:    FormatCycloneDX,
+		predicate: cycloneDXPredicate,
+		want: &Document{
+			Format:      FormatCycloneDX,
+			SpecVersion: "1.4",
+			Components: []Component{
+				{Name: "openssl", Version: "3.0.7", PURL: "pkg:apk/alpine/openssl@3.0.7", Licenses: []string{"Apache-2.0"}},
+				{Name: "libcrypto3", Version: "3.0.7", Licenses: []string{"Apache-2.0 OR MIT"}},
+				{Name: "busybox", Version: "1.35.0", Licenses: []string{"GPL-2.0-only", "Apache-2.0"}},
+			},
+			Licenses: []string{"Apache-2.0", "Apache-2.0 OR MIT", "GPL-2.0-only"},
+		},
+	}, {
+		name:      "spdx",
+		format:    FormatSPDX,
+		predicate: spdxPredicate,
+		want: &Document{
+			Format:      FormatSPDX,
+			SpecVersion: "2.3",
+			Components: []Component{
+				{Name: "openssl", Version: "3.0.7", PURL: "pkg:apk/alpine/openssl@3.0.7", Licenses: []string{"Apache-2.0"}},
+				{Name: "busybox", Version: "1.35.0", Licenses: []string{"GPL-2.0-only"}},
+			},
+			Licenses: []string{"Apache-2.0", "GPL-2.0-only"},
+		},
+	}, {
+		name:      "wrapped predicate",
+		format:    FormatSPDX,
+		predicate: `{"Data": "{\"spdxVersion\": \"SPDX-2.3\"}"}`,
+		want: &Document{
+			Format:      FormatSPDX,
+			SpecVersion: "2.3",
+			Components:  []Component{},
+			Licenses:    []string{},
+		},
+	}, {
+		name:      "invalid bom format",
+		format:    FormatCycloneDX,
+		predicate: `{"bomFormat": "other"}`,
+		wantErr:   true,
+	}, {
+		name:      "unsupported format",
+		format:    "other",
+		predicate: `{}`,
+		wantErr:   true,
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var predicate map[string]interface{}
+			assert.NilError(t, json.Unmarshal([]byte(tc.predicate), &predicate))
+			got, err := Parse(tc.format, predicate)
+			if tc.wantErr {
+				assert.Assert(t, err != nil)
+			} else {
+				assert.NilError(t, err)
+				assert.DeepEqual(t, got, tc.want)
+			}
+		})
+	}
+}
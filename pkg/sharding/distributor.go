@@ -0,0 +1,210 @@
+package sharding
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// HeaderSignature carries the HMAC of the timestamp and payload of a forwarded request
+	HeaderSignature = "X-Kyverno-Shard-Signature"
+	// HeaderTimestamp carries the unix time at which a request was forwarded
+	HeaderTimestamp = "X-Kyverno-Shard-Timestamp"
+	// maxClockSkew is the maximum age of a forwarded request
+	maxClockSkew = time.Minute
+	// signingKeyInfo binds the keys derived from the shared secret to the signature of forwarded requests
+	signingKeyInfo = "kyverno.io/sharding/signature"
+)
+
+// KeyProvider returns the secret shared by all the members, the key signing forwarded requests is derived from it
+type KeyProvider func() ([]byte, error)
+
+// CABundleProvider returns the PEM encoded CA bundle used to verify the certificate served by other members
+type CABundleProvider func() ([]byte, error)
+
+type Distributor interface {
+	// Forward sends the payload to the member owning the key. It returns false when the current replica
+	// owns the key or when the payload could not be forwarded, the work must then be processed locally.
+	Forward(ctx context.Context, key string, payload []byte) bool
+	// Verify checks that a forwarded request was sent by a member of the group
+	Verify(request *http.Request, payload []byte) error
+}
+
+type distributor struct {
+	membership Membership
+	path       string
+	key        KeyProvider
+	caBundle   CABundleProvider
+	serverName string
+	timeout    time.Duration
+
+	lock   sync.Mutex
+	client *http.Client
+	caHash [sha256.Size]byte
+}
+
+// NewDistributor returns a Distributor forwarding payloads to the given path on the owning member,
+// members are expected to serve a certificate for serverName signed by the CA bundle
+func NewDistributor(membership Membership, path string, key KeyProvider, caBundle CABundleProvider, serverName string, timeout time.Duration) Distributor {
+	return &distributor{
+		membership: membership,
+		path:       path,
+		key:        key,
+		caBundle:   caBundle,
+		serverName: serverName,
+		timeout:    timeout,
+	}
+}
+
+func (d *distributor) Forward(ctx context.Context, key string, payload []byte) bool {
+	members := d.membership.Members()
+	identities := make([]string, 0, len(members))
+	for _, member := range members {
+		identities = append(identities, member.Identity)
+	}
+	owner := Owner(key, identities...)
+	if owner == "" || owner == d.membership.Identity() {
+		return false
+	}
+	for _, member := range members {
+		if member.Identity == owner {
+			if err := d.send(ctx, member.Endpoint, payload); err != nil {
+				logger.Error(err, "failed to forward request, processing it locally", "owner", owner)
+				return false
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func (d *distributor) send(ctx context.Context, endpoint string, payload []byte) error {
+	secret, err := d.signingKey()
+	if err != nil {
+		return err
+	}
+	client, err := d.httpClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+d.path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(HeaderTimestamp, timestamp)
+	request.Header.Set(HeaderSignature, sign(secret, timestamp, payload))
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusAccepted && response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", response.StatusCode)
+	}
+	return nil
+}
+
+func (d *distributor) Verify(request *http.Request, payload []byte) error {
+	secret, err := d.signingKey()
+	if err != nil {
+		return err
+	}
+	return verify(secret, request.Header.Get(HeaderTimestamp), request.Header.Get(HeaderSignature), payload, time.Now())
+}
+
+// httpClient returns the client connecting to other members, it is only rebuilt when the CA bundle changes
+func (d *distributor) httpClient() (*http.Client, error) {
+	caPem, err := d.caBundle()
+	if err != nil {
+		return nil, err
+	}
+	caHash := sha256.Sum256(caPem)
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.client != nil && d.caHash == caHash {
+		return d.client, nil
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caPem) {
+		return nil, errors.New("failed to parse root CA certificates")
+	}
+	if d.client != nil {
+		d.client.CloseIdleConnections()
+	}
+	d.client = &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:    roots,
+				ServerName: d.serverName,
+				MinVersion: tls.VersionTLS12,
+			},
+			IdleConnTimeout: 90 * time.Second,
+		},
+	}
+	d.caHash = caHash
+	return d.client, nil
+}
+
+// signingKey returns the key signing forwarded requests, derived from the shared secret
+func (d *distributor) signingKey() ([]byte, error) {
+	secret, err := d.key()
+	if err != nil {
+		return nil, err
+	}
+	return deriveKey(secret)
+}
+
+// deriveKey derives a dedicated HMAC key from the shared secret with HKDF,
+// the secret is never used as is to sign requests
+func deriveKey(secret []byte) ([]byte, error) {
+	key := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, nil, []byte(signingKeyInfo)), key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func sign(secret []byte, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte{0})
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func verify(secret []byte, timestamp string, signature string, payload []byte, now time.Time) error {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.New("invalid timestamp")
+	}
+	if age := now.Sub(time.Unix(unix, 0)); age > maxClockSkew || age < -maxClockSkew {
+		return errors.New("request expired")
+	}
+	expected, err := hex.DecodeString(sign(secret, timestamp, payload))
+	if err != nil {
+		return err
+	}
+	actual, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, actual) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
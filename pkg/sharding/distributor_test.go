@@ -0,0 +1,110 @@
+package sharding
+
+import (
+	"encoding/pem"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_verify(t *testing.T) {
+	now := time.Now()
+	secret := []byte("secret")
+	payload := []byte(`{"uid":"abc"}`)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	signature := sign(secret, timestamp, payload)
+	tests := []struct {
+		name      string
+		secret    []byte
+		timestamp string
+		signature string
+		payload   []byte
+		wantErr   bool
+	}{{
+		name:      "valid",
+		secret:    secret,
+		timestamp: timestamp,
+		signature: signature,
+		payload:   payload,
+	}, {
+		name:      "other secret",
+		secret:    []byte("other"),
+		timestamp: timestamp,
+		signature: signature,
+		payload:   payload,
+		wantErr:   true,
+	}, {
+		name:      "tampered payload",
+		secret:    secret,
+		timestamp: timestamp,
+		signature: signature,
+		payload:   []byte(`{"uid":"def"}`),
+		wantErr:   true,
+	}, {
+		name:      "expired",
+		secret:    secret,
+		timestamp: strconv.FormatInt(now.Add(-2*time.Minute).Unix(), 10),
+		signature: sign(secret, strconv.FormatInt(now.Add(-2*time.Minute).Unix(), 10), payload),
+		payload:   payload,
+		wantErr:   true,
+	}, {
+		name:      "missing signature",
+		secret:    secret,
+		timestamp: timestamp,
+		payload:   payload,
+		wantErr:   true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verify(tt.secret, tt.timestamp, tt.signature, tt.payload, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_deriveKey(t *testing.T) {
+	secret := []byte("secret")
+	key, err := deriveKey(secret)
+	assert.NoError(t, err)
+	assert.Len(t, key, 32)
+	assert.NotEqual(t, secret, key)
+	again, err := deriveKey(secret)
+	assert.NoError(t, err)
+	assert.Equal(t, key, again)
+	other, err := deriveKey([]byte("other"))
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, other)
+}
+
+func Test_httpClient(t *testing.T) {
+	server := httptest.NewTLSServer(nil)
+	defer server.Close()
+	caPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	d := &distributor{
+		caBundle: func() ([]byte, error) { return caPem, nil },
+	}
+	client, err := d.httpClient()
+	assert.NoError(t, err)
+	again, err := d.httpClient()
+	assert.NoError(t, err)
+	assert.Same(t, client, again)
+
+	// a new CA bundle rebuilds the client
+	other := httptest.NewTLSServer(nil)
+	defer other.Close()
+	caPem = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: other.Certificate().Raw})
+	rebuilt, err := d.httpClient()
+	assert.NoError(t, err)
+	assert.NotSame(t, client, rebuilt)
+
+	caPem = []byte("invalid")
+	_, err = d.httpClient()
+	assert.Error(t, err)
+}
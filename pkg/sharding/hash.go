@@ -0,0 +1,34 @@
+package sharding
+
+import (
+	"hash/fnv"
+)
+
+// Owner returns the member owning the key using rendezvous hashing, every member scores the key
+// and the highest score wins. When a member joins or leaves, only the keys it owns are moved.
+func Owner(key string, members ...string) string {
+	var owner string
+	var best uint64
+	for _, member := range members {
+		score := score(key, member)
+		if owner == "" || score > best || (score == best && member < owner) {
+			owner, best = member, score
+		}
+	}
+	return owner
+}
+
+func score(key, member string) uint64 {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(member))
+	_, _ = hash.Write([]byte{0})
+	_, _ = hash.Write([]byte(key))
+	// fnv doesn't spread similar inputs well enough, mix the bits before comparing scores
+	sum := hash.Sum64()
+	sum ^= sum >> 33
+	sum *= 0xff51afd7ed558ccd
+	sum ^= sum >> 33
+	sum *= 0xc4ceb9fe1a85ec53
+	sum ^= sum >> 33
+	return sum
+}
@@ -0,0 +1,39 @@
+package sharding
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOwner(t *testing.T) {
+	assert.Equal(t, "", Owner("key"))
+	assert.Equal(t, "a", Owner("key", "a"))
+	// the owner doesn't depend on the order of members
+	assert.Equal(t, Owner("key", "a", "b", "c"), Owner("key", "c", "b", "a"))
+}
+
+func TestOwner_distribution(t *testing.T) {
+	members := []string{"kyverno-0", "kyverno-1", "kyverno-2"}
+	counts := map[string]int{}
+	for i := 0; i < 3000; i++ {
+		counts[Owner(fmt.Sprintf("uid-%d", i), members...)]++
+	}
+	for _, member := range members {
+		assert.InDelta(t, 1000, counts[member], 150, member)
+	}
+}
+
+func TestOwner_stability(t *testing.T) {
+	members := []string{"kyverno-0", "kyverno-1", "kyverno-2"}
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("uid-%d", i)
+		before := Owner(key, members...)
+		after := Owner(key, append(members, "kyverno-3")...)
+		// keys only move to the new member
+		if after != before {
+			assert.Equal(t, "kyverno-3", after)
+		}
+	}
+}
@@ -0,0 +1,5 @@
+package sharding
+
+import "github.com/kyverno/kyverno/pkg/logging"
+
+var logger = logging.WithName("sharding")
@@ -0,0 +1,185 @@
+package sharding
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coordinationv1client "k8s.io/client-go/kubernetes/typed/coordination/v1"
+	coordinationv1listers "k8s.io/client-go/listers/coordination/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// LabelGroup is set on member leases, its value is the name of the group
+	LabelGroup = "sharding.kyverno.io/group"
+	// AnnotationEndpoint is set on member leases, its value is the endpoint other members forward work to
+	AnnotationEndpoint = "sharding.kyverno.io/endpoint"
+)
+
+// Member is a replica taking part in the work distribution
+type Member struct {
+	Identity string
+	Endpoint string
+}
+
+type Membership interface {
+	// Identity returns the identity of the current replica
+	Identity() string
+	// Members returns the live members of the group sorted by identity, including the current replica
+	Members() []Member
+}
+
+type leaseMembership struct {
+	group    string
+	identity string
+	endpoint string
+	duration time.Duration
+	client   coordinationv1client.LeaseInterface
+	lister   coordinationv1listers.LeaseNamespaceLister
+}
+
+// NewLeaseMembership returns a Membership where every replica maintains its own lease,
+// members are discovered from the leases of the group that are not expired
+func NewLeaseMembership(
+	group string,
+	identity string,
+	endpoint string,
+	duration time.Duration,
+	client coordinationv1client.LeaseInterface,
+	lister coordinationv1listers.LeaseNamespaceLister,
+) *leaseMembership {
+	return &leaseMembership{
+		group:    group,
+		identity: identity,
+		endpoint: endpoint,
+		duration: duration,
+		client:   client,
+		lister:   lister,
+	}
+}
+
+func (m *leaseMembership) Identity() string {
+	return m.identity
+}
+
+func (m *leaseMembership) Members() []Member {
+	leases, err := m.lister.List(labels.SelectorFromSet(labels.Set{LabelGroup: m.group}))
+	if err != nil {
+		logger.Error(err, "failed to list leases", "group", m.group)
+		return nil
+	}
+	now := time.Now()
+	var members []Member
+	for _, lease := range leases {
+		if isExpired(lease, now) {
+			continue
+		}
+		endpoint := lease.GetAnnotations()[AnnotationEndpoint]
+		if lease.Spec.HolderIdentity == nil || endpoint == "" {
+			continue
+		}
+		members = append(members, Member{
+			Identity: *lease.Spec.HolderIdentity,
+			Endpoint: endpoint,
+		})
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].Identity < members[j].Identity
+	})
+	return members
+}
+
+// Run renews the lease of the current replica until the context is cancelled, the lease is then released
+func (m *leaseMembership) Run(ctx context.Context, _ int) {
+	logger := logger.WithValues("group", m.group, "identity", m.identity)
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := m.renew(ctx); err != nil {
+			logger.Error(err, "failed to renew lease")
+		}
+		m.cleanup(ctx)
+	}, m.duration/3)
+	// use a fresh context, the one we got is already cancelled
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := m.client.Delete(ctx, m.leaseName(), metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		logger.Error(err, "failed to release lease")
+	}
+}
+
+func (m *leaseMembership) leaseName() string {
+	return "kyverno-" + m.group + "-" + m.identity
+}
+
+func (m *leaseMembership) renew(ctx context.Context) error {
+	now := metav1.NewMicroTime(time.Now())
+	lease, err := m.client.Get(ctx, m.leaseName(), metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: m.leaseName(),
+			},
+		}
+		m.update(lease, now)
+		_, err := m.client.Create(ctx, lease, metav1.CreateOptions{})
+		return err
+	}
+	m.update(lease, now)
+	_, err = m.client.Update(ctx, lease, metav1.UpdateOptions{})
+	return err
+}
+
+// cleanup deletes the leases of the group left behind by members that did not release them,
+// a lease is only deleted once it has been expired for a whole lease duration
+func (m *leaseMembership) cleanup(ctx context.Context) {
+	leases, err := m.lister.List(labels.SelectorFromSet(labels.Set{LabelGroup: m.group}))
+	if err != nil {
+		logger.Error(err, "failed to list leases", "group", m.group)
+		return
+	}
+	threshold := time.Now().Add(-m.duration)
+	for _, lease := range leases {
+		if lease.GetName() == m.leaseName() || !isExpired(lease, threshold) {
+			continue
+		}
+		// the precondition protects leases renewed since they were cached
+		options := metav1.DeleteOptions{
+			Preconditions: &metav1.Preconditions{ResourceVersion: ptr.To(lease.GetResourceVersion())},
+		}
+		if err := m.client.Delete(ctx, lease.GetName(), options); err != nil && !apierrors.IsNotFound(err) && !apierrors.IsConflict(err) {
+			logger.Error(err, "failed to delete expired lease", "group", m.group, "lease", lease.GetName())
+		}
+	}
+}
+
+func (m *leaseMembership) update(lease *coordinationv1.Lease, now metav1.MicroTime) {
+	if lease.Labels == nil {
+		lease.Labels = map[string]string{}
+	}
+	if lease.Annotations == nil {
+		lease.Annotations = map[string]string{}
+	}
+	lease.Labels[LabelGroup] = m.group
+	lease.Annotations[AnnotationEndpoint] = m.endpoint
+	lease.Spec.HolderIdentity = ptr.To(m.identity)
+	lease.Spec.LeaseDurationSeconds = ptr.To(int32(m.duration.Seconds()))
+	if lease.Spec.AcquireTime == nil {
+		lease.Spec.AcquireTime = &now
+	}
+	lease.Spec.RenewTime = &now
+}
+
+func isExpired(lease *coordinationv1.Lease, now time.Time) bool {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return true
+	}
+	return lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second).Before(now)
+}
@@ -0,0 +1,63 @@
+package sharding
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	coordinationv1listers "k8s.io/client-go/listers/coordination/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+)
+
+func newLease(name, identity string, renewed time.Time) *coordinationv1.Lease {
+	return &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "kyverno",
+			Labels:    map[string]string{LabelGroup: "audit"},
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       ptr.To(identity),
+			LeaseDurationSeconds: ptr.To(int32(30)),
+			RenewTime:            &metav1.MicroTime{Time: renewed},
+		},
+	}
+}
+
+func Test_cleanup(t *testing.T) {
+	now := time.Now()
+	leases := []*coordinationv1.Lease{
+		newLease("kyverno-audit-self", "self", now.Add(-time.Hour)),
+		newLease("kyverno-audit-live", "live", now),
+		newLease("kyverno-audit-recently-expired", "recently-expired", now.Add(-45*time.Second)),
+		newLease("kyverno-audit-gone", "gone", now.Add(-time.Hour)),
+	}
+	client := fake.NewSimpleClientset()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, lease := range leases {
+		_, err := client.CoordinationV1().Leases("kyverno").Create(context.TODO(), lease, metav1.CreateOptions{})
+		assert.NoError(t, err)
+		assert.NoError(t, indexer.Add(lease))
+	}
+	membership := NewLeaseMembership(
+		"audit",
+		"self",
+		"https://10.0.0.1:9443",
+		30*time.Second,
+		client.CoordinationV1().Leases("kyverno"),
+		coordinationv1listers.NewLeaseLister(indexer).Leases("kyverno"),
+	)
+	membership.cleanup(context.TODO())
+	list, err := client.CoordinationV1().Leases("kyverno").List(context.TODO(), metav1.ListOptions{})
+	assert.NoError(t, err)
+	var names []string
+	for _, lease := range list.Items {
+		names = append(names, lease.GetName())
+	}
+	assert.ElementsMatch(t, []string{"kyverno-audit-self", "kyverno-audit-live", "kyverno-audit-recently-expired"}, names)
+}
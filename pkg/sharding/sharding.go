@@ -0,0 +1,184 @@
+package sharding
+
+import (
+	"context"
+	"hash/fnv"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	coordinationv1client "k8s.io/client-go/kubernetes/typed/coordination/v1"
+	"k8s.io/utils/ptr"
+)
+
+// LabelShardGroup is the label set on the leases of the members of a shard group
+const LabelShardGroup = "kyverno.io/shard-group"
+
+// Sharder partitions the work between the replicas of a controller by namespace
+type Sharder interface {
+	// Run maintains the membership of the replica until the context is cancelled, it returns once the
+	// membership was synced a first time so that callers can start working right away
+	Run(ctx context.Context) error
+
+	// Owns returns true when the namespace is assigned to this replica, cluster wide resources are
+	// assigned like the empty namespace
+	Owns(namespace string) bool
+
+	// OnChange registers a callback invoked when the members of the group change
+	OnChange(func())
+}
+
+type sharder struct {
+	client        coordinationv1client.LeaseInterface
+	group         string
+	identity      string
+	leaseDuration time.Duration
+	logger        logr.Logger
+
+	lock      sync.RWMutex
+	members   []string
+	callbacks []func()
+}
+
+// NewLeaseSharder returns a sharder coordinating the members of a group with a lease per replica, the
+// members are the replicas whose lease was renewed within the lease duration. Namespaces are assigned
+// with rendezvous hashing so that only the namespaces of a leaving or joining member move.
+func NewLeaseSharder(logger logr.Logger, client coordinationv1client.LeaseInterface, group, identity string, leaseDuration time.Duration) Sharder {
+	return &sharder{
+		client:        client,
+		group:         group,
+		identity:      identity,
+		leaseDuration: leaseDuration,
+		logger:        logger.WithValues("group", group, "identity", identity),
+	}
+}
+
+func (s *sharder) leaseName() string {
+	return s.group + "-" + s.identity
+}
+
+func (s *sharder) Run(ctx context.Context) error {
+	if err := s.sync(ctx); err != nil {
+		return err
+	}
+	go func() {
+		ticker := time.NewTicker(s.leaseDuration / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				// release the lease so that the other members take over right away
+				if err := s.client.Delete(context.Background(), s.leaseName(), metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+					s.logger.Error(err, "failed to release shard lease")
+				}
+				return
+			case <-ticker.C:
+				if err := s.sync(ctx); err != nil {
+					s.logger.Error(err, "failed to sync shard members")
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// sync renews the lease of the replica and refreshes the members of the group
+func (s *sharder) sync(ctx context.Context) error {
+	if err := s.renew(ctx); err != nil {
+		return err
+	}
+	leases, err := s.client.List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{LabelShardGroup: s.group}).String(),
+	})
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	members := []string{s.identity}
+	for _, lease := range leases.Items {
+		if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity == s.identity || lease.Spec.RenewTime == nil {
+			continue
+		}
+		if lease.Spec.RenewTime.Add(s.leaseDuration).After(now) {
+			members = append(members, *lease.Spec.HolderIdentity)
+		}
+	}
+	slices.Sort(members)
+	s.lock.Lock()
+	changed := !slices.Equal(s.members, members)
+	s.members = members
+	callbacks := s.callbacks
+	s.lock.Unlock()
+	if changed {
+		s.logger.V(2).Info("shard members changed", "members", members)
+		for _, callback := range callbacks {
+			callback()
+		}
+	}
+	return nil
+}
+
+func (s *sharder) renew(ctx context.Context) error {
+	now := metav1.NewMicroTime(time.Now())
+	lease, err := s.client.Get(ctx, s.leaseName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = s.client.Create(ctx, &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   s.leaseName(),
+				Labels: map[string]string{LabelShardGroup: s.group},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       ptr.To(s.identity),
+				LeaseDurationSeconds: ptr.To(int32(s.leaseDuration.Seconds())),
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	lease = lease.DeepCopy()
+	lease.Spec.HolderIdentity = ptr.To(s.identity)
+	lease.Spec.RenewTime = &now
+	_, err = s.client.Update(ctx, lease, metav1.UpdateOptions{})
+	return err
+}
+
+func (s *sharder) Owns(namespace string) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return owner(s.members, namespace) == s.identity
+}
+
+func (s *sharder) OnChange(callback func()) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.callbacks = append(s.callbacks, callback)
+}
+
+// owner returns the member with the highest weight for the namespace
+func owner(members []string, namespace string) string {
+	var result string
+	var max uint64
+	for _, member := range members {
+		if weight := weight(member, namespace); result == "" || weight > max {
+			result, max = member, weight
+		}
+	}
+	return result
+}
+
+func weight(member, namespace string) uint64 {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(member))
+	_, _ = hash.Write([]byte{0})
+	_, _ = hash.Write([]byte(namespace))
+	return hash.Sum64()
+}
@@ -0,0 +1,51 @@
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_owner(t *testing.T) {
+	members := []string{"a", "b", "c"}
+	counts := map[string]int{}
+	moved := 0
+	for i := 0; i < 300; i++ {
+		namespace := fmt.Sprintf("ns-%d", i)
+		current := owner(members, namespace)
+		counts[current]++
+		// only the namespaces of the leaving member move
+		if after := owner([]string{"a", "b"}, namespace); after != current {
+			assert.Equal(t, "c", current)
+			moved++
+		}
+	}
+	assert.Len(t, counts, 3)
+	assert.Equal(t, counts["c"], moved)
+	assert.Empty(t, owner(nil, "default"))
+}
+
+func TestLeaseSharder(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	leases := fake.NewSimpleClientset().CoordinationV1().Leases("kyverno")
+	first := NewLeaseSharder(logr.Discard(), leases, "background", "first", time.Minute)
+	second := NewLeaseSharder(logr.Discard(), leases, "background", "second", time.Minute)
+	assert.NoError(t, first.Run(ctx))
+	// a single member owns everything
+	assert.True(t, first.Owns("default"))
+	changed := false
+	first.OnChange(func() { changed = true })
+	assert.NoError(t, second.Run(ctx))
+	assert.NoError(t, first.(*sharder).sync(ctx))
+	assert.True(t, changed)
+	for i := 0; i < 10; i++ {
+		namespace := fmt.Sprintf("ns-%d", i)
+		assert.NotEqual(t, first.Owns(namespace), second.Owns(namespace))
+	}
+}
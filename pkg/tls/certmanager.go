@@ -0,0 +1,148 @@
+package tls
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+)
+
+var certificateGVR = schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificates"}
+
+// CertManagerIssuer references the cert-manager issuer signing the serving certificate
+type CertManagerIssuer struct {
+	Kind string
+	Name string
+}
+
+// ParseCertManagerIssuer parses an issuer reference formatted as [<kind>/]<name>, the kind defaults to Issuer
+func ParseCertManagerIssuer(value string) (CertManagerIssuer, error) {
+	kind, name, found := strings.Cut(value, "/")
+	if !found {
+		kind, name = "Issuer", value
+	}
+	if kind != "Issuer" && kind != "ClusterIssuer" {
+		return CertManagerIssuer{}, fmt.Errorf("invalid cert-manager issuer kind %s, must be Issuer or ClusterIssuer", kind)
+	}
+	if name == "" {
+		return CertManagerIssuer{}, fmt.Errorf("invalid cert-manager issuer %s, name is required", value)
+	}
+	return CertManagerIssuer{Kind: kind, Name: name}, nil
+}
+
+func newCertificate(namespace, name, commonName string, dnsNames []string, issuer CertManagerIssuer) *unstructured.Unstructured {
+	names := make([]interface{}, 0, len(dnsNames))
+	for _, dnsName := range dnsNames {
+		names = append(names, dnsName)
+	}
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "cert-manager.io/v1",
+			"kind":       "Certificate",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": namespace,
+			},
+			"spec": map[string]interface{}{
+				"secretName": name,
+				"commonName": commonName,
+				"dnsNames":   names,
+				"duration":   TLSValidityDuration.String(),
+				"issuerRef": map[string]interface{}{
+					"group": "cert-manager.io",
+					"kind":  issuer.Kind,
+					"name":  issuer.Name,
+				},
+			},
+		},
+	}
+}
+
+// EnsureCertManagerCertificate creates or updates the cert-manager Certificate issuing the serving certificate,
+// the certificate and its secret share the same name
+func EnsureCertManagerCertificate(ctx context.Context, client dynamic.Interface, namespace, name, commonName string, dnsNames []string, issuer CertManagerIssuer) error {
+	certificates := client.Resource(certificateGVR).Namespace(namespace)
+	desired := newCertificate(namespace, name, commonName, dnsNames, issuer)
+	existing, err := certificates.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		_, err := certificates.Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	}
+	existing.Object["spec"] = desired.Object["spec"]
+	_, err = certificates.Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
+
+// CertManagerSource is a certificate source reading the secret issued by cert-manager, the CA bundle is read
+// from the ca.crt key of the same secret
+type CertManagerSource struct {
+	informer   corev1informers.SecretInformer
+	secretName string
+	namespace  string
+}
+
+// NewCertManagerSource returns a certificate source reading the secret of a cert-manager Certificate
+func NewCertManagerSource(informer corev1informers.SecretInformer, secretName, namespace string) *CertManagerSource {
+	return &CertManagerSource{
+		informer:   informer,
+		secretName: secretName,
+		namespace:  namespace,
+	}
+}
+
+func (s *CertManagerSource) secret() (*corev1.Secret, error) {
+	return s.informer.Lister().Secrets(s.namespace).Get(s.secretName)
+}
+
+func (s *CertManagerSource) KeyPair() ([]byte, []byte, error) {
+	secret, err := s.secret()
+	if err != nil {
+		return nil, nil, err
+	}
+	return secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey], nil
+}
+
+func (s *CertManagerSource) CABundle() ([]byte, error) {
+	secret, err := s.secret()
+	if err != nil {
+		return nil, err
+	}
+	ca := secret.Data[corev1.ServiceAccountRootCAKey]
+	if len(ca) == 0 {
+		return nil, fmt.Errorf("secret %s/%s has no %s, the issuer must provide the CA", s.namespace, s.secretName, corev1.ServiceAccountRootCAKey)
+	}
+	return ca, nil
+}
+
+// ValidateCert checks the serving certificate is signed by the CA bundle and not expired
+func (s *CertManagerSource) ValidateCert(context.Context) (bool, error) {
+	secret, err := s.secret()
+	if err != nil {
+		return false, err
+	}
+	certs := pemToCertificates(secret.Data[corev1.TLSCertKey])
+	if len(certs) == 0 {
+		return false, fmt.Errorf("no certificate found in secret %s/%s", s.namespace, s.secretName)
+	}
+	return validateCert(time.Now(), certs[0], append(pemToCertificates(secret.Data[corev1.ServiceAccountRootCAKey]), certs[1:]...)...), nil
+}
+
+func (s *CertManagerSource) AddEventHandler(handler func()) {
+	addSecretEventHandler(s.informer, s.namespace, s.secretName, handler)
+}
+
+// AddKeyPairEventHandler registers a func called when the secret changes, the key pair and the CA share the same secret
+func (s *CertManagerSource) AddKeyPairEventHandler(handler func()) {
+	addSecretEventHandler(s.informer, s.namespace, s.secretName, handler)
+}
@@ -0,0 +1,74 @@
+package tls
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubeinformers "k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseCertManagerIssuer(t *testing.T) {
+	issuer, err := ParseCertManagerIssuer("kyverno-issuer")
+	assert.NoError(t, err)
+	assert.Equal(t, CertManagerIssuer{Kind: "Issuer", Name: "kyverno-issuer"}, issuer)
+	issuer, err = ParseCertManagerIssuer("ClusterIssuer/ca-issuer")
+	assert.NoError(t, err)
+	assert.Equal(t, CertManagerIssuer{Kind: "ClusterIssuer", Name: "ca-issuer"}, issuer)
+	_, err = ParseCertManagerIssuer("Secret/ca-issuer")
+	assert.Error(t, err)
+	_, err = ParseCertManagerIssuer("Issuer/")
+	assert.Error(t, err)
+}
+
+func TestEnsureCertManagerCertificate(t *testing.T) {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		certificateGVR: "CertificateList",
+	})
+	dnsNames := []string{"kyverno-svc.kyverno.svc"}
+	assert.NoError(t, EnsureCertManagerCertificate(context.TODO(), client, "kyverno", "kyverno-svc.kyverno.svc.kyverno-tls-pair", "kyverno-svc", dnsNames, CertManagerIssuer{Kind: "Issuer", Name: "first"}))
+	assert.NoError(t, EnsureCertManagerCertificate(context.TODO(), client, "kyverno", "kyverno-svc.kyverno.svc.kyverno-tls-pair", "kyverno-svc", dnsNames, CertManagerIssuer{Kind: "ClusterIssuer", Name: "second"}))
+	certificate, err := client.Resource(certificateGVR).Namespace("kyverno").Get(context.TODO(), "kyverno-svc.kyverno.svc.kyverno-tls-pair", metav1.GetOptions{})
+	assert.NoError(t, err)
+	secretName, _, _ := unstructured.NestedString(certificate.Object, "spec", "secretName")
+	assert.Equal(t, "kyverno-svc.kyverno.svc.kyverno-tls-pair", secretName)
+	issuer, _, _ := unstructured.NestedStringMap(certificate.Object, "spec", "issuerRef")
+	assert.Equal(t, map[string]string{"group": "cert-manager.io", "kind": "ClusterIssuer", "name": "second"}, issuer)
+}
+
+func TestCertManagerSource(t *testing.T) {
+	caKey, caCert, err := generateCA(nil, RSA2048, time.Hour)
+	assert.NoError(t, err)
+	tlsKey, tlsCert, err := generateTLS("", caCert, caKey, RSA2048, time.Hour, "kyverno-svc", []string{"kyverno-svc.kyverno.svc"})
+	assert.NoError(t, err)
+	informer := kubeinformers.NewSharedInformerFactory(kubefake.NewSimpleClientset(), 0).Core().V1().Secrets()
+	source := NewCertManagerSource(informer, "tls", "kyverno")
+	_, _, err = source.KeyPair()
+	assert.Error(t, err)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "tls", Namespace: "kyverno"},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certificateToPem(tlsCert),
+			corev1.TLSPrivateKeyKey: privateKeyToPem(tlsKey),
+		},
+	}
+	assert.NoError(t, informer.Informer().GetIndexer().Add(secret))
+	// the CA is required to configure webhooks
+	_, err = source.CABundle()
+	assert.Error(t, err)
+	secret.Data[corev1.ServiceAccountRootCAKey] = certificateToPem(caCert)
+	ca, err := source.CABundle()
+	assert.NoError(t, err)
+	assert.Equal(t, certificateToPem(caCert), ca)
+	valid, err := source.ValidateCert(context.TODO())
+	assert.NoError(t, err)
+	assert.True(t, valid)
+}
@@ -1,6 +1,9 @@
 package tls
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -12,13 +15,69 @@ import (
 	"time"
 )
 
+// KeyAlgorithm is the algorithm and size of the generated private keys
+type KeyAlgorithm string
+
+const (
+	RSA2048   KeyAlgorithm = "RSA2048"
+	RSA4096   KeyAlgorithm = "RSA4096"
+	ECDSAP256 KeyAlgorithm = "ECDSAP256"
+	ECDSAP384 KeyAlgorithm = "ECDSAP384"
+)
+
+// DefaultKeyAlgorithm is the algorithm used when none is configured
+const DefaultKeyAlgorithm = RSA2048
+
+// ParseKeyAlgorithm returns the key algorithm with the given name
+func ParseKeyAlgorithm(name string) (KeyAlgorithm, error) {
+	switch algorithm := KeyAlgorithm(name); algorithm {
+	case RSA2048, RSA4096, ECDSAP256, ECDSAP384:
+		return algorithm, nil
+	default:
+		return "", fmt.Errorf("unsupported key algorithm %s, must be one of %s, %s, %s, %s", name, RSA2048, RSA4096, ECDSAP256, ECDSAP384)
+	}
+}
+
+func generateKey(algorithm KeyAlgorithm) (crypto.Signer, error) {
+	switch algorithm {
+	case RSA4096:
+		return rsa.GenerateKey(rand.Reader, 4096)
+	case ECDSAP256:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case ECDSAP384:
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	default:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	}
+}
+
+// matchesKeyAlgorithm returns true if the key was generated with the given algorithm
+func matchesKeyAlgorithm(key crypto.Signer, algorithm KeyAlgorithm) bool {
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		return (algorithm == RSA2048 && key.N.BitLen() == 2048) || (algorithm == RSA4096 && key.N.BitLen() == 4096)
+	case *ecdsa.PrivateKey:
+		return (algorithm == ECDSAP256 && key.Curve == elliptic.P256()) || (algorithm == ECDSAP384 && key.Curve == elliptic.P384())
+	default:
+		return false
+	}
+}
+
+// keyUsage returns the key usage of a certificate for the given key, key encipherment only applies to RSA keys
+func keyUsage(key crypto.Signer) x509.KeyUsage {
+	if _, ok := key.(*rsa.PrivateKey); ok {
+		return x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+	}
+	return x509.KeyUsageDigitalSignature
+}
+
 // generateCA creates the self-signed CA cert and private key
 // it will be used to sign the webhook server certificate
-func generateCA(key *rsa.PrivateKey, certValidityDuration time.Duration) (*rsa.PrivateKey, *x509.Certificate, error) {
+func generateCA(key crypto.Signer, algorithm KeyAlgorithm, certValidityDuration time.Duration) (crypto.Signer, *x509.Certificate, error) {
 	now := time.Now()
 	begin, end := now.Add(-1*time.Hour), now.Add(certValidityDuration)
 	if key == nil {
-		newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		newKey, err := generateKey(algorithm)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -31,7 +90,7 @@ func generateCA(key *rsa.PrivateKey, certValidityDuration time.Duration) (*rsa.P
 		},
 		NotBefore:             begin,
 		NotAfter:              end,
-		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageCertSign,
+		KeyUsage:              keyUsage(key) | x509.KeyUsageCertSign,
 		BasicConstraintsValid: true,
 		IsCA:                  true,
 	}
@@ -48,7 +107,7 @@ func generateCA(key *rsa.PrivateKey, certValidityDuration time.Duration) (*rsa.P
 
 // generateTLS takes the results of GenerateCACert and uses it to create the
 // PEM-encoded public certificate and private key, respectively
-func generateTLS(server string, caCert *x509.Certificate, caKey *rsa.PrivateKey, certValidityDuration time.Duration, commonName string, dnsNames []string) (*rsa.PrivateKey, *x509.Certificate, error) {
+func generateTLS(server string, caCert *x509.Certificate, caKey crypto.Signer, algorithm KeyAlgorithm, certValidityDuration time.Duration, commonName string, dnsNames []string) (crypto.Signer, *x509.Certificate, error) {
 	now := time.Now()
 	begin, end := now.Add(-1*time.Hour), now.Add(certValidityDuration)
 	var ips []net.IP
@@ -68,6 +127,10 @@ func generateTLS(server string, caCert *x509.Certificate, caKey *rsa.PrivateKey,
 			ips = append(ips, ip)
 		}
 	}
+	key, err := generateKey(algorithm)
+	if err != nil {
+		return nil, nil, err
+	}
 	templ := &x509.Certificate{
 		SerialNumber: big.NewInt(1),
 		Subject: pkix.Name{
@@ -77,14 +140,10 @@ func generateTLS(server string, caCert *x509.Certificate, caKey *rsa.PrivateKey,
 		IPAddresses:           ips,
 		NotBefore:             begin,
 		NotAfter:              end,
-		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		KeyUsage:              keyUsage(key),
 		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
 		BasicConstraintsValid: true,
 	}
-	key, err := rsa.GenerateKey(rand.Reader, 2048)
-	if err != nil {
-		return nil, nil, err
-	}
 	der, err := x509.CreateCertificate(rand.Reader, templ, caCert, key.Public(), caKey)
 	if err != nil {
 		return nil, nil, err
@@ -0,0 +1,75 @@
+package tls
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    uint16
+		wantErr bool
+	}{{
+		name: "",
+		want: tls.VersionTLS12,
+	}, {
+		name: "VersionTLS12",
+		want: tls.VersionTLS12,
+	}, {
+		name: "VersionTLS13",
+		want: tls.VersionTLS13,
+	}, {
+		name:    "VersionTLS10",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseVersion(tt.name)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseCipherSuites(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   string
+		want    []uint16
+		wantErr bool
+	}{{
+		name:  "empty",
+		names: "",
+		want:  DefaultCipherSuites,
+	}, {
+		name:  "valid",
+		names: "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+		want:  []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384},
+	}, {
+		name:    "insecure",
+		names:   "TLS_RSA_WITH_RC4_128_SHA",
+		wantErr: true,
+	}, {
+		name:    "unknown",
+		names:   "foo",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCipherSuites(tt.names)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
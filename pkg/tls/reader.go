@@ -1,7 +1,9 @@
 package tls
 
 import (
+	"crypto/x509"
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
@@ -24,5 +26,46 @@ func ReadRootCASecret(name, namespace string, client corev1listers.SecretNamespa
 	if len(result) == 0 {
 		return nil, fmt.Errorf("%s in secret %s/%s", errorsNotFound, namespace, stlsca.Name)
 	}
+	// externally provided CAs can store the root in "ca.crt" and the intermediates in "tls.crt",
+	// the bundle contains the full chain
+	if ca := stlsca.Data[corev1.ServiceAccountRootCAKey]; len(ca) != 0 {
+		certs := pemToCertificates(result)
+		for _, cert := range pemToCertificates(ca) {
+			if !containsCertificate(certs, cert) {
+				certs = append(certs, cert)
+			}
+		}
+		result = certificateToPem(certs...)
+	}
 	return result, nil
 }
+
+func secretCertificates(secret *corev1.Secret) []*x509.Certificate {
+	certs := secret.Data[corev1.TLSCertKey]
+	if len(certs) == 0 {
+		certs = secret.Data[rootCAKey]
+	}
+	return pemToCertificates(certs)
+}
+
+// ReadCAExpiration returns the expiration of a CA secret, the latest one as previous CAs are kept until they expire
+func ReadCAExpiration(secret *corev1.Secret) (time.Time, bool) {
+	var expiration time.Time
+	for _, cert := range secretCertificates(secret) {
+		if cert.NotAfter.After(expiration) {
+			expiration = cert.NotAfter
+		}
+	}
+	return expiration, !expiration.IsZero()
+}
+
+// ReadTLSExpiration returns the expiration of a TLS secret, the earliest one as the chain is invalid when any certificate expires
+func ReadTLSExpiration(secret *corev1.Secret) (time.Time, bool) {
+	var expiration time.Time
+	for _, cert := range secretCertificates(secret) {
+		if expiration.IsZero() || cert.NotAfter.Before(expiration) {
+			expiration = cert.NotAfter
+		}
+	}
+	return expiration, !expiration.IsZero()
+}
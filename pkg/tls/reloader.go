@@ -0,0 +1,211 @@
+package tls
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+// SelfTest checks the server presents the given certificate and that it's trusted by the CA bundle
+type SelfTest func(ctx context.Context, caBundle []byte, cert []byte) error
+
+var reloadBackoff = wait.Backoff{
+	Duration: time.Second,
+	Factor:   2,
+	Jitter:   0.1,
+	Steps:    6,
+	Cap:      time.Minute,
+}
+
+// KeyPairReloader serves a copy of the key pair of a certificate source, changes are picked up after a random
+// delay so that replicas don't switch certificates at the same time while the leader updates the webhooks CA
+// bundle, the server is reported ready once a self test confirms it presents the new certificate
+type KeyPairReloader struct {
+	source    CertificateSource
+	validator CertValidator
+	jitter    time.Duration
+	selfTest  SelfTest
+	trigger   chan struct{}
+	lock      sync.RWMutex
+	cert      []byte
+	key       []byte
+	tested    bool
+}
+
+// NewKeyPairReloader returns a reloader for the given source, the validator checks the certificates validity and
+// the self test is skipped when nil
+func NewKeyPairReloader(source CertificateSource, validator CertValidator, jitter time.Duration, selfTest SelfTest) *KeyPairReloader {
+	r := &KeyPairReloader{
+		source:    source,
+		validator: validator,
+		jitter:    jitter,
+		selfTest:  selfTest,
+		trigger:   make(chan struct{}, 1),
+	}
+	source.AddKeyPairEventHandler(r.notify)
+	return r
+}
+
+func (r *KeyPairReloader) notify() {
+	select {
+	case r.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// KeyPair returns the loaded key pair, the source is read directly until a key pair is loaded
+func (r *KeyPairReloader) KeyPair() ([]byte, []byte, error) {
+	r.lock.RLock()
+	cert, key := r.cert, r.key
+	r.lock.RUnlock()
+	if len(cert) != 0 {
+		return cert, key, nil
+	}
+	return r.source.KeyPair()
+}
+
+// ValidateCert checks the self test passed for the loaded key pair and the certificates are valid
+func (r *KeyPairReloader) ValidateCert(ctx context.Context) (bool, error) {
+	r.lock.RLock()
+	tested := r.tested
+	r.lock.RUnlock()
+	if !tested {
+		return false, nil
+	}
+	return r.validator.ValidateCert(ctx)
+}
+
+// Run reloads the key pair when the source changes until the context is cancelled, the signature matches controllers.Controller
+func (r *KeyPairReloader) Run(ctx context.Context, _ int) {
+	logger := logging.WithName("certificates-reloader")
+	// the first key pair isn't delayed, the server has nothing to serve yet
+	r.reloadWithBackoff(ctx, logger)
+	// retry periodically while the self test fails
+	ticker := time.NewTicker(FileSourcePollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if r.isTested() {
+				continue
+			}
+		case <-r.trigger:
+			if r.jitter > 0 {
+				delay := time.Duration(rand.Int63n(int64(r.jitter))) //nolint:gosec
+				logger.V(2).Info("key pair changed, reloading", "delay", delay)
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(delay):
+				}
+			}
+		}
+		r.reloadWithBackoff(ctx, logger)
+	}
+}
+
+func (r *KeyPairReloader) isTested() bool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.tested
+}
+
+func (r *KeyPairReloader) reloadWithBackoff(ctx context.Context, logger logr.Logger) {
+	if err := retry.OnError(reloadBackoff, func(error) bool { return ctx.Err() == nil }, func() error {
+		return r.reload(ctx)
+	}); err != nil {
+		logger.Error(err, "failed to reload key pair")
+	}
+}
+
+// reload loads the key pair from the source when it changed and runs the self test until it passes
+func (r *KeyPairReloader) reload(ctx context.Context) error {
+	cert, key, err := r.source.KeyPair()
+	if err != nil {
+		return err
+	}
+	if _, err := tls.X509KeyPair(cert, key); err != nil {
+		return fmt.Errorf("invalid key pair (%w)", err)
+	}
+	r.lock.Lock()
+	if !bytes.Equal(cert, r.cert) || !bytes.Equal(key, r.key) {
+		r.cert, r.key, r.tested = cert, key, false
+	}
+	tested := r.tested
+	r.lock.Unlock()
+	if tested {
+		return nil
+	}
+	if r.selfTest != nil {
+		caBundle, err := r.source.CABundle()
+		if err != nil {
+			return err
+		}
+		if err := r.selfTest(ctx, caBundle, cert); err != nil {
+			return fmt.Errorf("self test failed (%w)", err)
+		}
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	// the key pair could have been replaced during the self test
+	if bytes.Equal(cert, r.cert) {
+		r.tested = true
+	}
+	return nil
+}
+
+// NewServerSelfTest returns a self test calling the given url of the server, it verifies the server presents the
+// expected certificate for the server name and that the certificate is trusted by the CA bundle
+func NewServerSelfTest(url string, serverName string, timeout time.Duration) SelfTest {
+	return func(ctx context.Context, caBundle []byte, cert []byte) error {
+		expected := pemToCertificates(cert)
+		if len(expected) == 0 {
+			return errors.New("no certificate found in key pair")
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(caBundle) {
+			return errors.New("no certificate found in CA bundle")
+		}
+		client := &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					RootCAs:    roots,
+					ServerName: serverName,
+					MinVersion: tls.VersionTLS12,
+				},
+				DisableKeepAlives: true,
+			},
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.TLS == nil || len(resp.TLS.PeerCertificates) == 0 || !resp.TLS.PeerCertificates[0].Equal(expected[0]) {
+			return errors.New("server doesn't present the expected certificate")
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,92 @@
+package tls
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyPairReloader(t *testing.T) {
+	dir := t.TempDir()
+	writeKeyPair(t, dir)
+	source, err := NewFileSource(filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"), filepath.Join(dir, "ca.crt"))
+	assert.NoError(t, err)
+	var reloader *KeyPairReloader
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			cert, key, err := reloader.KeyPair()
+			if err != nil {
+				return nil, err
+			}
+			pair, err := tls.X509KeyPair(cert, key)
+			return &pair, err
+		},
+	}
+	server.StartTLS()
+	defer server.Close()
+	reloader = NewKeyPairReloader(source, source, time.Hour, NewServerSelfTest(server.URL, "kyverno-svc.kyverno.svc", time.Second))
+	// not ready until the self test passed
+	valid, err := reloader.ValidateCert(context.TODO())
+	assert.NoError(t, err)
+	assert.False(t, valid)
+	assert.NoError(t, reloader.reload(context.TODO()))
+	valid, err = reloader.ValidateCert(context.TODO())
+	assert.NoError(t, err)
+	assert.True(t, valid)
+	// rotated files notify the reloader but the loaded key pair is served until it reloads
+	served, _, err := reloader.KeyPair()
+	assert.NoError(t, err)
+	writeKeyPair(t, dir)
+	_, err = source.reload()
+	assert.NoError(t, err)
+	assert.Len(t, reloader.trigger, 1)
+	cert, _, err := reloader.KeyPair()
+	assert.NoError(t, err)
+	assert.Equal(t, served, cert)
+	assert.NoError(t, reloader.reload(context.TODO()))
+	cert, _, err = reloader.KeyPair()
+	assert.NoError(t, err)
+	assert.NotEqual(t, served, cert)
+	assert.True(t, reloader.isTested())
+}
+
+func TestServerSelfTest(t *testing.T) {
+	dir := t.TempDir()
+	writeKeyPair(t, dir)
+	source, err := NewFileSource(filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"), filepath.Join(dir, "ca.crt"))
+	assert.NoError(t, err)
+	cert, key, err := source.KeyPair()
+	assert.NoError(t, err)
+	pair, err := tls.X509KeyPair(cert, key)
+	assert.NoError(t, err)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{pair}}
+	server.StartTLS()
+	defer server.Close()
+	ca, err := source.CABundle()
+	assert.NoError(t, err)
+	selfTest := NewServerSelfTest(server.URL, "kyverno-svc.kyverno.svc", time.Second)
+	assert.NoError(t, selfTest(context.TODO(), ca, cert))
+	// the server doesn't present the rotated certificate yet
+	writeKeyPair(t, dir)
+	_, err = source.reload()
+	assert.NoError(t, err)
+	rotated, _, err := source.KeyPair()
+	assert.NoError(t, err)
+	assert.Error(t, selfTest(context.TODO(), ca, rotated))
+	// the server certificate isn't trusted by another CA
+	otherCA, err := source.CABundle()
+	assert.NoError(t, err)
+	assert.Error(t, selfTest(context.TODO(), otherCA, cert))
+}
@@ -2,7 +2,7 @@ package tls
 
 import (
 	"context"
-	"crypto/rsa"
+	"crypto"
 	"crypto/x509"
 	"fmt"
 	"time"
@@ -51,6 +51,7 @@ type certRenewer struct {
 	caValidityDuration  time.Duration
 	tlsValidityDuration time.Duration
 	renewBefore         time.Duration
+	keyAlgorithm        KeyAlgorithm
 
 	// server is an IP address or domain name where Kyverno controller runs. Only required if out-of-cluster.
 	server     string
@@ -68,6 +69,7 @@ func NewCertRenewer(
 	caValidityDuration,
 	tlsValidityDuration,
 	renewBefore time.Duration,
+	keyAlgorithm KeyAlgorithm,
 	server string,
 	commonName string,
 	dnsNames []string,
@@ -81,6 +83,7 @@ func NewCertRenewer(
 		caValidityDuration:  caValidityDuration,
 		tlsValidityDuration: tlsValidityDuration,
 		renewBefore:         renewBefore,
+		keyAlgorithm:        keyAlgorithm,
 		server:              server,
 		commonName:          commonName,
 		dnsNames:            dnsNames,
@@ -107,7 +110,11 @@ func (c *certRenewer) RenewCA(ctx context.Context) error {
 	if secret != nil && secret.Type != corev1.SecretTypeTLS {
 		return c.client.Delete(ctx, secret.Name, metav1.DeleteOptions{})
 	}
-	caKey, caCert, err := generateCA(key, c.caValidityDuration)
+	// the key is kept across renewals unless the configured algorithm changed
+	if key != nil && !matchesKeyAlgorithm(key, c.keyAlgorithm) {
+		key = nil
+	}
+	caKey, caCert, err := generateCA(key, c.keyAlgorithm, c.caValidityDuration)
 	if err != nil {
 		return fmt.Errorf("failed to generate CA (%w)", err)
 	}
@@ -134,15 +141,15 @@ func (c *certRenewer) RenewTLS(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to read CA (%w)", err)
 	}
-	secret, _, cert, err := c.decodeTLSSecret(ctx)
+	secret, _, certs, err := c.decodeTLSSecret(ctx)
 	if err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("failed to read TLS (%w)", err)
 	}
 	now := time.Now()
-	if cert != nil {
+	if len(certs) != 0 {
 		valid, err := c.ValidateCert(ctx)
 		if err != nil || !valid {
-		} else if !allCertificatesExpired(now.Add(c.renewBefore), cert) {
+		} else if !allCertificatesExpired(now.Add(c.renewBefore), certs[0]) {
 			return nil
 		}
 	}
@@ -153,11 +160,16 @@ func (c *certRenewer) RenewTLS(ctx context.Context) error {
 	if secret != nil && secret.Type != corev1.SecretTypeTLS {
 		return c.client.Delete(ctx, secret.Name, metav1.DeleteOptions{})
 	}
-	tlsKey, tlsCert, err := generateTLS(c.server, caCerts[len(caCerts)-1], caKey, c.tlsValidityDuration, c.commonName, c.dnsNames)
+	// the CA can be provided with its intermediates, the serving certificate is issued by the one matching the key
+	signer := signingCertificate(caKey, caCerts...)
+	if signer == nil {
+		return fmt.Errorf("no CA certificate matches the CA private key")
+	}
+	tlsKey, tlsCert, err := generateTLS(c.server, signer, caKey, c.keyAlgorithm, c.tlsValidityDuration, c.commonName, c.dnsNames)
 	if err != nil {
 		return fmt.Errorf("failed to generate TLS (%w)", err)
 	}
-	if err := c.writeTLSSecret(ctx, tlsKey, tlsCert); err != nil {
+	if err := c.writeTLSSecret(ctx, tlsKey, append([]*x509.Certificate{tlsCert}, intermediateChain(signer, caCerts...)...)...); err != nil {
 		return fmt.Errorf("failed to write TLS (%w)", err)
 	}
 	return nil
@@ -169,11 +181,14 @@ func (c *certRenewer) ValidateCert(ctx context.Context) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	_, _, cert, err := c.decodeTLSSecret(ctx)
+	_, _, certs, err := c.decodeTLSSecret(ctx)
 	if err != nil {
 		return false, err
 	}
-	return validateCert(time.Now(), cert, caCerts...), nil
+	if len(certs) == 0 {
+		return false, nil
+	}
+	return validateCert(time.Now(), certs[0], append(caCerts, certs[1:]...)...), nil
 }
 
 func (c *certRenewer) getSecret(ctx context.Context, name string) (*corev1.Secret, error) {
@@ -184,7 +199,7 @@ func (c *certRenewer) getSecret(ctx context.Context, name string) (*corev1.Secre
 	}
 }
 
-func (c *certRenewer) decodeSecret(ctx context.Context, name string) (*corev1.Secret, *rsa.PrivateKey, []*x509.Certificate, error) {
+func (c *certRenewer) decodeSecret(ctx context.Context, name string) (*corev1.Secret, crypto.Signer, []*x509.Certificate, error) {
 	secret, err := c.getSecret(ctx, name)
 	if err != nil {
 		return nil, nil, nil, err
@@ -197,7 +212,7 @@ func (c *certRenewer) decodeSecret(ctx context.Context, name string) (*corev1.Se
 			certBytes = secret.Data[rootCAKey]
 		}
 	}
-	var key *rsa.PrivateKey
+	var key crypto.Signer
 	if keyBytes != nil {
 		usedkey, err := pemToPrivateKey(keyBytes)
 		if err != nil {
@@ -208,25 +223,26 @@ func (c *certRenewer) decodeSecret(ctx context.Context, name string) (*corev1.Se
 	return secret, key, pemToCertificates(certBytes), nil
 }
 
-func (c *certRenewer) decodeCASecret(ctx context.Context) (*corev1.Secret, *rsa.PrivateKey, []*x509.Certificate, error) {
-	return c.decodeSecret(ctx, c.caSecret)
-}
-
-func (c *certRenewer) decodeTLSSecret(ctx context.Context) (*corev1.Secret, *rsa.PrivateKey, *x509.Certificate, error) {
-	secret, key, certs, err := c.decodeSecret(ctx, c.pairSecret)
+func (c *certRenewer) decodeCASecret(ctx context.Context) (*corev1.Secret, crypto.Signer, []*x509.Certificate, error) {
+	secret, key, certs, err := c.decodeSecret(ctx, c.caSecret)
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	if len(certs) == 0 {
-		return secret, key, nil, nil
-	} else if len(certs) == 1 {
-		return secret, key, certs[0], nil
-	} else {
-		return nil, nil, nil, err
+	// externally provided CAs can store the root separately from the intermediates
+	for _, cert := range pemToCertificates(secret.Data[corev1.ServiceAccountRootCAKey]) {
+		if !containsCertificate(certs, cert) {
+			certs = append(certs, cert)
+		}
 	}
+	return secret, key, certs, nil
+}
+
+// decodeTLSSecret returns the serving certificate followed by the intermediates of its chain
+func (c *certRenewer) decodeTLSSecret(ctx context.Context) (*corev1.Secret, crypto.Signer, []*x509.Certificate, error) {
+	return c.decodeSecret(ctx, c.pairSecret)
 }
 
-func (c *certRenewer) writeSecret(ctx context.Context, name string, key *rsa.PrivateKey, certs ...*x509.Certificate) error {
+func (c *certRenewer) writeSecret(ctx context.Context, name string, key crypto.Signer, certs ...*x509.Certificate) error {
 	secret, err := c.getSecret(ctx, name)
 	if err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("failed to get CA secret (%w)", err)
@@ -261,11 +277,12 @@ func (c *certRenewer) writeSecret(ctx context.Context, name string, key *rsa.Pri
 }
 
 // writeCASecret stores the CA cert in secret
-func (c *certRenewer) writeCASecret(ctx context.Context, key *rsa.PrivateKey, certs ...*x509.Certificate) error {
+func (c *certRenewer) writeCASecret(ctx context.Context, key crypto.Signer, certs ...*x509.Certificate) error {
 	return c.writeSecret(ctx, c.caSecret, key, certs...)
 }
 
-// writeTLSSecret Writes the pair of TLS certificate and key to the specified secret.
-func (c *certRenewer) writeTLSSecret(ctx context.Context, key *rsa.PrivateKey, cert *x509.Certificate) error {
-	return c.writeSecret(ctx, c.pairSecret, key, cert)
+// writeTLSSecret Writes the pair of TLS certificate and key to the specified secret,
+// the certificate is followed by the intermediates of its chain.
+func (c *certRenewer) writeTLSSecret(ctx context.Context, key crypto.Signer, certs ...*x509.Certificate) error {
+	return c.writeSecret(ctx, c.pairSecret, key, certs...)
 }
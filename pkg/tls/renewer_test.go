@@ -0,0 +1,117 @@
+package tls
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func generateIntermediate(t *testing.T, parent *x509.Certificate, parentKey crypto.Signer) (*rsa.PrivateKey, *x509.Certificate) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	templ := &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: "intermediate"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, templ, parent, key.Public(), parentKey)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return key, cert
+}
+
+func TestRenewTLSWithExternalCA(t *testing.T) {
+	rootKey, rootCert, err := generateCA(nil, RSA2048, time.Hour)
+	assert.NoError(t, err)
+	intermediateKey, intermediateCert := generateIntermediate(t, rootCert, rootKey)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(intermediateKey)
+	assert.NoError(t, err)
+	secrets := kubefake.NewSimpleClientset().CoreV1().Secrets("kyverno")
+	// the root is stored separately from the issuing intermediate, the secret isn't managed by kyverno
+	_, err = secrets.Create(context.TODO(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ca", Namespace: "kyverno"},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:              certificateToPem(intermediateCert),
+			corev1.TLSPrivateKeyKey:        pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}),
+			corev1.ServiceAccountRootCAKey: certificateToPem(rootCert),
+		},
+	}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	renewer := NewCertRenewer(secrets, CertRenewalInterval, CAValidityDuration, TLSValidityDuration, time.Minute, RSA2048, "", "kyverno-svc", []string{"kyverno-svc.kyverno.svc"}, "kyverno", "ca", "tls")
+	assert.NoError(t, renewer.RenewCA(context.TODO()))
+	assert.NoError(t, renewer.RenewTLS(context.TODO()))
+	secret, err := secrets.Get(context.TODO(), "tls", metav1.GetOptions{})
+	assert.NoError(t, err)
+	// the serving certificate is issued by the intermediate and followed by it
+	certs := pemToCertificates(secret.Data[corev1.TLSCertKey])
+	assert.Len(t, certs, 2)
+	assert.NoError(t, certs[0].CheckSignatureFrom(intermediateCert))
+	assert.True(t, certs[1].Equal(intermediateCert))
+	valid, err := renewer.ValidateCert(context.TODO())
+	assert.NoError(t, err)
+	assert.True(t, valid)
+	// clients trusting the root alone verify the served chain
+	roots, intermediates := x509.NewCertPool(), x509.NewCertPool()
+	roots.AddCert(rootCert)
+	intermediates.AddCert(certs[1])
+	_, err = certs[0].Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates})
+	assert.NoError(t, err)
+}
+
+func TestIntermediateChain(t *testing.T) {
+	rootKey, rootCert, err := generateCA(nil, RSA2048, time.Hour)
+	assert.NoError(t, err)
+	intermediateKey, intermediateCert := generateIntermediate(t, rootCert, rootKey)
+	assert.Empty(t, intermediateChain(rootCert, rootCert, intermediateCert))
+	chain := intermediateChain(intermediateCert, rootCert, intermediateCert)
+	assert.Len(t, chain, 1)
+	assert.True(t, chain[0].Equal(intermediateCert))
+	assert.True(t, signingCertificate(intermediateKey, rootCert, intermediateCert).Equal(intermediateCert))
+	assert.True(t, signingCertificate(rootKey, rootCert, intermediateCert).Equal(rootCert))
+	assert.Nil(t, signingCertificate(nil, rootCert))
+}
+
+func TestRenewWithKeyAlgorithm(t *testing.T) {
+	secrets := kubefake.NewSimpleClientset().CoreV1().Secrets("kyverno")
+	renewer := NewCertRenewer(secrets, CertRenewalInterval, CAValidityDuration, TLSValidityDuration, time.Minute, ECDSAP384, "", "kyverno-svc", []string{"kyverno-svc.kyverno.svc"}, "kyverno", "ca", "tls")
+	assert.NoError(t, renewer.RenewCA(context.TODO()))
+	assert.NoError(t, renewer.RenewTLS(context.TODO()))
+	for _, name := range []string{"ca", "tls"} {
+		_, key, _, err := renewer.decodeSecret(context.TODO(), name)
+		assert.NoError(t, err)
+		ecKey, ok := key.(*ecdsa.PrivateKey)
+		assert.True(t, ok)
+		assert.Equal(t, elliptic.P384(), ecKey.Curve)
+	}
+	valid, err := renewer.ValidateCert(context.TODO())
+	assert.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestParseKeyAlgorithm(t *testing.T) {
+	algorithm, err := ParseKeyAlgorithm("ECDSAP256")
+	assert.NoError(t, err)
+	assert.Equal(t, ECDSAP256, algorithm)
+	_, err = ParseKeyAlgorithm("DSA1024")
+	assert.Error(t, err)
+}
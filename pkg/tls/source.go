@@ -0,0 +1,227 @@
+package tls
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/kyverno/kyverno/pkg/logging"
+	corev1 "k8s.io/api/core/v1"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// FileSourcePollInterval is the interval at which mounted certificate files are checked for changes
+const FileSourcePollInterval = 10 * time.Second
+
+// CertificateSource provides the serving key pair and the CA bundle of a webhook server
+type CertificateSource interface {
+	// KeyPair returns the PEM encoded serving certificate and private key
+	KeyPair() ([]byte, []byte, error)
+	// CABundle returns the PEM encoded CA bundle to configure in webhook configurations
+	CABundle() ([]byte, error)
+	// AddEventHandler registers a func called when the CA bundle changes
+	AddEventHandler(func())
+	// AddKeyPairEventHandler registers a func called when the serving key pair changes
+	AddKeyPairEventHandler(func())
+}
+
+// addSecretEventHandler calls the handler when the secret with the given name changes
+func addSecretEventHandler(informer corev1informers.SecretInformer, namespace, name string, handler func()) {
+	matches := func(obj interface{}) bool {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		secret, ok := obj.(*corev1.Secret)
+		return ok && secret.GetNamespace() == namespace && secret.GetName() == name
+	}
+	if _, err := informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if matches(obj) {
+				handler()
+			}
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			if matches(obj) {
+				handler()
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if matches(obj) {
+				handler()
+			}
+		},
+	}); err != nil {
+		logging.Error(err, "failed to register event handlers")
+	}
+}
+
+type secretSource struct {
+	caInformer    corev1informers.SecretInformer
+	tlsInformer   corev1informers.SecretInformer
+	caSecretName  string
+	tlsSecretName string
+	namespace     string
+}
+
+// NewSecretSource returns a certificate source reading the secrets managed by the certmanager controller
+func NewSecretSource(
+	caInformer corev1informers.SecretInformer,
+	tlsInformer corev1informers.SecretInformer,
+	caSecretName string,
+	tlsSecretName string,
+	namespace string,
+) CertificateSource {
+	return &secretSource{
+		caInformer:    caInformer,
+		tlsInformer:   tlsInformer,
+		caSecretName:  caSecretName,
+		tlsSecretName: tlsSecretName,
+		namespace:     namespace,
+	}
+}
+
+func (s *secretSource) KeyPair() ([]byte, []byte, error) {
+	secret, err := s.tlsInformer.Lister().Secrets(s.namespace).Get(s.tlsSecretName)
+	if err != nil {
+		return nil, nil, err
+	}
+	return secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey], nil
+}
+
+func (s *secretSource) CABundle() ([]byte, error) {
+	return ReadRootCASecret(s.caSecretName, s.namespace, s.caInformer.Lister().Secrets(s.namespace))
+}
+
+func (s *secretSource) AddEventHandler(handler func()) {
+	addSecretEventHandler(s.caInformer, s.namespace, s.caSecretName, handler)
+}
+
+func (s *secretSource) AddKeyPairEventHandler(handler func()) {
+	addSecretEventHandler(s.tlsInformer, s.namespace, s.tlsSecretName, handler)
+}
+
+// FileSource is a certificate source reading mounted files, typically a secret issued by cert-manager
+// or a service mesh, files are reloaded when their content changes
+type FileSource struct {
+	certFile string
+	keyFile  string
+	caFile   string
+	lock     sync.RWMutex
+	cert     []byte
+	key      []byte
+	ca       []byte
+	handlers []func()
+	// keyPairHandlers are called when the key pair changes
+	keyPairHandlers []func()
+}
+
+// NewFileSource returns a certificate source reading the given files, it fails if the files can't be read
+func NewFileSource(certFile, keyFile, caFile string) (*FileSource, error) {
+	s := &FileSource{
+		certFile: certFile,
+		keyFile:  keyFile,
+		caFile:   caFile,
+	}
+	if _, err := s.reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *FileSource) KeyPair() ([]byte, []byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.cert, s.key, nil
+}
+
+func (s *FileSource) CABundle() ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.ca, nil
+}
+
+// ValidateCert checks the serving certificate is signed by the CA bundle and not expired
+func (s *FileSource) ValidateCert(context.Context) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	certs := pemToCertificates(s.cert)
+	if len(certs) == 0 {
+		return false, fmt.Errorf("no certificate found in %s", s.certFile)
+	}
+	// issuers usually append the intermediates to the serving certificate
+	return validateCert(time.Now(), certs[0], append(pemToCertificates(s.ca), certs[1:]...)...), nil
+}
+
+func (s *FileSource) AddEventHandler(handler func()) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.handlers = append(s.handlers, handler)
+}
+
+func (s *FileSource) AddKeyPairEventHandler(handler func()) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.keyPairHandlers = append(s.keyPairHandlers, handler)
+}
+
+// Run polls the files until the context is cancelled, the signature matches controllers.Controller
+func (s *FileSource) Run(ctx context.Context, _ int) {
+	logger := logging.WithName("certificates")
+	ticker := time.NewTicker(FileSourcePollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changed, err := s.reload()
+			if err != nil {
+				logger.Error(err, "failed to reload certificates")
+				continue
+			}
+			if changed {
+				logger.Info("certificates reloaded", "cert", s.certFile, "ca", s.caFile)
+			}
+		}
+	}
+}
+
+// reload reads the files and notifies the handlers when the CA bundle or the key pair changed
+func (s *FileSource) reload() (bool, error) {
+	cert, err := os.ReadFile(s.certFile)
+	if err != nil {
+		return false, err
+	}
+	key, err := os.ReadFile(s.keyFile)
+	if err != nil {
+		return false, err
+	}
+	ca, err := os.ReadFile(s.caFile)
+	if err != nil {
+		return false, err
+	}
+	if len(cert) == 0 || len(key) == 0 || len(ca) == 0 {
+		return false, fmt.Errorf("empty certificate files %s, %s, %s", s.certFile, s.keyFile, s.caFile)
+	}
+	s.lock.Lock()
+	changed := !bytes.Equal(cert, s.cert) || !bytes.Equal(key, s.key)
+	caChanged := !bytes.Equal(ca, s.ca)
+	s.cert, s.key, s.ca = cert, key, ca
+	handlers, keyPairHandlers := s.handlers, s.keyPairHandlers
+	s.lock.Unlock()
+	if caChanged {
+		for _, handler := range handlers {
+			handler()
+		}
+	}
+	if changed {
+		for _, handler := range keyPairHandlers {
+			handler()
+		}
+	}
+	return changed || caChanged, nil
+}
@@ -0,0 +1,61 @@
+package tls
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeKeyPair(t *testing.T, dir string) {
+	caKey, caCert, err := generateCA(nil, RSA2048, time.Hour)
+	assert.NoError(t, err)
+	tlsKey, tlsCert, err := generateTLS("", caCert, caKey, RSA2048, time.Hour, "kyverno-svc", []string{"kyverno-svc.kyverno.svc"})
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "ca.crt"), certificateToPem(caCert), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "tls.crt"), certificateToPem(tlsCert), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "tls.key"), privateKeyToPem(tlsKey), 0o600))
+}
+
+func TestFileSource(t *testing.T) {
+	dir := t.TempDir()
+	_, err := NewFileSource(filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"), filepath.Join(dir, "ca.crt"))
+	assert.Error(t, err)
+	writeKeyPair(t, dir)
+	source, err := NewFileSource(filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"), filepath.Join(dir, "ca.crt"))
+	assert.NoError(t, err)
+	valid, err := source.ValidateCert(context.TODO())
+	assert.NoError(t, err)
+	assert.True(t, valid)
+	ca, err := source.CABundle()
+	assert.NoError(t, err)
+	var notified int
+	source.AddEventHandler(func() { notified++ })
+	// unchanged files don't notify handlers
+	changed, err := source.reload()
+	assert.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, 0, notified)
+	// rotated files are picked up
+	writeKeyPair(t, dir)
+	changed, err = source.reload()
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, 1, notified)
+	rotated, err := source.CABundle()
+	assert.NoError(t, err)
+	assert.NotEqual(t, ca, rotated)
+	// a mismatched key pair is reported as invalid
+	caPem, err := os.ReadFile(filepath.Join(dir, "ca.crt"))
+	assert.NoError(t, err)
+	writeKeyPair(t, dir)
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "ca.crt"), caPem, 0o600))
+	_, err = source.reload()
+	assert.NoError(t, err)
+	valid, err = source.ValidateCert(context.TODO())
+	assert.NoError(t, err)
+	assert.False(t, valid)
+}
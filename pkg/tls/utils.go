@@ -1,21 +1,40 @@
 package tls
 
 import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
+	"errors"
 	"time"
 
 	"github.com/kyverno/kyverno/api/kyverno"
 	corev1 "k8s.io/api/core/v1"
 )
 
-func privateKeyToPem(rsaKey *rsa.PrivateKey) []byte {
-	privateKey := &pem.Block{
-		Type:  "PRIVATE KEY",
-		Bytes: x509.MarshalPKCS1PrivateKey(rsaKey),
+func privateKeyToPem(key crypto.Signer) []byte {
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		privateKey := &pem.Block{
+			Type:  "PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		}
+		return pem.EncodeToMemory(privateKey)
+	case *ecdsa.PrivateKey:
+		der, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return nil
+		}
+		privateKey := &pem.Block{
+			Type:  "EC PRIVATE KEY",
+			Bytes: der,
+		}
+		return pem.EncodeToMemory(privateKey)
+	default:
+		return nil
 	}
-	return pem.EncodeToMemory(privateKey)
 }
 
 func certificateToPem(certs ...*x509.Certificate) []byte {
@@ -30,9 +49,30 @@ func certificateToPem(certs ...*x509.Certificate) []byte {
 	return raw
 }
 
-func pemToPrivateKey(raw []byte) (*rsa.PrivateKey, error) {
+func pemToPrivateKey(raw []byte) (crypto.Signer, error) {
 	block, _ := pem.Decode(raw)
-	return x509.ParsePKCS1PrivateKey(block.Bytes)
+	if block == nil {
+		return nil, errors.New("failed to decode private key")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	// keys of externally provided CAs are usually PKCS8 encoded
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		return key, nil
+	case *ecdsa.PrivateKey:
+		return key, nil
+	default:
+		return nil, errors.New("unsupported private key, only RSA and ECDSA keys are supported")
+	}
 }
 
 func pemToCertificates(raw []byte) []*x509.Certificate {
@@ -80,6 +120,56 @@ func validateCert(now time.Time, cert *x509.Certificate, caCerts ...*x509.Certif
 	return true
 }
 
+func isSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject) && cert.CheckSignatureFrom(cert) == nil
+}
+
+func containsCertificate(certs []*x509.Certificate, cert *x509.Certificate) bool {
+	for _, c := range certs {
+		if c.Equal(cert) {
+			return true
+		}
+	}
+	return false
+}
+
+// signingCertificate returns the CA certificate matching the private key, the one expiring last
+// when several certificates share the same key (the CA is renewed with the same key)
+func signingCertificate(key crypto.Signer, certs ...*x509.Certificate) *x509.Certificate {
+	if key == nil {
+		return nil
+	}
+	var signer *x509.Certificate
+	for _, cert := range certs {
+		public, ok := cert.PublicKey.(interface{ Equal(crypto.PublicKey) bool })
+		if !ok || !cert.IsCA || !public.Equal(key.Public()) {
+			continue
+		}
+		if signer == nil || cert.NotAfter.After(signer.NotAfter) {
+			signer = cert
+		}
+	}
+	return signer
+}
+
+// intermediateChain returns the signer followed by its parents found in certs, the root is excluded,
+// it's empty when the signer is a self-signed root
+func intermediateChain(signer *x509.Certificate, certs ...*x509.Certificate) []*x509.Certificate {
+	var chain []*x509.Certificate
+	for cert := signer; cert != nil && !isSelfSigned(cert) && !containsCertificate(chain, cert); {
+		chain = append(chain, cert)
+		var parent *x509.Certificate
+		for _, candidate := range certs {
+			if !candidate.Equal(cert) && cert.CheckSignatureFrom(candidate) == nil {
+				parent = candidate
+				break
+			}
+		}
+		cert = parent
+	}
+	return chain
+}
+
 func isSecretManagedByKyverno(secret *corev1.Secret) bool {
 	if secret != nil {
 		labels := secret.GetLabels()
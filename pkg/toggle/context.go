@@ -12,6 +12,7 @@ type Toggles interface {
 	EnableDeferredLoading() bool
 	GenerateValidatingAdmissionPolicy() bool
 	DumpMutatePatches() bool
+	GenerateWebhookMatchConditions() bool
 }
 
 type defaultToggles struct{}
@@ -36,6 +37,10 @@ func (defaultToggles) DumpMutatePatches() bool {
 	return DumpMutatePatches.enabled()
 }
 
+func (defaultToggles) GenerateWebhookMatchConditions() bool {
+	return GenerateWebhookMatchConditions.enabled()
+}
+
 type contextKey struct{}
 
 func NewContext(ctx context.Context, toggles Toggles) context.Context {
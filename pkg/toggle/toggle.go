@@ -31,6 +31,11 @@ const (
 	DumpMutatePatchesDescription = "Set the flag to 'true', to dump mutate patches."
 	dumpMutatePatchesEnvVar      = "FLAG_DUMP_PATCHES"
 	defaultDumpMutatePatches     = false
+	// generate webhook match conditions from policy preconditions
+	GenerateWebhookMatchConditionsFlagName    = "generateWebhookMatchConditions"
+	GenerateWebhookMatchConditionsDescription = "Set the flag to 'false', to disable the generation of webhook match conditions from policy preconditions."
+	generateWebhookMatchConditionsEnvVar      = "FLAG_GENERATE_WEBHOOK_MATCH_CONDITIONS"
+	defaultGenerateWebhookMatchConditions     = true
 )
 
 var (
@@ -39,6 +44,7 @@ var (
 	EnableDeferredLoading             = newToggle(defaultEnableDeferredLoading, enableDeferredLoadingEnvVar)
 	GenerateValidatingAdmissionPolicy = newToggle(defaultGenerateValidatingAdmissionPolicy, generateValidatingAdmissionPolicyEnvVar)
 	DumpMutatePatches                 = newToggle(defaultDumpMutatePatches, dumpMutatePatchesEnvVar)
+	GenerateWebhookMatchConditions    = newToggle(defaultGenerateWebhookMatchConditions, generateWebhookMatchConditionsEnvVar)
 )
 
 type ToggleFlag interface {
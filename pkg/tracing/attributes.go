@@ -7,12 +7,17 @@ import (
 const (
 	limit = 256
 	// engine attributes
-	PolicyGroupKey     = attribute.Key("kyverno.policy.group")
-	PolicyVersionKey   = attribute.Key("kyverno.policy.version")
-	PolicyKindKey      = attribute.Key("kyverno.policy.kind")
-	PolicyNameKey      = attribute.Key("kyverno.policy.name")
-	PolicyNamespaceKey = attribute.Key("kyverno.policy.namespace")
-	RuleNameKey        = attribute.Key("kyverno.rule.name")
+	PolicyGroupKey            = attribute.Key("kyverno.policy.group")
+	PolicyVersionKey          = attribute.Key("kyverno.policy.version")
+	PolicyKindKey             = attribute.Key("kyverno.policy.kind")
+	PolicyNameKey             = attribute.Key("kyverno.policy.name")
+	PolicyNamespaceKey        = attribute.Key("kyverno.policy.namespace")
+	RuleNameKey               = attribute.Key("kyverno.rule.name")
+	RuleTypeKey               = attribute.Key("kyverno.rule.type")
+	RuleStatusKey             = attribute.Key("kyverno.rule.status")
+	RuleContextDurationKey    = attribute.Key("kyverno.rule.context.duration")
+	RuleEvaluationDurationKey = attribute.Key("kyverno.rule.evaluation.duration")
+	ContextLoadOrderKey       = attribute.Key("kyverno.context.loadorder")
 	// admission resource attributes
 	// ResourceNameKey       = attribute.Key("admission.resource.name")
 	// ResourceNamespaceKey  = attribute.Key("admission.resource.namespace")
@@ -1,6 +1,8 @@
 package cel
 
 import (
+	"fmt"
+
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
 	"k8s.io/apiserver/pkg/admission/plugin/cel"
@@ -137,3 +139,24 @@ func (c Compiler) convertVariables() []cel.NamedExpressionAccessor {
 	}
 	return namedExpressions
 }
+
+// CompileMatchConditions compiles match conditions written with the v1beta1 API, the expressions have access to
+// the object, oldObject, request and authorizer variables. Compilation errors are returned as an error.
+func CompileMatchConditions(conditions []admissionregistrationv1beta1.MatchCondition) (cel.Filter, error) {
+	matchConditions := make([]admissionregistrationv1.MatchCondition, 0, len(conditions))
+	for _, condition := range conditions {
+		matchConditions = append(matchConditions, admissionregistrationv1.MatchCondition{
+			Name:       condition.Name,
+			Expression: condition.Expression,
+		})
+	}
+	compiler, err := NewCompiler(nil, nil, matchConditions, nil)
+	if err != nil {
+		return nil, err
+	}
+	filter := compiler.CompileMatchExpressions(cel.OptionalVariableDeclarations{HasParams: false, HasAuthorizer: true})
+	if errs := filter.CompilationErrors(); len(errs) > 0 {
+		return nil, fmt.Errorf("failed to compile CEL expressions: %v", errs)
+	}
+	return filter, nil
+}
@@ -2,6 +2,7 @@ package engine
 
 import (
 	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/informers"
 	"github.com/kyverno/kyverno/pkg/logging"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 )
@@ -19,3 +20,16 @@ func GetNamespaceSelectorsFromNamespaceLister(kind, namespaceOfResource string,
 	}
 	return namespaceLabels
 }
+
+// GetNamespaceLabels - extract the namespace labels from the namespace labels index
+func GetNamespaceLabels(kind, namespaceOfResource string, nsLabels informers.NamespaceLabels, logger logr.Logger) map[string]string {
+	if kind != "Namespace" && namespaceOfResource != "" {
+		labels, ok := nsLabels.Get(namespaceOfResource)
+		if !ok {
+			logger.V(2).Info("namespace not found in labels index", "name", namespaceOfResource)
+			return map[string]string{}
+		}
+		return labels
+	}
+	return map[string]string{}
+}
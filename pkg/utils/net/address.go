@@ -0,0 +1,39 @@
+package net
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// ParseBindAddress validates the IP address a server binds to and returns it without brackets so that it can
+// be given to net.JoinHostPort. An empty address is returned as is, it binds the server to all interfaces.
+func ParseBindAddress(address string) (string, error) {
+	if address == "" {
+		return address, nil
+	}
+	host := address
+	if strings.HasPrefix(host, "[") && strings.HasSuffix(host, "]") {
+		host = host[1 : len(host)-1]
+	}
+	if net.ParseIP(host) == nil {
+		return "", fmt.Errorf("invalid bind address %q, expected an IPv4 or IPv6 address", address)
+	}
+	return host, nil
+}
+
+// LoopbackAddress returns the address to use to reach a server bound to the given address from the same host.
+// Unspecified addresses are replaced with the loopback address of the same family.
+func LoopbackAddress(address string) string {
+	if address == "" {
+		return "127.0.0.1"
+	}
+	ip := net.ParseIP(address)
+	if ip == nil || !ip.IsUnspecified() {
+		return address
+	}
+	if ip.To4() != nil {
+		return "127.0.0.1"
+	}
+	return "::1"
+}
@@ -0,0 +1,109 @@
+package net
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBindAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		want    string
+		wantErr bool
+	}{{
+		name:    "empty",
+		address: "",
+		want:    "",
+	}, {
+		name:    "ipv4",
+		address: "10.0.0.1",
+		want:    "10.0.0.1",
+	}, {
+		name:    "ipv4 unspecified",
+		address: "0.0.0.0",
+		want:    "0.0.0.0",
+	}, {
+		name:    "ipv6",
+		address: "fd00::1",
+		want:    "fd00::1",
+	}, {
+		name:    "ipv6 unspecified",
+		address: "::",
+		want:    "::",
+	}, {
+		name:    "ipv6 with brackets",
+		address: "[::1]",
+		want:    "::1",
+	}, {
+		name:    "hostname",
+		address: "localhost",
+		wantErr: true,
+	}, {
+		name:    "with port",
+		address: "10.0.0.1:9443",
+		wantErr: true,
+	}, {
+		name:    "ipv6 with port",
+		address: "[::1]:9443",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBindAddress(tt.address)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseBindAddressJoinHostPort(t *testing.T) {
+	tests := []struct {
+		address string
+		want    string
+	}{
+		{address: "", want: ":9443"},
+		{address: "0.0.0.0", want: "0.0.0.0:9443"},
+		{address: "::", want: "[::]:9443"},
+		{address: "[::]", want: "[::]:9443"},
+		{address: "fd00::1", want: "[fd00::1]:9443"},
+		{address: "[fd00::1]", want: "[fd00::1]:9443"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			host, err := ParseBindAddress(tt.address)
+			assert.NoError(t, err)
+			got := net.JoinHostPort(host, "9443")
+			assert.Equal(t, tt.want, got)
+			// the joined address must be splittable back into the bind address and port
+			splitHost, port, err := net.SplitHostPort(got)
+			assert.NoError(t, err)
+			assert.Equal(t, host, splitHost)
+			assert.Equal(t, "9443", port)
+		})
+	}
+}
+
+func TestLoopbackAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		want    string
+	}{
+		{address: "", want: "127.0.0.1"},
+		{address: "0.0.0.0", want: "127.0.0.1"},
+		{address: "::", want: "::1"},
+		{address: "10.0.0.1", want: "10.0.0.1"},
+		{address: "fd00::1", want: "fd00::1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			assert.Equal(t, tt.want, LoopbackAddress(tt.address))
+		})
+	}
+}
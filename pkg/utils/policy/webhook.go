@@ -0,0 +1,91 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/toggle"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+)
+
+// GetFailurePolicy returns the failure policy of the webhooks covering the policy.
+// The failure policy annotation takes precedence over the policy spec, unless failure policies are forced to Ignore.
+func GetFailurePolicy(ctx context.Context, policy kyvernov1.PolicyInterface) kyvernov1.FailurePolicyType {
+	if toggle.FromContext(ctx).ForceFailurePolicyIgnore() {
+		return kyvernov1.Ignore
+	}
+	if value, ok := policy.GetAnnotations()[kyverno.AnnotationWebhookFailurePolicy]; ok {
+		if failurePolicy, err := parseFailurePolicy(value); err == nil {
+			return failurePolicy
+		}
+	}
+	return policy.GetSpec().GetFailurePolicy(ctx)
+}
+
+// GetWebhookTimeoutSeconds returns the timeout of the webhooks covering the policy, if any.
+// The timeout annotation takes precedence over the policy spec.
+func GetWebhookTimeoutSeconds(policy kyvernov1.PolicyInterface) *int32 {
+	if value, ok := policy.GetAnnotations()[kyverno.AnnotationWebhookTimeoutSeconds]; ok {
+		if timeout, err := parseTimeoutSeconds(value); err == nil {
+			return &timeout
+		}
+	}
+	return policy.GetSpec().GetWebhookTimeoutSeconds()
+}
+
+// GetReinvocationPolicy returns the reinvocation policy requested by the policy annotation, if any
+func GetReinvocationPolicy(policy kyvernov1.PolicyInterface) *admissionregistrationv1.ReinvocationPolicyType {
+	if value, ok := policy.GetAnnotations()[kyverno.AnnotationWebhookReinvocation]; ok {
+		if reinvocationPolicy, err := parseReinvocationPolicy(value); err == nil {
+			return &reinvocationPolicy
+		}
+	}
+	return nil
+}
+
+// ValidateWebhookAnnotations checks the values of the webhook annotations of the policy
+func ValidateWebhookAnnotations(policy kyvernov1.PolicyInterface) error {
+	if value, ok := policy.GetAnnotations()[kyverno.AnnotationWebhookFailurePolicy]; ok {
+		if _, err := parseFailurePolicy(value); err != nil {
+			return fmt.Errorf("invalid annotation %s: %w", kyverno.AnnotationWebhookFailurePolicy, err)
+		}
+	}
+	if value, ok := policy.GetAnnotations()[kyverno.AnnotationWebhookTimeoutSeconds]; ok {
+		if _, err := parseTimeoutSeconds(value); err != nil {
+			return fmt.Errorf("invalid annotation %s: %w", kyverno.AnnotationWebhookTimeoutSeconds, err)
+		}
+	}
+	if value, ok := policy.GetAnnotations()[kyverno.AnnotationWebhookReinvocation]; ok {
+		if _, err := parseReinvocationPolicy(value); err != nil {
+			return fmt.Errorf("invalid annotation %s: %w", kyverno.AnnotationWebhookReinvocation, err)
+		}
+	}
+	return nil
+}
+
+func parseFailurePolicy(value string) (kyvernov1.FailurePolicyType, error) {
+	switch kyvernov1.FailurePolicyType(value) {
+	case kyvernov1.Fail, kyvernov1.Ignore:
+		return kyvernov1.FailurePolicyType(value), nil
+	}
+	return "", fmt.Errorf("failure policy must be %s or %s, got %q", kyvernov1.Fail, kyvernov1.Ignore, value)
+}
+
+func parseTimeoutSeconds(value string) (int32, error) {
+	timeout, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || timeout < 1 || timeout > 30 {
+		return 0, fmt.Errorf("timeout must be an integer between 1 and 30 seconds, got %q", value)
+	}
+	return int32(timeout), nil
+}
+
+func parseReinvocationPolicy(value string) (admissionregistrationv1.ReinvocationPolicyType, error) {
+	switch admissionregistrationv1.ReinvocationPolicyType(value) {
+	case admissionregistrationv1.NeverReinvocationPolicy, admissionregistrationv1.IfNeededReinvocationPolicy:
+		return admissionregistrationv1.ReinvocationPolicyType(value), nil
+	}
+	return "", fmt.Errorf("reinvocation policy must be %s or %s, got %q", admissionregistrationv1.NeverReinvocationPolicy, admissionregistrationv1.IfNeededReinvocationPolicy, value)
+}
@@ -0,0 +1,143 @@
+package policy
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestGetFailurePolicy(t *testing.T) {
+	ignore := kyvernov1.Ignore
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		spec        kyvernov1.Spec
+		want        kyvernov1.FailurePolicyType
+		wantErr     bool
+	}{{
+		name: "default",
+		want: kyvernov1.Fail,
+	}, {
+		name: "spec",
+		spec: kyvernov1.Spec{FailurePolicy: &ignore},
+		want: kyvernov1.Ignore,
+	}, {
+		name:        "annotation",
+		annotations: map[string]string{kyverno.AnnotationWebhookFailurePolicy: "Ignore"},
+		want:        kyvernov1.Ignore,
+	}, {
+		name:        "annotation takes precedence",
+		annotations: map[string]string{kyverno.AnnotationWebhookFailurePolicy: "Fail"},
+		spec:        kyvernov1.Spec{WebhookConfiguration: &kyvernov1.WebhookConfiguration{FailurePolicy: &ignore}},
+		want:        kyvernov1.Fail,
+	}, {
+		name:        "invalid annotation",
+		annotations: map[string]string{kyverno.AnnotationWebhookFailurePolicy: "ignore"},
+		spec:        kyvernov1.Spec{FailurePolicy: &ignore},
+		want:        kyvernov1.Ignore,
+		wantErr:     true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &kyvernov1.ClusterPolicy{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations},
+				Spec:       tt.spec,
+			}
+			if got := GetFailurePolicy(context.TODO(), policy); got != tt.want {
+				t.Errorf("GetFailurePolicy() = %v, want %v", got, tt.want)
+			}
+			if err := ValidateWebhookAnnotations(policy); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateWebhookAnnotations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetWebhookTimeoutSeconds(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		spec        kyvernov1.Spec
+		want        *int32
+		wantErr     bool
+	}{{
+		name: "default",
+	}, {
+		name: "spec",
+		spec: kyvernov1.Spec{WebhookConfiguration: &kyvernov1.WebhookConfiguration{TimeoutSeconds: ptr.To[int32](15)}},
+		want: ptr.To[int32](15),
+	}, {
+		name:        "annotation takes precedence",
+		annotations: map[string]string{kyverno.AnnotationWebhookTimeoutSeconds: "5"},
+		spec:        kyvernov1.Spec{WebhookConfiguration: &kyvernov1.WebhookConfiguration{TimeoutSeconds: ptr.To[int32](15)}},
+		want:        ptr.To[int32](5),
+	}, {
+		name:        "out of range annotation",
+		annotations: map[string]string{kyverno.AnnotationWebhookTimeoutSeconds: "60"},
+		spec:        kyvernov1.Spec{WebhookConfiguration: &kyvernov1.WebhookConfiguration{TimeoutSeconds: ptr.To[int32](15)}},
+		want:        ptr.To[int32](15),
+		wantErr:     true,
+	}, {
+		name:        "invalid annotation",
+		annotations: map[string]string{kyverno.AnnotationWebhookTimeoutSeconds: "10s"},
+		wantErr:     true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &kyvernov1.ClusterPolicy{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations},
+				Spec:       tt.spec,
+			}
+			if got := GetWebhookTimeoutSeconds(policy); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetWebhookTimeoutSeconds() = %v, want %v", got, tt.want)
+			}
+			if err := ValidateWebhookAnnotations(policy); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateWebhookAnnotations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetReinvocationPolicy(t *testing.T) {
+	ifNeeded := admissionregistrationv1.IfNeededReinvocationPolicy
+	never := admissionregistrationv1.NeverReinvocationPolicy
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        *admissionregistrationv1.ReinvocationPolicyType
+		wantErr     bool
+	}{{
+		name: "default",
+	}, {
+		name:        "if needed",
+		annotations: map[string]string{kyverno.AnnotationWebhookReinvocation: "IfNeeded"},
+		want:        &ifNeeded,
+	}, {
+		name:        "never",
+		annotations: map[string]string{kyverno.AnnotationWebhookReinvocation: "Never"},
+		want:        &never,
+	}, {
+		name:        "invalid annotation",
+		annotations: map[string]string{kyverno.AnnotationWebhookReinvocation: "ifneeded"},
+		wantErr:     true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &kyvernov1.ClusterPolicy{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations},
+			}
+			if got := GetReinvocationPolicy(policy); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetReinvocationPolicy() = %v, want %v", got, tt.want)
+			}
+			if err := ValidateWebhookAnnotations(policy); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateWebhookAnnotations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	reportsv1 "github.com/kyverno/kyverno/api/reports/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const httpStoreTimeout = 10 * time.Second
+
+type httpStore struct {
+	endpoint *url.URL
+	token    string
+	client   *http.Client
+}
+
+// NewHTTPStore returns a store writing policy reports to an external REST API, usually fronting a database.
+// Reports are identified by their path, /clusterpolicyreports/{name} for cluster reports and
+// /namespaces/{namespace}/policyreports/{name} for namespaced ones, relative to the endpoint.
+// GET returns the report, PUT creates or replaces it and DELETE removes it, a 404 status means the
+// report doesn't exist. The token, when set, is sent as a bearer token.
+func NewHTTPStore(endpoint string, token string) (Store, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid report store url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid report store url scheme %s, must be http or https", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, errors.New("invalid report store url, missing host")
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	return &httpStore{
+		endpoint: u,
+		token:    token,
+		client:   &http.Client{Timeout: httpStoreTimeout},
+	}, nil
+}
+
+func (s *httpStore) path(namespace, name string) string {
+	u := *s.endpoint
+	if namespace == "" {
+		u.Path += "/clusterpolicyreports/" + url.PathEscape(name)
+	} else {
+		u.Path += "/namespaces/" + url.PathEscape(namespace) + "/policyreports/" + url.PathEscape(name)
+	}
+	return u.String()
+}
+
+func (s *httpStore) do(ctx context.Context, method, namespace, name string, body any) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.path(namespace, name), reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	return s.client.Do(req)
+}
+
+func (s *httpStore) Get(ctx context.Context, namespace, name string) (reportsv1.ReportInterface, error) {
+	resp, err := s.do(ctx, http.MethodGet, namespace, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get report %s/%s: unexpected status %d", namespace, name, resp.StatusCode)
+	}
+	report := newPolicyReport(namespace)
+	if err := json.NewDecoder(resp.Body).Decode(report); err != nil {
+		return nil, fmt.Errorf("failed to decode report %s/%s: %w", namespace, name, err)
+	}
+	return report, nil
+}
+
+func (s *httpStore) Create(ctx context.Context, report reportsv1.ReportInterface) (reportsv1.ReportInterface, error) {
+	return s.put(ctx, report)
+}
+
+func (s *httpStore) Update(ctx context.Context, report reportsv1.ReportInterface) (reportsv1.ReportInterface, error) {
+	return s.put(ctx, report)
+}
+
+func (s *httpStore) put(ctx context.Context, report reportsv1.ReportInterface) (reportsv1.ReportInterface, error) {
+	if err := checkPolicyReport(report); err != nil {
+		return nil, err
+	}
+	resp, err := s.do(ctx, http.MethodPut, report.GetNamespace(), report.GetName(), withTypeMeta(report))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		return nil, fmt.Errorf("failed to store report %s/%s: unexpected status %d", report.GetNamespace(), report.GetName(), resp.StatusCode)
+	}
+	return report, nil
+}
+
+func (s *httpStore) Delete(ctx context.Context, report reportsv1.ReportInterface) error {
+	if err := checkPolicyReport(report); err != nil {
+		return err
+	}
+	resp, err := s.do(ctx, http.MethodDelete, report.GetNamespace(), report.GetName(), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		resource := policyreportv1alpha2.SchemeGroupVersion.WithResource("policyreports").GroupResource()
+		if report.GetNamespace() == "" {
+			resource = policyreportv1alpha2.SchemeGroupVersion.WithResource("clusterpolicyreports").GroupResource()
+		}
+		return apierrors.NewNotFound(resource, report.GetName())
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to delete report %s/%s: unexpected status %d", report.GetNamespace(), report.GetName(), resp.StatusCode)
+	}
+	return nil
+}
+
+func newPolicyReport(namespace string) reportsv1.ReportInterface {
+	if namespace == "" {
+		return &policyreportv1alpha2.ClusterPolicyReport{}
+	}
+	return &policyreportv1alpha2.PolicyReport{}
+}
+
+func checkPolicyReport(report reportsv1.ReportInterface) error {
+	switch report.(type) {
+	case *policyreportv1alpha2.PolicyReport, *policyreportv1alpha2.ClusterPolicyReport:
+		return nil
+	default:
+		return errors.New("only policy reports can be stored in the report store")
+	}
+}
+
+// withTypeMeta returns a copy of the report with its apiVersion and kind set, typed objects built in
+// memory don't carry them and the external store has no other way to tell the report kind
+func withTypeMeta(report reportsv1.ReportInterface) reportsv1.ReportInterface {
+	switch v := report.(type) {
+	case *policyreportv1alpha2.PolicyReport:
+		v = v.DeepCopy()
+		v.APIVersion = policyreportv1alpha2.SchemeGroupVersion.String()
+		v.Kind = "PolicyReport"
+		return v
+	case *policyreportv1alpha2.ClusterPolicyReport:
+		v = v.DeepCopy()
+		v.APIVersion = policyreportv1alpha2.SchemeGroupVersion.String()
+		v.Kind = "ClusterPolicyReport"
+		return v
+	default:
+		return report
+	}
+}
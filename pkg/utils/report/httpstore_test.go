@@ -0,0 +1,111 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	reportsv1 "github.com/kyverno/kyverno/api/reports/v1"
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestReportServer(t *testing.T) *httptest.Server {
+	var mu sync.Mutex
+	reports := map[string][]byte{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodGet:
+			data, ok := reports[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(data)
+		case http.MethodPut:
+			data, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			reports[r.URL.Path] = data
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodDelete:
+			if _, ok := reports[r.URL.Path]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			delete(reports, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+}
+
+func TestHTTPStore(t *testing.T) {
+	server := newTestReportServer(t)
+	defer server.Close()
+	store, err := NewHTTPStore(server.URL+"/api/", "secret")
+	assert.NoError(t, err)
+	ctx := context.TODO()
+	report := &policyreportv1alpha2.PolicyReport{ObjectMeta: metav1.ObjectMeta{Name: "uid", Namespace: "default"}}
+	SetResults(report, policyreportv1alpha2.PolicyReportResult{Policy: "policy", Rule: "rule", Result: "fail"})
+	// not found
+	got, err := store.Get(ctx, "default", "uid")
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+	// create and get
+	_, err = store.Create(ctx, report)
+	assert.NoError(t, err)
+	got, err = store.Get(ctx, "default", "uid")
+	assert.NoError(t, err)
+	assert.Equal(t, "PolicyReport", got.(*policyreportv1alpha2.PolicyReport).Kind)
+	assert.Equal(t, report.GetResults(), got.GetResults())
+	// cluster reports don't collide with namespaced ones
+	got, err = store.Get(ctx, "", "uid")
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+	// delete
+	assert.NoError(t, store.Delete(ctx, report))
+	err = store.Delete(ctx, report)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestHTTPStore_unauthorized(t *testing.T) {
+	server := newTestReportServer(t)
+	defer server.Close()
+	store, err := NewHTTPStore(server.URL, "")
+	assert.NoError(t, err)
+	_, err = store.Get(context.TODO(), "default", "uid")
+	assert.Error(t, err)
+}
+
+func TestHTTPStore_ephemeralReports(t *testing.T) {
+	store, err := NewHTTPStore("http://localhost", "")
+	assert.NoError(t, err)
+	_, err = store.Create(context.TODO(), &reportsv1.EphemeralReport{})
+	assert.Error(t, err)
+}
+
+func TestNewHTTPStore(t *testing.T) {
+	for _, endpoint := range []string{"", "localhost:8080", "ftp://localhost", "http://"} {
+		_, err := NewHTTPStore(endpoint, "")
+		assert.Error(t, err, endpoint)
+	}
+}
+
+func Test_withTypeMeta(t *testing.T) {
+	report := &policyreportv1alpha2.ClusterPolicyReport{ObjectMeta: metav1.ObjectMeta{Name: "uid"}}
+	data, err := json.Marshal(withTypeMeta(report))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"apiVersion":"wgpolicyk8s.io/v1alpha2","kind":"ClusterPolicyReport","metadata":{"name":"uid","creationTimestamp":null},"summary":{"pass":0,"fail":0,"warn":0,"error":0,"skip":0}}`, string(data))
+	assert.Empty(t, report.Kind)
+}
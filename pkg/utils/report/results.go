@@ -3,22 +3,42 @@ package report
 import (
 	"cmp"
 	"encoding/json"
+	"fmt"
+	"hash/fnv"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/kyverno/kyverno/api/kyverno"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
 	reportsv1 "github.com/kyverno/kyverno/api/reports/v1"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/pss/utils"
+	"gomodules.xyz/jsonpatch/v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 )
 
+const (
+	// maxPatchSummary is the maximum number of patch operations listed in a mutation result
+	maxPatchSummary = 10
+
+	MutationPatched = "patched"
+	MutationSkipped = "skipped"
+	MutationError   = "error"
+
+	GenerationCreated   = "created"
+	GenerationSynced    = "synced"
+	GenerationUnchanged = "unchanged"
+	GenerationFailed    = "failed"
+)
+
 func SortReportResults(results []policyreportv1alpha2.PolicyReportResult) {
 	slices.SortFunc(results, func(a policyreportv1alpha2.PolicyReportResult, b policyreportv1alpha2.PolicyReportResult) int {
 		if x := cmp.Compare(a.Policy, b.Policy); x != 0 {
@@ -39,6 +59,54 @@ func SortReportResults(results []policyreportv1alpha2.PolicyReportResult) {
 	})
 }
 
+// ResultIdentity returns the canonical identity of a result, the same violation recorded by the admission
+// path and by the background scan has the same identity even if the rest of their metadata differs
+func ResultIdentity(result policyreportv1alpha2.PolicyReportResult) string {
+	var uid types.UID
+	if len(result.Resources) != 0 {
+		uid = result.Resources[0].UID
+	}
+	hash := fnv.New64a()
+	hash.Write([]byte(result.Message))
+	return result.Policy + "/" + result.Rule + "/" + string(uid) + "/" + strconv.FormatUint(hash.Sum64(), 16)
+}
+
+// DedupeResults merges the results sharing the same identity, the most recent result is kept and
+// the properties of the merged results are added to it
+func DedupeResults(results []policyreportv1alpha2.PolicyReportResult) []policyreportv1alpha2.PolicyReportResult {
+	indexes := make(map[string]int, len(results))
+	deduped := make([]policyreportv1alpha2.PolicyReportResult, 0, len(results))
+	for _, result := range results {
+		identity := ResultIdentity(result)
+		i, ok := indexes[identity]
+		if !ok {
+			indexes[identity] = len(deduped)
+			deduped = append(deduped, result)
+			continue
+		}
+		newer, older := result, deduped[i]
+		if isBefore(newer.Timestamp, older.Timestamp) {
+			newer, older = older, newer
+		}
+		if len(older.Properties) != 0 {
+			properties := make(map[string]string, len(older.Properties)+len(newer.Properties))
+			for k, v := range older.Properties {
+				properties[k] = v
+			}
+			for k, v := range newer.Properties {
+				properties[k] = v
+			}
+			newer.Properties = properties
+		}
+		deduped[i] = newer
+	}
+	return deduped
+}
+
+func isBefore(a, b metav1.Timestamp) bool {
+	return a.Seconds < b.Seconds || (a.Seconds == b.Seconds && a.Nanos < b.Nanos)
+}
+
 func CalculateSummary(results []policyreportv1alpha2.PolicyReportResult) (summary policyreportv1alpha2.PolicyReportSummary) {
 	for _, res := range results {
 		switch res.Result {
@@ -89,6 +157,39 @@ func SeverityFromString(severity string) policyreportv1alpha2.PolicySeverity {
 	return ""
 }
 
+// IsExcludedFromReports returns whether the results of a rule are excluded from the reports by the policy
+// annotations, the annotation is either true to exclude the whole policy or a comma separated list of rules.
+// Excluded rules are still enforced, only their results are not stored.
+func IsExcludedFromReports(annotations map[string]string, rule string) bool {
+	value := strings.TrimSpace(annotations[kyverno.AnnotationPolicyExcludeFromReports])
+	if value == "" {
+		return false
+	}
+	if value == "true" {
+		return true
+	}
+	for _, excluded := range strings.Split(value, ",") {
+		if strings.TrimSpace(excluded) == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// Remediation returns the guidance to fix a violation of a rule, the rule remediations annotation holds
+// a JSON object of remediations by rule name taking precedence over the policy remediation annotation
+func Remediation(annotations map[string]string, rule string) string {
+	if value := annotations[kyverno.AnnotationPolicyRuleRemediations]; value != "" {
+		var remediations map[string]string
+		if err := json.Unmarshal([]byte(value), &remediations); err == nil {
+			if remediation := strings.TrimSpace(remediations[rule]); remediation != "" {
+				return remediation
+			}
+		}
+	}
+	return strings.TrimSpace(annotations[kyverno.AnnotationPolicyRemediation])
+}
+
 func ToPolicyReportResult(policyType engineapi.PolicyType, policyName string, ruleResult engineapi.RuleResponse, annotations map[string]string, resource *corev1.ObjectReference) policyreportv1alpha2.PolicyReportResult {
 	result := policyreportv1alpha2.PolicyReportResult{
 		Source:     kyverno.ValueKyvernoApp,
@@ -107,6 +208,11 @@ func ToPolicyReportResult(policyType engineapi.PolicyType, policyName string, ru
 	if result.Result == "fail" && !result.Scored {
 		result.Result = "warn"
 	}
+	if result.Result == policyreportv1alpha2.StatusFail || result.Result == policyreportv1alpha2.StatusWarn {
+		if remediation := Remediation(annotations, result.Rule); remediation != "" {
+			addProperty("remediation", remediation, &result)
+		}
+	}
 	if resource != nil {
 		result.Resources = []corev1.ObjectReference{
 			*resource,
@@ -185,6 +291,9 @@ func EngineResponseToReportResults(response engineapi.EngineResponse) []policyre
 
 	results := make([]policyreportv1alpha2.PolicyReportResult, 0, len(response.PolicyResponse.Rules))
 	for _, ruleResult := range response.PolicyResponse.Rules {
+		if IsExcludedFromReports(annotations, ruleResult.Name()) {
+			continue
+		}
 		result := ToPolicyReportResult(policyType, policyName, ruleResult, annotations, nil)
 		results = append(results, result)
 	}
@@ -198,18 +307,48 @@ func MutationEngineResponseToReportResults(response engineapi.EngineResponse) []
 	policyType := pol.GetType()
 	annotations := pol.GetAnnotations()
 
+	patches := summarizePatches(response.GetPatches())
 	results := make([]policyreportv1alpha2.PolicyReportResult, 0, len(response.PolicyResponse.Rules))
 	for _, ruleResult := range response.PolicyResponse.Rules {
+		if IsExcludedFromReports(annotations, ruleResult.Name()) {
+			continue
+		}
 		result := ToPolicyReportResult(policyType, policyName, ruleResult, annotations, nil)
 		if target, _, _ := ruleResult.PatchedTarget(); target != nil {
 			addProperty("patched-target", getResourceInfo(target.GroupVersionKind(), target.GetName(), target.GetNamespace()), &result)
 		}
+		switch ruleResult.Status() {
+		case engineapi.RuleStatusPass:
+			addProperty("mutation", MutationPatched, &result)
+			// the engine doesn't track the patches per rule, the summary covers the patches of the policy
+			if patches != "" {
+				addProperty("patches", patches, &result)
+			}
+		case engineapi.RuleStatusSkip:
+			addProperty("mutation", MutationSkipped, &result)
+		case engineapi.RuleStatusError, engineapi.RuleStatusFail:
+			addProperty("mutation", MutationError, &result)
+		}
 		results = append(results, result)
 	}
 
 	return results
 }
 
+// summarizePatches lists the operations and paths of the patches, without the values that may hold
+// sensitive data
+func summarizePatches(patches []jsonpatch.JsonPatchOperation) string {
+	summary := make([]string, 0, min(len(patches), maxPatchSummary))
+	for _, patch := range patches {
+		if len(summary) == maxPatchSummary {
+			summary = append(summary, fmt.Sprintf("(%d more)", len(patches)-maxPatchSummary))
+			break
+		}
+		summary = append(summary, patch.Operation+" "+patch.Path)
+	}
+	return strings.Join(summary, "; ")
+}
+
 func GenerationEngineResponseToReportResults(response engineapi.EngineResponse) []policyreportv1alpha2.PolicyReportResult {
 	pol := response.Policy()
 	policyName, _ := cache.MetaNamespaceKeyFunc(pol.AsKyvernoPolicy())
@@ -218,6 +357,9 @@ func GenerationEngineResponseToReportResults(response engineapi.EngineResponse)
 
 	results := make([]policyreportv1alpha2.PolicyReportResult, 0, len(response.PolicyResponse.Rules))
 	for _, ruleResult := range response.PolicyResponse.Rules {
+		if IsExcludedFromReports(annotations, ruleResult.Name()) {
+			continue
+		}
 		result := ToPolicyReportResult(policyType, policyName, ruleResult, annotations, nil)
 		if generatedResources := ruleResult.GeneratedResources(); len(generatedResources) != 0 {
 			property := make([]string, 0)
@@ -257,6 +399,7 @@ func SplitResultsByPolicy(logger logr.Logger, results []policyreportv1alpha2.Pol
 }
 
 func SetResults(report reportsv1.ReportInterface, results ...policyreportv1alpha2.PolicyReportResult) {
+	results = DedupeResults(results)
 	SortReportResults(results)
 	report.SetResults(results)
 	report.SetSummary(CalculateSummary(results))
@@ -299,3 +442,8 @@ func getResourceInfo(gvk schema.GroupVersionKind, name, namespace string) string
 	}
 	return info
 }
+
+// ResourceSpecInfo formats a resource spec the same way the resources are referenced in the report properties
+func ResourceSpecInfo(spec kyvernov1.ResourceSpec) string {
+	return getResourceInfo(schema.FromAPIVersionAndKind(spec.APIVersion, spec.Kind), spec.Name, spec.Namespace)
+}
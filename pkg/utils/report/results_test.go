@@ -0,0 +1,118 @@
+package report
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kyverno/kyverno/api/kyverno"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	"gomodules.xyz/jsonpatch/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_summarizePatches(t *testing.T) {
+	assert.Equal(t, "", summarizePatches(nil))
+	patches := []jsonpatch.JsonPatchOperation{
+		{Operation: "add", Path: "/metadata/labels/team", Value: "secret-value"},
+		{Operation: "replace", Path: "/spec/replicas", Value: 2},
+	}
+	assert.Equal(t, "add /metadata/labels/team; replace /spec/replicas", summarizePatches(patches))
+}
+
+func Test_summarizePatches_truncated(t *testing.T) {
+	var patches []jsonpatch.JsonPatchOperation
+	for i := 0; i < maxPatchSummary+3; i++ {
+		patches = append(patches, jsonpatch.JsonPatchOperation{Operation: "add", Path: fmt.Sprintf("/metadata/labels/l%d", i)})
+	}
+	summary := summarizePatches(patches)
+	assert.Contains(t, summary, "add /metadata/labels/l9; (3 more)")
+	assert.NotContains(t, summary, "l10")
+}
+
+func Test_DedupeResults(t *testing.T) {
+	resources := []corev1.ObjectReference{{UID: "uid-1"}}
+	admission := policyreportv1alpha2.PolicyReportResult{
+		Policy:     "require-labels",
+		Rule:       "check-team",
+		Message:    "label team is required",
+		Result:     policyreportv1alpha2.StatusFail,
+		Resources:  resources,
+		Timestamp:  metav1.Timestamp{Seconds: 100},
+		Properties: map[string]string{"process": "admission review", "source": "admission"},
+	}
+	background := policyreportv1alpha2.PolicyReportResult{
+		Policy:     "require-labels",
+		Rule:       "check-team",
+		Message:    "label team is required",
+		Result:     policyreportv1alpha2.StatusFail,
+		Category:   "Best Practices",
+		Resources:  resources,
+		Timestamp:  metav1.Timestamp{Seconds: 200},
+		Properties: map[string]string{"process": "background scan"},
+	}
+	other := policyreportv1alpha2.PolicyReportResult{
+		Policy:    "require-labels",
+		Rule:      "check-team",
+		Message:   "label team must not be empty",
+		Result:    policyreportv1alpha2.StatusFail,
+		Resources: resources,
+		Timestamp: metav1.Timestamp{Seconds: 150},
+	}
+	deduped := DedupeResults([]policyreportv1alpha2.PolicyReportResult{background, other, admission})
+	assert.Len(t, deduped, 2)
+	assert.Equal(t, "Best Practices", deduped[0].Category)
+	assert.Equal(t, metav1.Timestamp{Seconds: 200}, deduped[0].Timestamp)
+	assert.Equal(t, map[string]string{"process": "background scan", "source": "admission"}, deduped[0].Properties)
+	assert.Equal(t, other, deduped[1])
+	// the merged results are not modified
+	assert.Equal(t, map[string]string{"process": "background scan"}, background.Properties)
+}
+
+func Test_ResultIdentity(t *testing.T) {
+	result := policyreportv1alpha2.PolicyReportResult{Policy: "p", Rule: "r", Message: "m", Resources: []corev1.ObjectReference{{UID: "uid-1"}}}
+	same := result
+	same.Source = "ValidatingAdmissionPolicy"
+	same.Timestamp = metav1.Timestamp{Seconds: 1}
+	assert.Equal(t, ResultIdentity(result), ResultIdentity(same))
+	otherResource := result
+	otherResource.Resources = []corev1.ObjectReference{{UID: "uid-2"}}
+	assert.NotEqual(t, ResultIdentity(result), ResultIdentity(otherResource))
+	otherMessage := result
+	otherMessage.Message = "n"
+	assert.NotEqual(t, ResultIdentity(result), ResultIdentity(otherMessage))
+}
+
+func Test_IsExcludedFromReports(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		rule        string
+		want        bool
+	}{
+		{name: "no annotation", annotations: nil, rule: "check-team", want: false},
+		{name: "whole policy", annotations: map[string]string{kyverno.AnnotationPolicyExcludeFromReports: "true"}, rule: "check-team", want: true},
+		{name: "listed rule", annotations: map[string]string{kyverno.AnnotationPolicyExcludeFromReports: "check-app, check-team"}, rule: "check-team", want: true},
+		{name: "other rule", annotations: map[string]string{kyverno.AnnotationPolicyExcludeFromReports: "check-app"}, rule: "check-team", want: false},
+		{name: "false", annotations: map[string]string{kyverno.AnnotationPolicyExcludeFromReports: "false"}, rule: "check-team", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsExcludedFromReports(tt.annotations, tt.rule))
+		})
+	}
+}
+
+func Test_Remediation(t *testing.T) {
+	annotations := map[string]string{
+		kyverno.AnnotationPolicyRemediation:      "Add the required labels.",
+		kyverno.AnnotationPolicyRuleRemediations: `{"check-team": "Set the team label to the owning team."}`,
+	}
+	assert.Equal(t, "Set the team label to the owning team.", Remediation(annotations, "check-team"))
+	assert.Equal(t, "Add the required labels.", Remediation(annotations, "check-app"))
+	assert.Equal(t, "", Remediation(nil, "check-app"))
+	// an invalid rule remediations annotation falls back to the policy remediation
+	annotations[kyverno.AnnotationPolicyRuleRemediations] = "not json"
+	assert.Equal(t, "Add the required labels.", Remediation(annotations, "check-team"))
+}
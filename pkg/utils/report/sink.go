@@ -0,0 +1,272 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/logging"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	resultSinkTimeout      = 30 * time.Second
+	resultSinkRetryBackoff = time.Second
+)
+
+var sinkLogger = logging.WithName("result-sink")
+
+// ResultEvent is a report result pushed to a sink, with the report and the resource it belongs to
+type ResultEvent struct {
+	// Namespace of the report, empty for cluster reports
+	Namespace string `json:"namespace,omitempty"`
+	// Report is the name of the report
+	Report string `json:"report"`
+	// Scope is the resource the report belongs to
+	Scope *corev1.ObjectReference `json:"scope,omitempty"`
+	// Result is the created or updated result
+	Result policyreportv1alpha2.PolicyReportResult `json:"result"`
+}
+
+// ResultSink receives the report results as they are created or updated
+type ResultSink interface {
+	// Send queues the given results, it never blocks and drops results when the sink is saturated
+	Send(events ...ResultEvent)
+	// Close flushes the queued results and stops the sink
+	Close()
+}
+
+// HTTPResultSinkOptions configures an http result sink
+type HTTPResultSinkOptions struct {
+	// URL receiving the results
+	URL string
+	// Token is sent as a bearer token when set
+	Token string
+	// CAFile is the CA bundle used to verify the server certificate, the system pool is used when empty
+	CAFile string
+	// CertFile and KeyFile are the client certificate and key used for mutual TLS
+	CertFile string
+	KeyFile  string
+	// Statuses filters the pushed results by status, all results are pushed when empty
+	Statuses []policyreportv1alpha2.PolicyResult
+	// BatchSize is the maximum number of results per request
+	BatchSize int
+	// BatchInterval is the maximum time a result is held before being sent
+	BatchInterval time.Duration
+	// MaxRetries is the number of retries of a failed batch before it is dropped
+	MaxRetries int
+}
+
+type httpResultSink struct {
+	client    *http.Client
+	url       string
+	token     string
+	statuses  sets.Set[policyreportv1alpha2.PolicyResult]
+	batchSize int
+	interval  time.Duration
+	retries   int
+	backoff   time.Duration
+	events    chan ResultEvent
+	stop      chan struct{}
+	stopOnce  sync.Once
+	done      chan struct{}
+}
+
+// NewHTTPResultSink returns a sink posting the results as JSON lines batches to an http endpoint,
+// batches are sent when they reach the batch size or the batch interval and are retried with an
+// exponential backoff on network errors, 429 and 5xx responses.
+func NewHTTPResultSink(options HTTPResultSinkOptions) (ResultSink, error) {
+	u, err := url.Parse(options.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid result sink url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid result sink url scheme %s, must be http or https", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, errors.New("invalid result sink url, missing host")
+	}
+	for _, status := range options.Statuses {
+		switch status {
+		case policyreportv1alpha2.StatusPass, policyreportv1alpha2.StatusFail, policyreportv1alpha2.StatusWarn, policyreportv1alpha2.StatusError, policyreportv1alpha2.StatusSkip:
+		default:
+			return nil, fmt.Errorf("invalid result sink status %s", status)
+		}
+	}
+	if options.BatchSize <= 0 {
+		return nil, errors.New("invalid result sink batch size, must be positive")
+	}
+	if options.BatchInterval <= 0 {
+		return nil, errors.New("invalid result sink batch interval, must be positive")
+	}
+	tlsConfig, err := resultSinkTLSConfig(options.CAFile, options.CertFile, options.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	s := &httpResultSink{
+		client: &http.Client{
+			Timeout:   resultSinkTimeout,
+			Transport: &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: tlsConfig},
+		},
+		url:       u.String(),
+		token:     options.Token,
+		statuses:  sets.New(options.Statuses...),
+		batchSize: options.BatchSize,
+		interval:  options.BatchInterval,
+		retries:   options.MaxRetries,
+		backoff:   resultSinkRetryBackoff,
+		events:    make(chan ResultEvent, 10*options.BatchSize),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	go s.run()
+	return s, nil
+}
+
+func resultSinkTLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		data, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read result sink CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, errors.New("failed to parse result sink CA")
+		}
+		config.RootCAs = pool
+	}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load result sink client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+func (s *httpResultSink) Send(events ...ResultEvent) {
+	for _, event := range events {
+		if s.statuses.Len() != 0 && !s.statuses.Has(event.Result.Result) {
+			continue
+		}
+		select {
+		case s.events <- event:
+		default:
+			sinkLogger.Info("result sink is saturated, dropping result", "policy", event.Result.Policy, "rule", event.Result.Rule)
+		}
+	}
+}
+
+func (s *httpResultSink) Close() {
+	s.stopOnce.Do(func() { close(s.stop) })
+	<-s.done
+}
+
+// run accumulates the results and sends the batches, a single goroutine sends the batches so that
+// a slow receiver saturates the queue instead of piling up requests
+func (s *httpResultSink) run() {
+	defer close(s.done)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	batch := make([]ResultEvent, 0, s.batchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := s.send(batch); err != nil {
+			sinkLogger.Error(err, "failed to send results, dropping batch", "url", s.url, "results", len(batch))
+		}
+		batch = batch[:0]
+	}
+	for {
+		select {
+		case <-s.stop:
+			// drain what was queued before closing
+			for {
+				select {
+				case event := <-s.events:
+					batch = append(batch, event)
+					if len(batch) >= s.batchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		case event := <-s.events:
+			batch = append(batch, event)
+			if len(batch) >= s.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+func (s *httpResultSink) send(batch []ResultEvent) error {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, event := range batch {
+		if err := encoder.Encode(event); err != nil {
+			return err
+		}
+	}
+	backoff := s.backoff
+	for attempt := 0; ; attempt++ {
+		retry, err := s.post(body.Bytes())
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= s.retries {
+			return err
+		}
+		sinkLogger.V(3).Info("failed to send results, retrying", "error", err.Error(), "attempt", attempt+1)
+		select {
+		case <-time.After(backoff):
+		case <-s.stop:
+			// don't hold the shutdown with long backoffs, the remaining attempts are made right away
+		}
+		backoff *= 2
+	}
+}
+
+// post sends a batch and returns whether a failure can be retried
+func (s *httpResultSink) post(body []byte) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), resultSinkTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	request.Header.Set("Content-Type", "application/x-ndjson")
+	if s.token != "" {
+		request.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	response, err := s.client.Do(request)
+	if err != nil {
+		return true, err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+	if response.StatusCode/100 == 2 {
+		return false, nil
+	}
+	retry := response.StatusCode == http.StatusTooManyRequests || response.StatusCode/100 == 5
+	return retry, fmt.Errorf("unexpected status code %d", response.StatusCode)
+}
@@ -0,0 +1,127 @@
+package report
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/stretchr/testify/assert"
+)
+
+type testSinkServer struct {
+	lock     sync.Mutex
+	failures int
+	batches  [][]ResultEvent
+	headers  []http.Header
+}
+
+func (s *testSinkServer) handler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		if s.failures > 0 {
+			s.failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		var batch []ResultEvent
+		scanner := bufio.NewScanner(bytes.NewReader(body))
+		for scanner.Scan() {
+			var event ResultEvent
+			assert.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+			batch = append(batch, event)
+		}
+		s.batches = append(s.batches, batch)
+		s.headers = append(s.headers, r.Header.Clone())
+	})
+}
+
+func newTestResultSink(t *testing.T, url string, options HTTPResultSinkOptions) *httpResultSink {
+	options.URL = url
+	sink, err := NewHTTPResultSink(options)
+	assert.NoError(t, err)
+	s := sink.(*httpResultSink)
+	s.backoff = time.Millisecond
+	return s
+}
+
+func testEvent(rule string, result policyreportv1alpha2.PolicyResult) ResultEvent {
+	return ResultEvent{Namespace: "default", Report: "uid", Result: policyreportv1alpha2.PolicyReportResult{Policy: "pol", Rule: rule, Result: result}}
+}
+
+func TestHTTPResultSink(t *testing.T) {
+	server := &testSinkServer{}
+	httpServer := httptest.NewServer(server.handler(t))
+	defer httpServer.Close()
+	sink := newTestResultSink(t, httpServer.URL, HTTPResultSinkOptions{
+		Token:         "secret",
+		Statuses:      []policyreportv1alpha2.PolicyResult{policyreportv1alpha2.StatusFail},
+		BatchSize:     2,
+		BatchInterval: time.Hour,
+	})
+	sink.Send(testEvent("a", policyreportv1alpha2.StatusFail), testEvent("b", policyreportv1alpha2.StatusPass), testEvent("c", policyreportv1alpha2.StatusFail))
+	sink.Send(testEvent("d", policyreportv1alpha2.StatusFail))
+	sink.Close()
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	// passed results are filtered, the batch size is honored and the last batch is flushed on close
+	assert.Len(t, server.batches, 2)
+	assert.Equal(t, []string{"a", "c"}, []string{server.batches[0][0].Result.Rule, server.batches[0][1].Result.Rule})
+	assert.Equal(t, "d", server.batches[1][0].Result.Rule)
+	assert.Equal(t, "Bearer secret", server.headers[0].Get("Authorization"))
+	assert.Equal(t, "application/x-ndjson", server.headers[0].Get("Content-Type"))
+}
+
+func TestHTTPResultSink_retry(t *testing.T) {
+	server := &testSinkServer{failures: 2}
+	httpServer := httptest.NewServer(server.handler(t))
+	defer httpServer.Close()
+	sink := newTestResultSink(t, httpServer.URL, HTTPResultSinkOptions{BatchSize: 10, BatchInterval: time.Hour, MaxRetries: 3})
+	assert.NoError(t, sink.send([]ResultEvent{testEvent("a", policyreportv1alpha2.StatusFail)}))
+	assert.Len(t, server.batches, 1)
+	sink.Close()
+}
+
+func TestHTTPResultSink_retryExhausted(t *testing.T) {
+	server := &testSinkServer{failures: 5}
+	httpServer := httptest.NewServer(server.handler(t))
+	defer httpServer.Close()
+	sink := newTestResultSink(t, httpServer.URL, HTTPResultSinkOptions{BatchSize: 10, BatchInterval: time.Hour, MaxRetries: 1})
+	assert.Error(t, sink.send([]ResultEvent{testEvent("a", policyreportv1alpha2.StatusFail)}))
+	assert.Equal(t, 3, server.failures)
+	sink.Close()
+}
+
+func TestHTTPResultSink_untrustedServer(t *testing.T) {
+	server := &testSinkServer{}
+	httpServer := httptest.NewTLSServer(server.handler(t))
+	defer httpServer.Close()
+	// the server certificate is not trusted without the CA
+	sink := newTestResultSink(t, httpServer.URL, HTTPResultSinkOptions{BatchSize: 10, BatchInterval: time.Hour})
+	assert.Error(t, sink.send([]ResultEvent{testEvent("a", policyreportv1alpha2.StatusFail)}))
+	sink.Close()
+}
+
+func TestNewHTTPResultSink(t *testing.T) {
+	for name, options := range map[string]HTTPResultSinkOptions{
+		"url":            {URL: "localhost:8080", BatchSize: 1, BatchInterval: time.Second},
+		"host":           {URL: "https://", BatchSize: 1, BatchInterval: time.Second},
+		"status":         {URL: "https://localhost", BatchSize: 1, BatchInterval: time.Second, Statuses: []policyreportv1alpha2.PolicyResult{"failed"}},
+		"batch size":     {URL: "https://localhost", BatchInterval: time.Second},
+		"batch interval": {URL: "https://localhost", BatchSize: 1},
+		"ca":             {URL: "https://localhost", BatchSize: 1, BatchInterval: time.Second, CAFile: "/does/not/exist"},
+		"client cert":    {URL: "https://localhost", BatchSize: 1, BatchInterval: time.Second, CertFile: "/does/not/exist"},
+	} {
+		_, err := NewHTTPResultSink(options)
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,70 @@
+package report
+
+import (
+	"context"
+
+	reportsv1 "github.com/kyverno/kyverno/api/reports/v1"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// StorageCRD stores policy reports in the etcd backed custom resources
+	StorageCRD = "crd"
+	// StorageReportsServer stores policy reports in the reports-server aggregated API
+	StorageReportsServer = "reports-server"
+	// StorageHTTP stores policy reports in an external store exposing a REST API
+	StorageHTTP = "http"
+)
+
+// Store persists policy reports (PolicyReport and ClusterPolicyReport), ephemeral reports are short lived
+// and consumed through informers, they are always written with the kyverno client
+type Store interface {
+	// Get returns the policy report with the given namespace and name, or nil if it doesn't exist
+	Get(ctx context.Context, namespace, name string) (reportsv1.ReportInterface, error)
+	Create(ctx context.Context, report reportsv1.ReportInterface) (reportsv1.ReportInterface, error)
+	Update(ctx context.Context, report reportsv1.ReportInterface) (reportsv1.ReportInterface, error)
+	Delete(ctx context.Context, report reportsv1.ReportInterface) error
+}
+
+type clientStore struct {
+	client versioned.Interface
+}
+
+// NewClientStore returns a store writing reports through the Kubernetes API, it is used both for custom
+// resources and for the reports-server as they serve the same API groups, it also handles ephemeral reports
+func NewClientStore(client versioned.Interface) Store {
+	return &clientStore{
+		client: client,
+	}
+}
+
+func (s *clientStore) Get(ctx context.Context, namespace, name string) (reportsv1.ReportInterface, error) {
+	var report reportsv1.ReportInterface
+	var err error
+	if namespace == "" {
+		report, err = s.client.Wgpolicyk8sV1alpha2().ClusterPolicyReports().Get(ctx, name, metav1.GetOptions{})
+	} else {
+		report, err = s.client.Wgpolicyk8sV1alpha2().PolicyReports(namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return report, nil
+}
+
+func (s *clientStore) Create(ctx context.Context, report reportsv1.ReportInterface) (reportsv1.ReportInterface, error) {
+	return CreateReport(ctx, report, s.client)
+}
+
+func (s *clientStore) Update(ctx context.Context, report reportsv1.ReportInterface) (reportsv1.ReportInterface, error) {
+	return UpdateReport(ctx, report, s.client)
+}
+
+func (s *clientStore) Delete(ctx context.Context, report reportsv1.ReportInterface) error {
+	return DeleteReport(ctx, report, s.client)
+}
@@ -0,0 +1,224 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	reportsv1 "github.com/kyverno/kyverno/api/reports/v1"
+	"github.com/kyverno/kyverno/pkg/breaker"
+	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/metric"
+)
+
+const reportWriterTimeout = 10 * time.Second
+
+var writerLogger = logging.WithName("report-writer")
+
+// ReportWriter creates the admission reports
+type ReportWriter interface {
+	// Write creates the report, or queues it when writes are batched
+	Write(ctx context.Context, report reportsv1.ReportInterface) error
+	// Close flushes the queued reports and stops the writer
+	Close()
+}
+
+type reportWriter struct {
+	client  versioned.Interface
+	breaker breaker.Breaker
+}
+
+// NewReportWriter returns a writer creating every report right away, unless the breaker is open
+func NewReportWriter(client versioned.Interface, breaker breaker.Breaker) ReportWriter {
+	return &reportWriter{
+		client:  client,
+		breaker: breaker,
+	}
+}
+
+func (w *reportWriter) Write(ctx context.Context, report reportsv1.ReportInterface) error {
+	return w.breaker.Do(ctx, func(ctx context.Context) error {
+		_, err := CreateReport(ctx, report, w.client)
+		return err
+	})
+}
+
+func (w *reportWriter) Close() {}
+
+// BatchReportWriterOptions configures a batch report writer
+type BatchReportWriterOptions struct {
+	// BatchSize is the number of queued reports triggering a flush before the batch interval
+	BatchSize int
+	// BatchInterval is the maximum time a report is held before being created
+	BatchInterval time.Duration
+	// MaxQueued is the maximum number of queued reports, new reports are dropped when it is reached
+	MaxQueued int
+}
+
+type batchReportWriter struct {
+	client    versioned.Interface
+	breaker   breaker.Breaker
+	batchSize int
+	interval  time.Duration
+	maxQueued int
+	drops     sdkmetric.Int64Counter
+	lock      sync.Mutex
+	queue     []string
+	pending   map[string]reportsv1.ReportInterface
+	flush     chan struct{}
+	stop      chan struct{}
+	stopOnce  sync.Once
+	done      chan struct{}
+}
+
+// NewBatchReportWriter returns a writer queuing the reports and creating them in batches, reports queued
+// for the same resource within a batch are merged into a single report. The breaker is checked when a
+// report is queued so that an open breaker drops reports instead of growing the queue.
+func NewBatchReportWriter(client versioned.Interface, breaker breaker.Breaker, options BatchReportWriterOptions) (ReportWriter, error) {
+	if options.BatchSize <= 0 {
+		return nil, errors.New("invalid report writer batch size, must be positive")
+	}
+	if options.BatchInterval <= 0 {
+		return nil, errors.New("invalid report writer batch interval, must be positive")
+	}
+	if options.MaxQueued < options.BatchSize {
+		return nil, errors.New("invalid report writer max queued reports, must be greater than or equal to the batch size")
+	}
+	w := &batchReportWriter{
+		client:    client,
+		breaker:   breaker,
+		batchSize: options.BatchSize,
+		interval:  options.BatchInterval,
+		maxQueued: options.MaxQueued,
+		pending:   map[string]reportsv1.ReportInterface{},
+		flush:     make(chan struct{}, 1),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	depth, err := meter.Int64ObservableGauge(
+		"kyverno_admission_reports_queue_depth",
+		sdkmetric.WithDescription("can be used to track the number of admission reports waiting to be created"),
+	)
+	if err != nil {
+		writerLogger.Error(err, "Failed to create instrument, kyverno_admission_reports_queue_depth")
+	} else if _, err := meter.RegisterCallback(func(_ context.Context, observer sdkmetric.Observer) error {
+		observer.ObserveInt64(depth, int64(w.depth()))
+		return nil
+	}, depth); err != nil {
+		writerLogger.Error(err, "failed to register callback")
+	}
+	drops, err := meter.Int64Counter(
+		"kyverno_admission_reports_queue_drops",
+		sdkmetric.WithDescription("can be used to track the number of admission reports dropped because the queue was full"),
+	)
+	if err != nil {
+		writerLogger.Error(err, "Failed to create instrument, kyverno_admission_reports_queue_drops")
+	}
+	w.drops = drops
+	go w.run()
+	return w, nil
+}
+
+// batchKey identifies the report of a resource in the queue, reports of resources without uid are never merged
+func batchKey(report reportsv1.ReportInterface) string {
+	if uid := GetResourceUid(report); uid != "" {
+		return report.GetNamespace() + "/" + string(uid)
+	}
+	return report.GetNamespace() + "/" + report.GetGenerateName()
+}
+
+// mergeReports merges the results and labels of two reports of the same resource, the newest labels
+// and results win over the oldest ones
+func mergeReports(older, newer reportsv1.ReportInterface) reportsv1.ReportInterface {
+	for key, value := range older.GetLabels() {
+		if _, ok := newer.GetLabels()[key]; !ok {
+			controllerutils.SetLabel(newer, key, value)
+		}
+	}
+	SetResults(newer, append(older.GetResults(), newer.GetResults()...)...)
+	return newer
+}
+
+func (w *batchReportWriter) depth() int {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return len(w.queue)
+}
+
+func (w *batchReportWriter) Write(ctx context.Context, report reportsv1.ReportInterface) error {
+	return w.breaker.Do(ctx, func(ctx context.Context) error {
+		w.enqueue(ctx, report)
+		return nil
+	})
+}
+
+func (w *batchReportWriter) enqueue(ctx context.Context, report reportsv1.ReportInterface) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	key := batchKey(report)
+	if older, ok := w.pending[key]; ok {
+		w.pending[key] = mergeReports(older, report)
+		return
+	}
+	if len(w.queue) >= w.maxQueued {
+		if w.drops != nil {
+			w.drops.Add(ctx, 1)
+		}
+		writerLogger.V(2).Info("report writer queue is full, dropping report", "namespace", report.GetNamespace(), "uid", GetResourceUid(report))
+		return
+	}
+	w.queue = append(w.queue, key)
+	w.pending[key] = report
+	if len(w.queue) >= w.batchSize {
+		select {
+		case w.flush <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (w *batchReportWriter) Close() {
+	w.stopOnce.Do(func() { close(w.stop) })
+	<-w.done
+}
+
+// run creates the queued reports at every batch interval or when the batch size is reached, a single
+// goroutine creates the reports so that a slow API server fills the queue instead of piling up requests
+func (w *batchReportWriter) run() {
+	defer close(w.done)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			w.write()
+			return
+		case <-w.flush:
+			w.write()
+		case <-ticker.C:
+			w.write()
+		}
+	}
+}
+
+// write creates all the reports queued at the time it's called
+func (w *batchReportWriter) write() {
+	w.lock.Lock()
+	queue, pending := w.queue, w.pending
+	w.queue, w.pending = nil, map[string]reportsv1.ReportInterface{}
+	w.lock.Unlock()
+	for _, key := range queue {
+		report := pending[key]
+		ctx, cancel := context.WithTimeout(context.Background(), reportWriterTimeout)
+		if _, err := CreateReport(ctx, report, w.client); err != nil {
+			writerLogger.Error(err, "failed to create report", "namespace", report.GetNamespace(), "uid", GetResourceUid(report))
+		}
+		cancel()
+	}
+}
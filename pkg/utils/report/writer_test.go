@@ -0,0 +1,136 @@
+package report
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	reportsv1 "github.com/kyverno/kyverno/api/reports/v1"
+	"github.com/kyverno/kyverno/pkg/breaker"
+	versionedfake "github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func testAdmissionReport(name string, uid types.UID, policy string, rules ...string) reportsv1.ReportInterface {
+	report := &reportsv1.EphemeralReport{}
+	report.SetName(name)
+	report.SetNamespace("default")
+	SetResourceUid(report, uid)
+	controllerutils.SetLabel(report, "pol.kyverno.io/"+policy, "1")
+	var results []policyreportv1alpha2.PolicyReportResult
+	for _, rule := range rules {
+		results = append(results, policyreportv1alpha2.PolicyReportResult{
+			Policy:    policy,
+			Rule:      rule,
+			Result:    policyreportv1alpha2.StatusPass,
+			Timestamp: metav1.Timestamp{Seconds: 1},
+		})
+	}
+	SetResults(report, results...)
+	return report
+}
+
+func listEphemeralReports(t *testing.T, client *versionedfake.Clientset) []reportsv1.EphemeralReport {
+	list, err := client.ReportsV1().EphemeralReports("default").List(context.TODO(), metav1.ListOptions{})
+	assert.NoError(t, err)
+	return list.Items
+}
+
+func TestNewBatchReportWriter(t *testing.T) {
+	client := versionedfake.NewSimpleClientset()
+	reportsBreaker := breaker.NewBreaker("test", nil)
+	_, err := NewBatchReportWriter(client, reportsBreaker, BatchReportWriterOptions{BatchSize: 0, BatchInterval: time.Second, MaxQueued: 10})
+	assert.Error(t, err)
+	_, err = NewBatchReportWriter(client, reportsBreaker, BatchReportWriterOptions{BatchSize: 10, BatchInterval: 0, MaxQueued: 10})
+	assert.Error(t, err)
+	_, err = NewBatchReportWriter(client, reportsBreaker, BatchReportWriterOptions{BatchSize: 10, BatchInterval: time.Second, MaxQueued: 5})
+	assert.Error(t, err)
+}
+
+func TestBatchReportWriter(t *testing.T) {
+	client := versionedfake.NewSimpleClientset()
+	writer, err := NewBatchReportWriter(client, breaker.NewBreaker("test", nil), BatchReportWriterOptions{
+		BatchSize:     10,
+		BatchInterval: time.Hour,
+		MaxQueued:     10,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Write(context.TODO(), testAdmissionReport("first", "uid-1", "pol-a", "rule-1")))
+	assert.NoError(t, writer.Write(context.TODO(), testAdmissionReport("second", "uid-1", "pol-b", "rule-1", "rule-2")))
+	assert.NoError(t, writer.Write(context.TODO(), testAdmissionReport("third", "uid-2", "pol-a", "rule-1")))
+	assert.Equal(t, 2, writer.(*batchReportWriter).depth())
+	assert.Empty(t, listEphemeralReports(t, client))
+	writer.Close()
+	reports := listEphemeralReports(t, client)
+	assert.Len(t, reports, 2)
+	for _, report := range reports {
+		switch report.GetName() {
+		case "second":
+			assert.Len(t, report.GetResults(), 3)
+			assert.Equal(t, 3, report.Spec.Summary.Pass)
+			assert.Contains(t, report.GetLabels(), "pol.kyverno.io/pol-a")
+			assert.Contains(t, report.GetLabels(), "pol.kyverno.io/pol-b")
+		case "third":
+			assert.Len(t, report.GetResults(), 1)
+		default:
+			t.Errorf("unexpected report %s", report.GetName())
+		}
+	}
+}
+
+func TestBatchReportWriterFlush(t *testing.T) {
+	client := versionedfake.NewSimpleClientset()
+	writer, err := NewBatchReportWriter(client, breaker.NewBreaker("test", nil), BatchReportWriterOptions{
+		BatchSize:     2,
+		BatchInterval: time.Hour,
+		MaxQueued:     2,
+	})
+	assert.NoError(t, err)
+	defer writer.Close()
+	assert.NoError(t, writer.Write(context.TODO(), testAdmissionReport("first", "uid-1", "pol", "rule")))
+	assert.NoError(t, writer.Write(context.TODO(), testAdmissionReport("second", "uid-2", "pol", "rule")))
+	assert.Eventually(t, func() bool {
+		return len(listEphemeralReports(t, client)) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestBatchReportWriterBackpressure(t *testing.T) {
+	client := versionedfake.NewSimpleClientset()
+	open := false
+	writer, err := NewBatchReportWriter(client, breaker.NewBreaker("test", func(context.Context) bool { return open }), BatchReportWriterOptions{
+		BatchSize:     5,
+		BatchInterval: time.Hour,
+		MaxQueued:     5,
+	})
+	assert.NoError(t, err)
+	w := writer.(*batchReportWriter)
+	// keep the queue from being flushed when it's full
+	w.batchSize = 10
+	for _, uid := range []types.UID{"uid-1", "uid-2", "uid-3", "uid-4", "uid-5", "uid-6"} {
+		assert.NoError(t, writer.Write(context.TODO(), testAdmissionReport(string(uid), uid, "pol", "rule")))
+	}
+	assert.Equal(t, 5, w.depth())
+	// reports of queued resources are still merged when the queue is full
+	assert.NoError(t, writer.Write(context.TODO(), testAdmissionReport("uid-1", "uid-1", "pol", "other")))
+	assert.Equal(t, 5, w.depth())
+	open = true
+	w.lock.Lock()
+	w.queue, w.pending = nil, map[string]reportsv1.ReportInterface{}
+	w.lock.Unlock()
+	assert.NoError(t, writer.Write(context.TODO(), testAdmissionReport("uid-7", "uid-7", "pol", "rule")))
+	assert.Equal(t, 0, w.depth())
+	writer.Close()
+	assert.Empty(t, listEphemeralReports(t, client))
+}
+
+func TestReportWriter(t *testing.T) {
+	client := versionedfake.NewSimpleClientset()
+	writer := NewReportWriter(client, breaker.NewBreaker("test", nil))
+	assert.NoError(t, writer.Write(context.TODO(), testAdmissionReport("first", "uid-1", "pol", "rule")))
+	writer.Close()
+	assert.Len(t, listEphemeralReports(t, client), 1)
+}
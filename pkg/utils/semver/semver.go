@@ -0,0 +1,48 @@
+package semver
+
+import (
+	"strings"
+
+	"github.com/blang/semver/v4"
+)
+
+// Satisfies checks if a version satisfies a range like ">=1.25 <1.30 || 2.x".
+// Versions are parsed tolerantly, a leading "v" and missing minor or patch numbers are accepted.
+func Satisfies(version string, constraint string) (bool, error) {
+	v, err := semver.ParseTolerant(strings.TrimSpace(version))
+	if err != nil {
+		return false, err
+	}
+	normalized, err := normalizeRange(constraint)
+	if err != nil {
+		return false, err
+	}
+	r, err := semver.ParseRange(normalized)
+	if err != nil {
+		return false, err
+	}
+	return r(v), nil
+}
+
+// normalizeRange rewrites the versions of a range in their canonical form
+func normalizeRange(constraint string) (string, error) {
+	var ors []string
+	for _, or := range strings.Split(constraint, "||") {
+		var ands []string
+		for _, part := range strings.Fields(or) {
+			version := strings.TrimLeft(part, "<>=!")
+			op := part[:len(part)-len(version)]
+			// wildcards are expanded by the range parser
+			if !strings.ContainsAny(version, "xX*") {
+				v, err := semver.ParseTolerant(version)
+				if err != nil {
+					return "", err
+				}
+				version = v.String()
+			}
+			ands = append(ands, op+version)
+		}
+		ors = append(ors, strings.Join(ands, " "))
+	}
+	return strings.Join(ors, " || "), nil
+}
@@ -0,0 +1,68 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSatisfies(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    string
+		constraint string
+		want       bool
+		wantErr    bool
+	}{{
+		name:       "in range",
+		version:    "1.27.3",
+		constraint: ">=1.25 <1.30",
+		want:       true,
+	}, {
+		name:       "upper bound excluded",
+		version:    "v1.30.0",
+		constraint: ">=1.25 <1.30",
+		want:       false,
+	}, {
+		name:       "partial version",
+		version:    "v1.25",
+		constraint: ">=1.25.0",
+		want:       true,
+	}, {
+		name:       "or",
+		version:    "2.1.0",
+		constraint: "<1.0 || >=2.0",
+		want:       true,
+	}, {
+		name:       "wildcard",
+		version:    "1.4.2",
+		constraint: "1.x",
+		want:       true,
+	}, {
+		name:       "not equal",
+		version:    "1.2.3",
+		constraint: "!=1.2.3",
+		want:       false,
+	}, {
+		name:       "invalid version",
+		version:    "latest",
+		constraint: ">=1.0",
+		wantErr:    true,
+	}, {
+		name:       "invalid range",
+		version:    "1.0.0",
+		constraint: ">=one",
+		wantErr:    true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Satisfies(tt.version, tt.constraint)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
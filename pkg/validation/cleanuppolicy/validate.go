@@ -112,11 +112,15 @@ func canI(ctx context.Context, client dclient.Interface, kind, namespace, name,
 func validateVariables(logger logr.Logger, policy kyvernov2.CleanupPolicyInterface) error {
 	ctx := enginecontext.NewMockContext(allowedVariables)
 
-	c := policy.GetSpec().Conditions
-	conditionCopy := c.DeepCopy()
-	if _, err := variables.SubstituteAllInType(logger, ctx, conditionCopy); !variables.CheckNotFoundErr(err) {
+	spec := policy.GetSpec().DeepCopy()
+	if _, err := variables.SubstituteAllInType(logger, ctx, spec.Conditions); !variables.CheckNotFoundErr(err) {
 		return fmt.Errorf("variable substitution failed for policy %s: %s", policy.GetName(), err.Error())
 	}
+	for _, entry := range spec.Context {
+		if _, err := variables.SubstituteAllInType(logger, ctx, &entry); !variables.CheckNotFoundErr(err) {
+			return fmt.Errorf("variable substitution failed for context entry %s of policy %s: %s", entry.Name, policy.GetName(), err.Error())
+		}
+	}
 	return nil
 }
 
@@ -74,3 +74,17 @@ func Test_Validate(t *testing.T) {
 		})
 	}
 }
+
+func Test_ValidateCELConditions(t *testing.T) {
+	opts := ValidationOptions{Enabled: true, Namespace: "*"}
+	valid := []byte(`{"apiVersion":"kyverno.io/v2","kind":"PolicyException","metadata":{"name":"exception","namespace":"kyverno"},"spec":{"exceptions":[{"policyName":"enforce-label","ruleNames":["enforce-label"]}],"match":{"any":[{"resources":{"kinds":["Pod"]}}]},"celConditions":[{"name":"team","expression":"object.metadata.labels.team == 'infra'"}]}}`)
+	polex, err := admissionutils.UnmarshalPolicyException(valid)
+	assert.NilError(t, err)
+	_, err = Validate(context.Background(), logging.GlobalLogger(), polex, opts)
+	assert.NilError(t, err)
+	invalid := []byte(`{"apiVersion":"kyverno.io/v2","kind":"PolicyException","metadata":{"name":"exception","namespace":"kyverno"},"spec":{"exceptions":[{"policyName":"enforce-label","ruleNames":["enforce-label"]}],"match":{"any":[{"resources":{"kinds":["Pod"]}}]},"celConditions":[{"name":"team","expression":"object.metadata.labels.team =="}]}}`)
+	polex, err = admissionutils.UnmarshalPolicyException(invalid)
+	assert.NilError(t, err)
+	_, err = Validate(context.Background(), logging.GlobalLogger(), polex, opts)
+	assert.ErrorContains(t, err, "spec.celConditions")
+}
@@ -45,6 +45,7 @@ func resetMutableFields(rule kyvernov1.Rule) (*kyvernov1.Rule, *kyvernov1.Genera
 	generation.SetData(nil)
 	generation.ForEachGeneration = nil
 	generation.OrphanDownstreamOnPolicyDelete = true
+	generation.Orphan = nil
 	generation.GenerateExisting = nil
 
 	return new, generation
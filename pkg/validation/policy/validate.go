@@ -11,6 +11,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/aptible/supercronic/cronexpr"
 	"github.com/distribution/reference"
 	jsonpatch "github.com/evanphx/json-patch/v5"
 	"github.com/jmoiron/jsonq"
@@ -30,6 +31,7 @@ import (
 	celutils "github.com/kyverno/kyverno/pkg/utils/cel"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
 	vaputils "github.com/kyverno/kyverno/pkg/validatingadmissionpolicy"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
@@ -158,6 +160,23 @@ func Validate(policy, oldPolicy kyvernov1.PolicyInterface, client dclient.Interf
 		}
 	}
 
+	if err := policyutils.ValidateWebhookAnnotations(policy); err != nil {
+		return warnings, err
+	}
+
+	if value, ok := policy.GetAnnotations()[kyverno.AnnotationPolicyScanSchedule]; ok {
+		if _, err := cronexpr.Parse(value); err != nil {
+			return warnings, fmt.Errorf("invalid annotation %s: %w", kyverno.AnnotationPolicyScanSchedule, err)
+		}
+	}
+
+	if value, ok := policy.GetAnnotations()[kyverno.AnnotationPolicyRuleRemediations]; ok {
+		var remediations map[string]string
+		if err := json.Unmarshal([]byte(value), &remediations); err != nil {
+			return warnings, fmt.Errorf("invalid annotation %s, must be a JSON object of remediations by rule name: %w", kyverno.AnnotationPolicyRuleRemediations, err)
+		}
+	}
+
 	err := ValidateVariables(policy, background)
 	if err != nil {
 		return warnings, err
@@ -277,6 +296,10 @@ func Validate(policy, oldPolicy kyvernov1.PolicyInterface, client dclient.Interf
 		}
 	}
 
+	if err := validateRuleContext(kyvernov1.Rule{Context: spec.Variables}); err != nil {
+		return warnings, fmt.Errorf("path: spec.variables: %v", err)
+	}
+
 	for i, rule := range rules {
 		rulePath := rulesPath.Index(i)
 		if rule.Mutation != nil {
@@ -310,6 +333,22 @@ func Validate(policy, oldPolicy kyvernov1.PolicyInterface, client dclient.Interf
 			return warnings, fmt.Errorf("path: spec.rules[%d]: %v", i, err)
 		}
 
+		if len(rule.CELPreconditions) != 0 {
+			if _, err := celutils.CompileMatchConditions(rule.CELPreconditions); err != nil {
+				return warnings, fmt.Errorf("path: spec.rules[%d].celPreconditions: %v", i, err)
+			}
+		}
+
+		// rule context entries can reference the policy variables
+		contextEntries := append(append([]kyvernov1.ContextEntry{}, spec.Variables...), rule.Context...)
+		if _, err := enginecontext.ResolveLoadOrder(contextEntries); err != nil {
+			return warnings, fmt.Errorf("path: spec.rules[%d].context: %v", i, err)
+		}
+
+		if err := validateRuleOutputs(rule); err != nil {
+			return warnings, fmt.Errorf("path: spec.rules[%d]: %v", i, err)
+		}
+
 		if err := validateRuleImageExtractorsJMESPath(rule); err != nil {
 			return warnings, fmt.Errorf("path: spec.rules[%d]: %v", i, err)
 		}
@@ -571,6 +610,8 @@ func ValidateVariables(p kyvernov1.PolicyInterface, backgroundMode bool) error {
 
 // hasInvalidVariables - checks for unexpected variables in the policy
 func hasInvalidVariables(policy kyvernov1.PolicyInterface, background bool) error {
+	// outputs exported by the previous rules of the policy
+	var outputs []kyvernov1.RuleOutput
 	for _, r := range autogen.ComputeRules(policy, "") {
 		ruleCopy := r.DeepCopy()
 
@@ -592,6 +633,8 @@ func hasInvalidVariables(policy kyvernov1.PolicyInterface, background bool) erro
 			for i := range ruleCopy.Mutation.Targets {
 				withTargetOnly.Mutation.Targets[i].ResourceSpec = ruleCopy.Mutation.Targets[i].ResourceSpec
 				ctx := buildContext(withTargetOnly, background, false)
+				addContextVariables(policy.GetSpec().Variables, ctx)
+				addOutputVariables(outputs, ctx)
 				if _, err := variables.SubstituteAllInRule(logging.GlobalLogger(), ctx, *withTargetOnly); !variables.CheckNotFoundErr(err) {
 					return fmt.Errorf("invalid variables defined at mutate.targets[%d]: %s", i, err.Error())
 				}
@@ -599,9 +642,12 @@ func hasInvalidVariables(policy kyvernov1.PolicyInterface, background bool) erro
 		}
 
 		ctx := buildContext(ruleCopy, background, mutateTarget)
+		addContextVariables(policy.GetSpec().Variables, ctx)
+		addOutputVariables(outputs, ctx)
 		if _, err := variables.SubstituteAllInRule(logging.GlobalLogger(), ctx, *ruleCopy); !variables.CheckNotFoundErr(err) {
 			return fmt.Errorf("variable substitution failed for rule %s: %s", ruleCopy.Name, err.Error())
 		}
+		outputs = append(outputs, r.Outputs...)
 	}
 
 	return nil
@@ -798,7 +844,7 @@ func getAllowedVariables(background bool, target bool) *regexp.Regexp {
 
 func addContextVariables(entries []kyvernov1.ContextEntry, ctx *enginecontext.MockContext) {
 	for _, contextEntry := range entries {
-		if contextEntry.APICall != nil || contextEntry.GlobalReference != nil || contextEntry.ImageRegistry != nil || contextEntry.Variable != nil {
+		if contextEntry.APICall != nil || contextEntry.GlobalReference != nil || contextEntry.ImageRegistry != nil || contextEntry.Variable != nil || contextEntry.SecretStore != nil || contextEntry.GRPCCall != nil || contextEntry.ExternalData != nil {
 			ctx.AddVariable(contextEntry.Name + "*")
 		}
 
@@ -811,6 +857,12 @@ func addContextVariables(entries []kyvernov1.ContextEntry, ctx *enginecontext.Mo
 	}
 }
 
+func addOutputVariables(outputs []kyvernov1.RuleOutput, ctx *enginecontext.MockContext) {
+	for _, output := range outputs {
+		ctx.AddVariable("outputs." + output.Name + "*")
+	}
+}
+
 func addImageVerifyVariables(rule *kyvernov1.Rule, ctx *enginecontext.MockContext) {
 	if rule.HasValidateImageVerification() {
 		for _, verifyImage := range rule.VerifyImages {
@@ -1328,18 +1380,24 @@ func validateRuleContext(rule kyvernov1.Rule) error {
 		}
 
 		var err error
-		if entry.ConfigMap != nil && entry.APICall == nil && entry.GlobalReference == nil && entry.ImageRegistry == nil && entry.Variable == nil {
+		if entry.ConfigMap != nil && entry.APICall == nil && entry.GlobalReference == nil && entry.ImageRegistry == nil && entry.Variable == nil && entry.SecretStore == nil && entry.GRPCCall == nil && entry.ExternalData == nil {
 			err = validateConfigMap(entry)
-		} else if entry.ConfigMap == nil && entry.APICall != nil && entry.GlobalReference == nil && entry.ImageRegistry == nil && entry.Variable == nil {
+		} else if entry.ConfigMap == nil && entry.APICall != nil && entry.GlobalReference == nil && entry.ImageRegistry == nil && entry.Variable == nil && entry.SecretStore == nil && entry.GRPCCall == nil && entry.ExternalData == nil {
 			err = validateAPICall(entry)
-		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.GlobalReference != nil && entry.ImageRegistry == nil && entry.Variable == nil {
+		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.GlobalReference != nil && entry.ImageRegistry == nil && entry.Variable == nil && entry.SecretStore == nil && entry.GRPCCall == nil && entry.ExternalData == nil {
 			err = validateGlobalReference(entry)
-		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.GlobalReference == nil && entry.ImageRegistry != nil && entry.Variable == nil {
+		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.GlobalReference == nil && entry.ImageRegistry != nil && entry.Variable == nil && entry.SecretStore == nil && entry.GRPCCall == nil && entry.ExternalData == nil {
 			err = validateImageRegistry(entry)
-		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.GlobalReference == nil && entry.ImageRegistry == nil && entry.Variable != nil {
+		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.GlobalReference == nil && entry.ImageRegistry == nil && entry.Variable != nil && entry.SecretStore == nil && entry.GRPCCall == nil && entry.ExternalData == nil {
 			err = validateVariable(entry)
+		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.GlobalReference == nil && entry.ImageRegistry == nil && entry.Variable == nil && entry.SecretStore != nil && entry.GRPCCall == nil && entry.ExternalData == nil {
+			err = validateSecretStore(entry)
+		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.GlobalReference == nil && entry.ImageRegistry == nil && entry.Variable == nil && entry.SecretStore == nil && entry.GRPCCall != nil && entry.ExternalData == nil {
+			err = validateGRPCCall(entry)
+		} else if entry.ConfigMap == nil && entry.APICall == nil && entry.GlobalReference == nil && entry.ImageRegistry == nil && entry.Variable == nil && entry.SecretStore == nil && entry.GRPCCall == nil && entry.ExternalData != nil {
+			err = validateExternalData(entry)
 		} else {
-			return fmt.Errorf("exactly one of configMap or apiCall or imageRegistry or variable is required for context entries")
+			return fmt.Errorf("exactly one of configMap or apiCall or imageRegistry or variable or secretStore or grpcCall or externalData is required for context entries")
 		}
 
 		if err != nil {
@@ -1349,6 +1407,23 @@ func validateRuleContext(rule kyvernov1.Rule) error {
 	return nil
 }
 
+func validateRuleOutputs(rule kyvernov1.Rule) error {
+	names := sets.New[string]()
+	for _, output := range rule.Outputs {
+		if !bindingIdentifier.MatchString(output.Name) {
+			return fmt.Errorf("output name %s is invalid, it must be a single word", output.Name)
+		}
+		if names.Has(output.Name) {
+			return fmt.Errorf("output name %s must be unique within the rule", output.Name)
+		}
+		names.Insert(output.Name)
+		if output.JMESPath == "" {
+			return fmt.Errorf("a jmesPath is required for output %s", output.Name)
+		}
+	}
+	return nil
+}
+
 // validateRuleImageExtractorsJMESPath ensures that the rule does not
 // mutate image digests if it has an image extractor that uses a JMESPath.
 func validateRuleImageExtractorsJMESPath(rule kyvernov1.Rule) error {
@@ -1429,6 +1504,10 @@ func validateAPICall(entry kyvernov1.ContextEntry) error {
 		}
 	}
 
+	if entry.APICall.CacheTTL != nil && entry.APICall.CacheTTL.Duration < 0 {
+		return fmt.Errorf("cacheTTL must not be negative")
+	}
+
 	// If JMESPath contains variables, the validation will fail because it's not
 	// possible to infer which value will be inserted by the variable
 	// Skip validation if a variable is detected
@@ -1464,6 +1543,82 @@ func validateGlobalReference(entry kyvernov1.ContextEntry) error {
 	return nil
 }
 
+func validateSecretStore(entry kyvernov1.ContextEntry) error {
+	vault := entry.SecretStore.Vault
+	if vault == nil {
+		return fmt.Errorf("a vault is required for secretStore context entry")
+	}
+	if vault.Server == "" {
+		return fmt.Errorf("a server is required for secretStore context entry")
+	}
+	if vault.Path == "" {
+		return fmt.Errorf("a path is required for secretStore context entry")
+	}
+
+	// If JMESPath contains variables, the validation will fail because it's not
+	// possible to infer which value will be inserted by the variable
+	// Skip validation if a variable is detected
+
+	jmesPath := variables.ReplaceAllVars(entry.SecretStore.JMESPath, func(s string) string { return "kyvernojmespathvariable" })
+
+	if !strings.Contains(jmesPath, "kyvernojmespathvariable") && entry.SecretStore.JMESPath != "" {
+		if _, err := jmespath.NewParser().Parse(entry.SecretStore.JMESPath); err != nil {
+			return fmt.Errorf("failed to parse JMESPath %s: %v", entry.SecretStore.JMESPath, err)
+		}
+	}
+
+	return nil
+}
+
+func validateGRPCCall(entry kyvernov1.ContextEntry) error {
+	if entry.GRPCCall.Target == "" {
+		return fmt.Errorf("a target is required for grpcCall context entry")
+	}
+	if entry.GRPCCall.Method == "" {
+		return fmt.Errorf("a method is required for grpcCall context entry")
+	}
+	if entry.GRPCCall.Insecure && entry.GRPCCall.TLSSecret != nil {
+		return fmt.Errorf("a tlsSecret cannot be used for insecure grpcCall context entries")
+	}
+
+	// If JMESPath contains variables, the validation will fail because it's not
+	// possible to infer which value will be inserted by the variable
+	// Skip validation if a variable is detected
+
+	jmesPath := variables.ReplaceAllVars(entry.GRPCCall.JMESPath, func(s string) string { return "kyvernojmespathvariable" })
+
+	if !strings.Contains(jmesPath, "kyvernojmespathvariable") && entry.GRPCCall.JMESPath != "" {
+		if _, err := jmespath.NewParser().Parse(entry.GRPCCall.JMESPath); err != nil {
+			return fmt.Errorf("failed to parse JMESPath %s: %v", entry.GRPCCall.JMESPath, err)
+		}
+	}
+
+	return nil
+}
+
+func validateExternalData(entry kyvernov1.ContextEntry) error {
+	if entry.ExternalData.Provider == "" {
+		return fmt.Errorf("a provider is required for externalData context entry")
+	}
+	if len(entry.ExternalData.Keys) == 0 {
+		return fmt.Errorf("keys are required for externalData context entry")
+	}
+
+	// If JMESPath contains variables, the validation will fail because it's not
+	// possible to infer which value will be inserted by the variable
+	// Skip validation if a variable is detected
+
+	jmesPath := variables.ReplaceAllVars(entry.ExternalData.JMESPath, func(s string) string { return "kyvernojmespathvariable" })
+
+	if !strings.Contains(jmesPath, "kyvernojmespathvariable") && entry.ExternalData.JMESPath != "" {
+		if _, err := jmespath.NewParser().Parse(entry.ExternalData.JMESPath); err != nil {
+			return fmt.Errorf("failed to parse JMESPath %s: %v", entry.ExternalData.JMESPath, err)
+		}
+	}
+
+	return nil
+}
+
 func validateImageRegistry(entry kyvernov1.ContextEntry) error {
 	if entry.ImageRegistry.Reference == "" {
 		return fmt.Errorf("a ref is required for imageRegistry context entry")
@@ -3348,3 +3348,203 @@ func Test_isMapStringString(t *testing.T) {
 		})
 	}
 }
+
+func Test_Rule_Outputs_Variable_Substitution(t *testing.T) {
+	rawPolicy := []byte(`{
+  "apiVersion": "kyverno.io/v1",
+  "kind": "ClusterPolicy",
+  "metadata": {
+    "name": "rule-outputs"
+  },
+  "spec": {
+    "rules": [
+      {
+        "name": "export",
+        "match": {
+          "any": [{"resources": {"kinds": ["ConfigMap"]}}]
+        },
+        "context": [
+          {
+            "name": "namespaces",
+            "apiCall": {
+              "urlPath": "/api/v1/namespaces",
+              "jmesPath": "items[].metadata.name"
+            }
+          }
+        ],
+        "outputs": [
+          {
+            "name": "namespaces",
+            "jmesPath": "namespaces"
+          }
+        ],
+        "validate": {
+          "message": "not allowed",
+          "deny": {
+            "conditions": {
+              "all": [{"key": "{{ length(namespaces) }}", "operator": "Equals", "value": 0}]
+            }
+          }
+        }
+      },
+      {
+        "name": "import",
+        "match": {
+          "any": [{"resources": {"kinds": ["ConfigMap"]}}]
+        },
+        "validate": {
+          "message": "not allowed",
+          "deny": {
+            "conditions": {
+              "all": [{"key": "{{ request.object.metadata.name }}", "operator": "AnyIn", "value": "{{ outputs.namespaces }}"}]
+            }
+          }
+        }
+      }
+    ]
+  }
+}`)
+	var policy *kyverno.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	assert.Nil(t, err)
+	err = ValidateVariables(policy, false)
+	assert.Nil(t, err)
+
+	// outputs are only available to the subsequent rules
+	policy.Spec.Rules[0], policy.Spec.Rules[1] = policy.Spec.Rules[1], policy.Spec.Rules[0]
+	err = ValidateVariables(policy, false)
+	assert.NotNil(t, err)
+}
+
+func Test_Policy_Variables_Substitution(t *testing.T) {
+	rawPolicy := []byte(`{
+  "apiVersion": "kyverno.io/v1",
+  "kind": "ClusterPolicy",
+  "metadata": {
+    "name": "policy-variables"
+  },
+  "spec": {
+    "variables": [
+      {
+        "name": "allowedRegistries",
+        "configMap": {
+          "name": "registries",
+          "namespace": "kyverno"
+        }
+      }
+    ],
+    "rules": [
+      {
+        "name": "check-registry",
+        "match": {
+          "any": [{"resources": {"kinds": ["Pod"]}}]
+        },
+        "validate": {
+          "message": "registry not allowed",
+          "deny": {
+            "conditions": {
+              "all": [{"key": "{{ request.object.spec.containers[0].image }}", "operator": "AnyNotIn", "value": "{{ allowedRegistries.data.registries }}"}]
+            }
+          }
+        }
+      }
+    ]
+  }
+}`)
+	var policy *kyverno.ClusterPolicy
+	err := json.Unmarshal(rawPolicy, &policy)
+	assert.Nil(t, err)
+	err = ValidateVariables(policy, false)
+	assert.Nil(t, err)
+
+	policy.Spec.Variables = nil
+	err = ValidateVariables(policy, false)
+	assert.NotNil(t, err)
+}
+
+func Test_validateRuleOutputs(t *testing.T) {
+	tests := []struct {
+		name    string
+		outputs []kyverno.RuleOutput
+		wantErr bool
+	}{{
+		name: "valid",
+		outputs: []kyverno.RuleOutput{
+			{Name: "images", JMESPath: "images"},
+			{Name: "count", JMESPath: "length(images)"},
+		},
+	}, {
+		name:    "invalid name",
+		outputs: []kyverno.RuleOutput{{Name: "my.images", JMESPath: "images"}},
+		wantErr: true,
+	}, {
+		name: "duplicated name",
+		outputs: []kyverno.RuleOutput{
+			{Name: "images", JMESPath: "images"},
+			{Name: "images", JMESPath: "length(images)"},
+		},
+		wantErr: true,
+	}, {
+		name:    "missing jmesPath",
+		outputs: []kyverno.RuleOutput{{Name: "images"}},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRuleOutputs(kyverno.Rule{Name: "test", Outputs: tt.outputs})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_Validate_CELPreconditions(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		wantErr    bool
+	}{{
+		name:       "valid",
+		expression: "object.metadata.namespace != 'kube-system' && request.userInfo.username != ''",
+	}, {
+		name:       "syntax error",
+		expression: "object.metadata.namespace ==",
+		wantErr:    true,
+	}, {
+		name:       "undeclared variable",
+		expression: "params.namespace == 'default'",
+		wantErr:    true,
+	}, {
+		name:       "not a boolean",
+		expression: "object.metadata.name",
+		wantErr:    true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rawPolicy := []byte(fmt.Sprintf(`{
+				"apiVersion": "kyverno.io/v1",
+				"kind": "ClusterPolicy",
+				"metadata": {"name": "cel-preconditions"},
+				"spec": {
+					"rules": [{
+						"name": "require-team",
+						"match": {"any": [{"resources": {"kinds": ["ConfigMap"]}}]},
+						"celPreconditions": [{"name": "check", "expression": %q}],
+						"validate": {"message": "team label is required", "pattern": {"metadata": {"labels": {"team": "?*"}}}}
+					}]
+				}
+			}`, tt.expression))
+			var policy *kyverno.ClusterPolicy
+			assert.Nil(t, json.Unmarshal(rawPolicy, &policy))
+			_, err := Validate(policy, nil, nil, nil, true, "", "")
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "path: spec.rules[0].celPreconditions")
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
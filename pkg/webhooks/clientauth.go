@@ -0,0 +1,81 @@
+package webhooks
+
+import (
+	"bytes"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// ClientAuthentication holds the settings client certificates are verified against
+type ClientAuthentication struct {
+	// CABundle is the PEM encoded CA bundle client certificates must be signed by.
+	CABundle []byte
+	// AllowedNames are the common names accepted in client certificates, any name is accepted when empty.
+	AllowedNames []string
+}
+
+// clientVerifier verifies client certificates, the CA bundle is only parsed again when it changes
+type clientVerifier struct {
+	provider func() (ClientAuthentication, error)
+	lock     sync.Mutex
+	caPem    []byte
+	roots    *x509.CertPool
+}
+
+func newClientVerifier(provider func() (ClientAuthentication, error)) *clientVerifier {
+	return &clientVerifier{
+		provider: provider,
+	}
+}
+
+func (v *clientVerifier) verify(rawCerts [][]byte) error {
+	auth, err := v.provider()
+	if err != nil {
+		return err
+	}
+	roots, err := v.rootsFor(auth.CABundle)
+	if err != nil {
+		return err
+	}
+	certs := make([]*x509.Certificate, 0, len(rawCerts))
+	for _, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return err
+		}
+		certs = append(certs, cert)
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}); err != nil {
+		return err
+	}
+	if len(auth.AllowedNames) != 0 && !slices.Contains(auth.AllowedNames, certs[0].Subject.CommonName) {
+		return fmt.Errorf("client certificate common name %q is not allowed", certs[0].Subject.CommonName)
+	}
+	return nil
+}
+
+func (v *clientVerifier) rootsFor(caPem []byte) (*x509.CertPool, error) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	if v.roots != nil && bytes.Equal(v.caPem, caPem) {
+		return v.roots, nil
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caPem) {
+		return nil, errors.New("failed to parse client CA certificates")
+	}
+	v.caPem = caPem
+	v.roots = roots
+	return roots, nil
+}
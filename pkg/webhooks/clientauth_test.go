@@ -0,0 +1,94 @@
+package webhooks
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCertificate(t *testing.T, commonName string, isCA bool, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(raw)
+	assert.NoError(t, err)
+	return cert, key
+}
+
+func Test_clientVerifier(t *testing.T) {
+	ca, caKey := newTestCertificate(t, "front-proxy-ca", true, nil, nil)
+	other, otherKey := newTestCertificate(t, "other-ca", true, nil, nil)
+	client, _ := newTestCertificate(t, "front-proxy-client", false, ca, caKey)
+	untrusted, _ := newTestCertificate(t, "front-proxy-client", false, other, otherKey)
+	caPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw})
+	tests := []struct {
+		name         string
+		cert         *x509.Certificate
+		allowedNames []string
+		wantErr      bool
+	}{{
+		name: "any name allowed",
+		cert: client,
+	}, {
+		name:         "allowed name",
+		cert:         client,
+		allowedNames: []string{"front-proxy-client"},
+	}, {
+		name:         "name not allowed",
+		cert:         client,
+		allowedNames: []string{"aggregator"},
+		wantErr:      true,
+	}, {
+		name:    "untrusted certificate",
+		cert:    untrusted,
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := newClientVerifier(func() (ClientAuthentication, error) {
+				return ClientAuthentication{CABundle: caPem, AllowedNames: tt.allowedNames}, nil
+			})
+			err := verifier.verify([][]byte{tt.cert.Raw})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_clientVerifierCachesRoots(t *testing.T) {
+	ca, _ := newTestCertificate(t, "front-proxy-ca", true, nil, nil)
+	caPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw})
+	verifier := newClientVerifier(nil)
+	roots, err := verifier.rootsFor(caPem)
+	assert.NoError(t, err)
+	again, err := verifier.rootsFor(caPem)
+	assert.NoError(t, err)
+	assert.Same(t, roots, again)
+	_, err = verifier.rootsFor([]byte("invalid"))
+	assert.Error(t, err)
+}
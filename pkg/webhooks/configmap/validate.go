@@ -0,0 +1,37 @@
+package configmap
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/config"
+	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
+	"github.com/kyverno/kyverno/pkg/webhooks"
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type configHandlers struct{}
+
+func NewHandlers() webhooks.ConfigHandlers {
+	return &configHandlers{}
+}
+
+// Validate performs the validation check on the kyverno config map, other config maps are always allowed
+func (h *configHandlers) Validate(ctx context.Context, logger logr.Logger, request handlers.AdmissionRequest, startTime time.Time) handlers.AdmissionResponse {
+	if !config.IsKyvernoConfigMap(request.Namespace, request.Name) {
+		return admissionutils.ResponseSuccess(request.UID)
+	}
+	var cm corev1.ConfigMap
+	if err := json.Unmarshal(request.Object.Raw, &cm); err != nil {
+		logger.Error(err, "failed to unmarshal config map from admission request")
+		return admissionutils.Response(request.UID, err)
+	}
+	err := config.ValidateConfigMap(&cm)
+	if err != nil {
+		logger.Error(err, "config map validation errors")
+	}
+	return admissionutils.Response(request.UID, err)
+}
@@ -0,0 +1,114 @@
+package decisionlog
+
+import (
+	"encoding/json"
+	"time"
+
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Decision records the outcome of an admission review
+type Decision struct {
+	Timestamp   time.Time                   `json:"timestamp"`
+	Webhook     string                      `json:"webhook"`
+	UID         types.UID                   `json:"uid"`
+	Kind        metav1.GroupVersionKind     `json:"kind"`
+	Resource    metav1.GroupVersionResource `json:"resource"`
+	SubResource string                      `json:"subResource,omitempty"`
+	Namespace   string                      `json:"namespace,omitempty"`
+	Name        string                      `json:"name,omitempty"`
+	Operation   string                      `json:"operation"`
+	UserInfo    authenticationv1.UserInfo   `json:"userInfo"`
+	DryRun      bool                        `json:"dryRun,omitempty"`
+	Allowed     bool                        `json:"allowed"`
+	Message     string                      `json:"message,omitempty"`
+	Warnings    []string                    `json:"warnings,omitempty"`
+	Patches     json.RawMessage             `json:"patches,omitempty"`
+	Latency     metav1.Duration             `json:"latency"`
+	Policies    []PolicyDecision            `json:"policies,omitempty"`
+}
+
+// PolicyDecision records the results of a policy matching the admission review
+type PolicyDecision struct {
+	Name      string         `json:"name"`
+	Namespace string         `json:"namespace,omitempty"`
+	Action    string         `json:"validationFailureAction,omitempty"`
+	Rules     []RuleDecision `json:"rules,omitempty"`
+}
+
+// RuleDecision records the result of a rule
+type RuleDecision struct {
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	Status     string   `json:"status"`
+	Message    string   `json:"message,omitempty"`
+	Exceptions []string `json:"exceptions,omitempty"`
+}
+
+// NewDecision builds the decision of a webhook from the admission request, the response sent
+// to the API server and the engine responses of the policies processed by the webhook
+func NewDecision(
+	webhook string,
+	request handlers.AdmissionRequest,
+	response handlers.AdmissionResponse,
+	startTime time.Time,
+	engineResponses ...engineapi.EngineResponse,
+) Decision {
+	decision := Decision{
+		Timestamp:   startTime.UTC(),
+		Webhook:     webhook,
+		UID:         request.UID,
+		Kind:        request.Kind,
+		Resource:    request.Resource,
+		SubResource: request.SubResource,
+		Namespace:   request.Namespace,
+		Name:        request.Name,
+		Operation:   string(request.Operation),
+		UserInfo:    request.UserInfo,
+		DryRun:      request.DryRun != nil && *request.DryRun,
+		Allowed:     response.Allowed,
+		Warnings:    response.Warnings,
+		Latency:     metav1.Duration{Duration: time.Since(startTime)},
+	}
+	if response.Result != nil {
+		decision.Message = response.Result.Message
+	}
+	if len(response.Patch) != 0 {
+		// patches of secrets carry their data, values are redacted like in admission dumps
+		if patch, err := handlers.RedactPatch(request.Kind.Kind, response.Patch); err == nil {
+			decision.Patches = patch
+		}
+	}
+	for _, engineResponse := range engineResponses {
+		policy := engineResponse.Policy()
+		if policy == nil {
+			continue
+		}
+		policyDecision := PolicyDecision{
+			Name:      policy.GetName(),
+			Namespace: policy.GetNamespace(),
+		}
+		for i := range engineResponse.PolicyResponse.Rules {
+			rule := &engineResponse.PolicyResponse.Rules[i]
+			ruleDecision := RuleDecision{
+				Name:    rule.Name(),
+				Type:    string(rule.RuleType()),
+				Status:  string(rule.Status()),
+				Message: rule.Message(),
+			}
+			if rule.RuleType() == engineapi.Validation {
+				policyDecision.Action = string(engineResponse.GetValidationFailureAction())
+			}
+			for _, exception := range rule.Exceptions() {
+				ruleDecision.Exceptions = append(ruleDecision.Exceptions, exception.GetNamespace()+"/"+exception.GetName())
+			}
+			policyDecision.Rules = append(policyDecision.Rules, ruleDecision)
+		}
+		decision.Policies = append(decision.Policies, policyDecision)
+	}
+	return decision
+}
@@ -0,0 +1,104 @@
+package decisionlog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestNewDecision(t *testing.T) {
+	policy := engineapi.NewKyvernoPolicy(&kyvernov1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "require-labels",
+		},
+		Spec: kyvernov1.Spec{
+			ValidationFailureAction: kyvernov1.Enforce,
+		},
+	})
+	exception := kyvernov2.PolicyException{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "allow-team",
+			Namespace: "kyverno",
+		},
+	}
+	request := handlers.AdmissionRequest{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			UID:       "uid",
+			Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "Pod"},
+			Resource:  metav1.GroupVersionResource{Version: "v1", Resource: "pods"},
+			Namespace: "default",
+			Name:      "nginx",
+			Operation: admissionv1.Create,
+		},
+	}
+	engineResponses := []engineapi.EngineResponse{
+		engineapi.NewEngineResponse(unstructured.Unstructured{}, policy, nil).WithPolicyResponse(engineapi.PolicyResponse{
+			Rules: []engineapi.RuleResponse{
+				*engineapi.RuleFail("check-team", engineapi.Validation, "label team is required", nil),
+				*engineapi.RuleSkip("check-app", engineapi.Validation, "excluded", nil).WithExceptions([]kyvernov2.PolicyException{exception}),
+			},
+		}),
+		{},
+	}
+	response := admissionutils.Response(request.UID, errors.New("denied"), "warning")
+	decision := NewDecision("validate", request, response, time.Now(), engineResponses...)
+	assert.Equal(t, "validate", decision.Webhook)
+	assert.Equal(t, "nginx", decision.Name)
+	assert.Equal(t, "CREATE", decision.Operation)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, "denied", decision.Message)
+	assert.Equal(t, []string{"warning"}, decision.Warnings)
+	assert.Equal(t, []PolicyDecision{{
+		Name:   "require-labels",
+		Action: "Enforce",
+		Rules: []RuleDecision{{
+			Name:    "check-team",
+			Type:    "Validation",
+			Status:  "fail",
+			Message: "label team is required",
+		}, {
+			Name:       "check-app",
+			Type:       "Validation",
+			Status:     "skip",
+			Message:    "excluded",
+			Exceptions: []string{"kyverno/allow-team"},
+		}},
+	}}, decision.Policies)
+}
+
+type fakeSink struct {
+	payloads [][]byte
+}
+
+func (s *fakeSink) Write(_ context.Context, payload []byte) error {
+	s.payloads = append(s.payloads, payload)
+	return nil
+}
+
+func (s *fakeSink) Close() error {
+	return nil
+}
+
+func TestRecorder(t *testing.T) {
+	sink := &fakeSink{}
+	recorder := NewRecorder(logr.Discard(), sink)
+	recorder.Record(context.TODO(), Decision{UID: "uid", Webhook: "mutate", Allowed: true})
+	assert.Len(t, sink.payloads, 1)
+	var decision Decision
+	assert.NoError(t, json.Unmarshal(sink.payloads[0], &decision))
+	assert.Equal(t, "mutate", decision.Webhook)
+	assert.True(t, decision.Allowed)
+}
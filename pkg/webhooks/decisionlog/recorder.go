@@ -0,0 +1,44 @@
+package decisionlog
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/webhooks/dump"
+)
+
+// Recorder records admission decisions
+type Recorder interface {
+	// Record stores an admission decision
+	Record(ctx context.Context, decision Decision)
+}
+
+type recorder struct {
+	logger logr.Logger
+	sink   dump.Sink
+}
+
+// NewRecorder returns a recorder writing decisions as JSON documents to the given sink,
+// decisions are logged when the sink is nil
+func NewRecorder(logger logr.Logger, sink dump.Sink) Recorder {
+	return &recorder{
+		logger: logger,
+		sink:   sink,
+	}
+}
+
+func (r *recorder) Record(ctx context.Context, decision Decision) {
+	payload, err := json.Marshal(decision)
+	if err != nil {
+		r.logger.Error(err, "failed to marshal admission decision", "uid", decision.UID)
+		return
+	}
+	if r.sink == nil {
+		r.logger.Info("admission decision", "decision", json.RawMessage(payload))
+		return
+	}
+	if err := r.sink.Write(ctx, payload); err != nil {
+		r.logger.Error(err, "failed to write admission decision", "uid", decision.UID)
+	}
+}
@@ -0,0 +1,111 @@
+package dump
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const filePrefix = "admission-"
+
+type fileSink struct {
+	lock     sync.Mutex
+	dir      string
+	maxSize  int64
+	maxAge   time.Duration
+	maxFiles int
+	file     *os.File
+	size     int64
+	opened   time.Time
+	now      func() time.Time
+}
+
+// NewFileSink returns a sink writing dumps as JSON lines in rotated files of the given directory
+func NewFileSink(dir string, maxSize int64, maxAge time.Duration, maxFiles int) (Sink, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	return &fileSink{
+		dir:      dir,
+		maxSize:  maxSize,
+		maxAge:   maxAge,
+		maxFiles: maxFiles,
+		now:      time.Now,
+	}, nil
+}
+
+func (s *fileSink) Write(_ context.Context, payload []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	now := s.now()
+	if s.file != nil && (s.size+int64(len(payload))+1 > s.maxSize || now.Sub(s.opened) >= s.maxAge) {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	if s.file == nil {
+		if err := s.open(now); err != nil {
+			return err
+		}
+	}
+	n, err := s.file.Write(append(payload, '\n'))
+	s.size += int64(n)
+	return err
+}
+
+func (s *fileSink) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+func (s *fileSink) open(now time.Time) error {
+	// the nanoseconds keep names unique and sortable when rotating quickly
+	name := filepath.Join(s.dir, fmt.Sprintf("%s%s.jsonl", filePrefix, now.UTC().Format("20060102T150405.000000000")))
+	file, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return err
+	}
+	s.file, s.size, s.opened = file, 0, now
+	return s.prune()
+}
+
+func (s *fileSink) rotate() error {
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// prune removes the oldest dump files beyond maxFiles
+func (s *fileSink) prune() error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasPrefix(entry.Name(), filePrefix) {
+			files = append(files, entry.Name())
+		}
+	}
+	if len(files) <= s.maxFiles {
+		return nil
+	}
+	sort.Strings(files)
+	for _, name := range files[:len(files)-s.maxFiles] {
+		if err := os.Remove(filepath.Join(s.dir, name)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
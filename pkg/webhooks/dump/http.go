@@ -0,0 +1,114 @@
+package dump
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type httpSink struct {
+	lock     sync.Mutex
+	uploads  sync.WaitGroup
+	client   *http.Client
+	url      string
+	maxSize  int64
+	maxAge   time.Duration
+	buffer   bytes.Buffer
+	started  time.Time
+	now      func() time.Time
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewHTTPSink returns a sink posting dumps as JSON lines batches to the given url,
+// batches are sent when they reach maxSize or maxAge.
+func NewHTTPSink(url string, maxSize int64, maxAge time.Duration) (Sink, error) {
+	s := &httpSink{
+		client:  &http.Client{Timeout: 30 * time.Second},
+		url:     url,
+		maxSize: maxSize,
+		maxAge:  maxAge,
+		now:     time.Now,
+		stop:    make(chan struct{}),
+	}
+	go s.run(maxAge)
+	return s, nil
+}
+
+// run periodically flushes the batch so that dumps are not held when traffic is low
+func (s *httpSink) run(period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.lock.Lock()
+			if s.buffer.Len() != 0 && s.now().Sub(s.started) >= s.maxAge {
+				s.flush(context.Background())
+			}
+			s.lock.Unlock()
+		}
+	}
+}
+
+func (s *httpSink) Write(ctx context.Context, payload []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	now := s.now()
+	if s.buffer.Len() == 0 {
+		s.started = now
+	}
+	s.buffer.Write(payload)
+	s.buffer.WriteByte('\n')
+	if int64(s.buffer.Len()) >= s.maxSize || now.Sub(s.started) >= s.maxAge {
+		s.flush(context.WithoutCancel(ctx))
+	}
+	return nil
+}
+
+func (s *httpSink) Close() error {
+	s.stopOnce.Do(func() { close(s.stop) })
+	s.lock.Lock()
+	s.flush(context.Background())
+	s.lock.Unlock()
+	s.uploads.Wait()
+	return nil
+}
+
+// flush posts the current batch in the background, it must be called with the lock held
+func (s *httpSink) flush(ctx context.Context) {
+	if s.buffer.Len() == 0 {
+		return
+	}
+	body := bytes.Clone(s.buffer.Bytes())
+	s.buffer.Reset()
+	s.uploads.Add(1)
+	go func() {
+		defer s.uploads.Done()
+		if err := s.post(ctx, body); err != nil {
+			logger.Error(err, "failed to post payload dumps", "url", s.url)
+		}
+	}()
+}
+
+func (s *httpSink) post(ctx context.Context, body []byte) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/x-ndjson")
+	response, err := s.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status code %d", response.StatusCode)
+	}
+	return nil
+}
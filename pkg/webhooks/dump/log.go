@@ -0,0 +1,5 @@
+package dump
+
+import "github.com/kyverno/kyverno/pkg/logging"
+
+var logger = logging.WithName("dump")
@@ -0,0 +1,138 @@
+package dump
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+)
+
+type s3Sink struct {
+	lock        sync.Mutex
+	uploads     sync.WaitGroup
+	client      *http.Client
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+	endpoint    string
+	region      string
+	bucket      string
+	prefix      string
+	maxSize     int64
+	maxAge      time.Duration
+	buffer      bytes.Buffer
+	started     time.Time
+	now         func() time.Time
+}
+
+// NewS3Sink returns a sink uploading dumps as JSON lines objects to an S3 compatible storage,
+// objects are uploaded when the batch reaches maxSize or maxAge. Credentials are resolved with
+// the default AWS chain (environment, shared config, web identity, instance metadata).
+func NewS3Sink(ctx context.Context, endpoint, region, bucket, prefix string, maxSize int64, maxAge time.Duration) (Sink, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if region == "" {
+		region = cfg.Region
+	}
+	if region == "" {
+		region = "us-east-1"
+	}
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", region)
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	return &s3Sink{
+		client:      &http.Client{Timeout: 30 * time.Second},
+		credentials: cfg.Credentials,
+		signer:      v4.NewSigner(),
+		endpoint:    strings.TrimSuffix(endpoint, "/"),
+		region:      region,
+		bucket:      bucket,
+		prefix:      path.Join(strings.Trim(prefix, "/"), hostname),
+		maxSize:     maxSize,
+		maxAge:      maxAge,
+		now:         time.Now,
+	}, nil
+}
+
+func (s *s3Sink) Write(ctx context.Context, payload []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	now := s.now()
+	if s.buffer.Len() == 0 {
+		s.started = now
+	}
+	s.buffer.Write(payload)
+	s.buffer.WriteByte('\n')
+	if int64(s.buffer.Len()) >= s.maxSize || now.Sub(s.started) >= s.maxAge {
+		s.flush(context.WithoutCancel(ctx))
+	}
+	return nil
+}
+
+func (s *s3Sink) Close() error {
+	s.lock.Lock()
+	s.flush(context.Background())
+	s.lock.Unlock()
+	s.uploads.Wait()
+	return nil
+}
+
+// flush uploads the current batch in the background, it must be called with the lock held
+func (s *s3Sink) flush(ctx context.Context) {
+	if s.buffer.Len() == 0 {
+		return
+	}
+	body := bytes.Clone(s.buffer.Bytes())
+	key := path.Join(s.prefix, fmt.Sprintf("%s%s.jsonl", filePrefix, s.started.UTC().Format("20060102T150405.000000000")))
+	s.buffer.Reset()
+	s.uploads.Add(1)
+	go func() {
+		defer s.uploads.Done()
+		if err := s.upload(ctx, key, body); err != nil {
+			logger.Error(err, "failed to upload payload dumps", "bucket", s.bucket, "key", key)
+		}
+	}()
+}
+
+func (s *s3Sink) upload(ctx context.Context, key string, body []byte) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, fmt.Sprintf("%s/%s/%s", s.endpoint, s.bucket, key), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	hash := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(hash[:])
+	request.Header.Set("Content-Type", "application/x-ndjson")
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	credentials, err := s.credentials.Retrieve(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.signer.SignHTTP(ctx, credentials, request, payloadHash, "s3", s.region, s.now()); err != nil {
+		return err
+	}
+	response, err := s.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status code %d", response.StatusCode)
+	}
+	return nil
+}
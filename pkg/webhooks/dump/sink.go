@@ -0,0 +1,126 @@
+package dump
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	defaultMaxSize  = 10 * 1024 * 1024
+	defaultMaxAge   = time.Hour
+	defaultMaxFiles = 10
+	// http batches are sent more often, receivers usually expect near real time data
+	defaultHTTPMaxAge = 10 * time.Second
+)
+
+// Sink receives admission payload dumps, one JSON document per call
+type Sink interface {
+	// Write stores a payload dump
+	Write(ctx context.Context, payload []byte) error
+	// Close flushes buffered dumps and releases resources
+	Close() error
+}
+
+// NewSink creates a sink from its URL, an empty spec or "log" returns a nil sink meaning dumps are logged.
+// Supported sinks are:
+//   - file:///path/to/dir?maxSize=10Mi&maxAge=1h&maxFiles=10
+//   - s3://bucket/prefix?endpoint=https://minio:9000&region=us-east-1&maxSize=10Mi&maxAge=1h
+//   - https://collector:8080/path?maxSize=1Mi&maxAge=10s
+//
+// Files, objects and http batches are rotated when they reach maxSize or maxAge, only the last maxFiles files are kept.
+func NewSink(ctx context.Context, spec string) (Sink, error) {
+	if spec == "" || spec == "log" {
+		return nil, nil
+	}
+	u, err := url.Parse(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sink %q: %w", spec, err)
+	}
+	query := u.Query()
+	maxSize, err := parseSize(query.Get("maxSize"))
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "file":
+		maxAge, err := parseDuration(query.Get("maxAge"), defaultMaxAge)
+		if err != nil {
+			return nil, err
+		}
+		maxFiles, err := parseCount(query.Get("maxFiles"))
+		if err != nil {
+			return nil, err
+		}
+		if u.Path == "" {
+			return nil, fmt.Errorf("invalid sink %q: directory is required", spec)
+		}
+		return NewFileSink(u.Path, maxSize, maxAge, maxFiles)
+	case "s3":
+		if u.Host == "" {
+			return nil, fmt.Errorf("invalid sink %q: bucket is required", spec)
+		}
+		maxAge, err := parseDuration(query.Get("maxAge"), defaultMaxAge)
+		if err != nil {
+			return nil, err
+		}
+		return NewS3Sink(ctx, query.Get("endpoint"), query.Get("region"), u.Host, u.Path, maxSize, maxAge)
+	case "http", "https":
+		maxAge, err := parseDuration(query.Get("maxAge"), defaultHTTPMaxAge)
+		if err != nil {
+			return nil, err
+		}
+		query.Del("maxSize")
+		query.Del("maxAge")
+		u.RawQuery = query.Encode()
+		return NewHTTPSink(u.String(), maxSize, maxAge)
+	default:
+		return nil, fmt.Errorf("invalid sink %q: unsupported scheme %q", spec, u.Scheme)
+	}
+}
+
+func parseSize(value string) (int64, error) {
+	if value == "" {
+		return defaultMaxSize, nil
+	}
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid maxSize %q: %w", value, err)
+	}
+	if quantity.Value() <= 0 {
+		return 0, fmt.Errorf("invalid maxSize %q: must be positive", value)
+	}
+	return quantity.Value(), nil
+}
+
+func parseDuration(value string, defaultValue time.Duration) (time.Duration, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid maxAge %q: %w", value, err)
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("invalid maxAge %q: must be positive", value)
+	}
+	return duration, nil
+}
+
+func parseCount(value string) (int, error) {
+	if value == "" {
+		return defaultMaxFiles, nil
+	}
+	count, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid maxFiles %q: %w", value, err)
+	}
+	if count <= 0 {
+		return 0, fmt.Errorf("invalid maxFiles %q: must be positive", value)
+	}
+	return count, nil
+}
@@ -0,0 +1,132 @@
+package dump
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSink(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		spec    string
+		wantNil bool
+		wantErr bool
+	}{{
+		name:    "empty",
+		spec:    "",
+		wantNil: true,
+	}, {
+		name:    "log",
+		spec:    "log",
+		wantNil: true,
+	}, {
+		name: "file",
+		spec: "file://" + dir + "?maxSize=1Mi&maxAge=10m&maxFiles=3",
+	}, {
+		name:    "file without directory",
+		spec:    "file://",
+		wantErr: true,
+	}, {
+		name:    "invalid size",
+		spec:    "file://" + dir + "?maxSize=abc",
+		wantErr: true,
+	}, {
+		name:    "invalid age",
+		spec:    "file://" + dir + "?maxAge=-1h",
+		wantErr: true,
+	}, {
+		name:    "invalid files",
+		spec:    "file://" + dir + "?maxFiles=0",
+		wantErr: true,
+	}, {
+		name:    "s3 without bucket",
+		spec:    "s3:///prefix",
+		wantErr: true,
+	}, {
+		name:    "unsupported scheme",
+		spec:    "ftp://localhost/dumps",
+		wantErr: true,
+	}, {
+		name: "http",
+		spec: "http://localhost/dumps?maxSize=1Mi&maxAge=5s",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSink(context.TODO(), tt.spec)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantNil, got == nil)
+			if got != nil {
+				assert.NoError(t, got.Close())
+			}
+		})
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	dir := t.TempDir()
+	sink, err := NewFileSink(dir, 16, time.Hour, 2)
+	assert.NoError(t, err)
+	fs := sink.(*fileSink)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fs.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+	// each payload fills a file, rotation happens on every write
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, sink.Write(context.TODO(), []byte(`{"uid":"0123456789"}`)))
+	}
+	assert.NoError(t, sink.Close())
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	content, err := os.ReadFile(dir + "/" + entries[1].Name())
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"uid\":\"0123456789\"}\n", string(content))
+}
+
+func TestFileSinkMaxAge(t *testing.T) {
+	dir := t.TempDir()
+	sink, err := NewFileSink(dir, 1024, time.Minute, 10)
+	assert.NoError(t, err)
+	fs := sink.(*fileSink)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fs.now = func() time.Time { return now }
+	assert.NoError(t, sink.Write(context.TODO(), []byte(`{}`)))
+	assert.NoError(t, sink.Write(context.TODO(), []byte(`{}`)))
+	now = now.Add(2 * time.Minute)
+	assert.NoError(t, sink.Write(context.TODO(), []byte(`{}`)))
+	assert.NoError(t, sink.Close())
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestHTTPSink(t *testing.T) {
+	bodies := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+		bodies <- string(body)
+	}))
+	defer server.Close()
+	sink, err := NewSink(context.TODO(), server.URL+"/decisions?maxSize=32&maxAge=1h")
+	assert.NoError(t, err)
+	assert.NoError(t, sink.Write(context.TODO(), []byte(`{"uid":"1"}`)))
+	assert.NoError(t, sink.Write(context.TODO(), []byte(`{"uid":"2"}`)))
+	assert.NoError(t, sink.Write(context.TODO(), []byte(`{"uid":"3"}`)))
+	assert.NoError(t, sink.Close())
+	assert.Equal(t, "{\"uid\":\"1\"}\n{\"uid\":\"2\"}\n{\"uid\":\"3\"}\n", <-bodies)
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+)
+
+// AuditEventsHandler processes the audit events received from the API server
+type AuditEventsHandler func(context.Context, ...auditv1.Event)
+
+// AuditEvents returns a handler accepting the audit event lists posted by the API server audit webhook backend,
+// the events are passed to the inner handler and the request is acknowledged without waiting for their processing
+func AuditEvents(logger logr.Logger, authenticate Authenticator, inner AuditEventsHandler) HttpHandler {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		ctx := request.Context()
+		if _, err := authenticate(ctx, request); err != nil {
+			code := http.StatusInternalServerError
+			if errors.Is(err, ErrUnauthenticated) {
+				code = http.StatusUnauthorized
+			} else if errors.Is(err, ErrForbidden) {
+				code = http.StatusForbidden
+			}
+			HttpError(ctx, writer, request, logger, err, code)
+			return
+		}
+		if request.Body == nil {
+			HttpError(ctx, writer, request, logger, errors.New("empty body"), http.StatusBadRequest)
+			return
+		}
+		defer request.Body.Close()
+		body, err := io.ReadAll(request.Body)
+		if err != nil {
+			HttpError(ctx, writer, request, logger, err, http.StatusBadRequest)
+			return
+		}
+		if contentType := request.Header.Get("Content-Type"); contentType != "application/json" {
+			HttpError(ctx, writer, request, logger, errors.New("invalid Content-Type"), http.StatusUnsupportedMediaType)
+			return
+		}
+		var events auditv1.EventList
+		if err := json.Unmarshal(body, &events); err != nil {
+			HttpError(ctx, writer, request, logger, err, http.StatusBadRequest)
+			return
+		}
+		if gvk := events.GroupVersionKind(); gvk != auditv1.SchemeGroupVersion.WithKind("EventList") {
+			HttpError(ctx, writer, request, logger, fmt.Errorf("unexpected kind %s", gvk), http.StatusBadRequest)
+			return
+		}
+		inner(ctx, events.Items...)
+		writer.WriteHeader(http.StatusOK)
+	}
+}
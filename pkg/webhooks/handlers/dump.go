@@ -2,46 +2,128 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
+	"hash/fnv"
 	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	kubeutils "github.com/kyverno/kyverno/pkg/utils/kube"
+	"github.com/kyverno/kyverno/pkg/webhooks/dump"
 	authenticationv1 "k8s.io/api/authentication/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 )
 
+// DumpOptions configures the dump of admission payloads
+type DumpOptions struct {
+	// Enabled returns true when the dump of admission payloads is active, it is checked for every request
+	Enabled func() bool
+	// Sink receives the dumps, they are logged when nil
+	Sink dump.Sink
+	// SampleRate is the ratio of requests dumped, between 0 and 1
+	SampleRate float64
+	// RedactFields are dot separated paths of the dump fields replaced before writing, e.g. request.object.data
+	RedactFields []string
+}
+
 func (inner AdmissionHandler) WithDump(
-	enabled bool,
+	opts DumpOptions,
 ) AdmissionHandler {
-	if !enabled {
+	if opts.Enabled == nil {
 		return inner
 	}
-	return inner.withDump().WithTrace("DUMP")
+	return inner.withDump(opts).WithTrace("DUMP")
 }
 
-func (inner AdmissionHandler) withDump() AdmissionHandler {
+func (inner AdmissionHandler) withDump(opts DumpOptions) AdmissionHandler {
 	return func(ctx context.Context, logger logr.Logger, request AdmissionRequest, startTime time.Time) AdmissionResponse {
 		response := inner(ctx, logger, request, startTime)
-		dumpPayload(logger, request, response)
+		if opts.Enabled() && sampled(request.UID, opts.SampleRate) {
+			dumpPayload(ctx, logger, opts, request, response)
+		}
 		return response
 	}
 }
 
 func dumpPayload(
+	ctx context.Context,
 	logger logr.Logger,
+	opts DumpOptions,
 	request AdmissionRequest,
 	response AdmissionResponse,
 ) {
 	reqPayload, err := newAdmissionRequestPayload(request)
 	if err != nil {
 		logger.Error(err, "Failed to extract resources")
-	} else {
+		return
+	}
+	if opts.Sink == nil && len(opts.RedactFields) == 0 {
 		logger = logger.WithValues("admission.response", response, "admission.request", reqPayload)
 		logger.Info("admission request dump")
+		return
+	}
+	payload, err := newDumpPayload(reqPayload, response, opts.RedactFields)
+	if err != nil {
+		logger.Error(err, "Failed to build dump")
+		return
+	}
+	if opts.Sink == nil {
+		logger.Info("admission request dump", "admission.dump", json.RawMessage(payload))
+		return
+	}
+	if err := opts.Sink.Write(ctx, payload); err != nil {
+		logger.Error(err, "Failed to write dump")
+	}
+}
+
+// sampled decides if a request is dumped, the decision only depends on the request uid
+// so that all the webhooks called for the same request make the same decision
+func sampled(uid types.UID, rate float64) bool {
+	if rate >= 1 {
+		return true
+	}
+	if rate <= 0 {
+		return false
+	}
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(uid))
+	return float64(hash.Sum64()%10000) < rate*10000
+}
+
+// newDumpPayload serializes the request and response, redacting the given fields
+func newDumpPayload(request *admissionRequestPayload, response AdmissionResponse, redactFields []string) ([]byte, error) {
+	raw, err := json.Marshal(map[string]interface{}{
+		"timestamp": time.Now().UTC().Format(time.RFC3339Nano),
+		"request":   request,
+		"response":  response,
+	})
+	if err != nil || len(redactFields) == 0 {
+		return raw, err
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, err
+	}
+	for _, field := range redactFields {
+		redactField(payload, strings.Split(field, "."))
+	}
+	return json.Marshal(payload)
+}
+
+func redactField(object map[string]interface{}, path []string) {
+	value, ok := object[path[0]]
+	if !ok {
+		return
+	}
+	if len(path) == 1 {
+		object[path[0]] = "**REDACTED**"
+		return
+	}
+	if child, ok := value.(map[string]interface{}); ok {
+		redactField(child, path[1:])
 	}
 }
 
@@ -120,3 +202,20 @@ func redactPayload(payload *admissionRequestPayload) (*admissionRequestPayload,
 	}
 	return payload, nil
 }
+
+// RedactPatch replaces the values of a JSON patch applied to a Secret, operations and paths are kept
+func RedactPatch(kind string, patch []byte) ([]byte, error) {
+	if len(patch) == 0 || !strings.EqualFold(kind, "Secret") {
+		return patch, nil
+	}
+	var operations []map[string]interface{}
+	if err := json.Unmarshal(patch, &operations); err != nil {
+		return nil, err
+	}
+	for _, operation := range operations {
+		if _, ok := operation["value"]; ok {
+			operation["value"] = "**REDACTED**"
+		}
+	}
+	return json.Marshal(operations)
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
@@ -174,3 +175,66 @@ func Test_RedactPayload(t *testing.T) {
 		})
 	}
 }
+
+func Test_Sampled(t *testing.T) {
+	assert.Equal(t, sampled("631a230b-b949-468d-b9ae-927fdd76217e", 1), true)
+	assert.Equal(t, sampled("631a230b-b949-468d-b9ae-927fdd76217e", 0), false)
+	assert.Equal(t, sampled("631a230b-b949-468d-b9ae-927fdd76217e", 0.5), sampled("631a230b-b949-468d-b9ae-927fdd76217e", 0.5))
+}
+
+func Test_RedactField(t *testing.T) {
+	tc := []struct {
+		name     string
+		path     string
+		expected map[string]interface{}
+	}{
+		{
+			name: "nested field",
+			path: "request.object.data",
+			expected: map[string]interface{}{
+				"request": map[string]interface{}{
+					"object": map[string]interface{}{"data": "**REDACTED**", "kind": "ConfigMap"},
+				},
+			},
+		},
+		{
+			name: "missing field",
+			path: "request.oldObject.data",
+			expected: map[string]interface{}{
+				"request": map[string]interface{}{
+					"object": map[string]interface{}{"data": map[string]interface{}{"key": "value"}, "kind": "ConfigMap"},
+				},
+			},
+		},
+		{
+			name: "field on a scalar",
+			path: "request.object.kind.name",
+			expected: map[string]interface{}{
+				"request": map[string]interface{}{
+					"object": map[string]interface{}{"data": map[string]interface{}{"key": "value"}, "kind": "ConfigMap"},
+				},
+			},
+		},
+	}
+	for _, test := range tc {
+		t.Run(test.name, func(t *testing.T) {
+			payload := map[string]interface{}{
+				"request": map[string]interface{}{
+					"object": map[string]interface{}{"data": map[string]interface{}{"key": "value"}, "kind": "ConfigMap"},
+				},
+			}
+			redactField(payload, strings.Split(test.path, "."))
+			assert.DeepEqual(t, payload, test.expected)
+		})
+	}
+}
+
+func Test_RedactPatch(t *testing.T) {
+	patch := []byte(`[{"op":"add","path":"/data/password","value":"c2VjcmV0"},{"op":"remove","path":"/data/token"}]`)
+	redacted, err := RedactPatch("Secret", patch)
+	assert.NilError(t, err)
+	assert.Equal(t, string(redacted), `[{"op":"add","path":"/data/password","value":"**REDACTED**"},{"op":"remove","path":"/data/token"}]`)
+	unchanged, err := RedactPatch("ConfigMap", patch)
+	assert.NilError(t, err)
+	assert.Equal(t, string(unchanged), string(patch))
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	policyreportv1alpha2 "github.com/kyverno/kyverno/api/policyreport/v1alpha2"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"github.com/kyverno/kyverno/pkg/userinfo"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	rbacv1listers "k8s.io/client-go/listers/rbac/v1"
+	"k8s.io/utils/ptr"
+)
+
+var (
+	// ErrUnauthenticated is returned by an Authenticator when the caller credentials are missing or invalid
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrForbidden is returned by an Authenticator when the caller is not allowed to use the endpoint
+	ErrForbidden = errors.New("forbidden")
+)
+
+// Authenticator identifies the caller of a request
+type Authenticator func(context.Context, *http.Request) (authenticationv1.UserInfo, error)
+
+// Evaluation is the result of evaluating the policies matching an admission request without side effects
+type Evaluation struct {
+	// Response is the admission response the webhooks would have returned.
+	Response AdmissionResponse `json:"response"`
+	// Results are the rule results of the evaluated policies.
+	Results []policyreportv1alpha2.PolicyReportResult `json:"results,omitempty"`
+}
+
+// EvaluationHandler evaluates the policies matching an admission request
+type EvaluationHandler func(context.Context, logr.Logger, AdmissionRequest) Evaluation
+
+// Evaluate returns a handler accepting an AdmissionReview or a raw object, raw objects are evaluated as a CREATE
+// request of the authenticated caller and the evaluation is returned as JSON
+func Evaluate(
+	logger logr.Logger,
+	authenticate Authenticator,
+	discovery dclient.IDiscovery,
+	rbLister rbacv1listers.RoleBindingLister,
+	crbLister rbacv1listers.ClusterRoleBindingLister,
+	inner EvaluationHandler,
+) HttpHandler {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		ctx := request.Context()
+		user, err := authenticate(ctx, request)
+		if err != nil {
+			code := http.StatusInternalServerError
+			if errors.Is(err, ErrUnauthenticated) {
+				code = http.StatusUnauthorized
+			} else if errors.Is(err, ErrForbidden) {
+				code = http.StatusForbidden
+			}
+			HttpError(ctx, writer, request, logger, err, code)
+			return
+		}
+		if request.Body == nil {
+			HttpError(ctx, writer, request, logger, errors.New("empty body"), http.StatusBadRequest)
+			return
+		}
+		defer request.Body.Close()
+		body, err := io.ReadAll(request.Body)
+		if err != nil {
+			HttpError(ctx, writer, request, logger, err, http.StatusBadRequest)
+			return
+		}
+		if contentType := request.Header.Get("Content-Type"); contentType != "application/json" {
+			HttpError(ctx, writer, request, logger, errors.New("invalid Content-Type"), http.StatusUnsupportedMediaType)
+			return
+		}
+		admissionRequest, err := toAdmissionRequest(body, user, discovery)
+		if err != nil {
+			HttpError(ctx, writer, request, logger, err, http.StatusBadRequest)
+			return
+		}
+		gvk, err := discovery.GetGVKFromGVR(schema.GroupVersionResource(admissionRequest.Resource))
+		if err != nil {
+			HttpError(ctx, writer, request, logger, err, http.StatusBadRequest)
+			return
+		}
+		admissionRequest.GroupVersionKind = gvk
+		roles, clusterRoles, err := userinfo.GetRoleRef(rbLister, crbLister, admissionRequest.UserInfo)
+		if err != nil {
+			HttpError(ctx, writer, request, logger, err, http.StatusInternalServerError)
+			return
+		}
+		admissionRequest.Roles = roles
+		admissionRequest.ClusterRoles = clusterRoles
+		logger := logger.WithValues(
+			"gvk", admissionRequest.Kind,
+			"namespace", admissionRequest.Namespace,
+			"name", admissionRequest.Name,
+			"operation", admissionRequest.Operation,
+			"caller", user.Username,
+		)
+		responseJSON, err := json.Marshal(inner(ctx, logger, admissionRequest))
+		if err != nil {
+			HttpError(ctx, writer, request, logger, err, http.StatusInternalServerError)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if _, err := writer.Write(responseJSON); err != nil {
+			HttpError(ctx, writer, request, logger, err, http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// toAdmissionRequest decodes an AdmissionReview or wraps a raw object in a CREATE request,
+// requests are always marked as dry run and always run as the authenticated caller, the user info
+// of an AdmissionReview is ignored so that callers can't evaluate policies as someone else
+func toAdmissionRequest(body []byte, user authenticationv1.UserInfo, discovery dclient.IDiscovery) (AdmissionRequest, error) {
+	var typeMeta metav1.TypeMeta
+	if err := json.Unmarshal(body, &typeMeta); err != nil {
+		return AdmissionRequest{}, err
+	}
+	if typeMeta.GroupVersionKind() == admissionv1.SchemeGroupVersion.WithKind("AdmissionReview") {
+		var review admissionv1.AdmissionReview
+		if err := json.Unmarshal(body, &review); err != nil {
+			return AdmissionRequest{}, err
+		}
+		if review.Request == nil {
+			return AdmissionRequest{}, errors.New("admission review has no request")
+		}
+		request := *review.Request
+		request.UserInfo = user
+		request.DryRun = ptr.To(true)
+		return AdmissionRequest{AdmissionRequest: request}, nil
+	}
+	var object unstructured.Unstructured
+	if err := object.UnmarshalJSON(body); err != nil {
+		return AdmissionRequest{}, err
+	}
+	gvk := object.GroupVersionKind()
+	gvr, err := discovery.GetGVRFromGVK(gvk)
+	if err != nil {
+		return AdmissionRequest{}, fmt.Errorf("failed to resolve resource of %s: %w", gvk, err)
+	}
+	return AdmissionRequest{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			UID:       uuid.NewUUID(),
+			Kind:      metav1.GroupVersionKind(gvk),
+			Resource:  metav1.GroupVersionResource(gvr),
+			Name:      object.GetName(),
+			Namespace: object.GetNamespace(),
+			Operation: admissionv1.Create,
+			UserInfo:  user,
+			Object:    runtime.RawExtension{Raw: body},
+			DryRun:    ptr.To(true),
+		},
+	}, nil
+}
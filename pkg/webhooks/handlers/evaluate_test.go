@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/clients/dclient"
+	"gotest.tools/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_toAdmissionRequest(t *testing.T) {
+	discovery := dclient.NewFakeDiscoveryClient([]schema.GroupVersionResource{{Version: "v1", Resource: "pods"}})
+	user := authenticationv1.UserInfo{Username: "alice"}
+	t.Run("raw object", func(t *testing.T) {
+		body := []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"test","namespace":"default"}}`)
+		request, err := toAdmissionRequest(body, user, discovery)
+		assert.NilError(t, err)
+		assert.Equal(t, request.Operation, admissionv1.Create)
+		assert.Equal(t, request.Kind.Kind, "Pod")
+		assert.Equal(t, request.Resource.Resource, "pods")
+		assert.Equal(t, request.Name, "test")
+		assert.Equal(t, request.Namespace, "default")
+		assert.Equal(t, request.UserInfo.Username, "alice")
+		assert.Equal(t, *request.DryRun, true)
+		assert.Assert(t, request.UID != "")
+	})
+	t.Run("admission review", func(t *testing.T) {
+		body := []byte(`{"apiVersion":"admission.k8s.io/v1","kind":"AdmissionReview","request":{"uid":"42","operation":"UPDATE","userInfo":{"username":"bob"}}}`)
+		request, err := toAdmissionRequest(body, user, discovery)
+		assert.NilError(t, err)
+		assert.Equal(t, string(request.UID), "42")
+		assert.Equal(t, request.Operation, admissionv1.Update)
+		assert.Equal(t, request.UserInfo.Username, "alice")
+		assert.Equal(t, *request.DryRun, true)
+	})
+	t.Run("admission review with spoofed user info", func(t *testing.T) {
+		body := []byte(`{"apiVersion":"admission.k8s.io/v1","kind":"AdmissionReview","request":{"uid":"42","operation":"CREATE","userInfo":{"username":"system:admin","groups":["system:masters"]}}}`)
+		request, err := toAdmissionRequest(body, user, discovery)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, request.UserInfo, user)
+	})
+	t.Run("admission review without request", func(t *testing.T) {
+		body := []byte(`{"apiVersion":"admission.k8s.io/v1","kind":"AdmissionReview"}`)
+		_, err := toAdmissionRequest(body, user, discovery)
+		assert.ErrorContains(t, err, "no request")
+	})
+	t.Run("unknown kind", func(t *testing.T) {
+		body := []byte(`{"apiVersion":"v1","kind":"Secret","metadata":{"name":"test"}}`)
+		_, err := toAdmissionRequest(body, user, discovery)
+		assert.ErrorContains(t, err, "failed to resolve resource")
+	})
+}
+
+func Test_Evaluate_authentication(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{{
+		name: "unauthenticated",
+		err:  fmt.Errorf("%w: missing bearer token", ErrUnauthenticated),
+		want: http.StatusUnauthorized,
+	}, {
+		name: "forbidden",
+		err:  fmt.Errorf("%w: not allowed", ErrForbidden),
+		want: http.StatusForbidden,
+	}, {
+		name: "review failure",
+		err:  fmt.Errorf("failed to review token"),
+		want: http.StatusInternalServerError,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := Evaluate(
+				logr.Discard(),
+				func(context.Context, *http.Request) (authenticationv1.UserInfo, error) {
+					return authenticationv1.UserInfo{}, tt.err
+				},
+				dclient.NewFakeDiscoveryClient(nil),
+				nil,
+				nil,
+				func(context.Context, logr.Logger, AdmissionRequest) Evaluation {
+					called = true
+					return Evaluation{}
+				},
+			)
+			request := httptest.NewRequest(http.MethodPost, "/evaluate", strings.NewReader(`{}`))
+			request.Header.Set("Content-Type", "application/json")
+			recorder := httptest.NewRecorder()
+			handler(recorder, request)
+			assert.Equal(t, recorder.Code, tt.want)
+			assert.Equal(t, called, false)
+		})
+	}
+}
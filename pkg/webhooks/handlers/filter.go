@@ -41,6 +41,10 @@ func (inner AdmissionHandler) withFilter(c config.Configuration) AdmissionHandle
 		if c.ToFilter(request.GroupVersionKind, request.SubResource, request.Namespace, request.Name) {
 			return filtered(ctx, logger, request, "admission request filtered because it appears in configmap resource filters")
 		}
+		// filter by resource exclusions
+		if c.IsResourceExcluded(exclusionRequest(request)) {
+			return filtered(ctx, logger, request, "admission request filtered because it matches a configmap resource exclusion")
+		}
 		// filter kyverno resources
 		if webhookutils.ExcludeKyvernoResources(request.Kind.Kind) {
 			return filtered(ctx, logger, request, "admission request filtered because it is for a kyverno resource")
@@ -49,6 +53,31 @@ func (inner AdmissionHandler) withFilter(c config.Configuration) AdmissionHandle
 	}
 }
 
+// exclusionRequest returns the request checked against the resource exclusions, the labels are the ones of the
+// new object or of the old object when there's no new object
+func exclusionRequest(request AdmissionRequest) config.ExclusionRequest {
+	raw := request.Object.Raw
+	if len(raw) == 0 {
+		raw = request.OldObject.Raw
+	}
+	var labels map[string]string
+	if len(raw) != 0 {
+		if object, err := admissionutils.UnmarshalPartialObjectMetadata(raw); err == nil && object != nil {
+			labels = object.GetLabels()
+		}
+	}
+	return config.ExclusionRequest{
+		Kind:        request.GroupVersionKind,
+		Subresource: request.SubResource,
+		Namespace:   request.Namespace,
+		Name:        request.Name,
+		Labels:      labels,
+		Operation:   string(request.Operation),
+		Username:    request.UserInfo.Username,
+		Groups:      request.UserInfo.Groups,
+	}
+}
+
 func (inner AdmissionHandler) withOperationFilter(operations ...admissionv1.Operation) AdmissionHandler {
 	allowed := sets.New[string]()
 	for _, operation := range operations {
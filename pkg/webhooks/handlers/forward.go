@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/kyverno/kyverno/pkg/sharding"
+)
+
+// ForwardedHandler processes a request forwarded by another replica
+type ForwardedHandler func(context.Context, logr.Logger, AdmissionRequest)
+
+// Forwarded returns a handler accepting the admission requests forwarded by other replicas of the group,
+// requests are authenticated with the distributor and processed asynchronously
+func Forwarded(logger logr.Logger, distributor sharding.Distributor, inner ForwardedHandler) HttpHandler {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if request.Body == nil {
+			HttpError(request.Context(), writer, request, logger, errors.New("empty body"), http.StatusBadRequest)
+			return
+		}
+		defer request.Body.Close()
+		body, err := io.ReadAll(request.Body)
+		if err != nil {
+			HttpError(request.Context(), writer, request, logger, err, http.StatusBadRequest)
+			return
+		}
+		if err := distributor.Verify(request, body); err != nil {
+			HttpError(request.Context(), writer, request, logger, err, http.StatusForbidden)
+			return
+		}
+		var admissionRequest AdmissionRequest
+		if err := json.Unmarshal(body, &admissionRequest); err != nil {
+			HttpError(request.Context(), writer, request, logger, err, http.StatusBadRequest)
+			return
+		}
+		logger := logger.WithValues(
+			"gvk", admissionRequest.Kind,
+			"namespace", admissionRequest.Namespace,
+			"name", admissionRequest.Name,
+			"operation", admissionRequest.Operation,
+			"uid", admissionRequest.UID,
+		)
+		// processing outlives the http request
+		inner(context.WithoutCancel(request.Context()), logger, admissionRequest)
+		writer.WriteHeader(http.StatusAccepted)
+	}
+}
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
+)
+
+func (inner AdmissionHandler) WithWarningBudget(maxBytes int) AdmissionHandler {
+	return inner.withWarningBudget(maxBytes).WithTrace("WARNINGS")
+}
+
+func (inner AdmissionHandler) withWarningBudget(maxBytes int) AdmissionHandler {
+	return func(ctx context.Context, logger logr.Logger, request AdmissionRequest, startTime time.Time) AdmissionResponse {
+		response := inner(ctx, logger, request, startTime)
+		if len(response.Warnings) != 0 {
+			warnings := webhookutils.BudgetWarnings(response.Warnings, maxBytes)
+			if len(warnings) < len(response.Warnings) {
+				logger.V(4).Info("admission warnings reduced", "warnings", len(response.Warnings), "kept", len(warnings))
+			}
+			response.Warnings = warnings
+		}
+		return response
+	}
+}
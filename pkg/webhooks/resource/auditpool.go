@@ -0,0 +1,71 @@
+package resource
+
+import (
+	"sync"
+
+	"github.com/alitto/pond"
+	"github.com/kyverno/kyverno/pkg/config"
+)
+
+// auditPool runs the audit work, the pool is replaced when its size changes so that the number of workers and
+// the capacity can be reloaded from the configuration, the work queued in a replaced pool is still processed
+type auditPool struct {
+	lock     sync.RWMutex
+	pool     *pond.WorkerPool
+	workers  int
+	capacity int
+}
+
+func newAuditPool(workers, capacity int) *auditPool {
+	return &auditPool{
+		pool:     pond.New(workers, capacity, pond.Strategy(pond.Lazy())),
+		workers:  workers,
+		capacity: capacity,
+	}
+}
+
+// Submit queues the audit work, it blocks while the queue is full
+func (p *auditPool) Submit(task func()) {
+	// the lock is held until the task is queued so that the pool isn't stopped in between
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	p.pool.Submit(task)
+}
+
+func (p *auditPool) WaitingTasks() uint64 {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.pool.WaitingTasks()
+}
+
+func (p *auditPool) MaxCapacity() int {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.pool.MaxCapacity()
+}
+
+// resize replaces the pool when the number of workers or the capacity changed, it returns true if the pool was replaced
+func (p *auditPool) resize(workers, capacity int) bool {
+	p.lock.Lock()
+	if workers == p.workers && capacity == p.capacity {
+		p.lock.Unlock()
+		return false
+	}
+	previous := p.pool
+	p.pool = pond.New(workers, capacity, pond.Strategy(pond.Lazy()))
+	p.workers, p.capacity = workers, capacity
+	p.lock.Unlock()
+	go previous.StopAndWait()
+	return true
+}
+
+// auditPoolSize returns the number of workers and the capacity of the audit pool, the configuration overrides the flags
+func auditPoolSize(configuration config.Configuration, workers, capacity int) (int, int) {
+	if configured, ok := configuration.GetMaxAuditWorkers(); ok {
+		workers = configured
+	}
+	if configured, ok := configuration.GetMaxAuditCapacity(); ok {
+		capacity = configured
+	}
+	return workers, capacity
+}
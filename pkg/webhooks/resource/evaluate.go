@@ -0,0 +1,98 @@
+package resource
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
+	"github.com/kyverno/kyverno/pkg/policycache"
+	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
+	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
+	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
+	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Evaluate applies the policies matching the request the same way the mutating and validating webhooks do,
+// nothing is recorded: no events, reports, update requests or decisions are created
+func (h *resourceHandlers) Evaluate(ctx context.Context, logger logr.Logger, request handlers.AdmissionRequest) handlers.Evaluation {
+	logger = logger.WithValues("kind", request.Kind.Kind)
+	logger.V(4).Info("received an evaluation request")
+	gvr := schema.GroupVersionResource(request.Resource)
+	getPolicies := func(policyType policycache.PolicyType) []kyvernov1.PolicyInterface {
+		return h.pCache.GetPolicies(policyType, gvr, request.SubResource, request.Namespace)
+	}
+	policyContext, err := h.buildPolicyContextFromAdmissionRequest(logger, request)
+	if err != nil {
+		return handlers.Evaluation{Response: errorResponse(logger, request.UID, err, "failed to build policy context")}
+	}
+	var evaluation handlers.Evaluation
+	var patches []jsonpatch.JsonPatchOperation
+	var mutateResponses, enforceResponses, auditResponses []engineapi.EngineResponse
+	mutateFailurePolicy, enforceFailurePolicy := kyvernov1.Ignore, kyvernov1.Ignore
+	// mutations are chained, the next policy sees the resource patched by the previous ones
+	for _, policy := range getPolicies(policycache.Mutate) {
+		if !policy.GetSpec().HasMutateStandard() {
+			continue
+		}
+		if policyutils.GetFailurePolicy(ctx, policy) == kyvernov1.Fail {
+			mutateFailurePolicy = kyvernov1.Fail
+		}
+		response := h.engine.Mutate(ctx, policyContext.WithPolicy(policy))
+		policyContext = policyContext.WithNewResource(response.PatchedResource)
+		if emitWarning := policy.GetSpec().EmitWarning; emitWarning != nil && *emitWarning {
+			response = response.WithWarning()
+		}
+		patches = append(patches, response.GetPatches()...)
+		mutateResponses = append(mutateResponses, response)
+		evaluation.Results = append(evaluation.Results, reportutils.MutationEngineResponseToReportResults(response)...)
+	}
+	for _, policy := range getPolicies(policycache.VerifyImagesMutate) {
+		if policyutils.GetFailurePolicy(ctx, policy) == kyvernov1.Fail {
+			mutateFailurePolicy = kyvernov1.Fail
+		}
+		response, _ := h.engine.VerifyAndPatchImages(ctx, policyContext.WithPolicy(policy))
+		if response.IsEmpty() {
+			continue
+		}
+		policyContext = policyContext.WithNewResource(response.PatchedResource)
+		patches = append(patches, response.GetPatches()...)
+		mutateResponses = append(mutateResponses, response)
+		evaluation.Results = append(evaluation.Results, reportutils.EngineResponseToReportResults(response)...)
+	}
+	enforcePolicies := append(getPolicies(policycache.ValidateEnforce), getPolicies(policycache.VerifyImagesValidate)...)
+	for _, policy := range enforcePolicies {
+		if policyutils.GetFailurePolicy(ctx, policy) == kyvernov1.Fail {
+			enforceFailurePolicy = kyvernov1.Fail
+		}
+		if response := h.engine.Validate(ctx, policyContext.WithPolicy(policy)); !response.IsNil() {
+			enforceResponses = append(enforceResponses, response)
+		}
+	}
+	// audit policies include the ones emitting warnings
+	for _, policy := range getPolicies(policycache.ValidateAudit) {
+		if response := h.engine.Validate(ctx, policyContext.WithPolicy(policy)); !response.IsNil() {
+			auditResponses = append(auditResponses, response)
+		}
+	}
+	validateResponses := append(enforceResponses, auditResponses...)
+	for _, response := range validateResponses {
+		evaluation.Results = append(evaluation.Results, reportutils.EngineResponseToReportResults(response)...)
+	}
+	warnings := webhookutils.GetWarningMessages(append(mutateResponses, validateResponses...))
+	switch {
+	case webhookutils.BlockRequest(mutateResponses, mutateFailurePolicy, logger):
+		evaluation.Response = admissionutils.Response(request.UID, errors.New(webhookutils.GetBlockedMessages(mutateResponses)), warnings...)
+	case webhookutils.BlockRequest(enforceResponses, enforceFailurePolicy, logger):
+		evaluation.Response = admissionutils.Response(request.UID, errors.New(webhookutils.GetBlockedMessages(enforceResponses)), warnings...)
+	default:
+		evaluation.Response = admissionutils.MutationResponse(request.UID, jsonutils.JoinPatches(patch.ConvertPatches(patches...)...), warnings...)
+	}
+	return evaluation
+}
@@ -3,7 +3,6 @@ package resource
 import (
 	"context"
 
-	"github.com/alitto/pond"
 	fakekyvernov1 "github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
 	kyvernoinformers "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
 	"github.com/kyverno/kyverno/pkg/clients/dclient"
@@ -16,6 +15,7 @@ import (
 	"github.com/kyverno/kyverno/pkg/event"
 	"github.com/kyverno/kyverno/pkg/exceptions"
 	"github.com/kyverno/kyverno/pkg/imageverifycache"
+	"github.com/kyverno/kyverno/pkg/informers"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/policycache"
 	"github.com/kyverno/kyverno/pkg/registryclient"
@@ -30,8 +30,9 @@ func NewFakeHandlers(ctx context.Context, policyCache policycache.Cache) *resour
 	client := fake.NewSimpleClientset()
 	metricsConfig := metrics.NewFakeMetricsConfig()
 
-	informers := kubeinformers.NewSharedInformerFactory(client, 0)
-	informers.Start(ctx.Done())
+	kubeInformers := kubeinformers.NewSharedInformerFactory(client, 0)
+	nsLabels, _ := informers.NewNamespaceLabels(kubeInformers.Core().V1().Namespaces())
+	kubeInformers.Start(ctx.Done())
 
 	kyvernoclient := fakekyvernov1.NewSimpleClientset()
 	kyvernoInformers := kyvernoinformers.NewSharedInformerFactory(kyvernoclient, 0)
@@ -50,12 +51,12 @@ func NewFakeHandlers(ctx context.Context, policyCache policycache.Cache) *resour
 		configuration:   configuration,
 		metricsConfig:   metricsConfig,
 		pCache:          policyCache,
-		nsLister:        informers.Core().V1().Namespaces().Lister(),
+		nsLabels:        nsLabels,
 		urLister:        urLister,
 		urGenerator:     updaterequest.NewFake(),
 		eventGen:        event.NewFake(),
 		pcBuilder:       webhookutils.NewPolicyContextBuilder(configuration, jp),
-		auditPool:       pond.New(8, 1000),
+		auditPool:       newAuditPool(8, 1000),
 		reportingConfig: report.NewReportingConfig("validate", "mutate", "mutateExisiting", "generate", "imageVerify"),
 		engine: engine.NewEngine(
 			configuration,
@@ -66,6 +67,9 @@ func NewFakeHandlers(ctx context.Context, policyCache policycache.Cache) *resour
 			imageverifycache.DisabledImageVerifyCache(),
 			factories.DefaultContextLoaderFactory(configMapResolver),
 			exceptions.New(peLister),
+			nil,
+			nil,
+			nil,
 		),
 	}
 }
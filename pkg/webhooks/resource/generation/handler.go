@@ -18,12 +18,12 @@ import (
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	engineutils "github.com/kyverno/kyverno/pkg/engine/utils"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/informers"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	utils "github.com/kyverno/kyverno/pkg/utils/engine"
 	webhookgenerate "github.com/kyverno/kyverno/pkg/webhooks/updaterequest"
 	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
 	admissionv1 "k8s.io/api/admission/v1"
-	corev1listers "k8s.io/client-go/listers/core/v1"
 )
 
 type GenerationHandler interface {
@@ -35,7 +35,7 @@ func NewGenerationHandler(
 	engine engineapi.Engine,
 	client dclient.Interface,
 	kyvernoClient versioned.Interface,
-	nsLister corev1listers.NamespaceLister,
+	nsLabels informers.NamespaceLabels,
 	urLister kyvernov2listers.UpdateRequestNamespaceLister,
 	cpolLister kyvernov1listers.ClusterPolicyLister,
 	polLister kyvernov1listers.PolicyLister,
@@ -50,7 +50,7 @@ func NewGenerationHandler(
 		engine:                       engine,
 		client:                       client,
 		kyvernoClient:                kyvernoClient,
-		nsLister:                     nsLister,
+		nsLabels:                     nsLabels,
 		urLister:                     urLister,
 		cpolLister:                   cpolLister,
 		polLister:                    polLister,
@@ -67,7 +67,7 @@ type generationHandler struct {
 	engine                       engineapi.Engine
 	client                       dclient.Interface
 	kyvernoClient                versioned.Interface
-	nsLister                     corev1listers.NamespaceLister
+	nsLabels                     informers.NamespaceLabels
 	urLister                     kyvernov2listers.UpdateRequestNamespaceLister
 	cpolLister                   kyvernov1listers.ClusterPolicyLister
 	polLister                    kyvernov1listers.PolicyLister
@@ -121,7 +121,7 @@ func (h *generationHandler) handleTrigger(
 		var appliedRules, failedRules []engineapi.RuleResponse
 		policyContext := policyContext.WithPolicy(policy)
 		if request.Kind.Kind != "Namespace" && request.Namespace != "" {
-			policyContext = policyContext.WithNamespaceLabels(utils.GetNamespaceSelectorsFromNamespaceLister(request.Kind.Kind, request.Namespace, h.nsLister, h.log))
+			policyContext = policyContext.WithNamespaceLabels(utils.GetNamespaceLabels(request.Kind.Kind, request.Namespace, h.nsLabels, h.log))
 		}
 		engineResponse := h.engine.ApplyBackgroundChecks(ctx, policyContext)
 		for _, rule := range engineResponse.PolicyResponse.Rules {
@@ -309,6 +309,10 @@ func (h *generationHandler) processRequest(ctx context.Context, policyContext *e
 		if err != nil {
 			return err
 		}
+		if policy.GetSpec().DryRun {
+			h.log.V(4).Info("skip creating UR for policy in dry run mode", "policy", pName)
+			continue
+		}
 
 		pKey := common.PolicyKey(pNamespace, pName)
 		urSpec := kyvernov2.UpdateRequestSpec{
@@ -2,13 +2,13 @@ package resource
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/alitto/pond"
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	"github.com/kyverno/kyverno/pkg/breaker"
@@ -22,21 +22,28 @@ import (
 	"github.com/kyverno/kyverno/pkg/engine/jmespath"
 	"github.com/kyverno/kyverno/pkg/engine/policycontext"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/informers"
+	"github.com/kyverno/kyverno/pkg/limiter"
+	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/policycache"
+	"github.com/kyverno/kyverno/pkg/sharding"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
 	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
+	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	"github.com/kyverno/kyverno/pkg/webhooks"
+	"github.com/kyverno/kyverno/pkg/webhooks/decisionlog"
 	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
 	"github.com/kyverno/kyverno/pkg/webhooks/resource/imageverification"
 	"github.com/kyverno/kyverno/pkg/webhooks/resource/mutation"
 	"github.com/kyverno/kyverno/pkg/webhooks/resource/validation"
 	webhookgenerate "github.com/kyverno/kyverno/pkg/webhooks/updaterequest"
 	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
+	sdkmetric "go.opentelemetry.io/otel/metric"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	corev1listers "k8s.io/client-go/listers/core/v1"
 )
 
 type resourceHandlers struct {
@@ -53,7 +60,7 @@ type resourceHandlers struct {
 	pCache policycache.Cache
 
 	// listers
-	nsLister   corev1listers.NamespaceLister
+	nsLabels   informers.NamespaceLabels
 	urLister   kyvernov2listers.UpdateRequestNamespaceLister
 	cpolLister kyvernov1listers.ClusterPolicyLister
 	polLister  kyvernov1listers.PolicyLister
@@ -65,9 +72,15 @@ type resourceHandlers struct {
 	admissionReports             bool
 	backgroundServiceAccountName string
 	reportsServiceAccountName    string
-	auditPool                    *pond.WorkerPool
+	auditPool                    *auditPool
+	auditDrops                   sdkmetric.Int64Counter
 	reportingConfig              reportutils.ReportingConfiguration
-	reportsBreaker               breaker.Breaker
+	reportsWriter                reportutils.ReportWriter
+	auditDistributor             sharding.Distributor
+	decisions                    decisionlog.Recorder
+	limiter                      limiter.Limiter
+	auditBreaker                 breaker.Breaker
+	latencyObserver              breaker.LatencyObserver
 }
 
 func NewHandlers(
@@ -77,7 +90,7 @@ func NewHandlers(
 	configuration config.Configuration,
 	metricsConfig metrics.MetricsConfigManager,
 	pCache policycache.Cache,
-	nsLister corev1listers.NamespaceLister,
+	nsLabels informers.NamespaceLabels,
 	urLister kyvernov2listers.UpdateRequestNamespaceLister,
 	cpolInformer kyvernov1informers.ClusterPolicyInformer,
 	polInformer kyvernov1informers.PolicyInformer,
@@ -90,8 +103,20 @@ func NewHandlers(
 	maxAuditWorkers int,
 	maxAuditCapacity int,
 	reportingConfig reportutils.ReportingConfiguration,
-	reportsBreaker breaker.Breaker,
+	reportsWriter reportutils.ReportWriter,
+	auditDistributor sharding.Distributor,
+	decisions decisionlog.Recorder,
+	limiter limiter.Limiter,
+	auditBreaker breaker.Breaker,
+	latencyObserver breaker.LatencyObserver,
 ) webhooks.ResourceHandlers {
+	auditPool := newAuditPool(auditPoolSize(configuration, maxAuditWorkers, maxAuditCapacity))
+	configuration.OnSettingsChanged(func([]config.SettingChange) {
+		workers, capacity := auditPoolSize(configuration, maxAuditWorkers, maxAuditCapacity)
+		if auditPool.resize(workers, capacity) {
+			logging.WithName("audit-pool").Info("audit pool resized", "maxAuditWorkers", workers, "maxAuditCapacity", capacity)
+		}
+	})
 	return &resourceHandlers{
 		engine:                       engine,
 		client:                       client,
@@ -99,7 +124,7 @@ func NewHandlers(
 		configuration:                configuration,
 		metricsConfig:                metricsConfig,
 		pCache:                       pCache,
-		nsLister:                     nsLister,
+		nsLabels:                     nsLabels,
 		urLister:                     urLister,
 		cpolLister:                   cpolInformer.Lister(),
 		polLister:                    polInformer.Lister(),
@@ -109,9 +134,15 @@ func NewHandlers(
 		admissionReports:             admissionReports,
 		backgroundServiceAccountName: backgroundServiceAccountName,
 		reportsServiceAccountName:    reportsServiceAccountName,
-		auditPool:                    pond.New(maxAuditWorkers, maxAuditCapacity, pond.Strategy(pond.Lazy())),
+		auditPool:                    auditPool,
+		auditDrops:                   newAuditPoolMetrics(auditPool),
 		reportingConfig:              reportingConfig,
-		reportsBreaker:               reportsBreaker,
+		reportsWriter:                reportsWriter,
+		auditDistributor:             auditDistributor,
+		decisions:                    decisions,
+		limiter:                      limiter,
+		auditBreaker:                 auditBreaker,
+		latencyObserver:              latencyObserver,
 	}
 }
 
@@ -120,10 +151,18 @@ func (h *resourceHandlers) Validate(ctx context.Context, logger logr.Logger, req
 	logger = logger.WithValues("kind", kind).WithValues("URLParams", request.URLParams)
 	logger.V(4).Info("received an admission request in validating webhook")
 
+	defer h.observe(ctx, startTime)
+	release, err := h.acquire(ctx, limiter.PriorityHigh)
+	if err != nil {
+		return h.recordDecision(ctx, "validate", request, startTime, errorResponse(logger, request.UID, err, "failed to process admission request"))
+	}
+	defer release()
+
 	policies, mutatePolicies, generatePolicies, _, auditWarnPolicies, err := h.retrieveAndCategorizePolicies(ctx, logger, request, failurePolicy, false)
 	if err != nil {
-		return errorResponse(logger, request.UID, err, "failed to fetch policy with key")
+		return h.recordDecision(ctx, "validate", request, startTime, errorResponse(logger, request.UID, err, "failed to fetch policy with key"))
 	}
+	h.skipPolicies(logger, request, true, &policies, &mutatePolicies, &generatePolicies, &auditWarnPolicies)
 
 	if len(policies) == 0 && len(mutatePolicies) == 0 && len(generatePolicies) == 0 && len(auditWarnPolicies) == 0 {
 		logger.V(4).Info("no policies matched admission request")
@@ -131,6 +170,14 @@ func (h *resourceHandlers) Validate(ctx context.Context, logger logr.Logger, req
 
 	logger.V(4).Info("processing policies for validate admission request", "validate", len(policies), "mutate", len(mutatePolicies), "generate", len(generatePolicies))
 
+	// audit policies are treated as skipped while the admission controller is overloaded
+	skipAudit := h.skipAudit(ctx)
+	if skipAudit {
+		logger.V(2).Info("admission controller overloaded, skipping audit policies")
+		h.dropAudit(ctx, "overloaded")
+		auditWarnPolicies = nil
+	}
+
 	vh := validation.NewValidationHandler(
 		logger,
 		h.kyvernoClient,
@@ -141,9 +188,9 @@ func (h *resourceHandlers) Validate(ctx context.Context, logger logr.Logger, req
 		h.admissionReports,
 		h.metricsConfig,
 		h.configuration,
-		h.nsLister,
+		h.nsLabels,
 		h.reportingConfig,
-		h.reportsBreaker,
+		h.reportsWriter,
 	)
 	var wg sync.WaitGroup
 	var ok bool
@@ -165,10 +212,23 @@ func (h *resourceHandlers) Validate(ctx context.Context, logger logr.Logger, req
 		logger.Info("admission request denied")
 		events := webhookutils.GenerateEvents(enforceResponses, true, h.configuration)
 		h.eventGen.Add(events...)
-		return admissionutils.Response(request.UID, errors.New(msg), warnings...)
+		return h.recordDecision(ctx, "validate", request, startTime, admissionutils.Response(request.UID, errors.New(msg), warnings...), enforceResponses...)
 	}
 	go h.auditPool.Submit(func() {
+		if skipAudit || h.forwardAudit(ctx, logger, request) {
+			h.eventGen.Add(webhookutils.GenerateEvents(enforceResponses, false, h.configuration)...)
+			return
+		}
+		release, err := h.acquire(ctx, limiter.PriorityLow)
+		if err != nil {
+			logger.V(2).Info("audit policies processing shed", "reason", err.Error())
+			h.dropAudit(ctx, "shed")
+			h.eventGen.Add(webhookutils.GenerateEvents(enforceResponses, false, h.configuration)...)
+			return
+		}
+		defer release()
 		auditResponses := vh.HandleValidationAudit(ctx, request)
+		h.recordDecision(ctx, "audit", request, startTime, admissionutils.ResponseSuccess(request.UID), auditResponses...)
 		var events []event.Info
 
 		switch {
@@ -183,33 +243,111 @@ func (h *resourceHandlers) Validate(ctx context.Context, logger logr.Logger, req
 
 		h.eventGen.Add(events...)
 	})
-	return admissionutils.ResponseSuccess(request.UID, warnings...)
+	return h.recordDecision(ctx, "validate", request, startTime, admissionutils.ResponseSuccess(request.UID, warnings...), enforceResponses...)
+}
+
+// Audit processes the audit policies for a request forwarded by another replica
+func (h *resourceHandlers) Audit(ctx context.Context, logger logr.Logger, request handlers.AdmissionRequest) {
+	vh := validation.NewValidationHandler(
+		logger,
+		h.kyvernoClient,
+		h.engine,
+		h.pCache,
+		h.pcBuilder,
+		h.eventGen,
+		h.admissionReports,
+		h.metricsConfig,
+		h.configuration,
+		h.nsLabels,
+		h.reportingConfig,
+		h.reportsWriter,
+	)
+	startTime := time.Now()
+	if h.skipAudit(ctx) {
+		logger.V(2).Info("admission controller overloaded, skipping forwarded audit policies")
+		h.dropAudit(ctx, "overloaded")
+		return
+	}
+	h.auditPool.Submit(func() {
+		release, err := h.acquire(ctx, limiter.PriorityLow)
+		if err != nil {
+			logger.V(2).Info("forwarded audit policies processing shed", "reason", err.Error())
+			h.dropAudit(ctx, "shed")
+			return
+		}
+		defer release()
+		auditResponses := vh.HandleValidationAudit(ctx, request)
+		h.recordDecision(ctx, "audit", request, startTime, admissionutils.ResponseSuccess(request.UID), auditResponses...)
+		h.eventGen.Add(webhookutils.GenerateEvents(auditResponses, false, h.configuration)...)
+	})
+}
+
+// forwardAudit sends the request to the replica owning the resource, it returns false
+// if audit policies must be processed by the current replica
+func (h *resourceHandlers) forwardAudit(ctx context.Context, logger logr.Logger, request handlers.AdmissionRequest) bool {
+	if h.auditDistributor == nil || admissionutils.IsDryRun(request.AdmissionRequest) {
+		return false
+	}
+	payload, err := json.Marshal(request)
+	if err != nil {
+		logger.Error(err, "failed to marshal admission request")
+		return false
+	}
+	return h.auditDistributor.Forward(context.WithoutCancel(ctx), auditShardKey(request), payload)
+}
+
+// auditShardKey returns the key used to distribute audit work, the resource uid is not
+// set on creation, the request uid is used instead
+func auditShardKey(request handlers.AdmissionRequest) string {
+	for _, raw := range [][]byte{request.Object.Raw, request.OldObject.Raw} {
+		if len(raw) == 0 {
+			continue
+		}
+		var object metav1.PartialObjectMetadata
+		if err := json.Unmarshal(raw, &object); err == nil && object.UID != "" {
+			return string(object.UID)
+		}
+	}
+	return string(request.UID)
 }
 
 func (h *resourceHandlers) Mutate(ctx context.Context, logger logr.Logger, request handlers.AdmissionRequest, failurePolicy string, startTime time.Time) handlers.AdmissionResponse {
+	defer h.observe(ctx, startTime)
+	release, err := h.acquire(ctx, limiter.PriorityHigh)
+	if err != nil {
+		return h.recordDecision(ctx, "mutate", request, startTime, errorResponse(logger, request.UID, err, "failed to process admission request"))
+	}
+	defer release()
+	response, engineResponses := h.mutate(ctx, logger, request, failurePolicy, startTime)
+	return h.recordDecision(ctx, "mutate", request, startTime, response, engineResponses...)
+}
+
+func (h *resourceHandlers) mutate(ctx context.Context, logger logr.Logger, request handlers.AdmissionRequest, failurePolicy string, startTime time.Time) (handlers.AdmissionResponse, []engineapi.EngineResponse) {
 	kind := request.Kind.Kind
 	logger = logger.WithValues("kind", kind).WithValues("URLParams", request.URLParams)
 	logger.V(4).Info("received an admission request in mutating webhook")
 
 	_, mutatePolicies, _, verifyImagesPolicies, _, err := h.retrieveAndCategorizePolicies(ctx, logger, request, failurePolicy, true) //nolint:dogsled
 	if err != nil {
-		return errorResponse(logger, request.UID, err, "failed to fetch policy with key")
+		return errorResponse(logger, request.UID, err, "failed to fetch policy with key"), nil
 	}
+	// skipped policies are reported by the validating webhook
+	h.skipPolicies(logger, request, false, &mutatePolicies, &verifyImagesPolicies)
 	if len(mutatePolicies) == 0 && len(verifyImagesPolicies) == 0 {
 		logger.V(4).Info("no policies matched mutate admission request")
-		return admissionutils.ResponseSuccess(request.UID)
+		return admissionutils.ResponseSuccess(request.UID), nil
 	}
 	logger.V(4).Info("processing policies for mutate admission request", "mutatePolicies", len(mutatePolicies), "verifyImagesPolicies", len(verifyImagesPolicies))
 	policyContext, err := h.pcBuilder.Build(request.AdmissionRequest, request.Roles, request.ClusterRoles, request.GroupVersionKind)
 	if err != nil {
 		logger.Error(err, "failed to build policy context")
-		return admissionutils.Response(request.UID, err)
+		return admissionutils.Response(request.UID, err), nil
 	}
-	mh := mutation.NewMutationHandler(logger, h.kyvernoClient, h.engine, h.eventGen, h.nsLister, h.metricsConfig, h.admissionReports, h.reportingConfig, h.reportsBreaker)
-	patches, warnings, err := mh.HandleMutation(ctx, request, mutatePolicies, policyContext, startTime, h.configuration)
+	mh := mutation.NewMutationHandler(logger, h.kyvernoClient, h.engine, h.eventGen, h.nsLabels, h.metricsConfig, h.admissionReports, h.reportingConfig, h.reportsWriter)
+	patches, warnings, engineResponses, err := mh.HandleMutation(ctx, request, mutatePolicies, policyContext, startTime, h.configuration)
 	if err != nil {
 		logger.Error(err, "mutation failed")
-		return admissionutils.Response(request.UID, err)
+		return admissionutils.Response(request.UID, err), engineResponses
 	}
 	if len(verifyImagesPolicies) != 0 {
 		newRequest := patchRequest(patches, request.AdmissionRequest, logger)
@@ -217,7 +355,7 @@ func (h *resourceHandlers) Mutate(ctx context.Context, logger logr.Logger, reque
 		policyContext, err = h.pcBuilder.Build(newRequest, request.Roles, request.ClusterRoles, request.GroupVersionKind)
 		if err != nil {
 			logger.Error(err, "failed to build policy context")
-			return admissionutils.Response(request.UID, err)
+			return admissionutils.Response(request.UID, err), engineResponses
 		}
 		ivh := imageverification.NewImageVerificationHandler(
 			logger,
@@ -226,19 +364,63 @@ func (h *resourceHandlers) Mutate(ctx context.Context, logger logr.Logger, reque
 			h.eventGen,
 			h.admissionReports,
 			h.configuration,
-			h.nsLister,
+			h.nsLabels,
 			h.reportingConfig,
-			h.reportsBreaker,
+			h.reportsWriter,
 		)
-		imagePatches, imageVerifyWarnings, err := ivh.Handle(ctx, newRequest, verifyImagesPolicies, policyContext)
+		imagePatches, imageVerifyWarnings, imageVerifyResponses, err := ivh.Handle(ctx, newRequest, verifyImagesPolicies, policyContext)
+		engineResponses = append(engineResponses, imageVerifyResponses...)
 		if err != nil {
 			logger.Error(err, "image verification failed")
-			return admissionutils.Response(request.UID, err)
+			return admissionutils.Response(request.UID, err), engineResponses
 		}
 		patches = jsonutils.JoinPatches(patches, imagePatches)
 		warnings = append(warnings, imageVerifyWarnings...)
 	}
-	return admissionutils.MutationResponse(request.UID, patches, warnings...)
+	return admissionutils.MutationResponse(request.UID, patches, warnings...), engineResponses
+}
+
+// acquire reserves a slot in the admission limiter, work is not limited when the limiter is not configured
+func (h *resourceHandlers) acquire(ctx context.Context, priority limiter.Priority) (func(), error) {
+	if h.limiter == nil {
+		return func() {}, nil
+	}
+	return h.limiter.Acquire(ctx, priority)
+}
+
+// skipAudit returns true when audit work must be skipped to protect the admission controller
+func (h *resourceHandlers) skipAudit(ctx context.Context) bool {
+	if h.auditBreaker == nil {
+		return false
+	}
+	skip := true
+	_ = h.auditBreaker.Do(ctx, func(context.Context) error {
+		skip = false
+		return nil
+	})
+	return skip
+}
+
+// observe feeds the latency breaker, requests that exceeded their deadline are counted as failures
+func (h *resourceHandlers) observe(ctx context.Context, startTime time.Time) {
+	if h.latencyObserver != nil {
+		h.latencyObserver.Observe(time.Since(startTime), ctx.Err() != nil)
+	}
+}
+
+// recordDecision records the admission decision when the decision log is enabled
+func (h *resourceHandlers) recordDecision(
+	ctx context.Context,
+	webhook string,
+	request handlers.AdmissionRequest,
+	startTime time.Time,
+	response handlers.AdmissionResponse,
+	engineResponses ...engineapi.EngineResponse,
+) handlers.AdmissionResponse {
+	if h.decisions != nil {
+		h.decisions.Record(ctx, decisionlog.NewDecision(webhook, request, response, startTime, engineResponses...))
+	}
+	return response
 }
 
 func (h *resourceHandlers) retrieveAndCategorizePolicies(
@@ -312,7 +494,7 @@ func (h *resourceHandlers) buildPolicyContextFromAdmissionRequest(logger logr.Lo
 	}
 	namespaceLabels := make(map[string]string)
 	if request.Kind.Kind != "Namespace" && request.Namespace != "" {
-		namespaceLabels = engineutils.GetNamespaceSelectorsFromNamespaceLister(request.Kind.Kind, request.Namespace, h.nsLister, logger)
+		namespaceLabels = engineutils.GetNamespaceLabels(request.Kind.Kind, request.Namespace, h.nsLabels, logger)
 	}
 	policyContext = policyContext.WithNamespaceLabels(namespaceLabels)
 	return policyContext, nil
@@ -322,11 +504,11 @@ func filterPolicies(ctx context.Context, failurePolicy string, policies ...kyver
 	var results []kyvernov1.PolicyInterface
 	for _, policy := range policies {
 		if failurePolicy == "fail" {
-			if policy.GetSpec().GetFailurePolicy(ctx) == kyvernov1.Fail {
+			if policyutils.GetFailurePolicy(ctx, policy) == kyvernov1.Fail {
 				results = append(results, policy)
 			}
 		} else if failurePolicy == "ignore" {
-			if policy.GetSpec().GetFailurePolicy(ctx) == kyvernov1.Ignore {
+			if policyutils.GetFailurePolicy(ctx, policy) == kyvernov1.Ignore {
 				results = append(results, policy)
 			}
 		} else {
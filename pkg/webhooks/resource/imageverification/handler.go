@@ -7,17 +7,18 @@ import (
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
-	"github.com/kyverno/kyverno/pkg/breaker"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/informers"
 	"github.com/kyverno/kyverno/pkg/tracing"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
 	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
+	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
 	"go.opentelemetry.io/otel/trace"
@@ -25,11 +26,10 @@ import (
 	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	corev1listers "k8s.io/client-go/listers/core/v1"
 )
 
 type ImageVerificationHandler interface {
-	Handle(context.Context, admissionv1.AdmissionRequest, []kyvernov1.PolicyInterface, *engine.PolicyContext) ([]byte, []string, error)
+	Handle(context.Context, admissionv1.AdmissionRequest, []kyvernov1.PolicyInterface, *engine.PolicyContext) ([]byte, []string, []engineapi.EngineResponse, error)
 }
 
 type imageVerificationHandler struct {
@@ -39,9 +39,9 @@ type imageVerificationHandler struct {
 	eventGen         event.Interface
 	admissionReports bool
 	cfg              config.Configuration
-	nsLister         corev1listers.NamespaceLister
+	nsLabels         informers.NamespaceLabels
 	reportConfig     reportutils.ReportingConfiguration
-	reportsBreaker   breaker.Breaker
+	reportsWriter    reportutils.ReportWriter
 }
 
 func NewImageVerificationHandler(
@@ -51,9 +51,9 @@ func NewImageVerificationHandler(
 	eventGen event.Interface,
 	admissionReports bool,
 	cfg config.Configuration,
-	nsLister corev1listers.NamespaceLister,
+	nsLabels informers.NamespaceLabels,
 	reportConfig reportutils.ReportingConfiguration,
-	reportsBreaker breaker.Breaker,
+	reportsWriter reportutils.ReportWriter,
 ) ImageVerificationHandler {
 	return &imageVerificationHandler{
 		kyvernoClient:    kyvernoClient,
@@ -62,9 +62,9 @@ func NewImageVerificationHandler(
 		eventGen:         eventGen,
 		admissionReports: admissionReports,
 		cfg:              cfg,
-		nsLister:         nsLister,
+		nsLabels:         nsLabels,
 		reportConfig:     reportConfig,
-		reportsBreaker:   reportsBreaker,
+		reportsWriter:    reportsWriter,
 	}
 }
 
@@ -73,13 +73,13 @@ func (h *imageVerificationHandler) Handle(
 	request admissionv1.AdmissionRequest,
 	policies []kyvernov1.PolicyInterface,
 	policyContext *engine.PolicyContext,
-) ([]byte, []string, error) {
-	ok, message, imagePatches, warnings := h.handleVerifyImages(ctx, h.log, request, policyContext, policies, h.cfg)
+) ([]byte, []string, []engineapi.EngineResponse, error) {
+	ok, message, imagePatches, warnings, engineResponses := h.handleVerifyImages(ctx, h.log, request, policyContext, policies, h.cfg)
 	if !ok {
-		return nil, nil, errors.New(message)
+		return nil, nil, engineResponses, errors.New(message)
 	}
 	h.log.V(6).Info("images verified", "patches", string(imagePatches), "warnings", warnings)
-	return imagePatches, warnings, nil
+	return imagePatches, warnings, engineResponses, nil
 }
 
 func (h *imageVerificationHandler) handleVerifyImages(
@@ -89,9 +89,9 @@ func (h *imageVerificationHandler) handleVerifyImages(
 	policyContext *engine.PolicyContext,
 	policies []kyvernov1.PolicyInterface,
 	cfg config.Configuration,
-) (bool, string, []byte, []string) {
+) (bool, string, []byte, []string, []engineapi.EngineResponse) {
 	if len(policies) == 0 {
-		return true, "", nil, nil
+		return true, "", nil, nil, nil
 	}
 	var engineResponses []engineapi.EngineResponse
 	var patches []jsonpatch.JsonPatchOperation
@@ -104,13 +104,13 @@ func (h *imageVerificationHandler) handleVerifyImages(
 			"",
 			fmt.Sprintf("POLICY %s/%s", policy.GetNamespace(), policy.GetName()),
 			func(ctx context.Context, span trace.Span) {
-				if policy.GetSpec().GetFailurePolicy(ctx) == kyvernov1.Fail {
+				if policyutils.GetFailurePolicy(ctx, policy) == kyvernov1.Fail {
 					failurePolicy = kyvernov1.Fail
 				}
 
 				policyContext := policyContext.WithPolicy(policy)
 				if request.Kind.Kind != "Namespace" && request.Namespace != "" {
-					policyContext = policyContext.WithNamespaceLabels(engineutils.GetNamespaceSelectorsFromNamespaceLister(request.Kind.Kind, request.Namespace, h.nsLister, h.log))
+					policyContext = policyContext.WithNamespaceLabels(engineutils.GetNamespaceLabels(request.Kind.Kind, request.Namespace, h.nsLabels, h.log))
 				}
 
 				resp, ivm := h.engine.VerifyAndPatchImages(ctx, policyContext)
@@ -130,7 +130,7 @@ func (h *imageVerificationHandler) handleVerifyImages(
 
 	if blocked {
 		logger.V(4).Info("admission request blocked")
-		return false, webhookutils.GetBlockedMessages(engineResponses), nil, nil
+		return false, webhookutils.GetBlockedMessages(engineResponses), nil, nil, engineResponses
 	}
 
 	if !verifiedImageData.IsEmpty() {
@@ -147,7 +147,7 @@ func (h *imageVerificationHandler) handleVerifyImages(
 	go h.handleAudit(ctx, policyContext.NewResource(), request, nil, engineResponses...)
 
 	warnings := webhookutils.GetWarningMessages(engineResponses)
-	return true, "", jsonutils.JoinPatches(patch.ConvertPatches(patches...)...), warnings
+	return true, "", jsonutils.JoinPatches(patch.ConvertPatches(patches...)...), warnings, engineResponses
 }
 
 func hasAnnotations(context *engine.PolicyContext) bool {
@@ -186,10 +186,7 @@ func (v *imageVerificationHandler) handleAudit(
 			if createReport {
 				report := reportutils.BuildAdmissionReport(resource, request, engineResponses...)
 				if len(report.GetResults()) > 0 {
-					err := v.reportsBreaker.Do(ctx, func(ctx context.Context) error {
-						_, err := reportutils.CreateReport(context.Background(), report, v.kyvernoClient)
-						return err
-					})
+					err := v.reportsWriter.Write(ctx, report)
 					if err != nil {
 						v.log.Error(err, "failed to create report")
 					}
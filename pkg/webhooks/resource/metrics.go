@@ -0,0 +1,56 @@
+package resource
+
+import (
+	"context"
+
+	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/metrics"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/metric"
+)
+
+// newAuditPoolMetrics exposes the depth and capacity of the audit queue, it returns the counter of the audit
+// work dropped because the admission controller is overloaded
+func newAuditPoolMetrics(pool *auditPool) sdkmetric.Int64Counter {
+	logger := logging.WithName("audit-pool")
+	meter := otel.GetMeterProvider().Meter(metrics.MeterName)
+	depth, err := meter.Int64ObservableGauge(
+		"kyverno_audit_queue_depth",
+		sdkmetric.WithDescription("can be used to track the number of audit work units waiting to be processed"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_audit_queue_depth")
+	}
+	capacity, err := meter.Int64ObservableGauge(
+		"kyverno_audit_queue_capacity",
+		sdkmetric.WithDescription("can be used to track the maximum number of audit work units waiting to be processed"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_audit_queue_capacity")
+	}
+	if depth != nil && capacity != nil {
+		if _, err := meter.RegisterCallback(func(_ context.Context, observer sdkmetric.Observer) error {
+			observer.ObserveInt64(depth, int64(pool.WaitingTasks()))
+			observer.ObserveInt64(capacity, int64(pool.MaxCapacity()))
+			return nil
+		}, depth, capacity); err != nil {
+			logger.Error(err, "failed to register callback")
+		}
+	}
+	drops, err := meter.Int64Counter(
+		"kyverno_audit_queue_drops",
+		sdkmetric.WithDescription("can be used to track the number of audit work units dropped because the admission controller was overloaded"),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to create instrument, kyverno_audit_queue_drops")
+	}
+	return drops
+}
+
+// dropAudit records audit work dropped for the given reason
+func (h *resourceHandlers) dropAudit(ctx context.Context, reason string) {
+	if h.auditDrops != nil {
+		h.auditDrops.Add(ctx, 1, sdkmetric.WithAttributes(attribute.String("reason", reason)))
+	}
+}
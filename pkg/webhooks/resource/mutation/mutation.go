@@ -7,18 +7,19 @@ import (
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
-	"github.com/kyverno/kyverno/pkg/breaker"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/engine"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/mutate/patch"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/informers"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/toggle"
 	"github.com/kyverno/kyverno/pkg/tracing"
 	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
 	jsonutils "github.com/kyverno/kyverno/pkg/utils/json"
+	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
 	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
@@ -26,14 +27,13 @@ import (
 	"gomodules.xyz/jsonpatch/v2"
 	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	corev1listers "k8s.io/client-go/listers/core/v1"
 )
 
 type MutationHandler interface {
 	// HandleMutation handles validating webhook admission request
 	// If there are no errors in validating rule we apply generation rules
 	// patchedResource is the (resource + patches) after applying mutation rules
-	HandleMutation(context.Context, handlers.AdmissionRequest, []kyvernov1.PolicyInterface, *engine.PolicyContext, time.Time, config.Configuration) ([]byte, []string, error)
+	HandleMutation(context.Context, handlers.AdmissionRequest, []kyvernov1.PolicyInterface, *engine.PolicyContext, time.Time, config.Configuration) ([]byte, []string, []engineapi.EngineResponse, error)
 }
 
 func NewMutationHandler(
@@ -41,22 +41,22 @@ func NewMutationHandler(
 	kyvernoClient versioned.Interface,
 	engine engineapi.Engine,
 	eventGen event.Interface,
-	nsLister corev1listers.NamespaceLister,
+	nsLabels informers.NamespaceLabels,
 	metrics metrics.MetricsConfigManager,
 	admissionReports bool,
 	reportsConfig reportutils.ReportingConfiguration,
-	reportsBreaker breaker.Breaker,
+	reportsWriter reportutils.ReportWriter,
 ) MutationHandler {
 	return &mutationHandler{
 		log:              log,
 		kyvernoClient:    kyvernoClient,
 		engine:           engine,
 		eventGen:         eventGen,
-		nsLister:         nsLister,
+		nsLabels:         nsLabels,
 		metrics:          metrics,
 		admissionReports: admissionReports,
 		reportsConfig:    reportsConfig,
-		reportsBreaker:   reportsBreaker,
+		reportsWriter:    reportsWriter,
 	}
 }
 
@@ -65,11 +65,11 @@ type mutationHandler struct {
 	kyvernoClient    versioned.Interface
 	engine           engineapi.Engine
 	eventGen         event.Interface
-	nsLister         corev1listers.NamespaceLister
+	nsLabels         informers.NamespaceLabels
 	metrics          metrics.MetricsConfigManager
 	admissionReports bool
 	reportsConfig    reportutils.ReportingConfiguration
-	reportsBreaker   breaker.Breaker
+	reportsWriter    reportutils.ReportWriter
 }
 
 func (h *mutationHandler) HandleMutation(
@@ -79,15 +79,15 @@ func (h *mutationHandler) HandleMutation(
 	policyContext *engine.PolicyContext,
 	admissionRequestTimestamp time.Time,
 	cfg config.Configuration,
-) ([]byte, []string, error) {
+) ([]byte, []string, []engineapi.EngineResponse, error) {
 	mutatePatches, mutateEngineResponses, err := h.applyMutations(ctx, request, policies, policyContext, cfg)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	if toggle.FromContext(ctx).DumpMutatePatches() {
 		h.log.V(2).Info("", "generated patches", string(mutatePatches))
 	}
-	return mutatePatches, webhookutils.GetWarningMessages(mutateEngineResponses), nil
+	return mutatePatches, webhookutils.GetWarningMessages(mutateEngineResponses), mutateEngineResponses, nil
 }
 
 // applyMutations handles mutating webhook admission request
@@ -120,7 +120,7 @@ func (v *mutationHandler) applyMutations(
 			func(ctx context.Context, span trace.Span) error {
 				v.log.V(3).Info("applying policy mutate rules", "policy", policy.GetName())
 				currentContext := policyContext.WithPolicy(policy)
-				if policy.GetSpec().GetFailurePolicy(ctx) == kyvernov1.Fail {
+				if policyutils.GetFailurePolicy(ctx, policy) == kyvernov1.Fail {
 					failurePolicy = kyvernov1.Fail
 				}
 
@@ -174,7 +174,7 @@ func (v *mutationHandler) applyMutations(
 
 func (h *mutationHandler) applyMutation(ctx context.Context, request admissionv1.AdmissionRequest, policyContext *engine.PolicyContext, failurePolicy kyvernov1.FailurePolicyType) (*engineapi.EngineResponse, []jsonpatch.JsonPatchOperation, error) {
 	if request.Kind.Kind != "Namespace" && request.Namespace != "" {
-		policyContext = policyContext.WithNamespaceLabels(engineutils.GetNamespaceSelectorsFromNamespaceLister(request.Kind.Kind, request.Namespace, h.nsLister, h.log))
+		policyContext = policyContext.WithNamespaceLabels(engineutils.GetNamespaceLabels(request.Kind.Kind, request.Namespace, h.nsLabels, h.log))
 	}
 
 	engineResponse := h.engine.Mutate(ctx, policyContext)
@@ -201,10 +201,7 @@ func (h *mutationHandler) createReports(
 ) error {
 	report := reportutils.BuildMutationReport(resource, request.AdmissionRequest, engineResponses...)
 	if len(report.GetResults()) > 0 {
-		err := h.reportsBreaker.Do(ctx, func(ctx context.Context) error {
-			_, err := reportutils.CreateReport(ctx, report, h.kyvernoClient)
-			return err
-		})
+		err := h.reportsWriter.Write(ctx, report)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,95 @@
+package resource
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/go-logr/logr"
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// skippedPolicies returns the policies listed in the skip policies annotation of the admitted resource,
+// the annotation is ignored unless the requester is allowed to skip policies
+func (h *resourceHandlers) skippedPolicies(logger logr.Logger, request handlers.AdmissionRequest) sets.Set[string] {
+	annotation := h.configuration.GetSkipPoliciesAnnotation()
+	if annotation == "" || len(request.Object.Raw) == 0 {
+		return nil
+	}
+	var object metav1.PartialObjectMetadata
+	if err := json.Unmarshal(request.Object.Raw, &object); err != nil {
+		return nil
+	}
+	value, ok := object.GetAnnotations()[annotation]
+	if !ok {
+		return nil
+	}
+	if !h.configuration.CanSkipPolicies(request.UserInfo.Username, request.UserInfo.Groups) {
+		logger.V(2).Info("skip policies annotation ignored, user is not allowed to skip policies", "annotation", annotation, "user", request.UserInfo.Username)
+		return nil
+	}
+	skipped := sets.New[string]()
+	for _, name := range strings.Split(value, ",") {
+		if name := strings.TrimSpace(name); name != "" {
+			skipped.Insert(name)
+		}
+	}
+	return skipped
+}
+
+// withoutSkippedPolicies removes the skipped policies, cluster policies are identified by their name
+// and namespaced policies by their namespace and name
+func withoutSkippedPolicies(skipped sets.Set[string], policies []kyvernov1.PolicyInterface) ([]kyvernov1.PolicyInterface, []kyvernov1.PolicyInterface) {
+	if skipped.Len() == 0 {
+		return policies, nil
+	}
+	var kept, removed []kyvernov1.PolicyInterface
+	for _, policy := range policies {
+		key := policy.GetName()
+		if policy.IsNamespaced() {
+			key = policy.GetNamespace() + "/" + key
+		}
+		if skipped.Has(key) {
+			removed = append(removed, policy)
+		} else {
+			kept = append(kept, policy)
+		}
+	}
+	return kept, removed
+}
+
+// skipPolicies removes the skipped policies from each of the given lists, skipped policies are logged
+// and reported with an event when report is true
+func (h *resourceHandlers) skipPolicies(logger logr.Logger, request handlers.AdmissionRequest, report bool, policies ...*[]kyvernov1.PolicyInterface) {
+	skipped := h.skippedPolicies(logger, request)
+	if skipped.Len() == 0 {
+		return
+	}
+	reported := sets.New[string]()
+	for _, list := range policies {
+		kept, removed := withoutSkippedPolicies(skipped, *list)
+		*list = kept
+		for _, policy := range removed {
+			key := policy.GetNamespace() + "/" + policy.GetName()
+			if reported.Has(key) {
+				continue
+			}
+			reported.Insert(key)
+			logger.Info("policy skipped by annotation", "policy", policy.GetName(), "namespace", policy.GetNamespace(), "user", request.UserInfo.Username)
+			if report {
+				resource := corev1.ObjectReference{
+					APIVersion: schema.GroupVersion{Group: request.Kind.Group, Version: request.Kind.Version}.String(),
+					Kind:       request.Kind.Kind,
+					Name:       request.Name,
+					Namespace:  request.Namespace,
+				}
+				h.eventGen.Add(event.NewPolicySkippedEvent(event.AdmissionController, policy, resource, h.configuration.GetSkipPoliciesAnnotation(), request.UserInfo.Username))
+			}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package resource
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/kyverno/kyverno/pkg/logging"
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	"gotest.tools/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func Test_skippedPolicies(t *testing.T) {
+	configuration := config.NewDefaultConfiguration(false)
+	configuration.Load(&corev1.ConfigMap{
+		Data: map[string]string{
+			"skipPoliciesAnnotation": "kyverno.io/skip-policies",
+			"skipPoliciesUsernames":  "admin,system:serviceaccount:ops:*",
+			"skipPoliciesGroups":     "break-glass",
+		},
+	})
+	h := &resourceHandlers{configuration: configuration}
+	pod := []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"test","annotations":{"kyverno.io/skip-policies":"policy-a, ns/policy-b"}}}`)
+	tests := []struct {
+		name   string
+		user   authenticationv1.UserInfo
+		object []byte
+		want   []string
+	}{{
+		name:   "allowed username",
+		user:   authenticationv1.UserInfo{Username: "admin"},
+		object: pod,
+		want:   []string{"ns/policy-b", "policy-a"},
+	}, {
+		name:   "allowed service account",
+		user:   authenticationv1.UserInfo{Username: "system:serviceaccount:ops:deployer"},
+		object: pod,
+		want:   []string{"ns/policy-b", "policy-a"},
+	}, {
+		name:   "allowed group",
+		user:   authenticationv1.UserInfo{Username: "bob", Groups: []string{"break-glass"}},
+		object: pod,
+		want:   []string{"ns/policy-b", "policy-a"},
+	}, {
+		name:   "not allowed",
+		user:   authenticationv1.UserInfo{Username: "bob", Groups: []string{"developers"}},
+		object: pod,
+		want:   []string{},
+	}, {
+		name:   "no annotation",
+		user:   authenticationv1.UserInfo{Username: "admin"},
+		object: []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"test"}}`),
+		want:   []string{},
+	}, {
+		name: "no object",
+		user: authenticationv1.UserInfo{Username: "admin"},
+		want: []string{},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := handlers.AdmissionRequest{
+				AdmissionRequest: admissionv1.AdmissionRequest{
+					UserInfo: tt.user,
+					Object:   runtime.RawExtension{Raw: tt.object},
+				},
+			}
+			got := h.skippedPolicies(logging.GlobalLogger(), request)
+			assert.DeepEqual(t, sets.List(got), tt.want)
+		})
+	}
+}
+
+func Test_skippedPolicies_disabled(t *testing.T) {
+	h := &resourceHandlers{configuration: config.NewDefaultConfiguration(false)}
+	request := handlers.AdmissionRequest{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			UserInfo: authenticationv1.UserInfo{Username: "admin"},
+			Object:   runtime.RawExtension{Raw: []byte(`{"metadata":{"annotations":{"kyverno.io/skip-policies":"policy-a"}}}`)},
+		},
+	}
+	assert.Equal(t, h.skippedPolicies(logging.GlobalLogger(), request).Len(), 0)
+}
+
+func Test_withoutSkippedPolicies(t *testing.T) {
+	clusterPolicy := &kyvernov1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy-a"}}
+	policy := &kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Name: "policy-b", Namespace: "ns"}}
+	other := &kyvernov1.Policy{ObjectMeta: metav1.ObjectMeta{Name: "policy-a", Namespace: "ns"}}
+	policies := []kyvernov1.PolicyInterface{clusterPolicy, policy, other}
+	kept, removed := withoutSkippedPolicies(sets.New("policy-a", "ns/policy-b"), policies)
+	assert.Equal(t, len(kept), 1)
+	assert.Equal(t, kept[0], kyvernov1.PolicyInterface(other))
+	assert.Equal(t, len(removed), 2)
+	assert.Equal(t, removed[0], kyvernov1.PolicyInterface(clusterPolicy))
+	assert.Equal(t, removed[1], kyvernov1.PolicyInterface(policy))
+	kept, removed = withoutSkippedPolicies(nil, policies)
+	assert.Equal(t, len(kept), 3)
+	assert.Equal(t, len(removed), 0)
+}
@@ -10,13 +10,16 @@ import (
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
 	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
 	"github.com/kyverno/kyverno/pkg/autogen"
+	"github.com/kyverno/kyverno/pkg/background/dryrun"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
+	"github.com/kyverno/kyverno/pkg/engine/policycontext"
 	"github.com/kyverno/kyverno/pkg/event"
 	datautils "github.com/kyverno/kyverno/pkg/utils/data"
 	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
 	"github.com/kyverno/kyverno/pkg/webhooks/resource/generation"
 	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
 	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // handleBackgroundApplies applies generate and mutateExisting policies, and creates update requests for background reconcile
@@ -50,15 +53,13 @@ func (h *resourceHandlers) handleMutateExisting(ctx context.Context, logger logr
 		if policyNew == nil {
 			continue
 		}
-		logger.V(4).Info("update request for mutateExisting policy")
-
 		// skip rules that don't specify the DELETE operation in case the admission request is of type DELETE
-		var skipped []string
-		for _, rule := range autogen.ComputeRules(policy, "") {
-			if request.AdmissionRequest.Operation == admissionv1.Delete && !webhookutils.MatchDeleteOperation(rule) {
-				skipped = append(skipped, rule.Name)
-			}
+		skipped := skippedOnDelete(request.AdmissionRequest, policy)
+		if policyNew.GetSpec().DryRun {
+			h.simulateBackgroundApply(ctx, logger, policyContext.WithPolicy(policyNew), event.MutateExistingController, skipped)
+			continue
 		}
+		logger.V(4).Info("update request for mutateExisting policy")
 
 		var rules []engineapi.RuleResponse
 		policyContext := policyContext.WithPolicy(policyNew)
@@ -106,13 +107,59 @@ func (h *resourceHandlers) handleGenerate(ctx context.Context, logger logr.Logge
 		return
 	}
 
-	gh := generation.NewGenerationHandler(logger, h.engine, h.client, h.kyvernoClient, h.nsLister, h.urLister, h.cpolLister, h.polLister, h.urGenerator, h.eventGen, h.metricsConfig, h.backgroundServiceAccountName, h.reportsServiceAccountName)
+	gh := generation.NewGenerationHandler(logger, h.engine, h.client, h.kyvernoClient, h.nsLabels, h.urLister, h.cpolLister, h.polLister, h.urGenerator, h.eventGen, h.metricsConfig, h.backgroundServiceAccountName, h.reportsServiceAccountName)
 	var policies []kyvernov1.PolicyInterface
 	for _, p := range generatePolicies {
 		new := skipBackgroundRequests(p, logger, h.backgroundServiceAccountName, policyContext.AdmissionInfo().AdmissionUserInfo.Username)
-		if new != nil {
-			policies = append(policies, new)
+		if new == nil {
+			continue
 		}
+		if new.GetSpec().DryRun {
+			h.simulateBackgroundApply(ctx, logger, policyContext.WithPolicy(new), event.GeneratePolicyController, skippedOnDelete(request.AdmissionRequest, new))
+			continue
+		}
+		policies = append(policies, new)
 	}
 	gh.Handle(ctx, request.AdmissionRequest, policies, policyContext)
 }
+
+// skippedOnDelete returns the rules of the policy that don't specify the DELETE operation when the admission request is of type DELETE
+func skippedOnDelete(request admissionv1.AdmissionRequest, policy kyvernov1.PolicyInterface) []string {
+	var skipped []string
+	if request.Operation != admissionv1.Delete {
+		return skipped
+	}
+	for _, rule := range autogen.ComputeRules(policy, "") {
+		if !webhookutils.MatchDeleteOperation(rule) {
+			skipped = append(skipped, rule.Name)
+		}
+	}
+	return skipped
+}
+
+// simulateBackgroundApply computes the side effects of a policy in dry run mode, they are reported
+// through events instead of creating update requests
+func (h *resourceHandlers) simulateBackgroundApply(ctx context.Context, logger logr.Logger, policyContext *policycontext.PolicyContext, source event.Source, skipped []string) {
+	policy := policyContext.Policy()
+	logger = logger.WithValues("policy", policy.GetName(), "dryRun", true)
+	response := dryrun.Simulate(ctx, logger, h.engine, h.client, policyContext)
+	var resources []*unstructured.Unstructured
+	for _, rule := range response.PolicyResponse.Rules {
+		if datautils.SliceContains(skipped, rule.Name()) {
+			continue
+		}
+		switch rule.Status() {
+		case engineapi.RuleStatusPass:
+			if target, _, _ := rule.PatchedTarget(); target != nil {
+				resources = append(resources, target)
+			}
+			resources = append(resources, rule.GeneratedResources()...)
+		case engineapi.RuleStatusError:
+			logger.Info("failed to simulate rule", "rule", rule.Name(), "message", rule.Message())
+		}
+	}
+	for _, resource := range resources {
+		logger.V(4).Info("simulated background apply", "gvk", resource.GroupVersionKind().String(), "namespace", resource.GetNamespace(), "name", resource.GetName())
+	}
+	h.eventGen.Add(event.NewDryRunEvents(source, policy, resources)...)
+}
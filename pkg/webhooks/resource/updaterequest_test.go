@@ -0,0 +1,39 @@
+package resource
+
+import (
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	"gotest.tools/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+)
+
+func Test_skippedOnDelete(t *testing.T) {
+	policy := &kyvernov1.ClusterPolicy{
+		Spec: kyvernov1.Spec{
+			Rules: []kyvernov1.Rule{{
+				Name: "on-delete",
+				MatchResources: kyvernov1.MatchResources{
+					Any: kyvernov1.ResourceFilters{{
+						ResourceDescription: kyvernov1.ResourceDescription{
+							Kinds:      []string{"ConfigMap"},
+							Operations: []kyvernov1.AdmissionOperation{kyvernov1.Delete},
+						},
+					}},
+				},
+			}, {
+				Name: "on-create",
+				MatchResources: kyvernov1.MatchResources{
+					Any: kyvernov1.ResourceFilters{{
+						ResourceDescription: kyvernov1.ResourceDescription{
+							Kinds:      []string{"ConfigMap"},
+							Operations: []kyvernov1.AdmissionOperation{kyvernov1.Create},
+						},
+					}},
+				},
+			}},
+		},
+	}
+	assert.DeepEqual(t, skippedOnDelete(admissionv1.AdmissionRequest{Operation: admissionv1.Create}, policy), []string(nil))
+	assert.DeepEqual(t, skippedOnDelete(admissionv1.AdmissionRequest{Operation: admissionv1.Delete}, policy), []string{"on-create"})
+}
@@ -7,24 +7,24 @@ import (
 
 	"github.com/go-logr/logr"
 	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
-	"github.com/kyverno/kyverno/pkg/breaker"
 	"github.com/kyverno/kyverno/pkg/client/clientset/versioned"
 	"github.com/kyverno/kyverno/pkg/config"
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 	"github.com/kyverno/kyverno/pkg/engine/policycontext"
 	"github.com/kyverno/kyverno/pkg/event"
+	"github.com/kyverno/kyverno/pkg/informers"
 	"github.com/kyverno/kyverno/pkg/metrics"
 	"github.com/kyverno/kyverno/pkg/policycache"
 	"github.com/kyverno/kyverno/pkg/tracing"
 	admissionutils "github.com/kyverno/kyverno/pkg/utils/admission"
 	engineutils "github.com/kyverno/kyverno/pkg/utils/engine"
+	policyutils "github.com/kyverno/kyverno/pkg/utils/policy"
 	reportutils "github.com/kyverno/kyverno/pkg/utils/report"
 	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
 	webhookutils "github.com/kyverno/kyverno/pkg/webhooks/utils"
 	"go.opentelemetry.io/otel/trace"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	corev1listers "k8s.io/client-go/listers/core/v1"
 )
 
 type ValidationHandler interface {
@@ -45,9 +45,9 @@ func NewValidationHandler(
 	admissionReports bool,
 	metrics metrics.MetricsConfigManager,
 	cfg config.Configuration,
-	nsLister corev1listers.NamespaceLister,
+	nsLabels informers.NamespaceLabels,
 	reportConfig reportutils.ReportingConfiguration,
-	reportsBreaker breaker.Breaker,
+	reportsWriter reportutils.ReportWriter,
 ) ValidationHandler {
 	return &validationHandler{
 		log:              log,
@@ -59,9 +59,9 @@ func NewValidationHandler(
 		admissionReports: admissionReports,
 		metrics:          metrics,
 		cfg:              cfg,
-		nsLister:         nsLister,
+		nsLabels:         nsLabels,
 		reportConfig:     reportConfig,
-		reportsBreaker:   reportsBreaker,
+		reportsWriter:    reportsWriter,
 	}
 }
 
@@ -75,9 +75,9 @@ type validationHandler struct {
 	admissionReports bool
 	metrics          metrics.MetricsConfigManager
 	cfg              config.Configuration
-	nsLister         corev1listers.NamespaceLister
+	nsLabels         informers.NamespaceLabels
 	reportConfig     reportutils.ReportingConfiguration
-	reportsBreaker   breaker.Breaker
+	reportsWriter    reportutils.ReportWriter
 }
 
 func (v *validationHandler) HandleValidationEnforce(
@@ -109,7 +109,7 @@ func (v *validationHandler) HandleValidationEnforce(
 			fmt.Sprintf("POLICY %s/%s", policy.GetNamespace(), policy.GetName()),
 			func(ctx context.Context, span trace.Span) {
 				policyContext := policyContext.WithPolicy(policy)
-				if policy.GetSpec().GetFailurePolicy(ctx) == kyvernov1.Fail {
+				if policyutils.GetFailurePolicy(ctx, policy) == kyvernov1.Fail {
 					failurePolicy = kyvernov1.Fail
 				}
 
@@ -240,7 +240,7 @@ func (v *validationHandler) buildPolicyContextFromAdmissionRequest(logger logr.L
 	}
 	namespaceLabels := make(map[string]string)
 	if request.Kind.Kind != "Namespace" && request.Namespace != "" {
-		namespaceLabels = engineutils.GetNamespaceSelectorsFromNamespaceLister(request.Kind.Kind, request.Namespace, v.nsLister, logger)
+		namespaceLabels = engineutils.GetNamespaceLabels(request.Kind.Kind, request.Namespace, v.nsLabels, logger)
 	}
 	policyContext = policyContext.WithNamespaceLabels(namespaceLabels)
 	return policyContext, nil
@@ -254,10 +254,7 @@ func (v *validationHandler) createReports(
 ) error {
 	report := reportutils.BuildAdmissionReport(resource, request.AdmissionRequest, engineResponses...)
 	if len(report.GetResults()) > 0 {
-		err := v.reportsBreaker.Do(ctx, func(ctx context.Context) error {
-			_, err := reportutils.CreateReport(ctx, report, v.kyvernoClient)
-			return err
-		})
+		err := v.reportsWriter.Write(ctx, report)
 		if err != nil {
 			return err
 		}
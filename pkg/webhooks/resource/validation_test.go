@@ -2086,6 +2086,9 @@ func TestValidate_failure_action_overrides(t *testing.T) {
 		imageverifycache.DisabledImageVerifyCache(),
 		factories.DefaultContextLoaderFactory(nil),
 		nil,
+		nil,
+		nil,
+		nil,
 	)
 	for i, tc := range testcases {
 		t.Run(fmt.Sprintf("case %d", i), func(t *testing.T) {
@@ -2188,6 +2191,9 @@ func Test_RuleSelector(t *testing.T) {
 		imageverifycache.DisabledImageVerifyCache(),
 		factories.DefaultContextLoaderFactory(nil),
 		nil,
+		nil,
+		nil,
+		nil,
 	)
 	resp := eng.Validate(
 		context.TODO(),
@@ -3,8 +3,12 @@ package webhooks
 import (
 	"context"
 	"crypto/tls"
-	"fmt"
+	"crypto/x509"
+	"errors"
+	"net"
 	"net/http"
+	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -14,20 +18,47 @@ import (
 	"github.com/kyverno/kyverno/pkg/config"
 	"github.com/kyverno/kyverno/pkg/logging"
 	"github.com/kyverno/kyverno/pkg/metrics"
+	"github.com/kyverno/kyverno/pkg/sharding"
+	tlsutils "github.com/kyverno/kyverno/pkg/tls"
 	"github.com/kyverno/kyverno/pkg/toggle"
 	controllerutils "github.com/kyverno/kyverno/pkg/utils/controller"
 	runtimeutils "github.com/kyverno/kyverno/pkg/utils/runtime"
+	"github.com/kyverno/kyverno/pkg/webhooks/dump"
 	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
 	admissionv1 "k8s.io/api/admission/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	rbacv1listers "k8s.io/client-go/listers/rbac/v1"
 )
 
 // DebugModeOptions holds the options to configure debug mode
 type DebugModeOptions struct {
-	// DumpPayload is used to activate/deactivate debug mode.
+	// DumpPayload is used to activate/deactivate debug mode, the configuration overrides it when set.
 	DumpPayload bool
+	// DumpSink receives the payload dumps, they are logged when nil.
+	DumpSink dump.Sink
+	// DumpSampleRate is the ratio of requests dumped.
+	DumpSampleRate float64
+	// DumpRedactFields are the dump fields redacted before writing.
+	DumpRedactFields []string
+}
+
+// DumpOptions returns the options of the dump handlers, debug mode can be toggled from the configuration at runtime
+func (o DebugModeOptions) DumpOptions(configuration config.Configuration) handlers.DumpOptions {
+	return handlers.DumpOptions{
+		Enabled: func() bool {
+			if configuration != nil {
+				if dumpPayload, ok := configuration.GetDumpPayload(); ok {
+					return dumpPayload
+				}
+			}
+			return o.DumpPayload
+		},
+		Sink:         o.DumpSink,
+		SampleRate:   o.DumpSampleRate,
+		RedactFields: o.DumpRedactFields,
+	}
 }
 
 type Server interface {
@@ -47,6 +78,11 @@ type GlobalContextHandlers interface {
 	Validate(context.Context, logr.Logger, handlers.AdmissionRequest, time.Time) admissionv1.AdmissionResponse
 }
 
+type ConfigHandlers interface {
+	// Validate performs the validation check on the kyverno config map
+	Validate(context.Context, logr.Logger, handlers.AdmissionRequest, time.Time) admissionv1.AdmissionResponse
+}
+
 type PolicyHandlers interface {
 	// Mutate performs the mutation of policy resources
 	Mutate(context.Context, logr.Logger, handlers.AdmissionRequest, time.Time) admissionv1.AdmissionResponse
@@ -59,18 +95,87 @@ type ResourceHandlers interface {
 	Mutate(context.Context, logr.Logger, handlers.AdmissionRequest, string, time.Time) admissionv1.AdmissionResponse
 	// Validate performs the validation check on kube resources
 	Validate(context.Context, logr.Logger, handlers.AdmissionRequest, string, time.Time) admissionv1.AdmissionResponse
+	// Audit processes the audit policies for a request forwarded by another replica
+	Audit(context.Context, logr.Logger, handlers.AdmissionRequest)
+	// Evaluate applies the policies matching a request without side effects
+	Evaluate(context.Context, logr.Logger, handlers.AdmissionRequest) handlers.Evaluation
 }
 
 type server struct {
-	server      *http.Server
+	server *http.Server
+	// probeServer serves the health probes over plain HTTP when a probe address is configured
+	probeServer *http.Server
 	runtime     runtimeutils.Runtime
 	mwcClient   controllerutils.DeleteCollectionClient
 	vwcClient   controllerutils.DeleteCollectionClient
 	leaseClient controllerutils.DeleteClient
+	// draining is set when the server stops, the readiness probe fails from then on
+	draining *atomic.Bool
+	// shutdownGracePeriod is the time in-flight requests are given to complete when the server stops
+	shutdownGracePeriod time.Duration
 }
 
 type TlsProvider func() ([]byte, []byte, error)
 
+// TLSOptions holds the options to configure the TLS server
+type TLSOptions struct {
+	// MinVersion is the minimum TLS version accepted, TLS 1.2 is used when zero.
+	MinVersion uint16
+	// CipherSuites are the cipher suites accepted with TLS 1.2, defaults are used when empty.
+	CipherSuites []uint16
+	// ClientAuthProvider returns the CA bundle and common names used to verify client certificates,
+	// client certificates are not verified when nil.
+	ClientAuthProvider func() (ClientAuthentication, error)
+}
+
+func (o TLSOptions) config(tlsProvider TlsProvider) *tls.Config {
+	cfg := &tls.Config{
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			certPem, keyPem, err := tlsProvider()
+			if err != nil {
+				return nil, err
+			}
+			pair, err := tls.X509KeyPair(certPem, keyPem)
+			if err != nil {
+				return nil, err
+			}
+			return &pair, nil
+		},
+		MinVersion:   o.MinVersion,
+		CipherSuites: o.CipherSuites,
+	}
+	if cfg.MinVersion == 0 {
+		cfg.MinVersion = tls.VersionTLS12
+	}
+	if len(cfg.CipherSuites) == 0 {
+		cfg.CipherSuites = tlsutils.DefaultCipherSuites
+	}
+	if o.ClientAuthProvider != nil {
+		verifier := newClientVerifier(o.ClientAuthProvider)
+		// probes don't present a certificate, the certificate is only required on webhook paths
+		cfg.ClientAuth = tls.RequestClientCert
+		cfg.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return nil
+			}
+			return verifier.verify(rawCerts)
+		}
+	}
+	return cfg
+}
+
+// requireClientCertificate rejects requests without a verified client certificate, except on the given paths
+func requireClientCertificate(inner http.Handler, exemptPaths ...string) http.Handler {
+	exempt := sets.New(exemptPaths...)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !exempt.Has(r.URL.Path) && (r.TLS == nil || len(r.TLS.PeerCertificates) == 0) {
+			http.Error(w, "client certificate required", http.StatusUnauthorized)
+			return
+		}
+		inner.ServeHTTP(w, r)
+	})
+}
+
 // NewServer creates new instance of server accordingly to given configuration
 func NewServer(
 	ctx context.Context,
@@ -78,10 +183,12 @@ func NewServer(
 	resourceHandlers ResourceHandlers,
 	exceptionHandlers ExceptionHandlers,
 	globalContextHandlers GlobalContextHandlers,
+	configHandlers ConfigHandlers,
 	configuration config.Configuration,
 	metricsConfig metrics.MetricsConfigManager,
 	debugModeOpts DebugModeOptions,
 	tlsProvider TlsProvider,
+	tlsOptions TLSOptions,
 	mwcClient controllerutils.DeleteCollectionClient,
 	vwcClient controllerutils.DeleteCollectionClient,
 	leaseClient controllerutils.DeleteClient,
@@ -89,13 +196,23 @@ func NewServer(
 	rbLister rbacv1listers.RoleBindingLister,
 	crbLister rbacv1listers.ClusterRoleBindingLister,
 	discovery dclient.IDiscovery,
+	webhookServerAddress string,
 	webhookServerPort int32,
+	probeAddress string,
+	auditDistributor sharding.Distributor,
+	shutdownGracePeriod time.Duration,
+	evaluateAuthenticator handlers.Authenticator,
+	maxWarningBytes int,
+	auditEventsAuthenticator handlers.Authenticator,
+	auditEvents handlers.AuditEventsHandler,
 ) Server {
 	mux := httprouter.New()
+	draining := &atomic.Bool{}
 	resourceLogger := logger.WithName("resource")
 	policyLogger := logger.WithName("policy")
 	exceptionLogger := logger.WithName("exception")
 	globalContextLogger := logger.WithName("globalcontext")
+	configLogger := logger.WithName("config")
 	verifyLogger := logger.WithName("verify")
 	registerWebhookHandlers(
 		mux,
@@ -104,9 +221,10 @@ func NewServer(
 		resourceHandlers.Mutate,
 		func(handler handlers.AdmissionHandler) handlers.HttpHandler {
 			return handler.
+				WithWarningBudget(maxWarningBytes).
 				WithFilter(configuration).
 				WithProtection(toggle.FromContext(ctx).ProtectManagedResources()).
-				WithDump(debugModeOpts.DumpPayload).
+				WithDump(debugModeOpts.DumpOptions(configuration)).
 				WithTopLevelGVK(discovery).
 				WithRoles(rbLister, crbLister).
 				WithOperationFilter(admissionv1.Create, admissionv1.Update, admissionv1.Connect).
@@ -121,9 +239,10 @@ func NewServer(
 		resourceHandlers.Validate,
 		func(handler handlers.AdmissionHandler) handlers.HttpHandler {
 			return handler.
+				WithWarningBudget(maxWarningBytes).
 				WithFilter(configuration).
 				WithProtection(toggle.FromContext(ctx).ProtectManagedResources()).
-				WithDump(debugModeOpts.DumpPayload).
+				WithDump(debugModeOpts.DumpOptions(configuration)).
 				WithTopLevelGVK(discovery).
 				WithRoles(rbLister, crbLister).
 				WithMetrics(resourceLogger, metricsConfig.Config(), metrics.WebhookValidating).
@@ -134,7 +253,7 @@ func NewServer(
 		"POST",
 		config.PolicyMutatingWebhookServicePath,
 		handlers.FromAdmissionFunc("MUTATE", policyHandlers.Mutate).
-			WithDump(debugModeOpts.DumpPayload).
+			WithDump(debugModeOpts.DumpOptions(configuration)).
 			WithMetrics(policyLogger, metricsConfig.Config(), metrics.WebhookMutating).
 			WithAdmission(policyLogger.WithName("mutate")).
 			ToHandlerFunc("MUTATE"),
@@ -143,7 +262,7 @@ func NewServer(
 		"POST",
 		config.PolicyValidatingWebhookServicePath,
 		handlers.FromAdmissionFunc("VALIDATE", policyHandlers.Validate).
-			WithDump(debugModeOpts.DumpPayload).
+			WithDump(debugModeOpts.DumpOptions(configuration)).
 			WithSubResourceFilter().
 			WithMetrics(policyLogger, metricsConfig.Config(), metrics.WebhookValidating).
 			WithAdmission(policyLogger.WithName("validate")).
@@ -153,7 +272,7 @@ func NewServer(
 		"POST",
 		config.ExceptionValidatingWebhookServicePath,
 		handlers.FromAdmissionFunc("VALIDATE", exceptionHandlers.Validate).
-			WithDump(debugModeOpts.DumpPayload).
+			WithDump(debugModeOpts.DumpOptions(configuration)).
 			WithSubResourceFilter().
 			WithMetrics(exceptionLogger, metricsConfig.Config(), metrics.WebhookValidating).
 			WithAdmission(exceptionLogger.WithName("validate")).
@@ -163,12 +282,22 @@ func NewServer(
 		"POST",
 		config.GlobalContextValidatingWebhookServicePath,
 		handlers.FromAdmissionFunc("VALIDATE", globalContextHandlers.Validate).
-			WithDump(debugModeOpts.DumpPayload).
+			WithDump(debugModeOpts.DumpOptions(configuration)).
 			WithSubResourceFilter().
 			WithMetrics(globalContextLogger, metricsConfig.Config(), metrics.WebhookValidating).
 			WithAdmission(globalContextLogger.WithName("validate")).
 			ToHandlerFunc("VALIDATE"),
 	)
+	mux.HandlerFunc(
+		"POST",
+		config.ConfigValidatingWebhookServicePath,
+		handlers.FromAdmissionFunc("VALIDATE", configHandlers.Validate).
+			WithDump(debugModeOpts.DumpOptions(configuration)).
+			WithSubResourceFilter().
+			WithMetrics(configLogger, metricsConfig.Config(), metrics.WebhookValidating).
+			WithAdmission(configLogger.WithName("validate")).
+			ToHandlerFunc("VALIDATE"),
+	)
 	mux.HandlerFunc(
 		"POST",
 		config.VerifyMutatingWebhookServicePath,
@@ -176,45 +305,71 @@ func NewServer(
 			WithAdmission(verifyLogger.WithName("mutate")).
 			ToHandlerFunc("VERIFY"),
 	)
+	if auditDistributor != nil {
+		mux.HandlerFunc(
+			"POST",
+			config.AuditShardServicePath,
+			handlers.Forwarded(resourceLogger.WithName("audit"), auditDistributor, resourceHandlers.Audit).ToHandlerFunc("AUDIT"),
+		)
+	}
+	if evaluateAuthenticator != nil {
+		mux.HandlerFunc(
+			"POST",
+			config.EvaluateServicePath,
+			handlers.Evaluate(resourceLogger.WithName("evaluate"), evaluateAuthenticator, discovery, rbLister, crbLister, resourceHandlers.Evaluate).ToHandlerFunc("EVALUATE"),
+		)
+	}
+	if auditEvents != nil {
+		mux.HandlerFunc(
+			"POST",
+			config.AuditEventsServicePath,
+			handlers.AuditEvents(logger.WithName("auditevents"), auditEventsAuthenticator, auditEvents).ToHandlerFunc("AUDITEVENTS"),
+		)
+	}
 	mux.HandlerFunc("GET", config.LivenessServicePath, handlers.Probe(runtime.IsLive))
-	mux.HandlerFunc("GET", config.ReadinessServicePath, handlers.Probe(runtime.IsReady))
+	mux.HandlerFunc("GET", config.ReadinessServicePath, handlers.Probe(func(ctx context.Context) bool {
+		return !draining.Load() && runtime.IsReady(ctx)
+	}))
+	var probeServer *http.Server
+	if probeAddress != "" {
+		probeMux := httprouter.New()
+		probeMux.HandlerFunc("GET", config.LivenessServicePath, handlers.Probe(runtime.IsLive))
+		probeMux.HandlerFunc("GET", config.ReadinessServicePath, handlers.Probe(func(ctx context.Context) bool {
+			return !draining.Load() && runtime.IsReady(ctx)
+		}))
+		probeServer = &http.Server{
+			Addr:              probeAddress,
+			Handler:           probeMux,
+			ReadTimeout:       30 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			ReadHeaderTimeout: 30 * time.Second,
+			IdleTimeout:       5 * time.Minute,
+			ErrorLog:          logging.StdLogger(logger.WithName("probe-server"), ""),
+		}
+	}
+	var handler http.Handler = mux
+	if tlsOptions.ClientAuthProvider != nil {
+		// forwarded audit requests are authenticated by the distributor signature, evaluation requests and audit events by their bearer token
+		handler = requireClientCertificate(mux, config.LivenessServicePath, config.ReadinessServicePath, config.AuditShardServicePath, config.EvaluateServicePath, config.AuditEventsServicePath)
+	}
 	return &server{
 		server: &http.Server{
-			Addr: fmt.Sprintf(":%d", webhookServerPort),
-			TLSConfig: &tls.Config{
-				GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
-					certPem, keyPem, err := tlsProvider()
-					if err != nil {
-						return nil, err
-					}
-					pair, err := tls.X509KeyPair(certPem, keyPem)
-					if err != nil {
-						return nil, err
-					}
-					return &pair, nil
-				},
-				MinVersion: tls.VersionTLS12,
-				CipherSuites: []uint16{
-					// AEADs w/ ECDHE
-					tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
-					tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
-					tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
-					tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
-					tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
-					tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
-				},
-			},
-			Handler:           mux,
+			Addr:              net.JoinHostPort(webhookServerAddress, strconv.Itoa(int(webhookServerPort))),
+			TLSConfig:         tlsOptions.config(tlsProvider),
+			Handler:           handler,
 			ReadTimeout:       30 * time.Second,
 			WriteTimeout:      30 * time.Second,
 			ReadHeaderTimeout: 30 * time.Second,
 			IdleTimeout:       5 * time.Minute,
 			ErrorLog:          logging.StdLogger(logger.WithName("server"), ""),
 		},
-		mwcClient:   mwcClient,
-		vwcClient:   vwcClient,
-		leaseClient: leaseClient,
-		runtime:     runtime,
+		probeServer:         probeServer,
+		mwcClient:           mwcClient,
+		vwcClient:           vwcClient,
+		leaseClient:         leaseClient,
+		runtime:             runtime,
+		draining:            draining,
+		shutdownGracePeriod: shutdownGracePeriod,
 	}
 }
 
@@ -224,12 +379,22 @@ func (s *server) Run() {
 			logging.Error(err, "failed to start server")
 		}
 	}()
+	if s.probeServer != nil {
+		go func() {
+			if err := s.probeServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logging.Error(err, "failed to start probe server")
+			}
+		}()
+	}
 }
 
+// Stop drains the server before deregistering the webhooks: the readiness probe starts failing, new connections
+// are refused and in-flight admission reviews are served until they complete or the shutdown grace period expires
 func (s *server) Stop() {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	s.draining.Store(true)
+	logger.Info("draining in-flight requests", "gracePeriod", s.shutdownGracePeriod.String())
+	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownGracePeriod)
 	defer cancel()
-	s.cleanup(ctx)
 	err := s.server.Shutdown(ctx)
 	if err != nil {
 		logger.Error(err, "shutting down server")
@@ -238,6 +403,16 @@ func (s *server) Stop() {
 			logger.Error(err, "server shut down failed")
 		}
 	}
+	// use a fresh context, the grace period can be exhausted by the drain
+	cleanupCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	s.cleanup(cleanupCtx)
+	// probes are served until the end so that the pod is reported as not ready while draining
+	if s.probeServer != nil {
+		if err := s.probeServer.Shutdown(cleanupCtx); err != nil {
+			logger.Error(err, "shutting down probe server")
+		}
+	}
 }
 
 func (s *server) cleanup(ctx context.Context) {
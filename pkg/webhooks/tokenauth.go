@@ -0,0 +1,65 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	authenticationv1client "k8s.io/client-go/kubernetes/typed/authentication/v1"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+// NewTokenAuthenticator returns an authenticator validating bearer tokens with a TokenReview,
+// the caller must be allowed to POST the given non resource path by a SubjectAccessReview
+func NewTokenAuthenticator(
+	tokenReviews authenticationv1client.TokenReviewInterface,
+	accessReviews authorizationv1client.SubjectAccessReviewInterface,
+	path string,
+) handlers.Authenticator {
+	return func(ctx context.Context, request *http.Request) (authenticationv1.UserInfo, error) {
+		token, ok := strings.CutPrefix(request.Header.Get("Authorization"), "Bearer ")
+		if !ok || strings.TrimSpace(token) == "" {
+			return authenticationv1.UserInfo{}, fmt.Errorf("%w: missing bearer token", handlers.ErrUnauthenticated)
+		}
+		tokenReview, err := tokenReviews.Create(ctx, &authenticationv1.TokenReview{
+			Spec: authenticationv1.TokenReviewSpec{
+				Token: strings.TrimSpace(token),
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return authenticationv1.UserInfo{}, fmt.Errorf("failed to review token: %w", err)
+		}
+		if !tokenReview.Status.Authenticated {
+			return authenticationv1.UserInfo{}, fmt.Errorf("%w: %s", handlers.ErrUnauthenticated, tokenReview.Status.Error)
+		}
+		user := tokenReview.Status.User
+		extra := map[string]authorizationv1.ExtraValue{}
+		for key, value := range user.Extra {
+			extra[key] = authorizationv1.ExtraValue(value)
+		}
+		accessReview, err := accessReviews.Create(ctx, &authorizationv1.SubjectAccessReview{
+			Spec: authorizationv1.SubjectAccessReviewSpec{
+				NonResourceAttributes: &authorizationv1.NonResourceAttributes{
+					Path: path,
+					Verb: "post",
+				},
+				User:   user.Username,
+				UID:    user.UID,
+				Groups: user.Groups,
+				Extra:  extra,
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return authenticationv1.UserInfo{}, fmt.Errorf("failed to review access: %w", err)
+		}
+		if !accessReview.Status.Allowed {
+			return authenticationv1.UserInfo{}, fmt.Errorf("%w: %s is not allowed to post %s", handlers.ErrForbidden, user.Username, path)
+		}
+		return user, nil
+	}
+}
@@ -0,0 +1,84 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kyverno/kyverno/pkg/webhooks/handlers"
+	"github.com/stretchr/testify/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func newTokenAuthenticatorClient(authenticated, allowed bool) *fake.Clientset {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "tokenreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		review := action.(clienttesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if review.Spec.Token == "valid" && authenticated {
+			review.Status.Authenticated = true
+			review.Status.User = authenticationv1.UserInfo{Username: "alice", Groups: []string{"tooling"}}
+		}
+		return true, review, nil
+	})
+	client.PrependReactor("create", "subjectaccessreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		review := action.(clienttesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		attributes := review.Spec.NonResourceAttributes
+		review.Status.Allowed = allowed && review.Spec.User == "alice" && attributes != nil && attributes.Path == "/evaluate" && attributes.Verb == "post"
+		return true, review, nil
+	})
+	return client
+}
+
+func TestTokenAuthenticator(t *testing.T) {
+	tests := []struct {
+		name          string
+		header        string
+		authenticated bool
+		allowed       bool
+		wantErr       error
+	}{{
+		name:          "allowed",
+		header:        "Bearer valid",
+		authenticated: true,
+		allowed:       true,
+	}, {
+		name:          "missing token",
+		authenticated: true,
+		allowed:       true,
+		wantErr:       handlers.ErrUnauthenticated,
+	}, {
+		name:          "invalid token",
+		header:        "Bearer invalid",
+		authenticated: true,
+		allowed:       true,
+		wantErr:       handlers.ErrUnauthenticated,
+	}, {
+		name:          "not allowed",
+		header:        "Bearer valid",
+		authenticated: true,
+		wantErr:       handlers.ErrForbidden,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTokenAuthenticatorClient(tt.authenticated, tt.allowed)
+			authenticate := NewTokenAuthenticator(client.AuthenticationV1().TokenReviews(), client.AuthorizationV1().SubjectAccessReviews(), "/evaluate")
+			request := httptest.NewRequest(http.MethodPost, "/evaluate", nil)
+			if tt.header != "" {
+				request.Header.Set("Authorization", tt.header)
+			}
+			user, err := authenticate(context.TODO(), request)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "alice", user.Username)
+		})
+	}
+}
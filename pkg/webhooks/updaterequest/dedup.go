@@ -0,0 +1,71 @@
+package updaterequest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+)
+
+// dedupWindow is the time the keys of a created update request are remembered, it covers the update
+// requests created in bursts before the informer cache knows about the first one
+const dedupWindow = 5 * time.Second
+
+// specHash returns the hash of the parts of a spec shared by all its rule contexts, the admission
+// request uid is ignored so that the update requests of equivalent admission requests hash the same
+func specHash(spec kyvernov2.UpdateRequestSpec) (string, error) {
+	spec = *spec.DeepCopy()
+	spec.Rule = ""
+	spec.Resource = kyvernov1.ResourceSpec{}
+	spec.RuleContext = nil
+	if request := spec.Context.AdmissionRequestInfo.AdmissionRequest; request != nil {
+		request.UID = ""
+	}
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func triggerKey(trigger kyvernov1.ResourceSpec) string {
+	if trigger.UID != "" {
+		return string(trigger.UID)
+	}
+	return trigger.String()
+}
+
+func dedupKey(policy, rule string, trigger kyvernov1.ResourceSpec, deleteDownstream bool, hash string) string {
+	return fmt.Sprintf("%s/%s/%s/%t/%s", policy, rule, triggerKey(trigger), deleteDownstream, hash)
+}
+
+// ruleContextKey returns the key identifying the equivalent requests of a generate rule context
+func ruleContextKey(spec kyvernov2.UpdateRequestSpec, ruleContext kyvernov2.RuleContext, hash string) string {
+	return dedupKey(spec.Policy, ruleContext.Rule, ruleContext.Trigger, ruleContext.DeleteDownstream, hash)
+}
+
+// dedupKeys returns the keys identifying the equivalent update requests, by policy, rule, trigger uid and
+// spec hash, generate requests have one key per rule context
+func dedupKeys(spec kyvernov2.UpdateRequestSpec) ([]string, error) {
+	hash, err := specHash(spec)
+	if err != nil {
+		return nil, err
+	}
+	if spec.GetRequestType() != kyvernov2.Generate {
+		return []string{dedupKey(spec.Policy, spec.Rule, spec.Resource, spec.DeleteDownstream, hash)}, nil
+	}
+	keys := make([]string, 0, len(spec.RuleContext))
+	for _, ruleContext := range spec.RuleContext {
+		keys = append(keys, ruleContextKey(spec, ruleContext, hash))
+	}
+	return keys, nil
+}
+
+func isPending(ur *kyvernov2.UpdateRequest) bool {
+	return ur.Status.State == "" || ur.Status.State == kyvernov2.Pending
+}
@@ -0,0 +1,94 @@
+package updaterequest
+
+import (
+	"context"
+	"testing"
+
+	kyvernov1 "github.com/kyverno/kyverno/api/kyverno/v1"
+	kyvernov2 "github.com/kyverno/kyverno/api/kyverno/v2"
+	"github.com/kyverno/kyverno/pkg/background/common"
+	versionedfake "github.com/kyverno/kyverno/pkg/client/clientset/versioned/fake"
+	kyvernoinformers "github.com/kyverno/kyverno/pkg/client/informers/externalversions"
+	"github.com/kyverno/kyverno/pkg/config"
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func testGenerateSpec(uid string, triggers ...string) kyvernov2.UpdateRequestSpec {
+	spec := kyvernov2.UpdateRequestSpec{
+		Type:   kyvernov2.Generate,
+		Policy: "generate-netpol",
+		Context: kyvernov2.UpdateRequestSpecContext{
+			AdmissionRequestInfo: kyvernov2.AdmissionRequestInfoObject{
+				AdmissionRequest: &admissionv1.AdmissionRequest{UID: "uid-" + types.UID(uid)},
+				Operation:        admissionv1.Create,
+			},
+		},
+	}
+	for _, trigger := range triggers {
+		spec.RuleContext = append(spec.RuleContext, kyvernov2.RuleContext{
+			Rule:    "default-deny",
+			Trigger: kyvernov1.ResourceSpec{Kind: "Namespace", Name: trigger, UID: types.UID(trigger)},
+		})
+	}
+	return spec
+}
+
+func Test_dedupKeys(t *testing.T) {
+	first, err := dedupKeys(testGenerateSpec("1", "ns-a", "ns-b"))
+	assert.NoError(t, err)
+	assert.Len(t, first, 2)
+	// the admission request uid is ignored
+	second, err := dedupKeys(testGenerateSpec("2", "ns-a"))
+	assert.NoError(t, err)
+	assert.Equal(t, first[0], second[0])
+	deletion := testGenerateSpec("1", "ns-a")
+	deletion.RuleContext[0].DeleteDownstream = true
+	third, err := dedupKeys(deletion)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first[0], third[0])
+}
+
+func Test_dedupe(t *testing.T) {
+	pending := &kyvernov2.UpdateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ur-pending",
+			Namespace: config.KyvernoNamespace(),
+			Labels:    common.GenerateLabelsSet("generate-netpol"),
+		},
+		Spec: testGenerateSpec("1", "ns-a"),
+	}
+	client := versionedfake.NewSimpleClientset(pending)
+	factory := kyvernoinformers.NewSharedInformerFactory(client, 0)
+	urInformer := factory.Kyverno().V2().UpdateRequests()
+	assert.NoError(t, urInformer.Informer().GetIndexer().Add(pending))
+	g := NewGenerator(client, urInformer, nil).(*generator)
+	// already pending
+	spec := testGenerateSpec("2", "ns-a")
+	create, _, err := g.dedupe(context.TODO(), &spec, common.GenerateLabelsSet(spec.Policy))
+	assert.NoError(t, err)
+	assert.False(t, create)
+	// merged into the pending request
+	spec = testGenerateSpec("3", "ns-a", "ns-b")
+	create, keys, err := g.dedupe(context.TODO(), &spec, common.GenerateLabelsSet(spec.Policy))
+	assert.NoError(t, err)
+	assert.False(t, create)
+	assert.Len(t, keys, 1)
+	merged, err := client.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).Get(context.TODO(), "ur-pending", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, merged.Spec.RuleContext, 2)
+	// recently merged
+	spec = testGenerateSpec("4", "ns-b")
+	create, _, err = g.dedupe(context.TODO(), &spec, common.GenerateLabelsSet(spec.Policy))
+	assert.NoError(t, err)
+	assert.False(t, create)
+	// a different trigger context needs a new request
+	spec = testGenerateSpec("5", "ns-c")
+	spec.Context.AdmissionRequestInfo.Operation = admissionv1.Update
+	create, keys, err = g.dedupe(context.TODO(), &spec, common.GenerateLabelsSet(spec.Policy))
+	assert.NoError(t, err)
+	assert.True(t, create)
+	assert.Len(t, keys, 1)
+}
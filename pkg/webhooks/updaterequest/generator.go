@@ -2,6 +2,7 @@ package updaterequest
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	backoff "github.com/cenkalti/backoff"
@@ -14,6 +15,7 @@ import (
 	generatorutils "github.com/kyverno/kyverno/pkg/utils/generator"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 // Generator provides interface to manage update requests
@@ -30,6 +32,10 @@ type generator struct {
 	urLister kyvernov2listers.UpdateRequestNamespaceLister
 
 	urGenerator generatorutils.UpdateRequestGenerator
+
+	// recent holds the keys of the update requests recently created or merged, with their expiration
+	lock   sync.Mutex
+	recent map[string]time.Time
 }
 
 // NewGenerator returns a new instance of UpdateRequest resource generator
@@ -38,6 +44,7 @@ func NewGenerator(client versioned.Interface, urInformer kyvernov2informers.Upda
 		client:      client,
 		urLister:    urInformer.Lister().UpdateRequests(config.KyvernoNamespace()),
 		urGenerator: urGenerator,
+		recent:      map[string]time.Time{},
 	}
 }
 
@@ -76,6 +83,15 @@ func (g *generator) tryApplyResource(ctx context.Context, urSpec kyvernov2.Updat
 		queryLabels = common.GenerateLabelsSet(urSpec.Policy)
 	}
 
+	create, keys, err := g.dedupe(ctx, &urSpec, queryLabels)
+	if err != nil {
+		return err
+	}
+	if !create {
+		l.V(4).Info("skipping UpdateRequest creation, equivalent requests are already pending")
+		return nil
+	}
+
 	l.V(4).Info("creating new UpdateRequest")
 	ur := kyvernov2.UpdateRequest{
 		ObjectMeta: metav1.ObjectMeta{
@@ -87,9 +103,11 @@ func (g *generator) tryApplyResource(ctx context.Context, urSpec kyvernov2.Updat
 	}
 	created, err := g.urGenerator.Generate(ctx, g.client, &ur, l)
 	if err != nil {
+		g.forget(keys)
 		l.V(4).Error(err, "failed to create UpdateRequest, retrying", "name", ur.GetGenerateName(), "namespace", ur.GetNamespace())
 		return err
 	} else if created == nil {
+		g.forget(keys)
 		return nil
 	}
 	updated := created.DeepCopy()
@@ -101,3 +119,86 @@ func (g *generator) tryApplyResource(ctx context.Context, urSpec kyvernov2.Updat
 	l.V(4).Info("successfully created UpdateRequest", "name", updated.GetName(), "namespace", ur.GetNamespace())
 	return nil
 }
+
+// dedupe drops the rule contexts already requested by a pending or recently created update request and
+// merges the remaining ones into a pending generate request sharing the same spec hash. It returns whether
+// an update request still needs to be created, and the keys reserved for it.
+func (g *generator) dedupe(ctx context.Context, urSpec *kyvernov2.UpdateRequestSpec, queryLabels labels.Set) (bool, []string, error) {
+	hash, err := specHash(*urSpec)
+	if err != nil {
+		return false, nil, err
+	}
+	pending, err := g.urLister.List(labels.SelectorFromSet(queryLabels))
+	if err != nil {
+		return false, nil, err
+	}
+	known := sets.New[string]()
+	for _, ur := range pending {
+		if !isPending(ur) {
+			continue
+		}
+		keys, err := dedupKeys(ur.Spec)
+		if err != nil {
+			return false, nil, err
+		}
+		known.Insert(keys...)
+	}
+	g.lock.Lock()
+	now := time.Now()
+	for key, expiration := range g.recent {
+		if now.After(expiration) {
+			delete(g.recent, key)
+		} else {
+			known.Insert(key)
+		}
+	}
+	var keys []string
+	if urSpec.GetRequestType() == kyvernov2.Generate {
+		var ruleContexts []kyvernov2.RuleContext
+		for _, ruleContext := range urSpec.RuleContext {
+			if key := ruleContextKey(*urSpec, ruleContext, hash); !known.Has(key) {
+				known.Insert(key)
+				keys = append(keys, key)
+				ruleContexts = append(ruleContexts, ruleContext)
+			}
+		}
+		urSpec.RuleContext = ruleContexts
+	} else if key := dedupKey(urSpec.Policy, urSpec.Rule, urSpec.Resource, urSpec.DeleteDownstream, hash); !known.Has(key) {
+		keys = append(keys, key)
+	}
+	// keys are reserved right away, concurrent equivalent requests are dropped
+	for _, key := range keys {
+		g.recent[key] = now.Add(dedupWindow)
+	}
+	g.lock.Unlock()
+	if len(keys) == 0 {
+		return false, nil, nil
+	}
+	if urSpec.GetRequestType() == kyvernov2.Generate {
+		for _, ur := range pending {
+			if !isPending(ur) || ur.Spec.Policy != urSpec.Policy {
+				continue
+			}
+			if urHash, err := specHash(ur.Spec); err != nil || urHash != hash {
+				continue
+			}
+			merged := ur.DeepCopy()
+			merged.Spec.RuleContext = append(merged.Spec.RuleContext, urSpec.RuleContext...)
+			// a conflict means the request is being processed, a new one is created instead
+			if _, err := g.client.KyvernoV2().UpdateRequests(config.KyvernoNamespace()).Update(ctx, merged, metav1.UpdateOptions{}); err == nil {
+				logger.V(4).Info("merged rule contexts into pending UpdateRequest", "name", merged.GetName(), "ruleContexts", len(urSpec.RuleContext))
+				return false, keys, nil
+			}
+		}
+	}
+	return true, keys, nil
+}
+
+// forget releases the keys reserved for an update request that wasn't created
+func (g *generator) forget(keys []string) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	for _, key := range keys {
+		delete(g.recent, key)
+	}
+}
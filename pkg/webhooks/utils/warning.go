@@ -2,19 +2,84 @@ package utils
 
 import (
 	"fmt"
+	"strings"
 
 	engineapi "github.com/kyverno/kyverno/pkg/engine/api"
 )
 
+// GetWarningMessages returns one warning per policy, identified by namespace/name for namespaced policies,
+// rules of the same policy reporting the same message are grouped together
 func GetWarningMessages(engineResponses []engineapi.EngineResponse) []string {
-	var warnings []string
+	var policies []string
+	rulesByPolicy := map[string][]engineapi.RuleResponse{}
 	for _, er := range engineResponses {
 		for _, rule := range er.PolicyResponse.Rules {
 			if rule.EmitWarning() || (er.EmitsWarning() && rule.Status() != engineapi.RuleStatusSkip) {
-				msg := fmt.Sprintf("policy %s.%s: %s", er.Policy().GetName(), rule.Name(), rule.Message())
-				warnings = append(warnings, msg)
+				policy := er.Policy().GetName()
+				if namespace := er.Policy().GetNamespace(); namespace != "" {
+					policy = namespace + "/" + policy
+				}
+				if _, ok := rulesByPolicy[policy]; !ok {
+					policies = append(policies, policy)
+				}
+				rulesByPolicy[policy] = append(rulesByPolicy[policy], rule)
 			}
 		}
 	}
+	var warnings []string
+	for _, policy := range policies {
+		var messages []string
+		namesByMessage := map[string][]string{}
+		for _, rule := range rulesByPolicy[policy] {
+			if _, ok := namesByMessage[rule.Message()]; !ok {
+				messages = append(messages, rule.Message())
+			}
+			namesByMessage[rule.Message()] = append(namesByMessage[rule.Message()], rule.Name())
+		}
+		parts := make([]string, 0, len(messages))
+		for _, message := range messages {
+			parts = append(parts, fmt.Sprintf("%s: %s", strings.Join(namesByMessage[message], ", "), message))
+		}
+		warnings = append(warnings, fmt.Sprintf("policy %s: %s", policy, strings.Join(parts, "; ")))
+	}
 	return warnings
 }
+
+// BudgetWarnings removes duplicated warnings and keeps the first ones fitting in maxBytes, a summary
+// warning is added when warnings are omitted, the budget is not enforced when maxBytes is not positive
+func BudgetWarnings(warnings []string, maxBytes int) []string {
+	var unique []string
+	seen := map[string]struct{}{}
+	for _, warning := range warnings {
+		if _, ok := seen[warning]; !ok {
+			seen[warning] = struct{}{}
+			unique = append(unique, warning)
+		}
+	}
+	if maxBytes <= 0 {
+		return unique
+	}
+	total := 0
+	for _, warning := range unique {
+		total += len(warning)
+	}
+	if total <= maxBytes {
+		return unique
+	}
+	// room is kept for the summary, its size is bounded by the one omitting every warning
+	budget := maxBytes - len(truncationWarning(len(unique), maxBytes))
+	var result []string
+	size := 0
+	for _, warning := range unique {
+		if size+len(warning) > budget {
+			break
+		}
+		size += len(warning)
+		result = append(result, warning)
+	}
+	return append(result, truncationWarning(len(unique)-len(result), maxBytes))
+}
+
+func truncationWarning(omitted, maxBytes int) string {
+	return fmt.Sprintf("%d more warnings omitted, the warning budget of %d bytes was exceeded", omitted, maxBytes)
+}
@@ -41,7 +41,7 @@ func TestGetWarningMessages(t *testing.T) {
 			})),
 		}},
 		want: []string{
-			"policy test.rule: message warn",
+			"policy test: rule: message warn",
 		},
 	}, {
 		name: "multiple rules",
@@ -63,9 +63,83 @@ func TestGetWarningMessages(t *testing.T) {
 			})),
 		}},
 		want: []string{
-			"policy test.rule-warn: message warn",
-			"policy test.rule-fail: message fail",
-			"policy test.rule-error: message error",
+			"policy test: rule-warn: message warn; rule-fail: message fail; rule-error: message error",
+		},
+	}, {
+		name: "same message in multiple rules and policies",
+		args: args{[]engineapi.EngineResponse{
+			engineapi.EngineResponse{
+				PolicyResponse: engineapi.PolicyResponse{
+					Rules: []engineapi.RuleResponse{
+						*engineapi.RuleFail("rule-a", engineapi.Validation, "label is missing", nil),
+						*engineapi.RuleFail("rule-b", engineapi.Validation, "label is missing", nil),
+						*engineapi.RuleFail("rule-c", engineapi.Validation, "image is not pinned", nil),
+					},
+				},
+			}.WithPolicy(engineapi.NewKyvernoPolicy(&v1.ClusterPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+			})),
+			engineapi.EngineResponse{
+				PolicyResponse: engineapi.PolicyResponse{
+					Rules: []engineapi.RuleResponse{
+						*engineapi.RuleFail("rule", engineapi.Validation, "label is missing", nil),
+					},
+				},
+			}.WithPolicy(engineapi.NewKyvernoPolicy(&v1.ClusterPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "other",
+				},
+			})),
+		}},
+		want: []string{
+			"policy test: rule-a, rule-b: label is missing; rule-c: image is not pinned",
+			"policy other: rule: label is missing",
+		},
+	}, {
+		name: "policies with the same name in different namespaces",
+		args: args{[]engineapi.EngineResponse{
+			engineapi.EngineResponse{
+				PolicyResponse: engineapi.PolicyResponse{
+					Rules: []engineapi.RuleResponse{
+						*engineapi.RuleFail("rule", engineapi.Validation, "label is missing", nil),
+					},
+				},
+			}.WithPolicy(engineapi.NewKyvernoPolicy(&v1.Policy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "team-a",
+				},
+			})),
+			engineapi.EngineResponse{
+				PolicyResponse: engineapi.PolicyResponse{
+					Rules: []engineapi.RuleResponse{
+						*engineapi.RuleFail("rule", engineapi.Validation, "image is not pinned", nil),
+					},
+				},
+			}.WithPolicy(engineapi.NewKyvernoPolicy(&v1.Policy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "team-b",
+				},
+			})),
+			engineapi.EngineResponse{
+				PolicyResponse: engineapi.PolicyResponse{
+					Rules: []engineapi.RuleResponse{
+						*engineapi.RuleFail("rule", engineapi.Validation, "label is missing", nil),
+					},
+				},
+			}.WithPolicy(engineapi.NewKyvernoPolicy(&v1.ClusterPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+			})),
+		}},
+		want: []string{
+			"policy team-a/test: rule: label is missing",
+			"policy team-b/test: rule: image is not pinned",
+			"policy test: rule: label is missing",
 		},
 	}}
 	for _, tt := range tests {
@@ -75,3 +149,52 @@ func TestGetWarningMessages(t *testing.T) {
 		})
 	}
 }
+
+func TestBudgetWarnings(t *testing.T) {
+	tests := []struct {
+		name     string
+		warnings []string
+		maxBytes int
+		want     []string
+	}{{
+		name:     "nil",
+		maxBytes: 100,
+		want:     nil,
+	}, {
+		name:     "duplicates",
+		warnings: []string{"a", "b", "a"},
+		maxBytes: 100,
+		want:     []string{"a", "b"},
+	}, {
+		name:     "no budget",
+		warnings: []string{"aaaa", "bbbb", "aaaa"},
+		maxBytes: 0,
+		want:     []string{"aaaa", "bbbb"},
+	}, {
+		name:     "within budget",
+		warnings: []string{"aaaa", "bbbb"},
+		maxBytes: 8,
+		want:     []string{"aaaa", "bbbb"},
+	}, {
+		name:     "exceeded",
+		warnings: []string{"aaaaaaaaaa", "bbbbbbbbbb", "cccccccccc", "dddddddddd"},
+		maxBytes: 90,
+		want: []string{
+			"aaaaaaaaaa",
+			"bbbbbbbbbb",
+			"2 more warnings omitted, the warning budget of 90 bytes was exceeded",
+		},
+	}, {
+		name:     "summary only",
+		warnings: []string{"aaaaaaaaaa", "bbbbbbbbbb"},
+		maxBytes: 15,
+		want: []string{
+			"2 more warnings omitted, the warning budget of 15 bytes was exceeded",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, BudgetWarnings(tt.warnings, tt.maxBytes))
+		})
+	}
+}